@@ -25,27 +25,39 @@ package admin
 import (
 	"net"
 	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/proxy"
 	"github.com/berkaroad/saashard/utils/simplelog"
 )
 
+var (
+	baseConnID uint32
+)
+
 // Server Admin
 type Server struct {
+	sync.Mutex
 	cfg    *config.Config
 	bindIP net.IP
 	port   int
+	proxy  *proxy.Server
 
 	listener net.Listener
 	running  bool
+	conns    map[uint32]*ClientConn
 }
 
 // NewServer create admin.
-func NewServer(cfg *config.Config) (*Server, error) {
+func NewServer(cfg *config.Config, proxy *proxy.Server) (*Server, error) {
 	admin := new(Server)
 	admin.cfg = cfg
 	admin.bindIP = net.ParseIP(cfg.BindIP)
 	admin.port = cfg.AdminPort
+	admin.proxy = proxy
+	admin.conns = make(map[uint32]*ClientConn)
 
 	var err error
 	netProto := "tcp"
@@ -87,4 +99,22 @@ func (admin *Server) Close() {
 
 func (admin *Server) onConn(c net.Conn) {
 	simplelog.Info("%s %s %s", "server/admin", "onConn", c.RemoteAddr().String())
+	conn := newClientConn(admin, c, atomic.AddUint32(&baseConnID, 1))
+
+	defer func() {
+		conn.Close()
+		admin.Lock()
+		delete(admin.conns, conn.connectionID)
+		admin.Unlock()
+	}()
+
+	if err := conn.Handshake(); err != nil {
+		simplelog.Error("%s %s %s", "server/admin", "onConn", err.Error())
+		return
+	}
+
+	admin.Lock()
+	admin.conns[conn.connectionID] = conn
+	admin.Unlock()
+	conn.Run()
 }
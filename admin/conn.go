@@ -0,0 +1,148 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package admin
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// adminDB is the db name of admin connection.
+const adminDB = "saashard"
+
+// ClientConn admin client <-> proxy
+type ClientConn struct {
+	pkg          *mysql.PacketIO
+	c            net.Conn
+	admin        *Server
+	capability   uint32
+	connectionID uint32
+	status       uint16
+	user         string
+	salt         []byte
+	closed       bool
+}
+
+func newClientConn(admin *Server, c net.Conn, connectionID uint32) *ClientConn {
+	conn := new(ClientConn)
+	conn.c = c
+	conn.admin = admin
+	conn.pkg = mysql.NewPacketIO(c)
+	conn.pkg.Sequence = 0
+	conn.connectionID = connectionID
+	conn.status = mysql.SERVER_STATUS_AUTOCOMMIT
+	conn.salt, _ = mysql.RandomBuf(20)
+	return conn
+}
+
+// Handshake between admin client and proxy.
+func (c *ClientConn) Handshake() error {
+	var err error
+	if err = c.pkg.WriteInitialHandshake(c.connectionID, c.salt, mysql.DEFAULT_COLLATION_ID, mysql.DEFAULT_CAPABILITY, c.status); err != nil {
+		return err
+	}
+
+	getDefaultSchemaByUser := func(user string) (string, error) {
+		return adminDB, nil
+	}
+	getCredentialsConfigBySchema := func(user, schema string) (string, string, error) {
+		if len(c.admin.cfg.AdminUser) == 0 {
+			return "", "", mysql.NewDefaultError(mysql.ER_ACCESS_DENIED_ERROR, "", c.c.RemoteAddr().String(), "No")
+		}
+		return c.admin.cfg.AdminUser, c.admin.cfg.AdminPassword, nil
+	}
+	c.capability, _, c.user, _, _, err = c.pkg.ReadHandshakeResponse(getDefaultSchemaByUser, c.c.RemoteAddr().String(), c.salt, getCredentialsConfigBySchema)
+	if err != nil {
+		c.pkg.WriteError(c.capability, err)
+		return err
+	}
+
+	if err = c.pkg.WriteOK(c.capability, c.status, nil); err != nil {
+		return err
+	}
+	c.pkg.Sequence = 0
+	return nil
+}
+
+// Run after handshake.
+func (c *ClientConn) Run() {
+	defer func() {
+		r := recover()
+		if err, ok := r.(error); ok {
+			const size = 4096
+			buf := make([]byte, size)
+			buf = buf[:runtime.Stack(buf, false)]
+
+			simplelog.Error("%s %s %s stack=%s",
+				"admin.ClientConn", "Run", err.Error(),
+				string(buf))
+		}
+	}()
+
+	for {
+		data, err := c.pkg.ReadPacket()
+		if err != nil {
+			return
+		}
+		if err := c.dispatch(data); err != nil {
+			simplelog.Error("%s %s %s connection id=%d", "server/admin", "Run", err.Error(), c.connectionID)
+			c.pkg.WriteError(c.capability, err)
+		}
+		if c.closed {
+			return
+		}
+		c.pkg.Sequence = 0
+	}
+}
+
+// Close admin client.
+func (c *ClientConn) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.c.Close()
+	c.closed = true
+	return nil
+}
+
+func (c *ClientConn) dispatch(data []byte) error {
+	cmd := data[0]
+	data = data[1:]
+
+	switch cmd {
+	case mysql.COM_QUIT:
+		c.Close()
+		return nil
+	case mysql.COM_QUERY:
+		return c.handleQuery(string(data))
+	case mysql.COM_PING, mysql.COM_INIT_DB:
+		return c.pkg.WriteOK(c.capability, c.status, nil)
+	default:
+		msg := fmt.Sprintf("command %d not supported now", cmd)
+		return mysql.NewError(mysql.ER_UNKNOWN_ERROR, msg)
+	}
+}
@@ -0,0 +1,590 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/proxy"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+var variableNameField = &mysql.Field{Name: []byte("Variable_name"),
+	Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+	ColumnLength: 192,
+	ColumnType:   mysql.MYSQL_TYPE_VAR_STRING,
+	Flags:        mysql.NOT_NULL_FLAG}
+
+var variableValueField = &mysql.Field{Name: []byte("Value"),
+	Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+	ColumnLength: 3072,
+	ColumnType:   mysql.MYSQL_TYPE_VAR_STRING}
+
+func (c *ClientConn) handleQuery(sql string) error {
+	statement, err := sqlparser.Parse(strings.TrimRight(strings.TrimSpace(sql), ";"))
+	if err != nil {
+		return mysql.NewError(mysql.ER_SYNTAX_ERROR, fmt.Sprintf("Syntax error or not supported for '%s': '%s'", sql, err.Error()))
+	}
+
+	switch v := statement.(type) {
+	case *sqlparser.SetVariable:
+		return c.handleSetVariable(v)
+	case *sqlparser.ShowVariables:
+		return c.handleShowVariables(v)
+	case *sqlparser.ShowStatus:
+		return c.handleShowStatus(v)
+	case *sqlparser.ShowProcessList, *sqlparser.ShowFullProcessList:
+		return c.handleShowProcessList()
+	case *sqlparser.SimpleSelect:
+		return c.handleSimpleSelect(v)
+	case *sqlparser.Reload:
+		return c.handleReload(v)
+	case *sqlparser.CloneTenant:
+		return c.handleCloneTenant(v)
+	case *sqlparser.ShowPreflight:
+		return c.handleShowPreflight()
+	case *sqlparser.ShowAudit:
+		return c.handleShowAudit()
+	case *sqlparser.ShowMigration:
+		return c.handleShowMigration()
+	case *sqlparser.FailoverDrill:
+		return c.handleFailoverDrill(v)
+	case *sqlparser.ShowFailoverDrill:
+		return c.handleShowFailoverDrill()
+	case *sqlparser.ExportRouting:
+		return c.handleExportRouting(v)
+	case sqlparser.KillStatement:
+		return c.handleKill(v)
+	case *sqlparser.CreateProxyUser:
+		return c.handleCreateProxyUser(v)
+	case *sqlparser.AlterProxyUser:
+		return c.handleAlterProxyUser(v)
+	case *sqlparser.DropProxyUser:
+		return c.handleDropProxyUser(v)
+	case *sqlparser.ShowProxyUsers:
+		return c.handleShowProxyUsers()
+	case *sqlparser.UseDB:
+		return c.pkg.WriteOK(c.capability, c.status, nil)
+	default:
+		return errors.ErrCmdUnsupport
+	}
+}
+
+// handleSetVariable 'SET GLOBAL saashard_xxx = value'
+func (c *ClientConn) handleSetVariable(statement *sqlparser.SetVariable) error {
+	for _, expr := range statement.Exprs {
+		name := expr.VarName()
+		if !expr.IsGlobal(statement.Scope) {
+			return mysql.NewDefaultError(mysql.ER_GLOBAL_VARIABLE, name)
+		}
+		var value string
+		switch v := expr.Expr.(type) {
+		case sqlparser.StrVal:
+			value = string(v)
+		default:
+			value = sqlparser.String(v)
+		}
+		oldValue, _ := c.admin.proxy.GetVariable(name)
+		if err := c.admin.proxy.SetVariable(name, value); err != nil {
+			if err == errors.ErrNoVariable {
+				return mysql.NewDefaultError(mysql.ER_UNKNOWN_SYSTEM_VARIABLE, name)
+			}
+			return mysql.NewDefaultError(mysql.ER_WRONG_VALUE_FOR_VAR, name, value)
+		}
+		newValue, _ := c.admin.proxy.GetVariable(name)
+		c.admin.proxy.Audit(c.user, c.c.RemoteAddr().String(), proxy.AuditActionSet, name, oldValue, newValue)
+	}
+	return c.pkg.WriteOK(c.capability, c.status, nil)
+}
+
+// handleReload 'RELOAD TLS'
+func (c *ClientConn) handleReload(statement *sqlparser.Reload) error {
+	switch strings.ToLower(string(statement.Name)) {
+	case "tls":
+		if err := c.admin.proxy.ReloadTLS(); err != nil {
+			return mysql.NewError(mysql.ER_UNKNOWN_ERROR, err.Error())
+		}
+	default:
+		return errors.ErrCmdUnsupport
+	}
+	c.admin.proxy.Audit(c.user, c.c.RemoteAddr().String(), proxy.AuditActionReload, strings.ToLower(string(statement.Name)), "", "")
+	return c.pkg.WriteOK(c.capability, c.status, nil)
+}
+
+// handleCloneTenant 'CLONE TENANT 1 FROM prod TO staging', progress is shown by 'SHOW STATUS'.
+func (c *ClientConn) handleCloneTenant(statement *sqlparser.CloneTenant) error {
+	tenant := sqlparser.String(statement.Tenant)
+	if err := c.admin.proxy.CloneTenant(tenant, string(statement.From), string(statement.To)); err != nil {
+		return mysql.NewError(mysql.ER_UNKNOWN_ERROR, err.Error())
+	}
+	c.admin.proxy.Audit(c.user, c.c.RemoteAddr().String(), proxy.AuditActionClone, "tenant "+tenant,
+		string(statement.From), string(statement.To))
+	return c.pkg.WriteOK(c.capability, c.status, nil)
+}
+
+// handleShowVariables 'SHOW VARIABLES LIKE "saashard%"'
+func (c *ClientConn) handleShowVariables(statement *sqlparser.ShowVariables) error {
+	names := c.admin.proxy.VariableNames()
+	values := make([]string, len(names))
+	for i, name := range names {
+		values[i], _ = c.admin.proxy.GetVariable(name)
+	}
+	return c.writeNameValues(names, values, statement.LikeOrWhere)
+}
+
+// handleShowStatus 'SHOW STATUS', metrics of proxy.
+func (c *ClientConn) handleShowStatus(statement *sqlparser.ShowStatus) error {
+	counter := c.admin.proxy.GetCounter()
+	names := []string{
+		"saashard_client_conns",
+		"saashard_client_qps",
+		"saashard_err_log_total",
+		"saashard_slow_log_total",
+		"saashard_memory_used",
+		"saashard_diff_total",
+		"saashard_diff_mismatch_total",
+		"saashard_shadow_total",
+		"saashard_shadow_diverge_total",
+		"saashard_long_trans_total",
+		"saashard_big_trans_total",
+	}
+	values := []string{
+		strconv.FormatInt(atomic.LoadInt64(&counter.ClientConns), 10),
+		strconv.FormatInt(atomic.LoadInt64(&counter.OldClientQPS), 10),
+		strconv.FormatInt(atomic.LoadInt64(&counter.ErrLogTotal), 10),
+		strconv.FormatInt(atomic.LoadInt64(&counter.SlowLogTotal), 10),
+		strconv.FormatInt(atomic.LoadInt64(&counter.MemoryUsed), 10),
+		strconv.FormatInt(atomic.LoadInt64(&counter.DiffTotal), 10),
+		strconv.FormatInt(atomic.LoadInt64(&counter.DiffMismatchTotal), 10),
+		strconv.FormatInt(atomic.LoadInt64(&counter.ShadowTotal), 10),
+		strconv.FormatInt(atomic.LoadInt64(&counter.ShadowDivergeTotal), 10),
+		strconv.FormatInt(atomic.LoadInt64(&counter.LongTransTotal), 10),
+		strconv.FormatInt(atomic.LoadInt64(&counter.BigTransTotal), 10),
+	}
+	statsNames, statsValues := c.admin.proxy.GetCardinalityNames()
+	for i, name := range statsNames {
+		names = append(names, "saashard_cardinality."+name)
+		values = append(values, strconv.FormatInt(statsValues[i], 10))
+	}
+	strategyNames, strategies, histories := c.admin.proxy.GetStrategyNames()
+	for i, name := range strategyNames {
+		names = append(names, "saashard_strategy."+name, "saashard_cardinality_history."+name)
+		values = append(values, strategies[i], histories[i])
+	}
+	cloneNames, cloneValues := c.admin.proxy.GetCloneStatus()
+	for i, name := range cloneNames {
+		names = append(names, "saashard_clone."+name)
+		values = append(values, cloneValues[i])
+	}
+	ruleNames, ruleValues := c.admin.proxy.GetRuleVersions()
+	for i, name := range ruleNames {
+		names = append(names, "saashard_rule_version."+name)
+		values = append(values, ruleValues[i])
+	}
+	return c.writeNameValues(names, values, statement.LikeOrWhere)
+}
+
+// handleShowProcessList 'SHOW PROCESSLIST', client sessions of proxy.
+func (c *ClientConn) handleShowProcessList() error {
+	result := new(mysql.Result)
+	result.Status = c.status
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{
+		newStringField("Id"),
+		newStringField("User"),
+		newStringField("Host"),
+		newStringField("db"),
+		newStringField("Mode"),
+		newStringField("Memory_used"),
+	}
+	result.Rows = make([]*mysql.Row, 0)
+	for _, session := range c.admin.proxy.GetSessions() {
+		row := mysql.NewTextRow(result.Resultset.Fields)
+		row.AppendUIntValue(uint64(session.ID))
+		row.AppendStringValue(session.User)
+		row.AppendStringValue(session.Host)
+		row.AppendStringValue(session.DB)
+		if session.ReadOnly {
+			row.AppendStringValue("ro")
+		} else {
+			row.AppendStringValue("rw")
+		}
+		row.AppendIntValue(session.MemoryUsed)
+		result.Rows = append(result.Rows, row)
+	}
+	return c.pkg.WriteResultSet(c.capability, c.status, result)
+}
+
+// handleShowPreflight 'SHOW PREFLIGHT', checks against backends, such as reachable, version, writable master and tables.
+func (c *ClientConn) handleShowPreflight() error {
+	result := new(mysql.Result)
+	result.Status = c.status
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{
+		newStringField("Host"),
+		newStringField("Node"),
+		newStringField("Addr"),
+		newStringField("Role"),
+		newStringField("Check"),
+		newStringField("Object"),
+		newStringField("Result"),
+		newStringField("Message"),
+	}
+	result.Rows = make([]*mysql.Row, 0)
+	for _, check := range c.admin.proxy.Preflight() {
+		row := mysql.NewTextRow(result.Resultset.Fields)
+		row.AppendStringValue(check.Host)
+		row.AppendStringValue(check.Node)
+		row.AppendStringValue(check.Addr)
+		row.AppendStringValue(check.Role)
+		row.AppendStringValue(check.Check)
+		row.AppendStringValue(check.Object)
+		if check.Passed {
+			row.AppendStringValue("ok")
+		} else {
+			row.AppendStringValue("failed")
+		}
+		row.AppendStringValue(check.Message)
+		result.Rows = append(result.Rows, row)
+	}
+	return c.pkg.WriteResultSet(c.capability, c.status, result)
+}
+
+// handleKill 'KILL [CONNECTION | QUERY] id', close client session of proxy.
+func (c *ClientConn) handleKill(statement sqlparser.KillStatement) error {
+	connID := statement.GetConnectionID()
+	session, ok := c.admin.proxy.KillSession(connID)
+	if !ok {
+		return mysql.NewDefaultError(mysql.ER_NO_SUCH_THREAD, connID)
+	}
+	c.admin.proxy.Audit(c.user, c.c.RemoteAddr().String(), proxy.AuditActionKill, strconv.FormatUint(uint64(connID), 10), session, "")
+	return c.pkg.WriteOK(c.capability, c.status, nil)
+}
+
+// handleCreateProxyUser 'CREATE PROXY USER 'u1' IDENTIFIED BY 'pwd' SCHEMAS s1, s2 [READ ONLY]'
+func (c *ClientConn) handleCreateProxyUser(statement *sqlparser.CreateProxyUser) error {
+	user := proxy.ProxyUser{
+		Name:     string(statement.Name),
+		Password: string(statement.Options.Password),
+		Schemas:  proxyUserSchemas(statement.Options),
+		ReadOnly: statement.Options.ReadOnly == sqlparser.AST_READ_ONLY,
+	}
+	if err := c.admin.proxy.CreateProxyUser(user, statement.IfNotExists); err != nil {
+		return err
+	}
+	c.admin.proxy.Audit(c.user, c.c.RemoteAddr().String(), proxy.AuditActionCreateUser, strings.ToLower(user.Name), "", user.String())
+	return c.pkg.WriteOK(c.capability, c.status, nil)
+}
+
+// handleAlterProxyUser 'ALTER PROXY USER 'u1' [IDENTIFIED BY 'pwd'] [SCHEMAS s1, s2] [READ ONLY | READ WRITE]'
+func (c *ClientConn) handleAlterProxyUser(statement *sqlparser.AlterProxyUser) error {
+	var password *string
+	if statement.Options.Identified {
+		value := string(statement.Options.Password)
+		password = &value
+	}
+	var readOnly *bool
+	if statement.Options.ReadOnly != "" {
+		value := statement.Options.ReadOnly == sqlparser.AST_READ_ONLY
+		readOnly = &value
+	}
+	oldUser, newUser, err := c.admin.proxy.AlterProxyUser(string(statement.Name), password, proxyUserSchemas(statement.Options), readOnly)
+	if err != nil {
+		return err
+	}
+	newValue := newUser.String()
+	if password != nil {
+		newValue += ",password=changed"
+	}
+	c.admin.proxy.Audit(c.user, c.c.RemoteAddr().String(), proxy.AuditActionAlterUser, newUser.Name, oldUser.String(), newValue)
+	return c.pkg.WriteOK(c.capability, c.status, nil)
+}
+
+// handleDropProxyUser 'DROP PROXY USER [IF EXISTS] u1'
+func (c *ClientConn) handleDropProxyUser(statement *sqlparser.DropProxyUser) error {
+	if err := c.admin.proxy.DropProxyUser(string(statement.Name), statement.IfExists); err != nil {
+		return err
+	}
+	c.admin.proxy.Audit(c.user, c.c.RemoteAddr().String(), proxy.AuditActionDropUser, strings.ToLower(string(statement.Name)), "", "")
+	return c.pkg.WriteOK(c.capability, c.status, nil)
+}
+
+// handleShowProxyUsers 'SHOW PROXY USERS', password isn't shown.
+func (c *ClientConn) handleShowProxyUsers() error {
+	result := new(mysql.Result)
+	result.Status = c.status
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{
+		newStringField("User"),
+		newStringField("Schemas"),
+		newStringField("Read_only"),
+	}
+	result.Rows = make([]*mysql.Row, 0)
+	for _, user := range c.admin.proxy.GetProxyUsers() {
+		row := mysql.NewTextRow(result.Resultset.Fields)
+		row.AppendStringValue(user.Name)
+		row.AppendStringValue(strings.Join(user.Schemas, ","))
+		row.AppendStringValue(strconv.FormatBool(user.ReadOnly))
+		result.Rows = append(result.Rows, row)
+	}
+	return c.pkg.WriteResultSet(c.capability, c.status, result)
+}
+
+// proxyUserSchemas schemas of proxy user options, nil if not specified.
+func proxyUserSchemas(options *sqlparser.ProxyUserOptions) []string {
+	if options.Schemas == nil {
+		return nil
+	}
+	schemas := make([]string, len(options.Schemas))
+	for i, schema := range options.Schemas {
+		schemas[i] = string(schema)
+	}
+	return schemas
+}
+
+// handleShowMigration 'SHOW MIGRATION', ratio of shard keys moved between nodes, if staged shard rules are activated.
+func (c *ClientConn) handleShowMigration() error {
+	result := new(mysql.Result)
+	result.Status = c.status
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{
+		newStringField("Schema"),
+		newStringField("From_node"),
+		newStringField("To_node"),
+		newStringField("Ratio"),
+	}
+	result.Rows = make([]*mysql.Row, 0)
+	for _, migration := range c.admin.proxy.GetRuleMigrations() {
+		row := mysql.NewTextRow(result.Resultset.Fields)
+		row.AppendStringValue(migration.Schema)
+		row.AppendStringValue(migration.From)
+		row.AppendStringValue(migration.To)
+		row.AppendStringValue(strconv.FormatFloat(migration.Ratio, 'f', 4, 64))
+		result.Rows = append(result.Rows, row)
+	}
+	return c.pkg.WriteResultSet(c.capability, c.status, result)
+}
+
+// handleFailoverDrill 'START FAILOVER DRILL host1' and 'STOP FAILOVER DRILL', report is shown by 'SHOW FAILOVER DRILL'.
+func (c *ClientConn) handleFailoverDrill(statement *sqlparser.FailoverDrill) error {
+	host := string(statement.Host)
+	var err error
+	if statement.Action == sqlparser.AST_DRILL_START {
+		err = c.admin.proxy.StartFailoverDrill(host)
+	} else {
+		if report := c.admin.proxy.GetFailoverDrill(); report != nil {
+			host = report.Host
+		}
+		err = c.admin.proxy.StopFailoverDrill()
+	}
+	if err != nil {
+		return mysql.NewError(mysql.ER_UNKNOWN_ERROR, err.Error())
+	}
+	c.admin.proxy.Audit(c.user, c.c.RemoteAddr().String(), proxy.AuditActionDrill, host, "", statement.Action)
+	return c.pkg.WriteOK(c.capability, c.status, nil)
+}
+
+// handleShowFailoverDrill 'SHOW FAILOVER DRILL', report of current or last drill, empty if none.
+func (c *ClientConn) handleShowFailoverDrill() error {
+	result := new(mysql.Result)
+	result.Status = c.status
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{
+		newStringField("Host"),
+		newStringField("Addr"),
+		newStringField("Status"),
+		newStringField("Started"),
+		newStringField("Stopped"),
+		newStringField("Rejected"),
+		newStringField("Client_errors"),
+		newStringField("Recovery_time"),
+	}
+	result.Rows = make([]*mysql.Row, 0)
+	if report := c.admin.proxy.GetFailoverDrill(); report != nil {
+		row := mysql.NewTextRow(result.Resultset.Fields)
+		row.AppendStringValue(report.Host)
+		row.AppendStringValue(report.Addr)
+		row.AppendStringValue(report.Status)
+		row.AppendStringValue(report.Started.Format(time.RFC3339))
+		if report.Stopped.IsZero() {
+			row.AppendStringValue("")
+		} else {
+			row.AppendStringValue(report.Stopped.Format(time.RFC3339))
+		}
+		row.AppendStringValue(strconv.FormatInt(report.Rejected, 10))
+		row.AppendStringValue(strconv.FormatInt(report.ClientErrors, 10))
+		if report.Status == proxy.DrillStatusRecovered {
+			row.AppendStringValue(report.RecoveryTime.Round(time.Millisecond).String())
+		} else {
+			row.AppendStringValue("")
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return c.pkg.WriteResultSet(c.capability, c.status, result)
+}
+
+// handleExportRouting 'EXPORT ROUTING JSON' and 'EXPORT ROUTING MARKDOWN', effective routing in one row.
+func (c *ClientConn) handleExportRouting(statement *sqlparser.ExportRouting) error {
+	export := c.admin.proxy.ExportRouting()
+	var doc string
+	if statement.Type == sqlparser.AST_EXPORT_JSON {
+		data, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return mysql.NewError(mysql.ER_UNKNOWN_ERROR, err.Error())
+		}
+		doc = string(data)
+	} else {
+		doc = export.Markdown()
+	}
+	result := new(mysql.Result)
+	result.Status = c.status
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{newStringField("Routing")}
+	row := mysql.NewTextRow(result.Resultset.Fields)
+	row.AppendStringValue(doc)
+	result.Rows = []*mysql.Row{row}
+	return c.pkg.WriteResultSet(c.capability, c.status, result)
+}
+
+// handleShowAudit 'SHOW AUDIT', recent admin actions, oldest first.
+func (c *ClientConn) handleShowAudit() error {
+	result := new(mysql.Result)
+	result.Status = c.status
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{
+		newStringField("Time"),
+		newStringField("User"),
+		newStringField("Host"),
+		newStringField("Action"),
+		newStringField("Object"),
+		newStringField("Old_value"),
+		newStringField("New_value"),
+	}
+	result.Rows = make([]*mysql.Row, 0)
+	for _, entry := range c.admin.proxy.GetAuditLog() {
+		row := mysql.NewTextRow(result.Resultset.Fields)
+		row.AppendStringValue(entry.Time)
+		row.AppendStringValue(entry.User)
+		row.AppendStringValue(entry.Host)
+		row.AppendStringValue(entry.Action)
+		row.AppendStringValue(entry.Object)
+		row.AppendStringValue(entry.Old)
+		row.AppendStringValue(entry.New)
+		result.Rows = append(result.Rows, row)
+	}
+	return c.pkg.WriteResultSet(c.capability, c.status, result)
+}
+
+// writeNameValues write Variable_name and Value pairs, filtered by like.
+func (c *ClientConn) writeNameValues(names, values []string, likeOrWhere sqlparser.Expr) error {
+	var pattern *regexp.Regexp
+	if like, ok := likeOrWhere.(*sqlparser.LikeExpr); ok {
+		if val, ok := like.Expr.(sqlparser.StrVal); ok {
+			pattern = likePatternToRegexp(string(val))
+		}
+	}
+
+	result := new(mysql.Result)
+	result.Status = c.status
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{variableNameField, variableValueField}
+	result.Rows = make([]*mysql.Row, 0)
+	for i, name := range names {
+		if pattern != nil && !pattern.MatchString(name) {
+			continue
+		}
+		row := mysql.NewTextRow(result.Resultset.Fields)
+		row.AppendStringValue(name)
+		row.AppendStringValue(values[i])
+		result.Rows = append(result.Rows, row)
+	}
+	return c.pkg.WriteResultSet(c.capability, c.status, result)
+}
+
+func newStringField(name string) *mysql.Field {
+	return &mysql.Field{Name: []byte(name),
+		Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+		ColumnLength: 192,
+		ColumnType:   mysql.MYSQL_TYPE_VAR_STRING}
+}
+
+// handleSimpleSelect 'SELECT @@version_comment', it's used by mysql client.
+func (c *ClientConn) handleSimpleSelect(statement *sqlparser.SimpleSelect) error {
+	result := new(mysql.Result)
+	result.Status = c.status
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = make([]*mysql.Field, len(statement.SelectExprs))
+	values := make([]string, len(statement.SelectExprs))
+	for i, expr := range statement.SelectExprs {
+		name := strings.ToLower(sqlparser.String(expr))
+		switch name {
+		case "@@version_comment":
+			values[i] = mysql.SourceInfo
+		case "version()", "@@version":
+			values[i] = mysql.ServerVersion
+		default:
+			if strings.HasPrefix(name, "@@") {
+				value, err := c.admin.proxy.GetVariable(strings.TrimPrefix(strings.TrimPrefix(name, "@@"), "global."))
+				if err != nil {
+					return mysql.NewDefaultError(mysql.ER_UNKNOWN_SYSTEM_VARIABLE, name)
+				}
+				values[i] = value
+			} else {
+				return errors.ErrCmdUnsupport
+			}
+		}
+		result.Resultset.Fields[i] = &mysql.Field{Name: []byte(sqlparser.String(expr)),
+			Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+			ColumnLength: 3072,
+			ColumnType:   mysql.MYSQL_TYPE_VAR_STRING}
+	}
+	row := mysql.NewTextRow(result.Resultset.Fields)
+	for _, value := range values {
+		row.AppendStringValue(value)
+	}
+	result.Rows = []*mysql.Row{row}
+	return c.pkg.WriteResultSet(c.capability, c.status, result)
+}
+
+func likePatternToRegexp(pattern string) *regexp.Regexp {
+	var buf []string
+	for _, ch := range strings.ToLower(pattern) {
+		switch ch {
+		case '%':
+			buf = append(buf, ".*")
+		case '_':
+			buf = append(buf, ".")
+		default:
+			buf = append(buf, regexp.QuoteMeta(string(ch)))
+		}
+	}
+	return regexp.MustCompile("^" + strings.Join(buf, "") + "$")
+}
@@ -33,6 +33,19 @@ import (
 	"github.com/berkaroad/saashard/utils/simplelog"
 )
 
+// retryCount is retry count when get connection from pool failed.
+var retryCount int32 = 3
+
+// SetRetryCount set retry count when get connection from pool failed.
+func SetRetryCount(count int) {
+	atomic.StoreInt32(&retryCount, int32(count))
+}
+
+// GetRetryCount get retry count when get connection from pool failed.
+func GetRetryCount() int {
+	return int(atomic.LoadInt32(&retryCount))
+}
+
 // CreateConnection create connection function, this is default value, must replace it.
 var CreateConnection = func(dbHost *DBHost) Connection { return new(nilConnection) }
 
@@ -154,8 +167,22 @@ func NewConnectionPool(maxPoolSize uint32, dbHost *DBHost) *ConnectionPool {
 	return p
 }
 
+// SetMaxPoolSize set max pool size, 0 is unlimited.
+func (p *ConnectionPool) SetMaxPoolSize(maxPoolSize uint32) {
+	defer p.locker.Unlock()
+
+	p.locker.Lock()
+	p.MaxPoolSize = maxPoolSize
+	if p.MaxPoolSize == 0 {
+		p.MaxPoolSize = math.MaxUint32
+	}
+}
+
 // GetIdleCount Get Idle count.
 func (p *ConnectionPool) GetIdleCount() uint32 {
+	if p.used >= p.MaxPoolSize {
+		return 0
+	}
 	return p.MaxPoolSize - p.used
 }
 
@@ -167,7 +194,6 @@ func (p *ConnectionPool) GetConnection(database string) (Connection, error) {
 	p.locker.Lock()
 	var conn Connection
 	var err error
-	var retryCount int
 	f := func() (Connection, error) {
 		var conn Connection
 		var err error
@@ -200,8 +226,8 @@ func (p *ConnectionPool) GetConnection(database string) (Connection, error) {
 		return conn, err
 	}
 	conn, err = f()
-	retryCount++
-	for ; err != nil && retryCount <= 3; retryCount++ {
+	maxRetryCount := GetRetryCount()
+	for retried := 0; err != nil && retried < maxRetryCount; retried++ {
 		conn, err = f()
 	}
 
@@ -223,6 +249,49 @@ func (p *ConnectionPool) ReturnConnection(conn Connection) {
 	}
 }
 
+// ProbeIdleConnections ping idle connections, and prune those couldn't response,
+// so that half-open connections couldn't be given to client. It returns count of alive connections.
+func (p *ConnectionPool) ProbeIdleConnections() int {
+	p.locker.Lock()
+	idleConns := make([]Connection, 0, p.connections.Len())
+	for elem := p.connections.Front(); elem != nil; elem = elem.Next() {
+		conn := elem.Value.(Connection)
+		idleConns = append(idleConns, conn)
+		delete(p.connids, conn.GetConnectionID())
+	}
+	p.connections.Init()
+	p.locker.Unlock()
+
+	pruned := 0
+	aliveConns := idleConns[:0]
+	for _, conn := range idleConns {
+		if conn.IsClosed() || conn.Ping() != nil {
+			conn.Close()
+			pruned++
+		} else {
+			aliveConns = append(aliveConns, conn)
+		}
+	}
+
+	p.locker.Lock()
+	for _, conn := range aliveConns {
+		if _, exists := p.connids[conn.GetConnectionID()]; !exists {
+			p.connections.PushBack(conn)
+			p.connids[conn.GetConnectionID()] = nil
+		}
+	}
+	p.locker.Unlock()
+
+	if pruned > 0 {
+		simplelog.Warn("%s %s %s DBHost=%s,pruned=%d,cached=%d",
+			"backend", "ProbeIdleConnections", "Prune connections that couldn't response",
+			p.dbHost.Addr,
+			pruned,
+			len(aliveConns))
+	}
+	return len(aliveConns)
+}
+
 func (p *ConnectionPool) logConnIdleInfo() {
 	idleCount := p.GetIdleCount()
 	// idleCount is zero, or less or equal then 20%, then warn
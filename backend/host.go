@@ -26,6 +26,8 @@ import (
 	"container/ring"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/berkaroad/saashard/config"
 	"github.com/berkaroad/saashard/errors"
@@ -41,6 +43,12 @@ type DataHost struct {
 	Slaves             []*DBHost
 	slavePolling       *ring.Ring
 	slavePollingLength int
+	Analytics          []*DBHost // replicas for reporting workloads.
+	analyticsIndex     uint32
+	closeCh            chan struct{}
+
+	// OnStateChange is called when master or replica is down after no alive for DownAfterNoAlive seconds, or up again.
+	OnStateChange func(db *DBHost, up bool)
 }
 
 // NewDataHost new host.
@@ -50,7 +58,9 @@ func NewDataHost(hostCfg config.HostConfig) *DataHost {
 	h.MaxConnNum = hostCfg.MaxConnNum
 	h.DownAfterNoAlive = hostCfg.DownAfterNoAlive
 	h.PingInterval = hostCfg.PingInterval
+	h.closeCh = make(chan struct{})
 	h.Master = NewDBHost(hostCfg.Master, hostCfg.User, hostCfg.Password, 0, h.MaxConnNum)
+	h.Master.TCPKeepAlive = hostCfg.TCPKeepAlive
 
 	if len(hostCfg.Slaves) > 0 {
 		h.Slaves = make([]*DBHost, len(hostCfg.Slaves))
@@ -72,6 +82,7 @@ func NewDataHost(hostCfg config.HostConfig) *DataHost {
 				totalWeight += slaveWeight
 			}
 			h.Slaves[i] = NewDBHost(slaveConfig[0], hostCfg.User, hostCfg.Password, slaveWeight, h.MaxConnNum)
+			h.Slaves[i].TCPKeepAlive = hostCfg.TCPKeepAlive
 		}
 		adjustWeight := 1 - minWeight // the min weight must 1.
 		minWeight = 1
@@ -93,9 +104,105 @@ func NewDataHost(hostCfg config.HostConfig) *DataHost {
 		}
 	}
 
+	for _, addr := range hostCfg.Analytics {
+		analytics := NewDBHost(addr, hostCfg.User, hostCfg.Password, 0, h.MaxConnNum)
+		analytics.TCPKeepAlive = hostCfg.TCPKeepAlive
+		h.Analytics = append(h.Analytics, analytics)
+	}
+
 	return h
 }
 
+// Run to probe idle connections of master and slaves every PingInterval seconds.
+func (h *DataHost) Run() {
+	if h.PingInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Duration(h.PingInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.closeCh:
+			return
+		case <-ticker.C:
+			h.probe(h.Master)
+			for _, slave := range h.Slaves {
+				h.probe(slave)
+				h.measureLag(slave)
+			}
+			for _, analytics := range h.Analytics {
+				h.probe(analytics)
+				h.measureLag(analytics)
+			}
+		}
+	}
+}
+
+// probe idle connections of db host, and update its state if DownAfterNoAlive is set.
+func (h *DataHost) probe(db *DBHost) {
+	alive := db.Pool.ProbeIdleConnections() > 0
+	if h.DownAfterNoAlive <= 0 {
+		return
+	}
+	if !alive {
+		conn := CreateConnection(db)
+		if err := conn.Connect(db, ""); err == nil {
+			conn.Close()
+			alive = true
+		}
+	}
+
+	now := time.Now().Unix()
+	if alive {
+		atomic.StoreInt64(&db.lastAlive, now)
+		if atomic.CompareAndSwapInt32(&db.down, 1, 0) && h.OnStateChange != nil {
+			h.OnStateChange(db, true)
+		}
+	} else if now-atomic.LoadInt64(&db.lastAlive) >= int64(h.DownAfterNoAlive) {
+		if atomic.CompareAndSwapInt32(&db.down, 0, 1) && h.OnStateChange != nil {
+			h.OnStateChange(db, false)
+		}
+	}
+}
+
+// measureLag of replica by pooled connection, it's cached until next measurement.
+func (h *DataHost) measureLag(db *DBHost) {
+	lag := int64(-1)
+	if !db.IsDown() {
+		if conn, err := db.GetConnection(""); err == nil {
+			if reader, ok := conn.(LagReader); ok {
+				if l, err := reader.ReplicaLag(); err == nil {
+					lag = l
+				}
+			}
+			conn.ReturnConnection()
+		}
+	}
+	atomic.StoreInt64(&db.lag, lag)
+}
+
+// SetMaxConnNum set max conn num of master and slaves.
+func (h *DataHost) SetMaxConnNum(maxConnNum int) {
+	h.MaxConnNum = maxConnNum
+	h.Master.Pool.SetMaxPoolSize(uint32(maxConnNum))
+	for _, slave := range h.Slaves {
+		slave.Pool.SetMaxPoolSize(uint32(maxConnNum))
+	}
+	for _, analytics := range h.Analytics {
+		analytics.Pool.SetMaxPoolSize(uint32(maxConnNum))
+	}
+}
+
+// Close to stop probing.
+func (h *DataHost) Close() {
+	select {
+	case <-h.closeCh:
+	default:
+		close(h.closeCh)
+	}
+}
+
 // GetSlave get slave by balance algorithm
 func (h *DataHost) GetSlave() (*DBHost, error) {
 	if len(h.Slaves) == 0 {
@@ -109,6 +216,15 @@ func (h *DataHost) GetSlave() (*DBHost, error) {
 	return slave, nil
 }
 
+// GetAnalytics get analytics replica by polling.
+func (h *DataHost) GetAnalytics() (*DBHost, error) {
+	if len(h.Analytics) == 0 {
+		return nil, errors.ErrNoAnalyticsDB
+	}
+	i := atomic.AddUint32(&h.analyticsIndex, 1)
+	return h.Analytics[int(i)%len(h.Analytics)], nil
+}
+
 // DBHost db host.
 type DBHost struct {
 	Addr     string
@@ -116,6 +232,19 @@ type DBHost struct {
 	Password string
 	Weight   int
 	Pool     *ConnectionPool
+
+	TCPKeepAlive int // tcp keepalive period in seconds, 0 is os setting.
+
+	lastAlive int64 // unix time of last alive probe.
+	down      int32 // 1 if no alive for DownAfterNoAlive seconds.
+	drill     int32 // 1 if unreachable by failover drill, mysql isn't touched.
+	lag       int64 // seconds behind master of replica, -1 if unknown.
+}
+
+// LagReader is connection that could read replication lag.
+type LagReader interface {
+	// ReplicaLag read seconds behind master, -1 if replication is stopped.
+	ReplicaLag() (int64, error)
 }
 
 // NewDBHost new db host.
@@ -126,11 +255,40 @@ func NewDBHost(addr string, user, password string, weight int, maxConnNum int) *
 	h.Password = password
 	h.Weight = weight
 	h.Pool = NewConnectionPool(uint32(maxConnNum), h)
+	h.lastAlive = time.Now().Unix()
+	h.lag = -1
 	return h
 }
 
+// GetLag get measured lag in seconds of replica, -1 if unknown.
+func (h *DBHost) GetLag() int64 {
+	return atomic.LoadInt64(&h.lag)
+}
+
+// IsDown is true if no alive for DownAfterNoAlive seconds, or unreachable by failover drill.
+func (h *DBHost) IsDown() bool {
+	return atomic.LoadInt32(&h.down) == 1 || h.InDrill()
+}
+
+// SetDrill mark db host unreachable by failover drill, or restore it.
+func (h *DBHost) SetDrill(unreachable bool) {
+	var drill int32
+	if unreachable {
+		drill = 1
+	}
+	atomic.StoreInt32(&h.drill, drill)
+}
+
+// InDrill is true if unreachable by failover drill.
+func (h *DBHost) InDrill() bool {
+	return atomic.LoadInt32(&h.drill) == 1
+}
+
 // GetConnection to connect a backend conn.
 func (h *DBHost) GetConnection(database string) (Connection, error) {
+	if h.InDrill() {
+		return nil, errors.ErrMasterDrill
+	}
 	return h.Pool.GetConnection(database)
 }
 
@@ -0,0 +1,356 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package mock is a scriptable fake mysql server, as backend of proxy in unit and integration tests.
+//
+//	s, _ := mock.NewServer("127.0.0.1:0", "root", "root")
+//	defer s.Close()
+//	s.Handle(`^select \* from t1`, &mock.Response{Columns: []string{"id"}, Rows: [][]interface{}{{1}}})
+//	s.Handle(`^delete `, &mock.Response{Err: mysql.NewDefaultError(mysql.ER_LOCK_WAIT_TIMEOUT)})
+//	s.SetLatency(100 * time.Millisecond)
+package mock
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/berkaroad/saashard/net/mysql"
+)
+
+// Response of statement. Err takes precedence, and nil Columns is an OK packet.
+type Response struct {
+	Columns      []string
+	Rows         [][]interface{} // nil, string, bool, int, int64, uint64, float64 or fmt.Sprint of value.
+	AffectedRows uint64
+	InsertID     uint64
+	Err          error         // error packet, such as mysql.NewDefaultError(mysql.ER_LOCK_WAIT_TIMEOUT).
+	Latency      time.Duration // delay before response, added to latency of server.
+	Close        bool          // close connection without response, as crashed backend.
+}
+
+var autoCommitRegexp = regexp.MustCompile(`^set autocommit ?=? ?[01]$`)
+
+type rule struct {
+	re      *regexp.Regexp
+	respond func(query string) *Response
+}
+
+// Server is fake mysql server, with canned handshake and programmable responses.
+type Server struct {
+	user     string
+	password string
+
+	listener net.Listener
+	connID   uint32
+
+	mu      sync.Mutex
+	rules   []*rule
+	queries []string
+	conns   map[uint32]net.Conn
+	latency time.Duration
+	refuse  bool
+	closed  bool
+}
+
+// NewServer listen on addr, "127.0.0.1:0" to pick a free port. Login is checked by user and password.
+func NewServer(addr, user, password string) (*Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		user:     user,
+		password: password,
+		listener: listener,
+		conns:    make(map[uint32]net.Conn),
+	}
+	go s.run()
+	return s, nil
+}
+
+// Addr listened, as addr of db host.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Close listener and all connections.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	err := s.listener.Close()
+	s.CloseConnections()
+	return err
+}
+
+// Handle statements matched by pattern, regexp of lower case query. Rules are matched in order of adding.
+func (s *Server) Handle(pattern string, resp *Response) {
+	s.HandleFunc(pattern, func(query string) *Response { return resp })
+}
+
+// HandleFunc is Handle, with response computed from query.
+func (s *Server) HandleFunc(pattern string, respond func(query string) *Response) {
+	re := regexp.MustCompile(pattern)
+	s.mu.Lock()
+	s.rules = append(s.rules, &rule{re: re, respond: respond})
+	s.mu.Unlock()
+}
+
+// Reset rules and recorded queries.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	s.rules = nil
+	s.queries = nil
+	s.mu.Unlock()
+}
+
+// SetLatency delay every response, including ping.
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	s.latency = latency
+	s.mu.Unlock()
+}
+
+// SetRefuse close new connections before handshake if true, as unreachable backend.
+func (s *Server) SetRefuse(refuse bool) {
+	s.mu.Lock()
+	s.refuse = refuse
+	s.mu.Unlock()
+}
+
+// CloseConnections close all established connections, as restarted backend.
+func (s *Server) CloseConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, conn := range s.conns {
+		conn.Close()
+		delete(s.conns, id)
+	}
+}
+
+// ConnCount is count of established connections.
+func (s *Server) ConnCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+// Queries received, in order.
+func (s *Server) Queries() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	queries := make([]string, len(s.queries))
+	copy(queries, s.queries)
+	return queries
+}
+
+func (s *Server) run() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		if s.refuse || s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			continue
+		}
+		id := atomic.AddUint32(&s.connID, 1)
+		s.conns[id] = conn
+		s.mu.Unlock()
+		go s.serve(id, conn)
+	}
+}
+
+// session is server side of connection.
+type session struct {
+	server *Server
+	conn   net.Conn
+	pkg    *mysql.PacketIO
+
+	capability uint32
+	status     uint16
+}
+
+func (s *Server) serve(id uint32, conn net.Conn) {
+	defer func() {
+		conn.Close()
+		s.mu.Lock()
+		delete(s.conns, id)
+		s.mu.Unlock()
+	}()
+	c := &session{server: s, conn: conn, pkg: mysql.NewPacketIO(conn), status: mysql.SERVER_STATUS_AUTOCOMMIT}
+	if err := c.handshake(id); err != nil {
+		return
+	}
+	for {
+		c.pkg.Sequence = 0
+		data, err := c.pkg.ReadPacket()
+		if err != nil || len(data) == 0 {
+			return
+		}
+		if !c.dispatch(data[0], string(data[1:])) {
+			return
+		}
+	}
+}
+
+func (c *session) handshake(id uint32) error {
+	salt, _ := mysql.RandomBuf(20)
+	if err := c.pkg.WriteInitialHandshake(id, salt, mysql.DEFAULT_COLLATION_ID, mysql.DEFAULT_CAPABILITY, c.status); err != nil {
+		return err
+	}
+	getDefaultSchemaByUser := func(user string) (string, error) {
+		return "", nil
+	}
+	getCredentialsConfigBySchema := func(user, db string) (string, string, error) {
+		return c.server.user, c.server.password, nil
+	}
+	var err error
+	c.capability, _, _, _, _, err = c.pkg.ReadHandshakeResponse(getDefaultSchemaByUser, c.conn.RemoteAddr().String(), salt, getCredentialsConfigBySchema)
+	if err != nil {
+		c.pkg.WriteError(c.capability, err)
+		return err
+	}
+	return c.pkg.WriteOK(c.capability, c.status, nil)
+}
+
+// dispatch command, false if connection should be closed.
+func (c *session) dispatch(cmd byte, arg string) bool {
+	var resp *Response
+	switch cmd {
+	case mysql.COM_QUIT:
+		return false
+	case mysql.COM_QUERY:
+		resp = c.server.respond(arg)
+		c.trackStatus(arg, resp)
+	case mysql.COM_PING, mysql.COM_INIT_DB:
+		resp = &Response{}
+	default:
+		resp = &Response{Err: mysql.NewError(mysql.ER_UNKNOWN_COM_ERROR, fmt.Sprintf("command %d not supported by mock", cmd))}
+	}
+
+	c.server.mu.Lock()
+	latency := c.server.latency + resp.Latency
+	c.server.mu.Unlock()
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+	if resp.Close {
+		return false
+	}
+	return c.write(resp) == nil
+}
+
+// respond by the first matched rule, default is OK.
+func (s *Server) respond(query string) *Response {
+	query = strings.ToLower(strings.TrimSpace(query))
+	s.mu.Lock()
+	s.queries = append(s.queries, query)
+	rules := s.rules
+	s.mu.Unlock()
+	for _, r := range rules {
+		if r.re.MatchString(query) {
+			if resp := r.respond(query); resp != nil {
+				return resp
+			}
+		}
+	}
+	return &Response{}
+}
+
+// trackStatus of transaction and autocommit, by statements without error.
+func (c *session) trackStatus(query string, resp *Response) {
+	if resp.Err != nil || resp.Close {
+		return
+	}
+	query = strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	switch {
+	case query == "begin" || strings.HasPrefix(query, "start transaction"):
+		c.status |= mysql.SERVER_STATUS_IN_TRANS
+	case query == "commit" || query == "rollback":
+		c.status &= ^mysql.SERVER_STATUS_IN_TRANS
+	case autoCommitRegexp.MatchString(query):
+		if strings.HasSuffix(query, "0") {
+			c.status &= ^mysql.SERVER_STATUS_AUTOCOMMIT
+		} else {
+			c.status |= mysql.SERVER_STATUS_AUTOCOMMIT
+		}
+	}
+}
+
+func (c *session) write(resp *Response) error {
+	if resp.Err != nil {
+		return c.pkg.WriteError(c.capability, resp.Err)
+	}
+	if resp.Columns == nil {
+		return c.pkg.WriteOK(c.capability, c.status, &mysql.Result{Status: c.status, AffectedRows: resp.AffectedRows, InsertID: resp.InsertID})
+	}
+	return c.pkg.WriteResultSet(c.capability, c.status, newResult(c.status, resp))
+}
+
+// newResult of text rows, built for each write since field is dumped in place.
+func newResult(status uint16, resp *Response) *mysql.Result {
+	result := &mysql.Result{Status: status, Resultset: new(mysql.Resultset)}
+	result.Fields = make([]*mysql.Field, len(resp.Columns))
+	result.FieldNames = make(map[string]int, len(resp.Columns))
+	for i, name := range resp.Columns {
+		result.Fields[i] = &mysql.Field{Name: []byte(name),
+			Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+			ColumnLength: 192,
+			ColumnType:   mysql.MYSQL_TYPE_VAR_STRING}
+		result.FieldNames[name] = i
+	}
+	result.Rows = make([]*mysql.Row, 0, len(resp.Rows))
+	for _, values := range resp.Rows {
+		row := mysql.NewTextRow(result.Fields)
+		for _, value := range values {
+			switch v := value.(type) {
+			case nil:
+				row.AppendNullValue()
+			case string:
+				row.AppendStringValue(v)
+			case bool:
+				row.AppendBooleanValue(v)
+			case int:
+				row.AppendIntValue(int64(v))
+			case int64:
+				row.AppendIntValue(v)
+			case uint64:
+				row.AppendUIntValue(v)
+			case float64:
+				row.AppendStringValue(strconv.FormatFloat(v, 'f', -1, 64))
+			default:
+				row.AppendStringValue(fmt.Sprint(v))
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result
+}
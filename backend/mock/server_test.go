@@ -0,0 +1,79 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mock
+
+import (
+	"testing"
+
+	"github.com/berkaroad/saashard/backend"
+	mysqlBackend "github.com/berkaroad/saashard/backend/mysql"
+	"github.com/berkaroad/saashard/net/mysql"
+)
+
+func TestServer(t *testing.T) {
+	s, err := NewServer("127.0.0.1:0", "root", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.Handle(`^select id, name from t1`, &Response{Columns: []string{"id", "name"}, Rows: [][]interface{}{{1, "a"}, {2, nil}}})
+	s.Handle(`^delete `, &Response{Err: mysql.NewDefaultError(mysql.ER_LOCK_WAIT_TIMEOUT)})
+
+	conn := new(mysqlBackend.Conn)
+	if err = conn.Connect(backend.NewDBHost(s.Addr(), "root", "secret", 1, 1), "db1"); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	result, err := conn.Query("SELECT id, name FROM t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RowNumber() != 2 {
+		t.Fatalf("rows: %d", result.RowNumber())
+	}
+	if name, _ := result.GetString(0, 1); name != "a" {
+		t.Errorf("name: %s", name)
+	}
+	if null, _ := result.IsNull(1, 1); !null {
+		t.Errorf("expected null")
+	}
+	if _, err = conn.Query("delete from t1"); err == nil {
+		t.Errorf("expected error")
+	}
+	if err = conn.Begin(); err != nil || !conn.IsInTransaction() {
+		t.Errorf("expected in transaction: %v", err)
+	}
+	// sql_mode is read at connect.
+	if queries := s.Queries(); len(queries) != 4 || queries[0] != "select @@session.sql_mode" || queries[1] != "select id, name from t1" {
+		t.Errorf("queries: %v", queries)
+	}
+
+	s.SetRefuse(true)
+	if err = new(mysqlBackend.Conn).Connect(backend.NewDBHost(s.Addr(), "root", "secret", 1, 1), "db1"); err == nil {
+		t.Errorf("expected connect error")
+	}
+	if err = new(mysqlBackend.Conn).Connect(backend.NewDBHost(s.Addr(), "root", "wrong", 1, 1), "db1"); err == nil {
+		t.Errorf("expected access denied")
+	}
+}
@@ -23,14 +23,35 @@
 package mysql
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/berkaroad/saashard/backend"
+	"github.com/berkaroad/saashard/errors"
 	"github.com/berkaroad/saashard/net/mysql"
 )
 
+// pingTimeout is the deadline of ping, to detect half-open connection.
+const pingTimeout = 3 * time.Second
+
+// connectAttrs is sent to mysql server when connecting, so that
+// backend connections could be traced in performance_schema.session_connect_attrs.
+var connectAttrs = map[string]string{
+	"_client_name":    "saashard",
+	"_client_version": mysql.ServerVersion,
+	"_os":             runtime.GOOS,
+	"_platform":       runtime.GOARCH,
+	"_pid":            strconv.Itoa(os.Getpid()),
+	"program_name":    "saashard",
+}
+
 func init() {
 	backend.CreateConnection = func(dbHost *backend.DBHost) backend.Connection {
 		return new(Conn)
@@ -46,13 +67,22 @@ type Conn struct {
 	dbHost       *backend.DBHost
 	db           string
 	connectionID uint32
+	threadID     uint32 // connection id of mysql server, to kill query
 
 	capability uint32
 	status     uint16
 
 	collation mysql.CollationID
 	charset   string
+	sqlMode   string
+	sqlModeOn bool              // sql_mode is set or not
+	// defaultSQLMode is sql_mode read at connect, nil if it couldn't be read.
+	defaultSQLMode *string
+	variables map[string]string // session variables set by client, empty value if it isn't literal
+	locked    bool              // tables are locked by client
 	salt      []byte
+
+	tracker mysql.MemoryTracker // account buffered rows to client session
 }
 
 // GetConnectionID get connection id
@@ -105,18 +135,25 @@ func (c *Conn) Reconnect() error {
 			//I set this option false.
 			tcpConn.SetNoDelay(false)
 			tcpConn.SetKeepAlive(true)
+			if c.dbHost.TCPKeepAlive > 0 {
+				tcpConn.SetKeepAlivePeriod(time.Duration(c.dbHost.TCPKeepAlive) * time.Second)
+			}
 		}
 
 		c.conn = netConn
 		c.pkg = mysql.NewPacketIO(netConn)
+		c.pkg.SetMemoryTracker(c.tracker)
+		c.sqlMode, c.sqlModeOn = "", false
+		c.variables = nil
+		c.locked = false
 
-		if c.capability, c.status, c.collation, err = c.pkg.ReadInitialHandshake(&(c.salt)); err != nil {
+		if c.capability, c.status, c.collation, err = c.pkg.ReadInitialHandshake(&(c.salt), &(c.threadID)); err != nil {
 			c.conn.Close()
 			c.conn = nil
 			return err
 		}
 
-		if err := c.pkg.WriteAuthHandshake(&(c.capability), c.dbHost.User, c.dbHost.Password, c.db, c.salt, c.collation); err != nil {
+		if err := c.pkg.WriteAuthHandshake(&(c.capability), c.dbHost.User, c.dbHost.Password, c.db, c.salt, c.collation, connectAttrs); err != nil {
 			c.conn.Close()
 			c.conn = nil
 			return err
@@ -138,10 +175,30 @@ func (c *Conn) Reconnect() error {
 			return err
 		}
 	}
+	if needReconnect {
+		c.readDefaultSQLMode()
+	}
 
 	return nil
 }
 
+// readDefaultSQLMode read sql_mode of new session, so that sql_mode equal to it isn't set or reset.
+func (c *Conn) readDefaultSQLMode() {
+	c.defaultSQLMode = nil
+	result, err := c.pkg.Query(c.capability, &(c.status), "select @@session.sql_mode")
+	if err != nil || result.Resultset == nil {
+		return
+	}
+	if sqlMode, err := result.GetString(0, 0); err == nil {
+		c.defaultSQLMode = &sqlMode
+	}
+}
+
+// isDefaultSQLMode is true if sql_mode is equal to sql_mode read at connect.
+func (c *Conn) isDefaultSQLMode(sqlMode string) bool {
+	return c.defaultSQLMode != nil && *c.defaultSQLMode == sqlMode
+}
+
 // Close conn
 func (c *Conn) Close() error {
 	if !c.IsClosed() {
@@ -157,6 +214,31 @@ func (c *Conn) Close() error {
 
 // ReturnConnection give back connection.
 func (c *Conn) ReturnConnection() {
+	// Reset sql_mode set by client, before give back to pool, unless it's still the default.
+	if c.sqlModeOn && !c.IsClosed() {
+		if !c.isDefaultSQLMode(c.sqlMode) {
+			if _, err := c.pkg.Query(c.capability, &(c.status), "set sql_mode = default"); err != nil {
+				c.Close()
+			}
+		}
+		c.sqlMode, c.sqlModeOn = "", false
+	}
+	// Reset session variables set by client.
+	if len(c.variables) > 0 && !c.IsClosed() {
+		if _, err := c.pkg.Query(c.capability, &(c.status), c.resetVariablesSQL()); err != nil {
+			c.Close()
+		}
+	}
+	c.variables = nil
+	// Release tables locked by client.
+	if c.locked && !c.IsClosed() {
+		if _, err := c.pkg.Query(c.capability, &(c.status), "unlock tables"); err != nil {
+			c.Close()
+		}
+	}
+	c.locked = false
+	c.SetMemoryTracker(nil)
+	c.SetCapture(nil)
 	if c.dbHost != nil {
 		c.dbHost.Pool.ReturnConnection(c)
 	}
@@ -167,9 +249,30 @@ func (c *Conn) Ping() error {
 	if c.IsClosed() {
 		c.Reconnect()
 	}
+	if c.IsClosed() {
+		return errors.ErrBadConn
+	}
+	netConn := c.conn
+	netConn.SetDeadline(time.Now().Add(pingTimeout))
+	defer netConn.SetDeadline(time.Time{})
 	return c.pkg.Ping(c.capability, &(c.status))
 }
 
+// ReplicaLag read Seconds_Behind_Master of replica, -1 if replication is stopped.
+func (c *Conn) ReplicaLag() (int64, error) {
+	result, err := c.Query("show slave status")
+	if err != nil {
+		return -1, err
+	}
+	if result.Resultset == nil || result.RowNumber() == 0 {
+		return -1, errors.ErrNotReplica
+	}
+	if null, err := result.IsNullByName(0, "Seconds_Behind_Master"); err != nil || null {
+		return -1, err
+	}
+	return result.GetIntByName(0, "Seconds_Behind_Master")
+}
+
 // UseDB use db.
 func (c *Conn) UseDB(dbName string) error {
 	if c.IsClosed() {
@@ -210,6 +313,88 @@ func (c *Conn) Query(query string) (*mysql.Result, error) {
 	return c.pkg.Query(c.capability, &(c.status), query)
 }
 
+// QueryContext is Query that could be cancelled, the running query is killed when ctx is done.
+func (c *Conn) QueryContext(ctx context.Context, query string) (*mysql.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	defer c.watchContext(ctx)()
+	return c.Query(query)
+}
+
+// ExecuteContext is Execute that could be cancelled, the running query is killed when ctx is done.
+func (c *Conn) ExecuteContext(ctx context.Context, command string, args []interface{}) (*mysql.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	defer c.watchContext(ctx)()
+	return c.Execute(command, args)
+}
+
+// ExecuteCursorContext execute stmt with read only cursor, the running query is killed when ctx is done.
+// If cursor is opened, stmt is returned to fetch rows, and should be closed by caller.
+func (c *Conn) ExecuteCursorContext(ctx context.Context, command string, args []interface{}) (*mysql.Stmt, *mysql.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	defer c.watchContext(ctx)()
+	s, err := c.Prepare(command)
+	if err != nil {
+		return nil, nil, err
+	}
+	var r *mysql.Result
+	if r, err = s.ExecuteCursor(args); err != nil || r.Status&mysql.SERVER_STATUS_CURSOR_EXISTS == 0 {
+		s.Close()
+		return nil, r, err
+	}
+	return s, r, nil
+}
+
+// FetchContext fetch rows from opened cursor of stmt, the running fetch is killed when ctx is done.
+func (c *Conn) FetchContext(ctx context.Context, s *mysql.Stmt, fields []*mysql.Field, rows uint32) (*mysql.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	defer c.watchContext(ctx)()
+	return s.Fetch(fields, rows)
+}
+
+// watchContext kill query when ctx is done, until stop is called.
+// stop waits for killing, so that the next query of connection wouldn't be killed.
+func (c *Conn) watchContext(ctx context.Context) (stop func()) {
+	if ctx.Done() == nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	killed := make(chan struct{})
+	go func() {
+		defer close(killed)
+		select {
+		case <-ctx.Done():
+			c.killQuery()
+		case <-done:
+		}
+	}()
+	return func() {
+		close(done)
+		<-killed
+	}
+}
+
+// killQuery kill running query by another connection.
+func (c *Conn) killQuery() {
+	if c.threadID == 0 {
+		return
+	}
+	conn := new(Conn)
+	if err := conn.Connect(c.dbHost, ""); err != nil {
+		return
+	}
+	defer conn.Close()
+	conn.conn.SetDeadline(time.Now().Add(pingTimeout))
+	conn.Query(fmt.Sprintf("kill query %d", c.threadID))
+}
+
 // FieldList return field list.
 func (c *Conn) FieldList(table string, wildcard string) ([]*mysql.Field, error) {
 	if c.IsClosed() {
@@ -294,6 +479,122 @@ func (c *Conn) SetCharset(charset string) error {
 
 }
 
+// SetSQLMode set session's sql_mode.
+func (c *Conn) SetSQLMode(sqlMode string) error {
+	if c.IsClosed() {
+		c.Reconnect()
+	}
+	if c.sqlModeOn && c.sqlMode == sqlMode {
+		return nil
+	}
+	if !c.sqlModeOn && c.isDefaultSQLMode(sqlMode) {
+		c.sqlMode, c.sqlModeOn = sqlMode, true
+		return nil
+	}
+
+	if _, err := c.pkg.Query(c.capability, &(c.status), fmt.Sprintf("set sql_mode = '%s'", mysql.Escape(sqlMode))); err != nil {
+		return err
+	}
+	c.sqlMode, c.sqlModeOn = sqlMode, true
+	return nil
+}
+
+// ReadSQLMode read sql_mode of session back, such as after it's set by DEFAULT or expression.
+func (c *Conn) ReadSQLMode() (string, error) {
+	result, err := c.Query("select @@session.sql_mode")
+	if err != nil {
+		return "", err
+	}
+	sqlMode, err := result.GetString(0, 0)
+	if err != nil {
+		return "", err
+	}
+	c.sqlMode, c.sqlModeOn = sqlMode, true
+	return sqlMode, nil
+}
+
+// SetTablesLocked track tables locked by LOCK TABLES or released by UNLOCK TABLES,
+// locked tables are released before give back to pool.
+func (c *Conn) SetTablesLocked(locked bool) {
+	c.locked = locked
+}
+
+// SetVariables set session variables, that are different from current values.
+// Name is @x of user variable or @@session.x of system variable, value is literal of sql.
+func (c *Conn) SetVariables(variables map[string]string) error {
+	names := make([]string, 0, len(variables))
+	for name, value := range variables {
+		if current, ok := c.variables[name]; !ok || current != value {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	if c.IsClosed() {
+		c.Reconnect()
+	}
+	sort.Strings(names)
+	assignments := make([]string, len(names))
+	for i, name := range names {
+		assignments[i] = name + " = " + variables[name]
+	}
+	if _, err := c.pkg.Query(c.capability, &(c.status), "set "+strings.Join(assignments, ", ")); err != nil {
+		return err
+	}
+	for _, name := range names {
+		c.TrackVariable(name, variables[name])
+	}
+	return nil
+}
+
+// TrackVariable record session variable set by client's statement, value is empty if it isn't literal.
+func (c *Conn) TrackVariable(name, value string) {
+	if c.variables == nil {
+		c.variables = make(map[string]string)
+	}
+	c.variables[name] = value
+}
+
+// resetVariablesSQL reset user variables to null, and system variables to default.
+func (c *Conn) resetVariablesSQL() string {
+	names := make([]string, 0, len(c.variables))
+	for name := range c.variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	assignments := make([]string, len(names))
+	for i, name := range names {
+		if strings.HasPrefix(name, "@@") {
+			assignments[i] = name + " = default"
+		} else {
+			assignments[i] = name + " = null"
+		}
+	}
+	return "set " + strings.Join(assignments, ", ")
+}
+
+// SetMemoryTracker set tracker to account rows buffered by queries.
+func (c *Conn) SetMemoryTracker(tracker mysql.MemoryTracker) {
+	c.tracker = tracker
+	if c.pkg != nil {
+		c.pkg.SetMemoryTracker(tracker)
+	}
+}
+
+// SetCapture set capture to record packets of queries.
+// It is not kept on reconnect, so that handshake with password is never recorded.
+func (c *Conn) SetCapture(capture mysql.PacketCapture) {
+	if c.pkg != nil {
+		c.pkg.SetCapture(capture)
+	}
+}
+
+// GetSQLMode get session's sql_mode.
+func (c *Conn) GetSQLMode() string {
+	return c.sqlMode
+}
+
 // GetCharset get charset.
 func (c *Conn) GetCharset() string {
 	return c.charset
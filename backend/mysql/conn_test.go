@@ -0,0 +1,77 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mysql
+
+import (
+	"testing"
+
+	"github.com/berkaroad/saashard/backend"
+	"github.com/berkaroad/saashard/backend/mock"
+)
+
+// sql_mode equal to the one read at connect isn't set, nor reset when conn is given back.
+func TestSQLModeDefault(t *testing.T) {
+	s, err := mock.NewServer("127.0.0.1:0", "root", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.Handle(`^select @@session.sql_mode`, &mock.Response{Columns: []string{"@@session.sql_mode"},
+		Rows: [][]interface{}{{"STRICT_TRANS_TABLES"}}})
+	pool := backend.NewDBHost(s.Addr(), "root", "secret", 1, 1)
+
+	cases := []struct {
+		sqlModes []string // set in order, then conn is given back.
+		queries  []string
+	}{
+		{[]string{"STRICT_TRANS_TABLES"}, nil},
+		{[]string{"ANSI_QUOTES"}, []string{"set sql_mode = 'ansi_quotes'", "set sql_mode = default"}},
+		{[]string{"ANSI_QUOTES", "STRICT_TRANS_TABLES"}, []string{"set sql_mode = 'ansi_quotes'", "set sql_mode = 'strict_trans_tables'"}},
+	}
+	for _, c := range cases {
+		conn, err := pool.GetConnection("db")
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Reset()
+		s.Handle(`^select @@session.sql_mode`, &mock.Response{Columns: []string{"@@session.sql_mode"},
+			Rows: [][]interface{}{{"STRICT_TRANS_TABLES"}}})
+		for _, sqlMode := range c.sqlModes {
+			if err = conn.(*Conn).SetSQLMode(sqlMode); err != nil {
+				t.Fatal(err)
+			}
+		}
+		conn.ReturnConnection()
+		queries := s.Queries()
+		if len(queries) != len(c.queries) {
+			t.Errorf("%v: queries = %v, expected %v", c.sqlModes, queries, c.queries)
+			continue
+		}
+		for i := range queries {
+			if queries[i] != c.queries[i] {
+				t.Errorf("%v: queries = %v, expected %v", c.sqlModes, queries, c.queries)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/server"
+)
+
+// runDump write logical dump of sharded table into stdout or file, it returns exit code, 1 if dump is failed.
+func runDump(args []string) int {
+	flags := flag.NewFlagSet("dump", flag.ExitOnError)
+	configFile := flags.String("config", "/opt/saashard/conf/ss.yaml", "saashard config file")
+	configEnv := flags.String("env", os.Getenv("SAASHARD_ENV"), "config overlay of environment [dev|staging|prod|...]")
+	schema := flags.String("schema", "", "schema of table")
+	table := flags.String("table", "", "logical table to dump")
+	outFile := flags.String("out", "", "output file, default is stdout")
+	chunk := flags.Int("chunk", 1000, "rows of each insert statement")
+	flags.Parse(args)
+
+	if len(*schema) == 0 || len(*table) == 0 {
+		fmt.Fprintln(os.Stderr, "must specify schema and table")
+		return 1
+	}
+	cfg, err := config.ParseConfigFileWithEnv(*configFile, *configEnv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "parse config file error:%v\n", err.Error())
+		return 1
+	}
+	var w io.Writer = os.Stdout
+	if len(*outFile) != 0 {
+		f, err := os.Create(*outFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "create output file error:%v\n", err.Error())
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+	if err = server.Dump(cfg, *schema, *table, *chunk, w); err != nil {
+		fmt.Fprintf(os.Stderr, "dump error:%v\n", err.Error())
+		return 1
+	}
+	return 0
+}
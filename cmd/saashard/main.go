@@ -40,6 +40,7 @@ import (
 
 var (
 	configFile = flag.String("config", "/opt/saashard/conf/ss.yaml", "saashard config file")
+	configEnv  = flag.String("env", os.Getenv("SAASHARD_ENV"), "config overlay of environment [dev|staging|prod|...], '<config>.<env>.yaml' is merged into config")
 	logLevel   = flag.String("log-level", "", "log level [debug|info|warn|error], default error")
 	version    = flag.Bool("v", false, "the version of saashard")
 	cpuprofile = flag.String("cpuprofile", "", "write cpu profile to file")
@@ -65,10 +66,26 @@ const banner string = `
 `
 
 func main() {
+	// saashard dump [flags], write logical dump of table into stdout or file, before banner.
+	if len(os.Args) > 1 && os.Args[1] == "dump" {
+		os.Exit(runDump(os.Args[2:]))
+	}
 	fmt.Print(banner)
 	runtime.GOMAXPROCS(runtime.NumCPU())
 
-	flag.Parse()
+	// saashard replay [flags], replay captured session against proxy.
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		os.Exit(runReplay(os.Args[2:]))
+	}
+	// saashard check-config [flags], validate config only.
+	// saashard preflight [flags], validate config and check backends, exit non-zero if any check is failed.
+	preflight := len(os.Args) > 1 && os.Args[1] == "preflight"
+	checkConfig := preflight || (len(os.Args) > 1 && os.Args[1] == "check-config")
+	if checkConfig {
+		flag.CommandLine.Parse(os.Args[2:])
+	} else {
+		flag.Parse()
+	}
 	fmt.Printf("Git commit:%s\n", saashard.Version)
 	fmt.Printf("Build time:%s\n", saashard.Compile)
 	if *version {
@@ -98,12 +115,29 @@ func main() {
 	}
 	if len(*configFile) == 0 {
 		fmt.Println("must use a config file")
+		if checkConfig {
+			os.Exit(1)
+		}
 		return
 	}
 
-	cfg, err := config.ParseConfigFile(*configFile)
+	cfg, err := config.ParseConfigFileWithEnv(*configFile, *configEnv)
 	if err != nil {
 		fmt.Printf("parse config file error:%v\n", err.Error())
+		if checkConfig {
+			os.Exit(1)
+		}
+		return
+	}
+	if checkConfig {
+		if err = server.CheckConfig(cfg); err != nil {
+			fmt.Printf("check config error:%v\n", err.Error())
+			os.Exit(1)
+		}
+		if preflight {
+			os.Exit(runPreflight(cfg))
+		}
+		fmt.Println("config is ok")
 		return
 	}
 
@@ -111,7 +145,8 @@ func main() {
 	svr, err = server.NewServer(cfg)
 	if err != nil {
 		simplelog.Error("%s %s %s", "main", "main", err.Error())
-		return
+		fmt.Printf("start server error:%v\n", err.Error())
+		os.Exit(1)
 	}
 
 	sc := make(chan os.Signal, 1)
@@ -125,7 +160,10 @@ func main() {
 	go func() {
 		for {
 			sig := <-sc
-			if sig == syscall.SIGINT || sig == syscall.SIGTERM || sig == syscall.SIGQUIT {
+			if sig == syscall.SIGTERM {
+				simplelog.Info("%s %s %s signal=%s", "main", "main", "Got signal", sig)
+				go svr.Drain()
+			} else if sig == syscall.SIGINT || sig == syscall.SIGQUIT {
 				simplelog.Info("%s %s %s signal=%s", "main", "main", "Got signal", sig)
 				svr.Close()
 			} else if sig == syscall.SIGPIPE {
@@ -135,5 +173,31 @@ func main() {
 		}
 	}()
 
+	go svr.WatchConfig(*configFile, *configEnv)
 	svr.Run()
 }
+
+// runPreflight print results of checks against backends, return 1 if any is failed.
+func runPreflight(cfg *config.Config) int {
+	results, err := server.Preflight(cfg)
+	if err != nil {
+		fmt.Printf("preflight error:%v\n", err.Error())
+		return 1
+	}
+	failed := 0
+	for _, result := range results {
+		status := "ok"
+		if !result.Passed {
+			status = "FAILED"
+			failed++
+		}
+		fmt.Printf("%-6s host=%s,node=%s,addr=%s,role=%s,check=%s,object=%s %s\n", status,
+			result.Host, result.Node, result.Addr, result.Role, result.Check, result.Object, result.Message)
+	}
+	if failed > 0 {
+		fmt.Printf("preflight failed: %d of %d checks\n", failed, len(results))
+		return 1
+	}
+	fmt.Println("preflight is ok")
+	return 0
+}
@@ -0,0 +1,69 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/berkaroad/saashard/replay"
+)
+
+// runReplay replay captured session against proxy, it returns exit code, 1 if any packet mismatches.
+func runReplay(args []string) int {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	captureFile := flags.String("capture", "", "capture file")
+	addr := flags.String("addr", "127.0.0.1:6051", "address of proxy")
+	user := flags.String("user", "", "user to login, default is user of capture")
+	password := flags.String("password", "", "password to login")
+	db := flags.String("db", "", "database to use, default is db of capture")
+	timeout := flags.Int("timeout", 10, "timeout of each packet in seconds")
+	flags.Parse(args)
+
+	capture, err := replay.ReadCaptureFile(*captureFile)
+	if err != nil {
+		fmt.Printf("read capture file error:%v\n", err.Error())
+		return 1
+	}
+	replayer := &replay.Replayer{
+		Addr:     *addr,
+		User:     *user,
+		Password: *password,
+		DB:       *db,
+		Timeout:  time.Duration(*timeout) * time.Second,
+	}
+	result, err := replayer.Replay(capture)
+	if err != nil {
+		fmt.Printf("replay error:%v\n", err.Error())
+		return 1
+	}
+	for _, mismatch := range result.Mismatches {
+		fmt.Println(mismatch.String())
+	}
+	fmt.Printf("commands:%d,mismatches:%d\n", result.Commands, len(result.Mismatches))
+	if len(result.Mismatches) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -23,6 +23,7 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"strconv"
@@ -40,9 +41,71 @@ type Config struct {
 	LogLevel       string   `yaml:"log_level"`
 	LogSQL         string   `yaml:"log_sql"`
 	SlowLogTime    int      `yaml:"slow_log_time"`
+	QueryTimeout   int      `yaml:"query_timeout"`
 	AllowIps       []string `yaml:"allow_ips"`
 	Charset        string   `yaml:"charset"`
 	AllowKillQuery bool     `yaml:"allow_kill_query"`
+	AllowGrant     bool     `yaml:"allow_grant"`
+	AllowLock      bool     `yaml:"allow_lock_tables"`
+	TCPKeepAlive   int      `yaml:"tcp_keepalive"`
+	MaxFanout      int      `yaml:"max_fanout"`
+	SQLAttribution bool     `yaml:"sql_attribution"`
+	MemoryBudget   int      `yaml:"memory_budget"`
+	MemoryPolicy   string   `yaml:"memory_policy"`
+	SpillDir       string   `yaml:"spill_dir"`
+	SpillMaxSize   int      `yaml:"spill_max_size"`
+	StatsInterval  int      `yaml:"stats_interval"`
+	AnalyticsCost  int      `yaml:"analytics_cost"`
+	DiffMode       string   `yaml:"diff_mode"`
+	ShadowParser   string   `yaml:"shadow_parser"`
+	ReusePort      bool     `yaml:"reuse_port"`
+	Acceptors      int      `yaml:"acceptors"`
+	MaxProcs       int      `yaml:"max_procs"`
+	CaptureDir     string   `yaml:"capture_dir"`
+	SingletonUsers []string `yaml:"singleton_users"`
+
+	SingletonAttribute string `yaml:"singleton_attribute"`
+
+	MaxQueryCost    int    `yaml:"max_query_cost"`
+	QueryCostPolicy string `yaml:"query_cost_policy"`
+
+	CloneChunkSize    int    `yaml:"clone_chunk_size"`
+	CloneThrottle     int    `yaml:"clone_throttle"`
+	CloneProgressFile string `yaml:"clone_progress_file"`
+
+	TLSCert           string `yaml:"tls_cert"`
+	TLSKey            string `yaml:"tls_key"`
+	TLSCA             string `yaml:"tls_ca"`
+	TLSReloadInterval int    `yaml:"tls_reload_interval"`
+
+	AdminUser        string `yaml:"admin_user"`
+	AdminPassword    string `yaml:"admin_password"`
+	RuntimeStateFile string `yaml:"runtime_state_file"`
+	AdminAuditLog    string `yaml:"admin_audit_log"`
+
+	HealthPort    int    `yaml:"health_port"`
+	ProbeUser     string `yaml:"probe_user"`
+	ProbePassword string `yaml:"probe_password"`
+
+	ExportPort int `yaml:"export_port"` // http export of tables, authenticated by admin user.
+
+	Kubernetes          bool `yaml:"kubernetes"`
+	ConfigWatchInterval int  `yaml:"config_watch_interval"`
+	DrainDelay          int  `yaml:"drain_delay"`
+	DrainTimeout        int  `yaml:"drain_timeout"`
+
+	LongTransTime      int `yaml:"long_trans_time"`      // seconds, transaction longer than it is alerted.
+	BigTransStatements int `yaml:"big_trans_statements"` // transaction with more statements is alerted.
+	BigTransRows       int `yaml:"big_trans_rows"`       // transaction with more affected rows is alerted.
+
+	Preflight bool `yaml:"preflight"` // check backends before startup, and fail if any check is failed.
+
+	PartialResultPolicy string `yaml:"partial_result_policy"` // fail, partial or replica, when a node fails during fan-out select.
+
+	Listeners []ListenerConfig `yaml:"listeners"`
+	Hooks     []HookConfig     `yaml:"hooks"`
+	Authz     AuthzConfig      `yaml:"authz"`
+	Shipper   LogShipperConfig `yaml:"log_shipper"`
 
 	Hosts   []HostConfig   `yaml:"hosts"`
 	Nodes   []NodeConfig   `yaml:"nodes"`
@@ -65,16 +128,59 @@ func (config *Config) GetNodes() map[string]*NodeConfig {
 	return config.nodes
 }
 
+// Equal compares configs by their yaml, cache of config is ignored.
+func Equal(a, b *Config) bool {
+	dataA, errA := yaml.Marshal(a)
+	dataB, errB := yaml.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(dataA, dataB)
+}
+
+// ListenerConfig is a config of extra proxy listener.
+type ListenerConfig struct {
+	Name     string `yaml:"name"`
+	Port     int    `yaml:"port"`
+	ReadOnly bool   `yaml:"read_only"`
+}
+
+// HookConfig is a config of event hook, delivered as webhook or command with json payload.
+type HookConfig struct {
+	Events  []string `yaml:"events"`
+	Webhook string   `yaml:"webhook"`
+	Command string   `yaml:"command"`
+	Timeout int      `yaml:"timeout"`
+}
+
+// LogShipperConfig is a config of shipping audit and slow logs to elasticsearch or opensearch by bulk api.
+type LogShipperConfig struct {
+	URL           string `yaml:"url"`   // such as http://127.0.0.1:9200, empty is off.
+	Index         string `yaml:"index"` // prefix of daily indices and index template, default saashard.
+	User          string `yaml:"user"`
+	Password      string `yaml:"password"`
+	BatchSize     int    `yaml:"batch_size"`     // entries per bulk request.
+	FlushInterval int    `yaml:"flush_interval"` // seconds that batch is sent even if it's not full.
+	MaxBackoff    int    `yaml:"max_backoff"`    // seconds between retries at most, when bulk request fails.
+}
+
+// AuthzConfig is a config of external authorization, each statement is allowed or denied by webhook.
+type AuthzConfig struct {
+	Webhook    string `yaml:"webhook"`
+	Timeout    int    `yaml:"timeout"`     // milliseconds.
+	CacheTTL   int    `yaml:"cache_ttl"`   // seconds that decision is cached by user, client ip and fingerprint.
+	FailPolicy string `yaml:"fail_policy"` // closed or open, when webhook fails.
+}
+
 // HostConfig is a config of data host.
 type HostConfig struct {
 	Name             string   `yaml:"name"`
 	MaxConnNum       int      `yaml:"max_conn_num"`
 	DownAfterNoAlive int      `yaml:"down_after_noalive"`
 	PingInterval     int      `yaml:"ping_interval"`
+	TCPKeepAlive     int      `yaml:"tcp_keepalive"`
 	User             string   `yaml:"user"`
 	Password         string   `yaml:"password"`
 	Master           string   `yaml:"master"`
 	Slaves           []string `yaml:"slaves"`
+	Analytics        []string `yaml:"analytics"`
 }
 
 // NodeConfig is a config of data node.
@@ -86,15 +192,26 @@ type NodeConfig struct {
 
 // SchemaConfig is a config of schema.
 type SchemaConfig struct {
-	Name               string        `yaml:"name"`
-	User               string        `yaml:"user"`
-	Password           string        `yaml:"password"`
-	MaxRowCount        int           `yaml:"max_row_count"`
-	ShardKey           string        `yaml:"shard_key"`
-	ShardAlgo          string        `yaml:"shard_algo"`
-	Nodes              []string      `yaml:"nodes"`
-	CheckTableDisabled bool          `yaml:"check_table_disabled"`
-	Tables             []TableConfig `yaml:"tables"`
+	Name               string         `yaml:"name"`
+	User               string         `yaml:"user"`
+	Password           string         `yaml:"password"`
+	MaxRowCount        int            `yaml:"max_row_count"`
+	MaxRowCountUsers   []string       `yaml:"max_row_count_users"`
+	ShardKey           string         `yaml:"shard_key"`
+	ShardAlgo          string         `yaml:"shard_algo"`
+	ShardHashSeed      uint32         `yaml:"shard_hash_seed"`
+	ShardKeyCollation  string         `yaml:"shard_key_collation"`
+	ShardKeyNormalize  []string       `yaml:"shard_key_normalize"`
+	Nodes              []string       `yaml:"nodes"`
+	DatabasesPerNode   int            `yaml:"databases_per_node"` // physical databases of each node, such as db_0000 ... db_0031.
+	NodeWeights        map[string]int `yaml:"node_weights"`       // capacity weight of nodes, default is 1.
+	CheckTableDisabled bool           `yaml:"check_table_disabled"`
+	DiffSchema         string         `yaml:"diff_schema"`
+	MinHealthyNodes    int            `yaml:"min_healthy_nodes"`
+	Tables             []TableConfig  `yaml:"tables"`
+
+	// ExpandedNodes is expanded nodes of physical databases by configured node, if databases_per_node > 1.
+	ExpandedNodes map[string][]string `yaml:"-"`
 
 	tables map[string]*TableConfig
 }
@@ -113,6 +230,55 @@ func (schema *SchemaConfig) GetTables() map[string]*TableConfig {
 	return schema.tables
 }
 
+// NodeWeight get capacity weight of node, default is 1.
+func (schema *SchemaConfig) NodeWeight(node string) int {
+	if weight, ok := schema.NodeWeights[node]; ok {
+		return weight
+	}
+	return 1
+}
+
+// ExpandNodeNames map configured nodes, such as nodes of hint, to expanded nodes of physical databases,
+// if databases_per_node > 1. Other names are kept.
+func (schema *SchemaConfig) ExpandNodeNames(names []string) []string {
+	if len(schema.ExpandedNodes) == 0 {
+		return names
+	}
+	expanded := make([]string, 0, len(names))
+	for _, name := range names {
+		if nodes, ok := schema.ExpandedNodes[name]; ok {
+			expanded = append(expanded, nodes...)
+		} else {
+			expanded = append(expanded, name)
+		}
+	}
+	return expanded
+}
+
+// ConfiguredNodeName get configured node of expanded node, or name itself if it isn't expanded.
+func (schema *SchemaConfig) ConfiguredNodeName(name string) string {
+	for node, names := range schema.ExpandedNodes {
+		for _, expanded := range names {
+			if expanded == name {
+				return node
+			}
+		}
+	}
+	return name
+}
+
+// ConfiguredNodeCount is count of distinct configured nodes of names, physical databases of a node count once.
+func (schema *SchemaConfig) ConfiguredNodeCount(names []string) int {
+	if len(schema.ExpandedNodes) == 0 {
+		return len(names)
+	}
+	counted := make(map[string]bool, len(names))
+	for _, name := range names {
+		counted[schema.ConfiguredNodeName(name)] = true
+	}
+	return len(counted)
+}
+
 // ShardEnabled to use shard func.
 func (schema *SchemaConfig) ShardEnabled() bool {
 	return schema.ShardKey != ""
@@ -120,7 +286,15 @@ func (schema *SchemaConfig) ShardEnabled() bool {
 
 // TableConfig is a config of table
 type TableConfig struct {
-	Name string `yaml:"name"`
+	Name           string `yaml:"name"`
+	ShardKeyPolicy string `yaml:"shard_key_policy"`
+	DefaultNode    string `yaml:"default_node"`
+	ShardKeyDerive string `yaml:"shard_key_derive"`
+	SubShardKey    string `yaml:"sub_shard_key"`
+	SubShardAlgo   string `yaml:"sub_shard_algo"`
+	SubShardCount  int    `yaml:"sub_shard_count"`
+
+	PinNodes []string `yaml:"pin_nodes"` // pin table to node group, regardless of schema nodes.
 }
 
 // ParseConfigData is to parse config data.
@@ -199,17 +373,87 @@ func ParseConfigData(data []byte) (*Config, error) {
 	cfg.Schemas = newSchemas
 	newSchemas = nil
 
+	for _, schema := range cfg.Schemas {
+		for node, weight := range schema.NodeWeights {
+			if weight < 1 {
+				return nil, fmt.Errorf("weight %d of node '%s' in schema '%s' should be at least 1", weight, node, schema.Name)
+			}
+		}
+	}
+	expandNodeDatabases(&cfg)
+
 	return &cfg, nil
 }
 
-// ParseConfigFile is to parse config file.
-func ParseConfigFile(fileName string) (*Config, error) {
-	data, err := ioutil.ReadFile(fileName)
-	if err != nil {
-		return nil, err
+// expandNodeDatabases expand nodes of schema whose databases_per_node > 1, into a node of each physical database.
+// Node 'node1' of database 'db' is expanded into 'node1_0000' of database 'db_0000' and so on,
+// index is continuous across nodes of schema, so that shard key is hashed into all physical databases.
+func expandNodeDatabases(cfg *Config) {
+	nodes := make(map[string]NodeConfig)
+	for _, node := range cfg.Nodes {
+		nodes[node.Name] = node
 	}
+	for i := range cfg.Schemas {
+		schema := &cfg.Schemas[i]
+		if schema.DatabasesPerNode <= 1 {
+			continue
+		}
+		virtualNodes := make(map[string][]string)
+		newNodeNames := make([]string, 0, len(schema.Nodes)*schema.DatabasesPerNode)
+		for j, nodeName := range schema.Nodes {
+			node, ok := nodes[nodeName]
+			if !ok {
+				newNodeNames = append(newNodeNames, nodeName)
+				continue
+			}
+			for k := 0; k < schema.DatabasesPerNode; k++ {
+				index := j*schema.DatabasesPerNode + k
+				newNode := NodeConfig{
+					Name:     fmt.Sprintf("%s_%04d", node.Name, index),
+					Host:     node.Host,
+					Database: fmt.Sprintf("%s_%04d", node.Database, index),
+				}
+				if _, ok := nodes[newNode.Name]; !ok {
+					nodes[newNode.Name] = newNode
+					cfg.Nodes = append(cfg.Nodes, newNode)
+				}
+				virtualNodes[nodeName] = append(virtualNodes[nodeName], newNode.Name)
+				if weight, ok := schema.NodeWeights[nodeName]; ok {
+					schema.NodeWeights[newNode.Name] = weight
+				}
+				newNodeNames = append(newNodeNames, newNode.Name)
+			}
+			delete(schema.NodeWeights, nodeName)
+		}
+		schema.Nodes = newNodeNames
+		schema.ExpandedNodes = virtualNodes
 
-	return ParseConfigData(data)
+		tables := make([]TableConfig, len(schema.Tables))
+		for j, table := range schema.Tables {
+			if names, ok := virtualNodes[table.DefaultNode]; ok {
+				table.DefaultNode = names[0]
+			}
+			if len(table.PinNodes) > 0 {
+				pinNodes := make([]string, 0, len(table.PinNodes))
+				for _, nodeName := range table.PinNodes {
+					if names, ok := virtualNodes[nodeName]; ok {
+						pinNodes = append(pinNodes, names...)
+					} else {
+						pinNodes = append(pinNodes, nodeName)
+					}
+				}
+				table.PinNodes = pinNodes
+			}
+			tables[j] = table
+		}
+		schema.Tables = tables
+	}
+	cfg.nodes = nil
+}
+
+// ParseConfigFile is to parse config file.
+func ParseConfigFile(fileName string) (*Config, error) {
+	return ParseConfigFileWithEnv(fileName, "")
 }
 
 // WriteConfigFile is to write to config file.
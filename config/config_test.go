@@ -0,0 +1,89 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandNodeDatabases(t *testing.T) {
+	cfg := &Config{
+		Nodes: []NodeConfig{
+			{Name: "node1", Host: "host1", Database: "db_a"},
+			{Name: "node2", Host: "host2", Database: "db_b"},
+		},
+		Schemas: []SchemaConfig{{
+			Name:             "db",
+			ShardKey:         "tenant_id",
+			Nodes:            []string{"node1", "node2"},
+			DatabasesPerNode: 2,
+			NodeWeights:      map[string]int{"node2": 3},
+			Tables:           []TableConfig{{Name: "t", PinNodes: []string{"node2"}}},
+		}},
+	}
+	expandNodeDatabases(cfg)
+	schema := cfg.Schemas[0]
+	if want := []string{"node1_0000", "node1_0001", "node2_0002", "node2_0003"}; !reflect.DeepEqual(schema.Nodes, want) {
+		t.Errorf("Nodes = %v, want %v", schema.Nodes, want)
+	}
+	if node := cfg.GetNodes()["node2_0003"]; node == nil || node.Database != "db_b_0003" || node.Host != "host2" {
+		t.Errorf("GetNodes()[node2_0003] = %+v", node)
+	}
+	if weight := schema.NodeWeight("node2_0002"); weight != 3 {
+		t.Errorf("NodeWeight(node2_0002) = %d, want 3", weight)
+	}
+	if pinNodes := schema.GetTables()["t"].PinNodes; !reflect.DeepEqual(pinNodes, []string{"node2_0002", "node2_0003"}) {
+		t.Errorf("PinNodes = %v", pinNodes)
+	}
+
+	if got, want := schema.ExpandNodeNames([]string{"node2", "node1_0001", "other"}),
+		[]string{"node2_0002", "node2_0003", "node1_0001", "other"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpandNodeNames() = %v, want %v", got, want)
+	}
+	if got := schema.ConfiguredNodeName("node1_0001"); got != "node1" {
+		t.Errorf("ConfiguredNodeName(node1_0001) = %s, want node1", got)
+	}
+	cases := []struct {
+		names []string
+		want  int
+	}{
+		{schema.Nodes, 2},
+		{[]string{"node1_0000", "node1_0001"}, 1},
+		{[]string{"node1_0001", "node2_0002"}, 2},
+		{nil, 0},
+	}
+	for _, c := range cases {
+		if got := schema.ConfiguredNodeCount(c.names); got != c.want {
+			t.Errorf("ConfiguredNodeCount(%v) = %d, want %d", c.names, got, c.want)
+		}
+	}
+
+	plain := SchemaConfig{Nodes: []string{"node1", "node2"}}
+	if got := plain.ExpandNodeNames([]string{"node1"}); !reflect.DeepEqual(got, []string{"node1"}) {
+		t.Errorf("ExpandNodeNames() of schema without databases_per_node = %v", got)
+	}
+	if got := plain.ConfiguredNodeCount(plain.Nodes); got != 2 {
+		t.Errorf("ConfiguredNodeCount() of schema without databases_per_node = %d, want 2", got)
+	}
+}
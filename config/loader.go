@@ -0,0 +1,232 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-yaml/yaml"
+)
+
+// ${NAME}, ${NAME:-default} or ${file:/path/to/secret}.
+var regInterpolation = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// OverlayFileName name of environment overlay, such as "ss.prod.yaml" of "ss.yaml".
+func OverlayFileName(fileName, env string) string {
+	ext := filepath.Ext(fileName)
+	return strings.TrimSuffix(fileName, ext) + "." + env + ext
+}
+
+// ParseConfigFileWithEnv is to parse config file, merged with overlay of env if env is not empty.
+// Maps are merged recursively, and lists of named items (nodes, hosts, schemas, tables) are merged by name.
+func ParseConfigFileWithEnv(fileName, env string) (*Config, error) {
+	base, err := readConfigMap(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if env != "" {
+		overlay, err := readConfigMap(OverlayFileName(fileName, env))
+		if err != nil {
+			return nil, err
+		}
+		base = mergeConfigValue(base, overlay).(map[interface{}]interface{})
+	}
+
+	data, err := yaml.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfigData(data)
+}
+
+// readConfigMap read config file, validated, then with environment variables and secret files interpolated.
+func readConfigMap(fileName string) (map[interface{}]interface{}, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate on its own, so that errors have line number of this file.
+	// unknown or misspelled keys are rejected, type errors of values to interpolate are skipped.
+	var cfg Config
+	if err = skipInterpolationErrors(yaml.UnmarshalStrict(data, &cfg)); err != nil {
+		return nil, fmt.Errorf("%s: %v", fileName, err)
+	}
+	values := make(map[interface{}]interface{})
+	if err = yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("%s: %v", fileName, err)
+	}
+	if _, err = interpolate(fileName, strings.Split(string(data), "\n"), values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// skipInterpolationErrors remove type errors of values with ${...}, such as int of ${PORT}.
+func skipInterpolationErrors(err error) error {
+	typeErr, ok := err.(*yaml.TypeError)
+	if !ok {
+		return err
+	}
+	var errs []string
+	for _, e := range typeErr.Errors {
+		if !strings.Contains(e, "`${") {
+			errs = append(errs, e)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &yaml.TypeError{Errors: errs}
+}
+
+// interpolate replace ${NAME}, ${NAME:-default} with environment variable, and ${file:path} with content of secret file,
+// in string values of parsed config, so that values could have any character, such as '#', ': ', quotes or newlines.
+// Value that is only a reference is typed as int, float or bool if it is, such as port of ${PORT}.
+// Errors have number of the line that the reference is at.
+func interpolate(fileName string, lines []string, value interface{}) (interface{}, error) {
+	var err error
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		for key, item := range v {
+			if v[key], err = interpolate(fileName, lines, item); err != nil {
+				return nil, err
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if v[i], err = interpolate(fileName, lines, item); err != nil {
+				return nil, err
+			}
+		}
+	case string:
+		interpolated := regInterpolation.ReplaceAllStringFunc(v, func(ref string) string {
+			name := ref[2 : len(ref)-1]
+			if strings.HasPrefix(name, "file:") {
+				secret, e := ioutil.ReadFile(strings.TrimPrefix(name, "file:"))
+				if e != nil && err == nil {
+					err = fmt.Errorf("%s:%d: read secret of %s: %v", fileName, lineOf(lines, ref), ref, e)
+				}
+				return strings.TrimRight(string(secret), "\r\n")
+			}
+			defaultValue, hasDefault := "", false
+			if pos := strings.Index(name, ":-"); pos >= 0 {
+				name, defaultValue, hasDefault = name[:pos], name[pos+2:], true
+			}
+			if value, ok := os.LookupEnv(name); ok && (value != "" || !hasDefault) {
+				return value
+			}
+			if !hasDefault && err == nil {
+				err = fmt.Errorf("%s:%d: environment variable '%s' is not set", fileName, lineOf(lines, ref), name)
+			}
+			return defaultValue
+		})
+		if err != nil {
+			return nil, err
+		}
+		if interpolated != v && regInterpolation.FindString(v) == v {
+			return typedValue(interpolated), nil
+		}
+		return interpolated, nil
+	}
+	return value, nil
+}
+
+// lineOf reference, it's the first line except comments, 0 if not found.
+func lineOf(lines []string, ref string) int {
+	for i, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "#") && strings.Contains(line, ref) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// typedValue of int, float or bool in canonical form, other value is string.
+func typedValue(value string) interface{} {
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil && strconv.FormatInt(n, 10) == value {
+		return int(n)
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil && strconv.FormatFloat(f, 'f', -1, 64) == value {
+		return f
+	}
+	if value == "true" || value == "false" {
+		return value == "true"
+	}
+	return value
+}
+
+// mergeConfigValue merge overlay into base.
+func mergeConfigValue(base, overlay interface{}) interface{} {
+	switch overlay := overlay.(type) {
+	case map[interface{}]interface{}:
+		baseMap, ok := base.(map[interface{}]interface{})
+		if !ok {
+			return overlay
+		}
+		for key, value := range overlay {
+			baseMap[key] = mergeConfigValue(baseMap[key], value)
+		}
+		return baseMap
+	case []interface{}:
+		baseList, ok := base.([]interface{})
+		if !ok || !isNamedList(baseList) || !isNamedList(overlay) {
+			return overlay
+		}
+		for _, item := range overlay {
+			name := item.(map[interface{}]interface{})["name"]
+			merged := false
+			for i, baseItem := range baseList {
+				if baseItem.(map[interface{}]interface{})["name"] == name {
+					baseList[i] = mergeConfigValue(baseItem, item)
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				baseList = append(baseList, item)
+			}
+		}
+		return baseList
+	}
+	return overlay
+}
+
+func isNamedList(list []interface{}) bool {
+	for _, item := range list {
+		itemMap, ok := item.(map[interface{}]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok = itemMap["name"]; !ok {
+			return false
+		}
+	}
+	return true
+}
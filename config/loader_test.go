@@ -0,0 +1,99 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/go-yaml/yaml"
+)
+
+func TestInterpolate(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := ioutil.WriteFile(secretFile, []byte("p#ss: 'x\"\ny\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_PASSWORD", "a: b # c")
+	t.Setenv("TEST_PORT", "6051")
+	t.Setenv("TEST_EMPTY", "")
+	lines := []string{
+		"# admin_password : ${TEST_MISSING}",
+		"admin_password : ${TEST_PASSWORD}",
+		"proxy_port : ${TEST_PORT}",
+		"probe_password : ${file:" + secretFile + "}",
+		"nodes :",
+		"- name : node_${TEST_EMPTY:-1}",
+		"  database : '${TEST_PORT}_db'",
+		"charset : ${TEST_MISSING}",
+	}
+	values := map[interface{}]interface{}{
+		"admin_password": "${TEST_PASSWORD}",
+		"proxy_port":     "${TEST_PORT}",
+		"probe_password": "${file:" + secretFile + "}",
+		"nodes": []interface{}{
+			map[interface{}]interface{}{"name": "node_${TEST_EMPTY:-1}", "database": "${TEST_PORT}_db"},
+		},
+		"max_fanout": 8,
+	}
+	if _, err := interpolate("ss.yaml", lines, values); err != nil {
+		t.Fatal(err)
+	}
+	want := map[interface{}]interface{}{
+		"admin_password": "a: b # c",
+		"proxy_port":     6051,
+		"probe_password": "p#ss: 'x\"\ny",
+		"nodes": []interface{}{
+			map[interface{}]interface{}{"name": "node_1", "database": "6051_db"},
+		},
+		"max_fanout": 8,
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("interpolate() = %v, want %v", values, want)
+	}
+
+	// line of reference is reported, comment isn't.
+	_, err := interpolate("ss.yaml", lines, map[interface{}]interface{}{"charset": "${TEST_MISSING}"})
+	if want := "ss.yaml:8: environment variable 'TEST_MISSING' is not set"; err == nil || err.Error() != want {
+		t.Errorf("interpolate() error = %v, want %s", err, want)
+	}
+}
+
+func TestSkipInterpolationErrors(t *testing.T) {
+	err := skipInterpolationErrors(&yaml.TypeError{Errors: []string{
+		"line 3: cannot unmarshal !!str `${TEST_...` into int",
+		"line 5: field proxy_prot not found in type config.Config",
+	}})
+	typeErr, ok := err.(*yaml.TypeError)
+	if !ok || !reflect.DeepEqual(typeErr.Errors, []string{"line 5: field proxy_prot not found in type config.Config"}) {
+		t.Errorf("skipInterpolationErrors() = %v", err)
+	}
+	if err = skipInterpolationErrors(&yaml.TypeError{Errors: []string{"line 3: cannot unmarshal !!str `${PORT}` into int"}}); err != nil {
+		t.Errorf("skipInterpolationErrors() = %v, want nil", err)
+	}
+	if err = skipInterpolationErrors(nil); err != nil {
+		t.Errorf("skipInterpolationErrors(nil) = %v, want nil", err)
+	}
+}
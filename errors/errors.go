@@ -33,8 +33,10 @@ var (
 	ErrNoDefaultNode = errors.New("no default node")
 	ErrNoMasterDB    = errors.New("no master database")
 	ErrNoSlaveDB     = errors.New("no slave database")
+	ErrNoAnalyticsDB = errors.New("no analytics database")
 	ErrNoDatabase    = errors.New("no database")
 	ErrNoIdleConn    = errors.New("exceed max conn num")
+	ErrNoVariable    = errors.New("unknown system variable")
 
 	ErrNoDataHost = errors.New("no data host")
 	ErrNoDataNode = errors.New("no data node")
@@ -55,20 +57,46 @@ var (
 	ErrConnIsNil     = errors.New("connection is nil")
 	ErrBadConn       = errors.New("connection was bad")
 	ErrIgnoreSQL     = errors.New("ignore this sql")
+	ErrFaultInjected = errors.New("parse error injected by saashard_fault_parse_error")
+	ErrMasterDrill   = errors.New("master is unreachable by failover drill")
 
 	ErrAddressNull     = errors.New("address is nil")
 	ErrInvalidArgument = errors.New("argument is invalid")
 	ErrInvalidCharset  = errors.New("charset is invalid")
+	ErrTLSDisabled     = errors.New("tls is disabled")
 	ErrCmdUnsupport    = errors.New("command unsupport")
+	ErrLocalInFile     = errors.New("load data local infile is not allowed")
 
 	ErrSelectInInsert   = errors.New("select in insert not allowed")
 	ErrTransInMulti     = errors.New("transaction in multi node")
 	ErrExecInMulti      = errors.New("execute in multi node")
+	ErrUserVarInMulti   = errors.New("user variable in multi node")
+	ErrExceedMaxFanout  = errors.New("exceed max fan-out node count")
+	ErrExceedMemory     = errors.New("exceed memory budget of buffered rows")
+	ErrExceedSpillSize  = errors.New("exceed max size of spilled rows")
+	ErrCloneRunning     = errors.New("clone job is running")
+	ErrDrillRunning     = errors.New("failover drill is running")
+	ErrNoDrill          = errors.New("no failover drill is running")
+	ErrReadOnlyListener = errors.New("write statement is not allowed on read-only port or by read-only user")
+	ErrDestructiveDDL   = errors.New("destructive ddl is rejected, use hint /* saashard:allow_destructive */ to force it")
 	ErrColsLenNotMatch  = errors.New("insert or replace cols and values length not match")
 	ErrInsertColumnsKey = errors.New("no shard key in insert column list")
 	ErrInsertValuesKey  = errors.New("no shard key or key has different values in insert values list")
+	ErrNullShardKey     = errors.New("shard key is null or missing in insert values list")
+	ErrShardKeyDerive   = errors.New("shard key derive expression is not supported")
 	ErrUpdateKey        = errors.New("shard key in update expression")
 	ErrWhereOrJoinOnKey = errors.New("no shard key or key has different values in where or join on expression")
+	ErrSubShardKey      = errors.New("no sub shard key or key has different values")
+	ErrInsertSelectKey  = errors.New("insert and select of insert ... select are not in the same shard")
+	ErrCrossNodeGroup   = errors.New("tables pinned to different node groups in one statement")
+	ErrPrepareFromVar   = errors.New("prepare from user variable is not supported")
+	ErrCallNode         = errors.New("call in sharded schema should be hinted with single node by /*!saashard nodes=node1 */")
+	ErrGrantDenied      = errors.New("grant and revoke are not allowed, unless allow_grant is true")
+	ErrGrantLevel       = errors.New("grant and revoke should be on current schema or its tables")
+	ErrLoadDataKey      = errors.New("load data in sharded schema should set shard key by literal in SET clause, or be hinted with single node")
+	ErrLockDenied       = errors.New("lock tables is not allowed, unless allow_lock_tables is true")
+	ErrLockNode         = errors.New("lock tables in sharded schema should be hinted with single node by /*!saashard nodes=node1 */")
+	ErrOrderedDMLMulti  = errors.New("order by or limit of update and delete should be in single shard by shard key in where expression, ordering across shards is not supported")
 
 	ErrMustPositiveIntegerInModShard = errors.New("shard key must positive integer when use mod shard algorithm")
 
@@ -81,6 +109,11 @@ var (
 	ErrDateRangeCount   = errors.New("date range count is not equal")
 	ErrSlaveExist       = errors.New("slave has exist")
 	ErrSlaveNotExist    = errors.New("slave has not exist")
+	ErrNotReplica       = errors.New("not a replica")
+	ErrReadOnlyMaster   = errors.New("master is read only")
+	ErrNotReady         = errors.New("saashard is not ready, healthy nodes of schema are less than min_healthy_nodes")
+	ErrProbeOnly        = errors.New("probe user could only ping")
+	ErrPreflight        = errors.New("preflight check against backends failed")
 
 	ErrMalformPacket = errors.New("Malform packet error")
 	ErrTxDone        = errors.New("Transaction has already been committed or rolled back")
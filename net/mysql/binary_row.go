@@ -0,0 +1,493 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mysql
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/sqlparser/sqltypes"
+)
+
+// binaryRowNullOffset is offset of null bitmap of binary row, the first 2 bits are reserved.
+// Null bitmap of COM_STMT_EXECUTE parameters has no offset.
+const binaryRowNullOffset = 2
+
+// NullBitmapLen is length of null bitmap for count of values.
+func NullBitmapLen(count, offset int) int {
+	return (count + offset + 7) >> 3
+}
+
+// IsNullBit check value at index is NULL in null bitmap.
+func IsNullBit(nullBitmap []byte, index, offset int) bool {
+	pos := index + offset
+	return nullBitmap[pos>>3]&(1<<(uint(pos)&7)) > 0
+}
+
+// SetNullBit mark value at index as NULL in null bitmap.
+func SetNullBit(nullBitmap []byte, index, offset int) {
+	pos := index + offset
+	nullBitmap[pos>>3] |= 1 << (uint(pos) & 7)
+}
+
+// EncodeBinaryRow encode values as binary row of prepared statement result, nil is NULL.
+func EncodeBinaryRow(fields []*Field, values []interface{}) ([]byte, error) {
+	if len(values) != len(fields) {
+		return nil, fmt.Errorf("binary row has %d values, but %d fields", len(values), len(fields))
+	}
+	bitmapLen := NullBitmapLen(len(fields), binaryRowNullOffset)
+	data := make([]byte, 1+bitmapLen, 1+bitmapLen+8*len(fields))
+	data[0] = OK_HEADER
+	var err error
+	for i, v := range values {
+		if sv, ok := v.(sqltypes.Value); v == nil || ok && sv.IsNull() {
+			SetNullBit(data[1:1+bitmapLen], i, binaryRowNullOffset)
+			continue
+		}
+		if data, err = AppendBinaryValue(data, fields[i].ColumnType, fields[i].Flags&UNSIGNED_FLAG > 0, v); err != nil {
+			return nil, fmt.Errorf("field %s: %v", fields[i].Name, err)
+		}
+	}
+	return data, nil
+}
+
+// DecodeBinaryRow decode binary row of prepared statement result.
+// Integers are int64 or uint64 if unsigned, FLOAT and DOUBLE are float64, others are text as []byte.
+func DecodeBinaryRow(fields []*Field, data []byte) ([]interface{}, error) {
+	values, _, _, err := parseAsBinaryRow(data, fields)
+	return values, err
+}
+
+// AppendBinaryValue append value in binary protocol of column type.
+// Integers and floats accept go numbers or text, temporal types accept time.Time, time.Duration or text,
+// others accept []byte, string, numbers, or sqltypes.Value.
+func AppendBinaryValue(data []byte, columnType byte, unsigned bool, v interface{}) ([]byte, error) {
+	switch columnType {
+	case MYSQL_TYPE_NULL:
+		return data, nil
+
+	case MYSQL_TYPE_TINY, MYSQL_TYPE_SHORT, MYSQL_TYPE_YEAR, MYSQL_TYPE_INT24, MYSQL_TYPE_LONG, MYSQL_TYPE_LONGLONG:
+		var n uint64
+		if unsigned {
+			u, err := toUint64Value(v)
+			if err != nil {
+				return nil, err
+			}
+			n = u
+		} else {
+			i, err := toInt64Value(v)
+			if err != nil {
+				return nil, err
+			}
+			n = uint64(i)
+		}
+		switch columnType {
+		case MYSQL_TYPE_TINY:
+			return append(data, byte(n)), nil
+		case MYSQL_TYPE_SHORT, MYSQL_TYPE_YEAR:
+			return append(data, Uint16ToBytes(uint16(n))...), nil
+		case MYSQL_TYPE_INT24, MYSQL_TYPE_LONG:
+			return append(data, Uint32ToBytes(uint32(n))...), nil
+		}
+		return append(data, Uint64ToBytes(n)...), nil
+
+	case MYSQL_TYPE_FLOAT, MYSQL_TYPE_DOUBLE:
+		f, err := toFloat64Value(v)
+		if err != nil {
+			return nil, err
+		}
+		if columnType == MYSQL_TYPE_FLOAT {
+			return append(data, Uint32ToBytes(math.Float32bits(float32(f)))...), nil
+		}
+		return append(data, Uint64ToBytes(math.Float64bits(f))...), nil
+
+	case MYSQL_TYPE_DATE, MYSQL_TYPE_NEWDATE, MYSQL_TYPE_DATETIME, MYSQL_TYPE_TIMESTAMP,
+		MYSQL_TYPE_DATETIME2, MYSQL_TYPE_TIMESTAMP2:
+		var parts [7]int
+		if t, ok := v.(time.Time); ok {
+			parts = [7]int{t.Year(), int(t.Month()), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond() / 1000}
+		} else {
+			var err error
+			if parts, err = parseDatetimeParts(string(toBytesValue(v))); err != nil {
+				return nil, err
+			}
+		}
+		return appendBinaryDatetime(data, parts, columnType == MYSQL_TYPE_DATE || columnType == MYSQL_TYPE_NEWDATE), nil
+
+	case MYSQL_TYPE_TIME, MYSQL_TYPE_TIME2:
+		var negative bool
+		var micros int64
+		if d, ok := v.(time.Duration); ok {
+			negative, micros = d < 0, int64(d/time.Microsecond)
+		} else {
+			var err error
+			if negative, micros, err = parseTimeMicros(string(toBytesValue(v))); err != nil {
+				return nil, err
+			}
+		}
+		return appendBinaryTime(data, negative, micros), nil
+
+	case MYSQL_TYPE_DECIMAL, MYSQL_TYPE_NEWDECIMAL, MYSQL_TYPE_VARCHAR,
+		MYSQL_TYPE_BIT, MYSQL_TYPE_ENUM, MYSQL_TYPE_SET, MYSQL_TYPE_TINY_BLOB,
+		MYSQL_TYPE_MEDIUM_BLOB, MYSQL_TYPE_LONG_BLOB, MYSQL_TYPE_BLOB,
+		MYSQL_TYPE_VAR_STRING, MYSQL_TYPE_STRING, MYSQL_TYPE_GEOMETRY, MYSQL_TYPE_JSON:
+		return append(data, StringToLenencStr(toBytesValue(v))...), nil
+	}
+	return nil, fmt.Errorf("Stmt Unknown FieldType %d", columnType)
+}
+
+// ReadBinaryValue read value in binary protocol of column type, n is length of bytes read.
+func ReadBinaryValue(data []byte, columnType byte, unsigned bool) (v interface{}, n int, err error) {
+	switch columnType {
+	case MYSQL_TYPE_NULL:
+		return nil, 0, nil
+
+	case MYSQL_TYPE_TINY:
+		if len(data) < 1 {
+			return nil, 0, errors.ErrMalformPacket
+		}
+		if unsigned {
+			return uint64(data[0]), 1, nil
+		}
+		return int64(int8(data[0])), 1, nil
+
+	case MYSQL_TYPE_SHORT, MYSQL_TYPE_YEAR:
+		if len(data) < 2 {
+			return nil, 0, errors.ErrMalformPacket
+		}
+		u := binary.LittleEndian.Uint16(data[:2])
+		if unsigned {
+			return uint64(u), 2, nil
+		}
+		return int64(int16(u)), 2, nil
+
+	case MYSQL_TYPE_INT24, MYSQL_TYPE_LONG:
+		if len(data) < 4 {
+			return nil, 0, errors.ErrMalformPacket
+		}
+		u := binary.LittleEndian.Uint32(data[:4])
+		if unsigned {
+			return uint64(u), 4, nil
+		}
+		return int64(int32(u)), 4, nil
+
+	case MYSQL_TYPE_LONGLONG:
+		if len(data) < 8 {
+			return nil, 0, errors.ErrMalformPacket
+		}
+		u := binary.LittleEndian.Uint64(data[:8])
+		if unsigned {
+			return u, 8, nil
+		}
+		return int64(u), 8, nil
+
+	case MYSQL_TYPE_FLOAT:
+		if len(data) < 4 {
+			return nil, 0, errors.ErrMalformPacket
+		}
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(data[:4]))), 4, nil
+
+	case MYSQL_TYPE_DOUBLE:
+		if len(data) < 8 {
+			return nil, 0, errors.ErrMalformPacket
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(data[:8])), 8, nil
+
+	case MYSQL_TYPE_DATE, MYSQL_TYPE_NEWDATE, MYSQL_TYPE_DATETIME, MYSQL_TYPE_TIMESTAMP,
+		MYSQL_TYPE_DATETIME2, MYSQL_TYPE_TIMESTAMP2:
+		if len(data) < 1 || len(data) < 1+int(data[0]) {
+			return nil, 0, errors.ErrMalformPacket
+		}
+		text, err := formatBinaryDatetime(data[1:1+int(data[0])], columnType == MYSQL_TYPE_DATE || columnType == MYSQL_TYPE_NEWDATE)
+		return text, 1 + int(data[0]), err
+
+	case MYSQL_TYPE_TIME, MYSQL_TYPE_TIME2:
+		if len(data) < 1 || len(data) < 1+int(data[0]) {
+			return nil, 0, errors.ErrMalformPacket
+		}
+		text, err := formatBinaryTime(data[1 : 1+int(data[0])])
+		return text, 1 + int(data[0]), err
+
+	case MYSQL_TYPE_DECIMAL, MYSQL_TYPE_NEWDECIMAL, MYSQL_TYPE_VARCHAR,
+		MYSQL_TYPE_BIT, MYSQL_TYPE_ENUM, MYSQL_TYPE_SET, MYSQL_TYPE_TINY_BLOB,
+		MYSQL_TYPE_MEDIUM_BLOB, MYSQL_TYPE_LONG_BLOB, MYSQL_TYPE_BLOB,
+		MYSQL_TYPE_VAR_STRING, MYSQL_TYPE_STRING, MYSQL_TYPE_GEOMETRY, MYSQL_TYPE_JSON:
+	default:
+		return nil, 0, fmt.Errorf("Stmt Unknown FieldType %d", columnType)
+	}
+
+	v, isNull, n, err := LenencStrToString(data)
+	if err != nil {
+		return nil, 0, err
+	} else if n > len(data) {
+		return nil, 0, errors.ErrMalformPacket
+	} else if isNull {
+		return nil, n, nil
+	}
+	return v, n, nil
+}
+
+// appendBinaryDatetime append length and the shortest form of year, month, day, hour, minute, second and microsecond.
+func appendBinaryDatetime(data []byte, parts [7]int, dateOnly bool) []byte {
+	length := 0
+	if parts[6] != 0 && !dateOnly {
+		length = 11
+	} else if (parts[3] != 0 || parts[4] != 0 || parts[5] != 0) && !dateOnly {
+		length = 7
+	} else if parts[0] != 0 || parts[1] != 0 || parts[2] != 0 {
+		length = 4
+	}
+	data = append(data, byte(length))
+	if length == 0 {
+		return data
+	}
+	data = append(data, Uint16ToBytes(uint16(parts[0]))...)
+	data = append(data, byte(parts[1]), byte(parts[2]))
+	if length == 4 {
+		return data
+	}
+	data = append(data, byte(parts[3]), byte(parts[4]), byte(parts[5]))
+	if length == 7 {
+		return data
+	}
+	return append(data, Uint32ToBytes(uint32(parts[6]))...)
+}
+
+// appendBinaryTime append length, sign, days, hour, minute, second and microsecond of the shortest form.
+func appendBinaryTime(data []byte, negative bool, micros int64) []byte {
+	if micros < 0 {
+		micros = -micros
+	}
+	fraction := micros % 1000000
+	seconds := micros / 1000000
+	length := 0
+	if fraction != 0 {
+		length = 12
+	} else if seconds != 0 {
+		length = 8
+	}
+	data = append(data, byte(length))
+	if length == 0 {
+		return data
+	}
+	var sign byte
+	if negative {
+		sign = 1
+	}
+	data = append(data, sign)
+	data = append(data, Uint32ToBytes(uint32(seconds/86400))...)
+	data = append(data, byte(seconds/3600%24), byte(seconds/60%60), byte(seconds%60))
+	if length == 8 {
+		return data
+	}
+	return append(data, Uint32ToBytes(uint32(fraction))...)
+}
+
+func formatBinaryDatetime(data []byte, dateOnly bool) ([]byte, error) {
+	var parts [7]int
+	switch len(data) {
+	case 11:
+		parts[6] = int(binary.LittleEndian.Uint32(data[7:11]))
+		fallthrough
+	case 7:
+		parts[3], parts[4], parts[5] = int(data[4]), int(data[5]), int(data[6])
+		fallthrough
+	case 4:
+		parts[0], parts[1], parts[2] = int(binary.LittleEndian.Uint16(data[:2])), int(data[2]), int(data[3])
+	case 0:
+	default:
+		return nil, fmt.Errorf("invalid datetime packet length %d", len(data))
+	}
+	text := fmt.Sprintf("%04d-%02d-%02d", parts[0], parts[1], parts[2])
+	if dateOnly {
+		return []byte(text), nil
+	}
+	text += fmt.Sprintf(" %02d:%02d:%02d", parts[3], parts[4], parts[5])
+	if len(data) == 11 {
+		text += fmt.Sprintf(".%06d", parts[6])
+	}
+	return []byte(text), nil
+}
+
+func formatBinaryTime(data []byte) ([]byte, error) {
+	switch len(data) {
+	case 0:
+		return []byte("00:00:00"), nil
+	case 8, 12:
+	default:
+		return nil, fmt.Errorf("invalid time packet length %d", len(data))
+	}
+	var sign string
+	if data[0] == 1 {
+		sign = "-"
+	}
+	hours := uint64(binary.LittleEndian.Uint32(data[1:5]))*24 + uint64(data[5])
+	text := fmt.Sprintf("%s%02d:%02d:%02d", sign, hours, data[6], data[7])
+	if len(data) == 12 {
+		text += fmt.Sprintf(".%06d", binary.LittleEndian.Uint32(data[8:12]))
+	}
+	return []byte(text), nil
+}
+
+// parseDatetimeParts parse 'YYYY-MM-DD[ hh:mm:ss[.ffffff]]' as year, month, day, hour, minute, second and microsecond.
+func parseDatetimeParts(s string) (parts [7]int, err error) {
+	s = strings.TrimSpace(s)
+	date, clock := s, ""
+	if i := strings.IndexAny(s, " T"); i >= 0 {
+		date, clock = s[:i], s[i+1:]
+	}
+	ymd := strings.Split(date, "-")
+	if len(ymd) != 3 {
+		return parts, fmt.Errorf("invalid datetime '%s'", s)
+	}
+	for i, text := range ymd {
+		if parts[i], err = strconv.Atoi(text); err != nil || parts[i] < 0 {
+			return parts, fmt.Errorf("invalid datetime '%s'", s)
+		}
+	}
+	if len(clock) > 0 {
+		negative, micros, err := parseTimeMicros(clock)
+		if err != nil || negative || micros >= 86400000000 {
+			return parts, fmt.Errorf("invalid datetime '%s'", s)
+		}
+		seconds := int(micros / 1000000)
+		parts[3], parts[4], parts[5], parts[6] = seconds/3600, seconds/60%60, seconds%60, int(micros%1000000)
+	}
+	return parts, nil
+}
+
+// parseTimeMicros parse '[-]hhh:mm:ss[.ffffff]' as microseconds.
+func parseTimeMicros(s string) (negative bool, micros int64, err error) {
+	s = strings.TrimSpace(s)
+	text := s
+	if negative = strings.HasPrefix(text, "-"); negative {
+		text = text[1:]
+	}
+	var fraction int64
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		digits := text[i+1:]
+		if len(digits) == 0 || len(digits) > 6 {
+			return false, 0, fmt.Errorf("invalid time '%s'", s)
+		}
+		if fraction, err = strconv.ParseInt(digits+strings.Repeat("0", 6-len(digits)), 10, 64); err != nil || fraction < 0 {
+			return false, 0, fmt.Errorf("invalid time '%s'", s)
+		}
+		text = text[:i]
+	}
+	hms := strings.Split(text, ":")
+	if len(hms) != 3 {
+		return false, 0, fmt.Errorf("invalid time '%s'", s)
+	}
+	var seconds int64
+	for _, part := range hms {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return false, 0, fmt.Errorf("invalid time '%s'", s)
+		}
+		seconds = seconds*60 + n
+	}
+	micros = seconds*1000000 + fraction
+	if negative {
+		micros = -micros
+	}
+	return negative, micros, nil
+}
+
+func toInt64Value(v interface{}) (int64, error) {
+	switch x := v.(type) {
+	case int:
+		return int64(x), nil
+	case int8:
+		return int64(x), nil
+	case int16:
+		return int64(x), nil
+	case int32:
+		return int64(x), nil
+	case int64:
+		return x, nil
+	case uint:
+		return int64(x), nil
+	case uint8:
+		return int64(x), nil
+	case uint16:
+		return int64(x), nil
+	case uint32:
+		return int64(x), nil
+	case uint64:
+		return int64(x), nil
+	case bool:
+		if x {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return strconv.ParseInt(string(toBytesValue(v)), 10, 64)
+}
+
+func toUint64Value(v interface{}) (uint64, error) {
+	switch x := v.(type) {
+	case string, []byte, sqltypes.Value:
+		return strconv.ParseUint(string(toBytesValue(x)), 10, 64)
+	}
+	n, err := toInt64Value(v)
+	return uint64(n), err
+}
+
+func toFloat64Value(v interface{}) (float64, error) {
+	switch x := v.(type) {
+	case float32:
+		return float64(x), nil
+	case float64:
+		return x, nil
+	case string, []byte, sqltypes.Value:
+		return strconv.ParseFloat(string(toBytesValue(x)), 64)
+	}
+	if u, ok := v.(uint64); ok {
+		return float64(u), nil
+	}
+	n, err := toInt64Value(v)
+	return float64(n), err
+}
+
+func toBytesValue(v interface{}) []byte {
+	switch x := v.(type) {
+	case []byte:
+		return x
+	case string:
+		return []byte(x)
+	case sqltypes.Value:
+		return x.Raw()
+	case float32:
+		return strconv.AppendFloat(nil, float64(x), 'f', -1, 32)
+	case float64:
+		return strconv.AppendFloat(nil, x, 'f', -1, 64)
+	case time.Time:
+		return []byte(x.Format("2006-01-02 15:04:05.999999"))
+	}
+	return []byte(fmt.Sprint(v))
+}
@@ -0,0 +1,175 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mysql
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBinaryValue(t *testing.T) {
+	cases := []struct {
+		columnType byte
+		unsigned   bool
+		in         interface{}
+		want       interface{}
+		length     int
+	}{
+		{MYSQL_TYPE_TINY, false, -1, int64(-1), 1},
+		{MYSQL_TYPE_TINY, true, uint8(255), uint64(255), 1},
+		{MYSQL_TYPE_SHORT, false, "-32768", int64(-32768), 2},
+		{MYSQL_TYPE_SHORT, true, 65535, uint64(65535), 2},
+		{MYSQL_TYPE_YEAR, true, 2016, uint64(2016), 2},
+		{MYSQL_TYPE_INT24, false, -8388608, int64(-8388608), 4},
+		{MYSQL_TYPE_LONG, false, int32(-2147483648), int64(-2147483648), 4},
+		{MYSQL_TYPE_LONG, true, uint32(4294967295), uint64(4294967295), 4},
+		{MYSQL_TYPE_LONGLONG, false, int64(-9223372036854775808), int64(-9223372036854775808), 8},
+		{MYSQL_TYPE_LONGLONG, true, "18446744073709551615", uint64(18446744073709551615), 8},
+		{MYSQL_TYPE_FLOAT, false, float32(-1.5), float64(-1.5), 4},
+		{MYSQL_TYPE_DOUBLE, false, "3.25", float64(3.25), 8},
+		{MYSQL_TYPE_NEWDECIMAL, false, "-12345.678900", []byte("-12345.678900"), 14},
+		{MYSQL_TYPE_VAR_STRING, false, "hello", []byte("hello"), 6},
+		{MYSQL_TYPE_BLOB, false, bytes.Repeat([]byte{'x'}, 300), bytes.Repeat([]byte{'x'}, 300), 303},
+		{MYSQL_TYPE_JSON, false, `{"a": [1, 2]}`, []byte(`{"a": [1, 2]}`), 14},
+		{MYSQL_TYPE_BIT, false, []byte{0x01, 0x02}, []byte{0x01, 0x02}, 3},
+		{MYSQL_TYPE_ENUM, false, "a", []byte("a"), 2},
+		{MYSQL_TYPE_NULL, false, 1, nil, 0},
+		{MYSQL_TYPE_DATE, false, "0000-00-00", []byte("0000-00-00"), 1},
+		{MYSQL_TYPE_DATE, false, "2016-01-02 03:04:05", []byte("2016-01-02"), 5},
+		{MYSQL_TYPE_DATETIME, false, "0000-00-00 00:00:00", []byte("0000-00-00 00:00:00"), 1},
+		{MYSQL_TYPE_DATETIME, false, "2016-01-02", []byte("2016-01-02 00:00:00"), 5},
+		{MYSQL_TYPE_DATETIME, false, "2016-01-02 03:04:05", []byte("2016-01-02 03:04:05"), 8},
+		{MYSQL_TYPE_TIMESTAMP, false, "2016-01-02 03:04:05.000123", []byte("2016-01-02 03:04:05.000123"), 12},
+		{MYSQL_TYPE_DATETIME, false, time.Date(2016, 1, 2, 3, 4, 5, 500000000, time.UTC), []byte("2016-01-02 03:04:05.500000"), 12},
+		{MYSQL_TYPE_TIME, false, "00:00:00", []byte("00:00:00"), 1},
+		{MYSQL_TYPE_TIME, false, "12:34:56", []byte("12:34:56"), 9},
+		{MYSQL_TYPE_TIME, false, "-838:59:59", []byte("-838:59:59"), 9},
+		{MYSQL_TYPE_TIME, false, "-01:02:03.5", []byte("-01:02:03.500000"), 13},
+		{MYSQL_TYPE_TIME, false, -(50*time.Hour + time.Second), []byte("-50:00:01"), 9},
+	}
+	for _, c := range cases {
+		data, err := AppendBinaryValue(nil, c.columnType, c.unsigned, c.in)
+		if err != nil {
+			t.Errorf("AppendBinaryValue(%d, %v) error: %v", c.columnType, c.in, err)
+			continue
+		}
+		if len(data) != c.length {
+			t.Errorf("AppendBinaryValue(%d, %v) length = %d, want %d", c.columnType, c.in, len(data), c.length)
+		}
+		got, n, err := ReadBinaryValue(data, c.columnType, c.unsigned)
+		if err != nil {
+			t.Errorf("ReadBinaryValue(%d, %v) error: %v", c.columnType, data, err)
+		} else if n != len(data) || !reflect.DeepEqual(got, c.want) {
+			t.Errorf("ReadBinaryValue(%d, %v) = %v, %d, want %v, %d", c.columnType, data, got, n, c.want, len(data))
+		}
+		if len(data) > 0 {
+			if _, _, err = ReadBinaryValue(data[:len(data)-1], c.columnType, c.unsigned); err == nil {
+				t.Errorf("ReadBinaryValue(%d, %v) of truncated data should be error", c.columnType, data[:len(data)-1])
+			}
+		}
+	}
+}
+
+func TestBinaryRow(t *testing.T) {
+	var fields []*Field
+	var values []interface{}
+	for i := 0; i < 10; i++ {
+		fields = append(fields, &Field{Name: []byte("c"), ColumnType: MYSQL_TYPE_LONG})
+		if i%3 == 0 {
+			values = append(values, nil)
+		} else {
+			values = append(values, int64(-i))
+		}
+	}
+	fields = append(fields, &Field{Name: []byte("s"), ColumnType: MYSQL_TYPE_VAR_STRING}, &Field{Name: []byte("d"), ColumnType: MYSQL_TYPE_DATETIME})
+	values = append(values, []byte("x"), []byte("2016-01-02 03:04:05"))
+
+	data, err := EncodeBinaryRow(fields, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// header, 2 bytes of null bitmap for 12 columns, 6 ints, lenenc string and datetime.
+	if len(data) != 1+2+6*4+2+8 {
+		t.Errorf("EncodeBinaryRow length = %d", len(data))
+	}
+	got, err := DecodeBinaryRow(fields, data)
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(got, values) {
+		t.Errorf("DecodeBinaryRow = %v, want %v", got, values)
+	}
+
+	row, err := RowData(data).Parse(true, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	row.Data = nil
+	if dump := row.Dump(); !bytes.Equal(dump, data) {
+		t.Errorf("Dump = %v, want %v", dump, data)
+	}
+
+	if _, err = DecodeBinaryRow(fields, data[:len(data)-1]); err == nil {
+		t.Errorf("DecodeBinaryRow of truncated data should be error")
+	}
+	if _, err = EncodeBinaryRow(fields, values[1:]); err == nil {
+		t.Errorf("EncodeBinaryRow with less values should be error")
+	}
+}
+
+func TestBindStmtTemporalArgs(t *testing.T) {
+	paramTypes := []byte{
+		MYSQL_TYPE_DATETIME, 0, MYSQL_TYPE_TIMESTAMP, 0, MYSQL_TYPE_DATE, 0,
+		MYSQL_TYPE_TIME, 0, MYSQL_TYPE_TIME, 0, MYSQL_TYPE_LONG, 0,
+	}
+	paramValues := []byte{
+		// zero datetime
+		0,
+		// 2016-01-02 03:04:05.000123
+		11, 0xe0, 0x07, 1, 2, 3, 4, 5, 0x7b, 0, 0, 0,
+		// 2016-01-02
+		4, 0xe0, 0x07, 1, 2,
+		// -838:59:59
+		8, 1, 34, 0, 0, 0, 22, 59, 59,
+		// -01:02:03.500000
+		12, 1, 0, 0, 0, 0, 1, 2, 3, 0x20, 0xa1, 0x07, 0,
+		// 1
+		1, 0, 0, 0,
+	}
+	s := &Stmt{ParamNum: 6, Args: make([]interface{}, 6)}
+	if err := new(PacketIO).bindStmtArgs(s, []byte{0}, paramTypes, paramValues); err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{
+		[]byte("0000-00-00 00:00:00"), []byte("2016-01-02 03:04:05.000123"), []byte("2016-01-02"),
+		[]byte("-838:59:59"), []byte("-01:02:03.500000"), int32(1),
+	}
+	if !reflect.DeepEqual(s.Args, want) {
+		t.Errorf("bindStmtArgs = %v, want %v", s.Args, want)
+	}
+
+	if err := new(PacketIO).bindStmtArgs(s, []byte{0}, paramTypes, paramValues[:10]); err == nil {
+		t.Errorf("bindStmtArgs of truncated data should be error")
+	}
+}
@@ -53,5 +53,5 @@ const (
 // DEFAULT_CAPABILITY default server capability.
 var DEFAULT_CAPABILITY uint32 = CLIENT_LONG_PASSWORD | CLIENT_LONG_FLAG |
 	CLIENT_CONNECT_WITH_DB | CLIENT_PROTOCOL_41 |
-	CLIENT_MULTI_STATEMENTS | CLIENT_MULTI_RESULTS |
+	CLIENT_MULTI_STATEMENTS | CLIENT_MULTI_RESULTS | CLIENT_PS_MULTI_RESULTS |
 	CLIENT_TRANSACTIONS | CLIENT_SECURE_CONNECTION
@@ -115,6 +115,56 @@ var Charsets = map[string]string{
 	"eucjpms":  "eucjpms_japanese_ci",
 }
 
+// CharsetInfo is description and max bytes per character of charset.
+type CharsetInfo struct {
+	Description string
+	MaxLen      int
+}
+
+// CharsetInfos key is charset name, for 'SHOW CHARACTER SET'.
+var CharsetInfos = map[string]CharsetInfo{
+	"big5":     {"Big5 Traditional Chinese", 2},
+	"dec8":     {"DEC West European", 1},
+	"cp850":    {"DOS West European", 1},
+	"hp8":      {"HP West European", 1},
+	"koi8r":    {"KOI8-R Relcom Russian", 1},
+	"latin1":   {"cp1252 West European", 1},
+	"latin2":   {"ISO 8859-2 Central European", 1},
+	"swe7":     {"7bit Swedish", 1},
+	"ascii":    {"US ASCII", 1},
+	"ujis":     {"EUC-JP Japanese", 3},
+	"sjis":     {"Shift-JIS Japanese", 2},
+	"hebrew":   {"ISO 8859-8 Hebrew", 1},
+	"tis620":   {"TIS620 Thai", 1},
+	"euckr":    {"EUC-KR Korean", 2},
+	"koi8u":    {"KOI8-U Ukrainian", 1},
+	"gb2312":   {"GB2312 Simplified Chinese", 2},
+	"greek":    {"ISO 8859-7 Greek", 1},
+	"cp1250":   {"Windows Central European", 1},
+	"gbk":      {"GBK Simplified Chinese", 2},
+	"latin5":   {"ISO 8859-9 Turkish", 1},
+	"armscii8": {"ARMSCII-8 Armenian", 1},
+	"utf8":     {"UTF-8 Unicode", 3},
+	"ucs2":     {"UCS-2 Unicode", 2},
+	"cp866":    {"DOS Russian", 1},
+	"keybcs2":  {"DOS Kamenicky Czech-Slovak", 1},
+	"macce":    {"Mac Central European", 1},
+	"macroman": {"Mac West European", 1},
+	"cp852":    {"DOS Central European", 1},
+	"latin7":   {"ISO 8859-13 Baltic", 1},
+	"utf8mb4":  {"UTF-8 Unicode", 4},
+	"cp1251":   {"Windows Cyrillic", 1},
+	"utf16":    {"UTF-16 Unicode", 4},
+	"utf16le":  {"UTF-16LE Unicode", 4},
+	"cp1256":   {"Windows Arabic", 1},
+	"cp1257":   {"Windows Baltic", 1},
+	"utf32":    {"UTF-32 Unicode", 4},
+	"binary":   {"Binary pseudo charset", 1},
+	"geostd8":  {"GEOSTD8 Georgian", 1},
+	"cp932":    {"SJIS for Windows Japanese", 2},
+	"eucjpms":  {"UJIS for Windows Japanese", 3},
+}
+
 // Collations key is collation id, value is collation name.
 var Collations = map[CollationID]string{
 	1:   "big5_chinese_ci",
@@ -24,9 +24,12 @@ package mysql
 
 import (
 	"bufio"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
+	"sync"
+	"time"
 
 	"github.com/berkaroad/saashard/errors"
 )
@@ -40,6 +43,11 @@ type PacketIO struct {
 	rb *bufio.Reader
 	wb io.Writer
 
+	conn      net.Conn
+	tlsConfig *tls.Config
+	tracker   MemoryTracker
+	capture   PacketCapture
+
 	Sequence uint8
 }
 
@@ -49,12 +57,105 @@ func NewPacketIO(conn net.Conn) *PacketIO {
 
 	p.rb = bufio.NewReaderSize(conn, defaultReaderSize)
 	p.wb = conn
+	p.conn = conn
 
 	p.Sequence = 0
 
 	return p
 }
 
+// SetMemoryTracker set tracker to account buffered rows, nil means no accounting.
+func (p *PacketIO) SetMemoryTracker(tracker MemoryTracker) {
+	p.tracker = tracker
+}
+
+// PacketCapture records packets, in is true if received, data is payload without header.
+type PacketCapture interface {
+	CapturePacket(in bool, sequence uint8, data []byte)
+}
+
+// SetCapture set capture to record packets, nil means no capture.
+func (p *PacketIO) SetCapture(capture PacketCapture) {
+	p.capture = capture
+}
+
+// EnableTLS allow client to upgrade connection to tls by ssl request.
+func (p *PacketIO) EnableTLS(config *tls.Config) {
+	p.tlsConfig = config
+}
+
+// IsTLS check whether connection is upgraded to tls.
+func (p *PacketIO) IsTLS() bool {
+	_, ok := p.conn.(*tls.Conn)
+	return ok
+}
+
+// upgradeTLS do tls handshake as server, and replace reader and writer.
+func (p *PacketIO) upgradeTLS() error {
+	// Data buffered by reader may be a part of tls handshake.
+	tlsConn := tls.Server(&bufferedConn{Conn: p.conn, rb: p.rb}, p.tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		return err
+	}
+	p.conn = tlsConn
+	p.rb = bufio.NewReaderSize(tlsConn, defaultReaderSize)
+	p.wb = tlsConn
+	return nil
+}
+
+// bufferedConn read from buffered reader first.
+type bufferedConn struct {
+	net.Conn
+	rb *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.rb.Read(b)
+}
+
+// WatchClose poll connection by read deadline every interval until stopped, onClose is called if peer closed connection.
+// Data sent by peer isn't consumed, it's left to next ReadPacket. Packets shouldn't be read until stopped.
+func (p *PacketIO) WatchClose(interval time.Duration, onClose func()) (stop func()) {
+	var mu sync.Mutex
+	stopped := false
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		for {
+			mu.Lock()
+			if stopped {
+				mu.Unlock()
+				return
+			}
+			p.conn.SetReadDeadline(time.Now().Add(interval))
+			mu.Unlock()
+
+			_, err := p.rb.Peek(1)
+			if err == nil {
+				return
+			}
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				continue
+			}
+			mu.Lock()
+			if !stopped {
+				onClose()
+			}
+			mu.Unlock()
+			return
+		}
+	}()
+	return func() {
+		mu.Lock()
+		stopped = true
+		// interrupt peek.
+		p.conn.SetReadDeadline(time.Now())
+		mu.Unlock()
+		<-exited
+		p.conn.SetReadDeadline(time.Time{})
+	}
+}
+
 // ReadPacket is to read packet.
 func (p *PacketIO) ReadPacket() ([]byte, error) {
 	header := []byte{0, 0, 0, 0}
@@ -80,6 +181,9 @@ func (p *PacketIO) ReadPacket() ([]byte, error) {
 	if _, err := io.ReadFull(p.rb, data); err != nil {
 		return nil, errors.ErrBadConn
 	}
+	if p.capture != nil {
+		p.capture.CapturePacket(true, sequence, data)
+	}
 	if length < MaxPayloadLen {
 		return data, nil
 	}
@@ -103,6 +207,9 @@ func (p *PacketIO) WritePacket(data []byte) error {
 		data[2] = 0xff
 
 		data[3] = p.Sequence
+		if p.capture != nil {
+			p.capture.CapturePacket(false, p.Sequence, data[4:4+MaxPayloadLen])
+		}
 
 		if n, err := p.wb.Write(data[:4+MaxPayloadLen]); err != nil {
 			return errors.ErrBadConn
@@ -119,6 +226,9 @@ func (p *PacketIO) WritePacket(data []byte) error {
 	data[1] = byte(length >> 8)
 	data[2] = byte(length >> 16)
 	data[3] = p.Sequence
+	if p.capture != nil {
+		p.capture.CapturePacket(false, p.Sequence, data[4:])
+	}
 
 	if n, err := p.wb.Write(data); err != nil {
 		return errors.ErrBadConn
@@ -156,6 +266,9 @@ func (p *PacketIO) WritePacketBatch(total, data []byte, direct bool) ([]byte, er
 		data[2] = 0xff
 
 		data[3] = p.Sequence
+		if p.capture != nil {
+			p.capture.CapturePacket(false, p.Sequence, data[4:4+MaxPayloadLen])
+		}
 		total = append(total, data[:4+MaxPayloadLen]...)
 
 		p.Sequence++
@@ -167,6 +280,9 @@ func (p *PacketIO) WritePacketBatch(total, data []byte, direct bool) ([]byte, er
 	data[1] = byte(length >> 8)
 	data[2] = byte(length >> 16)
 	data[3] = p.Sequence
+	if p.capture != nil {
+		p.capture.CapturePacket(false, p.Sequence, data[4:])
+	}
 
 	total = append(total, data...)
 	p.Sequence++
@@ -326,6 +326,11 @@ func (p *PacketIO) StmtPrepare(capability uint32, stmt *Stmt) (err error) {
 
 // StmtExecute use command COM_STMT_EXECUTE
 func (p *PacketIO) StmtExecute(stmtID uint32, args []interface{}) error {
+	return p.StmtExecuteWithCursor(stmtID, CURSOR_TYPE_NO_CURSOR, args)
+}
+
+// StmtExecuteWithCursor use command COM_STMT_EXECUTE with cursor type flag
+func (p *PacketIO) StmtExecuteWithCursor(stmtID uint32, cursorType byte, args []interface{}) error {
 	paramsNum := len(args)
 
 	paramTypes := make([]byte, paramsNum<<1)
@@ -414,8 +419,8 @@ func (p *PacketIO) StmtExecute(stmtID uint32, args []interface{}) error {
 	// data = append(data, COM_STMT_EXECUTE)
 	data = append(data, byte(stmtID), byte(stmtID>>8), byte(stmtID>>16), byte(stmtID>>24))
 
-	//flag: CURSOR_TYPE_NO_CURSOR
-	data = append(data, 0x00)
+	//flag: cursor type
+	data = append(data, cursorType)
 
 	//iteration-count, always 1
 	data = append(data, 1, 0, 0, 0)
@@ -441,7 +446,13 @@ func (p *PacketIO) StmtExecute(stmtID uint32, args []interface{}) error {
 }
 
 // StmtSendLongData use command COM_STMT_SEND_LONG_DATA
-//
+func (p *PacketIO) StmtSendLongData(stmtID uint32, paramID uint16, chunk []byte) error {
+	data := make([]byte, 0, 6+len(chunk))
+	data = append(data, Uint32ToBytes(stmtID)...)
+	data = append(data, Uint16ToBytes(paramID)...)
+	data = append(data, chunk...)
+	return p.WriteCommandBuf(COM_STMT_SEND_LONG_DATA, data)
+}
 
 // StmtClose use command COM_STMT_CLOSE
 func (p *PacketIO) StmtClose(stmtID uint32) error {
@@ -458,7 +469,12 @@ func (p *PacketIO) StmtClose(stmtID uint32) error {
 //
 
 // StmtFetch use command COM_STMT_FETCH
-//
+func (p *PacketIO) StmtFetch(stmtID uint32, rows uint32) error {
+	data := make([]byte, 0, 8)
+	data = append(data, Uint32ToBytes(stmtID)...)
+	data = append(data, Uint32ToBytes(rows)...)
+	return p.WriteCommandBuf(COM_STMT_FETCH, data)
+}
 
 // Daemon use command COM_DAEMON
 //
@@ -37,11 +37,16 @@ func (p *PacketIO) WriteEOF(capability uint32, status uint16) error {
 
 // WriteEOFBatch is to write EOF packet in batch.
 func (p *PacketIO) WriteEOFBatch(total []byte, capability uint32, status uint16, direct bool) ([]byte, error) {
+	return p.writeEOFBatch(total, capability, status, 0, direct)
+}
+
+// writeEOFBatch with warnings count.
+func (p *PacketIO) writeEOFBatch(total []byte, capability uint32, status uint16, warnings uint16, direct bool) ([]byte, error) {
 	data := make([]byte, 4, 9)
 
 	data = append(data, EOF_HEADER)
 	if capability&CLIENT_PROTOCOL_41 > 0 {
-		data = append(data, 0, 0)
+		data = append(data, byte(warnings), byte(warnings>>8))
 		data = append(data, byte(status), byte(status>>8))
 	}
 	return p.WritePacketBatch(total, data, direct)
@@ -25,9 +25,11 @@ package mysql
 import (
 	"bytes"
 	"encoding/binary"
-	"errors"
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/berkaroad/saashard/errors"
 )
 
 // WriteInitialHandshake write initial handshake
@@ -87,7 +89,8 @@ func (p *PacketIO) WriteInitialHandshake(connectionID uint32, salt []byte, colla
 
 // ReadInitialHandshake read initial handshake.
 // salt:
-func (p *PacketIO) ReadInitialHandshake(salt *[]byte) (capability uint32, status uint16, collationID CollationID, err error) {
+// connectionID: thread id of server, could be nil.
+func (p *PacketIO) ReadInitialHandshake(salt *[]byte, connectionID *uint32) (capability uint32, status uint16, collationID CollationID, err error) {
 	var data []byte
 	data, err = p.ReadPacket()
 	if err != nil {
@@ -104,10 +107,14 @@ func (p *PacketIO) ReadInitialHandshake(salt *[]byte) (capability uint32, status
 		return
 	}
 
-	//skip mysql version and connection id
+	//skip mysql version
 	//mysql version end with 0x00
 	//connection id length is 4
-	pos := 1 + bytes.IndexByte(data[1:], 0x00) + 1 + 4
+	pos := 1 + bytes.IndexByte(data[1:], 0x00) + 1
+	if connectionID != nil {
+		*connectionID = binary.LittleEndian.Uint32(data[pos : pos+4])
+	}
+	pos += 4
 
 	*salt = append(*salt, data[pos:pos+8]...)
 
@@ -146,7 +153,9 @@ func (p *PacketIO) ReadInitialHandshake(salt *[]byte) (capability uint32, status
 }
 
 // WriteAuthHandshake write auth handshake
-func (p *PacketIO) WriteAuthHandshake(capability *uint32, user, password, db string, salt []byte, collationID CollationID) error {
+// Connection attributes are sent only if server supports CLIENT_CONNECT_ATTRS.
+func (p *PacketIO) WriteAuthHandshake(capability *uint32, user, password, db string, salt []byte, collationID CollationID, attrs map[string]string) error {
+	serverCapability := *capability
 	// Adjust client capability flags based on server support
 	*capability &= DEFAULT_CAPABILITY
 
@@ -171,6 +180,23 @@ func (p *PacketIO) WriteAuthHandshake(capability *uint32, user, password, db str
 		length += len(db) + 1
 	}
 
+	var attrsData []byte
+	if len(attrs) > 0 && serverCapability&CLIENT_CONNECT_ATTRS > 0 {
+		*capability |= CLIENT_CONNECT_ATTRS
+
+		keys := make([]string, 0, len(attrs))
+		for key := range attrs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			attrsData = append(attrsData, StringToLenencStr([]byte(key))...)
+			attrsData = append(attrsData, StringToLenencStr([]byte(attrs[key]))...)
+		}
+		attrsData = append(NumberToLenencInt(uint64(len(attrsData))), attrsData...)
+		length += len(attrsData)
+	}
+
 	data := make([]byte, length+4)
 
 	//capability [32 bit]
@@ -208,13 +234,23 @@ func (p *PacketIO) WriteAuthHandshake(capability *uint32, user, password, db str
 	if len(db) > 0 {
 		pos += copy(data[pos:], db)
 		//data[pos] = 0x00
+		pos++
+	}
+
+	// attrs [length encoded key-value pairs]
+	if len(attrsData) > 0 {
+		copy(data[pos:], attrsData)
 	}
 
 	return p.WritePacket(data)
 }
 
+// sslRequestLength is length of ssl request, capability 4, max-packet size 4, charset 1, reserved 23.
+const sslRequestLength = 32
+
 // ReadHandshakeResponse read handshake response
-func (p *PacketIO) ReadHandshakeResponse(getDefaultSchemaByUser func(user string) (string, error), remoteAddr string, salt []byte, getCredentialsConfigBySchema func(db string) (user, password string, err error)) (capability uint32, collationID CollationID, user, db string, err error) {
+// Connection attributes are read if client sends them by CLIENT_CONNECT_ATTRS.
+func (p *PacketIO) ReadHandshakeResponse(getDefaultSchemaByUser func(user string) (string, error), remoteAddr string, salt []byte, getCredentialsConfigBySchema func(user, db string) (configUser, configPassword string, err error)) (capability uint32, collationID CollationID, user, db string, attrs map[string]string, err error) {
 	var data []byte
 	data, err = p.ReadPacket()
 
@@ -222,11 +258,25 @@ func (p *PacketIO) ReadHandshakeResponse(getDefaultSchemaByUser func(user string
 		return
 	}
 
+	// ssl request is a truncated handshake response, then do tls handshake.
+	if len(data) == sslRequestLength && binary.LittleEndian.Uint32(data[:4])&CLIENT_SSL > 0 {
+		if p.tlsConfig == nil {
+			err = errors.New("ssl is not enabled")
+			return
+		}
+		if err = p.upgradeTLS(); err != nil {
+			return
+		}
+		if data, err = p.ReadPacket(); err != nil {
+			return
+		}
+	}
+
 	pos := 0
 
 	//capability
-	capability = binary.LittleEndian.Uint32(data[:4])
-	capability = capability & DEFAULT_CAPABILITY
+	clientCapability := binary.LittleEndian.Uint32(data[:4])
+	capability = clientCapability & DEFAULT_CAPABILITY
 	pos += 4
 
 	//skip max packet size
@@ -255,11 +305,11 @@ func (p *PacketIO) ReadHandshakeResponse(getDefaultSchemaByUser func(user string
 		if len(data[pos:]) == 0 {
 			//if connect with non-name database, use default db
 			db, err = getDefaultSchemaByUser(user)
+		} else {
+			db = string(data[pos : pos+bytes.IndexByte(data[pos:], 0)])
+			pos += len(db) + 1
 		}
 
-		db = string(data[pos : pos+bytes.IndexByte(data[pos:], 0)])
-		pos += len(db) + 1
-
 	} else {
 		//if connect without database, use default db
 		db, err = getDefaultSchemaByUser(user)
@@ -269,8 +319,22 @@ func (p *PacketIO) ReadHandshakeResponse(getDefaultSchemaByUser func(user string
 	}
 	db = strings.ToLower(db)
 
+	//skip auth plugin name
+	if clientCapability&CLIENT_PLUGIN_AUTH > 0 && pos < len(data) {
+		if i := bytes.IndexByte(data[pos:], 0); i >= 0 {
+			pos += i + 1
+		} else {
+			pos = len(data)
+		}
+	}
+	if clientCapability&CLIENT_CONNECT_ATTRS > 0 && pos < len(data) {
+		if attrs, err = readConnectAttrs(data[pos:]); err != nil {
+			return
+		}
+	}
+
 	var configUser, configPassword string
-	configUser, configPassword, err = getCredentialsConfigBySchema(db)
+	configUser, configPassword, err = getCredentialsConfigBySchema(user, db)
 	if err != nil {
 		return
 	}
@@ -281,3 +345,30 @@ func (p *PacketIO) ReadHandshakeResponse(getDefaultSchemaByUser func(user string
 	}
 	return
 }
+
+// readConnectAttrs read length encoded key-value pairs of connection attributes.
+func readConnectAttrs(data []byte) (map[string]string, error) {
+	length, _, n := LenencIntToNumber(data)
+	if uint64(len(data)-n) < length {
+		return nil, errors.ErrMalformPacket
+	}
+	data = data[n : n+int(length)]
+	attrs := make(map[string]string)
+	for len(data) > 0 {
+		key, _, n, err := LenencStrToString(data)
+		if err != nil {
+			return nil, errors.ErrMalformPacket
+		}
+		data = data[n:]
+		if len(data) == 0 {
+			return nil, errors.ErrMalformPacket
+		}
+		value, _, n, err := LenencStrToString(data)
+		if err != nil {
+			return nil, errors.ErrMalformPacket
+		}
+		data = data[n:]
+		attrs[string(key)] = string(value)
+	}
+	return attrs, nil
+}
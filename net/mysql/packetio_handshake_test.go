@@ -0,0 +1,95 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mysql
+
+import (
+	"net"
+	"testing"
+)
+
+func TestReadConnectAttrs(t *testing.T) {
+	var pairs []byte
+	for _, s := range []string{"_client_name", "libmysql", "program_name", "billing-job", "empty", ""} {
+		pairs = append(pairs, StringToLenencStr([]byte(s))...)
+	}
+	data := append(NumberToLenencInt(uint64(len(pairs))), pairs...)
+	attrs, err := readConnectAttrs(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"_client_name": "libmysql", "program_name": "billing-job", "empty": ""}
+	if len(attrs) != len(want) {
+		t.Errorf("readConnectAttrs() = %v, want %v", attrs, want)
+	}
+	for key, value := range want {
+		if got, ok := attrs[key]; !ok || got != value {
+			t.Errorf("readConnectAttrs()[%q] = %q, want %q", key, got, value)
+		}
+	}
+
+	for _, truncated := range [][]byte{data[:len(data)-1], data[:len(data)-3]} {
+		if _, err = readConnectAttrs(truncated); err == nil {
+			t.Errorf("readConnectAttrs(% x) should be error", truncated)
+		}
+	}
+	truncatedPair := append(NumberToLenencInt(uint64(len(pairs)-1)), pairs[:len(pairs)-1]...)
+	if _, err = readConnectAttrs(truncatedPair); err == nil {
+		t.Errorf("readConnectAttrs() of truncated pair should be error")
+	}
+}
+
+// Client with CLIENT_CONNECT_WITH_DB may send no database at the end of handshake response, default schema of user is used.
+func TestReadHandshakeResponseDB(t *testing.T) {
+	salt := []byte("12345678901234567890")
+	auth := CalcPassword(salt, []byte("pw"))
+	cases := []struct {
+		db   []byte // sent after auth, nil is none.
+		want string
+	}{
+		{nil, "default_db"},
+		{[]byte("DB1\x00"), "db1"},
+	}
+	for _, c := range cases {
+		data := make([]byte, 4, 64)
+		data = append(data, Uint32ToBytes(CLIENT_PROTOCOL_41|CLIENT_SECURE_CONNECTION|CLIENT_CONNECT_WITH_DB)...)
+		data = append(data, make([]byte, 4+1+23)...)
+		data = append(data, "app\x00"...)
+		data = append(data, byte(len(auth)))
+		data = append(append(data, auth...), c.db...)
+
+		server, client := net.Pipe()
+		go func() {
+			NewPacketIO(client).WritePacket(data)
+		}()
+		_, _, user, db, _, err := NewPacketIO(server).ReadHandshakeResponse(
+			func(user string) (string, error) { return "default_db", nil }, "127.0.0.1", salt,
+			func(user, db string) (string, string, error) { return "app", "pw", nil })
+		server.Close()
+		client.Close()
+		if err != nil {
+			t.Errorf("ReadHandshakeResponse(%q) error: %v", c.db, err)
+		} else if user != "app" || db != c.want {
+			t.Errorf("ReadHandshakeResponse(%q) = %s, %s, want app, %s", c.db, user, db, c.want)
+		}
+	}
+}
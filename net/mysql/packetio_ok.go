@@ -46,7 +46,7 @@ func (p *PacketIO) WriteOK(capability uint32, status uint16, r *Result) error {
 
 	if capability&CLIENT_PROTOCOL_41 > 0 {
 		data = append(data, byte(r.Status), byte(r.Status>>8))
-		data = append(data, 0, 0)
+		data = append(data, byte(r.Warnings), byte(r.Warnings>>8))
 	}
 	return p.WritePacket(data)
 }
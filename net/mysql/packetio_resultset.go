@@ -56,13 +56,47 @@ func (p *PacketIO) WriteResultSet(capability uint32, status uint16, r *Result) e
 			}
 		}
 	}
+	if r.Spilled != nil {
+		defer func() {
+			r.Spilled.Close()
+			r.Spilled = nil
+		}()
+		err = r.Spilled.Each(func(data []byte) error {
+			total, err = p.writeResultSetRowData(total, &Row{Data: data})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
 	if capability&CLIENT_DEPRECATE_EOF > 0 {
 		total, err = p.WriteOKBatch(total, capability, status, r, true)
 		if err != nil {
 			return err
 		}
 	} else {
-		total, err = p.WriteEOFBatch(total, capability, status, true)
+		total, err = p.writeEOFBatch(total, capability, status, r.Warnings, true)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteResults write result and its next results of multi-results.
+// SERVER_MORE_RESULTS_EXISTS is set except the last one, and SERVER_PS_OUT_PARAMS of OUT parameters is kept.
+func (p *PacketIO) WriteResults(capability uint32, status uint16, r *Result) error {
+	for ; r != nil; r = r.Next {
+		resultStatus := status | r.Status&SERVER_PS_OUT_PARAMS
+		if r.Next != nil {
+			resultStatus |= SERVER_MORE_RESULTS_EXISTS
+		}
+		var err error
+		if r.Resultset == nil {
+			err = p.WriteOK(capability, resultStatus, r)
+		} else {
+			err = p.WriteResultSet(capability, resultStatus, r)
+		}
 		if err != nil {
 			return err
 		}
@@ -71,7 +105,21 @@ func (p *PacketIO) WriteResultSet(capability uint32, status uint16, r *Result) e
 }
 
 // ReadResultSet read result set.
+// Multi-results, such as result sets and OUT parameters of CALL, are all read and chained by Next.
 func (p *PacketIO) ReadResultSet(capability uint32, status *uint16, binary bool) (*Result, error) {
+	result, err := p.readResult(capability, status, binary)
+	if err != nil {
+		return nil, err
+	}
+	for last := result; last.Status&SERVER_MORE_RESULTS_EXISTS > 0; last = last.Next {
+		if last.Next, err = p.readResult(capability, status, binary); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (p *PacketIO) readResult(capability uint32, status *uint16, binary bool) (*Result, error) {
 	data, err := p.ReadPacket()
 	if err != nil {
 		return nil, err
@@ -82,12 +130,24 @@ func (p *PacketIO) ReadResultSet(capability uint32, status *uint16, binary bool)
 	} else if data[0] == ERR_HEADER {
 		return nil, p.handleErrorPacket(capability, data)
 	} else if data[0] == LocalInFile_HEADER {
-		return nil, errors.ErrMalformPacket
+		return nil, p.refuseLocalInFile(capability, status)
 	}
 
 	return p.handleResultsetPacket(capability, status, data, binary)
 }
 
+// refuseLocalInFile answer file request of server with empty content, so that connection is still in sync.
+// LOCAL INFILE is never relayed, since CLIENT_LOCAL_FILES isn't in capability.
+func (p *PacketIO) refuseLocalInFile(capability uint32, status *uint16) error {
+	if err := p.WritePacket(make([]byte, 4)); err != nil {
+		return err
+	}
+	if _, err := p.ReadOK(capability, status); err != nil {
+		return err
+	}
+	return errors.ErrLocalInFile
+}
+
 // WriteFieldList write fieldlist.
 func (p *PacketIO) WriteFieldList(capability uint32, status uint16, fs []*Field) error {
 	var err error
@@ -149,6 +209,10 @@ func (p *PacketIO) handleResultsetPacket(capability uint32, status *uint16, data
 	if err := p.handleResultColumns(capability, status, result); err != nil {
 		return nil, err
 	}
+	// rows of opened cursor are read by COM_STMT_FETCH.
+	if result.Status&SERVER_STATUS_CURSOR_EXISTS > 0 {
+		return result, nil
+	}
 
 	if err := p.handleResultRows(capability, status, result, binary); err != nil {
 		return nil, err
@@ -196,14 +260,29 @@ func (p *PacketIO) handleResultColumns(capability uint32, status *uint16, result
 
 func (p *PacketIO) handleResultRows(capability uint32, status *uint16, result *Result, isBinary bool) (err error) {
 	var data []byte
+	// When exceed memory budget, rest rows should be read but discarded.
+	var budgetErr error
 
 	for {
 		data, err = p.ReadPacket()
 
 		if err != nil {
+			if result.Spilled != nil {
+				result.Spilled.Close()
+				result.Spilled = nil
+			}
 			return
 		}
 
+		// ERR Packet, such as query is killed or cursor isn't opened.
+		if data[0] == ERR_HEADER {
+			if result.Spilled != nil {
+				result.Spilled.Close()
+				result.Spilled = nil
+			}
+			return p.handleErrorPacket(capability, data)
+		}
+
 		// EOF Packet
 		if p.isEOFPacket(data) {
 			if capability&CLIENT_PROTOCOL_41 > 0 {
@@ -215,6 +294,26 @@ func (p *PacketIO) handleResultRows(capability uint32, status *uint16, result *R
 
 			break
 		}
+		if budgetErr != nil {
+			continue
+		}
+		if p.tracker != nil && result.Spilled == nil {
+			if e := p.tracker.Consume(int64(len(data))); e != nil {
+				if dir := p.tracker.SpillDir(); len(dir) > 0 {
+					result.Spilled, e = NewSpillFile(dir, p.tracker.SpillMaxSize())
+				}
+				if e != nil {
+					budgetErr = e
+					continue
+				}
+			}
+		}
+		if result.Spilled != nil {
+			if e := result.Spilled.Append(data); e != nil {
+				budgetErr = e
+			}
+			continue
+		}
 		var row *Row
 		row, err = RowData(data).Parse(isBinary, result.Fields)
 		if err != nil {
@@ -223,6 +322,14 @@ func (p *PacketIO) handleResultRows(capability uint32, status *uint16, result *R
 		result.Rows = append(result.Rows, row)
 	}
 
+	if budgetErr != nil {
+		if result.Spilled != nil {
+			result.Spilled.Close()
+			result.Spilled = nil
+		}
+		return budgetErr
+	}
+
 	result.Values = make([][]interface{}, len(result.Rows))
 
 	for i := range result.Values {
@@ -115,10 +115,11 @@ func (p *PacketIO) ReadStmtExecuteRequest(data []byte, findStmtByID func(id uint
 
 	flag := data[pos]
 	pos++
-	//now we only support CURSOR_TYPE_NO_CURSOR flag
-	if flag != 0 {
+	//now we only support CURSOR_TYPE_NO_CURSOR and CURSOR_TYPE_READ_ONLY flag
+	if flag != CURSOR_TYPE_NO_CURSOR && flag != CURSOR_TYPE_READ_ONLY {
 		return nil, NewError(ER_UNKNOWN_ERROR, fmt.Sprintf("unsupported flag %d", flag))
 	}
+	s.CursorType = flag
 
 	//skip iteration-count, always 1
 	pos += 4
@@ -153,10 +154,142 @@ func (p *PacketIO) ReadStmtExecuteRequest(data []byte, findStmtByID func(id uint
 				return nil, err
 			}
 		}
+		for i, v := range s.LongData {
+			s.Args[i] = v
+		}
 	}
 	return s, nil
 }
 
+// RedactStmtExecuteRequest return copy of stmt execute request (without command), with string values of params
+// replaced by '***'. Values that couldn't be located, such as types aren't bound by this request, are dropped.
+func RedactStmtExecuteRequest(data []byte, s *Stmt) []byte {
+	pos := 9 + (s.ParamNum+7)>>3
+	if s.ParamNum == 0 || len(data) <= pos {
+		return data
+	}
+	nullBitmap := data[9:pos]
+	if data[pos] != 1 || len(data) < pos+1+(s.ParamNum<<1) {
+		return append([]byte(nil), data[:pos+1]...)
+	}
+	pos++
+	paramTypes := data[pos : pos+(s.ParamNum<<1)]
+	pos += s.ParamNum << 1
+
+	redacted := append(make([]byte, 0, len(data)), data[:pos]...)
+	for i := 0; i < s.ParamNum; i++ {
+		if nullBitmap[i>>3]&(1<<(uint(i)%8)) > 0 {
+			continue
+		}
+		if _, ok := s.LongData[i]; ok {
+			continue
+		}
+		tp := paramTypes[i<<1]
+		_, n, err := ReadBinaryValue(data[pos:], tp, (paramTypes[(i<<1)+1]&0x80) > 0)
+		if err != nil {
+			return redacted
+		}
+		switch tp {
+		case MYSQL_TYPE_VARCHAR, MYSQL_TYPE_TINY_BLOB, MYSQL_TYPE_MEDIUM_BLOB, MYSQL_TYPE_LONG_BLOB, MYSQL_TYPE_BLOB,
+			MYSQL_TYPE_VAR_STRING, MYSQL_TYPE_STRING:
+			redacted = append(redacted, StringToLenencStr([]byte("***"))...)
+		default:
+			redacted = append(redacted, data[pos:pos+n]...)
+		}
+		pos += n
+	}
+	return redacted
+}
+
+// ReadStmtSendLongDataRequest read from stmt send long data request, and append data to param of stmt.
+// There's no response of this command, so unknown stmt or param is ignored.
+func (p *PacketIO) ReadStmtSendLongDataRequest(data []byte, findStmtByID func(id uint32) *Stmt) {
+	if len(data) < 6 {
+		return
+	}
+	s := findStmtByID(binary.LittleEndian.Uint32(data[0:4]))
+	paramID := int(binary.LittleEndian.Uint16(data[4:6]))
+	if s == nil || paramID >= s.ParamNum {
+		return
+	}
+	if s.LongData == nil {
+		s.LongData = make(map[int][]byte)
+	}
+	s.LongData[paramID] = append(s.LongData[paramID], data[6:]...)
+}
+
+// ReadStmtFetchRequest read stmt id and number of rows from stmt fetch request.
+func (p *PacketIO) ReadStmtFetchRequest(data []byte) (id uint32, rows uint32, err error) {
+	if len(data) < 8 {
+		return 0, 0, errors.ErrMalformPacket
+	}
+	return binary.LittleEndian.Uint32(data[0:4]), binary.LittleEndian.Uint32(data[4:8]), nil
+}
+
+// WriteStmtCursorResponse write fields of stmt execute with opened cursor, rows are written by COM_STMT_FETCH.
+func (p *PacketIO) WriteStmtCursorResponse(capability uint32, status uint16, r *Result) error {
+	total := make([]byte, 0, 1024)
+	var err error
+	total, err = p.writeResultSetHeader(total, r)
+	if err != nil {
+		return err
+	}
+	for _, f := range r.Fields {
+		total, err = p.writeResultSetField(total, f)
+		if err != nil {
+			return err
+		}
+	}
+	status |= SERVER_STATUS_CURSOR_EXISTS
+	if capability&CLIENT_DEPRECATE_EOF > 0 {
+		_, err = p.WriteOKBatch(total, capability, status, nil, true)
+	} else {
+		_, err = p.WriteEOFBatch(total, capability, status, true)
+	}
+	return err
+}
+
+// WriteStmtFetchResponse write rows of stmt fetch, status should have SERVER_STATUS_LAST_ROW_SEND if no more rows.
+func (p *PacketIO) WriteStmtFetchResponse(capability uint32, status uint16, r *Result) error {
+	total := make([]byte, 0, 1024)
+	var err error
+	for _, row := range r.Rows {
+		total, err = p.writeResultSetRowData(total, row)
+		if err != nil {
+			return err
+		}
+	}
+	if r.Spilled != nil {
+		defer func() {
+			r.Spilled.Close()
+			r.Spilled = nil
+		}()
+		err = r.Spilled.Each(func(data []byte) error {
+			total, err = p.writeResultSetRowData(total, &Row{Data: data})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	status |= SERVER_STATUS_CURSOR_EXISTS
+	if capability&CLIENT_DEPRECATE_EOF > 0 {
+		_, err = p.WriteOKBatch(total, capability, status, nil, true)
+	} else {
+		_, err = p.WriteEOFBatch(total, capability, status, true)
+	}
+	return err
+}
+
+// ReadStmtFetchResult read rows of stmt fetch.
+func (p *PacketIO) ReadStmtFetchResult(capability uint32, status *uint16, fields []*Field) (*Result, error) {
+	result := &Result{Resultset: &Resultset{Fields: fields}}
+	if err := p.handleResultRows(capability, status, result, true); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (p *PacketIO) bindStmtArgs(s *Stmt, nullBitmap, paramTypes, paramValues []byte) error {
 	args := s.Args
 
@@ -172,6 +305,10 @@ func (p *PacketIO) bindStmtArgs(s *Stmt, nullBitmap, paramTypes, paramValues []b
 			args[i] = nil
 			continue
 		}
+		// value of param sent by long data isn't in packet.
+		if _, ok := s.LongData[i]; ok {
+			continue
+		}
 
 		tp := paramTypes[i<<1]
 		isUnsigned := (paramTypes[(i<<1)+1] & 0x80) > 0
@@ -252,12 +389,19 @@ func (p *PacketIO) bindStmtArgs(s *Stmt, nullBitmap, paramTypes, paramValues []b
 			pos += 8
 			continue
 
+		case MYSQL_TYPE_DATE, MYSQL_TYPE_NEWDATE,
+			MYSQL_TYPE_TIMESTAMP, MYSQL_TYPE_DATETIME, MYSQL_TYPE_TIME:
+			// temporal param is packed as binary row value, zero date is of length 0.
+			if args[i], n, err = ReadBinaryValue(paramValues[pos:], tp, isUnsigned); err != nil {
+				return err
+			}
+			pos += n
+			continue
+
 		case MYSQL_TYPE_DECIMAL, MYSQL_TYPE_NEWDECIMAL, MYSQL_TYPE_VARCHAR,
 			MYSQL_TYPE_BIT, MYSQL_TYPE_ENUM, MYSQL_TYPE_SET, MYSQL_TYPE_TINY_BLOB,
 			MYSQL_TYPE_MEDIUM_BLOB, MYSQL_TYPE_LONG_BLOB, MYSQL_TYPE_BLOB,
-			MYSQL_TYPE_VAR_STRING, MYSQL_TYPE_STRING, MYSQL_TYPE_GEOMETRY,
-			MYSQL_TYPE_DATE, MYSQL_TYPE_NEWDATE,
-			MYSQL_TYPE_TIMESTAMP, MYSQL_TYPE_DATETIME, MYSQL_TYPE_TIME:
+			MYSQL_TYPE_VAR_STRING, MYSQL_TYPE_STRING, MYSQL_TYPE_GEOMETRY:
 			if len(paramValues) < (pos + 1) {
 				return errors.ErrMalformPacket
 			}
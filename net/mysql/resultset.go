@@ -32,7 +32,10 @@ type Result struct {
 	Status       uint16
 	InsertID     uint64
 	AffectedRows uint64
+	Warnings     uint16 // such as skipped nodes of partial result.
 	*Resultset
+
+	Next *Result // next result of multi-results, such as result sets and OUT parameters of CALL.
 }
 
 // Resultset is result set of result.
@@ -42,6 +45,10 @@ type Resultset struct {
 	Values     [][]interface{}
 
 	Rows []*Row
+
+	// Spilled rows after Rows, when memory budget is exceeded.
+	// It's removed after written.
+	Spilled *SpillFile
 }
 
 // RowNumber row number
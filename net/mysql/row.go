@@ -23,12 +23,11 @@
 package mysql
 
 import (
-	"encoding/binary"
 	"fmt"
-	"math"
 	"strconv"
 
 	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/sqlparser/sqltypes"
 )
 
 // RowData is row's dump data.
@@ -127,6 +126,70 @@ func (r *Row) AppendNullValue() {
 	}
 }
 
+// GetValue get field value by column index.
+func (r *Row) GetValue(column int) interface{} {
+	if column < 0 || column >= len(r.fieldValues) {
+		return nil
+	}
+	return r.fieldValues[column]
+}
+
+// GetRawValue get text of field value by column index, return nil if it's NULL or binary row.
+func (r *Row) GetRawValue(column int) []byte {
+	if r.isBinary || column < 0 || column >= len(r.fieldValues) || r.fieldValues[column] == nil {
+		return nil
+	}
+	v, _, _, _ := LenencStrToString(r.fieldValuesCache[column])
+	return v
+}
+
+// GetSQLValue get field value by column index, typed by column type of field.
+func (r *Row) GetSQLValue(column int) sqltypes.Value {
+	if column < 0 || column >= len(r.fieldValues) || column >= len(r.fields) || r.fieldValues[column] == nil {
+		return sqltypes.Value{}
+	}
+	raw := r.GetRawValue(column)
+	if raw == nil {
+		switch v := r.fieldValues[column].(type) {
+		case []byte:
+			raw = v
+		case string:
+			raw = []byte(v)
+		default:
+			raw = []byte(fmt.Sprint(v))
+		}
+	}
+	switch r.fields[column].ColumnType {
+	case MYSQL_TYPE_TINY, MYSQL_TYPE_SHORT, MYSQL_TYPE_LONG, MYSQL_TYPE_INT24,
+		MYSQL_TYPE_LONGLONG, MYSQL_TYPE_YEAR:
+		return sqltypes.MakeNumeric(raw)
+	case MYSQL_TYPE_FLOAT, MYSQL_TYPE_DOUBLE:
+		return sqltypes.MakeFractional(raw)
+	case MYSQL_TYPE_DECIMAL, MYSQL_TYPE_NEWDECIMAL:
+		return sqltypes.MakeDecimal(raw)
+	case MYSQL_TYPE_DATE, MYSQL_TYPE_NEWDATE, MYSQL_TYPE_DATETIME, MYSQL_TYPE_TIMESTAMP,
+		MYSQL_TYPE_DATETIME2, MYSQL_TYPE_TIMESTAMP2:
+		return sqltypes.MakeDatetime(raw)
+	case MYSQL_TYPE_TIME, MYSQL_TYPE_TIME2:
+		return sqltypes.MakeTime(raw)
+	case MYSQL_TYPE_JSON:
+		return sqltypes.MakeJSON(raw)
+	}
+	return sqltypes.MakeString(raw)
+}
+
+// isTypedColumn is true for decimal, temporal and json, which are compared and added by sqltypes when merging.
+func isTypedColumn(field *Field) bool {
+	switch field.ColumnType {
+	case MYSQL_TYPE_DECIMAL, MYSQL_TYPE_NEWDECIMAL,
+		MYSQL_TYPE_DATE, MYSQL_TYPE_NEWDATE, MYSQL_TYPE_DATETIME, MYSQL_TYPE_TIMESTAMP,
+		MYSQL_TYPE_DATETIME2, MYSQL_TYPE_TIMESTAMP2, MYSQL_TYPE_TIME, MYSQL_TYPE_TIME2,
+		MYSQL_TYPE_JSON:
+		return true
+	}
+	return false
+}
+
 // Dump the Row as byte array.
 func (r *Row) Dump() []byte {
 	if r.Data != nil {
@@ -171,13 +234,13 @@ func parseAsTextRow(raw []byte, f []*Field) (fieldValues []interface{}, fieldVal
 		if err != nil {
 			return nil, nil, err
 		}
-		fieldValuesCache[i] = StringToLenencStr(v)
-
 		pos += n
 
 		if isNull {
 			fieldValues[i] = nil
+			fieldValuesCache[i] = []byte{0xfb}
 		} else {
+			fieldValuesCache[i] = StringToLenencStr(v)
 			isUnsigned = (f[i].Flags&UNSIGNED_FLAG > 0)
 			switch f[i].ColumnType {
 			case MYSQL_TYPE_TINY, MYSQL_TYPE_SHORT, MYSQL_TYPE_LONG, MYSQL_TYPE_INT24,
@@ -206,167 +269,23 @@ func parseAsTextRow(raw []byte, f []*Field) (fieldValues []interface{}, fieldVal
 func parseAsBinaryRow(raw []byte, f []*Field) (fieldValues []interface{}, nullBitmap []byte, fieldValuesCache [][]byte, err error) {
 	fieldValues = make([]interface{}, len(f))
 	fieldValuesCache = make([][]byte, len(f))
-	if raw[0] != OK_HEADER {
+	pos := 1 + NullBitmapLen(len(f), binaryRowNullOffset)
+	if len(raw) < pos || raw[0] != OK_HEADER {
 		return nil, nil, nil, errors.ErrMalformPacket
 	}
-	fieldValuesCache[0] = []byte{raw[0]}
-	pos := 1 + ((len(f) + 7 + 2) >> 3)
-
 	nullBitmap = raw[1:pos]
 
-	var isUnsigned bool
-	var isNull bool
 	var n int
-
-	var v []byte
 	for i := range fieldValues {
-		if nullBitmap[(i+2)/8]&(1<<(uint(i+2)%8)) > 0 {
-			fieldValues[i] = nil
+		if IsNullBit(nullBitmap, i, binaryRowNullOffset) {
 			continue
 		}
-
-		isUnsigned = f[i].Flags&UNSIGNED_FLAG > 0
-
-		switch f[i].ColumnType {
-		case MYSQL_TYPE_NULL:
-			fieldValues[i] = nil
-			continue
-
-		case MYSQL_TYPE_TINY:
-			if isUnsigned {
-				fieldValues[i] = uint64(raw[pos])
-			} else {
-				fieldValues[i] = int64(raw[pos])
-			}
-			fieldValuesCache[i] = []byte{raw[pos]}
-			pos++
-			continue
-
-		case MYSQL_TYPE_SHORT, MYSQL_TYPE_YEAR:
-			if isUnsigned {
-				fieldValues[i] = uint64(binary.LittleEndian.Uint16(raw[pos : pos+2]))
-			} else {
-				fieldValues[i] = int64((binary.LittleEndian.Uint16(raw[pos : pos+2])))
-			}
-			fieldValuesCache[i] = raw[pos : pos+2]
-			pos += 2
-			continue
-
-		case MYSQL_TYPE_INT24, MYSQL_TYPE_LONG:
-			if isUnsigned {
-				fieldValues[i] = uint64(binary.LittleEndian.Uint32(raw[pos : pos+4]))
-			} else {
-				fieldValues[i] = int64(binary.LittleEndian.Uint32(raw[pos : pos+4]))
-			}
-			fieldValuesCache[i] = raw[pos : pos+4]
-			pos += 4
-			continue
-
-		case MYSQL_TYPE_LONGLONG:
-			if isUnsigned {
-				fieldValues[i] = binary.LittleEndian.Uint64(raw[pos : pos+8])
-			} else {
-				fieldValues[i] = int64(binary.LittleEndian.Uint64(raw[pos : pos+8]))
-			}
-			fieldValuesCache[i] = raw[pos : pos+8]
-			pos += 8
-			continue
-
-		case MYSQL_TYPE_FLOAT:
-			fieldValues[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(raw[pos : pos+4])))
-			fieldValuesCache[i] = raw[pos : pos+4]
-			pos += 4
-			continue
-
-		case MYSQL_TYPE_DOUBLE:
-			fieldValues[i] = math.Float64frombits(binary.LittleEndian.Uint64(raw[pos : pos+8]))
-			fieldValuesCache[i] = raw[pos : pos+8]
-			pos += 8
-			continue
-
-		case MYSQL_TYPE_DECIMAL, MYSQL_TYPE_NEWDECIMAL, MYSQL_TYPE_VARCHAR,
-			MYSQL_TYPE_BIT, MYSQL_TYPE_ENUM, MYSQL_TYPE_SET, MYSQL_TYPE_TINY_BLOB,
-			MYSQL_TYPE_MEDIUM_BLOB, MYSQL_TYPE_LONG_BLOB, MYSQL_TYPE_BLOB,
-			MYSQL_TYPE_VAR_STRING, MYSQL_TYPE_STRING, MYSQL_TYPE_GEOMETRY:
-			v, isNull, n, err = LenencStrToString(raw[pos:])
-			if err != nil {
-				return nil, nil, nil, err
-			}
-			fieldValuesCache[i] = raw[pos : pos+n]
-			pos += n
-
-			if !isNull {
-				fieldValues[i] = v
-				continue
-			} else {
-				fieldValues[i] = nil
-				continue
-			}
-		case MYSQL_TYPE_DATE, MYSQL_TYPE_NEWDATE:
-			var num uint64
-			num, isNull, n = LenencIntToNumber(raw[pos:])
-			if isNull {
-				fieldValuesCache[i] = raw[pos : pos+n]
-			}
-			pos += n
-
-			if isNull {
-				fieldValues[i] = nil
-				continue
-			}
-
-			fieldValues[i], err = FormatBinaryDate(int(num), raw[pos:])
-
-			if err != nil {
-				return nil, nil, nil, err
-			}
-			fieldValuesCache[i] = raw[pos:int(num)]
-			pos += int(num)
-
-		case MYSQL_TYPE_TIMESTAMP, MYSQL_TYPE_DATETIME:
-			var num uint64
-			num, isNull, n = LenencIntToNumber(raw[pos:])
-			if isNull {
-				fieldValuesCache[i] = raw[pos : pos+n]
-			}
-			pos += n
-
-			if isNull {
-				fieldValues[i] = nil
-				continue
-			}
-
-			fieldValues[i], err = FormatBinaryDateTime(int(num), raw[pos:])
-			fieldValuesCache[i] = raw[pos : pos+int(num)]
-			pos += int(num)
-
-			if err != nil {
-				return nil, nil, nil, err
-			}
-
-		case MYSQL_TYPE_TIME:
-			var num uint64
-			num, isNull, n = LenencIntToNumber(raw[pos:])
-			if isNull {
-				fieldValuesCache[i] = raw[pos:n]
-			}
-			pos += n
-
-			if isNull {
-				fieldValues[i] = nil
-				continue
-			}
-
-			fieldValues[i], err = FormatBinaryTime(int(num), raw[pos:])
-			if err != nil {
-				return nil, nil, nil, err
-			}
-			fieldValuesCache[i] = raw[pos:int(num)]
-			pos += int(num)
-
-		default:
-			return nil, nil, nil, fmt.Errorf("Stmt Unknown FieldType %d %s", f[i].ColumnType, f[i].Name)
+		fieldValues[i], n, err = ReadBinaryValue(raw[pos:], f[i].ColumnType, f[i].Flags&UNSIGNED_FLAG > 0)
+		if err != nil {
+			return nil, nil, nil, err
 		}
+		fieldValuesCache[i] = raw[pos : pos+n]
+		pos += n
 	}
 	return
 }
@@ -0,0 +1,819 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mysql
+
+import (
+	"bytes"
+	"container/heap"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/sqlparser"
+	"github.com/berkaroad/saashard/sqlparser/sqltypes"
+)
+
+// DefaultGroupConcatMaxLen is default of group_concat_max_len.
+const DefaultGroupConcatMaxLen = 1024
+
+// Combiner merge partial values of aggregate function from multi nodes.
+type Combiner interface {
+	// Combine partial value of a node into accumulated value, both of them are not NULL.
+	Combine(acc, partial interface{}) (interface{}, error)
+}
+
+// CombinerFunc is func as Combiner.
+type CombinerFunc func(acc, partial interface{}) (interface{}, error)
+
+// Combine partial value into accumulated value.
+func (f CombinerFunc) Combine(acc, partial interface{}) (interface{}, error) {
+	return f(acc, partial)
+}
+
+// AVG isn't combinable, it's rewritten into SUM and COUNT, see Avg of Aggregate.
+var combiners = map[string]Combiner{
+	"count":   CombinerFunc(combineSum),
+	"sum":     CombinerFunc(combineSum),
+	"min":     CombinerFunc(combineMin),
+	"max":     CombinerFunc(combineMax),
+	"bit_or":  CombinerFunc(combineBitOr),
+	"bit_and": CombinerFunc(combineBitAnd),
+	"bit_xor": CombinerFunc(combineBitXor),
+}
+var combinersLock sync.RWMutex
+
+// RegisterCombiner register combiner of aggregate function, such as merging HLL sketches stored as blobs.
+// Name is case insensitive, and built-in combiner could be replaced.
+func RegisterCombiner(name string, combiner Combiner) {
+	combinersLock.Lock()
+	defer combinersLock.Unlock()
+	combiners[strings.ToLower(name)] = combiner
+}
+
+// GetCombiner get combiner of aggregate function.
+func GetCombiner(name string) (Combiner, bool) {
+	combinersLock.RLock()
+	defer combinersLock.RUnlock()
+	combiner, ok := combiners[strings.ToLower(name)]
+	return combiner, ok
+}
+
+// GetFuncCombiner get combiner of aggregate function expression,
+// GROUP_CONCAT is joined by it's separator and truncated to groupConcatMaxLen,
+// it isn't combinable if ordered by other expression than the concatenated one.
+// COUNT, SUM or AVG with DISTINCT isn't combinable, it should be merged by CountDistinct of Aggregate.
+func GetFuncCombiner(funcExpr *sqlparser.FuncExpr, groupConcatMaxLen int) (Combiner, bool) {
+	name := strings.ToLower(string(funcExpr.Name))
+	if name != "group_concat" {
+		if funcExpr.Distinct && name != "min" && name != "max" {
+			return nil, false
+		}
+		return GetCombiner(name)
+	}
+	separator := []byte(",")
+	if funcExpr.Separator != nil {
+		separator = funcExpr.Separator
+	}
+	// Fragments could be re-sorted only when ordered by the concatenated expression itself.
+	var ordered, desc bool
+	if len(funcExpr.OrderBy) == 1 && len(funcExpr.Exprs) == 1 &&
+		sqlparser.String(funcExpr.OrderBy[0].Expr) == sqlparser.String(funcExpr.Exprs[0]) {
+		ordered = true
+		desc = funcExpr.OrderBy[0].Direction == sqlparser.AST_DESC
+	} else if len(funcExpr.OrderBy) > 0 {
+		return nil, false
+	}
+	return NewGroupConcatCombiner(separator, groupConcatMaxLen, ordered, desc, funcExpr.Distinct), true
+}
+
+// NewGroupConcatCombiner create combiner of GROUP_CONCAT, fragments of nodes are joined by separator,
+// and truncated to maxLen bytes. If ordered or distinct, fragments are split into elements by separator,
+// then elements are sorted in direction desc or not, and duplicated elements are removed.
+func NewGroupConcatCombiner(separator []byte, maxLen int, ordered, desc, distinct bool) Combiner {
+	if maxLen <= 0 {
+		maxLen = DefaultGroupConcatMaxLen
+	}
+	return CombinerFunc(func(acc, partial interface{}) (interface{}, error) {
+		x, y := toBytes(acc), toBytes(partial)
+		var merged []byte
+		if !ordered && !distinct {
+			merged = make([]byte, 0, len(x)+len(separator)+len(y))
+			merged = append(append(append(merged, x...), separator...), y...)
+		} else {
+			elements := append(bytes.Split(x, separator), bytes.Split(y, separator)...)
+			if ordered {
+				sort.SliceStable(elements, func(i, j int) bool {
+					if desc {
+						return compareElement(elements[i], elements[j]) > 0
+					}
+					return compareElement(elements[i], elements[j]) < 0
+				})
+			}
+			if distinct {
+				seen := make(map[string]bool, len(elements))
+				unique := elements[:0]
+				for _, element := range elements {
+					if !seen[string(element)] {
+						seen[string(element)] = true
+						unique = append(unique, element)
+					}
+				}
+				elements = unique
+			}
+			merged = bytes.Join(elements, separator)
+		}
+		if len(merged) > maxLen {
+			merged = merged[:maxLen]
+		}
+		if _, ok := acc.(string); ok {
+			return string(merged), nil
+		}
+		return merged, nil
+	})
+}
+
+// Aggregate is column of aggregate function, and it's combiner.
+type Aggregate struct {
+	Column   int
+	Combiner Combiner
+
+	// CountDistinct column is distinct values of nodes, COUNT(DISTINCT x) is rewritten into
+	// x with GROUP BY x at each node, then values are counted per group, Combiner is ignored.
+	CountDistinct bool
+
+	// Avg column is SUM of nodes combined by Combiner, it's divided by COUNT at CountColumn after merged.
+	// Count columns of AVG are the last columns, they aren't in merged rows.
+	Avg         bool
+	CountColumn int
+}
+
+// RowAggregator merge partial aggregated rows of multi nodes, rows with the same GROUP BY columns
+// are combined into one row, NULL of aggregate columns are skipped, and other columns keep the first value.
+// Groups and distinct values are accounted by tracker, when memory budget is exceeded, groups are sorted
+// by GROUP BY columns and spilled to disk as a run of partial aggregates, runs are combined when reading.
+// Rows are read in order of first appended, or in order of GROUP BY columns if spilled.
+// GROUP BY columns of "_ci" collation are compared case insensitively, and trailing spaces are ignored
+// except "_0900_" collation, like mysql.
+type RowAggregator struct {
+	fields     []*Field
+	groupBy    []int
+	aggregates []Aggregate
+	tracker    MemoryTracker
+	columns    int // columns of merged rows, count columns of AVG are removed.
+
+	groups  []*aggregateGroup
+	index   map[string]int
+	used    int64
+	runs    []*SpillFile
+	spilled int64
+}
+
+// aggregateGroup is partial aggregate of rows with the same GROUP BY columns.
+type aggregateGroup struct {
+	key       string
+	values    []interface{}
+	distincts map[int]map[string]bool // distinct values of CountDistinct columns.
+	size      int64                   // bytes accounted by tracker.
+}
+
+// NewRowAggregator create aggregator of text rows, groupBy is empty if no GROUP BY, tracker could be nil.
+func NewRowAggregator(fields []*Field, groupBy []int, aggregates []Aggregate, tracker MemoryTracker) *RowAggregator {
+	columns := len(fields)
+	for _, aggregate := range aggregates {
+		if aggregate.Avg && aggregate.CountColumn < columns {
+			columns = aggregate.CountColumn
+		}
+	}
+	return &RowAggregator{
+		fields:     fields,
+		groupBy:    groupBy,
+		aggregates: aggregates,
+		tracker:    tracker,
+		columns:    columns,
+		index:      make(map[string]int),
+	}
+}
+
+// Append partial row of a node.
+func (a *RowAggregator) Append(row *Row) error {
+	group := a.newGroup(row)
+	i, ok := a.index[group.key]
+	if !ok {
+		group.size = int64(len(group.key) + len(row.Dump()))
+		for _, set := range group.distincts {
+			for value := range set {
+				group.size += int64(len(value))
+			}
+		}
+		a.index[group.key] = len(a.groups)
+		a.groups = append(a.groups, group)
+		return a.charge(group.size)
+	}
+	n, err := a.combine(a.groups[i], group)
+	if err != nil {
+		return err
+	}
+	a.groups[i].size += n
+	return a.charge(n)
+}
+
+// newGroup of a row, CountDistinct columns are count of distinct values.
+func (a *RowAggregator) newGroup(row *Row) *aggregateGroup {
+	var key bytes.Buffer
+	for _, column := range a.groupBy {
+		if column < 0 || column >= len(row.fieldValuesCache) {
+			continue
+		} else if row.GetValue(column) == nil || column >= len(a.fields) {
+			key.Write(row.fieldValuesCache[column])
+		} else {
+			key.Write(StringToLenencStr(collationKey(a.fields[column], row.GetRawValue(column))))
+		}
+	}
+	group := &aggregateGroup{key: key.String(), values: make([]interface{}, len(a.fields))}
+	for i := range group.values {
+		group.values[i] = a.value(row, i)
+	}
+	for _, aggregate := range a.aggregates {
+		if !aggregate.CountDistinct || aggregate.Column < 0 || aggregate.Column >= len(group.values) {
+			continue
+		}
+		set := make(map[string]bool)
+		if group.values[aggregate.Column] != nil {
+			set[string(row.fieldValuesCache[aggregate.Column])] = true
+		}
+		if group.distincts == nil {
+			group.distincts = make(map[int]map[string]bool)
+		}
+		group.distincts[aggregate.Column] = set
+		group.values[aggregate.Column] = int64(len(set))
+	}
+	return group
+}
+
+// combine partial group into accumulated group, return bytes of added distinct values.
+func (a *RowAggregator) combine(acc, partial *aggregateGroup) (int64, error) {
+	var n int64
+	for _, aggregate := range a.aggregates {
+		if aggregate.Column < 0 || aggregate.Column >= len(acc.values) {
+			continue
+		}
+		if aggregate.CountDistinct {
+			set := acc.distincts[aggregate.Column]
+			for value := range partial.distincts[aggregate.Column] {
+				if !set[value] {
+					set[value] = true
+					n += int64(len(value))
+				}
+			}
+			acc.values[aggregate.Column] = int64(len(set))
+			continue
+		}
+		value := partial.values[aggregate.Column]
+		if value == nil {
+			continue
+		} else if acc.values[aggregate.Column] == nil {
+			acc.values[aggregate.Column] = value
+			continue
+		}
+		value, err := aggregate.Combiner.Combine(acc.values[aggregate.Column], value)
+		if err != nil {
+			return n, err
+		}
+		acc.values[aggregate.Column] = value
+	}
+	return n, nil
+}
+
+// charge bytes to tracker, groups are spilled if memory budget is exceeded.
+func (a *RowAggregator) charge(n int64) error {
+	if a.tracker == nil || n == 0 {
+		return nil
+	}
+	a.used += n
+	if err := a.tracker.Consume(n); err != nil {
+		if len(a.tracker.SpillDir()) == 0 {
+			return err
+		}
+		return a.spill()
+	}
+	return nil
+}
+
+// value of column, decimal, temporal and json are typed by sqltypes, so that they're exact in combiners.
+func (a *RowAggregator) value(row *Row, column int) interface{} {
+	if column < len(a.fields) && isTypedColumn(a.fields[column]) {
+		if v := row.GetSQLValue(column); !v.IsNull() {
+			return v
+		}
+		return nil
+	}
+	return row.GetValue(column)
+}
+
+// spill groups to disk as a run sorted by key.
+func (a *RowAggregator) spill() error {
+	var maxSize int64
+	if maxSize = a.tracker.SpillMaxSize(); maxSize > 0 {
+		if maxSize -= a.spilled; maxSize <= 0 {
+			return errors.ErrExceedSpillSize
+		}
+	}
+	run, err := NewSpillFile(a.tracker.SpillDir(), maxSize)
+	if err != nil {
+		return err
+	}
+	a.runs = append(a.runs, run)
+
+	sort.SliceStable(a.groups, func(i, j int) bool { return a.groups[i].key < a.groups[j].key })
+	for _, group := range a.groups {
+		if err = run.Append(a.dumpGroup(group)); err != nil {
+			return err
+		}
+	}
+	a.spilled += run.Size()
+	a.release()
+	return nil
+}
+
+// spillFields is fields of spilled group, key and packed distinct values of CountDistinct columns follow values.
+func (a *RowAggregator) spillFields() []*Field {
+	fields := append([]*Field{}, a.fields...)
+	for i := 0; i <= len(a.aggregates); i++ {
+		fields = append(fields, &Field{ColumnType: MYSQL_TYPE_BLOB, Charset: uint16(CollationNames["binary"]), Flags: BINARY_FLAG})
+	}
+	return fields
+}
+
+func (a *RowAggregator) dumpGroup(group *aggregateGroup) []byte {
+	row := NewTextRow(a.spillFields())
+	for _, v := range group.values {
+		appendValue(row, v)
+	}
+	row.AppendStringValue(group.key)
+	for _, aggregate := range a.aggregates {
+		var packed []byte
+		for value := range group.distincts[aggregate.Column] {
+			packed = append(packed, StringToLenencStr([]byte(value))...)
+		}
+		row.AppendStringValue(string(packed))
+	}
+	return row.Dump()
+}
+
+func (a *RowAggregator) parseGroup(data []byte) (*aggregateGroup, error) {
+	row, err := RowData(data).Parse(false, a.spillFields())
+	if err != nil {
+		return nil, err
+	}
+	group := &aggregateGroup{key: string(row.GetRawValue(len(a.fields))), values: make([]interface{}, len(a.fields))}
+	for i := range group.values {
+		group.values[i] = a.value(row, i)
+	}
+	for i, aggregate := range a.aggregates {
+		if !aggregate.CountDistinct || aggregate.Column < 0 || aggregate.Column >= len(group.values) {
+			continue
+		}
+		set := make(map[string]bool)
+		for packed := row.GetRawValue(len(a.fields) + 1 + i); len(packed) > 0; {
+			value, _, n, err := LenencStrToString(packed)
+			if err != nil {
+				return nil, err
+			}
+			set[string(value)] = true
+			packed = packed[n:]
+		}
+		if group.distincts == nil {
+			group.distincts = make(map[int]map[string]bool)
+		}
+		group.distincts[aggregate.Column] = set
+	}
+	return group, nil
+}
+
+// Each read merged rows, they're parsed as rows of nodes, so that they could be sorted by their values.
+// If no GROUP BY and no rows, a row is read like mysql, CountDistinct columns are 0 and others are NULL.
+// Groups are released as they're read, so that rows aren't accounted twice when consumer is a RowSorter,
+// and Each could be called only once.
+func (a *RowAggregator) Each(fn func(row *Row) error) error {
+	if len(a.runs) > 0 {
+		return a.eachSpilled(fn)
+	}
+	if len(a.groups) == 0 && len(a.groupBy) == 0 {
+		values := make([]interface{}, len(a.fields))
+		for _, aggregate := range a.aggregates {
+			if aggregate.CountDistinct && aggregate.Column >= 0 && aggregate.Column < len(values) {
+				values[aggregate.Column] = int64(0)
+			}
+		}
+		return a.emit(&aggregateGroup{values: values}, fn)
+	}
+	for i, group := range a.groups {
+		a.groups[i] = nil
+		if err := a.emit(group, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eachSpilled combine groups of the same key in runs and memory, in order of key.
+func (a *RowAggregator) eachSpilled(fn func(row *Row) error) error {
+	sort.SliceStable(a.groups, func(i, j int) bool { return a.groups[i].key < a.groups[j].key })
+	h := &groupHeap{}
+	for i, run := range a.runs {
+		r, err := run.NewReader()
+		if err != nil {
+			return err
+		}
+		h.cursors = append(h.cursors, &groupCursor{order: i, reader: r})
+	}
+	h.cursors = append(h.cursors, &groupCursor{order: len(a.runs), groups: a.groups})
+	for i := len(h.cursors) - 1; i >= 0; i-- {
+		if err := h.cursors[i].next(a); err == io.EOF {
+			h.cursors = append(h.cursors[:i], h.cursors[i+1:]...)
+		} else if err != nil {
+			return err
+		}
+	}
+	heap.Init(h)
+	for h.Len() > 0 {
+		var acc *aggregateGroup
+		for h.Len() > 0 && (acc == nil || h.cursors[0].group.key == acc.key) {
+			cursor := h.cursors[0]
+			if acc == nil {
+				acc = cursor.group
+			} else if _, err := a.combine(acc, cursor.group); err != nil {
+				return err
+			} else {
+				a.releaseGroup(cursor.group)
+			}
+			if err := cursor.next(a); err == io.EOF {
+				heap.Pop(h)
+			} else if err != nil {
+				return err
+			} else {
+				heap.Fix(h, 0)
+			}
+		}
+		if err := a.emit(acc, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// emit group as a row, and release it.
+func (a *RowAggregator) emit(group *aggregateGroup, fn func(row *Row) error) error {
+	for _, aggregate := range a.aggregates {
+		if aggregate.Avg && aggregate.Column < len(group.values) && aggregate.CountColumn < len(group.values) {
+			avg, err := avgValue(group.values[aggregate.Column], group.values[aggregate.CountColumn], a.fields[aggregate.Column])
+			if err != nil {
+				return err
+			}
+			group.values[aggregate.Column] = avg
+		}
+	}
+	fields := a.fields[:a.columns]
+	row := NewTextRow(fields)
+	for _, v := range group.values[:a.columns] {
+		appendValue(row, v)
+	}
+	parsed, err := RowData(row.Dump()).Parse(false, fields)
+	if err != nil {
+		return err
+	}
+	parsed.Data = row.Data
+	a.releaseGroup(group)
+	return fn(parsed)
+}
+
+// releaseGroup release accounted bytes of group in memory.
+func (a *RowAggregator) releaseGroup(group *aggregateGroup) {
+	if a.tracker != nil && group.size > 0 {
+		a.tracker.Consume(-group.size)
+		a.used -= group.size
+		group.size = 0
+	}
+}
+
+// Close release groups and remove spilled runs.
+func (a *RowAggregator) Close() error {
+	a.release()
+	var err error
+	for _, run := range a.runs {
+		if e := run.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	a.runs = nil
+	return err
+}
+
+func (a *RowAggregator) release() {
+	if a.tracker != nil && a.used > 0 {
+		a.tracker.Consume(-a.used)
+	}
+	a.groups = nil
+	a.index = make(map[string]int)
+	a.used = 0
+}
+
+// groupCursor read groups of a sorted run, or groups in memory.
+type groupCursor struct {
+	order  int
+	group  *aggregateGroup
+	groups []*aggregateGroup
+	reader *SpillReader
+}
+
+func (c *groupCursor) next(a *RowAggregator) error {
+	if c.reader == nil {
+		if len(c.groups) == 0 {
+			return io.EOF
+		}
+		c.group, c.groups = c.groups[0], c.groups[1:]
+		return nil
+	}
+	data, err := c.reader.Next()
+	if err != nil {
+		return err
+	}
+	c.group, err = a.parseGroup(data)
+	return err
+}
+
+// groupHeap merge sorted runs by key, groups of earlier runs are first.
+type groupHeap struct {
+	cursors []*groupCursor
+}
+
+func (h *groupHeap) Len() int { return len(h.cursors) }
+
+func (h *groupHeap) Less(i, j int) bool {
+	if h.cursors[i].group.key != h.cursors[j].group.key {
+		return h.cursors[i].group.key < h.cursors[j].group.key
+	}
+	return h.cursors[i].order < h.cursors[j].order
+}
+
+func (h *groupHeap) Swap(i, j int) { h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i] }
+
+func (h *groupHeap) Push(x interface{}) { h.cursors = append(h.cursors, x.(*groupCursor)) }
+
+func (h *groupHeap) Pop() interface{} {
+	n := len(h.cursors)
+	x := h.cursors[n-1]
+	h.cursors = h.cursors[:n-1]
+	return x
+}
+
+// avgValue divide sum by count, decimal is rounded to decimals of field, NULL if count is 0.
+func avgValue(sum, count interface{}, field *Field) (interface{}, error) {
+	if sum == nil || count == nil {
+		return nil, nil
+	}
+	n, ok := new(big.Rat).SetString(string(toBytes(count)))
+	if !ok {
+		return nil, fmt.Errorf("count of avg is %v", count)
+	} else if n.Sign() == 0 {
+		return nil, nil
+	}
+	switch field.ColumnType {
+	case MYSQL_TYPE_DECIMAL, MYSQL_TYPE_NEWDECIMAL:
+		x, ok := new(big.Rat).SetString(string(toBytes(sum)))
+		if !ok {
+			return nil, fmt.Errorf("sum of avg is %v", sum)
+		}
+		return x.Quo(x, n).FloatString(int(field.Decimals)), nil
+	}
+	x, err := toNumber(sum)
+	if err != nil {
+		return nil, err
+	}
+	y, _ := n.Float64()
+	return x / y, nil
+}
+
+// collationKey of text value, that values equal in collation of field have the same key.
+func collationKey(field *Field, value []byte) []byte {
+	switch field.ColumnType {
+	case MYSQL_TYPE_VARCHAR, MYSQL_TYPE_VAR_STRING, MYSQL_TYPE_STRING, MYSQL_TYPE_ENUM, MYSQL_TYPE_SET,
+		MYSQL_TYPE_TINY_BLOB, MYSQL_TYPE_MEDIUM_BLOB, MYSQL_TYPE_LONG_BLOB, MYSQL_TYPE_BLOB:
+	default:
+		return value
+	}
+	collation := Collations[CollationID(field.Charset)]
+	if collation == "" || collation == "binary" {
+		return value
+	}
+	if !strings.Contains(collation, "_0900_") {
+		value = bytes.TrimRight(value, " ")
+	}
+	if !strings.HasSuffix(collation, "_ci") {
+		return value
+	}
+	charset := collation[:strings.Index(collation+"_", "_")]
+	if strings.HasPrefix(charset, "utf8") && utf8.Valid(value) {
+		return bytes.Map(unicode.ToLower, bytes.Map(unicode.ToUpper, value))
+	} else if CharsetInfos[charset].MaxLen != 1 && !isASCII(value) {
+		// trailing bytes of multi-byte charset such as gbk could be ascii letters, they aren't folded.
+		return value
+	}
+	// only ascii letters are folded in other charsets, such as latin1.
+	folded := make([]byte, len(value))
+	for i, c := range value {
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		folded[i] = c
+	}
+	return folded
+}
+
+func isASCII(value []byte) bool {
+	for _, c := range value {
+		if c >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func appendValue(row *Row, v interface{}) {
+	switch x := v.(type) {
+	case nil:
+		row.AppendNullValue()
+	case int64:
+		row.AppendIntValue(x)
+	case uint64:
+		row.AppendUIntValue(x)
+	case float64:
+		row.AppendStringValue(strconv.FormatFloat(x, 'f', -1, 64))
+	case string:
+		row.AppendStringValue(x)
+	case []byte:
+		row.AppendStringValue(string(x))
+	case sqltypes.Value:
+		if x.IsNull() {
+			row.AppendNullValue()
+		} else {
+			row.AppendStringValue(x.String())
+		}
+	default:
+		row.AppendStringValue(fmt.Sprint(x))
+	}
+}
+
+func combineSum(acc, partial interface{}) (interface{}, error) {
+	switch x := acc.(type) {
+	case sqltypes.Value:
+		if y, ok := partial.(sqltypes.Value); ok {
+			return sqltypes.Add(x, y)
+		}
+	case int64:
+		switch y := partial.(type) {
+		case int64:
+			return x + y, nil
+		case uint64:
+			if x >= 0 {
+				return uint64(x) + y, nil
+			}
+		}
+	case uint64:
+		switch y := partial.(type) {
+		case uint64:
+			return x + y, nil
+		case int64:
+			if y >= 0 {
+				return x + uint64(y), nil
+			}
+		}
+	}
+	x, err := toNumber(acc)
+	if err != nil {
+		return nil, err
+	}
+	y, err := toNumber(partial)
+	if err != nil {
+		return nil, err
+	}
+	return x + y, nil
+}
+
+func combineMin(acc, partial interface{}) (interface{}, error) {
+	if compareValue(partial, acc) < 0 {
+		return partial, nil
+	}
+	return acc, nil
+}
+
+func combineMax(acc, partial interface{}) (interface{}, error) {
+	if compareValue(partial, acc) > 0 {
+		return partial, nil
+	}
+	return acc, nil
+}
+
+func combineBitOr(acc, partial interface{}) (interface{}, error) {
+	return combineBits(acc, partial, func(x, y uint64) uint64 { return x | y })
+}
+
+func combineBitAnd(acc, partial interface{}) (interface{}, error) {
+	return combineBits(acc, partial, func(x, y uint64) uint64 { return x & y })
+}
+
+func combineBitXor(acc, partial interface{}) (interface{}, error) {
+	return combineBits(acc, partial, func(x, y uint64) uint64 { return x ^ y })
+}
+
+func combineBits(acc, partial interface{}, op func(x, y uint64) uint64) (interface{}, error) {
+	x, err := toUint(acc)
+	if err != nil {
+		return nil, err
+	}
+	y, err := toUint(partial)
+	if err != nil {
+		return nil, err
+	}
+	return op(x, y), nil
+}
+
+// toNumber convert value to float, text of decimal is parsed.
+func toNumber(v interface{}) (float64, error) {
+	switch x := v.(type) {
+	case string:
+		return strconv.ParseFloat(x, 64)
+	case []byte:
+		return strconv.ParseFloat(string(x), 64)
+	case sqltypes.Value:
+		return strconv.ParseFloat(x.String(), 64)
+	case int64, uint64, float64:
+		return toFloat(x), nil
+	}
+	return 0, fmt.Errorf("data type is %T", v)
+}
+
+func toBytes(v interface{}) []byte {
+	switch x := v.(type) {
+	case []byte:
+		return x
+	case string:
+		return []byte(x)
+	case sqltypes.Value:
+		return x.Raw()
+	}
+	return []byte(fmt.Sprint(v))
+}
+
+// compareElement of GROUP_CONCAT, numbers are compared by value.
+func compareElement(a, b []byte) int {
+	x, errX := strconv.ParseFloat(string(a), 64)
+	y, errY := strconv.ParseFloat(string(b), 64)
+	if errX == nil && errY == nil {
+		return sign(x < y, x > y)
+	}
+	return bytes.Compare(a, b)
+}
+
+func toUint(v interface{}) (uint64, error) {
+	switch x := v.(type) {
+	case uint64:
+		return x, nil
+	case int64:
+		return uint64(x), nil
+	case float64:
+		return uint64(x), nil
+	case string:
+		return strconv.ParseUint(x, 10, 64)
+	case []byte:
+		return strconv.ParseUint(string(x), 10, 64)
+	}
+	return 0, fmt.Errorf("data type is %T", v)
+}
@@ -0,0 +1,268 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mysql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+// aggregateRows merge rows of nodes, values of row are separated by ',', return merged rows as strings.
+func aggregateRows(t *testing.T, fields []*Field, groupBy []int, aggregates []Aggregate, nodes ...[]string) string {
+	tracker := &testTracker{budget: 1 << 20}
+	aggregator := NewRowAggregator(fields, groupBy, aggregates, tracker)
+	for _, rows := range nodes {
+		for _, values := range rows {
+			if err := aggregator.Append(newTestRow(t, fields, strings.Split(values, ",")...)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	var got []string
+	err := aggregator.Each(func(row *Row) error {
+		values := make([]string, len(fields))
+		for i := range values {
+			if row.GetValue(i) == nil {
+				values[i] = "NULL"
+			} else {
+				values[i] = string(row.GetRawValue(i))
+			}
+		}
+		got = append(got, strings.Join(values, ","))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = aggregator.Close(); err != nil || tracker.used != 0 {
+		t.Errorf("Close() = %v, used = %d, want nil and 0", err, tracker.used)
+	}
+	return strings.Join(got, " ")
+}
+
+func getTestCombiner(t *testing.T, name string) Combiner {
+	combiner, ok := GetCombiner(name)
+	if !ok {
+		t.Fatalf("GetCombiner(%q) not found", name)
+	}
+	return combiner
+}
+
+func TestRowAggregatorSumDecimal(t *testing.T) {
+	fields := []*Field{newTestField("g", MYSQL_TYPE_VAR_STRING), newTestField("s", MYSQL_TYPE_NEWDECIMAL),
+		newTestField("c", MYSQL_TYPE_LONGLONG)}
+	aggregates := []Aggregate{{Column: 1, Combiner: getTestCombiner(t, "sum")}, {Column: 2, Combiner: getTestCombiner(t, "count")}}
+	got := aggregateRows(t, fields, []int{0}, aggregates,
+		[]string{"a,0.10,1", "b,12345678901234567890.000001,2", "c,NULL,0"},
+		[]string{"b,0.000002,3", "a,0.20,4", "c,NULL,0", "d,-1.5,1"})
+	want := "a,0.30,5 b,12345678901234567890.000003,5 c,NULL,0 d,-1.5,1"
+	if got != want {
+		t.Errorf("merged rows = %s, want %s", got, want)
+	}
+}
+
+func TestRowAggregatorMinMaxDatetime(t *testing.T) {
+	fields := []*Field{newTestField("min", MYSQL_TYPE_DATETIME), newTestField("max", MYSQL_TYPE_DATETIME),
+		newTestField("t", MYSQL_TYPE_TIME)}
+	aggregates := []Aggregate{{Column: 0, Combiner: getTestCombiner(t, "min")}, {Column: 1, Combiner: getTestCombiner(t, "max")},
+		{Column: 2, Combiner: getTestCombiner(t, "max")}}
+	got := aggregateRows(t, fields, nil, aggregates,
+		[]string{"NULL,NULL,NULL"},
+		[]string{"2016-01-02 03:04:05.5,2016-01-02 03:04:05.5,100:00:00"},
+		[]string{"2016-01-02 03:04:05.25,2016-01-02 03:04:05.75,9:59:59"},
+		[]string{"2015-12-31 23:59:59,2015-12-31 23:59:59,NULL"})
+	want := "2015-12-31 23:59:59,2016-01-02 03:04:05.75,100:00:00"
+	if got != want {
+		t.Errorf("merged rows = %s, want %s", got, want)
+	}
+}
+
+func TestRowAggregatorNull(t *testing.T) {
+	fields := []*Field{newTestField("g", MYSQL_TYPE_LONGLONG), newTestField("sum", MYSQL_TYPE_LONGLONG),
+		newTestField("min", MYSQL_TYPE_VAR_STRING), newTestField("x", MYSQL_TYPE_VAR_STRING)}
+	aggregates := []Aggregate{{Column: 1, Combiner: getTestCombiner(t, "sum")}, {Column: 2, Combiner: getTestCombiner(t, "min")}}
+	// NULL of aggregate is skipped, NULL of group by is a group, other column keeps the first value.
+	got := aggregateRows(t, fields, []int{0}, aggregates,
+		[]string{"1,NULL,NULL,x1", "NULL,2,b,x2"},
+		[]string{"1,3,c,x3", "NULL,NULL,a,x4", "2,NULL,NULL,x5"},
+		[]string{"1,4,NULL,x6", "2,NULL,NULL,x7"})
+	want := "1,7,c,x1 NULL,2,a,x2 2,NULL,NULL,x5"
+	if got != want {
+		t.Errorf("merged rows = %s, want %s", got, want)
+	}
+}
+
+func TestRegisterCombiner(t *testing.T) {
+	// sketches are merged by bitwise or of registers.
+	RegisterCombiner("TEST_SKETCH_MERGE", CombinerFunc(func(acc, partial interface{}) (interface{}, error) {
+		x, y := toBytes(acc), toBytes(partial)
+		merged := append([]byte{}, x...)
+		for i := range y {
+			if i < len(merged) {
+				merged[i] |= y[i]
+			} else {
+				merged = append(merged, y[i])
+			}
+		}
+		return merged, nil
+	}))
+	statement, err := sqlparser.Parse("select test_sketch_merge(s) from t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	funcExpr := statement.(*sqlparser.Select).SelectExprs[0].(*sqlparser.NonStarExpr).Expr.(*sqlparser.FuncExpr)
+	combiner, ok := GetFuncCombiner(funcExpr, 0)
+	if !ok {
+		t.Fatalf("GetFuncCombiner(%s) not found", sqlparser.String(funcExpr))
+	}
+	fields := []*Field{newTestField("s", MYSQL_TYPE_BLOB)}
+	got := aggregateRows(t, fields, nil, []Aggregate{{Column: 0, Combiner: combiner}},
+		[]string{"AB"}, []string{"NULL"}, []string{"ab\x01"})
+	if want := "ab\x01"; got != want {
+		t.Errorf("merged rows = %q, want %q", got, want)
+	}
+}
+
+func TestRowAggregatorSpill(t *testing.T) {
+	fields := []*Field{newTestField("g", MYSQL_TYPE_VAR_STRING), newTestField("sum", MYSQL_TYPE_LONGLONG),
+		newTestField("d", MYSQL_TYPE_VAR_STRING), newTestField("x", MYSQL_TYPE_VAR_STRING)}
+	aggregates := []Aggregate{{Column: 1, Combiner: getTestCombiner(t, "sum")}, {Column: 2, CountDistinct: true}}
+	tracker := &testTracker{budget: 48, dir: t.TempDir()}
+	aggregator := NewRowAggregator(fields, []int{0}, aggregates, tracker)
+	defer aggregator.Close()
+
+	// rows of three nodes, distinct values of d are repeated across nodes.
+	for _, node := range [][]string{
+		{"e,1,v1,x1", "c,2,v1,x2", "a,3,v1,x3", "d,4,NULL,x4"},
+		{"c,5,v2,x5", "a,NULL,v1,x6", "b,6,v3,x7", "e,7,v1,x8"},
+		{"a,8,v2,x9", "b,9,v3,x10", "c,10,v1,x11", "d,11,NULL,x12"},
+	} {
+		for _, values := range node {
+			if err := aggregator.Append(newTestRow(t, fields, strings.Split(values, ",")...)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if len(aggregator.runs) < 2 {
+		t.Fatalf("spilled runs = %d, want at least 2", len(aggregator.runs))
+	}
+
+	var got []string
+	err := aggregator.Each(func(row *Row) error {
+		values := make([]string, len(fields))
+		for i := range values {
+			values[i] = string(row.GetRawValue(i))
+		}
+		got = append(got, strings.Join(values, ","))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// groups are combined across runs in order of key, other column keeps the first value.
+	if want := "a,11,2,x3 b,15,1,x7 c,17,2,x2 d,15,0,x4 e,8,1,x1"; strings.Join(got, " ") != want {
+		t.Errorf("merged rows = %q, want %q", strings.Join(got, " "), want)
+	}
+	if err = aggregator.Close(); err != nil || tracker.used != 0 {
+		t.Errorf("Close() = %v, used = %d, want nil and 0", err, tracker.used)
+	}
+}
+
+func TestRowAggregatorSortedOnce(t *testing.T) {
+	fields := []*Field{newTestField("g", MYSQL_TYPE_LONGLONG), newTestField("sum", MYSQL_TYPE_LONGLONG)}
+	tracker := &testTracker{budget: 1 << 20}
+	aggregator := NewRowAggregator(fields, []int{0}, []Aggregate{{Column: 1, Combiner: getTestCombiner(t, "sum")}}, tracker)
+	defer aggregator.Close()
+	for _, values := range []string{"3,1", "1,2", "2,3", "1,4", "3,5"} {
+		if err := aggregator.Append(newTestRow(t, fields, strings.Split(values, ",")...)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if aggregator.used == 0 || tracker.used != aggregator.used {
+		t.Fatalf("accounted groups = %d, tracker = %d", aggregator.used, tracker.used)
+	}
+
+	// groups move from aggregator to sorter, they're accounted once.
+	sorter := NewRowSorter(fields, []SortKey{{Column: 0}}, tracker)
+	defer sorter.Close()
+	if err := aggregator.Each(sorter.Append); err != nil {
+		t.Fatal(err)
+	}
+	if aggregator.used != 0 || tracker.used != sorter.used {
+		t.Errorf("accounted groups = %d, sorted rows = %d, tracker = %d, want 0, %d, %d",
+			aggregator.used, sorter.used, tracker.used, tracker.used, sorter.used)
+	}
+}
+
+func TestRowAggregatorCollation(t *testing.T) {
+	utf8Field := newTestField("g", MYSQL_TYPE_VAR_STRING)
+	binField := newTestField("g", MYSQL_TYPE_VAR_STRING)
+	binField.Charset = uint16(CollationNames["utf8mb4_bin"])
+	latin1Field := newTestField("g", MYSQL_TYPE_VAR_STRING)
+	latin1Field.Charset = uint16(CollationNames["latin1_swedish_ci"])
+	gbkField := newTestField("g", MYSQL_TYPE_VAR_STRING)
+	gbkField.Charset = uint16(CollationNames["gbk_chinese_ci"])
+	cases := []struct {
+		field *Field
+		rows  []string
+		want  string
+	}{
+		// "_ci" folds case, and PAD SPACE collation ignores trailing spaces, the first value is kept.
+		{utf8Field, []string{"Ab,1", "aB ,2", "ÄB,3", "äb,4", "a,5", "NULL,6"}, "Ab,3 ÄB,7 a,5 NULL,6"},
+		{binField, []string{"Ab,1", "aB,2", "Ab ,3"}, "Ab,4 aB,2"},
+		{latin1Field, []string{"A\xc4,1", "a\xc4,2", "a\xe4,3"}, "A\xc4,3 a\xe4,3"},
+		{gbkField, []string{"\x81A,1", "\x81a,2", "B,3", "b,4"}, "\x81A,1 \x81a,2 B,7"},
+	}
+	for _, c := range cases {
+		fields := []*Field{c.field, newTestField("sum", MYSQL_TYPE_LONGLONG)}
+		got := aggregateRows(t, fields, []int{0}, []Aggregate{{Column: 1, Combiner: getTestCombiner(t, "sum")}}, c.rows)
+		if got != c.want {
+			t.Errorf("merged rows of %s = %q, want %q", Collations[CollationID(c.field.Charset)], got, c.want)
+		}
+	}
+}
+
+func TestRowAggregatorMinMaxTemporal(t *testing.T) {
+	fields := []*Field{newTestField("g", MYSQL_TYPE_LONGLONG), newTestField("min_dt", MYSQL_TYPE_DATETIME),
+		newTestField("max_dt", MYSQL_TYPE_DATETIME), newTestField("min_t", MYSQL_TYPE_TIME), newTestField("max_t", MYSQL_TYPE_TIME),
+		newTestField("min_d", MYSQL_TYPE_DATE)}
+	for _, field := range fields[1:5] {
+		field.Decimals = 6
+	}
+	aggregates := []Aggregate{{Column: 1, Combiner: getTestCombiner(t, "min")}, {Column: 2, Combiner: getTestCombiner(t, "max")},
+		{Column: 3, Combiner: getTestCombiner(t, "min")}, {Column: 4, Combiner: getTestCombiner(t, "max")},
+		{Column: 5, Combiner: getTestCombiner(t, "min")}}
+	// zero date is the smallest, negative TIME is less than zero, microseconds of DATETIME(6) are compared exactly.
+	got := aggregateRows(t, fields, []int{0}, aggregates,
+		[]string{"1,2016-01-02 03:04:05.000010,2016-01-02 03:04:05.000010,-01:00:00.500000,-01:00:00.500000,2016-01-02",
+			"2,2016-01-02 03:04:05.000001,2016-01-02 03:04:05.000001,00:00:00.000001,00:00:00.000001,NULL"},
+		[]string{"1,2016-01-02 03:04:05.000002,2016-01-02 03:04:05.000020,-01:00:00.000000,-838:59:59.000000,0000-00-00",
+			"2,0000-00-00 00:00:00.000000,2016-01-02 03:04:05.000002,-10:00:00.000000,-00:00:00.000001,2015-12-31"})
+	want := "1,2016-01-02 03:04:05.000002,2016-01-02 03:04:05.000020,-01:00:00.500000,-01:00:00.500000,0000-00-00 " +
+		"2,0000-00-00 00:00:00.000000,2016-01-02 03:04:05.000002,-10:00:00.000000,00:00:00.000001,2015-12-31"
+	if got != want {
+		t.Errorf("merged rows = %s, want %s", got, want)
+	}
+}
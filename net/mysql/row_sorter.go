@@ -0,0 +1,316 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mysql
+
+import (
+	"bytes"
+	"container/heap"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/sqlparser/sqltypes"
+)
+
+// SortKey is column of rows to sort by.
+type SortKey struct {
+	Column int
+	Desc   bool
+}
+
+// RowSorter sort rows by keys, it's used to merge ORDER BY or GROUP BY results of multi nodes.
+// Rows are buffered in memory and accounted by tracker, when memory budget is exceeded,
+// buffered rows are sorted and spilled to disk as a run, runs are merged when reading.
+type RowSorter struct {
+	fields  []*Field
+	keys    []SortKey
+	tracker MemoryTracker
+
+	rows    []*Row
+	used    int64
+	runs    []*SpillFile
+	spilled int64
+}
+
+// NewRowSorter create sorter of text rows, tracker could be nil.
+func NewRowSorter(fields []*Field, keys []SortKey, tracker MemoryTracker) *RowSorter {
+	return &RowSorter{fields: fields, keys: keys, tracker: tracker}
+}
+
+// Append row.
+func (s *RowSorter) Append(row *Row) error {
+	n := int64(len(row.Dump()))
+	s.rows = append(s.rows, row)
+	s.used += n
+	if s.tracker == nil {
+		return nil
+	}
+	if err := s.tracker.Consume(n); err != nil {
+		if len(s.tracker.SpillDir()) == 0 {
+			return err
+		}
+		return s.spill()
+	}
+	return nil
+}
+
+// spill buffered rows to disk as a sorted run.
+func (s *RowSorter) spill() error {
+	var maxSize int64
+	if maxSize = s.tracker.SpillMaxSize(); maxSize > 0 {
+		if maxSize -= s.spilled; maxSize <= 0 {
+			return errors.ErrExceedSpillSize
+		}
+	}
+	run, err := NewSpillFile(s.tracker.SpillDir(), maxSize)
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, run)
+
+	s.sortRows()
+	for _, row := range s.rows {
+		if err = run.Append(row.Dump()); err != nil {
+			return err
+		}
+	}
+	s.spilled += run.Size()
+	s.release()
+	return nil
+}
+
+// Each read rows in order of keys.
+func (s *RowSorter) Each(fn func(row *Row) error) error {
+	s.sortRows()
+	if len(s.runs) == 0 {
+		for _, row := range s.rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	h := &rowHeap{sorter: s}
+	if len(s.rows) > 0 {
+		h.cursors = append(h.cursors, &rowCursor{rows: s.rows})
+	}
+	for _, run := range s.runs {
+		r, err := run.NewReader()
+		if err != nil {
+			return err
+		}
+		h.cursors = append(h.cursors, &rowCursor{reader: r})
+	}
+	for i := len(h.cursors) - 1; i >= 0; i-- {
+		if err := h.cursors[i].next(s.fields); err == io.EOF {
+			h.cursors = append(h.cursors[:i], h.cursors[i+1:]...)
+		} else if err != nil {
+			return err
+		}
+	}
+	heap.Init(h)
+	for h.Len() > 0 {
+		cursor := h.cursors[0]
+		if err := fn(cursor.row); err != nil {
+			return err
+		}
+		if err := cursor.next(s.fields); err == io.EOF {
+			heap.Pop(h)
+		} else if err != nil {
+			return err
+		} else {
+			heap.Fix(h, 0)
+		}
+	}
+	return nil
+}
+
+// Close release buffered rows and remove spilled runs.
+func (s *RowSorter) Close() error {
+	s.release()
+	var err error
+	for _, run := range s.runs {
+		if e := run.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	s.runs = nil
+	return err
+}
+
+func (s *RowSorter) release() {
+	if s.tracker != nil && s.used > 0 {
+		s.tracker.Consume(-s.used)
+	}
+	s.rows = nil
+	s.used = 0
+}
+
+func (s *RowSorter) sortRows() {
+	sort.SliceStable(s.rows, func(i, j int) bool { return s.less(s.rows[i], s.rows[j]) })
+}
+
+func (s *RowSorter) less(a, b *Row) bool {
+	for _, key := range s.keys {
+		c := compareColumn(s.fields, a, b, key.Column)
+		if c == 0 {
+			continue
+		}
+		if key.Desc {
+			return c > 0
+		}
+		return c < 0
+	}
+	return false
+}
+
+// compareColumn of rows, decimal, temporal and json are compared by sqltypes.
+func compareColumn(fields []*Field, a, b *Row, column int) int {
+	if column >= 0 && column < len(fields) && isTypedColumn(fields[column]) {
+		if c, err := sqltypes.Compare(a.GetSQLValue(column), b.GetSQLValue(column)); err == nil {
+			return c
+		}
+	}
+	return compareValue(a.GetValue(column), b.GetValue(column))
+}
+
+// compareValue of field, NULL is the smallest like mysql.
+func compareValue(a, b interface{}) int {
+	if a == nil || b == nil {
+		if a == nil && b == nil {
+			return 0
+		} else if a == nil {
+			return -1
+		}
+		return 1
+	}
+	switch x := a.(type) {
+	case int64:
+		switch y := b.(type) {
+		case int64:
+			return sign(x < y, x > y)
+		case uint64:
+			if x < 0 {
+				return -1
+			}
+			return sign(uint64(x) < y, uint64(x) > y)
+		}
+	case uint64:
+		switch y := b.(type) {
+		case uint64:
+			return sign(x < y, x > y)
+		case int64:
+			return -compareValue(b, a)
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y)
+		}
+	case []byte:
+		if y, ok := b.([]byte); ok {
+			return bytes.Compare(x, y)
+		}
+	case sqltypes.Value:
+		if y, ok := b.(sqltypes.Value); ok {
+			if c, err := sqltypes.Compare(x, y); err == nil {
+				return c
+			}
+		}
+	}
+	x, y := toFloat(a), toFloat(b)
+	return sign(x < y, x > y)
+}
+
+func sign(lt, gt bool) int {
+	if lt {
+		return -1
+	} else if gt {
+		return 1
+	}
+	return 0
+}
+
+func toFloat(v interface{}) float64 {
+	switch x := v.(type) {
+	case int64:
+		return float64(x)
+	case uint64:
+		return float64(x)
+	case float64:
+		return x
+	}
+	return 0
+}
+
+// rowCursor read rows of a sorted run.
+type rowCursor struct {
+	row    *Row
+	rows   []*Row
+	reader *SpillReader
+}
+
+func (c *rowCursor) next(fields []*Field) error {
+	if c.reader == nil {
+		if len(c.rows) == 0 {
+			return io.EOF
+		}
+		c.row, c.rows = c.rows[0], c.rows[1:]
+		return nil
+	}
+	data, err := c.reader.Next()
+	if err != nil {
+		return err
+	}
+	row, err := RowData(data).Parse(false, fields)
+	if err != nil {
+		return err
+	}
+	row.Data = data
+	c.row = row
+	return nil
+}
+
+// rowHeap merge sorted runs.
+type rowHeap struct {
+	sorter  *RowSorter
+	cursors []*rowCursor
+}
+
+func (h *rowHeap) Len() int { return len(h.cursors) }
+
+func (h *rowHeap) Less(i, j int) bool {
+	return h.sorter.less(h.cursors[i].row, h.cursors[j].row)
+}
+
+func (h *rowHeap) Swap(i, j int) { h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i] }
+
+func (h *rowHeap) Push(x interface{}) { h.cursors = append(h.cursors, x.(*rowCursor)) }
+
+func (h *rowHeap) Pop() interface{} {
+	n := len(h.cursors)
+	x := h.cursors[n-1]
+	h.cursors = h.cursors[:n-1]
+	return x
+}
@@ -0,0 +1,151 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mysql
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/berkaroad/saashard/errors"
+)
+
+// testTracker is memory budget of budget bytes, rows are spilled to dir.
+type testTracker struct {
+	budget, used int64
+	dir          string
+}
+
+func (t *testTracker) Consume(n int64) error {
+	t.used += n
+	if t.used > t.budget {
+		return errors.ErrExceedMemory
+	}
+	return nil
+}
+
+func (t *testTracker) SpillDir() string    { return t.dir }
+func (t *testTracker) SpillMaxSize() int64 { return 0 }
+
+func newTestField(name string, columnType byte) *Field {
+	return &Field{Name: []byte(name), ColumnType: columnType, Charset: uint16(DEFAULT_COLLATION_ID)}
+}
+
+// newTestRow parse text row of values, "NULL" is null.
+func newTestRow(t *testing.T, fields []*Field, values ...string) *Row {
+	row := NewTextRow(fields)
+	for _, v := range values {
+		if v == "NULL" {
+			row.AppendNullValue()
+		} else {
+			row.AppendStringValue(v)
+		}
+	}
+	parsed, err := RowData(row.Dump()).Parse(false, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestRowSorterSpill(t *testing.T) {
+	fields := []*Field{newTestField("id", MYSQL_TYPE_LONGLONG), newTestField("name", MYSQL_TYPE_VAR_STRING)}
+	tracker := &testTracker{budget: 24, dir: t.TempDir()}
+	sorter := NewRowSorter(fields, []SortKey{{Column: 1}, {Column: 0, Desc: true}}, tracker)
+
+	// rows of two nodes, each is sorted by itself.
+	for _, node := range [][]int{{1, 3, 5, 7, 9, 11, 13, 15}, {2, 4, 6, 8, 10, 12, 14, 16}} {
+		for _, id := range node {
+			if err := sorter.Append(newTestRow(t, fields, strconv.Itoa(id), "n"+strconv.Itoa(id%3))); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if len(sorter.runs) < 2 {
+		sorter.Close()
+		t.Fatalf("spilled runs = %d, want at least 2", len(sorter.runs))
+	}
+
+	var got []string
+	err := sorter.Each(func(row *Row) error {
+		got = append(got, string(row.GetRawValue(1))+":"+string(row.GetRawValue(0)))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "n0:15 n0:12 n0:9 n0:6 n0:3 n1:16 n1:13 n1:10 n1:7 n1:4 n1:1 n2:14 n2:11 n2:8 n2:5 n2:2"
+	if s := strings.Join(got, " "); s != want {
+		t.Errorf("sorted rows = %s, want %s", s, want)
+	}
+	if err = sorter.Close(); err != nil || tracker.used != 0 {
+		t.Errorf("Close() = %v, used = %d, want nil and 0", err, tracker.used)
+	}
+}
+
+// Temporal params bound by prepared statement come back from nodes as text,
+// DATETIME(6), negative TIME and zero dates of fan-out are sorted by value rather than by text, NULL is the smallest.
+func TestRowSorterTemporal(t *testing.T) {
+	fields := []*Field{newTestField("dt", MYSQL_TYPE_DATETIME), newTestField("t", MYSQL_TYPE_TIME),
+		newTestField("d", MYSQL_TYPE_DATE)}
+	fields[0].Decimals, fields[1].Decimals = 6, 6
+	cases := []struct {
+		keys []SortKey
+		want string
+	}{
+		{[]SortKey{{Column: 0}}, "NULL 0000-00-00 00:00:00.000000 2016-01-02 03:04:05.000001 2016-01-02 03:04:05.000010 2016-01-02 03:04:05.100000"},
+		{[]SortKey{{Column: 1}}, "-838:59:59.000000 -01:00:00.500000 -01:00:00.000000 00:00:00.000001 10:00:00.000000"},
+		{[]SortKey{{Column: 2, Desc: true}, {Column: 0}}, "2016-01-02 2016-01-02 2015-12-31 0000-00-00 NULL"},
+	}
+	nodes := [][]string{
+		{"2016-01-02 03:04:05.000010,-01:00:00.500000,2016-01-02", "0000-00-00 00:00:00.000000,10:00:00.000000,0000-00-00"},
+		{"2016-01-02 03:04:05.100000,-838:59:59.000000,2015-12-31", "2016-01-02 03:04:05.000001,-01:00:00.000000,2016-01-02"},
+		{"NULL,00:00:00.000001,NULL"},
+	}
+	for _, c := range cases {
+		sorter := NewRowSorter(fields, c.keys, &testTracker{budget: 1 << 20})
+		for _, rows := range nodes {
+			for _, values := range rows {
+				if err := sorter.Append(newTestRow(t, fields, strings.Split(values, ",")...)); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		var got []string
+		err := sorter.Each(func(row *Row) error {
+			if row.GetValue(c.keys[0].Column) == nil {
+				got = append(got, "NULL")
+			} else {
+				got = append(got, string(row.GetRawValue(c.keys[0].Column)))
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sorter.Close()
+		if s := strings.Join(got, " "); s != c.want {
+			t.Errorf("sorted by %v = %s, want %s", c.keys, s, c.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mysql
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/berkaroad/saashard/errors"
+)
+
+// MemoryTracker account bytes of buffered rows.
+type MemoryTracker interface {
+	// Consume n bytes, return error if memory budget is exceeded.
+	Consume(n int64) error
+	// SpillDir return the dir to spill rows when memory budget is exceeded,
+	// if it's empty, the query is aborted.
+	SpillDir() string
+	// SpillMaxSize return the max bytes could be spilled by a query, 0 means no limit.
+	SpillMaxSize() int64
+}
+
+// SpillFile store rows on disk, when memory budget is exceeded.
+type SpillFile struct {
+	f       *os.File
+	w       *bufio.Writer
+	count   int
+	size    int64
+	maxSize int64
+}
+
+// NewSpillFile create temp file in dir, maxSize 0 means no limit.
+func NewSpillFile(dir string, maxSize int64) (*SpillFile, error) {
+	f, err := ioutil.TempFile(dir, "saashard-spill-")
+	if err != nil {
+		return nil, err
+	}
+	return &SpillFile{f: f, w: bufio.NewWriterSize(f, defaultReaderSize), maxSize: maxSize}, nil
+}
+
+// Append row data.
+func (s *SpillFile) Append(data []byte) error {
+	if s.maxSize > 0 && s.size+int64(len(data))+4 > s.maxSize {
+		return errors.ErrExceedSpillSize
+	}
+	var header [4]byte
+	binary.LittleEndian.PutUint32(header[:], uint32(len(data)))
+	if _, err := s.w.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := s.w.Write(data); err != nil {
+		return err
+	}
+	s.count++
+	s.size += int64(len(data)) + 4
+	return nil
+}
+
+// Count of rows.
+func (s *SpillFile) Count() int {
+	return s.count
+}
+
+// Size of bytes on disk.
+func (s *SpillFile) Size() int64 {
+	return s.size
+}
+
+// Each read rows in order of appending.
+func (s *SpillFile) Each(fn func(data []byte) error) error {
+	r, err := s.NewReader()
+	if err != nil {
+		return err
+	}
+	for {
+		data, err := r.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if err = fn(data); err != nil {
+			return err
+		}
+	}
+	_, err = s.f.Seek(0, io.SeekEnd)
+	return err
+}
+
+// NewReader read rows from beginning, no more rows could be appended after that.
+// Readers of different spill files could be used at the same time.
+func (s *SpillFile) NewReader() (*SpillReader, error) {
+	if err := s.w.Flush(); err != nil {
+		return nil, err
+	}
+	if _, err := s.f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return &SpillReader{r: bufio.NewReaderSize(s.f, defaultReaderSize), remain: s.count}, nil
+}
+
+// SpillReader read rows of spill file one by one.
+type SpillReader struct {
+	r      *bufio.Reader
+	remain int
+}
+
+// Next row data, return io.EOF if no more rows.
+func (r *SpillReader) Next() ([]byte, error) {
+	if r.remain <= 0 {
+		return nil, io.EOF
+	}
+	var header [4]byte
+	if _, err := io.ReadFull(r.r, header[:]); err != nil {
+		return nil, err
+	}
+	data := make([]byte, binary.LittleEndian.Uint32(header[:]))
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return nil, err
+	}
+	r.remain--
+	return data, nil
+}
+
+// Close and remove the file.
+func (s *SpillFile) Close() error {
+	s.f.Close()
+	return os.Remove(s.f.Name())
+}
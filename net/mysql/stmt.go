@@ -24,6 +24,14 @@ package mysql
 
 import "github.com/berkaroad/saashard/sqlparser"
 
+// Cursor types of COM_STMT_EXECUTE.
+const (
+	CURSOR_TYPE_NO_CURSOR  byte = 0x00
+	CURSOR_TYPE_READ_ONLY  byte = 0x01
+	CURSOR_TYPE_FOR_UPDATE byte = 0x02
+	CURSOR_TYPE_SCROLLABLE byte = 0x04
+)
+
 // Stmt for stmt.
 type Stmt struct {
 	pkg        *PacketIO
@@ -38,6 +46,11 @@ type Stmt struct {
 	ColumnNum int
 	Columns   []*Field
 	Args      []interface{}
+
+	// CursorType is flag of the last COM_STMT_EXECUTE.
+	CursorType byte
+	// LongData is params sent by COM_STMT_SEND_LONG_DATA, they are cleared after execute.
+	LongData map[int][]byte
 }
 
 // NewStmt new stmt.
@@ -57,6 +70,23 @@ func (s *Stmt) Execute(args []interface{}) (*Result, error) {
 	return s.pkg.ReadResultSet(s.capability, s.status, true)
 }
 
+// ExecuteCursor execute stmt with read only cursor.
+// If cursor is opened, result has fields but no rows, and status has SERVER_STATUS_CURSOR_EXISTS.
+func (s *Stmt) ExecuteCursor(args []interface{}) (*Result, error) {
+	if err := s.pkg.StmtExecuteWithCursor(s.ID, CURSOR_TYPE_READ_ONLY, args); err != nil {
+		return nil, err
+	}
+	return s.pkg.ReadResultSet(s.capability, s.status, true)
+}
+
+// Fetch rows from opened cursor, status of result has SERVER_STATUS_LAST_ROW_SEND if no more rows.
+func (s *Stmt) Fetch(fields []*Field, rows uint32) (*Result, error) {
+	if err := s.pkg.StmtFetch(s.ID, rows); err != nil {
+		return nil, err
+	}
+	return s.pkg.ReadStmtFetchResult(s.capability, s.status, fields)
+}
+
 // Close stmt.
 func (s *Stmt) Close() error {
 	if err := s.pkg.StmtClose(s.ID); err != nil {
@@ -69,4 +99,5 @@ func (s *Stmt) Close() error {
 // ResetParams reset params.
 func (s *Stmt) ResetParams() {
 	s.Args = make([]interface{}, s.ParamNum)
+	s.LongData = nil
 }
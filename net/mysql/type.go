@@ -47,7 +47,8 @@ const (
 )
 
 const (
-	MYSQL_TYPE_NEWDECIMAL byte = iota + 0xf6
+	MYSQL_TYPE_JSON byte = iota + 0xf5
+	MYSQL_TYPE_NEWDECIMAL
 	MYSQL_TYPE_ENUM
 	MYSQL_TYPE_SET
 	MYSQL_TYPE_TINY_BLOB
@@ -49,13 +49,11 @@ var encodeRef = map[byte]byte{
 }
 
 func init() {
-	for i := range encodeRef {
-		encodeRef[i] = DONTESCAPE
+	for i := range encodeMap {
+		encodeMap[i] = DONTESCAPE
 	}
-	for i := range encodeRef {
-		if to, ok := encodeRef[byte(i)]; ok {
-			encodeRef[byte(i)] = to
-		}
+	for from, to := range encodeRef {
+		encodeMap[from] = to
 	}
 }
 
@@ -325,7 +323,7 @@ func Escape(sql string) string {
 
 	for i, w := 0, 0; i < len(sql); i += w {
 		runeValue, width := utf8.DecodeRuneInString(sql[i:])
-		if c := encodeRef[byte(runeValue)]; c == DONTESCAPE {
+		if c := encodeMap[byte(runeValue)]; c == DONTESCAPE || width > 1 {
 			dest = append(dest, sql[i:i+width]...)
 		} else {
 			dest = append(dest, '\\', c)
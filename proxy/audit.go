@@ -0,0 +1,146 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// AuditEntry.Action
+const (
+	AuditActionSet    = "set"
+	AuditActionReload = "reload"
+	AuditActionClone  = "clone"
+	AuditActionKill   = "kill"
+	AuditActionConfig = "config_reload" // by config watch, user is empty.
+	AuditActionDrill  = "failover_drill"
+
+	AuditActionCreateUser = "create_proxy_user"
+	AuditActionAlterUser  = "alter_proxy_user"
+	AuditActionDropUser   = "drop_proxy_user"
+)
+
+const auditLogMemorySize = 1000
+
+// AuditEntry is an admin action, with the acting user, previous and new values.
+type AuditEntry struct {
+	Time   string `json:"time"`
+	User   string `json:"user"`
+	Host   string `json:"host,omitempty"`
+	Action string `json:"action"`
+	Object string `json:"object"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+}
+
+// auditLog keeps recent admin actions in memory, and appends them to admin_audit_log file.
+type auditLog struct {
+	sync.Mutex
+	file    string
+	entries []*AuditEntry
+}
+
+// loadAuditLog load recent entries of admin_audit_log file, so that they're retrievable after restart.
+func (p *Server) loadAuditLog() error {
+	p.audit.file = p.cfg.AdminAuditLog
+	if len(p.audit.file) == 0 {
+		return nil
+	}
+	f, err := os.Open(p.audit.file)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := new(AuditEntry)
+		if err = json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			continue
+		}
+		p.audit.append(entry)
+	}
+	return scanner.Err()
+}
+
+// Audit record admin action, it's logged even if admin_audit_log file couldn't be written.
+func (p *Server) Audit(user, host, action, object, oldValue, newValue string) {
+	entry := &AuditEntry{Time: time.Now().Format(time.RFC3339Nano), User: user, Host: host,
+		Action: action, Object: object, Old: oldValue, New: newValue}
+	simplelog.Info("%s %s %s user=%s,host=%s,action=%s,object=%s,old=%s,new=%s", "proxy", "Audit", "Admin action",
+		user, host, action, object, oldValue, newValue)
+	if p.shipper != nil {
+		p.shipper.ship(shipperKindAudit, entry)
+	}
+
+	p.audit.Lock()
+	defer p.audit.Unlock()
+	p.audit.append(entry)
+	if len(p.audit.file) == 0 {
+		return
+	}
+	if err := p.audit.write(entry); err != nil {
+		simplelog.Error("%s %s %s file=%s", "proxy", "Audit", err.Error(), p.audit.file)
+	}
+}
+
+// GetAuditLog get recent admin actions, oldest first.
+func (p *Server) GetAuditLog() []*AuditEntry {
+	p.audit.Lock()
+	defer p.audit.Unlock()
+	entries := make([]*AuditEntry, len(p.audit.entries))
+	copy(entries, p.audit.entries)
+	return entries
+}
+
+func (log *auditLog) append(entry *AuditEntry) {
+	log.entries = append(log.entries, entry)
+	if len(log.entries) > auditLogMemorySize {
+		log.entries = log.entries[len(log.entries)-auditLogMemorySize:]
+	}
+}
+
+// write append entry as a json line, file is opened each time so that it could be rotated.
+func (log *auditLog) write(entry *AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(log.file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,209 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/route"
+	"github.com/berkaroad/saashard/sqlparser"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+const (
+	authzFailOpen   = "open"
+	authzFailClosed = "closed"
+
+	defaultAuthzTimeout = 1000
+	authzCacheSize      = 10000
+)
+
+// authzRequest is payload posted to authorization webhook, encoded as json.
+type authzRequest struct {
+	User        string   `json:"user"`
+	ClientIP    string   `json:"client_ip"`
+	DB          string   `json:"db,omitempty"`
+	Fingerprint string   `json:"fingerprint"`
+	Tables      []string `json:"tables"`
+	Type        string   `json:"type"`
+}
+
+// authzDecision is response of authorization webhook, annotation is written to log.
+type authzDecision struct {
+	Allow      bool   `json:"allow"`
+	Reason     string `json:"reason,omitempty"`
+	Annotation string `json:"annotation,omitempty"`
+}
+
+type authzCacheEntry struct {
+	decision *authzDecision
+	expire   time.Time
+}
+
+// authorizer call webhook with each statement, decisions are cached for cache_ttl seconds.
+type authorizer struct {
+	webhook  string
+	client   *http.Client
+	ttl      time.Duration
+	failOpen bool
+
+	lock  sync.Mutex
+	cache map[string]authzCacheEntry
+}
+
+func newAuthorizer(cfg config.AuthzConfig) *authorizer {
+	if len(cfg.Webhook) == 0 {
+		return nil
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultAuthzTimeout
+	}
+	return &authorizer{
+		webhook:  cfg.Webhook,
+		client:   &http.Client{Timeout: time.Duration(timeout) * time.Millisecond},
+		ttl:      time.Duration(cfg.CacheTTL) * time.Second,
+		failOpen: strings.EqualFold(cfg.FailPolicy, authzFailOpen),
+		cache:    make(map[string]authzCacheEntry),
+	}
+}
+
+func isAuthzFailPolicy(policy string) bool {
+	return len(policy) == 0 || strings.EqualFold(policy, authzFailOpen) || strings.EqualFold(policy, authzFailClosed)
+}
+
+// authorize statement of client by webhook, nil is returned if it's allowed.
+func (c *ClientConn) authorize(stmt sqlparser.Statement) error {
+	authz := c.proxy.authz
+	if authz == nil || c.probe {
+		return nil
+	}
+	clientIP, _, _ := net.SplitHostPort(c.c.RemoteAddr().String())
+	req := &authzRequest{
+		User:        c.user,
+		ClientIP:    clientIP,
+		DB:          c.db,
+		Fingerprint: sqlparser.Fingerprint(stmt),
+		Tables:      route.TableNames(stmt),
+		Type:        authzStatementType(stmt),
+	}
+	decision, err := authz.decide(req)
+	if err != nil {
+		simplelog.Error("%s %s %s user=%s,fingerprint=%s", "proxy", "authorize", err.Error(), req.User, req.Fingerprint)
+		if authz.failOpen {
+			return nil
+		}
+		return mysql.NewError(mysql.ER_SPECIFIC_ACCESS_DENIED_ERROR, "Access denied, authorization is unavailable")
+	}
+	if len(decision.Annotation) > 0 {
+		simplelog.Info("%s %s %s user=%s,fingerprint=%s", "proxy", "authorize", decision.Annotation, req.User, req.Fingerprint)
+	}
+	if !decision.Allow {
+		msg := fmt.Sprintf("Access denied for user '%s'@'%s' by authorization", req.User, req.ClientIP)
+		if len(decision.Reason) > 0 {
+			msg += ": " + decision.Reason
+		}
+		return mysql.NewError(mysql.ER_SPECIFIC_ACCESS_DENIED_ERROR, msg)
+	}
+	return nil
+}
+
+// decide return cached decision, or post request to webhook.
+func (a *authorizer) decide(req *authzRequest) (*authzDecision, error) {
+	key := req.User + "\x00" + req.ClientIP + "\x00" + req.DB + "\x00" + req.Fingerprint
+	if a.ttl > 0 {
+		a.lock.Lock()
+		entry, ok := a.cache[key]
+		a.lock.Unlock()
+		if ok && time.Now().Before(entry.expire) {
+			return entry.decision, nil
+		}
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.client.Post(a.webhook, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("authorization webhook '%s' responds status %d", a.webhook, resp.StatusCode)
+	}
+	decision := new(authzDecision)
+	if err = json.NewDecoder(resp.Body).Decode(decision); err != nil {
+		return nil, err
+	}
+
+	if a.ttl > 0 {
+		a.lock.Lock()
+		if len(a.cache) >= authzCacheSize {
+			a.cache = make(map[string]authzCacheEntry)
+		}
+		a.cache[key] = authzCacheEntry{decision: decision, expire: time.Now().Add(a.ttl)}
+		a.lock.Unlock()
+	}
+	return decision, nil
+}
+
+// authzStatementType is type of statement given to authorization webhook.
+func authzStatementType(stmt sqlparser.Statement) string {
+	switch stmt.(type) {
+	case sqlparser.SelectStatement:
+		return "select"
+	case *sqlparser.Insert:
+		return "insert"
+	case *sqlparser.Replace:
+		return "replace"
+	case *sqlparser.Update:
+		return "update"
+	case *sqlparser.Delete:
+		return "delete"
+	case *sqlparser.LoadData:
+		return "load"
+	case *sqlparser.Call:
+		return "call"
+	case sqlparser.DDLStatement:
+		return "ddl"
+	case *sqlparser.Grant, *sqlparser.Revoke, *sqlparser.CreateUser, *sqlparser.AlterUser, *sqlparser.DropUser:
+		return "dcl"
+	case sqlparser.SetStatement:
+		return "set"
+	case sqlparser.ShowStatement:
+		return "show"
+	case sqlparser.TransactionStatement:
+		return "transaction"
+	}
+	return "other"
+}
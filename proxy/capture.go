@@ -0,0 +1,271 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/replay"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// regCapturePassword match password literal in single or double quotes, with backslash or doubled quote escapes.
+var regCapturePassword = regexp.MustCompile(`(?i)(identified\s+(?:with\s+\S+\s+)?by\s+|password\s*\(\s*|password\s*=\s*)(?:'(?:[^'\\]|\\.|'')*'|"(?:[^"\\]|\\.|"")*")`)
+
+// regCapturePasswordParam match password placeholder of prepared statement, its params are redacted.
+var regCapturePasswordParam = regexp.MustCompile(`(?i)(identified\s+(?:with\s+\S+\s+)?by\s+|password\s*\(\s*|password\s*=\s*)\?`)
+
+// sessionCapture writes packets of a session to capture file, one json per line, read by replay.ReadCapture.
+type sessionCapture struct {
+	sync.Mutex
+	fileName string
+	file     *os.File
+	enc      *json.Encoder
+}
+
+// captureSide records packets of frontend or backend side, if session is being captured.
+type captureSide struct {
+	conn *ClientConn
+	side string
+	addr string
+}
+
+// CapturePacket implements mysql.PacketCapture.
+func (s *captureSide) CapturePacket(in bool, sequence uint8, data []byte) {
+	capture := s.conn.getCapture()
+	if capture == nil {
+		return
+	}
+	record := &replay.Record{
+		Time: time.Now().Format(time.RFC3339Nano),
+		Side: s.side,
+		Addr: s.addr,
+		In:   in,
+		Seq:  sequence,
+		Data: redactPacket(data, in == (s.side == replay.SideFrontend), s.findStmt),
+	}
+	capture.Lock()
+	capture.enc.Encode(record)
+	capture.Unlock()
+}
+
+// findStmt of frontend side, prepared statements of backend conns aren't tracked.
+func (s *captureSide) findStmt(id uint32) *mysql.Stmt {
+	if s.side != replay.SideFrontend {
+		return nil
+	}
+	return s.conn.stmts[id]
+}
+
+// redactPacket hide passwords of command sent by client,
+// params of prepared statement with password placeholder are hidden, that is found by findStmt.
+func redactPacket(data []byte, fromClient bool, findStmt func(id uint32) *mysql.Stmt) []byte {
+	if !fromClient || len(data) == 0 {
+		return data
+	}
+	switch data[0] {
+	case mysql.COM_CHANGE_USER:
+		// user is kept, auth response and the rest are hidden.
+		redacted := make([]byte, len(data))
+		copy(redacted, data)
+		if pos := bytes.IndexByte(redacted[1:], 0); pos >= 0 {
+			for i := pos + 2; i < len(redacted); i++ {
+				redacted[i] = 0
+			}
+		}
+		return redacted
+	case mysql.COM_QUERY:
+		if regCapturePassword.Match(data[1:]) {
+			return append([]byte{data[0]}, regCapturePassword.ReplaceAll(data[1:], []byte("$1'***'"))...)
+		}
+	case mysql.COM_STMT_EXECUTE:
+		if len(data) >= 5 {
+			if stmt := findStmt(binary.LittleEndian.Uint32(data[1:5])); stmt != nil && regCapturePasswordParam.MatchString(stmt.Query) {
+				return append([]byte{data[0]}, mysql.RedactStmtExecuteRequest(data[1:], stmt)...)
+			}
+		}
+	case mysql.COM_STMT_SEND_LONG_DATA:
+		// statement id and param id are kept.
+		if len(data) >= 7 {
+			if stmt := findStmt(binary.LittleEndian.Uint32(data[1:5])); stmt != nil && regCapturePasswordParam.MatchString(stmt.Query) {
+				return append(append([]byte(nil), data[:7]...), "***"...)
+			}
+		}
+	}
+	return data
+}
+
+func (c *ClientConn) getCapture() *sessionCapture {
+	capture, _ := c.capture.Load().(*sessionCapture)
+	return capture
+}
+
+// backendCapture capture of backend conn, nil if session is not being captured.
+func (c *ClientConn) backendCapture(addr string) mysql.PacketCapture {
+	if c.getCapture() == nil {
+		return nil
+	}
+	return &captureSide{conn: c, side: replay.SideBackend, addr: addr}
+}
+
+// startCapture create capture file in capture_dir, and record packets from now on.
+func (c *ClientConn) startCapture() error {
+	if c.getCapture() != nil {
+		return nil
+	}
+	dir := c.proxy.cfg.CaptureDir
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	fileName := filepath.Join(dir, fmt.Sprintf("capture_%d_%s.jsonl", c.connectionID, time.Now().Format("20060102150405")))
+	file, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	capture := &sessionCapture{fileName: fileName, file: file, enc: json.NewEncoder(file)}
+	capture.enc.Encode(&replay.Header{
+		Version:      replay.Version,
+		ConnectionID: c.connectionID,
+		User:         c.user,
+		DB:           c.db,
+		Client:       c.c.RemoteAddr().String(),
+		Capability:   c.capability,
+		Time:         time.Now().Format(time.RFC3339Nano),
+	})
+	c.capture.Store(capture)
+	simplelog.Info("%s %s %s connection id=%d,file=%s", "proxy", "startCapture", "Capture session", c.connectionID, fileName)
+	return nil
+}
+
+// stopCapture stop recording packets, and close capture file.
+func (c *ClientConn) stopCapture() {
+	capture := c.getCapture()
+	if capture == nil {
+		return
+	}
+	c.capture.Store((*sessionCapture)(nil))
+	capture.Lock()
+	capture.file.Close()
+	capture.Unlock()
+	simplelog.Info("%s %s %s connection id=%d,file=%s", "proxy", "stopCapture", "Capture stopped", c.connectionID, capture.fileName)
+}
+
+// captureFilter sessions to capture, by connection id or user.
+type captureFilter struct {
+	ids   []uint32
+	users []string
+}
+
+// parseCaptureFilter parse saashard_capture, such as "12,15" or "user:app", empty is off.
+func parseCaptureFilter(value string) (*captureFilter, error) {
+	filter := new(captureFilter)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(item), "user:") {
+			filter.users = append(filter.users, strings.ToLower(strings.TrimSpace(item[5:])))
+			continue
+		}
+		id, err := strconv.ParseUint(item, 10, 32)
+		if err != nil {
+			return nil, errors.ErrInvalidArgument
+		}
+		filter.ids = append(filter.ids, uint32(id))
+	}
+	return filter, nil
+}
+
+func (f *captureFilter) match(c *ClientConn) bool {
+	for _, id := range f.ids {
+		if id == c.connectionID {
+			return true
+		}
+	}
+	for _, user := range f.users {
+		if user == strings.ToLower(c.user) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *captureFilter) String() string {
+	items := make([]string, 0, len(f.ids)+len(f.users))
+	for _, id := range f.ids {
+		items = append(items, strconv.FormatUint(uint64(id), 10))
+	}
+	for _, user := range f.users {
+		items = append(items, "user:"+user)
+	}
+	return strings.Join(items, ",")
+}
+
+// setCapture set sessions to capture, sessions connected are started or stopped at once.
+func (p *Server) setCapture(value string) error {
+	filter, err := parseCaptureFilter(value)
+	if err != nil {
+		return err
+	}
+	next := 1 - atomic.LoadInt32(&p.captureIndex)
+	p.captures[next] = filter
+	atomic.StoreInt32(&p.captureIndex, next)
+
+	p.Lock()
+	conns := make([]*ClientConn, 0, len(p.conns))
+	for _, conn := range p.conns {
+		conns = append(conns, conn)
+	}
+	p.Unlock()
+	for _, conn := range conns {
+		if filter.match(conn) {
+			if err = conn.startCapture(); err != nil {
+				return err
+			}
+		} else {
+			conn.stopCapture()
+		}
+	}
+	return nil
+}
+
+func (p *Server) getCaptureFilter() *captureFilter {
+	if filter := p.captures[atomic.LoadInt32(&p.captureIndex)]; filter != nil {
+		return filter
+	}
+	return new(captureFilter)
+}
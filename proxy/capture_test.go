@@ -0,0 +1,175 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/berkaroad/saashard/backend"
+	"github.com/berkaroad/saashard/backend/mock"
+	mysqlBackend "github.com/berkaroad/saashard/backend/mysql"
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/replay"
+)
+
+func TestRedactPacket(t *testing.T) {
+	cases := []struct {
+		sql  string
+		want string
+	}{
+		{"create user 'u' identified by 'secret'", "create user 'u' identified by '***'"},
+		{`alter user u identified by "secret"`, "alter user u identified by '***'"},
+		{`create user u identified with mysql_native_password by 'it''s \'x\''`, "create user u identified with mysql_native_password by '***'"},
+		{`set password = "a""b", c = 'd'`, "set password = '***', c = 'd'"},
+		{"set password for 'u' = password('p')", "set password for 'u' = password('***')"},
+		{"select 'identified by'", "select 'identified by'"},
+	}
+	for _, c := range cases {
+		data := append([]byte{mysql.COM_QUERY}, c.sql...)
+		if got := string(redactPacket(data, true, nil)[1:]); got != c.want {
+			t.Errorf("redactPacket(%q) = %q, want %q", c.sql, got, c.want)
+		}
+	}
+}
+
+func TestRedactStmtPacket(t *testing.T) {
+	stmts := map[uint32]*mysql.Stmt{
+		1: {ID: 1, Query: "select id from t where id = ? and password = ?", ParamNum: 2},
+		2: {ID: 2, Query: "select id from t where id = ? and name = ?", ParamNum: 2},
+	}
+	findStmt := func(id uint32) *mysql.Stmt { return stmts[id] }
+	execute := func(id byte, bound bool, values ...byte) []byte {
+		data := []byte{mysql.COM_STMT_EXECUTE, id, 0, 0, 0, 0, 1, 0, 0, 0, 0}
+		if !bound {
+			return append(append(data, 0), values...)
+		}
+		data = append(data, 1, mysql.MYSQL_TYPE_LONG, 0, mysql.MYSQL_TYPE_VAR_STRING, 0)
+		return append(data, values...)
+	}
+	values := append([]byte{7, 0, 0, 0}, mysql.StringToLenencStr([]byte("secret"))...)
+	redacted := append([]byte{7, 0, 0, 0}, mysql.StringToLenencStr([]byte("***"))...)
+	cases := []struct {
+		name       string
+		data, want []byte
+	}{
+		{"password param", execute(1, true, values...), execute(1, true, redacted...)},
+		{"other stmt", execute(2, true, values...), execute(2, true, values...)},
+		{"types not bound", execute(1, false, values...), execute(1, false)},
+		{"unknown stmt", execute(3, true, values...), execute(3, true, values...)},
+		{"long data", append([]byte{mysql.COM_STMT_SEND_LONG_DATA, 1, 0, 0, 0, 1, 0}, "secret"...),
+			append([]byte{mysql.COM_STMT_SEND_LONG_DATA, 1, 0, 0, 0, 1, 0}, "***"...)},
+	}
+	for _, c := range cases {
+		if got := redactPacket(c.data, true, findStmt); !bytes.Equal(got, c.want) {
+			t.Errorf("redactPacket(%s) = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+// Session is captured by proxy against mock backend, then replayed and compared,
+// changed response of backend is a mismatch.
+func TestCaptureReplay(t *testing.T) {
+	s, err := mock.NewServer("127.0.0.1:0", "root", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	s.Handle(`^select @@session.sql_mode`, &mock.Response{Columns: []string{"@@session.sql_mode"},
+		Rows: [][]interface{}{{"STRICT_TRANS_TABLES"}}})
+	s.Handle("^select id, name from t order by id", &mock.Response{Columns: []string{"id", "name"},
+		Rows: [][]interface{}{{1, "a"}, {2, nil}}})
+
+	dir := t.TempDir()
+	cfg := &config.Config{BindIP: "127.0.0.1", CaptureDir: dir,
+		Hosts:   []config.HostConfig{{Name: "h1", MaxConnNum: 4, User: "root", Password: "secret", Master: s.Addr()}},
+		Nodes:   []config.NodeConfig{{Name: "n1", Host: "h1", Database: "db"}},
+		Schemas: []config.SchemaConfig{{Name: "db", User: "app", Password: "pw", Nodes: []string{"n1"}}}}
+	p, err := NewServer(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	if err = p.setCapture("user:app"); err != nil {
+		t.Fatal(err)
+	}
+	go p.Run()
+	addr := p.listener.Addr().String()
+
+	client := backend.NewDBHost(addr, "app", "pw", 1, 1)
+	conn, err := client.GetConnection("db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sql := range []string{"select id, name from t order by id", "select id, name from t order by id"} {
+		if _, err = conn.(*mysqlBackend.Conn).Query(sql); err != nil {
+			t.Fatal(err)
+		}
+	}
+	conn.Close()
+
+	var files []string
+	for i := 0; i < 100 && len(files) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+		p.Lock()
+		closed := len(p.conns) == 0
+		p.Unlock()
+		if closed {
+			files, _ = filepath.Glob(filepath.Join(dir, "capture_*.jsonl"))
+		}
+	}
+	if len(files) != 1 {
+		t.Fatalf("capture files = %v", files)
+	}
+	capture, err := replay.ReadCaptureFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if capture.Header.User != "app" || capture.Header.DB != "db" {
+		t.Errorf("header = %+v", capture.Header)
+	}
+
+	replayer := &replay.Replayer{Addr: addr, Password: "pw", Timeout: 5 * time.Second}
+	result, err := replayer.Replay(capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Commands < 2 || len(result.Mismatches) > 0 {
+		t.Errorf("replay commands = %d, mismatches = %v", result.Commands, result.Mismatches)
+	}
+
+	s.Reset()
+	s.Handle(`^select @@session.sql_mode`, &mock.Response{Columns: []string{"@@session.sql_mode"},
+		Rows: [][]interface{}{{"STRICT_TRANS_TABLES"}}})
+	s.Handle("^select id, name from t order by id", &mock.Response{Columns: []string{"id", "name"},
+		Rows: [][]interface{}{{1, "b"}, {2, nil}}})
+	if result, err = replayer.Replay(capture); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Mismatches) != 2 {
+		t.Errorf("mismatches of changed response = %v, expected 2", result.Mismatches)
+	}
+}
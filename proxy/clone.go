@@ -0,0 +1,419 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/berkaroad/saashard/backend"
+	mysqlBackend "github.com/berkaroad/saashard/backend/mysql"
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/route"
+	"github.com/berkaroad/saashard/utils"
+	"github.com/berkaroad/saashard/utils/simplelog"
+	"github.com/go-yaml/yaml"
+)
+
+const (
+	defaultCloneChunkSize = 1000
+
+	cloneStateRunning = "running"
+	cloneStateDone    = "done"
+	cloneStateFailed  = "failed"
+)
+
+// cloneJobs running or finished, key is from.to.tenant.
+type cloneJobs struct {
+	sync.Mutex
+	jobs map[string]*cloneJob
+}
+
+// cloneJob copy rows of a tenant from a schema to another, table by table in chunks.
+type cloneJob struct {
+	name   string
+	tenant string
+	from   *config.SchemaConfig
+	to     *config.SchemaConfig
+
+	state string
+	err   error
+	table string
+	rows  int64
+}
+
+// cloneProgress is saved into clone_progress_file after each chunk, so that job could be resumed.
+type cloneProgress struct {
+	Done   []string `yaml:"done"`
+	Table  string   `yaml:"table"`
+	Offset int64    `yaml:"offset"`
+}
+
+// CloneTenant copy rows of tenant from schema to another in background,
+// tenant is the value of shard key, or empty to copy all rows if schema is not sharded.
+// Tables must exist in target schema, rows are copied by 'replace into'.
+func (p *Server) CloneTenant(tenant, from, to string) error {
+	schemas := p.getSchemas()
+	fromSchema, toSchema := schemas[from], schemas[to]
+	if fromSchema == nil || toSchema == nil {
+		return errors.ErrNoSchema
+	}
+	if fromSchema == toSchema {
+		return errors.ErrInvalidArgument
+	}
+	job := &cloneJob{name: from + "." + to + "." + strings.Trim(tenant, "'\""),
+		tenant: tenant, from: fromSchema, to: toSchema, state: cloneStateRunning}
+
+	defer p.clones.Unlock()
+
+	p.clones.Lock()
+	if p.clones.jobs == nil {
+		p.clones.jobs = make(map[string]*cloneJob)
+	}
+	if running := p.clones.jobs[job.name]; running != nil && running.state == cloneStateRunning {
+		return errors.ErrCloneRunning
+	}
+	p.clones.jobs[job.name] = job
+	go p.runCloneJob(job)
+	return nil
+}
+
+// GetCloneStatus return names of clone jobs, and status of them.
+func (p *Server) GetCloneStatus() ([]string, []string) {
+	defer p.clones.Unlock()
+
+	p.clones.Lock()
+	names := make([]string, 0, len(p.clones.jobs))
+	for name := range p.clones.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := make([]string, len(names))
+	for i, name := range names {
+		job := p.clones.jobs[name]
+		if job.err != nil {
+			values[i] = fmt.Sprintf("%s table=%s,rows=%d,err=%s", job.state, job.table, job.rows, job.err)
+		} else {
+			values[i] = fmt.Sprintf("%s table=%s,rows=%d", job.state, job.table, job.rows)
+		}
+	}
+	return names, values
+}
+
+func (p *Server) runCloneJob(job *cloneJob) {
+	err := p.cloneTenant(job)
+
+	p.clones.Lock()
+	if err != nil {
+		job.state = cloneStateFailed
+		job.err = err
+	} else {
+		job.state = cloneStateDone
+	}
+	p.clones.Unlock()
+
+	if err != nil {
+		simplelog.Error("%s %s %s job=%s,err=%s", "proxy", "CloneTenant", "Clone tenant failed", job.name, err)
+	} else {
+		simplelog.Info("%s %s %s job=%s,rows=%d", "proxy", "CloneTenant", "Clone tenant done", job.name, job.rows)
+	}
+}
+
+func (p *Server) cloneTenant(job *cloneJob) error {
+	fromNode, err := p.getTenantNode(job.from, job.tenant)
+	if err != nil {
+		return err
+	}
+	toNode, err := p.getTenantNode(job.to, job.tenant)
+	if err != nil {
+		return err
+	}
+	src, err := getMasterConn(fromNode)
+	if err != nil {
+		return err
+	}
+	defer src.ReturnConnection()
+	dst, err := getMasterConn(toNode)
+	if err != nil {
+		return err
+	}
+	defer dst.ReturnConnection()
+
+	tables, err := getTablesInFKOrder(src, job.from)
+	if err != nil {
+		return err
+	}
+	progress, err := p.loadCloneProgress(job.name)
+	if err != nil {
+		return err
+	}
+	chunkSize := p.cfg.CloneChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultCloneChunkSize
+	}
+
+	for _, table := range tables {
+		if utils.Contains(progress.Done, table) {
+			continue
+		}
+		if progress.Table != table {
+			progress.Table, progress.Offset = table, 0
+		}
+		p.clones.Lock()
+		job.table = table
+		p.clones.Unlock()
+
+		selectSQL, err := buildCloneSelect(src, table, job.from.ShardKey, job.tenant)
+		if err != nil {
+			return err
+		}
+		for {
+			result, err := src.Query(fmt.Sprintf("%s limit %d, %d", selectSQL, progress.Offset, chunkSize))
+			if err != nil {
+				return err
+			}
+			if result.Resultset == nil || len(result.Rows) == 0 {
+				break
+			}
+			if _, err = dst.Query(buildCloneReplace(table, result.Resultset)); err != nil {
+				return err
+			}
+			progress.Offset += int64(len(result.Rows))
+			if err = p.saveCloneProgress(job.name, progress); err != nil {
+				return err
+			}
+			p.clones.Lock()
+			job.rows += int64(len(result.Rows))
+			p.clones.Unlock()
+
+			if len(result.Rows) < chunkSize {
+				break
+			}
+			if p.cfg.CloneThrottle > 0 {
+				time.Sleep(time.Duration(p.cfg.CloneThrottle) * time.Millisecond)
+			}
+		}
+		progress.Done = append(progress.Done, table)
+		progress.Table, progress.Offset = "", 0
+		if err = p.saveCloneProgress(job.name, progress); err != nil {
+			return err
+		}
+	}
+	return p.saveCloneProgress(job.name, nil)
+}
+
+// getTenantNode by shard key value, or the first node if schema is not sharded.
+func (p *Server) getTenantNode(schema *config.SchemaConfig, tenant string) (*backend.DataNode, error) {
+	nodeIndex := 0
+	if schema.ShardEnabled() {
+		if len(tenant) == 0 {
+			return nil, errors.ErrWhereOrJoinOnKey
+		}
+		var err error
+		if nodeIndex, err = route.ShardIndex(schema, tenant); err != nil {
+			return nil, err
+		}
+	}
+	node := p.nodes[schema.Nodes[nodeIndex]]
+	if node == nil {
+		return nil, errors.ErrNoDataNode
+	}
+	return node, nil
+}
+
+func getMasterConn(node *backend.DataNode) (*mysqlBackend.Conn, error) {
+	conn, err := node.DataHost.Master.GetConnection(node.Database)
+	if err != nil {
+		return nil, err
+	}
+	return conn.(*mysqlBackend.Conn), nil
+}
+
+// getTablesInFKOrder return tables of schema, referenced tables are before tables that reference them.
+func getTablesInFKOrder(conn *mysqlBackend.Conn, schema *config.SchemaConfig) ([]string, error) {
+	var tables []string
+	for table := range schema.GetTables() {
+		tables = append(tables, table)
+	}
+	if len(tables) == 0 {
+		result, err := conn.Query("show tables")
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range result.Rows {
+			tables = append(tables, string(row.GetRawValue(0)))
+		}
+	}
+	sort.Strings(tables)
+
+	result, err := conn.Query("select table_name, referenced_table_name from information_schema.key_column_usage " +
+		"where table_schema = database() and referenced_table_name is not null")
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[string][]string)
+	for _, row := range result.Rows {
+		table, parent := string(row.GetRawValue(0)), string(row.GetRawValue(1))
+		if table != parent {
+			parents[table] = append(parents[table], parent)
+		}
+	}
+
+	ordered := make([]string, 0, len(tables))
+	visited := make(map[string]bool)
+	var visit func(table string)
+	visit = func(table string) {
+		if visited[table] {
+			return
+		}
+		visited[table] = true
+		for _, parent := range parents[table] {
+			if utils.Contains(tables, parent) {
+				visit(parent)
+			}
+		}
+		ordered = append(ordered, table)
+	}
+	for _, table := range tables {
+		visit(table)
+	}
+	return ordered, nil
+}
+
+// buildCloneSelect order by primary key, so that chunks are stable.
+func buildCloneSelect(conn *mysqlBackend.Conn, table, shardKey, tenant string) (string, error) {
+	sql := "select * from " + quoteIdentifier(table)
+	if len(shardKey) > 0 {
+		sql += " where " + quoteIdentifier(shardKey) + " = " + tenant
+	}
+	columns, err := primaryKeyColumns(conn, table)
+	if err != nil {
+		return "", err
+	}
+	if len(columns) > 0 {
+		sql += " order by " + quoteIdentifiers(columns)
+	}
+	return sql, nil
+}
+
+// primaryKeyColumns of table in order of index, empty if table has no primary key.
+func primaryKeyColumns(conn *mysqlBackend.Conn, table string) ([]string, error) {
+	result, err := conn.Query(fmt.Sprintf("show index from %s where key_name = 'PRIMARY'", quoteIdentifier(table)))
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, len(result.Rows))
+	for i, row := range result.Rows {
+		columns[i] = string(row.GetRawValue(4))
+	}
+	return columns, nil
+}
+
+func quoteIdentifiers(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = quoteIdentifier(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func buildCloneReplace(table string, rs *mysql.Resultset) string {
+	return buildRowsSQL("replace into", table, rs)
+}
+
+// buildRowsSQL build insert or replace of rows with column list, values are quoted.
+func buildRowsSQL(verb, table string, rs *mysql.Resultset) string {
+	columns := make([]string, len(rs.Fields))
+	for i, field := range rs.Fields {
+		columns[i] = quoteIdentifier(string(field.Name))
+	}
+	rows := make([]string, len(rs.Rows))
+	values := make([]string, len(rs.Fields))
+	for i, row := range rs.Rows {
+		for j := range rs.Fields {
+			if row.GetValue(j) == nil {
+				values[j] = "null"
+			} else {
+				values[j] = "'" + mysql.Escape(string(row.GetRawValue(j))) + "'"
+			}
+		}
+		rows[i] = "(" + strings.Join(values, ", ") + ")"
+	}
+	return fmt.Sprintf("%s %s (%s) values %s", verb, quoteIdentifier(table),
+		strings.Join(columns, ", "), strings.Join(rows, ", "))
+}
+
+func (p *Server) loadCloneProgress(name string) (*cloneProgress, error) {
+	progress := make(map[string]*cloneProgress)
+	if err := p.readCloneProgressFile(progress); err != nil {
+		return nil, err
+	}
+	if progress[name] != nil {
+		return progress[name], nil
+	}
+	return new(cloneProgress), nil
+}
+
+// saveCloneProgress of job, nil progress removes it when job is done.
+func (p *Server) saveCloneProgress(name string, jobProgress *cloneProgress) error {
+	if len(p.cfg.CloneProgressFile) == 0 {
+		return nil
+	}
+	defer p.clones.Unlock()
+
+	p.clones.Lock()
+	progress := make(map[string]*cloneProgress)
+	if err := p.readCloneProgressFile(progress); err != nil {
+		return err
+	}
+	if jobProgress != nil {
+		progress[name] = jobProgress
+	} else {
+		delete(progress, name)
+	}
+	data, err := yaml.Marshal(progress)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p.cfg.CloneProgressFile, data, 0600)
+}
+
+func (p *Server) readCloneProgressFile(progress map[string]*cloneProgress) error {
+	if len(p.cfg.CloneProgressFile) == 0 {
+		return nil
+	}
+	data, err := ioutil.ReadFile(p.cfg.CloneProgressFile)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, &progress)
+}
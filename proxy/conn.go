@@ -23,18 +23,26 @@
 package proxy
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/berkaroad/saashard/backend"
 	"github.com/berkaroad/saashard/config"
 	"github.com/berkaroad/saashard/errors"
 	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/route"
+	"github.com/berkaroad/saashard/sqlparser"
 	"github.com/berkaroad/saashard/utils/simplelog"
 )
 
+// clientCloseCheckInterval is interval of polling client connection, while waiting for backend result.
+const clientCloseCheckInterval = 100 * time.Millisecond
+
 // ClientConn client <-> proxy
 type ClientConn struct {
 	sync.Mutex
@@ -47,18 +55,46 @@ type ClientConn struct {
 	status             uint16
 	collation          mysql.CollationID
 	charset            string
+	sqlMode            string            // sql_mode set by client
+	sqlModeOn          bool              // sql_mode is set by client or not
+	parserSQLMode      sqlparser.SQLMode // sql_mode that affects parser
+	sessionVariables   map[string]string // literal of user variables and session's system variables set by client
 	user               string
+	attrs              map[string]string // connection attributes sent by client, such as program_name.
 	db                 string
 	salt               []byte
 	schemas            map[string]*config.SchemaConfig
+	ruleVersion        int // version of shard rules that schemas are from
+	expectRuleVersion  int // version of shard rules declared by client, 0 is any version
 	backendMasterConns map[*backend.DataNode]backend.Connection
 	backendSlaveConns  map[*backend.DataNode]backend.Connection
+	backendOLAPConns   map[*backend.DataNode]backend.Connection // conns of analytics replicas
 	nodeInTrans        *backend.DataNode
+	tablesLocked       bool // LOCK TABLES at nodeInTrans, until UNLOCK TABLES
 	closed             bool
 	lastInsertID       int64
 	affectedRows       int64
 	stmtID             uint32
 	stmts              map[uint32]*mysql.Stmt //prepare,client -> proxy
+	cursors            map[uint32]*stmtCursor // opened cursors of stmts
+	moreResultsInBatch bool                   // more statements of script to execute
+	readOnly           bool                   // connected from read-only listener
+	probe              bool                   // probe_user, that only pings without schema
+	busy               int32                  // 1 while dispatching a command, -1 if closed by draining
+	memoryUsed         int64                  // bytes of rows buffered by current command
+	onAnalytics        bool                   // current plan goes to analytics replica
+	merge              *route.Merge           // current plan merges rows of fan-out select
+	partialResult      bool                   // last fan-out select skipped failed nodes
+	warnings           []string               // warnings of proxy for previous query command, such as skipped nodes
+	lastRoute          []*routeTrace          // executions at nodes of previous query command
+	trans              transTracker           // current transaction, for long and big transaction alerts
+	transEnded         bool                   // current statement ends transaction
+	capture            atomic.Value           // *sessionCapture, if session is being captured
+	ctx                context.Context        // cancelled when closed, so that running backend queries are killed
+	cancel             context.CancelFunc
+
+	// named prepared statements of PREPARE, tracked by proxy.
+	prepared map[string]sqlparser.Statement
 }
 
 // IsAllowConnect check ip in whitelist.
@@ -93,7 +129,11 @@ func (c *ClientConn) IsAllowConnect() bool {
 // Handshake between client and proxy.
 func (c *ClientConn) Handshake() error {
 	var err error
-	if err = c.pkg.WriteInitialHandshake(c.connectionID, c.salt, mysql.DEFAULT_COLLATION_ID, mysql.DEFAULT_CAPABILITY, c.status); err != nil {
+	capability := mysql.DEFAULT_CAPABILITY | mysql.CLIENT_CONNECT_ATTRS
+	if c.proxy.certs != nil {
+		capability |= mysql.CLIENT_SSL
+	}
+	if err = c.pkg.WriteInitialHandshake(c.connectionID, c.salt, mysql.DEFAULT_COLLATION_ID, capability, c.status); err != nil {
 		simplelog.Error("%s %s %s connection id=%d,msg=%s", "server", "Handshake", err.Error(),
 			c.connectionID,
 			"send initial handshake error")
@@ -101,6 +141,10 @@ func (c *ClientConn) Handshake() error {
 	}
 
 	getDefaultSchemaByUser := func(user string) (string, error) {
+		if c.proxy.isProbeUser(user) {
+			c.probe = true
+			return "", nil
+		}
 		c.schemas = c.proxy.getSchemasByUser(user)
 		if len(c.schemas) == 0 {
 			return "", errors.ErrNoSchema
@@ -112,14 +156,24 @@ func (c *ClientConn) Handshake() error {
 		}
 		return name, nil
 	}
-	getCredentialsConfigBySchema := func(schema string) (string, string, error) {
-		schemaConfig := c.proxy.schemas[schema]
+	getCredentialsConfigBySchema := func(user, schema string) (string, string, error) {
+		if c.probe && len(schema) == 0 {
+			return c.proxy.cfg.ProbeUser, c.proxy.cfg.ProbePassword, nil
+		}
+		if proxyUser := c.proxy.getProxyUser(user); proxyUser != nil {
+			if c.proxy.getSchemasByUser(user)[schema] == nil {
+				clientHost, _, _ := net.SplitHostPort(c.c.RemoteAddr().String())
+				return "", "", mysql.NewDefaultError(mysql.ER_DBACCESS_DENIED_ERROR, user, clientHost, schema)
+			}
+			return proxyUser.Name, proxyUser.Password, nil
+		}
+		schemaConfig := c.proxy.getSchemas()[schema]
 		if schemaConfig == nil {
 			return "", "", mysql.NewDefaultError(mysql.ER_BAD_DB_ERROR, schema)
 		}
 		return schemaConfig.User, schemaConfig.Password, nil
 	}
-	c.capability, c.collation, c.user, c.db, err = c.pkg.ReadHandshakeResponse(getDefaultSchemaByUser, c.c.RemoteAddr().String(), c.salt, getCredentialsConfigBySchema)
+	c.capability, c.collation, c.user, c.db, c.attrs, err = c.pkg.ReadHandshakeResponse(getDefaultSchemaByUser, c.c.RemoteAddr().String(), c.salt, getCredentialsConfigBySchema)
 	if err != nil {
 		simplelog.Error("%s %s %s connection id=%d,msg=%s",
 			"server", "readHandshakeResponse", err.Error(),
@@ -131,6 +185,9 @@ func (c *ClientConn) Handshake() error {
 		return err
 	}
 	c.schemas = c.proxy.getSchemasByUser(c.user)
+	if proxyUser := c.proxy.getProxyUser(c.user); proxyUser != nil && proxyUser.ReadOnly {
+		c.readOnly = true
+	}
 
 	if err := c.pkg.WriteOK(c.capability, c.status, nil); err != nil {
 		simplelog.Error("%s %s %s connection id=%d,msg=%s",
@@ -145,6 +202,34 @@ func (c *ClientConn) Handshake() error {
 	return nil
 }
 
+// readSQLMode read sql_mode of session from the first node of current schema after handshake,
+// so that statements are parsed by sql_mode of mysql before client sets it, such as ANSI_QUOTES.
+func (c *ClientConn) readSQLMode() {
+	schema := c.schemas[c.db]
+	if schema == nil || len(schema.Nodes) == 0 {
+		return
+	}
+	node := c.proxy.nodes[schema.Nodes[0]]
+	if node == nil {
+		return
+	}
+	conn, err := getMasterConn(node)
+	if err != nil {
+		simplelog.Warn("%s %s %s connection id=%d,node=%s", "ClientConn", "readSQLMode", err.Error(), c.connectionID, node.Name)
+		return
+	}
+	defer conn.ReturnConnection()
+	result, err := conn.Query("select @@session.sql_mode")
+	if err == nil {
+		var sqlMode string
+		if sqlMode, err = result.GetString(0, 0); err == nil {
+			c.parserSQLMode = sqlparser.ParseSQLMode(sqlMode)
+			return
+		}
+	}
+	simplelog.Warn("%s %s %s connection id=%d,node=%s", "ClientConn", "readSQLMode", err.Error(), c.connectionID, node.Name)
+}
+
 // Run after handshake.
 func (c *ClientConn) Run() {
 	defer func() {
@@ -169,7 +254,16 @@ func (c *ClientConn) Run() {
 			simplelog.Error("%s %s %s connection id=%d", "server", "Run", err.Error(), c.connectionID)
 			return
 		}
-		if err := c.dispatch(data); err != nil {
+		if !atomic.CompareAndSwapInt32(&c.busy, 0, 1) {
+			return
+		}
+		err = c.dispatch(data)
+		c.releaseMemory()
+		if data[0] == mysql.COM_QUERY || data[0] == mysql.COM_STMT_EXECUTE {
+			c.trackTransaction(c.commandSQL(data))
+		}
+		atomic.StoreInt32(&c.busy, 0)
+		if err != nil {
 			c.proxy.counter.IncrErrLogTotal()
 			if len(data) > 1 {
 				simplelog.Error("%s %s %s connection id=%d,sql=%s",
@@ -201,15 +295,25 @@ func (c *ClientConn) Close() error {
 	if c.closed {
 		return nil
 	}
+	c.cancel()
 	c.nodeInTrans = nil
+	c.tablesLocked = false
+	c.releaseMemory()
+	for id := range c.cursors {
+		c.closeCursor(id)
+	}
 	for node := range c.backendMasterConns {
 		c.returnMasterConn(node)
 	}
 	for node := range c.backendSlaveConns {
 		c.returnSlaveConn(node)
 	}
+	for node := range c.backendOLAPConns {
+		c.returnAnalyticsConn(node)
+	}
 
 	c.c.Close()
+	c.stopCapture()
 
 	c.closed = true
 	c.proxy.RemoveConnection(c.connectionID)
@@ -221,6 +325,11 @@ func (c *ClientConn) dispatch(data []byte) error {
 	cmd := data[0]
 	data = data[1:]
 
+	if c.probe {
+		return c.dispatchProbe(cmd)
+	}
+	c.refreshSchemas()
+
 	switch cmd {
 	case mysql.COM_QUIT:
 		c.Close()
@@ -239,10 +348,12 @@ func (c *ClientConn) dispatch(data []byte) error {
 		return c.handleStmtExecute(data)
 	case mysql.COM_STMT_CLOSE:
 		return c.handleStmtClose(data)
-	// case mysql.COM_STMT_SEND_LONG_DATA:
-	// 	return c.handleStmtSendLongData(data)
-	// case mysql.COM_STMT_RESET:
-	// 	return c.handleStmtReset(data)
+	case mysql.COM_STMT_SEND_LONG_DATA:
+		return c.handleStmtSendLongData(data)
+	case mysql.COM_STMT_RESET:
+		return c.handleStmtReset(data)
+	case mysql.COM_STMT_FETCH:
+		return c.handleStmtFetch(data)
 	case mysql.COM_SET_OPTION:
 		return c.pkg.WriteEOF(c.capability, 0)
 	default:
@@ -251,9 +362,65 @@ func (c *ClientConn) dispatch(data []byte) error {
 		return mysql.NewError(mysql.ER_UNKNOWN_ERROR, msg)
 	}
 }
+
+// dispatchProbe answers probe session by proxy, COM_PING fails if not ready.
+func (c *ClientConn) dispatchProbe(cmd byte) error {
+	switch cmd {
+	case mysql.COM_QUIT:
+		c.Close()
+		return nil
+	case mysql.COM_PING:
+		if c.proxy.getHealth().Status != "online" {
+			return errors.ErrNotReady
+		}
+		return c.pkg.WriteOK(c.capability, c.status, nil)
+	default:
+		return errors.ErrProbeOnly
+	}
+}
+
+// refreshSchemas get schemas of user again after shard rules are activated or rolled back,
+// they aren't changed in transaction.
+func (c *ClientConn) refreshSchemas() {
+	if version := c.proxy.getRuleVersion(); version != c.ruleVersion && !c.isInTransaction() {
+		c.schemas = c.proxy.getSchemasByUser(c.user)
+		c.ruleVersion = version
+	}
+}
+
+// queryContext is context of a command, it's cancelled when closed, client disconnected or query_timeout is exceeded.
+func (c *ClientConn) queryContext() (context.Context, context.CancelFunc) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout := c.proxy.cfg.QueryTimeout; timeout > 0 {
+		ctx, cancel = context.WithTimeout(c.ctx, time.Duration(timeout)*time.Millisecond)
+	} else {
+		ctx, cancel = context.WithCancel(c.ctx)
+	}
+	// Client hits Ctrl-C or crashes while waiting for result, running backend query is killed at once.
+	stopWatch := c.pkg.WatchClose(clientCloseCheckInterval, func() {
+		simplelog.Info("%s %s %s connection id=%d", "ClientConn", "queryContext", "client disconnected, cancel query", c.connectionID)
+		cancel()
+	})
+	return ctx, func() {
+		stopWatch()
+		cancel()
+	}
+}
+
+// isInTransaction is also true while tables are locked, statements run at the same node.
 func (c *ClientConn) isInTransaction() bool {
 	return c.status&mysql.SERVER_STATUS_IN_TRANS > 0 ||
-		!c.isAutoCommit()
+		!c.isAutoCommit() || c.tablesLocked
+}
+
+// setMoreResults set SERVER_MORE_RESULTS_EXISTS, that will be kept when more statements of script to execute.
+func (c *ClientConn) setMoreResults(moreResult bool) {
+	if moreResult || c.moreResultsInBatch {
+		c.status |= mysql.SERVER_MORE_RESULTS_EXISTS
+	} else {
+		c.status &= ^mysql.SERVER_MORE_RESULTS_EXISTS
+	}
 }
 
 func (c *ClientConn) isAutoCommit() bool {
@@ -264,6 +431,10 @@ func (c *ClientConn) getOrCreateMasterConn(node *backend.DataNode) (conn backend
 	defer c.Unlock()
 
 	c.Lock()
+	if node.DataHost.Master.InDrill() {
+		c.proxy.rejectByDrill()
+		return nil, errors.ErrMasterDrill
+	}
 	if conn = c.backendMasterConns[node]; conn == nil {
 		for cachedNode := range c.backendMasterConns {
 			if cachedNode.DataHost == node.DataHost {
@@ -283,6 +454,7 @@ func (c *ClientConn) getOrCreateMasterConn(node *backend.DataNode) (conn backend
 			c.backendMasterConns[node] = conn
 		}
 	}
+	c.proxy.recoverFromDrill(node.DataHost)
 	return
 }
 
@@ -298,39 +470,67 @@ func (c *ClientConn) returnMasterConn(node *backend.DataNode) {
 }
 
 func (c *ClientConn) getOrCreateSlaveConn(node *backend.DataNode) (conn backend.Connection, err error) {
+	return c.getOrCreateReplicaConn(c.backendSlaveConns, node, node.DataHost.GetSlave)
+}
+
+func (c *ClientConn) returnSlaveConn(node *backend.DataNode) {
+	c.returnReplicaConn(c.backendSlaveConns, node)
+}
+
+func (c *ClientConn) getOrCreateAnalyticsConn(node *backend.DataNode) (conn backend.Connection, err error) {
+	return c.getOrCreateReplicaConn(c.backendOLAPConns, node, node.DataHost.GetAnalytics)
+}
+
+func (c *ClientConn) returnAnalyticsConn(node *backend.DataNode) {
+	c.returnReplicaConn(c.backendOLAPConns, node)
+}
+
+func (c *ClientConn) getOrCreateReplicaConn(conns map[*backend.DataNode]backend.Connection, node *backend.DataNode,
+	getHost func() (*backend.DBHost, error)) (conn backend.Connection, err error) {
 	defer c.Unlock()
 
 	c.Lock()
-	if conn = c.backendSlaveConns[node]; conn == nil {
-		for cachedNode := range c.backendSlaveConns {
+	if conn = conns[node]; conn == nil {
+		for cachedNode := range conns {
 			if cachedNode.DataHost == node.DataHost {
-				conn = c.backendSlaveConns[cachedNode]
-				c.backendSlaveConns[node] = conn
+				conn = conns[cachedNode]
+				conns[node] = conn
 				break
 			}
 		}
 
 		if conn == nil || conn.IsClosed() {
 			var dbHost *backend.DBHost
-			dbHost, err = node.DataHost.GetSlave()
+			dbHost, err = getHost()
 			if err != nil {
 				return
 			}
 			if conn, err = dbHost.GetConnection(node.Database); err != nil {
 				return
 			}
-			c.backendSlaveConns[node] = conn
+			conns[node] = conn
 		}
 	}
 	return
 }
 
-func (c *ClientConn) returnSlaveConn(node *backend.DataNode) {
+func (c *ClientConn) returnReplicaConn(conns map[*backend.DataNode]backend.Connection, node *backend.DataNode) {
 	defer c.Unlock()
 
 	c.Lock()
-	if conn := c.backendSlaveConns[node]; conn != nil && !conn.IsClosed() {
+	if conn := conns[node]; conn != nil && !conn.IsClosed() {
 		conn.ReturnConnection()
-		delete(c.backendSlaveConns, node)
+		delete(conns, node)
+	}
+}
+
+// getBackendConn from analytics replica, slave or master.
+func (c *ClientConn) getBackendConn(node *backend.DataNode, isSlave bool) (backend.Connection, error) {
+	if isSlave && c.onAnalytics && len(node.DataHost.Analytics) > 0 {
+		return c.getOrCreateAnalyticsConn(node)
+	}
+	if isSlave && len(node.DataHost.Slaves) > 0 {
+		return c.getOrCreateSlaveConn(node)
 	}
+	return c.getOrCreateMasterConn(node)
 }
@@ -0,0 +1,316 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"fmt"
+	"hash/crc32"
+	"strings"
+	"sync/atomic"
+
+	"github.com/berkaroad/saashard/backend"
+	mysqlBackend "github.com/berkaroad/saashard/backend/mysql"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/route"
+	"github.com/berkaroad/saashard/sqlparser"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// Diff mode compares results of select between schema and its diff_schema.
+const (
+	diffModeOff int32 = iota
+	diffModeCount
+	diffModeChecksum
+	diffModeFull
+)
+
+var diffModeNames = []string{"off", "count", "checksum", "full"}
+
+// maxDiffRows is the max count of different rows to report in full mode.
+const maxDiffRows = 10
+
+func parseDiffMode(name string) (int32, error) {
+	if len(name) == 0 {
+		return diffModeOff, nil
+	}
+	for i, modeName := range diffModeNames {
+		if strings.EqualFold(name, modeName) {
+			return int32(i), nil
+		}
+	}
+	return diffModeOff, errors.ErrInvalidArgument
+}
+
+// diffQueueSize is the max count of selects waiting to be compared, selects aren't compared when queue is full,
+// so that diff mode doesn't slow down client, and only a sample of selects is compared under load.
+const diffQueueSize = 256
+
+// diffTask is a select to compare with diff_schema, after its result is sent to client.
+// Select of diff_schema that fans out to nodes is merged like fan-out select of client.
+type diffTask struct {
+	schema            string
+	diffSchema        string
+	nodes             []*backend.DataNode
+	isSlave           bool
+	sql               string
+	planned           *mysql.Result // result of plan without executing, such as select of constants.
+	merge             *route.Merge
+	groupConcatMaxLen int
+	sqlMode           string
+	sqlModeOn         bool
+	variables         map[string]string // session variables of client, that select may reference.
+	result            *mysql.Result
+	mode              int32
+}
+
+func (p *Server) startDiff() {
+	p.diffs = make(chan *diffTask, diffQueueSize)
+	go p.runDiff()
+}
+
+func (p *Server) runDiff() {
+	for task := range p.diffs {
+		reason, err := task.compare()
+		if err != nil {
+			reason = err.Error()
+		}
+		if len(reason) > 0 {
+			p.counter.IncrDiffMismatchTotal()
+			simplelog.Warn("%s %s %s schema=%s,diffSchema=%s,reason=%s,sql=%s", "proxy", "diffResult", "Result mismatch",
+				task.schema, task.diffSchema, reason, task.sql)
+		}
+	}
+}
+
+// diffResult queue select with routing of diff_schema, to report mismatches with result asynchronously.
+// It's used to verify new routing while resharding, client always get the result of current schema.
+// Select with lock or assignment of user variable isn't compared, since it has side effects.
+func (c *ClientConn) diffResult(statement sqlparser.Statement, result *mysql.Result, isSlave bool) {
+	mode := atomic.LoadInt32(&c.proxy.diffMode)
+	if mode == diffModeOff || result.Resultset == nil || result.Spilled != nil {
+		return
+	}
+	switch statement.(type) {
+	case *sqlparser.Select, *sqlparser.Union:
+	default:
+		return
+	}
+	if hasSideEffects(statement) {
+		return
+	}
+	schema := c.schemas[c.db]
+	if schema == nil || len(schema.DiffSchema) == 0 || c.proxy.getSchemas()[schema.DiffSchema] == nil {
+		return
+	}
+
+	c.proxy.counter.IncrDiffTotal()
+	task, err := c.newDiffTask(schema.DiffSchema, statement, result, isSlave, mode)
+	if err != nil {
+		c.proxy.counter.IncrDiffMismatchTotal()
+		simplelog.Warn("%s %s %s schema=%s,diffSchema=%s,reason=%s,sql=%s", "proxy", "diffResult", "Result mismatch",
+			c.db, schema.DiffSchema, err.Error(), sqlparser.String(statement))
+		return
+	}
+	select {
+	case c.proxy.diffs <- task:
+	default:
+		simplelog.Warn("%s %s %s sql=%s", "proxy", "diffResult", "diff queue is full, select isn't compared", task.sql)
+	}
+}
+
+// hasSideEffects check whether select locks rows or assigns user variables, that executing it again isn't harmless.
+func hasSideEffects(statement sqlparser.Statement) (found bool) {
+	buf := sqlparser.NewTrackedBuffer(func(buf *sqlparser.TrackedBuffer, node sqlparser.SQLNode) {
+		switch v := node.(type) {
+		case *sqlparser.Select:
+			if v.Lock != "" {
+				found = true
+			}
+		case *sqlparser.Assignment:
+			found = true
+		}
+		node.Format(buf)
+	})
+	buf.Fprintf("%v", statement)
+	return
+}
+
+func (c *ClientConn) newDiffTask(diffSchema string, statement sqlparser.Statement, result *mysql.Result,
+	isSlave bool, mode int32) (*diffTask, error) {
+	router := c.newRouter()
+	router.SchemaName = diffSchema
+	router.Schemas = c.proxy.getSchemas()
+	plan, err := router.BuildNormalPlan(statement)
+	if err != nil {
+		return nil, err
+	}
+	task := &diffTask{
+		schema:            c.db,
+		diffSchema:        diffSchema,
+		isSlave:           isSlave,
+		merge:             plan.GetMerge(),
+		groupConcatMaxLen: c.groupConcatMaxLen(),
+		sqlMode:           c.sqlMode,
+		sqlModeOn:         c.sqlModeOn,
+		variables:         make(map[string]string, len(c.sessionVariables)),
+		result:            result,
+		mode:              mode,
+	}
+	// statement of nodes is rewritten by plan, such as merged select, it's read by executor without executing.
+	err = plan.Execute(func(statements []sqlparser.Statement, results []*mysql.Result, dataNodes []string, _ bool,
+		_ map[sqlparser.Statement][]string) ([]string, error) {
+		if task.planned = results[0]; task.planned != nil {
+			return nil, nil
+		}
+		for _, dataNode := range dataNodes {
+			node := c.proxy.nodes[dataNode]
+			if node == nil {
+				return nil, errors.ErrNoDataNode
+			}
+			task.nodes = append(task.nodes, node)
+		}
+		task.sql = c.backendSQL(statements[0])
+		return nil, nil
+	}, c.c.RemoteAddr(), false, 0, c.proxy.counter)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range c.sessionVariables {
+		task.variables[name] = value
+	}
+	return task, nil
+}
+
+// compare execute select on nodes of diff_schema with session state of client, and merge their rows.
+func (t *diffTask) compare() (string, error) {
+	diffResult := t.planned
+	for _, node := range t.nodes {
+		nodeResult, err := t.query(node)
+		if err != nil {
+			return "", err
+		}
+		if nodeResult.Resultset == nil {
+			return "no resultset", nil
+		}
+		if diffResult == nil {
+			diffResult = nodeResult
+		} else if err = appendResult(diffResult, nodeResult); err != nil {
+			return "", err
+		}
+	}
+	if diffResult == nil || diffResult.Resultset == nil {
+		return "no resultset", nil
+	}
+	if t.merge != nil && len(t.nodes) > 1 {
+		if err := mergeResult(diffResult, t.merge, nil, t.groupConcatMaxLen); err != nil {
+			return "", err
+		}
+	}
+	return compareResults(t.result, diffResult, t.mode), nil
+}
+
+func (t *diffTask) query(node *backend.DataNode) (*mysql.Result, error) {
+	dbHost := node.DataHost.Master
+	if t.isSlave {
+		if slave, e := node.DataHost.GetSlave(); e == nil {
+			dbHost = slave
+		}
+	}
+	conn, err := dbHost.GetConnection(node.Database)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.ReturnConnection()
+
+	mysqlConn := conn.(*mysqlBackend.Conn)
+	if t.sqlModeOn {
+		if err = mysqlConn.SetSQLMode(t.sqlMode); err != nil {
+			return nil, err
+		}
+	}
+	if err = mysqlConn.SetVariables(t.variables); err != nil {
+		return nil, err
+	}
+	return mysqlConn.Query(t.sql)
+}
+
+// compareResults return the reason if results are different,
+// rows are compared regardless of order, since the order isn't stable without 'order by'.
+func compareResults(expected, actual *mysql.Result, mode int32) string {
+	if len(expected.Rows) != len(actual.Rows) {
+		return fmt.Sprintf("row count %d != %d", len(expected.Rows), len(actual.Rows))
+	}
+	switch mode {
+	case diffModeChecksum:
+		if expectedSum, actualSum := checksumRows(expected.Rows), checksumRows(actual.Rows); expectedSum != actualSum {
+			return fmt.Sprintf("checksum %d != %d", expectedSum, actualSum)
+		}
+	case diffModeFull:
+		counts := make(map[string]int)
+		for _, row := range expected.Rows {
+			counts[string(row.Dump())]++
+		}
+		for _, row := range actual.Rows {
+			counts[string(row.Dump())]--
+		}
+		var missing, extra []string
+		for _, row := range expected.Rows {
+			if key := string(row.Dump()); counts[key] > 0 && len(missing) < maxDiffRows {
+				missing = append(missing, formatRow(row, len(expected.Fields)))
+				counts[key]--
+			}
+		}
+		for _, row := range actual.Rows {
+			if key := string(row.Dump()); counts[key] < 0 && len(extra) < maxDiffRows {
+				extra = append(extra, formatRow(row, len(actual.Fields)))
+				counts[key]++
+			}
+		}
+		if len(missing) > 0 || len(extra) > 0 {
+			return fmt.Sprintf("missing rows [%s], extra rows [%s]", strings.Join(missing, "; "), strings.Join(extra, "; "))
+		}
+	}
+	return ""
+}
+
+// checksumRows sum crc32 of each row, so that it's independent of order.
+func checksumRows(rows []*mysql.Row) uint64 {
+	var sum uint64
+	for _, row := range rows {
+		sum += uint64(crc32.ChecksumIEEE(row.Dump()))
+	}
+	return sum
+}
+
+func formatRow(row *mysql.Row, columnCount int) string {
+	values := make([]string, columnCount)
+	for i := range values {
+		if row.GetValue(i) == nil {
+			values[i] = "NULL"
+		} else {
+			values[i] = string(row.GetRawValue(i))
+		}
+	}
+	return "(" + strings.Join(values, ", ") + ")"
+}
@@ -0,0 +1,92 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/berkaroad/saashard/backend"
+	"github.com/berkaroad/saashard/backend/mock"
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/route"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+func TestHasSideEffects(t *testing.T) {
+	cases := []struct {
+		sql  string
+		want bool
+	}{
+		{"select a from t where id = 1", false},
+		{"select a from t where id = @id", false},
+		{"select a from t where id = 1 for update", true},
+		{"select a from t where id = 1 lock in share mode", true},
+		{"select a from t union select a from t2 for update", true},
+		{"select @n := @n + 1, a from t", true},
+		{"select a from t where id in (select id from t2 for update)", true},
+	}
+	for _, c := range cases {
+		statement, err := sqlparser.Parse(c.sql)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", c.sql, err)
+			continue
+		}
+		if got := hasSideEffects(statement); got != c.want {
+			t.Errorf("hasSideEffects(%q) = %v, want %v", c.sql, got, c.want)
+		}
+	}
+}
+
+func TestDiffTaskCompareFanout(t *testing.T) {
+	node1, s1 := newMockNode(t, "n1")
+	node2, s2 := newMockNode(t, "n2")
+	s1.Handle(`^select g, count\(\*\) from t group by g`, &mock.Response{Columns: []string{"g", "count(*)"},
+		Rows: [][]interface{}{{"a", 1}, {"b", 2}}})
+	s2.Handle(`^select g, count\(\*\) from t group by g`, &mock.Response{Columns: []string{"g", "count(*)"},
+		Rows: [][]interface{}{{"b", 3}, {"c", 4}}})
+
+	fields := []*mysql.Field{newTestField("g", mysql.MYSQL_TYPE_VAR_STRING), newTestField("count(*)", mysql.MYSQL_TYPE_VAR_STRING)}
+	funcs := selectFuncs(t, "select g, count(*) from t group by g")
+	merge := &route.Merge{Grouped: true, GroupBy: []int{0}, Aggregates: []route.MergeAggregate{{Column: 1, Func: funcs[1]}},
+		OrderBy: []mysql.SortKey{{Column: 0}}, Count: -1}
+	cases := []struct {
+		expected *mysql.Result
+		want     string
+	}{
+		// groups of nodes are merged before compared, so that fan-out of diff_schema isn't a mismatch.
+		{newNodeResult(t, fields, "a,1", "b,5", "c,4"), ""},
+		{newNodeResult(t, fields, "a,1", "b,2", "c,4"), "checksum"},
+	}
+	for _, c := range cases {
+		task := &diffTask{nodes: []*backend.DataNode{node1, node2}, sql: "select g, count(*) from t group by g",
+			merge: merge, result: c.expected, mode: diffModeChecksum}
+		reason, err := task.compare()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(c.want) == 0 && len(reason) > 0 || len(c.want) > 0 && !strings.HasPrefix(reason, c.want) {
+			t.Errorf("compare() = %q, want %q", reason, c.want)
+		}
+	}
+}
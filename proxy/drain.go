@@ -0,0 +1,76 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package proxy
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+const drainCheckInterval = 100 * time.Millisecond
+
+// Drain proxy before shutdown, such as SIGTERM of pod within terminationGracePeriodSeconds.
+// Readiness is false at once, new connections are still accepted for delay, so that load balancer removes proxy,
+// then listeners are closed, and idle sessions (not in transaction or command) are closed until timeout.
+func (p *Server) Drain(delay, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	atomic.StoreInt32(&p.draining, 1)
+	simplelog.Info("%s %s %s delay=%s,timeout=%s", "server/proxy", "Drain", "Draining", delay, timeout)
+	time.Sleep(delay)
+
+	p.running = false
+	if p.listener != nil {
+		p.listener.Close()
+	}
+	for _, l := range p.listeners {
+		l.Close()
+	}
+	for time.Now().Before(deadline) {
+		if p.closeIdleSessions() == 0 {
+			simplelog.Info("%s %s %s", "server/proxy", "Drain", "All sessions are closed")
+			return
+		}
+		time.Sleep(drainCheckInterval)
+	}
+	simplelog.Warn("%s %s %s", "server/proxy", "Drain", "Drain timeout, busy sessions are closed")
+}
+
+// isDraining is true if proxy is draining.
+func (p *Server) isDraining() bool {
+	return atomic.LoadInt32(&p.draining) == 1
+}
+
+// closeIdleSessions close sessions that are idle out of transaction, and returns count of remained sessions.
+func (p *Server) closeIdleSessions() int {
+	defer p.Unlock()
+
+	p.Lock()
+	for _, conn := range p.conns {
+		if !conn.isInTransaction() && atomic.CompareAndSwapInt32(&conn.busy, 0, -1) {
+			// Run of session returns on read error, and closes it.
+			conn.c.Close()
+		}
+	}
+	return len(p.conns)
+}
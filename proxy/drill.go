@@ -0,0 +1,159 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/berkaroad/saashard/backend"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// FailoverDrillReport.Status
+const (
+	DrillStatusRunning    = "running"
+	DrillStatusRecovering = "recovering" // stopped, no statement reached master yet.
+	DrillStatusRecovered  = "recovered"
+)
+
+// failoverDrill is a rehearsal of failover, master of host is unreachable for routing until stopped, mysql isn't touched.
+type failoverDrill struct {
+	sync.Mutex
+	host       string // empty if no drill.
+	started    time.Time
+	stopped    time.Time
+	recovered  time.Time // first master connection of client after stopped.
+	errLogs    int64     // err log total when started.
+	clientErrs int64     // errors of clients during drill, when stopped.
+	rejected   int64     // statements rejected by unreachable master.
+	recovering int32     // 1 if stopped and not recovered.
+}
+
+// FailoverDrillReport is state of current or last failover drill.
+type FailoverDrillReport struct {
+	Host         string
+	Addr         string
+	Status       string
+	Started      time.Time
+	Stopped      time.Time     // zero if running.
+	Rejected     int64         // statements rejected by unreachable master.
+	ClientErrors int64         // errors written to clients.
+	RecoveryTime time.Duration // from stopped to first master connection of client.
+}
+
+// StartFailoverDrill mark master of host unreachable for routing, until StopFailoverDrill.
+func (p *Server) StartFailoverDrill(hostName string) error {
+	host, ok := p.hosts[hostName]
+	if !ok {
+		return fmt.Errorf("host '%s' not found", hostName)
+	}
+	d := &p.drill
+	d.Lock()
+	defer d.Unlock()
+	if len(d.host) > 0 && d.stopped.IsZero() {
+		return errors.ErrDrillRunning
+	}
+	atomic.StoreInt32(&d.recovering, 0)
+	d.host = hostName
+	d.started = time.Now()
+	d.stopped = time.Time{}
+	d.recovered = time.Time{}
+	d.errLogs = atomic.LoadInt64(&p.counter.ErrLogTotal)
+	d.clientErrs = 0
+	atomic.StoreInt64(&d.rejected, 0)
+	host.Master.SetDrill(true)
+
+	simplelog.Warn("%s %s %s host=%s,addr=%s", "proxy", "StartFailoverDrill", "Master is unreachable by failover drill",
+		hostName, host.Master.Addr)
+	p.fireHook(&hookEvent{Event: hookEventDrillStart, DataHost: hostName, Addr: host.Master.Addr})
+	return nil
+}
+
+// StopFailoverDrill restore master of host in drill, recovery time is measured by first master connection of client.
+func (p *Server) StopFailoverDrill() error {
+	d := &p.drill
+	d.Lock()
+	defer d.Unlock()
+	if len(d.host) == 0 || !d.stopped.IsZero() {
+		return errors.ErrNoDrill
+	}
+	host := p.hosts[d.host]
+	host.Master.SetDrill(false)
+	d.stopped = time.Now()
+	d.clientErrs = atomic.LoadInt64(&p.counter.ErrLogTotal) - d.errLogs
+	atomic.StoreInt32(&d.recovering, 1)
+
+	simplelog.Warn("%s %s %s host=%s,rejected=%d,client_errors=%d", "proxy", "StopFailoverDrill", "Failover drill is stopped",
+		d.host, atomic.LoadInt64(&d.rejected), d.clientErrs)
+	p.fireHook(&hookEvent{Event: hookEventDrillStop, DataHost: d.host, Addr: host.Master.Addr})
+	return nil
+}
+
+// GetFailoverDrill get report of current or last failover drill, nil if none.
+func (p *Server) GetFailoverDrill() *FailoverDrillReport {
+	d := &p.drill
+	d.Lock()
+	defer d.Unlock()
+	if len(d.host) == 0 {
+		return nil
+	}
+	report := &FailoverDrillReport{Host: d.host, Addr: p.hosts[d.host].Master.Addr,
+		Started: d.started, Stopped: d.stopped, Rejected: atomic.LoadInt64(&d.rejected)}
+	switch {
+	case d.stopped.IsZero():
+		report.Status = DrillStatusRunning
+		report.ClientErrors = atomic.LoadInt64(&p.counter.ErrLogTotal) - d.errLogs
+	case d.recovered.IsZero():
+		report.Status = DrillStatusRecovering
+		report.ClientErrors = d.clientErrs
+	default:
+		report.Status = DrillStatusRecovered
+		report.ClientErrors = d.clientErrs
+		report.RecoveryTime = d.recovered.Sub(d.stopped)
+	}
+	return report
+}
+
+// rejectByDrill count statement rejected by unreachable master.
+func (p *Server) rejectByDrill() {
+	atomic.AddInt64(&p.drill.rejected, 1)
+}
+
+// recoverFromDrill record recovery time, when client connects master of stopped drill first.
+func (p *Server) recoverFromDrill(host *backend.DataHost) {
+	d := &p.drill
+	if atomic.LoadInt32(&d.recovering) == 0 {
+		return
+	}
+	d.Lock()
+	defer d.Unlock()
+	if p.hosts[d.host] == host && atomic.CompareAndSwapInt32(&d.recovering, 1, 0) {
+		d.recovered = time.Now()
+		simplelog.Info("%s %s %s host=%s,recovery_time=%s", "proxy", "recoverFromDrill", "Failover drill is recovered",
+			d.host, d.recovered.Sub(d.stopped))
+	}
+}
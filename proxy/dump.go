@@ -0,0 +1,214 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/berkaroad/saashard/backend"
+	mysqlBackend "github.com/berkaroad/saashard/backend/mysql"
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/route"
+)
+
+const defaultDumpChunkSize = 1000
+
+// Dump write logical dump of table in schema into w, without running proxy.
+// It's in mysqldump format, CREATE TABLE with logical name, and INSERTs of rows gathered from all nodes,
+// so that restore tools could consume it as a single table.
+func Dump(cfg *config.Config, schemaName, table string, chunkSize int, w io.Writer) error {
+	p := new(Server)
+	p.cfg = cfg
+	p.hosts = make(map[string]*backend.DataHost)
+	p.nodes = make(map[string]*backend.DataNode)
+	if err := p.parseHosts(); err != nil {
+		return err
+	}
+	if err := p.parseNodes(); err != nil {
+		return err
+	}
+	if err := p.parseSchemas(); err != nil {
+		return err
+	}
+	return p.dumpTable(schemaName, strings.ToLower(table), chunkSize, w)
+}
+
+// dumpTable of nodes one by one, rows of each node are read in a consistent snapshot, chunked by primary key order.
+// Sub-sharded table is read from all of its physical tables.
+func (p *Server) dumpTable(schemaName, table string, chunkSize int, w io.Writer) error {
+	nodeNames, physicalNames, err := p.tableNodes(schemaName, table)
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(w)
+	writeCreate := func(createSQL string) error {
+		createSQL = strings.Replace(createSQL, quoteIdentifier(physicalNames[0]), quoteIdentifier(table), 1)
+		fmt.Fprintf(out, "-- SaaShard logical dump of table %s\n", quoteIdentifier(table))
+		fmt.Fprintf(out, "/*!40101 SET NAMES utf8mb4 */;\n")
+		fmt.Fprintf(out, "/*!40014 SET @OLD_FOREIGN_KEY_CHECKS=@@FOREIGN_KEY_CHECKS, FOREIGN_KEY_CHECKS=0 */;\n")
+		_, err := fmt.Fprintf(out, "DROP TABLE IF EXISTS %s;\n%s;\n", quoteIdentifier(table), createSQL)
+		return err
+	}
+	writeRows := func(rs *mysql.Resultset) error {
+		_, err := fmt.Fprintf(out, "%s;\n", buildRowsSQL("insert into", table, rs))
+		return err
+	}
+	for i, nodeName := range nodeNames {
+		node := p.nodes[nodeName]
+		if node == nil {
+			return errors.ErrNoDataNode
+		}
+		conn := backend.CreateConnection(node.DataHost.Master)
+		if err := conn.Connect(node.DataHost.Master, node.Database); err != nil {
+			return err
+		}
+		create := writeCreate
+		if i > 0 {
+			create = nil
+		}
+		err := scanNode(conn.(*mysqlBackend.Conn), physicalNames, chunkSize, create, writeRows)
+		conn.Close()
+		if err != nil {
+			return fmt.Errorf("dump node '%s' error: %s", nodeName, err.Error())
+		}
+	}
+	fmt.Fprintf(out, "/*!40014 SET FOREIGN_KEY_CHECKS=@OLD_FOREIGN_KEY_CHECKS */;\n")
+	return out.Flush()
+}
+
+// tableNodes of logical table in schema, and physical names of table in each node.
+func (p *Server) tableNodes(schemaName, table string) (nodeNames, physicalNames []string, err error) {
+	schema := p.getSchemas()[schemaName]
+	if schema == nil {
+		return nil, nil, mysql.NewDefaultError(mysql.ER_BAD_DB_ERROR, schemaName)
+	}
+	tableConfig := schema.GetTables()[table]
+	nodeNames = schema.Nodes
+	if tableConfig != nil && len(tableConfig.PinNodes) > 0 {
+		nodeNames = tableConfig.PinNodes
+	} else if !schema.ShardEnabled() {
+		nodeNames = schema.Nodes[:1]
+	}
+	physicalNames = []string{table}
+	if route.SubShardEnabled(tableConfig) {
+		physicalNames = make([]string, tableConfig.SubShardCount)
+		for index := range physicalNames {
+			physicalNames[index] = route.PhysicalTableName(table, index)
+		}
+	}
+	return nodeNames, physicalNames, nil
+}
+
+// scanNode read rows of physical tables in a consistent snapshot of conn, chunked by primary key order.
+// CREATE TABLE of the first physical table is passed to create before rows, if create isn't nil.
+// Chunks are read after primary key of the last row, table without primary key is chunked by offset.
+func scanNode(conn *mysqlBackend.Conn, physicalNames []string, chunkSize int,
+	create func(createSQL string) error, rows func(rs *mysql.Resultset) error) error {
+	if chunkSize <= 0 {
+		chunkSize = defaultDumpChunkSize
+	}
+	// isolation level of the next transaction only, so that it isn't left to session of pooled conn.
+	if _, err := conn.Query("set transaction isolation level repeatable read"); err != nil {
+		return err
+	}
+	if _, err := conn.Query("start transaction with consistent snapshot"); err != nil {
+		return err
+	}
+	defer conn.Rollback()
+
+	if create != nil {
+		result, err := conn.Query("show create table " + quoteIdentifier(physicalNames[0]))
+		if err != nil {
+			return err
+		}
+		createSQL, err := result.GetString(0, 1)
+		if err != nil {
+			return err
+		}
+		if err = create(createSQL); err != nil {
+			return err
+		}
+	}
+
+	for _, physicalName := range physicalNames {
+		keys, err := primaryKeyColumns(conn, physicalName)
+		if err != nil {
+			return err
+		}
+		selectSQL := "select * from " + quoteIdentifier(physicalName)
+		after := ""
+		for offset := 0; ; offset += chunkSize {
+			var sql string
+			if len(keys) > 0 {
+				sql = fmt.Sprintf("%s%s order by %s limit %d", selectSQL, after, quoteIdentifiers(keys), chunkSize)
+			} else {
+				sql = fmt.Sprintf("%s limit %d, %d", selectSQL, offset, chunkSize)
+			}
+			result, err := conn.Query(sql)
+			if err != nil {
+				return err
+			}
+			if result.Resultset == nil || len(result.Rows) == 0 {
+				break
+			}
+			if err = rows(result.Resultset); err != nil {
+				return err
+			}
+			if len(result.Rows) < chunkSize {
+				break
+			}
+			if len(keys) > 0 {
+				if after, err = keysetCondition(keys, result.Resultset); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// keysetCondition return where condition of rows after the last row of rs by primary key.
+func keysetCondition(keys []string, rs *mysql.Resultset) (string, error) {
+	row := rs.Rows[len(rs.Rows)-1]
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		index := -1
+		for j, field := range rs.Fields {
+			if strings.EqualFold(string(field.Name), key) {
+				index = j
+				break
+			}
+		}
+		if index < 0 {
+			return "", mysql.NewDefaultError(mysql.ER_BAD_FIELD_ERROR, key, "field list")
+		}
+		values[i] = "'" + mysql.Escape(string(row.GetRawValue(index))) + "'"
+	}
+	return fmt.Sprintf(" where (%s) > (%s)", quoteIdentifiers(keys), strings.Join(values, ", ")), nil
+}
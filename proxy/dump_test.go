@@ -0,0 +1,45 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/berkaroad/saashard/net/mysql"
+)
+
+func TestKeysetCondition(t *testing.T) {
+	fields := []*mysql.Field{newTestField("Tenant_ID", mysql.MYSQL_TYPE_LONG), newTestField("name", mysql.MYSQL_TYPE_VAR_STRING),
+		newTestField("seq", mysql.MYSQL_TYPE_LONG)}
+	result := newNodeResult(t, fields, "1,a,1", "1,it's,2")
+	got, err := keysetCondition([]string{"tenant_id", "seq"}, result.Resultset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := " where (`tenant_id`, `seq`) > ('1', '2')"; got != want {
+		t.Errorf("keysetCondition() = %q, want %q", got, want)
+	}
+	if _, err = keysetCondition([]string{"id"}, result.Resultset); err == nil {
+		t.Errorf("keysetCondition() of missing key should be error")
+	}
+}
@@ -0,0 +1,345 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// Formats of export endpoint.
+const (
+	exportCSV    = "csv"
+	exportNDJSON = "ndjson"
+	exportJSON   = "json"
+)
+
+var exportContentTypes = map[string]string{
+	exportCSV:    "text/csv; charset=utf-8",
+	exportNDJSON: "application/x-ndjson",
+	exportJSON:   "application/json",
+}
+
+// negotiateExportFormat by 'format' of query string, or by Accept header, default is json array.
+func negotiateExportFormat(r *http.Request) (string, bool) {
+	if format := strings.ToLower(r.URL.Query().Get("format")); len(format) > 0 {
+		_, ok := exportContentTypes[format]
+		return format, ok
+	}
+	accept := r.Header.Get("Accept")
+	if len(accept) == 0 {
+		return exportJSON, true
+	}
+	for _, mediaType := range strings.Split(accept, ",") {
+		if i := strings.IndexByte(mediaType, ';'); i >= 0 {
+			mediaType = mediaType[:i]
+		}
+		switch strings.ToLower(strings.TrimSpace(mediaType)) {
+		case "text/csv":
+			return exportCSV, true
+		case "application/x-ndjson", "application/ndjson":
+			return exportNDJSON, true
+		case "application/json", "application/*", "*/*":
+			return exportJSON, true
+		}
+	}
+	return "", false
+}
+
+// exportWriter write chunks of rows in format of export endpoint.
+type exportWriter interface {
+	writeRows(rs *mysql.Resultset) error
+	close() error
+}
+
+func newExportWriter(format string, w io.Writer) exportWriter {
+	switch format {
+	case exportCSV:
+		return &csvExportWriter{w: csv.NewWriter(w)}
+	case exportNDJSON:
+		return &jsonExportWriter{w: w}
+	default:
+		return &jsonExportWriter{w: w, array: true}
+	}
+}
+
+// exportBase64Header lists columns of binary strings, such as varbinary and blob, they're exported in base64.
+const exportBase64Header = "X-Base64-Columns"
+
+// isBinaryField is binary string or bit, that could be invalid utf-8.
+func isBinaryField(field *mysql.Field) bool {
+	if field.Charset != uint16(mysql.CollationNames["binary"]) {
+		return false
+	}
+	switch field.ColumnType {
+	case mysql.MYSQL_TYPE_STRING, mysql.MYSQL_TYPE_VAR_STRING, mysql.MYSQL_TYPE_VARCHAR, mysql.MYSQL_TYPE_BIT,
+		mysql.MYSQL_TYPE_TINY_BLOB, mysql.MYSQL_TYPE_MEDIUM_BLOB, mysql.MYSQL_TYPE_LONG_BLOB, mysql.MYSQL_TYPE_BLOB:
+		return true
+	}
+	return false
+}
+
+// exportValue is text of value, binary string is in base64.
+func exportValue(field *mysql.Field, row *mysql.Row, column int) string {
+	if isBinaryField(field) {
+		return base64.StdEncoding.EncodeToString(row.GetRawValue(column))
+	}
+	return string(row.GetRawValue(column))
+}
+
+// csvExportWriter write header of column names before the first row, null is empty.
+type csvExportWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (e *csvExportWriter) writeRows(rs *mysql.Resultset) error {
+	if !e.header {
+		names := make([]string, len(rs.Fields))
+		for i, field := range rs.Fields {
+			names[i] = string(field.Name)
+		}
+		if err := e.w.Write(names); err != nil {
+			return err
+		}
+		e.header = true
+	}
+	record := make([]string, len(rs.Fields))
+	for _, row := range rs.Rows {
+		for i, field := range rs.Fields {
+			record[i] = exportValue(field, row, i)
+		}
+		if err := e.w.Write(record); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExportWriter) close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// jsonExportWriter write rows as objects, one per line, or in a json array.
+type jsonExportWriter struct {
+	w     io.Writer
+	array bool
+	rows  int
+}
+
+func (e *jsonExportWriter) writeRows(rs *mysql.Resultset) error {
+	var buf []byte
+	for _, row := range rs.Rows {
+		if e.array {
+			if e.rows == 0 {
+				buf = append(buf, '[')
+			} else {
+				buf = append(buf, ',')
+			}
+		}
+		buf = appendRowJSON(buf, rs.Fields, row)
+		if !e.array {
+			buf = append(buf, '\n')
+		}
+		e.rows++
+	}
+	_, err := e.w.Write(buf)
+	return err
+}
+
+func (e *jsonExportWriter) close() error {
+	if !e.array {
+		return nil
+	}
+	var err error
+	if e.rows == 0 {
+		_, err = io.WriteString(e.w, "[]\n")
+	} else {
+		_, err = io.WriteString(e.w, "]\n")
+	}
+	return err
+}
+
+// appendRowJSON append object of row, columns are in order of fields, numbers are unquoted, binary strings are in base64.
+func appendRowJSON(buf []byte, fields []*mysql.Field, row *mysql.Row) []byte {
+	buf = append(buf, '{')
+	for i, field := range fields {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		name, _ := json.Marshal(string(field.Name))
+		buf = append(buf, name...)
+		buf = append(buf, ':')
+		switch {
+		case row.GetValue(i) == nil:
+			buf = append(buf, "null"...)
+		case isNumericField(field):
+			buf = append(buf, row.GetRawValue(i)...)
+		default:
+			value, _ := json.Marshal(exportValue(field, row, i))
+			buf = append(buf, value...)
+		}
+	}
+	return append(buf, '}')
+}
+
+func isNumericField(field *mysql.Field) bool {
+	switch field.ColumnType {
+	case mysql.MYSQL_TYPE_TINY, mysql.MYSQL_TYPE_SHORT, mysql.MYSQL_TYPE_YEAR, mysql.MYSQL_TYPE_INT24,
+		mysql.MYSQL_TYPE_LONG, mysql.MYSQL_TYPE_LONGLONG, mysql.MYSQL_TYPE_DECIMAL, mysql.MYSQL_TYPE_NEWDECIMAL:
+		return true
+	}
+	return false
+}
+
+// handleExport stream rows of logical table from all nodes, GET /export?schema=db1&table=t1[&format=csv][&chunk=1000].
+// Rows are read in chunks and flushed with chunked encoding, next chunk is read after client receives,
+// so result set isn't buffered. Error after the first chunk truncates response, as status is sent.
+// Binary string columns are in base64, and listed by X-Base64-Columns header.
+func (p *Server) handleExport(w http.ResponseWriter, r *http.Request) {
+	user, password, ok := r.BasicAuth()
+	// admin user is required, same as admin port.
+	if !ok || len(p.cfg.AdminUser) == 0 || user != p.cfg.AdminUser || subtle.ConstantTimeCompare([]byte(password), []byte(p.cfg.AdminPassword)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Basic realm="saashard"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	format, ok := negotiateExportFormat(r)
+	if !ok {
+		http.Error(w, "supported formats are csv, ndjson and json", http.StatusNotAcceptable)
+		return
+	}
+	query := r.URL.Query()
+	schemaName, table := query.Get("schema"), strings.ToLower(query.Get("table"))
+	if len(schemaName) == 0 || len(table) == 0 {
+		http.Error(w, "schema and table are required", http.StatusBadRequest)
+		return
+	}
+	chunkSize, _ := strconv.Atoi(query.Get("chunk"))
+	nodeNames, physicalNames, err := p.tableNodes(schemaName, table)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", exportContentTypes[format])
+	flusher, _ := w.(http.Flusher)
+	out := newExportWriter(format, w)
+	written := false
+	writeRows := func(rs *mysql.Resultset) error {
+		if !written {
+			var names []string
+			for _, field := range rs.Fields {
+				if isBinaryField(field) {
+					names = append(names, string(field.Name))
+				}
+			}
+			if len(names) > 0 {
+				w.Header().Set(exportBase64Header, strings.Join(names, ","))
+			}
+		}
+		if err := out.writeRows(rs); err != nil {
+			return err
+		}
+		written = true
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return r.Context().Err()
+	}
+	for _, nodeName := range nodeNames {
+		if err = p.exportNode(nodeName, physicalNames, chunkSize, writeRows); err != nil {
+			simplelog.Error("%s %s %s schema=%s,table=%s,node=%s", "proxy", "handleExport", err.Error(), schemaName, table, nodeName)
+			if !written {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+	}
+	out.close()
+}
+
+// exportNode read rows of physical tables at master of node, by conn from pool.
+func (p *Server) exportNode(nodeName string, physicalNames []string, chunkSize int, rows func(rs *mysql.Resultset) error) error {
+	node := p.nodes[nodeName]
+	if node == nil {
+		return errors.ErrNoDataNode
+	}
+	conn, err := getMasterConn(node)
+	if err != nil {
+		return err
+	}
+	defer conn.ReturnConnection()
+	return scanNode(conn, physicalNames, chunkSize, nil, rows)
+}
+
+// listenExport open export_port, rows of tables are exported by /export, authenticated by admin user.
+// It's https by tls_cert and tls_key, or http at localhost only, since password of basic auth isn't encrypted.
+func (p *Server) listenExport() error {
+	if p.cfg.ExportPort <= 0 {
+		return nil
+	}
+	ip := p.bindIP.String()
+	if p.certs == nil {
+		ip = "127.0.0.1"
+	}
+	addr := ip + ":" + strconv.Itoa(p.cfg.ExportPort)
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	if p.certs != nil {
+		l = tls.NewListener(l, p.certs.TLSConfig())
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/export", p.handleExport)
+	p.exportServer = &http.Server{Handler: mux}
+	p.exportListener = l
+
+	simplelog.Info("%s %s %s address=%s,tls=%v", "server/proxy", "NewServer", "Export endpoint running", addr, p.certs != nil)
+	return nil
+}
+
+func (p *Server) serveExport() {
+	if p.exportServer == nil {
+		return
+	}
+	if err := p.exportServer.Serve(p.exportListener); err != nil && err != http.ErrServerClosed {
+		simplelog.Error("%s %s %s", "server/proxy", "serveExport", err.Error())
+	}
+}
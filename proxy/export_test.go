@@ -0,0 +1,60 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/berkaroad/saashard/net/mysql"
+)
+
+func TestExportBinaryColumns(t *testing.T) {
+	data := newTestField("data", mysql.MYSQL_TYPE_BLOB)
+	data.Charset = uint16(mysql.CollationNames["binary"])
+	text := newTestField("text", mysql.MYSQL_TYPE_BLOB)
+	fields := []*mysql.Field{newTestField("id", mysql.MYSQL_TYPE_LONGLONG), text, data}
+	rs := &mysql.Resultset{Fields: fields, Rows: []*mysql.Row{
+		newNodeRow(t, fields, "1", "a\"b", "\xff\x00a"),
+		newNodeRow(t, fields, "2", "NULL", "NULL"),
+	}}
+
+	// bytes of binary string aren't replaced by U+FFFD, they're in base64.
+	var buf bytes.Buffer
+	out := newExportWriter(exportNDJSON, &buf)
+	if err := out.writeRows(rs); err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\"id\":1,\"text\":\"a\\\"b\",\"data\":\"/wBh\"}\n{\"id\":2,\"text\":null,\"data\":null}\n"; buf.String() != want {
+		t.Errorf("ndjson = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	out = newExportWriter(exportCSV, &buf)
+	if err := out.writeRows(rs); err != nil {
+		t.Fatal(err)
+	}
+	if want := "id,text,data\n1,\"a\"\"b\",/wBh\n2,,\n"; buf.String() != want {
+		t.Errorf("csv = %q, want %q", buf.String(), want)
+	}
+}
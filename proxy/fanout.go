@@ -0,0 +1,385 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/berkaroad/saashard/backend"
+	mysqlBackend "github.com/berkaroad/saashard/backend/mysql"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/route"
+	"github.com/berkaroad/saashard/sqlparser"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// partial_result_policy, when a node fails during fan-out select.
+const (
+	partialResultFail    = "fail"    // fail the whole select, it's default.
+	partialResultPartial = "partial" // skip failed nodes, with warning and saashard_partial_result.
+	partialResultReplica = "replica" // retry failed node on a slave.
+)
+
+func isPartialResultPolicy(policy string) bool {
+	switch strings.ToLower(policy) {
+	case "", partialResultFail, partialResultPartial, partialResultReplica:
+		return true
+	}
+	return false
+}
+
+// fanoutCount is count of configured nodes of data nodes, that max_fanout limits.
+// Physical databases of a node by databases_per_node count once.
+func (c *ClientConn) fanoutCount(dataNodes []string) int {
+	if schema := c.schemas[c.db]; schema != nil {
+		return schema.ConfiguredNodeCount(dataNodes)
+	}
+	return len(dataNodes)
+}
+
+// fanoutSelect execute select on nodes, and concatenate rows of them, or merge them by merge of plan.
+// Failed node is handled by partial_result_policy, error of mysql server always fails the whole select.
+func (c *ClientConn) fanoutSelect(ctx context.Context, statement sqlparser.Statement, dataNodes []string,
+	isSlave bool) (result *mysql.Result, backendConnAddrs []string, err error) {
+	policy := strings.ToLower(c.proxy.cfg.PartialResultPolicy)
+	sql := c.backendSQL(statement)
+
+	var failed []string
+	for _, dataNode := range dataNodes {
+		node := c.proxy.nodes[dataNode]
+		// If in transaction, must exec in the same node.
+		if c.isInTransaction() && node != c.nodeInTrans {
+			return nil, nil, errors.ErrTransInMulti
+		}
+
+		nodeResult, addr, nodeErr := c.queryNode(ctx, node, isSlave, sql)
+		if nodeErr != nil && !isServerError(nodeErr) && ctx.Err() == nil && policy == partialResultReplica {
+			simplelog.Warn("%s %s %s node=%s,error=%s", "proxy", "fanoutSelect", "Retry on slave", dataNode, nodeErr.Error())
+			if retryResult, retryAddr, retryErr := c.queryReplica(ctx, node, sql); retryErr == nil {
+				nodeResult, addr, nodeErr = retryResult, retryAddr, nil
+			}
+		}
+		if len(addr) > 0 {
+			backendConnAddrs = append(backendConnAddrs, addr)
+		}
+		if nodeErr != nil {
+			if isServerError(nodeErr) || ctx.Err() != nil || policy != partialResultPartial {
+				return nil, backendConnAddrs, nodeErr
+			}
+			simplelog.Warn("%s %s %s node=%s,error=%s", "proxy", "fanoutSelect", "Partial result", dataNode, nodeErr.Error())
+			failed = append(failed, dataNode)
+			c.warnings = append(c.warnings, fmt.Sprintf("Node %s is skipped by partial_result_policy: %s", dataNode, nodeErr.Error()))
+			err = nodeErr
+			continue
+		}
+		if nodeResult.Resultset == nil {
+			continue
+		}
+		if result == nil {
+			result = nodeResult
+		} else if err = appendResult(result, nodeResult); err != nil {
+			return nil, backendConnAddrs, err
+		}
+	}
+	c.partialResult = len(failed) > 0
+	if result == nil {
+		if err == nil {
+			err = errors.ErrCmdUnsupport
+		}
+		return nil, backendConnAddrs, err
+	}
+	if c.merge != nil {
+		if err = mergeResult(result, c.merge, c, c.groupConcatMaxLen()); err != nil {
+			return nil, backendConnAddrs, err
+		}
+	}
+	result.Warnings = uint16(len(failed))
+	return result, backendConnAddrs, nil
+}
+
+// handleShowWarnings answer SHOW WARNINGS by warnings of proxy, such as skipped nodes of partial result,
+// since warnings of backend conns don't have them.
+func (c *ClientConn) handleShowWarnings(statement *sqlparser.ShowWarnings) error {
+	warnings := c.warnings
+	if limit := statement.Limit; limit != nil {
+		offset, count := 0, len(warnings)
+		if v, ok := limit.Offset.(sqlparser.NumVal); ok {
+			offset, _ = strconv.Atoi(string(v))
+		}
+		if v, ok := limit.Rowcount.(sqlparser.NumVal); ok {
+			count, _ = strconv.Atoi(string(v))
+		}
+		if offset > len(warnings) {
+			offset = len(warnings)
+		}
+		if count < 0 || offset+count > len(warnings) {
+			count = len(warnings) - offset
+		}
+		warnings = warnings[offset : offset+count]
+	}
+
+	result := new(mysql.Result)
+	result.Status = c.status
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{
+		newRouteField("Level"),
+		{Name: []byte("Code"),
+			Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+			ColumnLength: 4,
+			ColumnType:   mysql.MYSQL_TYPE_LONG,
+			Flags:        mysql.NOT_NULL_FLAG | mysql.UNSIGNED_FLAG | mysql.BINARY_FLAG},
+		newRouteField("Message"),
+	}
+	result.Rows = make([]*mysql.Row, 0, len(warnings))
+	for _, warning := range warnings {
+		row := mysql.NewTextRow(result.Resultset.Fields)
+		row.AppendStringValue("Warning")
+		row.AppendUIntValue(uint64(mysql.ER_UNKNOWN_ERROR))
+		row.AppendStringValue(warning)
+		result.Rows = append(result.Rows, row)
+	}
+	return c.pkg.WriteResultSet(c.capability, c.status, result)
+}
+
+// queryNode execute sql in backend conn of session, from analytics replica, slave or master.
+func (c *ClientConn) queryNode(ctx context.Context, node *backend.DataNode, isSlave bool, sql string) (*mysql.Result, string, error) {
+	conn, err := c.getBackendConn(node, isSlave)
+	if err != nil {
+		return nil, "", err
+	}
+	var mysqlConn = conn.(*mysqlBackend.Conn)
+	mysqlConn.UseDB(node.Database)
+	if err = c.prepareBackendConn(mysqlConn); err != nil {
+		return nil, conn.GetAddr(), err
+	}
+	if err = c.injectFault(node.Name, mysqlConn); err != nil {
+		return nil, conn.GetAddr(), err
+	}
+	result, err := c.queryTraced(ctx, node, mysqlConn, sql)
+	return result, conn.GetAddr(), err
+}
+
+// queryReplica execute sql in a slave of node, by conn from pool rather than session.
+func (c *ClientConn) queryReplica(ctx context.Context, node *backend.DataNode, sql string) (*mysql.Result, string, error) {
+	dbHost, err := node.DataHost.GetSlave()
+	if err != nil {
+		return nil, "", err
+	}
+	conn, err := dbHost.GetConnection(node.Database)
+	if err != nil {
+		return nil, dbHost.Addr, err
+	}
+	defer conn.ReturnConnection()
+	var mysqlConn = conn.(*mysqlBackend.Conn)
+	if err = mysqlConn.UseDB(node.Database); err != nil {
+		return nil, conn.GetAddr(), err
+	}
+	if err = c.prepareBackendConn(mysqlConn); err != nil {
+		return nil, conn.GetAddr(), err
+	}
+	result, err := c.queryTraced(ctx, node, mysqlConn, sql)
+	return result, conn.GetAddr(), err
+}
+
+// appendResult append rows of next to result, spilled rows are kept after rows.
+func appendResult(result, next *mysql.Result) error {
+	if result.Spilled == nil {
+		result.Rows = append(result.Rows, next.Rows...)
+		result.Spilled = next.Spilled
+		return nil
+	}
+	for _, row := range next.Rows {
+		if err := result.Spilled.Append(row.Data); err != nil {
+			return err
+		}
+	}
+	if next.Spilled != nil {
+		defer next.Spilled.Close()
+		return next.Spilled.Each(result.Spilled.Append)
+	}
+	return nil
+}
+
+// errMergeLimit stop reading merged rows, after limit is reached.
+var errMergeLimit = errors.New("limit of merged rows is reached")
+
+// groupConcatMaxLen get group_concat_max_len set by client, or default of mysql.
+func (c *ClientConn) groupConcatMaxLen() int {
+	if v, ok := c.sessionVariables["@@session.group_concat_max_len"]; ok {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return mysql.DefaultGroupConcatMaxLen
+}
+
+// mergeResult replace concatenated rows of nodes by merged rows, that are aggregated, sorted, then limited.
+// GROUP_CONCAT is truncated to groupConcatMaxLen.
+// Rows are accounted by tracker, and spilled to disk when memory budget is exceeded.
+func mergeResult(result *mysql.Result, merge *route.Merge, tracker mysql.MemoryTracker, groupConcatMaxLen int) error {
+	each := func(fn func(row *mysql.Row) error) error {
+		return eachRow(result, fn)
+	}
+	fields := result.Fields
+	if merge.Grouped {
+		aggregates := make([]mysql.Aggregate, len(merge.Aggregates))
+		for i, aggregate := range merge.Aggregates {
+			if aggregate.CountDistinct {
+				// column of node is distinct values, it's count after merged.
+				fields = append([]*mysql.Field{}, fields...)
+				fields[aggregate.Column] = newCountField(fields[aggregate.Column].Name)
+				aggregates[i] = mysql.Aggregate{Column: aggregate.Column, CountDistinct: true}
+				continue
+			}
+			combiner, ok := mysql.GetFuncCombiner(aggregate.Func, groupConcatMaxLen)
+			if !ok {
+				return errors.ErrCmdUnsupport
+			}
+			aggregates[i] = mysql.Aggregate{Column: aggregate.Column, Combiner: combiner,
+				Avg: aggregate.Avg, CountColumn: aggregate.CountColumn}
+			if aggregate.Avg {
+				// column of node is sum, it's avg after merged.
+				fields = append([]*mysql.Field{}, fields...)
+				fields[aggregate.Column] = newAvgField(fields[aggregate.Column])
+			}
+		}
+		aggregator := mysql.NewRowAggregator(fields, merge.GroupBy, aggregates, tracker)
+		defer aggregator.Close()
+		if err := each(aggregator.Append); err != nil {
+			return err
+		}
+		each = aggregator.Each
+		if merge.Columns > 0 && merge.Columns < len(fields) {
+			// hidden columns, such as count of avg, aren't in merged rows.
+			fields = fields[:merge.Columns]
+		}
+	}
+	if len(merge.OrderBy) > 0 {
+		sorter := mysql.NewRowSorter(fields, merge.OrderBy, tracker)
+		defer sorter.Close()
+		if err := each(sorter.Append); err != nil {
+			return err
+		}
+		each = sorter.Each
+	}
+
+	merged := &mysql.Result{Resultset: &mysql.Resultset{Fields: fields}}
+	offset, count := merge.Offset, merge.Count
+	err := each(func(row *mysql.Row) error {
+		if offset > 0 {
+			offset--
+			return nil
+		} else if count == 0 {
+			return errMergeLimit
+		}
+		count--
+		return appendMergedRow(merged, row, tracker)
+	})
+	if err != nil && err != errMergeLimit {
+		if merged.Spilled != nil {
+			merged.Spilled.Close()
+		}
+		return err
+	}
+	if result.Spilled != nil {
+		result.Spilled.Close()
+	}
+	result.Fields = fields
+	result.Rows, result.Values, result.Spilled = merged.Rows, nil, merged.Spilled
+	return nil
+}
+
+// newCountField is field of COUNT(DISTINCT x) merged in proxy.
+func newCountField(name []byte) *mysql.Field {
+	return &mysql.Field{Name: name,
+		Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+		ColumnLength: 21,
+		ColumnType:   mysql.MYSQL_TYPE_LONGLONG,
+		Flags:        mysql.NOT_NULL_FLAG | mysql.BINARY_FLAG}
+}
+
+// newAvgField is field of AVG merged in proxy from SUM of nodes, decimal has 4 more decimals like mysql.
+func newAvgField(sum *mysql.Field) *mysql.Field {
+	field := *sum
+	field.Data = nil
+	if field.ColumnType == mysql.MYSQL_TYPE_NEWDECIMAL || field.ColumnType == mysql.MYSQL_TYPE_DECIMAL {
+		if field.Decimals += 4; field.Decimals > 30 {
+			field.Decimals = 30
+		}
+		field.ColumnLength += 4
+	}
+	return &field
+}
+
+// eachRow read rows of result, and spilled rows after them.
+func eachRow(result *mysql.Result, fn func(row *mysql.Row) error) error {
+	for _, row := range result.Rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	if result.Spilled == nil {
+		return nil
+	}
+	return result.Spilled.Each(func(data []byte) error {
+		row, err := mysql.RowData(data).Parse(false, result.Fields)
+		if err != nil {
+			return err
+		}
+		row.Data = data
+		return fn(row)
+	})
+}
+
+// appendMergedRow append row to result, it's spilled if memory budget is exceeded.
+func appendMergedRow(result *mysql.Result, row *mysql.Row, tracker mysql.MemoryTracker) error {
+	data := row.Dump()
+	if result.Spilled == nil {
+		if tracker == nil {
+			result.Rows = append(result.Rows, row)
+			return nil
+		}
+		err := tracker.Consume(int64(len(data)))
+		if err == nil {
+			result.Rows = append(result.Rows, row)
+			return nil
+		}
+		dir := tracker.SpillDir()
+		if len(dir) == 0 {
+			return err
+		}
+		if result.Spilled, err = mysql.NewSpillFile(dir, tracker.SpillMaxSize()); err != nil {
+			return err
+		}
+	}
+	return result.Spilled.Append(data)
+}
+
+// isServerError is error returned by mysql server, such as unknown column, it isn't failure of node.
+func isServerError(err error) bool {
+	_, ok := err.(*errors.SqlError)
+	return ok
+}
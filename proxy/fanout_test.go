@@ -0,0 +1,397 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/berkaroad/saashard/backend"
+	"github.com/berkaroad/saashard/backend/mock"
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/route"
+	"github.com/berkaroad/saashard/sqlparser"
+	"github.com/berkaroad/saashard/statistic"
+)
+
+// testTracker is memory budget of budget bytes, rows are spilled to dir if it isn't empty.
+type testTracker struct {
+	budget, used int64
+	dir          string
+}
+
+func (t *testTracker) Consume(n int64) error {
+	t.used += n
+	if t.used > t.budget {
+		return errors.ErrExceedMemory
+	}
+	return nil
+}
+
+func (t *testTracker) SpillDir() string    { return t.dir }
+func (t *testTracker) SpillMaxSize() int64 { return 0 }
+
+func newTestField(name string, columnType byte) *mysql.Field {
+	return &mysql.Field{Name: []byte(name), ColumnType: columnType, Charset: uint16(mysql.DEFAULT_COLLATION_ID)}
+}
+
+// newMockNode is node of database db at master of a mock server, server is closed when test is done.
+func newMockNode(t *testing.T, name string) (*backend.DataNode, *mock.Server) {
+	s, err := mock.NewServer("127.0.0.1:0", "root", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	host := backend.NewDataHost(config.HostConfig{Name: name, MaxConnNum: 4, User: "root", Password: "secret", Master: s.Addr()})
+	return backend.NewDataNode(config.NodeConfig{Name: name, Host: name, Database: "db"}, host), s
+}
+
+// newNodeResult is result of a node, values of row are separated by ','.
+func newNodeResult(t *testing.T, fields []*mysql.Field, rows ...string) *mysql.Result {
+	result := &mysql.Result{Resultset: &mysql.Resultset{Fields: fields}}
+	for _, values := range rows {
+		result.Rows = append(result.Rows, newNodeRow(t, fields, strings.Split(values, ",")...))
+	}
+	return result
+}
+
+// newNodeRow parse text row of values, "NULL" is null.
+func newNodeRow(t *testing.T, fields []*mysql.Field, values ...string) *mysql.Row {
+	row := mysql.NewTextRow(fields)
+	for _, v := range values {
+		if v == "NULL" {
+			row.AppendNullValue()
+		} else {
+			row.AppendStringValue(v)
+		}
+	}
+	parsed, err := mysql.RowData(row.Dump()).Parse(false, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+// mergeNodeResults concatenate results of nodes, then merge them, return merged rows as strings.
+func mergeNodeResults(t *testing.T, merge *route.Merge, tracker mysql.MemoryTracker, groupConcatMaxLen int,
+	results ...*mysql.Result) []string {
+	result := results[0]
+	for _, next := range results[1:] {
+		if err := appendResult(result, next); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mergeResult(result, merge, tracker, groupConcatMaxLen); err != nil {
+		t.Fatal(err)
+	}
+	return resultRows(t, result)
+}
+
+// resultRows return rows of result as strings, values are separated by ','.
+func resultRows(t *testing.T, result *mysql.Result) []string {
+	var rows []string
+	err := eachRow(result, func(row *mysql.Row) error {
+		values := make([]string, len(result.Fields))
+		for i := range values {
+			if row.GetValue(i) == nil {
+				values[i] = "NULL"
+			} else {
+				values[i] = string(row.GetRawValue(i))
+			}
+		}
+		rows = append(rows, strings.Join(values, ","))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Spilled != nil {
+		result.Spilled.Close()
+	}
+	return rows
+}
+
+func TestMergeResultSorted(t *testing.T) {
+	fields := []*mysql.Field{newTestField("id", mysql.MYSQL_TYPE_LONGLONG), newTestField("name", mysql.MYSQL_TYPE_VAR_STRING)}
+	// order by name, id desc limit 2, 6, rows are spilled by a tiny budget.
+	merge := &route.Merge{OrderBy: []mysql.SortKey{{Column: 1}, {Column: 0, Desc: true}}, Offset: 2, Count: 6}
+	tracker := &testTracker{budget: 24, dir: t.TempDir()}
+	got := mergeNodeResults(t, merge, tracker, 0,
+		newNodeResult(t, fields, "9,b", "7,a", "5,c", "3,b", "1,a"),
+		newNodeResult(t, fields, "10,a", "8,c", "6,b", "4,a", "2,c", "NULL,b"))
+	want := "4,a 1,a 9,b 6,b 3,b NULL,b"
+	if s := strings.Join(got, " "); s != want {
+		t.Errorf("merged rows = %s, want %s", s, want)
+	}
+
+	// without spill dir, the select is aborted when memory budget is exceeded.
+	tracker = &testTracker{budget: 24}
+	result := newNodeResult(t, fields, "9,b", "7,a", "5,c", "3,b", "1,a", "10,a", "8,c")
+	if err := mergeResult(result, merge, tracker, 0); err != errors.ErrExceedMemory {
+		t.Errorf("mergeResult() = %v, want %v", err, errors.ErrExceedMemory)
+	}
+}
+
+// selectFuncs get function of select expressions, nil if it isn't function.
+func selectFuncs(t *testing.T, sql string) []*sqlparser.FuncExpr {
+	statement, err := sqlparser.Parse(sql)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var funcs []*sqlparser.FuncExpr
+	for _, selectExpr := range statement.(*sqlparser.Select).SelectExprs {
+		funcExpr, _ := selectExpr.(*sqlparser.NonStarExpr).Expr.(*sqlparser.FuncExpr)
+		funcs = append(funcs, funcExpr)
+	}
+	return funcs
+}
+
+func TestMergeResultGrouped(t *testing.T) {
+	fields := []*mysql.Field{newTestField("b", mysql.MYSQL_TYPE_VAR_STRING), newTestField("count(*)", mysql.MYSQL_TYPE_LONGLONG),
+		newTestField("sum(c)", mysql.MYSQL_TYPE_NEWDECIMAL), newTestField("max(d)", mysql.MYSQL_TYPE_DATETIME)}
+	// select b, count(*), sum(c), max(d) from t group by b order by 2 desc limit 3
+	funcs := selectFuncs(t, "select b, count(*), sum(c), max(d) from t")
+	merge := &route.Merge{Grouped: true, GroupBy: []int{0},
+		Aggregates: []route.MergeAggregate{{Column: 1, Func: funcs[1]}, {Column: 2, Func: funcs[2]}, {Column: 3, Func: funcs[3]}},
+		OrderBy:    []mysql.SortKey{{Column: 1, Desc: true}}, Count: 3}
+	tracker := &testTracker{budget: 1 << 20}
+	got := mergeNodeResults(t, merge, tracker, 0,
+		newNodeResult(t, fields, "x,9,0.1,2016-01-02 00:00:00", "y,2,NULL,NULL", "z,1,1.25,2016-01-01 00:00:00.5"),
+		newNodeResult(t, fields, "y,8,0.2,2016-01-01 00:00:00", "z,10,2.5,2016-01-01 00:00:00.25", "w,1,3,NULL"))
+	want := "z,11,3.75,2016-01-01 00:00:00.5 y,10,0.2,2016-01-01 00:00:00 x,9,0.1,2016-01-02 00:00:00"
+	if s := strings.Join(got, " "); s != want {
+		t.Errorf("merged rows = %s, want %s", s, want)
+	}
+}
+
+func TestMergeResultGroupConcat(t *testing.T) {
+	cases := []struct {
+		expr   string
+		maxLen int
+		nodes  [2]string
+		want   string // empty if couldn't be merged
+	}{
+		{"group_concat(a)", 0, [2]string{"x,y", "z"}, "x,y,z"},
+		{"group_concat(a separator '|')", 0, [2]string{"x|y", "z"}, "x|y|z"},
+		{"group_concat(a separator '')", 0, [2]string{"xy", "z"}, "xyz"},
+		{"group_concat(a order by a desc separator ';')", 0, [2]string{"9;3;1", "10;4"}, "10;9;4;3;1"},
+		{"group_concat(a order by a)", 0, [2]string{"b,d", "a,c"}, "a,b,c,d"},
+		{"group_concat(distinct a order by a)", 0, [2]string{"a,b,c", "b,d"}, "a,b,c,d"},
+		{"group_concat(distinct a)", 0, [2]string{"b,a", "c,a"}, "b,a,c"},
+		{"group_concat(a)", 7, [2]string{"abc,def", "ghi"}, "abc,def"},
+		{"group_concat(distinct a order by a separator '-')", 5, [2]string{"c-d", "a-b"}, "a-b-c"},
+		{"group_concat(a order by b)", 0, [2]string{"x", "y"}, ""},
+	}
+	fields := []*mysql.Field{newTestField("g", mysql.MYSQL_TYPE_VAR_STRING), newTestField("c", mysql.MYSQL_TYPE_VAR_STRING)}
+	for _, c := range cases {
+		funcs := selectFuncs(t, "select g, "+c.expr+" from t")
+		combiner, ok := mysql.GetFuncCombiner(funcs[1], c.maxLen)
+		if c.want == "" {
+			if ok {
+				t.Errorf("GetFuncCombiner(%s) = %v, want not combinable", c.expr, combiner)
+			}
+			continue
+		}
+		// select g, group_concat(...) from t group by g
+		merge := &route.Merge{Grouped: true, GroupBy: []int{0}, Aggregates: []route.MergeAggregate{{Column: 1, Func: funcs[1]}}, Count: -1}
+		nodes := make([]*mysql.Result, 2)
+		for i, fragment := range c.nodes {
+			nodes[i] = newNodeResult(t, fields)
+			nodes[i].Rows = append(nodes[i].Rows, newNodeRow(t, fields, "g", fragment))
+		}
+		got := mergeNodeResults(t, merge, &testTracker{budget: 1 << 20}, c.maxLen, nodes...)
+		if want := "g," + c.want; len(got) != 1 || got[0] != want {
+			t.Errorf("merged %s = %v, want %s", c.expr, got, want)
+		}
+	}
+}
+
+func TestMergeResultCountDistinct(t *testing.T) {
+	fields := []*mysql.Field{newTestField("g", mysql.MYSQL_TYPE_VAR_STRING), newTestField("count(distinct a)", mysql.MYSQL_TYPE_NEWDECIMAL)}
+	funcs := selectFuncs(t, "select g, count(distinct a) from t")
+	aggregates := []route.MergeAggregate{{Column: 1, Func: funcs[1], CountDistinct: true}}
+
+	// select g, count(distinct a) from t group by g, distinct values of nodes overlap.
+	merge := &route.Merge{Grouped: true, GroupBy: []int{0}, Aggregates: aggregates, OrderBy: []mysql.SortKey{{Column: 0}}, Count: -1}
+	got := mergeNodeResults(t, merge, &testTracker{budget: 1 << 20}, 0,
+		newNodeResult(t, fields, "x,1.5", "x,2", "y,1", "z,NULL"),
+		newNodeResult(t, fields, "x,2", "x,3", "x,1.5", "y,1", "z,NULL", "w,7"))
+	if s, want := strings.Join(got, " "), "w,1 x,3 y,1 z,0"; s != want {
+		t.Errorf("merged rows = %s, want %s", s, want)
+	}
+	if result := newNodeResult(t, fields); mergeResult(result, merge, nil, 0) != nil || result.Fields[1].ColumnType != mysql.MYSQL_TYPE_LONGLONG {
+		t.Errorf("field of merged count = %d, want %d", result.Fields[1].ColumnType, mysql.MYSQL_TYPE_LONGLONG)
+	}
+
+	// select count(distinct a) from t, it's 0 if no rows.
+	merge = &route.Merge{Grouped: true, Aggregates: []route.MergeAggregate{{Column: 0, Func: funcs[1], CountDistinct: true}}, Count: -1}
+	fields = fields[1:]
+	got = mergeNodeResults(t, merge, &testTracker{budget: 1 << 20}, 0,
+		newNodeResult(t, fields, "1", "2", "3"), newNodeResult(t, fields, "3", "4", "2"), newNodeResult(t, fields))
+	if s, want := strings.Join(got, " "), "4"; s != want {
+		t.Errorf("merged rows = %s, want %s", s, want)
+	}
+	got = mergeNodeResults(t, merge, &testTracker{budget: 1 << 20}, 0, newNodeResult(t, fields), newNodeResult(t, fields))
+	if s, want := strings.Join(got, " "), "0"; s != want {
+		t.Errorf("merged rows = %s, want %s", s, want)
+	}
+
+	// distinct values are limited by memory budget.
+	result := newNodeResult(t, fields, "1", "2", "3", "4", "5", "6", "7", "8", "9", "10")
+	if err := mergeResult(result, merge, &testTracker{budget: 8}, 0); err != errors.ErrExceedMemory {
+		t.Errorf("mergeResult() = %v, want %v", err, errors.ErrExceedMemory)
+	}
+}
+
+func TestMergeResultAvg(t *testing.T) {
+	fields := []*mysql.Field{newTestField("g", mysql.MYSQL_TYPE_VAR_STRING), newTestField("avg(a)", mysql.MYSQL_TYPE_NEWDECIMAL),
+		newTestField("avg(b)", mysql.MYSQL_TYPE_DOUBLE), newTestField("count(a)", mysql.MYSQL_TYPE_LONGLONG),
+		newTestField("count(b)", mysql.MYSQL_TYPE_LONGLONG)}
+	funcs := selectFuncs(t, "select g, sum(a), sum(b), count(a), count(b) from t")
+	aggregates := []route.MergeAggregate{
+		{Column: 1, Func: funcs[1], Avg: true, CountColumn: 3}, {Column: 2, Func: funcs[2], Avg: true, CountColumn: 4},
+		{Column: 3, Func: funcs[3]}, {Column: 4, Func: funcs[4]},
+	}
+
+	// select g, avg(a), avg(b) from t group by g, sum and count of nodes are merged, then divided.
+	merge := &route.Merge{Grouped: true, GroupBy: []int{0}, Aggregates: aggregates, OrderBy: []mysql.SortKey{{Column: 1, Desc: true}},
+		Count: -1, Columns: 3}
+	result := newNodeResult(t, fields, "x,3,1.5,2,1", "y,1,NULL,1,0", "z,NULL,NULL,0,0")
+	got := mergeNodeResults(t, merge, &testTracker{budget: 1 << 20}, 0,
+		result, newNodeResult(t, fields, "x,2,3,2,2", "y,NULL,2,0,1"))
+	if s, want := strings.Join(got, " "), "x,1.2500,1.5 y,1.0000,2 z,NULL,NULL"; s != want {
+		t.Errorf("merged rows = %s, want %s", s, want)
+	}
+	if len(result.Fields) != 3 || result.Fields[1].Decimals != 4 || result.Fields[2].Decimals != 0 {
+		t.Errorf("fields of merged avg = %d, decimals %d and %d, want 3, 4 and 0",
+			len(result.Fields), result.Fields[1].Decimals, result.Fields[2].Decimals)
+	}
+}
+
+// newTestClientConn is session of proxy with nodes, without client connection.
+func newTestClientConn(nodes ...*backend.DataNode) *ClientConn {
+	p := &Server{cfg: new(config.Config), counter: new(statistic.Counter), nodes: make(map[string]*backend.DataNode)}
+	for _, node := range nodes {
+		p.nodes[node.Name] = node
+	}
+	c := &ClientConn{proxy: p, status: mysql.SERVER_STATUS_AUTOCOMMIT}
+	c.backendMasterConns = make(map[*backend.DataNode]backend.Connection)
+	c.backendSlaveConns = make(map[*backend.DataNode]backend.Connection)
+	c.backendOLAPConns = make(map[*backend.DataNode]backend.Connection)
+	return c
+}
+
+func TestFanoutSelectMock(t *testing.T) {
+	node1, s1 := newMockNode(t, "n1")
+	node2, s2 := newMockNode(t, "n2")
+	s1.Handle("^select id, name from t order by id", &mock.Response{Columns: []string{"id", "name"},
+		Rows: [][]interface{}{{1, "a"}, {3, "c"}}})
+	s2.Handle("^select id, name from t order by id", &mock.Response{Columns: []string{"id", "name"},
+		Rows: [][]interface{}{{2, "b"}, {4, nil}}})
+
+	c := newTestClientConn(node1, node2)
+	c.merge = &route.Merge{OrderBy: []mysql.SortKey{{Column: 0}}, Count: -1}
+	statement, err := sqlparser.Parse("select id, name from t order by id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, addrs, err := c.fanoutSelect(context.Background(), statement, []string{"n1", "n2"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addrs) != 2 || addrs[0] != s1.Addr() || addrs[1] != s2.Addr() {
+		t.Errorf("addrs = %v, expected %s and %s", addrs, s1.Addr(), s2.Addr())
+	}
+	expected := []string{"1,a", "2,b", "3,c", "4,NULL"}
+	if got := resultRows(t, result); strings.Join(got, ";") != strings.Join(expected, ";") {
+		t.Errorf("rows = %v, expected %v", got, expected)
+	}
+	for _, s := range []*mock.Server{s1, s2} {
+		if queries := s.Queries(); len(queries) == 0 || queries[len(queries)-1] != "select id, name from t order by id" {
+			t.Errorf("queries of %s = %v", s.Addr(), queries)
+		}
+	}
+}
+
+// Master of a node is unreachable, select is retried on its slave by partial_result_policy replica,
+// or the node is skipped with warning by partial, or the select fails by default.
+func TestFanoutSelectFailover(t *testing.T) {
+	node1, s1 := newMockNode(t, "n1")
+	master, err := mock.NewServer("127.0.0.1:0", "root", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer master.Close()
+	slave, err := mock.NewServer("127.0.0.1:0", "root", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer slave.Close()
+	host := backend.NewDataHost(config.HostConfig{Name: "n2", MaxConnNum: 4, User: "root", Password: "secret",
+		Master: master.Addr(), Slaves: []string{slave.Addr()}})
+	node2 := backend.NewDataNode(config.NodeConfig{Name: "n2", Host: "n2", Database: "db"}, host)
+
+	columns := []string{"id"}
+	s1.Handle("^select id from t", &mock.Response{Columns: columns, Rows: [][]interface{}{{1}}})
+	master.Handle("^select id from t", &mock.Response{Columns: columns, Rows: [][]interface{}{{2}}})
+	slave.Handle("^select id from t", &mock.Response{Columns: columns, Rows: [][]interface{}{{3}}})
+	master.SetRefuse(true)
+
+	statement, err := sqlparser.Parse("select id from t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		policy   string
+		expected string // rows separated by ';', empty if select fails.
+		warnings int
+	}{
+		{partialResultFail, "", 0},
+		{partialResultPartial, "1", 1},
+		{partialResultReplica, "1;3", 0},
+	}
+	for _, c := range cases {
+		conn := newTestClientConn(node1, node2)
+		conn.proxy.cfg.PartialResultPolicy = c.policy
+		result, _, err := conn.fanoutSelect(context.Background(), statement, []string{"n1", "n2"}, false)
+		if len(c.expected) == 0 {
+			if err == nil {
+				t.Errorf("%s: select of unreachable master doesn't fail", c.policy)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", c.policy, err)
+			continue
+		}
+		if got := strings.Join(resultRows(t, result), ";"); got != c.expected {
+			t.Errorf("%s: rows = %s, expected %s", c.policy, got, c.expected)
+		}
+		if len(conn.warnings) != c.warnings || conn.partialResult != (c.warnings > 0) {
+			t.Errorf("%s: warnings = %v, partial result = %v", c.policy, conn.warnings, conn.partialResult)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	mysqlBackend "github.com/berkaroad/saashard/backend/mysql"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+// faults is fault injection for resilience testing in staging, it's off by default.
+type faults struct {
+	dropConn    int             // percent of backend connections dropped before executing.
+	delays      map[string]int  // node -> delay of responses in milliseconds.
+	parseErrors map[string]bool // fingerprints that fail in parsing.
+}
+
+// getFaults return current faults, it shouldn't be modified.
+func (p *Server) getFaults() *faults {
+	if f := p.faults[atomic.LoadInt32(&p.faultsIndex)]; f != nil {
+		return f
+	}
+	return new(faults)
+}
+
+// updateFaults apply update to a copy of current faults, then swap.
+func (p *Server) updateFaults(update func(f *faults) error) error {
+	current := p.getFaults()
+	f := &faults{dropConn: current.dropConn, delays: current.delays, parseErrors: current.parseErrors}
+	if err := update(f); err != nil {
+		return err
+	}
+	next := 1 - atomic.LoadInt32(&p.faultsIndex)
+	p.faults[next] = f
+	atomic.StoreInt32(&p.faultsIndex, next)
+	return nil
+}
+
+// setFaultDropConn set percent of dropped backend connections, 0 is off.
+func (p *Server) setFaultDropConn(value string) error {
+	percent, err := parseNonNegativeInt(value)
+	if err != nil || percent > 100 {
+		return errors.ErrInvalidArgument
+	}
+	return p.updateFaults(func(f *faults) error {
+		f.dropConn = percent
+		return nil
+	})
+}
+
+// setFaultDelays apply entries such as 'node1:200, node2:50', empty is off.
+func (p *Server) setFaultDelays(value string) error {
+	delays := make(map[string]int)
+	for _, entry := range strings.Split(value, ",") {
+		if len(strings.TrimSpace(entry)) == 0 {
+			continue
+		}
+		pair := strings.SplitN(entry, ":", 2)
+		if len(pair) != 2 {
+			return errors.ErrInvalidArgument
+		}
+		node := strings.TrimSpace(pair[0])
+		if _, ok := p.nodes[node]; !ok {
+			return fmt.Errorf("node '%s' not found", node)
+		}
+		delay, err := parseNonNegativeInt(strings.TrimSpace(pair[1]))
+		if err != nil {
+			return err
+		}
+		if delay > 0 {
+			delays[node] = delay
+		}
+	}
+	return p.updateFaults(func(f *faults) error {
+		f.delays = delays
+		return nil
+	})
+}
+
+// setFaultParseErrors apply fingerprints or sqls separated by ';', empty is off.
+func (p *Server) setFaultParseErrors(value string) error {
+	parseErrors := make(map[string]bool)
+	for _, entry := range strings.Split(value, routeOverrideSeparator) {
+		if fingerprint := normalizeFingerprint(entry); len(fingerprint) > 0 {
+			parseErrors[fingerprint] = true
+		}
+	}
+	return p.updateFaults(func(f *faults) error {
+		f.parseErrors = parseErrors
+		return nil
+	})
+}
+
+func formatFaultDelays(delays map[string]int) string {
+	entries := make([]string, 0, len(delays))
+	for node, delay := range delays {
+		entries = append(entries, node+":"+strconv.Itoa(delay))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ", ")
+}
+
+func formatFaultParseErrors(parseErrors map[string]bool) string {
+	entries := make([]string, 0, len(parseErrors))
+	for fingerprint := range parseErrors {
+		entries = append(entries, fingerprint)
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, routeOverrideSeparator+" ")
+}
+
+// isFaultParseError is true if statement is forced to fail in parsing.
+func (p *Server) isFaultParseError(stmt sqlparser.Statement) bool {
+	parseErrors := p.getFaults().parseErrors
+	return len(parseErrors) > 0 && parseErrors[sqlparser.Fingerprint(stmt)]
+}
+
+// injectFault drop backend conn or delay before executing on node.
+func (c *ClientConn) injectFault(node string, mysqlConn *mysqlBackend.Conn) error {
+	f := c.proxy.getFaults()
+	if f.dropConn > 0 && rand.Intn(100) < f.dropConn {
+		mysqlConn.Close()
+		return errors.ErrBadConn
+	}
+	if delay := f.delays[node]; delay > 0 {
+		time.Sleep(time.Duration(delay) * time.Millisecond)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+// privileges of schema, according to read-only and options of proxy.
+const (
+	grantReadPrivileges  = "SELECT, SHOW VIEW"
+	grantWritePrivileges = "SELECT, INSERT, UPDATE, DELETE, CREATE, DROP, INDEX, ALTER, CREATE TEMPORARY TABLES, EXECUTE, CREATE VIEW, SHOW VIEW"
+)
+
+// isGrantsOfCurrentUser is true if 'SHOW GRANTS' is of current user, other users' grants are of backend.
+func (c *ClientConn) isGrantsOfCurrentUser(stmt *sqlparser.ShowGrants) bool {
+	return stmt.CurrentUser || stmt.For == nil || strings.EqualFold(string(stmt.For.User), c.user)
+}
+
+// handleShowGrants synthesize grants of current user by privileges of proxy, rather than user of backend,
+// such as schemas of user, read-only and allow_lock_tables, allow_grant.
+func (c *ClientConn) handleShowGrants() error {
+	account := fmt.Sprintf("`%s`@`%%`", c.user)
+	grants := []string{"GRANT USAGE ON *.* TO " + account}
+
+	names := make([]string, 0, len(c.schemas))
+	for name := range c.schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	privileges := grantWritePrivileges
+	if c.proxy.cfg.AllowLock {
+		privileges += ", LOCK TABLES"
+	}
+	if c.readOnly {
+		privileges = grantReadPrivileges
+	}
+	for _, name := range names {
+		grant := fmt.Sprintf("GRANT %s ON `%s`.* TO %s", privileges, name, account)
+		if c.proxy.cfg.AllowGrant && !c.readOnly {
+			grant += " WITH GRANT OPTION"
+		}
+		grants = append(grants, grant)
+	}
+
+	result := new(mysql.Result)
+	result.Status = c.status
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{newRouteField("Grants for " + c.user + "@%")}
+	result.Rows = make([]*mysql.Row, 0, len(grants))
+	for _, grant := range grants {
+		row := mysql.NewTextRow(result.Resultset.Fields)
+		row.AppendStringValue(grant)
+		result.Rows = append(result.Rows, row)
+	}
+	return c.pkg.WriteResultSet(c.capability, c.status, result)
+}
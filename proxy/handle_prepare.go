@@ -0,0 +1,72 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"fmt"
+
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+// resolvePrepared track named prepared statements of text protocol in proxy,
+// EXECUTE is replaced by prepared statement bound with its args, so that it's routed as usual.
+func (c *ClientConn) resolvePrepared(statement sqlparser.Statement) (sqlparser.Statement, error) {
+	switch v := statement.(type) {
+	case *sqlparser.Prepare:
+		query, ok := v.From.(sqlparser.StrVal)
+		if !ok {
+			return nil, errors.ErrPrepareFromVar
+		}
+		prepared, err := sqlparser.ParseWithSQLMode(string(query), c.parserSQLMode)
+		if err != nil {
+			return nil, mysql.NewError(mysql.ER_SYNTAX_ERROR, fmt.Sprintf("Syntax error or not supported for '%s': '%s'", query, err.Error()))
+		}
+		switch prepared.(type) {
+		case nil, *sqlparser.Prepare, *sqlparser.Execute, *sqlparser.Deallocate:
+			return nil, mysql.NewDefaultError(mysql.ER_UNSUPPORTED_PS)
+		}
+		c.prepared[string(v.Name)] = prepared
+
+	case *sqlparser.Execute:
+		prepared := c.prepared[string(v.Name)]
+		if prepared == nil {
+			return nil, mysql.NewError(mysql.ER_UNKNOWN_STMT_HANDLER,
+				fmt.Sprintf("Unknown prepared statement handler (%s) given to EXECUTE", v.Name))
+		}
+		bound, err := sqlparser.BindArgs(prepared, v.Using, c.parserSQLMode)
+		if err != nil {
+			return nil, mysql.NewDefaultError(mysql.ER_WRONG_ARGUMENTS, "EXECUTE")
+		}
+		return bound, nil
+
+	case *sqlparser.Deallocate:
+		if c.prepared[string(v.Name)] == nil {
+			return nil, mysql.NewError(mysql.ER_UNKNOWN_STMT_HANDLER,
+				fmt.Sprintf("Unknown prepared statement handler (%s) given to DEALLOCATE PREPARE", v.Name))
+		}
+		delete(c.prepared, string(v.Name))
+	}
+	return statement, nil
+}
@@ -23,9 +23,12 @@
 package proxy
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"runtime"
 	"strings"
+	"sync/atomic"
 
 	"github.com/berkaroad/saashard/backend"
 	mysqlBackend "github.com/berkaroad/saashard/backend/mysql"
@@ -58,14 +61,26 @@ func (c *ClientConn) handleQuery(sql string) (err error) {
 	var plan route.Plan
 	var sqls []string
 	if c.capability&mysql.CLIENT_MULTI_STATEMENTS > 0 {
-		sqls = sqlparser.SplitSQLStatement(sql)
+		sqls = sqlparser.SplitSQLScriptWithSQLMode(sql, c.parserSQLMode)
 	} else {
 		sqls = []string{strings.TrimSpace(sql)}
 	}
 
 	var stmts = make([]sqlparser.Statement, 0, len(sqls))
 	for _, sql := range sqls {
-		stmt, err := sqlparser.Parse(sql)
+		stmt, err := sqlparser.ParseWithSQLMode(sql, c.parserSQLMode)
+		c.proxy.shadowParse(sql, c.parserSQLMode)
+		if err == nil && stmt != nil && c.proxy.isFaultParseError(stmt) {
+			err = errors.ErrFaultInjected
+		}
+		if err == nil && stmt != nil {
+			if stmt, err = c.resolvePrepared(stmt); err != nil {
+				return err
+			}
+			if err = c.authorize(stmt); err != nil {
+				return err
+			}
+		}
 		if err != nil {
 			simplelog.Error("%s %s %s sql=%s", "proxy", "handleQuery", err.Error(), sql)
 			return mysql.NewError(mysql.ER_SYNTAX_ERROR, fmt.Sprintf("Syntax error or not supported for '%s': '%s'", sql, err.Error()))
@@ -75,18 +90,107 @@ func (c *ClientConn) handleQuery(sql string) (err error) {
 		}
 	}
 
+	if len(stmts) == 1 {
+		if _, ok := stmts[0].(*sqlparser.ShowLastRoute); ok {
+			return c.handleShowLastRoute()
+		}
+		if v, ok := stmts[0].(*sqlparser.ShowWarnings); ok && len(c.warnings) > 0 {
+			return c.handleShowWarnings(v)
+		}
+	}
+	c.lastRoute = nil
+	c.warnings = nil
+	if len(stmts) == 1 && isSetRuleVersion(stmts[0]) {
+		return c.handleSetRuleVersion(stmts[0].(*sqlparser.SetVariable))
+	}
+	if err = c.checkRuleVersion(sql); err != nil {
+		return err
+	}
+	if len(stmts) == 1 {
+		if v, ok := stmts[0].(*sqlparser.ShowGrants); ok && c.isGrantsOfCurrentUser(v) {
+			return c.handleShowGrants()
+		}
+	}
+
+	ctx, cancel := c.queryContext()
+	defer cancel()
+	if len(stmts) > 1 && hasDDLStatement(stmts) {
+		return c.handleScript(ctx, stmts)
+	}
 	if len(stmts) > 0 {
-		router := route.NewRouter(c.db, c.schemas, c.proxy.cfg.GetNodes(), c.connectionID, c.user, c.isInTransaction())
+		router := c.newRouter()
 		plan, err = router.BuildMergedPlan(stmts...)
 		if err != nil {
 			return
 		}
-		return plan.Execute(c.executePlanWithQueryCommand, c.c.RemoteAddr(), strings.ToLower(c.proxy.logSQL[c.proxy.logSQLIndex]) != "on", c.proxy.slowLogTime[c.proxy.slowLogTimeIndex], c.proxy.counter)
+		c.onAnalytics = plan.OnAnalytics()
+		c.merge = plan.GetMerge()
+		return plan.Execute(c.queryExecutor(ctx), c.c.RemoteAddr(), strings.ToLower(c.proxy.logSQL[c.proxy.logSQLIndex]) != "on", c.proxy.slowLogTime[c.proxy.slowLogTimeIndex], c.proxy.counter)
 	}
 	return c.pkg.WriteOK(c.capability, c.status, nil)
 }
 
-func (c *ClientConn) executePlanWithQueryCommand(statements []sqlparser.Statement, results []*mysql.Result,
+// handleScript execute statements one by one,
+// so that ddl in the script could be broadcast to its nodes.
+func (c *ClientConn) handleScript(ctx context.Context, stmts []sqlparser.Statement) (err error) {
+	defer func() {
+		c.moreResultsInBatch = false
+	}()
+
+	for i, stmt := range stmts {
+		var plan route.Plan
+		router := c.newRouter()
+		if plan, err = router.BuildNormalPlan(stmt); err != nil {
+			return
+		}
+		c.moreResultsInBatch = i < len(stmts)-1
+		c.onAnalytics = plan.OnAnalytics()
+		c.merge = plan.GetMerge()
+		if err = plan.Execute(c.queryExecutor(ctx), c.c.RemoteAddr(), strings.ToLower(c.proxy.logSQL[c.proxy.logSQLIndex]) != "on", c.proxy.slowLogTime[c.proxy.slowLogTimeIndex], c.proxy.counter); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (c *ClientConn) newRouter() *route.Router {
+	// Read-only connection prefers slave, even in transaction.
+	router := route.NewRouter(c.db, c.schemas, c.proxy.cfg.GetNodes(), c.connectionID, c.user, c.isInTransaction() && !c.readOnly)
+	router.Inspector = c
+	router.ReadOnly = c.readOnly
+	router.Overrides = c.proxy.getRouteOverrides()
+	router.AnalyticsCost = c.proxy.cfg.AnalyticsCost
+	router.MaxQueryCost = int64(c.proxy.cfg.MaxQueryCost)
+	router.CostPolicy = c.proxy.cfg.QueryCostPolicy
+	router.Stats = c.proxy
+	router.AllowGrant = c.proxy.cfg.AllowGrant
+	router.AllowLock = c.proxy.cfg.AllowLock
+	router.ReplicaLag = c.proxy.replicaLag
+	router.PartialResult = c.partialResult
+	return router
+}
+
+func hasDDLStatement(stmts []sqlparser.Statement) bool {
+	for _, stmt := range stmts {
+		if _, ok := stmt.(sqlparser.DDLStatement); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// queryExecutor execute plan with query command in ctx.
+func (c *ClientConn) queryExecutor(ctx context.Context) func(statements []sqlparser.Statement, results []*mysql.Result,
+	dataNodes []string, isSlave bool,
+	queryDataNodes map[sqlparser.Statement][]string) (backendConnAddrs []string, err error) {
+	return func(statements []sqlparser.Statement, results []*mysql.Result,
+		dataNodes []string, isSlave bool,
+		queryDataNodes map[sqlparser.Statement][]string) (backendConnAddrs []string, err error) {
+		return c.executePlanWithQueryCommand(ctx, statements, results, dataNodes, isSlave, queryDataNodes)
+	}
+}
+
+func (c *ClientConn) executePlanWithQueryCommand(ctx context.Context, statements []sqlparser.Statement, results []*mysql.Result,
 	dataNodes []string, isSlave bool,
 	queryDataNodes map[sqlparser.Statement][]string) (backendConnAddrs []string, err error) {
 
@@ -95,6 +199,13 @@ func (c *ClientConn) executePlanWithQueryCommand(statements []sqlparser.Statemen
 	if len(dataNodes) == 1 {
 		resultCount := len(statements)
 		node := c.proxy.nodes[dataNodes[0]]
+		// Savepoints and UNLOCK TABLES run at node of transaction or locked tables.
+		switch statements[0].(type) {
+		case sqlparser.TransactionStatement, *sqlparser.UnlockTables:
+			if c.isInTransaction() && c.nodeInTrans != nil {
+				node = c.nodeInTrans
+			}
+		}
 		// If in transaction, must exec in the same node.
 		if c.isInTransaction() && node != c.nodeInTrans {
 			err = errors.ErrTransInMulti
@@ -102,20 +213,20 @@ func (c *ClientConn) executePlanWithQueryCommand(statements []sqlparser.Statemen
 		}
 
 		var conn backend.Connection
-		// Get backend conn from slave or master.
-		if isSlave && len(node.DataHost.Slaves) > 0 {
-			if conn, err = c.getOrCreateSlaveConn(node); err != nil {
-				return
-			}
-		} else {
-			if conn, err = c.getOrCreateMasterConn(node); err != nil {
-				return
-			}
+		// Get backend conn from analytics replica, slave or master.
+		if conn, err = c.getBackendConn(node, isSlave); err != nil {
+			return
 		}
 
 		backendConnAddrs = []string{conn.GetAddr()}
 		var mysqlConn = conn.(*mysqlBackend.Conn)
 		mysqlConn.UseDB(node.Database)
+		if err = c.prepareBackendConn(mysqlConn); err != nil {
+			return
+		}
+		if err = c.injectFault(node.Name, mysqlConn); err != nil {
+			return
+		}
 		var moreResult = true
 
 		var result *mysql.Result
@@ -125,6 +236,7 @@ func (c *ClientConn) executePlanWithQueryCommand(statements []sqlparser.Statemen
 			}
 
 			if results[i] != nil {
+				c.setMoreResults(moreResult)
 				if results[i].Resultset == nil {
 					err = c.pkg.WriteOK(c.capability, c.status, results[i])
 				} else {
@@ -138,7 +250,7 @@ func (c *ClientConn) executePlanWithQueryCommand(statements []sqlparser.Statemen
 
 				switch v := statement.(type) {
 				case *sqlparser.UseDB:
-					c.status &= ^mysql.SERVER_MORE_RESULTS_EXISTS
+					c.setMoreResults(false)
 					err = c.handleInitDB(v.DB)
 					return
 				case *sqlparser.Begin:
@@ -147,35 +259,53 @@ func (c *ClientConn) executePlanWithQueryCommand(statements []sqlparser.Statemen
 					}
 					c.status |= mysql.SERVER_STATUS_IN_TRANS
 					c.nodeInTrans = node
-					c.status &= ^mysql.SERVER_MORE_RESULTS_EXISTS
+					c.setMoreResults(false)
 					err = c.pkg.WriteOK(c.capability, c.status, result)
 					return
 				case *sqlparser.Commit:
 					if err = mysqlConn.Commit(); err != nil {
 						return
 					}
+					c.transEnded = true
 					c.status &= ^mysql.SERVER_STATUS_IN_TRANS
 					if c.status&mysql.SERVER_STATUS_AUTOCOMMIT > 0 {
 						c.nodeInTrans = nil
 					}
-					c.status &= ^mysql.SERVER_MORE_RESULTS_EXISTS
+					c.setMoreResults(false)
 					err = c.pkg.WriteOK(c.capability, c.status, result)
 					return
 				case *sqlparser.Rollback:
 					if err = mysqlConn.Rollback(); err != nil {
 						return
 					}
+					c.transEnded = true
 					c.status &= ^mysql.SERVER_STATUS_IN_TRANS
 					if c.status&mysql.SERVER_STATUS_AUTOCOMMIT > 0 {
 						c.nodeInTrans = nil
 					}
-					c.status &= ^mysql.SERVER_MORE_RESULTS_EXISTS
+					c.setMoreResults(false)
 					err = c.pkg.WriteOK(c.capability, c.status, result)
 					return
+				case *sqlparser.LockTables, *sqlparser.UnlockTables:
+					if result, err = c.queryTraced(ctx, node, mysqlConn, c.backendSQL(statement)); err != nil {
+						return
+					}
+					// LOCK TABLES commits transaction implicitly, and releases tables locked before.
+					_, locked := v.(*sqlparser.LockTables)
+					mysqlConn.SetTablesLocked(locked)
+					c.tablesLocked = locked
+					if locked {
+						c.status &= ^mysql.SERVER_STATUS_IN_TRANS
+						c.nodeInTrans = node
+					} else if !c.isInTransaction() {
+						c.nodeInTrans = nil
+					}
+					c.setMoreResults(moreResult)
+					err = c.pkg.WriteOK(c.capability, c.status, result)
 				case *sqlparser.KillQuery:
 					connID := v.GetConnectionID()
 					if c.proxy.cfg.AllowKillQuery {
-						c.status &= ^mysql.SERVER_MORE_RESULTS_EXISTS
+						c.setMoreResults(false)
 						if connID == c.connectionID {
 							c.Close()
 						} else if specConn := c.proxy.GetConnection(connID); specConn != nil {
@@ -195,7 +325,7 @@ func (c *ClientConn) executePlanWithQueryCommand(statements []sqlparser.Statemen
 				case *sqlparser.KillConnection:
 					connID := v.GetConnectionID()
 					if c.proxy.cfg.AllowKillQuery {
-						c.status &= ^mysql.SERVER_MORE_RESULTS_EXISTS
+						c.setMoreResults(false)
 						if connID == c.connectionID {
 							c.Close()
 						} else if specConn := c.proxy.GetConnection(connID); specConn != nil {
@@ -212,13 +342,28 @@ func (c *ClientConn) executePlanWithQueryCommand(statements []sqlparser.Statemen
 						err = mysql.NewDefaultError(mysql.ER_KILL_DENIED_ERROR, connID)
 					}
 					return
+				case *sqlparser.Prepare, *sqlparser.Deallocate:
+					// tracked by proxy when parsed.
+					c.setMoreResults(moreResult)
+					err = c.pkg.WriteOK(c.capability, c.status, nil)
 				case *sqlparser.SetVariable:
-					sql := sqlparser.String(statement)
-					if result, err = mysqlConn.Query(sql); err != nil {
+					sql := c.backendSQL(statement)
+					if result, err = c.queryTraced(ctx, node, mysqlConn, sql); err != nil {
 						return
 					}
+					c.trackSessionVariables(v, mysqlConn)
+					var tracked bool
+					if tracked, err = c.trackSQLMode(v, mysqlConn); err != nil {
+						return
+					}
+					if tracked {
+						if err = c.prepareBackendConn(mysqlConn); err != nil {
+							return
+						}
+					}
 					for _, varNameVal := range v.Exprs {
-						if string(varNameVal.Name.Name) == "autocommit" {
+						if !varNameVal.User && !varNameVal.IsGlobal(v.Scope) && varNameVal.VarName() == "autocommit" {
+							c.transEnded = true
 							autoCommit := sqlparser.String(varNameVal.Expr)
 							if autoCommit == "0" {
 								c.status &= ^mysql.SERVER_STATUS_AUTOCOMMIT
@@ -231,23 +376,21 @@ func (c *ClientConn) executePlanWithQueryCommand(statements []sqlparser.Statemen
 							break
 						}
 					}
-					if moreResult {
-						c.status |= mysql.SERVER_MORE_RESULTS_EXISTS
-					} else {
-						c.status &= ^mysql.SERVER_MORE_RESULTS_EXISTS
-					}
+					c.setMoreResults(moreResult)
 					err = c.pkg.WriteOK(c.capability, c.status, result)
 				default:
-					sql := sqlparser.String(statement)
-					if result, err = mysqlConn.Query(sql); err != nil {
+					sql := c.backendSQL(statement)
+					if result, err = c.queryTraced(ctx, node, mysqlConn, sql); err != nil {
 						return
 					}
-					if moreResult {
-						c.status |= mysql.SERVER_MORE_RESULTS_EXISTS
-					} else {
-						c.status &= ^mysql.SERVER_MORE_RESULTS_EXISTS
-					}
-					if result.Resultset == nil {
+					c.trackAssignedVariables(statement, mysqlConn)
+					c.diffResult(statement, result, isSlave)
+					c.setMoreResults(moreResult)
+					if result.Next != nil {
+						if err = c.checkMultiResults(statement, mysql.CLIENT_MULTI_RESULTS); err == nil {
+							err = c.pkg.WriteResults(c.capability, c.status, result)
+						}
+					} else if result.Resultset == nil {
 						err = c.pkg.WriteOK(c.capability, c.status, result)
 					} else {
 						err = c.pkg.WriteResultSet(c.capability, c.status, result)
@@ -262,7 +405,19 @@ func (c *ClientConn) executePlanWithQueryCommand(statements []sqlparser.Statemen
 			}
 		}
 	} else {
+		if maxFanout := atomic.LoadInt32(&c.proxy.maxFanout); maxFanout > 0 && c.fanoutCount(dataNodes) > int(maxFanout) {
+			err = errors.ErrExceedMaxFanout
+			return
+		}
 		var result *mysql.Result
+		if _, ok := statements[0].(sqlparser.SelectStatement); ok {
+			if result, backendConnAddrs, err = c.fanoutSelect(ctx, statements[0], dataNodes, isSlave); err != nil {
+				return
+			}
+			c.setMoreResults(false)
+			err = c.pkg.WriteResultSet(c.capability, c.status, result)
+			return
+		}
 		for _, dataNode := range dataNodes {
 			node := c.proxy.nodes[dataNode]
 			statement := statements[0]
@@ -272,31 +427,40 @@ func (c *ClientConn) executePlanWithQueryCommand(statements []sqlparser.Statemen
 			}
 
 			var conn backend.Connection
-			// Get backend conn from slave or master.
-			if isSlave && len(node.DataHost.Slaves) > 0 {
-				if conn, err = c.getOrCreateSlaveConn(node); err != nil {
-					return
-				}
-			} else {
-				if conn, err = c.getOrCreateMasterConn(node); err != nil {
-					return
-				}
+			// Get backend conn from analytics replica, slave or master.
+			if conn, err = c.getBackendConn(node, isSlave); err != nil {
+				return
 			}
 
 			backendConnAddrs = append(backendConnAddrs, conn.GetAddr())
 			var mysqlConn = conn.(*mysqlBackend.Conn)
 			mysqlConn.UseDB(node.Database)
+			if err = c.prepareBackendConn(mysqlConn); err != nil {
+				return
+			}
+			if err = c.injectFault(node.Name, mysqlConn); err != nil {
+				return
+			}
 
 			switch statement.(type) {
-			case sqlparser.SelectStatement:
-				err = errors.ErrCmdUnsupport
-				return
 			case sqlparser.DDLStatement:
-				sql := sqlparser.String(statement)
-				if result, err = mysqlConn.Query(sql); err != nil {
+				sql := c.backendSQL(statement)
+				if result, err = c.queryTraced(ctx, node, mysqlConn, sql); err != nil {
+					return
+				}
+				c.setMoreResults(false)
+			case *sqlparser.TableMaintenance:
+				// Rows of each node are merged into a single result set, table column is qualified by node's database.
+				var next *mysql.Result
+				if next, err = c.queryTraced(ctx, node, mysqlConn, c.backendSQL(statement)); err != nil {
 					return
 				}
-				c.status &= ^mysql.SERVER_MORE_RESULTS_EXISTS
+				if result == nil {
+					result = next
+				} else if err = appendResult(result, next); err != nil {
+					return
+				}
+				c.setMoreResults(false)
 			default:
 				err = errors.ErrCmdUnsupport
 				return
@@ -314,3 +478,111 @@ func (c *ClientConn) executePlanWithQueryCommand(statements []sqlparser.Statemen
 	}
 	return
 }
+
+// backendSQL format statement that send to backend,
+// and append attribution comment of client if sql_attribution is enabled.
+func (c *ClientConn) backendSQL(statement sqlparser.Statement) string {
+	sql := sqlparser.StringWithSQLMode(statement, c.parserSQLMode)
+	if !c.proxy.cfg.SQLAttribution {
+		return sql
+	}
+	clientHost, _, _ := net.SplitHostPort(c.c.RemoteAddr().String())
+	return fmt.Sprintf("%s /* saashard user=%s,client=%s,conn=%d */", sql,
+		strings.Replace(c.user, "*/", "* /", -1), clientHost, c.connectionID)
+}
+
+// checkMultiResults reject multi-results of CALL, if client doesn't support them by capability flag, as mysql does.
+func (c *ClientConn) checkMultiResults(statement sqlparser.Statement, flag uint32) error {
+	if c.capability&flag > 0 {
+		return nil
+	}
+	var name string
+	if call, ok := statement.(*sqlparser.Call); ok {
+		name = sqlparser.String(call.Name)
+	}
+	return mysql.NewDefaultError(mysql.ER_SP_BADSELECT, name)
+}
+
+// trackSQLMode track sql_mode set by client, other value than string such as DEFAULT or expression is read back from backend conn.
+func (c *ClientConn) trackSQLMode(statement *sqlparser.SetVariable, mysqlConn *mysqlBackend.Conn) (tracked bool, err error) {
+	if statement.Scope == "global" {
+		return
+	}
+	for _, varNameVal := range statement.Exprs {
+		if varNameVal.User || varNameVal.IsGlobal(statement.Scope) {
+			continue
+		}
+		switch varNameVal.VarName() {
+		case "sql_mode":
+			sqlMode := ""
+			if val, ok := varNameVal.Expr.(sqlparser.StrVal); ok {
+				sqlMode = string(val)
+			} else if sqlMode, err = mysqlConn.ReadSQLMode(); err != nil {
+				return
+			}
+			c.sqlMode, c.sqlModeOn = sqlMode, true
+			c.parserSQLMode = sqlparser.ParseSQLMode(c.sqlMode)
+			tracked = true
+		}
+	}
+	return
+}
+
+// trackSessionVariables track user variables and session's system variables set by client, for replay on other backend conns.
+// Only literal value could be replayed, sql_mode and autocommit are tracked by themselves.
+func (c *ClientConn) trackSessionVariables(statement *sqlparser.SetVariable, mysqlConn *mysqlBackend.Conn) {
+	for _, varNameVal := range statement.Exprs {
+		if varNameVal.IsGlobal(statement.Scope) {
+			continue
+		}
+		if !varNameVal.User {
+			switch varNameVal.VarName() {
+			case "sql_mode", "autocommit":
+				continue
+			}
+		}
+		name := varNameVal.SessionVariable()
+		var value string
+		switch varNameVal.Expr.(type) {
+		case sqlparser.StrVal, sqlparser.NumVal, *sqlparser.NullVal, sqlparser.SetKeyword:
+			value = sqlparser.StringWithSQLMode(varNameVal.Expr, c.parserSQLMode)
+		}
+		if value == "" {
+			delete(c.sessionVariables, name)
+		} else {
+			if c.sessionVariables == nil {
+				c.sessionVariables = make(map[string]string)
+			}
+			c.sessionVariables[name] = value
+		}
+		mysqlConn.TrackVariable(name, value)
+	}
+}
+
+// trackAssignedVariables track user variables assigned by @name := expr, they couldn't be replayed on other backend conns.
+func (c *ClientConn) trackAssignedVariables(statement sqlparser.Statement, mysqlConn *mysqlBackend.Conn) {
+	_, assigned := sqlparser.UserVariables(statement)
+	for _, name := range assigned {
+		name = sqlparser.String(&sqlparser.UserVariable{Name: []byte(name)})
+		delete(c.sessionVariables, name)
+		mysqlConn.TrackVariable(name, "")
+	}
+}
+
+// prepareBackendConn sync session state to backend conn before executing.
+func (c *ClientConn) prepareBackendConn(mysqlConn *mysqlBackend.Conn) error {
+	mysqlConn.SetMemoryTracker(c)
+	mysqlConn.SetCapture(c.backendCapture(mysqlConn.GetAddr()))
+	if err := c.syncSQLMode(mysqlConn); err != nil {
+		return err
+	}
+	return mysqlConn.SetVariables(c.sessionVariables)
+}
+
+// syncSQLMode set sql_mode to backend conn, if client has set it.
+func (c *ClientConn) syncSQLMode(mysqlConn *mysqlBackend.Conn) error {
+	if !c.sqlModeOn {
+		return nil
+	}
+	return mysqlConn.SetSQLMode(c.sqlMode)
+}
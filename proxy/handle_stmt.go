@@ -23,7 +23,9 @@
 package proxy
 
 import (
+	"encoding/binary"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync/atomic"
 
@@ -31,6 +33,7 @@ import (
 	mysqlBackend "github.com/berkaroad/saashard/backend/mysql"
 	"github.com/berkaroad/saashard/errors"
 	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/route"
 	"github.com/berkaroad/saashard/sqlparser"
 )
 
@@ -40,11 +43,19 @@ func (c *ClientConn) handleStmtPrepare(sql string) error {
 	sql = strings.TrimRight(sql, ";")
 
 	var statement sqlparser.Statement
-	statement, err = sqlparser.Parse(sql)
+	statement, err = sqlparser.ParseWithSQLMode(sql, c.parserSQLMode)
+	c.proxy.shadowParse(sql, c.parserSQLMode)
 	if err != nil {
 		return mysql.NewError(mysql.ER_SYNTAX_ERROR, fmt.Sprintf("Syntax error or not supported for '%s': '%s'", sql, err.Error()))
 	}
 
+	if c.readOnly && route.IsWriteStatement(statement) {
+		return errors.ErrReadOnlyListener
+	}
+	if err = c.authorize(statement); err != nil {
+		return err
+	}
+
 	s.Query = sql
 	s.Statement = statement
 
@@ -63,9 +74,12 @@ func (c *ClientConn) handleStmtPrepare(sql string) error {
 
 	var mysqlConn = conn.(*mysqlBackend.Conn)
 	mysqlConn.UseDB(node.Database)
+	if err = c.prepareBackendConn(mysqlConn); err != nil {
+		return err
+	}
 
 	var stmtFromBackend *mysql.Stmt
-	stmtFromBackend, err = mysqlConn.Prepare(sqlparser.String(statement))
+	stmtFromBackend, err = mysqlConn.Prepare(c.backendSQL(statement))
 	if err != nil {
 		return err
 	}
@@ -101,10 +115,22 @@ func (c *ClientConn) handleStmtExecute(data []byte) error {
 	var err error
 	var s *mysql.Stmt
 	s, err = c.pkg.ReadStmtExecuteRequest(data, func(id uint32) *mysql.Stmt { return c.stmts[id] })
+	if err != nil {
+		return err
+	}
+	// re-execute closes opened cursor of stmt.
+	c.closeCursor(s.ID)
+	if err = c.checkRuleVersion(s.Query); err != nil {
+		return err
+	}
 
 	switch stmt := s.Statement.(type) {
 	case *sqlparser.Select:
-		err = c.handlePrepareSelect(stmt, s.Query, s.Args)
+		if s.CursorType == mysql.CURSOR_TYPE_READ_ONLY {
+			err = c.handlePrepareCursor(stmt, s)
+		} else {
+			err = c.handlePrepareSelect(stmt, s.Query, s.Args)
+		}
 	case *sqlparser.Insert:
 		err = c.handlePrepareExec(s.Statement, s.Query, s.Args)
 	case *sqlparser.Update:
@@ -115,6 +141,8 @@ func (c *ClientConn) handleStmtExecute(data []byte) error {
 		err = c.handlePrepareExec(s.Statement, s.Query, s.Args)
 	case *sqlparser.Commit:
 		err = c.handlePrepareExec(s.Statement, s.Query, s.Args)
+	case *sqlparser.Call:
+		err = c.handlePrepareExec(s.Statement, s.Query, s.Args)
 	default:
 		err = fmt.Errorf("command %T not supported now", stmt)
 	}
@@ -145,9 +173,14 @@ func (c *ClientConn) handlePrepareSelect(stmt *sqlparser.Select, sql string, arg
 
 	var mysqlConn = conn.(*mysqlBackend.Conn)
 	mysqlConn.UseDB(node.Database)
+	if err = c.prepareBackendConn(mysqlConn); err != nil {
+		return err
+	}
 
+	ctx, cancel := c.queryContext()
+	defer cancel()
 	var rs *mysql.Result
-	rs, err = mysqlConn.Execute(sql, args)
+	rs, err = mysqlConn.ExecuteContext(ctx, sql, args)
 	if err != nil {
 		return err
 	}
@@ -160,6 +193,49 @@ func (c *ClientConn) handlePrepareSelect(stmt *sqlparser.Select, sql string, arg
 	return err
 }
 
+// handlePrepareCursor open cursor of backend stmt, so that huge result set is fetched by COM_STMT_FETCH in batches.
+func (c *ClientConn) handlePrepareCursor(stmt *sqlparser.Select, s *mysql.Stmt) error {
+	var err error
+	node := c.proxy.nodes[c.schemas[c.db].Nodes[0]]
+	// If in transaction, must exec in the same node.
+	if c.isInTransaction() && node != c.nodeInTrans {
+		return errors.ErrTransInMulti
+	}
+
+	var conn backend.Connection
+	// Get backend conn from master, cursor is kept in it until closed.
+	conn, err = c.getOrCreateMasterConn(node)
+	if err != nil {
+		return err
+	}
+
+	var mysqlConn = conn.(*mysqlBackend.Conn)
+	mysqlConn.UseDB(node.Database)
+	if err = c.prepareBackendConn(mysqlConn); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.queryContext()
+	defer cancel()
+	var backendStmt *mysql.Stmt
+	var rs *mysql.Result
+	backendStmt, rs, err = mysqlConn.ExecuteCursorContext(ctx, s.Query, s.Args)
+	if err != nil {
+		return err
+	}
+
+	status := c.status | rs.Status
+	if backendStmt == nil {
+		// cursor isn't opened by backend, so rows are returned at once.
+		if rs.Resultset == nil {
+			rs.Resultset = c.newEmptyResultset(stmt)
+		}
+		return c.pkg.WriteResultSet(c.capability, status, rs)
+	}
+	c.cursors[s.ID] = &stmtCursor{conn: mysqlConn, stmt: backendStmt, fields: rs.Fields}
+	return c.pkg.WriteStmtCursorResponse(c.capability, status, rs)
+}
+
 func (c *ClientConn) handlePrepareExec(stmt sqlparser.Statement, sql string, args []interface{}) error {
 	var err error
 	node := c.proxy.nodes[c.schemas[c.db].Nodes[0]]
@@ -183,13 +259,26 @@ func (c *ClientConn) handlePrepareExec(stmt sqlparser.Statement, sql string, arg
 
 	var mysqlConn = conn.(*mysqlBackend.Conn)
 	mysqlConn.UseDB(node.Database)
+	if err = c.prepareBackendConn(mysqlConn); err != nil {
+		return err
+	}
 
+	ctx, cancel := c.queryContext()
+	defer cancel()
 	var rs *mysql.Result
-	rs, err = mysqlConn.Execute(sql, args)
+	rs, err = mysqlConn.ExecuteContext(ctx, sql, args)
 	if err != nil {
 		return err
 	}
 
+	// result sets of CALL are followed by result set of OUT parameters with SERVER_PS_OUT_PARAMS, and the final OK.
+	if rs.Next != nil {
+		if err = c.checkMultiResults(stmt, mysql.CLIENT_PS_MULTI_RESULTS); err != nil {
+			return err
+		}
+		return c.pkg.WriteResults(c.capability, c.status, rs)
+	}
+
 	status := c.status | rs.Status
 	if rs.Resultset != nil {
 		err = c.pkg.WriteResultSet(c.capability, status, rs)
@@ -202,17 +291,77 @@ func (c *ClientConn) handlePrepareExec(stmt sqlparser.Statement, sql string, arg
 
 func (c *ClientConn) handleStmtClose(data []byte) error {
 	mysql.PrintPacketData("handleStmtClose", data)
+	if len(data) < 4 {
+		return nil
+	}
+	id := binary.LittleEndian.Uint32(data[0:4])
+	c.closeCursor(id)
+	delete(c.stmts, id)
 	return nil
 }
 
+// handleStmtSendLongData append data to param of stmt, there's no response.
 func (c *ClientConn) handleStmtSendLongData(data []byte) error {
-	mysql.PrintPacketData("handleStmtSendLongData", data)
+	c.pkg.ReadStmtSendLongDataRequest(data, func(id uint32) *mysql.Stmt { return c.stmts[id] })
 	return nil
 }
 
+// handleStmtReset clear long data and close cursor of stmt.
 func (c *ClientConn) handleStmtReset(data []byte) error {
 	mysql.PrintPacketData("handleStmtReset", data)
-	return nil
+	if len(data) < 4 {
+		return errors.ErrMalformPacket
+	}
+	id := binary.LittleEndian.Uint32(data[0:4])
+	s := c.stmts[id]
+	if s == nil {
+		return mysql.NewDefaultError(mysql.ER_UNKNOWN_STMT_HANDLER,
+			strconv.FormatUint(uint64(id), 10), "stmt_reset")
+	}
+	c.closeCursor(id)
+	s.ResetParams()
+	return c.pkg.WriteOK(c.capability, c.status, nil)
+}
+
+// handleStmtFetch fetch rows from opened cursor of backend stmt.
+func (c *ClientConn) handleStmtFetch(data []byte) error {
+	id, rows, err := c.pkg.ReadStmtFetchRequest(data)
+	if err != nil {
+		return err
+	}
+	cursor := c.cursors[id]
+	if cursor == nil {
+		return mysql.NewError(mysql.ER_STMT_HAS_NO_OPEN_CURSOR, fmt.Sprintf("The statement (%d) has no open cursor.", id))
+	}
+
+	ctx, cancel := c.queryContext()
+	defer cancel()
+	var rs *mysql.Result
+	if rs, err = cursor.conn.FetchContext(ctx, cursor.stmt, cursor.fields, rows); err != nil {
+		c.closeCursor(id)
+		return err
+	}
+
+	status := c.status | rs.Status&mysql.SERVER_STATUS_LAST_ROW_SEND
+	if rs.Status&mysql.SERVER_STATUS_LAST_ROW_SEND > 0 {
+		c.closeCursor(id)
+	}
+	return c.pkg.WriteStmtFetchResponse(c.capability, status, rs)
+}
+
+// stmtCursor is opened cursor of backend stmt.
+type stmtCursor struct {
+	conn   *mysqlBackend.Conn
+	stmt   *mysql.Stmt
+	fields []*mysql.Field
+}
+
+// closeCursor close backend stmt of opened cursor.
+func (c *ClientConn) closeCursor(id uint32) {
+	if cursor := c.cursors[id]; cursor != nil {
+		cursor.stmt.Close()
+		delete(c.cursors, id)
+	}
 }
 
 func (c *ClientConn) newEmptyResultset(stmt *sqlparser.Select) *mysql.Resultset {
@@ -0,0 +1,127 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package proxy
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// health is state of proxy and schemas, reported by /readyz of health_port.
+type health struct {
+	Status  string          `json:"status"` // online, not_ready, draining or offline.
+	Schemas []*schemaHealth `json:"schemas"`
+}
+
+// schemaHealth is healthy node count of schema, node is healthy if master of its host isn't down.
+type schemaHealth struct {
+	Name            string `json:"name"`
+	Nodes           int    `json:"nodes"`
+	HealthyNodes    int    `json:"healthy_nodes"`
+	MinHealthyNodes int    `json:"min_healthy_nodes"` // default is all nodes.
+	Ready           bool   `json:"ready"`
+}
+
+// getHealth of proxy and schemas.
+func (p *Server) getHealth() *health {
+	h := &health{Status: "online"}
+	for _, schema := range p.getSchemas() {
+		s := &schemaHealth{Name: schema.Name, Nodes: schema.ConfiguredNodeCount(schema.Nodes), MinHealthyNodes: schema.MinHealthyNodes}
+		if s.MinHealthyNodes <= 0 {
+			s.MinHealthyNodes = s.Nodes
+		}
+		// physical databases of a configured node are counted once, they're on the same host.
+		var healthyNodes []string
+		for _, name := range schema.Nodes {
+			if node := p.nodes[name]; node != nil && !node.DataHost.Master.IsDown() {
+				healthyNodes = append(healthyNodes, name)
+			}
+		}
+		s.HealthyNodes = schema.ConfiguredNodeCount(healthyNodes)
+		s.Ready = s.HealthyNodes >= s.MinHealthyNodes
+		if !s.Ready && h.Status == "online" {
+			h.Status = "not_ready"
+		}
+		h.Schemas = append(h.Schemas, s)
+	}
+	sort.Slice(h.Schemas, func(i, j int) bool { return h.Schemas[i].Name < h.Schemas[j].Name })
+	if p.isDraining() {
+		h.Status = "draining"
+	} else if !p.running {
+		h.Status = "offline"
+	}
+	return h
+}
+
+// isProbeUser is true if user is probe_user, whose session only pings without backend.
+func (p *Server) isProbeUser(user string) bool {
+	return len(p.cfg.ProbeUser) > 0 && user == p.cfg.ProbeUser
+}
+
+// listenHealth open health_port, /healthz is liveness and /readyz is readiness with json of health.
+func (p *Server) listenHealth() error {
+	if p.cfg.HealthPort <= 0 {
+		return nil
+	}
+	addr := p.bindIP.String() + ":" + strconv.Itoa(p.cfg.HealthPort)
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if !p.running && !p.isDraining() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("offline\n"))
+			return
+		}
+		w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		h := p.getHealth()
+		w.Header().Set("Content-Type", "application/json")
+		if h.Status != "online" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(h)
+	})
+	p.healthServer = &http.Server{Handler: mux}
+	p.healthListener = l
+
+	simplelog.Info("%s %s %s address=%s", "server/proxy", "NewServer", "Health endpoint running", addr)
+	return nil
+}
+
+func (p *Server) serveHealth() {
+	if p.healthServer == nil {
+		return
+	}
+	if err := p.healthServer.Serve(p.healthListener); err != nil && err != http.ErrServerClosed {
+		simplelog.Error("%s %s %s", "server/proxy", "serveHealth", err.Error())
+	}
+}
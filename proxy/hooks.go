@@ -0,0 +1,161 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/berkaroad/saashard/backend"
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/utils"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+const (
+	hookEventConnect     = "connect"
+	hookEventDisconnect  = "disconnect"
+	hookEventBackendDown = "backend_down"
+	hookEventBackendUp   = "backend_up"
+	hookEventDrillStart  = "failover_drill_start"
+	hookEventDrillStop   = "failover_drill_stop"
+
+	hookQueueSize      = 1024
+	defaultHookTimeout = 5
+)
+
+// hookEvent is payload of hook, encoded as json.
+type hookEvent struct {
+	Event        string  `json:"event"`
+	Time         string  `json:"time"`
+	ConnectionID uint32  `json:"connection_id,omitempty"`
+	User         string  `json:"user,omitempty"`
+	Host         string  `json:"host,omitempty"`
+	DB           string  `json:"db,omitempty"`
+	Duration     float64 `json:"duration,omitempty"` // seconds connected on disconnect, or seconds of transaction.
+	DataHost     string  `json:"data_host,omitempty"`
+	Addr         string  `json:"addr,omitempty"`
+	Statements   int     `json:"statements,omitempty"` // statements of transaction.
+	Rows         uint64  `json:"rows,omitempty"`       // affected rows of transaction.
+	FirstSQL     string  `json:"first_sql,omitempty"`
+	LastSQL      string  `json:"last_sql,omitempty"`
+}
+
+// hookQueue delivers events to hooks one by one, events are dropped when queue is full.
+type hookQueue struct {
+	hooks  []config.HookConfig
+	events chan *hookEvent
+}
+
+func (p *Server) startHooks() {
+	if len(p.cfg.Hooks) == 0 {
+		return
+	}
+	p.hooks.hooks = p.cfg.Hooks
+	p.hooks.events = make(chan *hookEvent, hookQueueSize)
+	go p.hooks.run()
+
+	for name, host := range p.hosts {
+		hostName := name
+		host.OnStateChange = func(db *backend.DBHost, up bool) {
+			event := hookEventBackendDown
+			if up {
+				event = hookEventBackendUp
+			}
+			p.fireHook(&hookEvent{Event: event, DataHost: hostName, Addr: db.Addr})
+		}
+	}
+}
+
+// fireHook send event to hooks, without blocking.
+func (p *Server) fireHook(event *hookEvent) {
+	if p.hooks.events == nil {
+		return
+	}
+	event.Time = time.Now().Format(time.RFC3339)
+	select {
+	case p.hooks.events <- event:
+	default:
+		simplelog.Warn("%s %s %s event=%s", "proxy", "fireHook", "hook queue is full, event is dropped", event.Event)
+	}
+}
+
+func (q *hookQueue) run() {
+	for event := range q.events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			continue
+		}
+		for _, hook := range q.hooks {
+			if len(hook.Events) > 0 && !utils.Contains(hook.Events, event.Event) {
+				continue
+			}
+			if err = deliverHook(hook, event.Event, payload); err != nil {
+				simplelog.Error("%s %s %s event=%s", "proxy", "deliverHook", err.Error(), event.Event)
+			}
+		}
+	}
+}
+
+// deliverHook post payload to webhook, and execute command with payload as stdin.
+func deliverHook(hook config.HookConfig, event string, payload []byte) error {
+	timeout := time.Duration(hook.Timeout) * time.Second
+	if hook.Timeout <= 0 {
+		timeout = defaultHookTimeout * time.Second
+	}
+	if hook.Webhook != "" {
+		client := &http.Client{Timeout: timeout}
+		resp, err := client.Post(hook.Webhook, "application/json", bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook '%s' responds status %d", hook.Webhook, resp.StatusCode)
+		}
+	}
+	if args := strings.Fields(hook.Command); len(args) > 0 {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Env = append(os.Environ(), "SAASHARD_EVENT="+event)
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+		done := make(chan error, 1)
+		go func() { done <- cmd.Wait() }()
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(timeout):
+			cmd.Process.Kill()
+			return fmt.Errorf("command '%s' timeout", hook.Command)
+		}
+	}
+	return nil
+}
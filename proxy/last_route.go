@@ -0,0 +1,106 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/berkaroad/saashard/backend"
+	mysqlBackend "github.com/berkaroad/saashard/backend/mysql"
+	"github.com/berkaroad/saashard/net/mysql"
+)
+
+// maxRouteTraces of a query command, executions after are not traced.
+const maxRouteTraces = 256
+
+// routeTrace is execution of rewritten sql at a node, shown by 'show saashard last route'.
+type routeTrace struct {
+	Node     string
+	Addr     string
+	SQL      string
+	Duration time.Duration
+	Rows     uint64 // rows returned, or affected rows
+	Error    string
+}
+
+// queryTraced execute sql in mysqlConn of node, and trace it into route of current query command.
+func (c *ClientConn) queryTraced(ctx context.Context, node *backend.DataNode, mysqlConn *mysqlBackend.Conn, sql string) (*mysql.Result, error) {
+	start := time.Now()
+	result, err := mysqlConn.QueryContext(ctx, sql)
+	if err == nil && result.Resultset == nil {
+		c.trans.addRows(result.AffectedRows)
+	}
+	if len(c.lastRoute) >= maxRouteTraces {
+		return result, err
+	}
+	trace := &routeTrace{Node: node.Name, Addr: mysqlConn.GetAddr(), SQL: sql, Duration: time.Since(start)}
+	if err != nil {
+		trace.Error = err.Error()
+	} else if result.Resultset != nil {
+		trace.Rows = uint64(len(result.Rows))
+		if result.Spilled != nil {
+			trace.Rows += uint64(result.Spilled.Count())
+		}
+	} else {
+		trace.Rows = result.AffectedRows
+	}
+	c.lastRoute = append(c.lastRoute, trace)
+	return result, err
+}
+
+// handleShowLastRoute 'SHOW SAASHARD LAST ROUTE', nodes, rewritten sql, latency and rows of previous query command.
+// Statements answered by proxy have no rows.
+func (c *ClientConn) handleShowLastRoute() error {
+	result := new(mysql.Result)
+	result.Status = c.status
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{
+		newRouteField("Node"),
+		newRouteField("Addr"),
+		newRouteField("SQL"),
+		newRouteField("Latency_ms"),
+		newRouteField("Rows"),
+		newRouteField("Error"),
+	}
+	result.Rows = make([]*mysql.Row, 0, len(c.lastRoute))
+	for _, trace := range c.lastRoute {
+		row := mysql.NewTextRow(result.Resultset.Fields)
+		row.AppendStringValue(trace.Node)
+		row.AppendStringValue(trace.Addr)
+		row.AppendStringValue(trace.SQL)
+		row.AppendStringValue(strconv.FormatFloat(trace.Duration.Seconds()*1000, 'f', 3, 64))
+		row.AppendStringValue(strconv.FormatUint(trace.Rows, 10))
+		row.AppendStringValue(trace.Error)
+		result.Rows = append(result.Rows, row)
+	}
+	return c.pkg.WriteResultSet(c.capability, c.status, result)
+}
+
+func newRouteField(name string) *mysql.Field {
+	return &mysql.Field{Name: []byte(name),
+		Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+		ColumnLength: 192,
+		ColumnType:   mysql.MYSQL_TYPE_VAR_STRING}
+}
@@ -0,0 +1,91 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// listen on addr, with SO_REUSEPORT if reusePort, so that multiple sockets accept on the same port.
+func listen(netProto, addr string, reusePort bool) (net.Listener, error) {
+	if !reusePort {
+		return net.Listen(netProto, addr)
+	}
+	lc := net.ListenConfig{Control: reusePortControl}
+	return lc.Listen(context.Background(), netProto, addr)
+}
+
+// acceptors count of accept loops, it is count of sockets if reuse_port, else count of loops on one socket.
+func (p *Server) acceptors() int {
+	if p.cfg.Acceptors < 1 {
+		return 1
+	}
+	return p.cfg.Acceptors
+}
+
+// tuneProcs apply max_procs, and warn if GOMAXPROCS exceeds cpu quota of cgroup.
+// runtime.NumCPU already follows cpu affinity, so pinning by taskset or numactl limits GOMAXPROCS too.
+func tuneProcs(maxProcs int) {
+	if maxProcs > 0 {
+		runtime.GOMAXPROCS(maxProcs)
+	}
+	procs := runtime.GOMAXPROCS(0)
+	simplelog.Info("%s %s %s numCPU=%d,GOMAXPROCS=%d", "server/proxy", "tuneProcs", "CPU", runtime.NumCPU(), procs)
+	if quota := cgroupCPUQuota(); quota > 0 && float64(procs) > quota+0.5 {
+		simplelog.Warn("%s %s %s GOMAXPROCS=%d,quota=%.1f", "server/proxy", "tuneProcs",
+			"GOMAXPROCS exceeds cpu quota of cgroup, set max_procs to avoid throttling", procs, quota)
+	}
+}
+
+// cgroupCPUQuota cpu count limited by cgroup v2 or v1, 0 means unlimited or unknown.
+func cgroupCPUQuota() float64 {
+	if data, err := ioutil.ReadFile("/sys/fs/cgroup/cpu.max"); err == nil {
+		fields := strings.Fields(string(data))
+		if len(fields) == 2 && fields[0] != "max" {
+			return divide(fields[0], fields[1])
+		}
+		return 0
+	}
+	quota, err1 := ioutil.ReadFile("/sys/fs/cgroup/cpu/cpu.cfs_quota_us")
+	period, err2 := ioutil.ReadFile("/sys/fs/cgroup/cpu/cpu.cfs_period_us")
+	if err1 != nil || err2 != nil {
+		return 0
+	}
+	return divide(strings.TrimSpace(string(quota)), strings.TrimSpace(string(period)))
+}
+
+func divide(a, b string) float64 {
+	x, err1 := strconv.ParseFloat(a, 64)
+	y, err2 := strconv.ParseFloat(b, 64)
+	if err1 != nil || err2 != nil || x <= 0 || y <= 0 {
+		return 0
+	}
+	return x / y
+}
@@ -0,0 +1,77 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/berkaroad/saashard/errors"
+)
+
+const (
+	memoryPolicyAbort = "abort"
+	memoryPolicySpill = "spill"
+
+	megabyte = 1024 * 1024
+)
+
+// Consume account buffered bytes to session and global,
+// return error if global memory budget is exceeded.
+func (c *ClientConn) Consume(n int64) error {
+	atomic.AddInt64(&c.memoryUsed, n)
+	total := c.proxy.counter.AddMemoryUsed(n)
+	if budget := atomic.LoadInt64(&c.proxy.memoryBudget); budget > 0 && total > budget {
+		return errors.ErrExceedMemory
+	}
+	return nil
+}
+
+// SpillDir return dir to spill rows, if memory policy is spill.
+func (c *ClientConn) SpillDir() string {
+	if !strings.EqualFold(c.proxy.cfg.MemoryPolicy, memoryPolicySpill) {
+		return ""
+	}
+	if len(c.proxy.cfg.SpillDir) > 0 {
+		return c.proxy.cfg.SpillDir
+	}
+	return os.TempDir()
+}
+
+// SpillMaxSize return max bytes could be spilled by a query.
+func (c *ClientConn) SpillMaxSize() int64 {
+	return int64(c.proxy.cfg.SpillMaxSize) * megabyte
+}
+
+// GetMemoryUsed return bytes buffered by current command.
+func (c *ClientConn) GetMemoryUsed() int64 {
+	return atomic.LoadInt64(&c.memoryUsed)
+}
+
+// releaseMemory release all bytes of session after command finished.
+func (c *ClientConn) releaseMemory() {
+	if n := atomic.SwapInt64(&c.memoryUsed, 0); n != 0 {
+		c.proxy.counter.AddMemoryUsed(-n)
+	}
+}
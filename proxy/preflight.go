@@ -0,0 +1,273 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package proxy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/berkaroad/saashard/backend"
+	mysqlBackend "github.com/berkaroad/saashard/backend/mysql"
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/route"
+	"github.com/berkaroad/saashard/utils"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// min version of backend server, that's 5.5.
+const (
+	minServerMajorVersion = 5
+	minServerMinorVersion = 5
+)
+
+// PreflightResult is result of a check against backend.
+type PreflightResult struct {
+	Host    string
+	Node    string // empty if it's check of db host.
+	Addr    string
+	Role    string // master, slave or analytics.
+	Check   string // connect, version, writable, replica, database or table.
+	Object  string // database or table checked.
+	Passed  bool
+	Message string // why it's failed and how to fix it.
+}
+
+// Preflight check backends of config, without running proxy. It's used before startup.
+func Preflight(cfg *config.Config) ([]PreflightResult, error) {
+	p := new(Server)
+	p.cfg = cfg
+	p.hosts = make(map[string]*backend.DataHost)
+	p.nodes = make(map[string]*backend.DataNode)
+	if err := p.parseHosts(); err != nil {
+		return nil, err
+	}
+	if err := p.parseNodes(); err != nil {
+		return nil, err
+	}
+	if err := p.parseSchemas(); err != nil {
+		return nil, err
+	}
+	return p.Preflight(), nil
+}
+
+// PreflightError log failed checks, and return ErrPreflight if any.
+func PreflightError(results []PreflightResult) error {
+	var failed int
+	for _, result := range results {
+		if !result.Passed {
+			failed++
+			simplelog.Error("%s %s %s host=%s,node=%s,addr=%s,role=%s,check=%s,object=%s,message=%s", "proxy", "Preflight", "Check failed",
+				result.Host, result.Node, result.Addr, result.Role, result.Check, result.Object, result.Message)
+		}
+	}
+	if failed > 0 {
+		return errors.ErrPreflight
+	}
+	return nil
+}
+
+// Preflight check every db host is reachable with credentials, and its server version is compatible,
+// master is writable and slaves are replicas, databases of nodes and tables of schemas exist.
+// Hosts are checked concurrently, results are ordered by host.
+func (p *Server) Preflight() []PreflightResult {
+	names := make([]string, 0, len(p.hosts))
+	for name := range p.hosts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hostResults := make([][]PreflightResult, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, host *backend.DataHost) {
+			defer wg.Done()
+			hostResults[i] = p.preflightHost(host)
+		}(i, p.hosts[name])
+	}
+	wg.Wait()
+
+	var results []PreflightResult
+	for _, r := range hostResults {
+		results = append(results, r...)
+	}
+	return results
+}
+
+// preflightHost check master, slaves and analytics replicas of host, and nodes in master.
+func (p *Server) preflightHost(host *backend.DataHost) []PreflightResult {
+	var results []PreflightResult
+	results = append(results, p.preflightDB(host, host.Master, "master")...)
+	for _, slave := range host.Slaves {
+		results = append(results, p.preflightDB(host, slave, "slave")...)
+	}
+	for _, analytics := range host.Analytics {
+		results = append(results, p.preflightDB(host, analytics, "analytics")...)
+	}
+	return results
+}
+
+// preflightDB connect to db host, and check its version and role.
+func (p *Server) preflightDB(host *backend.DataHost, db *backend.DBHost, role string) []PreflightResult {
+	check := func(name string, err error, message string, args ...interface{}) PreflightResult {
+		result := PreflightResult{Host: host.Name, Addr: db.Addr, Role: role, Check: name, Passed: err == nil}
+		if err != nil {
+			result.Message = fmt.Sprintf("%s, "+message, append([]interface{}{err.Error()}, args...)...)
+		}
+		return result
+	}
+
+	conn := backend.CreateConnection(db)
+	if err := conn.Connect(db, ""); err != nil {
+		return []PreflightResult{check("connect", err, "check %s address, user and password of host '%s'", role, host.Name)}
+	}
+	defer conn.Close()
+	results := []PreflightResult{check("connect", nil, "")}
+	mysqlConn, ok := conn.(*mysqlBackend.Conn)
+	if !ok {
+		return results
+	}
+
+	result, err := mysqlConn.Query("select @@version, @@read_only")
+	if err != nil || result.Resultset == nil || result.RowNumber() == 0 {
+		if err == nil {
+			err = fmt.Errorf("no result of select @@version")
+		}
+		return append(results, check("version", err, "user of host '%s' should be able to run select", host.Name))
+	}
+	version, _ := result.GetString(0, 0)
+	readOnly, _ := result.GetInt(0, 1)
+	results = append(results, check("version", checkServerVersion(version), "%d.%d or later is required",
+		minServerMajorVersion, minServerMinorVersion))
+
+	if role == "master" {
+		if readOnly != 0 {
+			err = errors.ErrReadOnlyMaster
+		}
+		results = append(results, check("writable", err, "master of host '%s' should be the source of replication", host.Name))
+		return append(results, p.preflightNodes(host, mysqlConn)...)
+	}
+	if _, err = mysqlConn.ReplicaLag(); err == errors.ErrNotReplica {
+		return append(results, check("replica", err, "%s of host '%s' should replicate from master '%s'", role, host.Name, host.Master.Addr))
+	}
+	// replication may be stopped or lagging, it's measured every ping_interval.
+	return append(results, check("replica", nil, ""))
+}
+
+// preflightNodes check databases of nodes in host exist, and tables of schemas in them.
+func (p *Server) preflightNodes(host *backend.DataHost, conn *mysqlBackend.Conn) []PreflightResult {
+	var names []string
+	for name, node := range p.nodes {
+		if node.DataHost == host {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var results []PreflightResult
+	for _, name := range names {
+		node := p.nodes[name]
+		result := PreflightResult{Host: host.Name, Node: name, Addr: host.Master.Addr, Role: "master", Check: "database",
+			Object: node.Database, Passed: true}
+		if err := conn.UseDB(node.Database); err != nil {
+			result.Passed = false
+			result.Message = fmt.Sprintf("%s, database '%s' of node '%s' should exist, and user of host '%s' should have privileges on it",
+				err.Error(), node.Database, name, host.Name)
+			results = append(results, result)
+			continue
+		}
+		results = append(results, result)
+
+		existing, err := conn.Query("show tables")
+		if err != nil {
+			result.Check, result.Object, result.Passed, result.Message = "table", "", false, err.Error()
+			results = append(results, result)
+			continue
+		}
+		tables := make(map[string]bool)
+		if existing.Resultset != nil {
+			for i := 0; i < existing.RowNumber(); i++ {
+				table, _ := existing.GetString(i, 0)
+				tables[strings.ToLower(table)] = true
+			}
+		}
+		for _, table := range p.nodeTables(name) {
+			result := PreflightResult{Host: host.Name, Node: name, Addr: host.Master.Addr, Role: "master", Check: "table",
+				Object: table[1], Passed: tables[table[1]]}
+			if !result.Passed {
+				result.Message = fmt.Sprintf("table '%s' of schema '%s' doesn't exist in database '%s' of node '%s', it should be created before startup",
+					table[1], table[0], node.Database, name)
+			}
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// nodeTables are schema and physical name pairs of configured tables in node, sub-sharded table is checked by physical names.
+func (p *Server) nodeTables(nodeName string) [][2]string {
+	schemas := p.getSchemas()
+	var names []string
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var tables [][2]string
+	for _, name := range names {
+		schema := schemas[name]
+		for i := range schema.Tables {
+			table := &schema.Tables[i]
+			nodes := schema.Nodes
+			if len(table.PinNodes) > 0 {
+				nodes = table.PinNodes
+			}
+			if !utils.Contains(nodes, nodeName) {
+				continue
+			}
+			tableName := strings.ToLower(table.Name)
+			if !route.SubShardEnabled(table) {
+				tables = append(tables, [2]string{name, tableName})
+				continue
+			}
+			for index := 0; index < table.SubShardCount; index++ {
+				tables = append(tables, [2]string{name, route.PhysicalTableName(tableName, index)})
+			}
+		}
+	}
+	return tables
+}
+
+// checkServerVersion such as '5.7.30-log' or '10.4.12-MariaDB', it should be 5.5 or later.
+func checkServerVersion(version string) error {
+	var major, minor int
+	if _, err := fmt.Sscanf(version, "%d.%d", &major, &minor); err != nil {
+		return fmt.Errorf("server version '%s' is unknown", version)
+	}
+	if major < minServerMajorVersion || (major == minServerMajorVersion && minor < minServerMinorVersion) {
+		return fmt.Errorf("server version '%s' is not supported", version)
+	}
+	return nil
+}
@@ -23,9 +23,12 @@
 package proxy
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -38,7 +41,11 @@ import (
 	"github.com/berkaroad/saashard/config"
 	"github.com/berkaroad/saashard/errors"
 	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/replay"
+	"github.com/berkaroad/saashard/route"
+	"github.com/berkaroad/saashard/sqlparser"
 	"github.com/berkaroad/saashard/statistic"
+	"github.com/berkaroad/saashard/utils"
 	"github.com/berkaroad/saashard/utils/simplelog"
 )
 
@@ -49,12 +56,11 @@ var (
 // Server proxy daemon
 type Server struct {
 	sync.Mutex
-	cfg     *config.Config
-	bindIP  net.IP
-	port    int
-	hosts   map[string]*backend.DataHost
-	nodes   map[string]*backend.DataNode
-	schemas map[string]*config.SchemaConfig
+	cfg    *config.Config
+	bindIP net.IP
+	port   int
+	hosts  map[string]*backend.DataHost
+	nodes  map[string]*backend.DataNode
 
 	logSQLIndex      int32
 	logSQL           [2]string
@@ -62,11 +68,48 @@ type Server struct {
 	slowLogTime      [2]int
 	allowipsIndex    int32
 	allowips         [2][]net.IP
+	maxFanout        int32
+	maxConnNum       int32
+	memoryBudget     int64 // bytes, 0 means unlimited
+	diffMode         int32
+	diffs            chan *diffTask   // selects to compare with diff_schema.
+	shadows          chan *shadowTask // statements to parse again by shadow parser.
+	shadowParser     atomic.Value     // name of shadow parser, empty is off.
+	certs            *certStore
+	stats            cardinalityStats
+	clones           cloneJobs
+	hooks            hookQueue
+	authz            *authorizer // nil if authz webhook isn't set.
+	shipper          *logShipper // nil if log_shipper url isn't set.
+	overridesIndex   int32
+	overrides        [2]map[string]string // routing overrides, fingerprint -> target
+	captureIndex     int32
+	captures         [2]*captureFilter // sessions to capture
+	faultsIndex      int32
+	faults           [2]*faults // fault injection, off by default
+	drill            failoverDrill
+	audit            auditLog
+	rules            shardRules
+	users            proxyUsers
+
+	counter   *statistic.Counter
+	listener  net.Listener
+	listeners []*listener // extra listeners
+	running   bool
+	conns     map[uint32]*ClientConn
+
+	healthServer   *http.Server // health_port
+	healthListener net.Listener
+	exportServer   *http.Server // export_port
+	exportListener net.Listener
+	draining       int32 // 1 if draining before shutdown, readiness is false.
+}
 
-	counter  *statistic.Counter
-	listener net.Listener
-	running  bool
-	conns    map[uint32]*ClientConn
+// listener with its policy.
+type listener struct {
+	net.Listener
+	name     string
+	readOnly bool
 }
 
 // NewServer create proxy.
@@ -79,18 +122,30 @@ func NewServer(cfg *config.Config) (*Server, error) {
 
 	p.hosts = make(map[string]*backend.DataHost)
 	p.nodes = make(map[string]*backend.DataNode)
-	p.schemas = make(map[string]*config.SchemaConfig)
 
 	p.counter = new(statistic.Counter)
 	atomic.StoreInt32(&p.logSQLIndex, 0)
 	p.logSQL[p.logSQLIndex] = cfg.LogSQL
 	atomic.StoreInt32(&p.slowLogTimeIndex, 0)
 	p.slowLogTime[p.slowLogTimeIndex] = cfg.SlowLogTime
-	if len(cfg.Charset) != 0 {
-		cid, ok := mysql.CharsetIds[cfg.Charset]
-		if !ok {
-			return nil, errors.ErrInvalidCharset
+	atomic.StoreInt32(&p.maxFanout, int32(cfg.MaxFanout))
+	atomic.StoreInt64(&p.memoryBudget, int64(cfg.MemoryBudget)*megabyte)
+	if err := checkOptions(cfg); err != nil {
+		return nil, err
+	}
+	tuneProcs(cfg.MaxProcs)
+	diffMode, _ := parseDiffMode(cfg.DiffMode)
+	atomic.StoreInt32(&p.diffMode, diffMode)
+	if err := p.setShadowParser(cfg.ShadowParser); err != nil {
+		return nil, err
+	}
+	if len(cfg.LogLevel) != 0 {
+		if err := simplelog.SetLevel(cfg.LogLevel); err != nil {
+			return nil, err
 		}
+	}
+	if len(cfg.Charset) != 0 {
+		cid := mysql.CharsetIds[cfg.Charset]
 		//change the default charset
 		mysql.DEFAULT_CHARSET = cfg.Charset
 		mysql.DEFAULT_COLLATION_ID = cid
@@ -100,6 +155,14 @@ func NewServer(cfg *config.Config) (*Server, error) {
 	if err := p.parseHosts(); err != nil {
 		panic(err)
 	}
+	p.startHooks()
+	p.startDiff()
+	p.startShadow()
+	p.startLogShipper()
+	p.authz = newAuthorizer(cfg.Authz)
+	for _, host := range p.hosts {
+		go host.Run()
+	}
 
 	if err := p.parseNodes(); err != nil {
 		panic(err)
@@ -113,19 +176,77 @@ func NewServer(cfg *config.Config) (*Server, error) {
 		panic(err)
 	}
 
+	if err := p.loadRuntimeState(); err != nil {
+		return nil, err
+	}
+
+	if err := p.loadAuditLog(); err != nil {
+		return nil, err
+	}
+
+	if len(cfg.TLSCert) > 0 {
+		var err error
+		if p.certs, err = newCertStore(cfg.TLSCert, cfg.TLSKey, cfg.TLSCA); err != nil {
+			return nil, err
+		}
+		if cfg.TLSReloadInterval > 0 {
+			go p.certs.Watch(time.Duration(cfg.TLSReloadInterval) * time.Second)
+		}
+	}
+
 	var err error
 	netProto := "tcp"
 	addr := p.bindIP.String() + ":" + strconv.Itoa(p.port)
 
-	p.listener, err = net.Listen(netProto, addr)
+	p.listener, err = listen(netProto, addr, cfg.ReusePort)
 	if err != nil {
 		return nil, err
 	}
+	// sockets with SO_REUSEPORT, kernel balances connections among them.
+	sockets := 1
+	if cfg.ReusePort {
+		sockets = p.acceptors()
+	}
+	for i := 1; i < sockets; i++ {
+		l := &listener{name: "proxy"}
+		if l.Listener, err = listen(netProto, addr, true); err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.listeners = append(p.listeners, l)
+	}
 
-	simplelog.Info("%s %s %s netProto=%s,address=%s",
+	simplelog.Info("%s %s %s netProto=%s,address=%s,sockets=%d",
 		"server/proxy", "NewServer", "Server running",
 		netProto,
-		addr)
+		addr,
+		sockets)
+
+	for _, listenerConfig := range cfg.Listeners {
+		addr := p.bindIP.String() + ":" + strconv.Itoa(listenerConfig.Port)
+		for i := 0; i < sockets; i++ {
+			l := &listener{name: listenerConfig.Name, readOnly: listenerConfig.ReadOnly}
+			if l.Listener, err = listen(netProto, addr, cfg.ReusePort); err != nil {
+				p.Close()
+				return nil, err
+			}
+			p.listeners = append(p.listeners, l)
+		}
+		simplelog.Info("%s %s %s name=%s,netProto=%s,address=%s,readOnly=%v",
+			"server/proxy", "NewServer", "Listener running",
+			listenerConfig.Name,
+			netProto,
+			addr,
+			listenerConfig.ReadOnly)
+	}
+	if err = p.listenHealth(); err != nil {
+		p.Close()
+		return nil, err
+	}
+	if err = p.listenExport(); err != nil {
+		p.Close()
+		return nil, err
+	}
 	return p, nil
 }
 
@@ -136,14 +257,45 @@ func (p *Server) Run() {
 	// flush counter
 	go p.flushCounter()
 
+	// sample cardinality
+	if p.cfg.StatsInterval > 0 {
+		go p.collectStats(time.Duration(p.cfg.StatsInterval) * time.Second)
+	}
+
+	// long transaction alerts
+	if p.cfg.LongTransTime > 0 {
+		go p.checkTransactions()
+	}
+
+	// health
+	go p.serveHealth()
+	go p.serveExport()
+
 	// proxy
+	// accept loops of each socket, more than one if acceptors is set without reuse_port.
+	loops := 1
+	if !p.cfg.ReusePort {
+		loops = p.acceptors()
+	}
+	for _, l := range p.listeners {
+		for i := 0; i < loops; i++ {
+			go p.serve(l.Listener, l.readOnly)
+		}
+	}
+	for i := 1; i < loops; i++ {
+		go p.serve(p.listener, false)
+	}
+	p.serve(p.listener, false)
+}
+
+func (p *Server) serve(l net.Listener, readOnly bool) {
 	for p.running {
-		conn, err := p.listener.Accept()
+		conn, err := l.Accept()
 		if err != nil {
 			simplelog.Error("%s %s %s", "server/proxy", "Run", err.Error())
 			continue
 		}
-		go p.onConn(conn)
+		go p.onConn(conn, readOnly)
 	}
 }
 
@@ -153,6 +305,31 @@ func (p *Server) Close() {
 	if p.listener != nil {
 		p.listener.Close()
 	}
+	for _, l := range p.listeners {
+		l.Close()
+	}
+	if p.healthServer != nil {
+		p.healthServer.Close()
+		p.healthListener.Close()
+	}
+	if p.exportServer != nil {
+		p.exportServer.Close()
+		p.exportListener.Close()
+	}
+	for _, host := range p.hosts {
+		host.Close()
+	}
+	if p.certs != nil {
+		p.certs.Close()
+	}
+}
+
+// ReloadTLS reload certificates, new handshakes use new certificates.
+func (p *Server) ReloadTLS() error {
+	if p.certs == nil {
+		return errors.ErrTLSDisabled
+	}
+	return p.certs.Reload()
 }
 
 // GetConnection get connection
@@ -166,6 +343,52 @@ func (p *Server) GetConnection(connectionID uint32) *ClientConn {
 	return nil
 }
 
+// SessionInfo is state of client session, it's used by admin.
+type SessionInfo struct {
+	ID         uint32
+	User       string
+	Host       string
+	DB         string
+	ReadOnly   bool
+	MemoryUsed int64
+}
+
+// GetSessions of clients, order by connection id.
+func (p *Server) GetSessions() []*SessionInfo {
+	defer p.Unlock()
+
+	p.Lock()
+	sessions := make([]*SessionInfo, 0, len(p.conns))
+	for _, conn := range p.conns {
+		sessions = append(sessions, &SessionInfo{
+			ID:         conn.connectionID,
+			User:       conn.user,
+			Host:       conn.c.RemoteAddr().String(),
+			DB:         conn.db,
+			ReadOnly:   conn.readOnly,
+			MemoryUsed: conn.GetMemoryUsed(),
+		})
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID < sessions[j].ID })
+	return sessions
+}
+
+// KillSession close client session, return user@host of the session, false if it doesn't exist.
+func (p *Server) KillSession(connectionID uint32) (string, bool) {
+	conn := p.GetConnection(connectionID)
+	if conn == nil {
+		return "", false
+	}
+	session := conn.user + "@" + conn.c.RemoteAddr().String()
+	conn.Close()
+	return session, true
+}
+
+// GetCounter of proxy.
+func (p *Server) GetCounter() *statistic.Counter {
+	return p.counter
+}
+
 // RemoveConnection remove connection
 func (p *Server) RemoveConnection(connectionID uint32) {
 	defer p.Unlock()
@@ -177,9 +400,11 @@ func (p *Server) RemoveConnection(connectionID uint32) {
 	}
 }
 
-func (p *Server) onConn(c net.Conn) {
+func (p *Server) onConn(c net.Conn, readOnly bool) {
 	p.counter.IncrClientConns()
 	conn := p.newClientConn(c) //新建一个conn
+	conn.readOnly = readOnly
+	var connectedAt time.Time
 
 	defer func() {
 		err := recover()
@@ -195,6 +420,10 @@ func (p *Server) onConn(c net.Conn) {
 
 		conn.Close()
 		p.counter.DecrClientConns()
+		if !connectedAt.IsZero() && !conn.probe {
+			p.fireHook(&hookEvent{Event: hookEventDisconnect, ConnectionID: conn.connectionID, User: conn.user,
+				Host: c.RemoteAddr().String(), DB: conn.db, Duration: time.Since(connectedAt).Seconds()})
+		}
 	}()
 
 	if allowConnect := conn.IsAllowConnect(); allowConnect == false {
@@ -210,10 +439,65 @@ func (p *Server) onConn(c net.Conn) {
 	}
 
 	conn.schemas = p.getSchemasByUser(conn.user)
+	if conn.probe {
+		p.Lock()
+		p.conns[conn.connectionID] = conn
+		p.Unlock()
+		conn.Run()
+		return
+	}
+	conn.readSQLMode()
+	p.addConn(conn)
+	if p.getCaptureFilter().match(conn) {
+		if err := conn.startCapture(); err != nil {
+			simplelog.Error("%s %s %s", "server/proxy", "onConn", err.Error())
+		}
+	}
+	connectedAt = time.Now()
+	p.fireHook(&hookEvent{Event: hookEventConnect, ConnectionID: conn.connectionID, User: conn.user,
+		Host: c.RemoteAddr().String(), DB: conn.db})
+	conn.Run()
+}
+
+// addConn save conn, and close previous sessions of the same user if user is in singleton_users,
+// so that duplicate batch jobs wouldn't execute twice. If singleton_attribute is set, such as program_name,
+// only sessions with the same value of connection attribute are duplicate.
+// Duplicates are found and conn is saved under one lock, so that concurrent connections don't miss each other.
+func (p *Server) addConn(conn *ClientConn) {
+	singleton := false
+	for _, user := range p.cfg.SingletonUsers {
+		if user == conn.user {
+			singleton = true
+			break
+		}
+	}
+	var duplicates []*ClientConn
 	p.Lock()
-	p.conns[conn.connectionID] = conn // save conn
+	if singleton {
+		for _, c := range p.conns {
+			if c.connectionID != conn.connectionID && p.isDuplicateSession(c, conn) {
+				duplicates = append(duplicates, c)
+			}
+		}
+	}
+	p.conns[conn.connectionID] = conn
 	p.Unlock()
-	conn.Run()
+	for _, c := range duplicates {
+		simplelog.Info("%s %s %s user=%s,connection id=%d,new connection id=%d", "server/proxy", "addConn",
+			"Kill previous session of singleton user", c.user, c.connectionID, conn.connectionID)
+		c.Close()
+	}
+}
+
+// isDuplicateSession check whether previous session is of the same user and singleton_attribute as new conn.
+func (p *Server) isDuplicateSession(previous, conn *ClientConn) bool {
+	if previous.user != conn.user || previous.probe {
+		return false
+	}
+	if name := p.cfg.SingletonAttribute; len(name) > 0 {
+		return previous.attrs[name] == conn.attrs[name]
+	}
+	return true
 }
 
 func (p *Server) newClientConn(co net.Conn) *ClientConn {
@@ -226,9 +510,17 @@ func (p *Server) newClientConn(co net.Conn) *ClientConn {
 	// meaning that data is sent as soon as possible after a Write.
 	//I set this option false.
 	tcpConn.SetNoDelay(false)
+	tcpConn.SetKeepAlive(true)
+	if p.cfg.TCPKeepAlive > 0 {
+		tcpConn.SetKeepAlivePeriod(time.Duration(p.cfg.TCPKeepAlive) * time.Second)
+	}
 	c.c = tcpConn
 
 	c.pkg = mysql.NewPacketIO(tcpConn)
+	c.pkg.SetCapture(&captureSide{conn: c, side: replay.SideFrontend, addr: tcpConn.RemoteAddr().String()})
+	if p.certs != nil {
+		c.pkg.EnableTLS(p.certs.TLSConfig())
+	}
 	c.proxy = p
 	c.pkg.Sequence = 0
 	c.connectionID = atomic.AddUint32(&baseConnID, 1)
@@ -236,11 +528,15 @@ func (p *Server) newClientConn(co net.Conn) *ClientConn {
 	c.salt, _ = mysql.RandomBuf(20)
 	c.backendMasterConns = make(map[*backend.DataNode]backend.Connection)
 	c.backendSlaveConns = make(map[*backend.DataNode]backend.Connection)
+	c.backendOLAPConns = make(map[*backend.DataNode]backend.Connection)
 	c.closed = false
+	c.ctx, c.cancel = context.WithCancel(context.Background())
 	c.charset = mysql.DEFAULT_CHARSET
 	c.collation = mysql.DEFAULT_COLLATION_ID
 	c.stmtID = 0
 	c.stmts = make(map[uint32]*mysql.Stmt)
+	c.cursors = make(map[uint32]*stmtCursor)
+	c.prepared = make(map[string]sqlparser.Statement)
 	return c
 }
 
@@ -251,6 +547,56 @@ func (p *Server) flushCounter() {
 	}
 }
 
+// CheckConfig validate config without connecting to backends.
+func CheckConfig(cfg *config.Config) error {
+	if err := checkOptions(cfg); err != nil {
+		return err
+	}
+	p := new(Server)
+	p.cfg = cfg
+	p.hosts = make(map[string]*backend.DataHost)
+	p.nodes = make(map[string]*backend.DataNode)
+	if err := p.parseHosts(); err != nil {
+		return err
+	}
+	if err := p.parseNodes(); err != nil {
+		return err
+	}
+	return p.parseSchemas()
+}
+
+// checkOptions validate options of server.
+func checkOptions(cfg *config.Config) error {
+	if len(cfg.MemoryPolicy) > 0 && !strings.EqualFold(cfg.MemoryPolicy, memoryPolicyAbort) &&
+		!strings.EqualFold(cfg.MemoryPolicy, memoryPolicySpill) {
+		return fmt.Errorf("memory_policy '%s' is invalid", cfg.MemoryPolicy)
+	}
+	if _, err := parseDiffMode(cfg.DiffMode); err != nil {
+		return fmt.Errorf("diff_mode '%s' is invalid", cfg.DiffMode)
+	}
+	if !isPartialResultPolicy(cfg.PartialResultPolicy) {
+		return fmt.Errorf("partial_result_policy '%s' is invalid", cfg.PartialResultPolicy)
+	}
+	if cfg.Shipper.Index != strings.ToLower(cfg.Shipper.Index) {
+		return fmt.Errorf("log_shipper index '%s' should be lower case", cfg.Shipper.Index)
+	}
+	if !route.IsQueryCostPolicy(cfg.QueryCostPolicy) {
+		return fmt.Errorf("query_cost_policy '%s' is invalid", cfg.QueryCostPolicy)
+	}
+	if !isAuthzFailPolicy(cfg.Authz.FailPolicy) {
+		return fmt.Errorf("authz fail_policy '%s' is invalid", cfg.Authz.FailPolicy)
+	}
+	if cfg.ExportPort > 0 && len(cfg.AdminUser) == 0 {
+		return fmt.Errorf("export_port requires admin_user")
+	}
+	if len(cfg.Charset) != 0 {
+		if _, ok := mysql.CharsetIds[cfg.Charset]; !ok {
+			return errors.ErrInvalidCharset
+		}
+	}
+	return nil
+}
+
 func (p *Server) parseAllowIps() error {
 	atomic.StoreInt32(&p.allowipsIndex, 0)
 	cfg := p.cfg
@@ -299,33 +645,107 @@ func (p *Server) parseNodes() error {
 }
 
 func (p *Server) parseSchemas() error {
-	cfg := p.cfg
-	if len(cfg.Schemas) == 0 {
-		return errors.ErrNoSchema
+	schemas, err := p.parseSchemaConfigs(p.cfg.Schemas)
+	if err != nil {
+		return err
 	}
-	for _, schemaConfig := range cfg.Schemas {
+	p.rules.init(&ruleVersion{Version: 1, Schemas: schemas})
+	return nil
+}
+
+// parseSchemaConfigs validate schemas against data nodes, they're schemas of config file or staged shard rules.
+func (p *Server) parseSchemaConfigs(schemaConfigs []config.SchemaConfig) (map[string]*config.SchemaConfig, error) {
+	if len(schemaConfigs) == 0 {
+		return nil, errors.ErrNoSchema
+	}
+	schemas := make(map[string]*config.SchemaConfig)
+	for _, schemaConfig := range schemaConfigs {
 		schema := schemaConfig
-		if p.schemas[schema.Name] == nil {
+		if schemas[schema.Name] == nil {
 			if len(schema.Nodes) == 0 {
-				return fmt.Errorf("no data node in schema '%s'", schema.Name)
+				return nil, fmt.Errorf("no data node in schema '%s'", schema.Name)
 			}
 			for _, nodeInSchema := range schema.Nodes {
 				if p.nodes[nodeInSchema] == nil {
-					return fmt.Errorf("data node '%s' not exists", nodeInSchema)
+					return nil, fmt.Errorf("data node '%s' not exists", nodeInSchema)
+				}
+			}
+			if !route.IsShardAlgorithm(schema.ShardAlgo) {
+				return nil, fmt.Errorf("shard algorithm '%s' of schema '%s' is not supported", schema.ShardAlgo, schema.Name)
+			}
+			for _, name := range schema.ShardKeyNormalize {
+				if !route.IsShardKeyNormalize(name) {
+					return nil, fmt.Errorf("shard key normalize '%s' of schema '%s' is not supported", name, schema.Name)
+				}
+			}
+			for node, weight := range schema.NodeWeights {
+				if weight <= 0 || !utils.Contains(schema.Nodes, node) {
+					return nil, fmt.Errorf("weight %d of node '%s' in schema '%s' is invalid", weight, node, schema.Name)
 				}
 			}
-			p.schemas[schema.Name] = &schema
+			if schema.MinHealthyNodes < 0 || schema.MinHealthyNodes > len(schema.Nodes) {
+				return nil, fmt.Errorf("min healthy nodes %d of schema '%s' is invalid", schema.MinHealthyNodes, schema.Name)
+			}
+			for _, table := range schema.Tables {
+				if err := route.CheckShardKeyPolicy(&schema, &table); err != nil {
+					return nil, fmt.Errorf("shard key policy '%s' of table '%s' in schema '%s' is invalid: %v", table.ShardKeyPolicy, table.Name, schema.Name, err)
+				}
+				if table.SubShardKey != "" && !route.IsShardAlgorithm(table.SubShardAlgo) {
+					return nil, fmt.Errorf("sub shard algorithm '%s' of table '%s' in schema '%s' is not supported", table.SubShardAlgo, table.Name, schema.Name)
+				}
+				for _, node := range table.PinNodes {
+					if p.nodes[node] == nil {
+						return nil, fmt.Errorf("pinned data node '%s' of table '%s' in schema '%s' not exists", node, table.Name, schema.Name)
+					}
+				}
+			}
+			schemas[schema.Name] = &schema
 		}
 	}
-	return nil
+	return schemas, nil
+}
+
+// replicaLag get max measured lag in seconds of slaves of node, false if no slave or any lag is unknown.
+// If addr isn't empty, it's lag of the slave or analytics replica of node by addr.
+func (p *Server) replicaLag(node, addr string) (int64, bool) {
+	dataNode := p.nodes[node]
+	if dataNode == nil {
+		return 0, false
+	}
+	if len(addr) > 0 {
+		for _, replicas := range [][]*backend.DBHost{dataNode.DataHost.Slaves, dataNode.DataHost.Analytics} {
+			for _, replica := range replicas {
+				if replica.Addr == addr {
+					lag := replica.GetLag()
+					return lag, lag >= 0
+				}
+			}
+		}
+		return 0, false
+	}
+	if len(dataNode.DataHost.Slaves) == 0 {
+		return 0, false
+	}
+	var maxLag int64
+	for _, slave := range dataNode.DataHost.Slaves {
+		lag := slave.GetLag()
+		if lag < 0 {
+			return 0, false
+		}
+		if lag > maxLag {
+			maxLag = lag
+		}
+	}
+	return maxLag, true
 }
 
 func (p *Server) getSchemasByUser(user string) map[string]*config.SchemaConfig {
 	schemas := make(map[string]*config.SchemaConfig)
-	for _, schema := range p.schemas {
+	for _, schema := range p.getSchemas() {
 		if schema.User == strings.ToLower(user) {
 			schemas[schema.Name] = schema
 		}
 	}
+	p.addProxyUserSchemas(user, schemas)
 	return schemas
 }
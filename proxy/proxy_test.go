@@ -0,0 +1,60 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/berkaroad/saashard/config"
+)
+
+func TestIsDuplicateSession(t *testing.T) {
+	newConn := func(user, program string) *ClientConn {
+		c := &ClientConn{user: user}
+		if len(program) > 0 {
+			c.attrs = map[string]string{"program_name": program}
+		}
+		return c
+	}
+	cases := []struct {
+		attribute string
+		previous  *ClientConn
+		conn      *ClientConn
+		want      bool
+	}{
+		{"", newConn("migrator", "a"), newConn("migrator", "b"), true},
+		{"", newConn("migrator", ""), newConn("other", ""), false},
+		{"program_name", newConn("migrator", "a"), newConn("migrator", "a"), true},
+		{"program_name", newConn("migrator", "a"), newConn("migrator", "b"), false},
+		{"program_name", newConn("migrator", ""), newConn("migrator", ""), true},
+		{"program_name", newConn("migrator", "a"), newConn("migrator", ""), false},
+		{"program_name", newConn("other", "a"), newConn("migrator", "a"), false},
+		{"", &ClientConn{user: "migrator", probe: true}, newConn("migrator", ""), false},
+	}
+	for i, c := range cases {
+		p := &Server{cfg: &config.Config{SingletonUsers: []string{"migrator"}, SingletonAttribute: c.attribute}}
+		if got := p.isDuplicateSession(c.previous, c.conn); got != c.want {
+			t.Errorf("case %d: isDuplicateSession() = %v, want %v", i, got, c.want)
+		}
+	}
+}
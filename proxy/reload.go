@@ -0,0 +1,83 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package proxy
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// Reload apply changed options from old to new config, such as config of mounted ConfigMap or Secret is updated.
+// Options of runtime variables, allow_ips and certificates are applied, others need restart.
+func (p *Server) Reload(old, cfg *config.Config) {
+	apply := func(name string, changed bool, value string) {
+		if !changed {
+			return
+		}
+		oldValue := variables[name].get(p)
+		if err := variables[name].set(p, value); err != nil {
+			simplelog.Error("%s %s %s name=%s,value=%s,err=%s", "proxy", "Reload", "Invalid option", name, value, err.Error())
+			return
+		}
+		p.Audit("", "", AuditActionConfig, name, oldValue, variables[name].get(p))
+	}
+	apply("saashard_log_level", old.LogLevel != cfg.LogLevel && len(cfg.LogLevel) > 0, cfg.LogLevel)
+	apply("saashard_log_sql", old.LogSQL != cfg.LogSQL, cfg.LogSQL)
+	apply("saashard_slow_log_time", old.SlowLogTime != cfg.SlowLogTime, strconv.Itoa(cfg.SlowLogTime))
+	apply("saashard_max_fanout", old.MaxFanout != cfg.MaxFanout, strconv.Itoa(cfg.MaxFanout))
+	apply("saashard_memory_budget", old.MemoryBudget != cfg.MemoryBudget, strconv.Itoa(cfg.MemoryBudget))
+	apply("saashard_diff_mode", old.DiffMode != cfg.DiffMode, cfg.DiffMode)
+	apply("saashard_shadow_parser", old.ShadowParser != cfg.ShadowParser, cfg.ShadowParser)
+	if strings.Join(old.AllowIps, ",") != strings.Join(cfg.AllowIps, ",") {
+		p.setAllowIps(cfg.AllowIps)
+		p.Audit("", "", AuditActionConfig, "allow_ips", strings.Join(old.AllowIps, ","), strings.Join(cfg.AllowIps, ","))
+	}
+	// certificate files of Secret may be rotated in place.
+	if p.certs != nil && old.TLSCert == cfg.TLSCert && old.TLSKey == cfg.TLSKey && old.TLSCA == cfg.TLSCA {
+		if err := p.certs.Reload(); err != nil {
+			simplelog.Error("%s %s %s", "proxy", "Reload", err.Error())
+		}
+	}
+
+	rest := *cfg
+	rest.LogLevel, rest.LogSQL, rest.SlowLogTime = old.LogLevel, old.LogSQL, old.SlowLogTime
+	rest.MaxFanout, rest.MemoryBudget, rest.DiffMode, rest.AllowIps = old.MaxFanout, old.MemoryBudget, old.DiffMode, old.AllowIps
+	rest.ShadowParser = old.ShadowParser
+	if !config.Equal(old, &rest) {
+		simplelog.Warn("%s %s %s", "proxy", "Reload", "Config is changed, restart to apply options except runtime variables, allow_ips and certificates")
+	}
+}
+
+// setAllowIps replace ip whitelist, empty is to allow all.
+func (p *Server) setAllowIps(allowIps []string) {
+	next := 1 - atomic.LoadInt32(&p.allowipsIndex)
+	p.allowips[next] = make([]net.IP, 0, len(allowIps))
+	for _, ip := range allowIps {
+		p.allowips[next] = append(p.allowips[next], net.ParseIP(strings.TrimSpace(ip)))
+	}
+	atomic.StoreInt32(&p.allowipsIndex, next)
+}
@@ -0,0 +1,96 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/route"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+const (
+	routeOverrideSeparator = ";"
+	routeOverrideArrow     = "=>"
+	routeOverrideDefault   = "default"
+)
+
+// getRouteOverrides return current routing overrides, it shouldn't be modified.
+func (p *Server) getRouteOverrides() map[string]string {
+	return p.overrides[atomic.LoadInt32(&p.overridesIndex)]
+}
+
+// setRouteOverrides apply entries such as 'select * from t where a = ? => slave; ...',
+// target 'default' or empty removes the override.
+func (p *Server) setRouteOverrides(value string) error {
+	overrides := make(map[string]string)
+	for fingerprint, target := range p.getRouteOverrides() {
+		overrides[fingerprint] = target
+	}
+	for _, entry := range strings.Split(value, routeOverrideSeparator) {
+		if len(strings.TrimSpace(entry)) == 0 {
+			continue
+		}
+		arrowIndex := strings.LastIndex(entry, routeOverrideArrow)
+		if arrowIndex < 0 {
+			return errors.ErrInvalidArgument
+		}
+		fingerprint := normalizeFingerprint(entry[:arrowIndex])
+		target := strings.ToLower(strings.TrimSpace(entry[arrowIndex+len(routeOverrideArrow):]))
+		if len(fingerprint) == 0 {
+			return errors.ErrInvalidArgument
+		}
+		switch target {
+		case route.OverrideMaster, route.OverrideSlave, route.OverrideAnalytics:
+			overrides[fingerprint] = target
+		case "", routeOverrideDefault:
+			delete(overrides, fingerprint)
+		default:
+			return errors.ErrInvalidArgument
+		}
+	}
+	next := 1 - atomic.LoadInt32(&p.overridesIndex)
+	p.overrides[next] = overrides
+	atomic.StoreInt32(&p.overridesIndex, next)
+	return nil
+}
+
+// normalizeFingerprint accept fingerprint or sql with literals.
+func normalizeFingerprint(sql string) string {
+	if stmt, err := sqlparser.Parse(sql); err == nil {
+		return sqlparser.Fingerprint(stmt)
+	}
+	return strings.ToLower(strings.Join(strings.Fields(sql), " "))
+}
+
+func formatRouteOverrides(overrides map[string]string) string {
+	entries := make([]string, 0, len(overrides))
+	for fingerprint, target := range overrides {
+		entries = append(entries, fingerprint+" "+routeOverrideArrow+" "+target)
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, routeOverrideSeparator+" ")
+}
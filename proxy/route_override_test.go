@@ -0,0 +1,69 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/route"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+func TestSetRouteOverrides(t *testing.T) {
+	p := new(Server)
+	if err := p.setRouteOverrides("select a from t where b = 1 => analytics; select a from t => slave"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.setRouteOverrides("select a from t => replica"); err == nil {
+		t.Errorf("setRouteOverrides() of unknown target should be error")
+	}
+
+	schemas := map[string]*config.SchemaConfig{"db": {Name: "db", Nodes: []string{"n1"}}}
+	nodes := map[string]*config.NodeConfig{"n1": {Name: "n1"}}
+	cases := []struct {
+		sql         string
+		onSlave     bool
+		onAnalytics bool
+	}{
+		{"select a from t where b = 2", true, true},
+		{"select a from t", true, false},
+		{"select a from t where c = 2", true, false},
+	}
+	for _, c := range cases {
+		statement, err := sqlparser.Parse(c.sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		router := route.NewRouter("db", schemas, nodes, 1, "u", false)
+		router.Overrides = p.getRouteOverrides()
+		plan, err := router.BuildNormalPlan(statement)
+		if err != nil {
+			t.Fatalf("BuildNormalPlan(%q) error: %v", c.sql, err)
+		}
+		if plan.OnSlave() != c.onSlave || plan.OnAnalytics() != c.onAnalytics {
+			t.Errorf("BuildNormalPlan(%q) onSlave=%v,onAnalytics=%v, want %v,%v", c.sql,
+				plan.OnSlave(), plan.OnAnalytics(), c.onSlave, c.onAnalytics)
+		}
+	}
+}
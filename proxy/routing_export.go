@@ -0,0 +1,328 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/berkaroad/saashard/config"
+)
+
+// RoutingExport is the effective routing of active shard rules and live backends, exported by 'EXPORT ROUTING'.
+type RoutingExport struct {
+	RuleVersion int                `json:"rule_version"`
+	Policies    RoutingPolicies    `json:"policies"`
+	Hosts       []*RoutingHost     `json:"hosts"`
+	Nodes       []*RoutingNode     `json:"nodes"`
+	Schemas     []*RoutingSchema   `json:"schemas"`
+	Overrides   []*RoutingOverride `json:"overrides"`
+}
+
+// RoutingPolicies are policies that affect routing of statements.
+type RoutingPolicies struct {
+	MaxFanout           int    `json:"max_fanout"` // 0 is unlimited.
+	PartialResultPolicy string `json:"partial_result_policy"`
+	MaxQueryCost        int    `json:"max_query_cost"`
+	QueryCostPolicy     string `json:"query_cost_policy"`
+	AnalyticsCost       int    `json:"analytics_cost"`
+}
+
+// RoutingHost is a data host with master and replicas.
+type RoutingHost struct {
+	Name      string   `json:"name"`
+	Master    string   `json:"master"`
+	Slaves    []string `json:"slaves"`
+	Analytics []string `json:"analytics"`
+}
+
+// RoutingNode is a data node, database of data host.
+type RoutingNode struct {
+	Name     string `json:"name"`
+	Host     string `json:"host"`
+	Database string `json:"database"`
+}
+
+// RoutingSchema is a schema with shard rule, node groups and tables.
+type RoutingSchema struct {
+	Name              string              `json:"name"`
+	ShardKey          string              `json:"shard_key"` // empty is unsharded, on first node.
+	ShardAlgo         string              `json:"shard_algo"`
+	ShardHashSeed     uint32              `json:"shard_hash_seed"`
+	ShardKeyCollation string              `json:"shard_key_collation"`
+	ShardKeyNormalize []string            `json:"shard_key_normalize"`
+	Nodes             []string            `json:"nodes"`
+	NodeWeights       map[string]int      `json:"node_weights"`
+	MinHealthyNodes   int                 `json:"min_healthy_nodes"`
+	MaxRowCount       int                 `json:"max_row_count"`
+	NodeGroups        []*RoutingNodeGroup `json:"node_groups"`
+	Tables            []*RoutingTable     `json:"tables"`
+}
+
+// RoutingNodeGroup is nodes that tables are bound to, tables of the same group could be joined.
+// Default group is nodes of schema, other tables that aren't configured are in it too.
+type RoutingNodeGroup struct {
+	Nodes   []string `json:"nodes"`
+	Default bool     `json:"default"`
+	Tables  []string `json:"tables"`
+}
+
+// RoutingTable is a configured table with its effective nodes and shard key.
+type RoutingTable struct {
+	Name           string   `json:"name"`
+	Kind           string   `json:"kind"` // sharded, pinned or unsharded.
+	Nodes          []string `json:"nodes"`
+	ShardKey       string   `json:"shard_key"`
+	ShardKeyPolicy string   `json:"shard_key_policy"`
+	DefaultNode    string   `json:"default_node"`
+	ShardKeyDerive string   `json:"shard_key_derive"`
+	SubShardKey    string   `json:"sub_shard_key"`
+	SubShardAlgo   string   `json:"sub_shard_algo"`
+	SubShardCount  int      `json:"sub_shard_count"`
+}
+
+// RoutingTable.Kind
+const (
+	RoutingTableSharded   = "sharded"
+	RoutingTablePinned    = "pinned" // pinned to single node by pin_nodes.
+	RoutingTableUnsharded = "unsharded"
+)
+
+// RoutingOverride is a routing override of fingerprint.
+type RoutingOverride struct {
+	Fingerprint string `json:"fingerprint"`
+	Target      string `json:"target"`
+}
+
+// ExportRouting export effective routing from in-memory state, order by name.
+func (p *Server) ExportRouting() *RoutingExport {
+	export := &RoutingExport{
+		RuleVersion: p.getRuleVersion(),
+		Policies: RoutingPolicies{
+			MaxFanout:           int(atomic.LoadInt32(&p.maxFanout)),
+			PartialResultPolicy: p.cfg.PartialResultPolicy,
+			MaxQueryCost:        p.cfg.MaxQueryCost,
+			QueryCostPolicy:     p.cfg.QueryCostPolicy,
+			AnalyticsCost:       p.cfg.AnalyticsCost,
+		},
+		Hosts:     make([]*RoutingHost, 0, len(p.hosts)),
+		Nodes:     make([]*RoutingNode, 0, len(p.nodes)),
+		Schemas:   make([]*RoutingSchema, 0),
+		Overrides: make([]*RoutingOverride, 0),
+	}
+	for name, host := range p.hosts {
+		item := &RoutingHost{Name: name, Slaves: make([]string, 0), Analytics: make([]string, 0)}
+		if host.Master != nil {
+			item.Master = host.Master.Addr
+		}
+		for _, slave := range host.Slaves {
+			item.Slaves = append(item.Slaves, slave.Addr)
+		}
+		for _, analytics := range host.Analytics {
+			item.Analytics = append(item.Analytics, analytics.Addr)
+		}
+		export.Hosts = append(export.Hosts, item)
+	}
+	sort.Slice(export.Hosts, func(i, j int) bool { return export.Hosts[i].Name < export.Hosts[j].Name })
+	for name, node := range p.nodes {
+		item := &RoutingNode{Name: name, Database: node.Database}
+		if node.DataHost != nil {
+			item.Host = node.DataHost.Name
+		}
+		export.Nodes = append(export.Nodes, item)
+	}
+	sort.Slice(export.Nodes, func(i, j int) bool { return export.Nodes[i].Name < export.Nodes[j].Name })
+	for _, schema := range p.getSchemas() {
+		export.Schemas = append(export.Schemas, newRoutingSchema(schema))
+	}
+	sort.Slice(export.Schemas, func(i, j int) bool { return export.Schemas[i].Name < export.Schemas[j].Name })
+	for fingerprint, target := range p.getRouteOverrides() {
+		export.Overrides = append(export.Overrides, &RoutingOverride{fingerprint, target})
+	}
+	sort.Slice(export.Overrides, func(i, j int) bool { return export.Overrides[i].Fingerprint < export.Overrides[j].Fingerprint })
+	return export
+}
+
+func newRoutingSchema(schema *config.SchemaConfig) *RoutingSchema {
+	defaultNodes := append(make([]string, 0), schema.Nodes...)
+	if !schema.ShardEnabled() && len(defaultNodes) > 1 {
+		// unsharded schema is on first node.
+		defaultNodes = defaultNodes[:1]
+	}
+	item := &RoutingSchema{
+		Name:              schema.Name,
+		ShardKey:          schema.ShardKey,
+		ShardAlgo:         schema.ShardAlgo,
+		ShardHashSeed:     schema.ShardHashSeed,
+		ShardKeyCollation: schema.ShardKeyCollation,
+		ShardKeyNormalize: append(make([]string, 0), schema.ShardKeyNormalize...),
+		Nodes:             append(make([]string, 0), schema.Nodes...),
+		NodeWeights:       make(map[string]int),
+		MinHealthyNodes:   schema.MinHealthyNodes,
+		MaxRowCount:       schema.MaxRowCount,
+		NodeGroups:        []*RoutingNodeGroup{{Nodes: defaultNodes, Default: true, Tables: make([]string, 0)}},
+		Tables:            make([]*RoutingTable, 0, len(schema.Tables)),
+	}
+	for _, node := range schema.Nodes {
+		item.NodeWeights[node] = schema.NodeWeight(node)
+	}
+	groups := map[string]*RoutingNodeGroup{strings.Join(defaultNodes, ","): item.NodeGroups[0]}
+	names := make([]string, 0, len(schema.GetTables()))
+	for name := range schema.GetTables() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		tableConfig := schema.GetTables()[name]
+		table := &RoutingTable{
+			Name:           name,
+			Kind:           RoutingTableSharded,
+			Nodes:          defaultNodes,
+			ShardKey:       schema.ShardKey,
+			ShardKeyPolicy: tableConfig.ShardKeyPolicy,
+			DefaultNode:    tableConfig.DefaultNode,
+			ShardKeyDerive: tableConfig.ShardKeyDerive,
+			SubShardKey:    tableConfig.SubShardKey,
+			SubShardAlgo:   tableConfig.SubShardAlgo,
+			SubShardCount:  tableConfig.SubShardCount,
+		}
+		if len(tableConfig.PinNodes) > 0 {
+			table.Nodes = append(make([]string, 0), tableConfig.PinNodes...)
+			if len(tableConfig.PinNodes) == 1 {
+				// same as pinned router, no shard key is needed in single node.
+				table.Kind, table.ShardKey = RoutingTablePinned, ""
+			}
+		}
+		if !schema.ShardEnabled() {
+			table.Kind = RoutingTableUnsharded
+		}
+		key := strings.Join(table.Nodes, ",")
+		group := groups[key]
+		if group == nil {
+			group = &RoutingNodeGroup{Nodes: table.Nodes, Tables: make([]string, 0)}
+			groups[key] = group
+			item.NodeGroups = append(item.NodeGroups, group)
+		}
+		group.Tables = append(group.Tables, name)
+		item.Tables = append(item.Tables, table)
+	}
+	return item
+}
+
+// Markdown of routing export, for human reading.
+func (export *RoutingExport) Markdown() string {
+	var buf bytes.Buffer
+	buf.WriteString("# Routing\n\n")
+	fmt.Fprintf(&buf, "Rule version: %d\n\n", export.RuleVersion)
+
+	buf.WriteString("## Policies\n\n")
+	writeMarkdownTable(&buf, []string{"Policy", "Value"}, [][]string{
+		{"max_fanout", strconv.Itoa(export.Policies.MaxFanout)},
+		{"partial_result_policy", export.Policies.PartialResultPolicy},
+		{"max_query_cost", strconv.Itoa(export.Policies.MaxQueryCost)},
+		{"query_cost_policy", export.Policies.QueryCostPolicy},
+		{"analytics_cost", strconv.Itoa(export.Policies.AnalyticsCost)},
+	})
+
+	buf.WriteString("## Hosts\n\n")
+	rows := make([][]string, 0, len(export.Hosts))
+	for _, host := range export.Hosts {
+		rows = append(rows, []string{host.Name, host.Master, strings.Join(host.Slaves, ", "), strings.Join(host.Analytics, ", ")})
+	}
+	writeMarkdownTable(&buf, []string{"Host", "Master", "Slaves", "Analytics"}, rows)
+
+	buf.WriteString("## Nodes\n\n")
+	rows = make([][]string, 0, len(export.Nodes))
+	for _, node := range export.Nodes {
+		rows = append(rows, []string{node.Name, node.Host, node.Database})
+	}
+	writeMarkdownTable(&buf, []string{"Node", "Host", "Database"}, rows)
+
+	for _, schema := range export.Schemas {
+		fmt.Fprintf(&buf, "## Schema %s\n\n", schema.Name)
+		rows = [][]string{
+			{"shard_key", schema.ShardKey},
+			{"shard_algo", schema.ShardAlgo},
+			{"shard_hash_seed", strconv.FormatUint(uint64(schema.ShardHashSeed), 10)},
+			{"shard_key_collation", schema.ShardKeyCollation},
+			{"shard_key_normalize", strings.Join(schema.ShardKeyNormalize, ", ")},
+			{"min_healthy_nodes", strconv.Itoa(schema.MinHealthyNodes)},
+			{"max_row_count", strconv.Itoa(schema.MaxRowCount)},
+		}
+		writeMarkdownTable(&buf, []string{"Setting", "Value"}, rows)
+
+		buf.WriteString("### Node groups\n\n")
+		rows = make([][]string, 0, len(schema.NodeGroups))
+		for _, group := range schema.NodeGroups {
+			nodes := make([]string, 0, len(group.Nodes))
+			for _, node := range group.Nodes {
+				nodes = append(nodes, fmt.Sprintf("%s (%d)", node, schema.NodeWeights[node]))
+			}
+			tables := strings.Join(group.Tables, ", ")
+			if group.Default {
+				tables = strings.TrimPrefix(tables+", (other tables)", ", ")
+			}
+			rows = append(rows, []string{strings.Join(nodes, ", "), tables})
+		}
+		writeMarkdownTable(&buf, []string{"Nodes (weight)", "Tables"}, rows)
+
+		buf.WriteString("### Tables\n\n")
+		rows = make([][]string, 0, len(schema.Tables))
+		for _, table := range schema.Tables {
+			subShard := ""
+			if table.SubShardKey != "" {
+				subShard = fmt.Sprintf("%s %s %d", table.SubShardKey, table.SubShardAlgo, table.SubShardCount)
+			}
+			rows = append(rows, []string{table.Name, table.Kind, strings.Join(table.Nodes, ", "), table.ShardKey,
+				table.ShardKeyPolicy, table.DefaultNode, table.ShardKeyDerive, subShard})
+		}
+		writeMarkdownTable(&buf, []string{"Table", "Kind", "Nodes", "Shard key", "Shard key policy", "Default node", "Shard key derive", "Sub shard"}, rows)
+	}
+
+	buf.WriteString("## Routing overrides\n\n")
+	rows = make([][]string, 0, len(export.Overrides))
+	for _, override := range export.Overrides {
+		rows = append(rows, []string{override.Fingerprint, override.Target})
+	}
+	writeMarkdownTable(&buf, []string{"Fingerprint", "Target"}, rows)
+	return buf.String()
+}
+
+// writeMarkdownTable write header and rows, '|' and new line of cell are escaped.
+func writeMarkdownTable(buf *bytes.Buffer, header []string, rows [][]string) {
+	escaper := strings.NewReplacer("|", "\\|", "\r\n", " ", "\n", " ")
+	buf.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	buf.WriteString(strings.Repeat("| --- ", len(header)) + "|\n")
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = escaper.Replace(cell)
+		}
+		buf.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	buf.WriteString("\n")
+}
@@ -0,0 +1,82 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+// sessionRuleVersion is session variable that declares expected version of shard rules,
+// such as 'SET saashard_rule_version = 3', 'current' is version of session now, and 0 is any version.
+const sessionRuleVersion = "saashard_rule_version"
+
+// regRuleVersionHint is hint of expected rule version of a statement, such as /*!saashard rule_version=3 */.
+var regRuleVersionHint = regexp.MustCompile(`/\*(?:!saashard |\s*saashard:)\s*rule_version\s*=\s*(\d+)\s*\*/`)
+
+// isSetRuleVersion is true if it only sets expected rule version of session.
+func isSetRuleVersion(stmt sqlparser.Statement) bool {
+	v, ok := stmt.(*sqlparser.SetVariable)
+	return ok && len(v.Exprs) == 1 && !v.Exprs[0].User && !v.Exprs[0].IsGlobal(v.Scope) &&
+		v.Exprs[0].VarName() == sessionRuleVersion
+}
+
+// handleSetRuleVersion set expected rule version of session, it's answered by proxy.
+func (c *ClientConn) handleSetRuleVersion(stmt *sqlparser.SetVariable) error {
+	value := sqlparser.String(stmt.Exprs[0].Expr)
+	switch stmt.Exprs[0].Expr.(type) {
+	case sqlparser.NumVal:
+		version, err := strconv.Atoi(value)
+		if err != nil || version < 0 {
+			return mysql.NewDefaultError(mysql.ER_WRONG_VALUE_FOR_VAR, sessionRuleVersion, value)
+		}
+		c.expectRuleVersion = version
+	case sqlparser.StrVal, *sqlparser.ColName:
+		if !strings.EqualFold(strings.Trim(value, "'"), "current") {
+			return mysql.NewDefaultError(mysql.ER_WRONG_VALUE_FOR_VAR, sessionRuleVersion, value)
+		}
+		c.expectRuleVersion = c.ruleVersion
+	default:
+		return mysql.NewDefaultError(mysql.ER_WRONG_VALUE_FOR_VAR, sessionRuleVersion, value)
+	}
+	return c.pkg.WriteOK(c.capability, c.status, nil)
+}
+
+// checkRuleVersion check version of shard rules that session uses, against version expected by hint of sql or session.
+// Session keeps its version in transaction, and it's refreshed after, so that routing doesn't flip mid-transaction.
+func (c *ClientConn) checkRuleVersion(sql string) error {
+	expected := c.expectRuleVersion
+	if match := regRuleVersionHint.FindStringSubmatch(sql); match != nil {
+		expected, _ = strconv.Atoi(match[1])
+	}
+	if expected == 0 || expected == c.ruleVersion {
+		return nil
+	}
+	return mysql.NewError(mysql.ER_UNKNOWN_ERROR,
+		fmt.Sprintf("rule version changed, %d is expected, but session is of version %d", expected, c.ruleVersion))
+}
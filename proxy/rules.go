@@ -0,0 +1,281 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/route"
+	"github.com/berkaroad/saashard/utils/simplelog"
+	"github.com/go-yaml/yaml"
+)
+
+// ruleSnapshotName is the name of shard rules snapshot in runtime state file.
+const ruleSnapshotName = "saashard_rules"
+
+// ruleVersion is a version of shard rules, version 1 is schemas of config file, others are staged from file by admin.
+type ruleVersion struct {
+	Version int
+	File    string
+	Schemas map[string]*config.SchemaConfig
+}
+
+// shardRules are active, previous and staged versions of shard rules.
+// Active version is swapped atomically, previous version is retained for rollback.
+type shardRules struct {
+	sync.Mutex
+	index    int32
+	active   [2]*ruleVersion
+	previous *ruleVersion
+	staged   *ruleVersion
+	last     int // last version number
+}
+
+// ruleSnapshot is a version of shard rules saved in runtime state file.
+type ruleSnapshot struct {
+	Version int                   `yaml:"version"`
+	File    string                `yaml:"file"`
+	State   string                `yaml:"state"` // active, previous or staged.
+	Schemas []config.SchemaConfig `yaml:"schemas"`
+}
+
+func (rules *shardRules) init(version *ruleVersion) {
+	rules.active[0] = version
+	rules.last = version.Version
+	atomic.StoreInt32(&rules.index, 0)
+}
+
+func (rules *shardRules) getActive() *ruleVersion {
+	return rules.active[atomic.LoadInt32(&rules.index)]
+}
+
+// setActive swap active version, caller should hold the lock.
+func (rules *shardRules) setActive(version *ruleVersion) {
+	next := 1 - atomic.LoadInt32(&rules.index)
+	rules.active[next] = version
+	atomic.StoreInt32(&rules.index, next)
+}
+
+// getSchemas of active shard rules.
+func (p *Server) getSchemas() map[string]*config.SchemaConfig {
+	if active := p.rules.getActive(); active != nil {
+		return active.Schemas
+	}
+	return nil
+}
+
+// getRuleVersion of active shard rules.
+func (p *Server) getRuleVersion() int {
+	if active := p.rules.getActive(); active != nil {
+		return active.Version
+	}
+	return 0
+}
+
+// stageRules parse and validate schemas of file as a new version, it's kept inactive until activated.
+// Empty file is to discard staged version.
+func (p *Server) stageRules(file string) error {
+	p.rules.Lock()
+	defer p.rules.Unlock()
+	if len(file) == 0 {
+		p.rules.staged = nil
+		return nil
+	}
+	cfg, err := config.ParseConfigFile(file)
+	if err != nil {
+		simplelog.Error("%s %s %s file=%s", "proxy", "stageRules", err.Error(), file)
+		return err
+	}
+	version, err := p.newRuleVersion(p.rules.last+1, file, cfg.Schemas)
+	if err != nil {
+		simplelog.Error("%s %s %s file=%s", "proxy", "stageRules", err.Error(), file)
+		return err
+	}
+	p.rules.last = version.Version
+	p.rules.staged = version
+	return nil
+}
+
+// newRuleVersion validate schemas against data nodes and active version, schemas and their users couldn't be changed.
+func (p *Server) newRuleVersion(number int, file string, schemaConfigs []config.SchemaConfig) (*ruleVersion, error) {
+	schemas, err := p.parseSchemaConfigs(schemaConfigs)
+	if err != nil {
+		return nil, err
+	}
+	active := p.getSchemas()
+	if len(schemas) != len(active) {
+		return nil, fmt.Errorf("schemas of shard rules version %d are different from active version", number)
+	}
+	for name, schema := range active {
+		if schemas[name] == nil || schemas[name].User != schema.User {
+			return nil, fmt.Errorf("schema '%s' of shard rules version %d is different from active version", name, number)
+		}
+	}
+	return &ruleVersion{Version: number, File: file, Schemas: schemas}, nil
+}
+
+// activateRules activate staged version ('staged' or its number),
+// or roll back to previous version ('previous' or its number), active version becomes previous.
+func (p *Server) activateRules(value string) error {
+	p.rules.Lock()
+	defer p.rules.Unlock()
+	active := p.rules.getActive()
+	target := strings.ToLower(value)
+	switch {
+	case p.rules.staged != nil && (target == "staged" || target == strconv.Itoa(p.rules.staged.Version)):
+		p.rules.setActive(p.rules.staged)
+		p.rules.staged = nil
+	case p.rules.previous != nil && (target == "previous" || target == strconv.Itoa(p.rules.previous.Version)):
+		p.rules.setActive(p.rules.previous)
+	case active != nil && target == strconv.Itoa(active.Version):
+		return nil
+	default:
+		return errors.ErrInvalidArgument
+	}
+	p.rules.previous = active
+	simplelog.Info("%s %s %s version=%d,previous=%d", "proxy", "activateRules", "Shard rules are activated",
+		p.rules.getActive().Version, active.Version)
+	return nil
+}
+
+// GetRuleVersions get retained versions of shard rules, value is state and file.
+func (p *Server) GetRuleVersions() ([]string, []string) {
+	p.rules.Lock()
+	defer p.rules.Unlock()
+	var names, values []string
+	for _, item := range p.rules.retained() {
+		names = append(names, strconv.Itoa(item.version.Version))
+		values = append(values, strings.TrimSpace(item.state+" "+item.version.File))
+	}
+	return names, values
+}
+
+// RuleMigration is shard keys of schema moved between nodes, when staged version is activated.
+type RuleMigration struct {
+	Schema string
+	route.ShardMove
+}
+
+// GetRuleMigrations get migration plan from active to staged version of shard rules, order by schema.
+func (p *Server) GetRuleMigrations() []RuleMigration {
+	p.rules.Lock()
+	defer p.rules.Unlock()
+	active := p.rules.getActive()
+	if active == nil || p.rules.staged == nil {
+		return nil
+	}
+	names := make([]string, 0, len(p.rules.staged.Schemas))
+	for name := range p.rules.staged.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var migrations []RuleMigration
+	for _, name := range names {
+		from, ok := active.Schemas[name]
+		to := p.rules.staged.Schemas[name]
+		if !ok || !from.ShardEnabled() || !to.ShardEnabled() {
+			continue
+		}
+		for _, move := range route.ShardMoves(from, to) {
+			migrations = append(migrations, RuleMigration{name, move})
+		}
+	}
+	return migrations
+}
+
+type retainedRuleVersion struct {
+	state   string
+	version *ruleVersion
+}
+
+// retained versions order by version number, caller should hold the lock.
+func (rules *shardRules) retained() []retainedRuleVersion {
+	var versions []retainedRuleVersion
+	if active := rules.getActive(); active != nil {
+		versions = append(versions, retainedRuleVersion{"active", active})
+	}
+	if rules.previous != nil {
+		versions = append(versions, retainedRuleVersion{"previous", rules.previous})
+	}
+	if rules.staged != nil {
+		versions = append(versions, retainedRuleVersion{"staged", rules.staged})
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].version.Version < versions[j].version.Version })
+	return versions
+}
+
+// ruleSnapshot encode staged versions retained, version 1 is always from config file.
+func (p *Server) ruleSnapshot() (string, error) {
+	p.rules.Lock()
+	defer p.rules.Unlock()
+	var snapshots []ruleSnapshot
+	for _, item := range p.rules.retained() {
+		snapshot := ruleSnapshot{Version: item.version.Version, File: item.version.File, State: item.state}
+		if item.version.Version > 1 {
+			for _, schema := range item.version.Schemas {
+				snapshot.Schemas = append(snapshot.Schemas, *schema)
+			}
+			sort.Slice(snapshot.Schemas, func(i, j int) bool { return snapshot.Schemas[i].Name < snapshot.Schemas[j].Name })
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	data, err := yaml.Marshal(snapshots)
+	return string(data), err
+}
+
+// restoreRules restore retained versions from snapshot of runtime state file.
+func (p *Server) restoreRules(value string) error {
+	var snapshots []ruleSnapshot
+	if err := yaml.Unmarshal([]byte(value), &snapshots); err != nil {
+		return err
+	}
+	p.rules.Lock()
+	defer p.rules.Unlock()
+	configVersion := p.rules.getActive()
+	versions := make(map[string]*ruleVersion)
+	for _, snapshot := range snapshots {
+		version := configVersion
+		if snapshot.Version > 1 {
+			var err error
+			if version, err = p.newRuleVersion(snapshot.Version, snapshot.File, snapshot.Schemas); err != nil {
+				return err
+			}
+		}
+		versions[snapshot.State] = version
+		if snapshot.Version > p.rules.last {
+			p.rules.last = snapshot.Version
+		}
+	}
+	if versions["active"] != nil {
+		p.rules.setActive(versions["active"])
+	}
+	p.rules.previous, p.rules.staged = versions["previous"], versions["staged"]
+	return nil
+}
@@ -0,0 +1,98 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"strings"
+
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/sqlparser"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// getShadowParser get name of shadow parser, empty is off.
+func (p *Server) getShadowParser() string {
+	name, _ := p.shadowParser.Load().(string)
+	return name
+}
+
+// setShadowParser set name of registered shadow parser, empty or 'off' is off.
+func (p *Server) setShadowParser(name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "off" {
+		name = ""
+	}
+	if len(name) > 0 {
+		if _, ok := sqlparser.GetShadowParser(name); !ok {
+			return errors.ErrInvalidArgument
+		}
+	}
+	p.shadowParser.Store(name)
+	return nil
+}
+
+const shadowQueueSize = 1024
+
+// shadowTask is a statement to parse again by shadow parser.
+type shadowTask struct {
+	parser  string
+	sql     string
+	sqlMode sqlparser.SQLMode
+}
+
+func (p *Server) startShadow() {
+	p.shadows = make(chan *shadowTask, shadowQueueSize)
+	go p.runShadow()
+}
+
+// runShadow parse the sql by current parser and shadow parser, and log divergence.
+// Parsing by current parser again is deterministic, so that statement isn't shared with session, which may rewrite it.
+func (p *Server) runShadow() {
+	for task := range p.shadows {
+		parser, ok := sqlparser.GetShadowParser(task.parser)
+		if !ok {
+			continue
+		}
+		statement, err := sqlparser.ParseWithSQLMode(task.sql, task.sqlMode)
+		if reason := sqlparser.ShadowDiff(parser, task.sql, task.sqlMode, statement, err); len(reason) > 0 {
+			p.counter.IncrShadowDivergeTotal()
+			simplelog.Warn("%s %s %s shadowParser=%s,reason=%s,sql=%s", "proxy", "shadowParse", "Parse divergence",
+				task.parser, reason, task.sql)
+		}
+	}
+}
+
+// shadowParse queue the sql to parse again by shadow parser, and log divergence asynchronously.
+// It's a dry run to verify grammar of upgrade, client always get the result of parser.
+func (p *Server) shadowParse(sql string, sqlMode sqlparser.SQLMode) {
+	name := p.getShadowParser()
+	if len(name) == 0 {
+		return
+	}
+	p.counter.IncrShadowTotal()
+	select {
+	case p.shadows <- &shadowTask{parser: name, sql: sql, sqlMode: sqlMode}:
+	default:
+		simplelog.Warn("%s %s %s sql=%s", "proxy", "shadowParse", "shadow queue is full, sql isn't parsed", sql)
+	}
+}
@@ -0,0 +1,66 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/berkaroad/saashard/sqlparser"
+	"github.com/berkaroad/saashard/statistic"
+)
+
+func TestShadowParse(t *testing.T) {
+	sqlparser.RegisterShadowParser("test_upper", func(sql string, sqlMode sqlparser.SQLMode) (string, error) {
+		statement, err := sqlparser.ParseWithSQLMode(sql, sqlMode)
+		if err != nil {
+			return "", err
+		}
+		return strings.ToUpper(sqlparser.String(statement)), nil
+	})
+	p := &Server{counter: new(statistic.Counter)}
+	p.shadows = make(chan *shadowTask, 2)
+	p.shadowParse("select 1", 0)
+	if len(p.shadows) != 0 || p.counter.ShadowTotal != 0 {
+		t.Fatalf("shadow parser is off, but sql is queued")
+	}
+	if err := p.setShadowParser("Baseline"); err != nil {
+		t.Fatal(err)
+	}
+	p.shadowParse("select * from t where a = 'x'", 0)
+	if err := p.setShadowParser("test_upper"); err != nil {
+		t.Fatal(err)
+	}
+	p.shadowParse("select * from t where a = 'x'", 0)
+	p.shadowParse("select * from t where a = 'dropped'", 0)
+
+	// queue is full, the last sql is dropped without blocking.
+	if p.counter.ShadowTotal != 3 || len(p.shadows) != 2 {
+		t.Fatalf("shadow total = %d, queued = %d, expected 3 and 2", p.counter.ShadowTotal, len(p.shadows))
+	}
+	close(p.shadows)
+	p.runShadow()
+	if p.counter.ShadowDivergeTotal != 1 {
+		t.Errorf("shadow diverge total = %d, expected 1", p.counter.ShadowDivergeTotal)
+	}
+}
@@ -0,0 +1,279 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/route"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+const (
+	shipperKindAudit = "audit"
+	shipperKindSlow  = "slow"
+
+	shipperQueueSize         = 10000
+	shipperTimeout           = 10
+	defaultShipperIndex      = "saashard"
+	defaultShipperBatchSize  = 500
+	defaultShipperFlush      = 5
+	defaultShipperMaxBackoff = 60
+)
+
+// shippedLog is a json document to be indexed into daily index of its kind.
+type shippedLog struct {
+	kind string
+	time time.Time
+	doc  []byte
+}
+
+// logShipper batches audit and slow logs into elasticsearch or opensearch by bulk api,
+// logs are dropped when queue is full, such as elasticsearch is down for long.
+type logShipper struct {
+	url        string
+	index      string
+	user       string
+	password   string
+	batchSize  int
+	flush      time.Duration
+	maxBackoff time.Duration
+	client     *http.Client
+	templated  bool // index template is put.
+	logs       chan *shippedLog
+}
+
+func newLogShipper(cfg config.LogShipperConfig) *logShipper {
+	if len(cfg.URL) == 0 {
+		return nil
+	}
+	s := &logShipper{
+		url:        strings.TrimRight(cfg.URL, "/"),
+		index:      cfg.Index,
+		user:       cfg.User,
+		password:   cfg.Password,
+		batchSize:  cfg.BatchSize,
+		flush:      time.Duration(cfg.FlushInterval) * time.Second,
+		maxBackoff: time.Duration(cfg.MaxBackoff) * time.Second,
+		client:     &http.Client{Timeout: shipperTimeout * time.Second},
+		logs:       make(chan *shippedLog, shipperQueueSize),
+	}
+	if len(s.index) == 0 {
+		s.index = defaultShipperIndex
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = defaultShipperBatchSize
+	}
+	if s.flush <= 0 {
+		s.flush = defaultShipperFlush * time.Second
+	}
+	if s.maxBackoff <= 0 {
+		s.maxBackoff = defaultShipperMaxBackoff * time.Second
+	}
+	return s
+}
+
+func (p *Server) startLogShipper() {
+	p.shipper = newLogShipper(p.cfg.Shipper)
+	if p.shipper == nil {
+		return
+	}
+	go p.shipper.run()
+	route.OnSlowLog = func(log *route.SlowLog) {
+		p.shipper.ship(shipperKindSlow, log)
+	}
+}
+
+// ship queue log without blocking.
+func (s *logShipper) ship(kind string, log interface{}) {
+	doc, err := json.Marshal(log)
+	if err != nil {
+		return
+	}
+	select {
+	case s.logs <- &shippedLog{kind: kind, time: time.Now(), doc: doc}:
+	default:
+		simplelog.Warn("%s %s %s kind=%s", "proxy", "ship", "log shipper queue is full, log is dropped", kind)
+	}
+}
+
+// run send batch when it's full, or each flush interval.
+func (s *logShipper) run() {
+	ticker := time.NewTicker(s.flush)
+	defer ticker.Stop()
+	var batch []*shippedLog
+	for {
+		select {
+		case log := <-s.logs:
+			if batch = append(batch, log); len(batch) < s.batchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+		s.send(batch)
+		batch = nil
+	}
+}
+
+// send batch until it's accepted or rejected, retried with exponential backoff.
+func (s *logShipper) send(batch []*shippedLog) {
+	backoff := time.Second
+	for {
+		retry, err := s.bulk(batch)
+		if err == nil {
+			return
+		} else if !retry {
+			simplelog.Error("%s %s %s logs=%d", "proxy", "logShipper", err.Error(), len(batch))
+			return
+		}
+		simplelog.Warn("%s %s %s logs=%d,retry_after=%s", "proxy", "logShipper", err.Error(), len(batch), backoff)
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+	}
+}
+
+// bulk index batch, retry is false if it's rejected by elasticsearch, such as mapping conflict.
+func (s *logShipper) bulk(batch []*shippedLog) (retry bool, err error) {
+	if !s.templated {
+		if err = s.putTemplate(); err != nil && !isRejected(err) {
+			return true, err
+		} else if err != nil {
+			// such as index template api isn't supported, logs are still indexed by dynamic mapping.
+			simplelog.Warn("%s %s %s index=%s", "proxy", "putTemplate", err.Error(), s.index)
+		}
+		s.templated = true
+	}
+	var body bytes.Buffer
+	for _, log := range batch {
+		index := fmt.Sprintf("%s-%s-%s", s.index, log.kind, log.time.UTC().Format("2006.01.02"))
+		fmt.Fprintf(&body, "{\"index\":{\"_index\":\"%s\"}}\n", index)
+		body.Write(log.doc)
+		body.WriteByte('\n')
+	}
+	data, err := s.request("POST", "/_bulk", "application/x-ndjson", body.Bytes())
+	if err != nil {
+		return !isRejected(err), err
+	}
+	var resp struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err = json.Unmarshal(data, &resp); err != nil || !resp.Errors {
+		return false, nil
+	}
+	rejected := 0
+	var reason json.RawMessage
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Status >= 300 {
+				rejected++
+				reason = result.Error
+			}
+		}
+	}
+	return false, fmt.Errorf("%d logs are rejected, such as %s", rejected, reason)
+}
+
+// putTemplate of indices, so that time is date, sql is full text and other strings are keywords.
+func (s *logShipper) putTemplate() error {
+	template := map[string]interface{}{
+		"index_patterns": []string{s.index + "-*"},
+		"template": map[string]interface{}{
+			"mappings": map[string]interface{}{
+				"dynamic_templates": []interface{}{
+					map[string]interface{}{"strings": map[string]interface{}{
+						"match_mapping_type": "string",
+						"mapping":            map[string]interface{}{"type": "keyword", "ignore_above": 1024},
+					}},
+				},
+				"properties": map[string]interface{}{
+					"time":     map[string]string{"type": "date"},
+					"duration": map[string]string{"type": "double"},
+					"sql":      map[string]string{"type": "text"},
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+	_, err = s.request("PUT", "/_index_template/"+s.index, "application/json", data)
+	return err
+}
+
+// shipperStatusError is error status responded by elasticsearch.
+type shipperStatusError struct {
+	status int
+	body   []byte
+}
+
+func (e *shipperStatusError) Error() string {
+	return fmt.Sprintf("elasticsearch responds status %d: %s", e.status, e.body)
+}
+
+// isRejected is true if request is invalid, and retry won't succeed.
+func isRejected(err error) bool {
+	e, ok := err.(*shipperStatusError)
+	return ok && e.status >= 400 && e.status < 500 && e.status != http.StatusTooManyRequests &&
+		e.status != http.StatusUnauthorized && e.status != http.StatusForbidden
+}
+
+func (s *logShipper) request(method, path, contentType string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, s.url+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if len(s.user) > 0 {
+		req.SetBasicAuth(s.user, s.password)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		return nil, &shipperStatusError{status: resp.StatusCode, body: data}
+	}
+	return data, nil
+}
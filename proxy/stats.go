@@ -0,0 +1,328 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/berkaroad/saashard/backend"
+	mysqlBackend "github.com/berkaroad/saashard/backend/mysql"
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// Strategy advised for query filtered by a column, other than shard key.
+const (
+	StrategyShardKey    = "shard_key"    // routed to one node by shard key.
+	StrategyBroadcast   = "broadcast"    // table is small, fan out to all nodes.
+	StrategyGlobalIndex = "global_index" // column is selective, lookup nodes of its value by a global index.
+	StrategyProxyJoin   = "proxy_join"   // column is not selective, fetch rows of nodes and join at proxy.
+)
+
+const (
+	statsHistorySize = 12   // samples of cardinality kept.
+	broadcastRows    = 1000 // table not more than broadcastRows is broadcast.
+	globalIndexRows  = 10   // column matching not more than globalIndexRows per value is global indexed.
+)
+
+// cardinalityStats of leading index columns, sampled from backends periodically.
+// Cardinality of unique or shard key column is summed over nodes, since its values of nodes are disjoint.
+// Values of other columns may repeat across nodes, so max of nodes is taken, it's a lower bound of cardinality.
+type cardinalityStats struct {
+	sync.RWMutex
+	values     map[string]int64  // key is schema.table.column
+	rows       map[string]int64  // key is schema.table, table_rows summed over nodes, or max cardinality of its columns.
+	strategies map[string]string // key is schema.table.column
+	history    []statsSample     // oldest first, at most statsHistorySize.
+}
+
+type statsSample struct {
+	time   time.Time
+	values map[string]int64
+}
+
+// GetCardinality of column, return -1 if not sampled.
+func (p *Server) GetCardinality(schema, table, column string) int64 {
+	defer p.stats.RUnlock()
+
+	p.stats.RLock()
+	if v, ok := p.stats.values[strings.ToLower(schema+"."+table+"."+column)]; ok {
+		return v
+	}
+	return -1
+}
+
+// GetTableRows estimated by table_rows of information_schema summed over nodes, return -1 if not sampled.
+// If table_rows isn't sampled, it's max cardinality of leading index columns.
+func (p *Server) GetTableRows(schema, table string) int64 {
+	defer p.stats.RUnlock()
+
+	p.stats.RLock()
+	if v, ok := p.stats.rows[strings.ToLower(schema+"."+table)]; ok {
+		return v
+	}
+	return -1
+}
+
+// GetStrategy advised for query filtered by column, return "" if not sampled.
+func (p *Server) GetStrategy(schema, table, column string) string {
+	defer p.stats.RUnlock()
+
+	p.stats.RLock()
+	return p.stats.strategies[strings.ToLower(schema+"."+table+"."+column)]
+}
+
+// GetCardinalityNames return sampled schema.table.column names, and values of them.
+func (p *Server) GetCardinalityNames() ([]string, []int64) {
+	defer p.stats.RUnlock()
+
+	p.stats.RLock()
+	names := make([]string, 0, len(p.stats.values))
+	for name := range p.stats.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := make([]int64, len(names))
+	for i, name := range names {
+		values[i] = p.stats.values[name]
+	}
+	return names, values
+}
+
+// GetStrategyNames return sampled schema.table.column names, advised strategy and history of cardinality.
+// History is cardinality of samples separated by ',', oldest first, '-' if column is not sampled at that time.
+func (p *Server) GetStrategyNames() ([]string, []string, []string) {
+	names, _ := p.GetCardinalityNames()
+
+	defer p.stats.RUnlock()
+
+	p.stats.RLock()
+	strategies := make([]string, len(names))
+	histories := make([]string, len(names))
+	for i, name := range names {
+		strategies[i] = p.stats.strategies[name]
+		history := make([]string, len(p.stats.history))
+		for j, sample := range p.stats.history {
+			if v, ok := sample.values[name]; ok {
+				history[j] = strconv.FormatInt(v, 10)
+			} else {
+				history[j] = "-"
+			}
+		}
+		histories[i] = strings.Join(history, ",")
+	}
+	return names, strategies, histories
+}
+
+// collectStats sample cardinality every interval.
+func (p *Server) collectStats(interval time.Duration) {
+	for p.running {
+		values := make(map[string]int64)
+		rows := make(map[string]int64)
+		shardKeys := make(map[string]string)
+		for _, schema := range p.getSchemas() {
+			if !schema.ShardEnabled() {
+				continue
+			}
+			shardKeys[strings.ToLower(schema.Name)] = schema.ShardKey
+			p.sampleSchema(schema, values, rows)
+		}
+		p.updateStats(values, rows, shardKeys, time.Now())
+		time.Sleep(interval)
+	}
+}
+
+// updateStats with values and rows sampled, shardKeys is shard key of lower-cased schema name.
+// Rows of table not sampled is max cardinality of its columns.
+func (p *Server) updateStats(values, rows map[string]int64, shardKeys map[string]string, now time.Time) {
+	cardinalityRows := make(map[string]int64)
+	for key, value := range values {
+		table := key[:strings.LastIndex(key, ".")]
+		if value > cardinalityRows[table] {
+			cardinalityRows[table] = value
+		}
+	}
+	if rows == nil {
+		rows = make(map[string]int64)
+	}
+	for table, value := range cardinalityRows {
+		if _, ok := rows[table]; !ok {
+			rows[table] = value
+		}
+	}
+	strategies := make(map[string]string, len(values))
+	for key, value := range values {
+		table := key[:strings.LastIndex(key, ".")]
+		schema := key[:strings.Index(key, ".")]
+		strategies[key] = adviseStrategy(key[len(table)+1:], shardKeys[schema], value, rows[table])
+	}
+	p.stats.Lock()
+	p.stats.values = values
+	p.stats.rows = rows
+	p.stats.strategies = strategies
+	p.stats.history = append(p.stats.history, statsSample{time: now, values: values})
+	if len(p.stats.history) > statsHistorySize {
+		p.stats.history = p.stats.history[len(p.stats.history)-statsHistorySize:]
+	}
+	p.stats.Unlock()
+}
+
+// adviseStrategy for query filtered by column, with its cardinality and rows of its table.
+func adviseStrategy(column, shardKey string, cardinality, rows int64) string {
+	switch {
+	case strings.EqualFold(column, shardKey):
+		return StrategyShardKey
+	case rows <= broadcastRows:
+		return StrategyBroadcast
+	case cardinality > 0 && rows/cardinality <= globalIndexRows:
+		return StrategyGlobalIndex
+	}
+	return StrategyProxyJoin
+}
+
+// sampleSchema read cardinality of configured tables from 'show index' of each node,
+// and table_rows of information_schema summed over nodes.
+// Node or table failed is logged and skipped, so that others are still sampled.
+func (p *Server) sampleSchema(schema *config.SchemaConfig, values, rows map[string]int64) {
+	for _, nodeName := range schema.Nodes {
+		node := p.nodes[nodeName]
+		if node == nil {
+			continue
+		}
+		conn, err := getStatsConn(node)
+		if err != nil {
+			simplelog.Warn("%s %s %s schema=%s,node=%s,err=%s", "proxy", "sampleSchema", "Sample cardinality failed",
+				schema.Name, nodeName, err)
+			continue
+		}
+		tables := schema.GetTables()
+		for table := range tables {
+			if err = sampleTable(conn, schema, table, values); err != nil {
+				simplelog.Warn("%s %s %s schema=%s,node=%s,table=%s,err=%s", "proxy", "sampleSchema", "Sample cardinality failed",
+					schema.Name, nodeName, table, err)
+			}
+		}
+		if err = sampleTableRows(conn, schema, tables, rows); err != nil {
+			simplelog.Warn("%s %s %s schema=%s,node=%s,err=%s", "proxy", "sampleSchema", "Sample table rows failed",
+				schema.Name, nodeName, err)
+		}
+		conn.ReturnConnection()
+	}
+}
+
+func getStatsConn(node *backend.DataNode) (*mysqlBackend.Conn, error) {
+	var conn backend.Connection
+	var err error
+	if dbHost, e := node.DataHost.GetSlave(); e == nil && dbHost != nil {
+		conn, err = dbHost.GetConnection(node.Database)
+	} else {
+		conn, err = node.DataHost.Master.GetConnection(node.Database)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return conn.(*mysqlBackend.Conn), nil
+}
+
+func sampleTable(conn *mysqlBackend.Conn, schema *config.SchemaConfig, table string, values map[string]int64) error {
+	result, err := conn.Query(fmt.Sprintf("show index from %s where seq_in_index = 1", quoteIdentifier(table)))
+	if err != nil || result.Resultset == nil {
+		return err
+	}
+	// Non_unique is 1, Column_name is 4, Cardinality is 6.
+	sampled := make(map[string]bool)
+	for _, row := range result.Rows {
+		var column string
+		switch v := row.GetValue(4).(type) {
+		case string:
+			column = v
+		case []byte:
+			column = string(v)
+		default:
+			continue
+		}
+		key := strings.ToLower(schema.Name + "." + table + "." + column)
+		if sampled[key] {
+			continue
+		}
+		sampled[key] = true
+		cardinality := toInt64(row.GetValue(6))
+		if toInt64(row.GetValue(1)) == 0 || strings.EqualFold(column, schema.ShardKey) {
+			values[key] += cardinality
+		} else if cardinality > values[key] {
+			values[key] = cardinality
+		}
+	}
+	return nil
+}
+
+// sampleTableRows add table_rows of configured tables in database of conn, which is estimated by innodb.
+func sampleTableRows(conn *mysqlBackend.Conn, schema *config.SchemaConfig, tables map[string]*config.TableConfig,
+	rows map[string]int64) error {
+	result, err := conn.Query("select table_name, table_rows from information_schema.tables where table_schema = database()")
+	if err != nil || result.Resultset == nil {
+		return err
+	}
+	names := make(map[string]bool)
+	for table := range tables {
+		names[strings.ToLower(table)] = true
+	}
+	for _, row := range result.Rows {
+		var table string
+		switch v := row.GetValue(0).(type) {
+		case string:
+			table = v
+		case []byte:
+			table = string(v)
+		default:
+			continue
+		}
+		key := strings.ToLower(schema.Name + "." + table)
+		if !names[strings.ToLower(table)] || row.GetValue(1) == nil {
+			continue
+		}
+		rows[key] += toInt64(row.GetValue(1))
+	}
+	return nil
+}
+
+func toInt64(v interface{}) int64 {
+	switch x := v.(type) {
+	case int64:
+		return x
+	case uint64:
+		return int64(x)
+	case string:
+		i, _ := strconv.ParseInt(x, 10, 64)
+		return i
+	case []byte:
+		i, _ := strconv.ParseInt(string(x), 10, 64)
+		return i
+	}
+	return 0
+}
@@ -0,0 +1,142 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/berkaroad/saashard/backend"
+	"github.com/berkaroad/saashard/backend/mock"
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/net/mysql"
+)
+
+func TestAdviseStrategy(t *testing.T) {
+	cases := []struct {
+		column            string
+		cardinality, rows int64
+		expected          string
+	}{
+		{"tenant_id", 10, 100000, StrategyShardKey},
+		{"TENANT_ID", 10, 100000, StrategyShardKey},
+		{"name", 2, 1000, StrategyBroadcast},
+		{"email", 100000, 100000, StrategyGlobalIndex},
+		{"order_no", 20000, 200000, StrategyGlobalIndex},
+		{"status", 5, 100000, StrategyProxyJoin},
+		{"status", 0, 100000, StrategyProxyJoin},
+	}
+	for _, c := range cases {
+		if got := adviseStrategy(c.column, "tenant_id", c.cardinality, c.rows); got != c.expected {
+			t.Errorf("adviseStrategy(%s, %d, %d) = %s, expected %s", c.column, c.cardinality, c.rows, got, c.expected)
+		}
+	}
+}
+
+func TestUpdateStatsHistory(t *testing.T) {
+	p := new(Server)
+	shardKeys := map[string]string{"db": "tenant_id"}
+	for i := int64(1); i <= statsHistorySize+2; i++ {
+		values := map[string]int64{"db.t1.tenant_id": i, "db.t1.status": 2}
+		if i%2 == 0 {
+			values["db.t1.email"] = 5000
+		}
+		p.updateStats(values, nil, shardKeys, time.Now())
+	}
+	names, strategies, histories := p.GetStrategyNames()
+	expected := []struct{ name, strategy, history string }{
+		{"db.t1.email", StrategyGlobalIndex, "-,5000,-,5000,-,5000,-,5000,-,5000,-,5000"},
+		{"db.t1.status", StrategyProxyJoin, "2,2,2,2,2,2,2,2,2,2,2,2"},
+		{"db.t1.tenant_id", StrategyShardKey, "3,4,5,6,7,8,9,10,11,12,13,14"},
+	}
+	if len(names) != len(expected) {
+		t.Fatalf("names = %v, expected %d", names, len(expected))
+	}
+	for i, e := range expected {
+		if names[i] != e.name || strategies[i] != e.strategy || histories[i] != e.history {
+			t.Errorf("%s = %s [%s], expected %s = %s [%s]", names[i], strategies[i], histories[i], e.name, e.strategy, e.history)
+		}
+	}
+	if got := p.GetStrategy("DB", "t1", "Status"); got != StrategyProxyJoin {
+		t.Errorf("GetStrategy = %s, expected %s", got, StrategyProxyJoin)
+	}
+	p.updateStats(map[string]int64{"db.t1.status": 2}, nil, shardKeys, time.Now())
+	if got := p.GetStrategy("db", "t1", "status"); got != StrategyBroadcast {
+		t.Errorf("GetStrategy of small table = %s, expected %s", got, StrategyBroadcast)
+	}
+	p.updateStats(map[string]int64{"db.t1.status": 2}, map[string]int64{"db.t1": 100000}, shardKeys, time.Now())
+	if got := p.GetTableRows("db", "t1"); got != 100000 {
+		t.Errorf("GetTableRows = %d, expected 100000", got)
+	}
+	if got := p.GetStrategy("db", "t1", "status"); got != StrategyProxyJoin {
+		t.Errorf("GetStrategy of sampled rows = %s, expected %s", got, StrategyProxyJoin)
+	}
+}
+
+func TestSampleSchemaSkipsFailedTable(t *testing.T) {
+	node1, s1 := newMockNode(t, "node1")
+	node2, s2 := newMockNode(t, "node2")
+	columns := []string{"Table", "Non_unique", "Key_name", "Seq_in_index", "Column_name", "Collation", "Cardinality"}
+	s1.Handle("^show index from `t1`", &mock.Response{Err: mysql.NewDefaultError(mysql.ER_NO_SUCH_TABLE, "db", "t1")})
+	s1.Handle("^show index from `t2`", &mock.Response{Columns: columns, Rows: [][]interface{}{
+		{"t2", 0, "PRIMARY", 1, "id", "A", 100},
+		{"t2", 1, "idx_status", 1, "status", "A", 3},
+	}})
+	s2.Handle("^show index from `t1`", &mock.Response{Columns: columns, Rows: [][]interface{}{
+		{"t1", 1, "idx_tenant", 1, "tenant_id", "A", 7},
+	}})
+	s2.Handle("^show index from `t2`", &mock.Response{Columns: columns, Rows: [][]interface{}{
+		{"t2", 0, "PRIMARY", 1, "id", "A", 50},
+		{"t2", 1, "idx_status", 1, "status", "A", 4},
+	}})
+	tableColumns := []string{"TABLE_NAME", "TABLE_ROWS"}
+	s1.Handle("^select table_name, table_rows from information_schema.tables", &mock.Response{Columns: tableColumns,
+		Rows: [][]interface{}{{"t1", 1000}, {"T2", 120}, {"t3", 9}}})
+	s2.Handle("^select table_name, table_rows from information_schema.tables", &mock.Response{Columns: tableColumns,
+		Rows: [][]interface{}{{"t1", 2000}, {"t2", 80}}})
+
+	p := &Server{nodes: map[string]*backend.DataNode{"node1": node1, "node2": node2}}
+	schema := &config.SchemaConfig{Name: "db", Nodes: []string{"node1", "node2", "node3"}, ShardKey: "tenant_id",
+		Tables: []config.TableConfig{{Name: "t1"}, {Name: "t2"}}}
+	values := make(map[string]int64)
+	rows := make(map[string]int64)
+	p.sampleSchema(schema, values, rows)
+	expected := map[string]int64{"db.t1.tenant_id": 7, "db.t2.id": 150, "db.t2.status": 4}
+	if len(values) != len(expected) {
+		t.Errorf("values = %v, expected %v", values, expected)
+	}
+	for key, value := range expected {
+		if values[key] != value {
+			t.Errorf("%s = %d, expected %d", key, values[key], value)
+		}
+	}
+	expectedRows := map[string]int64{"db.t1": 3000, "db.t2": 200}
+	if len(rows) != len(expectedRows) {
+		t.Errorf("rows = %v, expected %v", rows, expectedRows)
+	}
+	for key, value := range expectedRows {
+		if rows[key] != value {
+			t.Errorf("rows of %s = %d, expected %d", key, rows[key], value)
+		}
+	}
+}
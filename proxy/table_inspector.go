@@ -0,0 +1,85 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"fmt"
+	"strings"
+
+	mysqlBackend "github.com/berkaroad/saashard/backend/mysql"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/net/mysql"
+)
+
+// GetColumnType from master of the node, it's used by ddl safety check.
+func (c *ClientConn) GetColumnType(nodeName, table, column string) (string, error) {
+	values, err := c.queryMetadata(nodeName, fmt.Sprintf("show columns from %s where field = '%s'",
+		quoteIdentifier(table), mysql.Escape(column)), 1)
+	if err != nil || len(values) == 0 {
+		return "", err
+	}
+	return values[0], nil
+}
+
+// GetIndexColumns from master of the node, it's used by ddl safety check.
+func (c *ClientConn) GetIndexColumns(nodeName, table, index string) ([]string, error) {
+	return c.queryMetadata(nodeName, fmt.Sprintf("show index from %s where key_name = '%s'",
+		quoteIdentifier(table), mysql.Escape(index)), 4)
+}
+
+// queryMetadata return the column's values of each row.
+func (c *ClientConn) queryMetadata(nodeName, sql string, column int) ([]string, error) {
+	node := c.proxy.nodes[nodeName]
+	if node == nil {
+		return nil, errors.ErrNoDataNode
+	}
+	conn, err := c.getOrCreateMasterConn(node)
+	if err != nil {
+		return nil, err
+	}
+	var mysqlConn = conn.(*mysqlBackend.Conn)
+	if err = mysqlConn.UseDB(node.Database); err != nil {
+		return nil, err
+	}
+	result, err := mysqlConn.Query(sql)
+	if err != nil {
+		return nil, err
+	}
+	if result.Resultset == nil {
+		return nil, nil
+	}
+	values := make([]string, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		switch v := row.GetValue(column).(type) {
+		case string:
+			values = append(values, v)
+		case []byte:
+			values = append(values, string(v))
+		}
+	}
+	return values, nil
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
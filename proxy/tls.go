@@ -0,0 +1,154 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// certStore hold current tls config, that could be reloaded at runtime.
+// New handshakes use the reloaded certificates, and existing sessions continue.
+type certStore struct {
+	sync.Mutex
+	certFile string
+	keyFile  string
+	caFile   string
+
+	current  atomic.Value // *tls.Config
+	modTimes map[string]time.Time
+	closeCh  chan struct{}
+}
+
+func newCertStore(certFile, keyFile, caFile string) (*certStore, error) {
+	store := &certStore{certFile: certFile, keyFile: keyFile, caFile: caFile}
+	store.closeCh = make(chan struct{})
+	if err := store.Reload(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// TLSConfig return the config that is used by handshake,
+// it delegates to the current config.
+func (store *certStore) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return store.current.Load().(*tls.Config), nil
+		},
+	}
+}
+
+// Reload certificates and ca bundle from files.
+func (store *certStore) Reload() error {
+	defer store.Unlock()
+
+	store.Lock()
+	cert, err := tls.LoadX509KeyPair(store.certFile, store.keyFile)
+	if err != nil {
+		return err
+	}
+	config := &tls.Config{Certificates: []tls.Certificate{cert}}
+	if len(store.caFile) > 0 {
+		caData, err := ioutil.ReadFile(store.caFile)
+		if err != nil {
+			return err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caData) {
+			return fmt.Errorf("no certificate in ca file '%s'", store.caFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	store.current.Store(config)
+	store.modTimes = store.readModTimes()
+	simplelog.Info("%s %s %s cert=%s,ca=%s", "server/proxy", "certStore.Reload", "Certificates loaded",
+		store.certFile, store.caFile)
+	return nil
+}
+
+// Watch files, reload if any file is modified.
+func (store *certStore) Watch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-store.closeCh:
+			return
+		case <-ticker.C:
+			if !store.isModified() {
+				continue
+			}
+			if err := store.Reload(); err != nil {
+				// Keep using old certificates, maybe files are being written.
+				simplelog.Error("%s %s %s", "server/proxy", "certStore.Watch", err.Error())
+			}
+		}
+	}
+}
+
+// Close stop watching, it could be called more than once.
+func (store *certStore) Close() {
+	defer store.Unlock()
+
+	store.Lock()
+	select {
+	case <-store.closeCh:
+	default:
+		close(store.closeCh)
+	}
+}
+
+func (store *certStore) isModified() bool {
+	defer store.Unlock()
+
+	store.Lock()
+	for file, modTime := range store.readModTimes() {
+		if !modTime.Equal(store.modTimes[file]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (store *certStore) readModTimes() map[string]time.Time {
+	modTimes := make(map[string]time.Time)
+	for _, file := range []string{store.certFile, store.keyFile, store.caFile} {
+		if len(file) == 0 {
+			continue
+		}
+		if info, err := os.Stat(file); err == nil {
+			modTimes[file] = info.ModTime()
+		}
+	}
+	return modTimes
+}
@@ -0,0 +1,185 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+const (
+	hookEventLongTransaction = "long_transaction"
+	hookEventBigTransaction  = "big_transaction"
+
+	transCheckInterval = time.Second
+	maxTransSQLLength  = 1024
+)
+
+// transTracker track duration, statements and affected rows of current transaction of session,
+// it's alerted once for long transaction and once for big transaction.
+type transTracker struct {
+	sync.Mutex
+	start       time.Time
+	statements  int
+	rows        uint64
+	firstSQL    string
+	lastSQL     string
+	longAlerted bool
+	bigAlerted  bool
+}
+
+// transInfo is snapshot of transaction for alert.
+type transInfo struct {
+	duration   time.Duration
+	statements int
+	rows       uint64
+	firstSQL   string
+	lastSQL    string
+}
+
+func (t *transTracker) addRows(rows uint64) {
+	t.Lock()
+	t.rows += rows
+	t.Unlock()
+}
+
+// track statement after dispatched, transaction is reset if session isn't in transaction.
+func (t *transTracker) track(inTrans bool, sql string) {
+	t.Lock()
+	defer t.Unlock()
+	if !inTrans {
+		t.start, t.statements, t.rows = time.Time{}, 0, 0
+		t.firstSQL, t.lastSQL = "", ""
+		t.longAlerted, t.bigAlerted = false, false
+		return
+	}
+	if len(sql) > maxTransSQLLength {
+		sql = sql[:maxTransSQLLength]
+	}
+	if t.start.IsZero() {
+		t.start = time.Now()
+		t.firstSQL = sql
+	}
+	t.statements++
+	t.lastSQL = sql
+}
+
+func (t *transTracker) info() transInfo {
+	return transInfo{
+		duration:   time.Since(t.start),
+		statements: t.statements,
+		rows:       t.rows,
+		firstSQL:   t.firstSQL,
+		lastSQL:    t.lastSQL,
+	}
+}
+
+// checkBig return transaction that exceeds big_trans_statements or big_trans_rows, and isn't alerted.
+func (t *transTracker) checkBig(maxStatements int, maxRows uint64) (transInfo, bool) {
+	t.Lock()
+	defer t.Unlock()
+	if t.start.IsZero() || t.bigAlerted ||
+		!(maxStatements > 0 && t.statements > maxStatements || maxRows > 0 && t.rows > maxRows) {
+		return transInfo{}, false
+	}
+	t.bigAlerted = true
+	return t.info(), true
+}
+
+// checkLong return transaction that exceeds long_trans_time, and isn't alerted.
+func (t *transTracker) checkLong(maxDuration time.Duration) (transInfo, bool) {
+	t.Lock()
+	defer t.Unlock()
+	if t.start.IsZero() || t.longAlerted || time.Since(t.start) <= maxDuration {
+		return transInfo{}, false
+	}
+	t.longAlerted = true
+	return t.info(), true
+}
+
+// commandSQL of COM_QUERY, or query of prepared stmt of COM_STMT_EXECUTE.
+func (c *ClientConn) commandSQL(data []byte) string {
+	if data[0] != mysql.COM_STMT_EXECUTE {
+		return string(data[1:])
+	}
+	if len(data) >= 5 {
+		if s := c.stmts[binary.LittleEndian.Uint32(data[1:5])]; s != nil {
+			return s.Query
+		}
+	}
+	return ""
+}
+
+func (p *Server) isTransTracked() bool {
+	return p.cfg.LongTransTime > 0 || p.cfg.BigTransStatements > 0 || p.cfg.BigTransRows > 0
+}
+
+// trackTransaction of session after each statement, and alert big transaction.
+// Transaction ends at commit, rollback or setting autocommit, and the next starts at the statement after them.
+func (c *ClientConn) trackTransaction(sql string) {
+	transEnded := c.transEnded
+	c.transEnded = false
+	if !c.proxy.isTransTracked() {
+		return
+	}
+	c.trans.track(c.isInTransaction() && !transEnded, sql)
+	if info, ok := c.trans.checkBig(c.proxy.cfg.BigTransStatements, uint64(c.proxy.cfg.BigTransRows)); ok {
+		c.proxy.counter.IncrBigTransTotal()
+		c.proxy.alertTransaction(c, hookEventBigTransaction, info)
+	}
+}
+
+// checkTransactions alert long transactions of sessions every second, even if session is idle in transaction.
+func (p *Server) checkTransactions() {
+	maxDuration := time.Duration(p.cfg.LongTransTime) * time.Second
+	for p.running {
+		time.Sleep(transCheckInterval)
+		p.Lock()
+		conns := make([]*ClientConn, 0, len(p.conns))
+		for _, conn := range p.conns {
+			conns = append(conns, conn)
+		}
+		p.Unlock()
+		for _, conn := range conns {
+			if info, ok := conn.trans.checkLong(maxDuration); ok {
+				p.counter.IncrLongTransTotal()
+				p.alertTransaction(conn, hookEventLongTransaction, info)
+			}
+		}
+	}
+}
+
+// alertTransaction log structured warning, and fire hook.
+func (p *Server) alertTransaction(c *ClientConn, event string, info transInfo) {
+	host := c.c.RemoteAddr().String()
+	simplelog.Warn("%s %s %s conn=%d,user=%s,host=%s,db=%s,duration=%.1fs,statements=%d,rows=%d,first_sql=%s,last_sql=%s",
+		"proxy", "alertTransaction", event, c.connectionID, c.user, host, c.db,
+		info.duration.Seconds(), info.statements, info.rows, info.firstSQL, info.lastSQL)
+	p.fireHook(&hookEvent{Event: event, ConnectionID: c.connectionID, User: c.user, Host: host, DB: c.db,
+		Duration: info.duration.Seconds(), Statements: info.statements, Rows: info.rows,
+		FirstSQL: info.firstSQL, LastSQL: info.lastSQL})
+}
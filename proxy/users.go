@@ -0,0 +1,210 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/utils/simplelog"
+	"github.com/go-yaml/yaml"
+)
+
+// proxyUserSnapshotName is the name of proxy users snapshot in runtime state file.
+const proxyUserSnapshotName = "saashard_proxy_users"
+
+// ProxyUser is a frontend user created at runtime by admin, besides users of schemas in config file.
+type ProxyUser struct {
+	Name     string   `yaml:"name"`
+	Password string   `yaml:"password"`
+	Schemas  []string `yaml:"schemas"`
+	ReadOnly bool     `yaml:"read_only"`
+}
+
+// String of proxy user without password, for audit log.
+func (user *ProxyUser) String() string {
+	return "schemas=" + strings.Join(user.Schemas, ",") + ",read_only=" + strconv.FormatBool(user.ReadOnly)
+}
+
+// proxyUsers are proxy users by name.
+type proxyUsers struct {
+	sync.RWMutex
+	users map[string]*ProxyUser
+}
+
+// getProxyUser get proxy user by name, nil if not exists.
+func (p *Server) getProxyUser(name string) *ProxyUser {
+	p.users.RLock()
+	defer p.users.RUnlock()
+	return p.users.users[strings.ToLower(name)]
+}
+
+// GetProxyUsers get proxy users ordered by name.
+func (p *Server) GetProxyUsers() []ProxyUser {
+	p.users.RLock()
+	defer p.users.RUnlock()
+	users := make([]ProxyUser, 0, len(p.users.users))
+	for _, user := range p.users.users {
+		users = append(users, *user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+	return users
+}
+
+// CreateProxyUser create proxy user, and save to runtime state file.
+func (p *Server) CreateProxyUser(user ProxyUser, ifNotExists bool) error {
+	user.Name = strings.ToLower(user.Name)
+	if err := p.checkProxyUser(&user, "CREATE PROXY USER"); err != nil {
+		return err
+	}
+	p.users.Lock()
+	if _, ok := p.users.users[user.Name]; ok {
+		p.users.Unlock()
+		if ifNotExists {
+			return nil
+		}
+		return mysql.NewDefaultError(mysql.ER_CANNOT_USER, "CREATE PROXY USER", user.Name)
+	}
+	if p.users.users == nil {
+		p.users.users = make(map[string]*ProxyUser)
+	}
+	p.users.users[user.Name] = &user
+	p.users.Unlock()
+	simplelog.Info("%s %s %s name=%s,%s", "proxy", "CreateProxyUser", "Create proxy user", user.Name, user.String())
+	return p.saveRuntimeState()
+}
+
+// AlterProxyUser change options of proxy user, nil options aren't changed. Return previous and current user.
+func (p *Server) AlterProxyUser(name string, password *string, schemas []string, readOnly *bool) (ProxyUser, ProxyUser, error) {
+	name = strings.ToLower(name)
+	p.users.Lock()
+	current, ok := p.users.users[name]
+	if !ok {
+		p.users.Unlock()
+		return ProxyUser{}, ProxyUser{}, mysql.NewDefaultError(mysql.ER_CANNOT_USER, "ALTER PROXY USER", name)
+	}
+	user := *current
+	if password != nil {
+		user.Password = *password
+	}
+	if schemas != nil {
+		user.Schemas = schemas
+	}
+	if readOnly != nil {
+		user.ReadOnly = *readOnly
+	}
+	if err := p.checkProxyUser(&user, "ALTER PROXY USER"); err != nil {
+		p.users.Unlock()
+		return ProxyUser{}, ProxyUser{}, err
+	}
+	p.users.users[name] = &user
+	p.users.Unlock()
+	simplelog.Info("%s %s %s name=%s,%s", "proxy", "AlterProxyUser", "Alter proxy user", user.Name, user.String())
+	return *current, user, p.saveRuntimeState()
+}
+
+// DropProxyUser drop proxy user, sessions of the user aren't closed.
+func (p *Server) DropProxyUser(name string, ifExists bool) error {
+	name = strings.ToLower(name)
+	p.users.Lock()
+	if _, ok := p.users.users[name]; !ok {
+		p.users.Unlock()
+		if ifExists {
+			return nil
+		}
+		return mysql.NewDefaultError(mysql.ER_CANNOT_USER, "DROP PROXY USER", name)
+	}
+	delete(p.users.users, name)
+	p.users.Unlock()
+	simplelog.Info("%s %s %s name=%s", "proxy", "DropProxyUser", "Drop proxy user", name)
+	return p.saveRuntimeState()
+}
+
+// checkProxyUser check that name isn't used by config, and schemas exist.
+func (p *Server) checkProxyUser(user *ProxyUser, operation string) error {
+	if len(user.Name) == 0 || p.isProbeUser(user.Name) || user.Name == strings.ToLower(p.cfg.AdminUser) {
+		return mysql.NewDefaultError(mysql.ER_CANNOT_USER, operation, user.Name)
+	}
+	schemas := p.getSchemas()
+	for _, schema := range schemas {
+		if schema.User == user.Name {
+			return mysql.NewDefaultError(mysql.ER_CANNOT_USER, operation, user.Name)
+		}
+	}
+	if len(user.Schemas) == 0 {
+		return errors.ErrNoSchema
+	}
+	for i, name := range user.Schemas {
+		name = strings.ToLower(name)
+		if schemas[name] == nil {
+			return mysql.NewDefaultError(mysql.ER_BAD_DB_ERROR, name)
+		}
+		user.Schemas[i] = name
+	}
+	return nil
+}
+
+// addProxyUserSchemas add allowed schemas of proxy user.
+func (p *Server) addProxyUserSchemas(name string, schemas map[string]*config.SchemaConfig) {
+	user := p.getProxyUser(name)
+	if user == nil {
+		return
+	}
+	all := p.getSchemas()
+	for _, schema := range user.Schemas {
+		if schemaConfig := all[schema]; schemaConfig != nil {
+			schemas[schema] = schemaConfig
+		}
+	}
+}
+
+// proxyUserSnapshot snapshot of proxy users, saved in runtime state file.
+func (p *Server) proxyUserSnapshot() (string, error) {
+	data, err := yaml.Marshal(p.GetProxyUsers())
+	return string(data), err
+}
+
+// restoreProxyUsers restore proxy users from snapshot of runtime state file.
+func (p *Server) restoreProxyUsers(value string) error {
+	var snapshot []ProxyUser
+	if err := yaml.Unmarshal([]byte(value), &snapshot); err != nil {
+		return err
+	}
+	users := make(map[string]*ProxyUser)
+	for i := range snapshot {
+		user := snapshot[i]
+		if err := p.checkProxyUser(&user, "CREATE PROXY USER"); err != nil {
+			return err
+		}
+		users[user.Name] = &user
+	}
+	p.users.Lock()
+	p.users.users = users
+	p.users.Unlock()
+	return nil
+}
@@ -0,0 +1,337 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package proxy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/berkaroad/saashard/backend"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/utils/simplelog"
+	"github.com/go-yaml/yaml"
+)
+
+// variable is runtime tunable variable, set by 'SET GLOBAL saashard_xxx = value' in admin.
+type variable struct {
+	get func(p *Server) string
+	set func(p *Server, value string) error
+
+	transient bool // not saved to runtime state file.
+}
+
+var variables = map[string]*variable{
+	"saashard_slow_log_time": &variable{
+		get: func(p *Server) string {
+			return strconv.Itoa(p.slowLogTime[atomic.LoadInt32(&p.slowLogTimeIndex)])
+		},
+		set: func(p *Server, value string) error {
+			slowLogTime, err := parseNonNegativeInt(value)
+			if err != nil {
+				return err
+			}
+			next := 1 - atomic.LoadInt32(&p.slowLogTimeIndex)
+			p.slowLogTime[next] = slowLogTime
+			atomic.StoreInt32(&p.slowLogTimeIndex, next)
+			return nil
+		},
+	},
+	"saashard_log_sql": &variable{
+		get: func(p *Server) string {
+			return p.logSQL[atomic.LoadInt32(&p.logSQLIndex)]
+		},
+		set: func(p *Server, value string) error {
+			value = strings.ToLower(value)
+			if value != "on" && value != "off" {
+				return errors.ErrInvalidArgument
+			}
+			next := 1 - atomic.LoadInt32(&p.logSQLIndex)
+			p.logSQL[next] = value
+			atomic.StoreInt32(&p.logSQLIndex, next)
+			return nil
+		},
+	},
+	"saashard_log_level": &variable{
+		get: func(p *Server) string {
+			return simplelog.GetLevel()
+		},
+		set: func(p *Server, value string) error {
+			return simplelog.SetLevel(value)
+		},
+	},
+	"saashard_max_fanout": &variable{
+		get: func(p *Server) string {
+			return strconv.Itoa(int(atomic.LoadInt32(&p.maxFanout)))
+		},
+		set: func(p *Server, value string) error {
+			maxFanout, err := parseNonNegativeInt(value)
+			if err != nil {
+				return err
+			}
+			atomic.StoreInt32(&p.maxFanout, int32(maxFanout))
+			return nil
+		},
+	},
+	"saashard_memory_budget": &variable{
+		get: func(p *Server) string {
+			return strconv.FormatInt(atomic.LoadInt64(&p.memoryBudget)/megabyte, 10)
+		},
+		set: func(p *Server, value string) error {
+			budget, err := parseNonNegativeInt(value)
+			if err != nil {
+				return err
+			}
+			atomic.StoreInt64(&p.memoryBudget, int64(budget)*megabyte)
+			return nil
+		},
+	},
+	"saashard_rule_stage": &variable{
+		get: func(p *Server) string {
+			p.rules.Lock()
+			defer p.rules.Unlock()
+			if p.rules.staged == nil {
+				return ""
+			}
+			return p.rules.staged.File
+		},
+		set: func(p *Server, value string) error {
+			return p.stageRules(value)
+		},
+		transient: true, // retained versions are saved as snapshot of shard rules.
+	},
+	"saashard_rule_version": &variable{
+		get: func(p *Server) string {
+			return strconv.Itoa(p.getRuleVersion())
+		},
+		set: func(p *Server, value string) error {
+			return p.activateRules(value)
+		},
+		transient: true,
+	},
+	"saashard_route_override": &variable{
+		get: func(p *Server) string {
+			return formatRouteOverrides(p.getRouteOverrides())
+		},
+		set: func(p *Server, value string) error {
+			return p.setRouteOverrides(value)
+		},
+	},
+	"saashard_diff_mode": &variable{
+		get: func(p *Server) string {
+			return diffModeNames[atomic.LoadInt32(&p.diffMode)]
+		},
+		set: func(p *Server, value string) error {
+			diffMode, err := parseDiffMode(value)
+			if err != nil {
+				return err
+			}
+			atomic.StoreInt32(&p.diffMode, diffMode)
+			return nil
+		},
+	},
+	"saashard_shadow_parser": &variable{
+		get: func(p *Server) string {
+			return p.getShadowParser()
+		},
+		set: func(p *Server, value string) error {
+			return p.setShadowParser(value)
+		},
+	},
+	"saashard_capture": &variable{
+		get: func(p *Server) string {
+			return p.getCaptureFilter().String()
+		},
+		set: func(p *Server, value string) error {
+			return p.setCapture(value)
+		},
+	},
+	"saashard_fault_drop_conn": &variable{
+		get: func(p *Server) string {
+			return strconv.Itoa(p.getFaults().dropConn)
+		},
+		set: func(p *Server, value string) error {
+			return p.setFaultDropConn(value)
+		},
+		transient: true,
+	},
+	"saashard_fault_delay": &variable{
+		get: func(p *Server) string {
+			return formatFaultDelays(p.getFaults().delays)
+		},
+		set: func(p *Server, value string) error {
+			return p.setFaultDelays(value)
+		},
+		transient: true,
+	},
+	"saashard_fault_parse_error": &variable{
+		get: func(p *Server) string {
+			return formatFaultParseErrors(p.getFaults().parseErrors)
+		},
+		set: func(p *Server, value string) error {
+			return p.setFaultParseErrors(value)
+		},
+		transient: true,
+	},
+	"saashard_retry_count": &variable{
+		get: func(p *Server) string {
+			return strconv.Itoa(backend.GetRetryCount())
+		},
+		set: func(p *Server, value string) error {
+			count, err := parseNonNegativeInt(value)
+			if err != nil {
+				return err
+			}
+			backend.SetRetryCount(count)
+			return nil
+		},
+	},
+	"saashard_max_conn_num": &variable{
+		get: func(p *Server) string {
+			return strconv.Itoa(int(atomic.LoadInt32(&p.maxConnNum)))
+		},
+		set: func(p *Server, value string) error {
+			maxConnNum, err := parseNonNegativeInt(value)
+			if err != nil {
+				return err
+			}
+			// 0 is to use max_conn_num of each host.
+			for _, hostCfg := range p.cfg.Hosts {
+				if host := p.hosts[hostCfg.Name]; host != nil {
+					if maxConnNum > 0 {
+						host.SetMaxConnNum(maxConnNum)
+					} else {
+						host.SetMaxConnNum(hostCfg.MaxConnNum)
+					}
+				}
+			}
+			atomic.StoreInt32(&p.maxConnNum, int32(maxConnNum))
+			return nil
+		},
+	},
+}
+
+func parseNonNegativeInt(value string) (int, error) {
+	i, err := strconv.Atoi(value)
+	if err != nil || i < 0 {
+		return 0, errors.ErrInvalidArgument
+	}
+	return i, nil
+}
+
+// VariableNames get names of runtime variables.
+func (p *Server) VariableNames() []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetVariable get value of runtime variable.
+func (p *Server) GetVariable(name string) (string, error) {
+	v, ok := variables[strings.ToLower(name)]
+	if !ok {
+		return "", errors.ErrNoVariable
+	}
+	return v.get(p), nil
+}
+
+// SetVariable set runtime variable with immediate effect, and save to runtime state file.
+func (p *Server) SetVariable(name, value string) error {
+	v, ok := variables[strings.ToLower(name)]
+	if !ok {
+		return errors.ErrNoVariable
+	}
+	if err := v.set(p, strings.Trim(value, "'\"")); err != nil {
+		return err
+	}
+	simplelog.Info("%s %s %s name=%s,value=%s", "proxy", "SetVariable", "Set runtime variable", name, value)
+	return p.saveRuntimeState()
+}
+
+// saveRuntimeState save runtime variables to runtime state file.
+func (p *Server) saveRuntimeState() error {
+	if len(p.cfg.RuntimeStateFile) == 0 {
+		return nil
+	}
+	state := make(map[string]string)
+	for name, v := range variables {
+		if !v.transient {
+			state[name] = v.get(p)
+		}
+	}
+	snapshot, err := p.ruleSnapshot()
+	if err != nil {
+		return err
+	}
+	state[ruleSnapshotName] = snapshot
+	if state[proxyUserSnapshotName], err = p.proxyUserSnapshot(); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p.cfg.RuntimeStateFile, data, 0600)
+}
+
+// loadRuntimeState load runtime variables from runtime state file.
+func (p *Server) loadRuntimeState() error {
+	if len(p.cfg.RuntimeStateFile) == 0 {
+		return nil
+	}
+	data, err := ioutil.ReadFile(p.cfg.RuntimeStateFile)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	state := make(map[string]string)
+	if err = yaml.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	for name, value := range state {
+		if v, ok := variables[name]; ok && !v.transient {
+			if err = v.set(p, value); err != nil {
+				return fmt.Errorf("invalid runtime variable %s=%s", name, value)
+			}
+		}
+	}
+	if snapshot, ok := state[ruleSnapshotName]; ok {
+		if err = p.restoreRules(snapshot); err != nil {
+			return fmt.Errorf("invalid snapshot of shard rules: %v", err)
+		}
+	}
+	if snapshot, ok := state[proxyUserSnapshotName]; ok {
+		if err = p.restoreProxyUsers(snapshot); err != nil {
+			return fmt.Errorf("invalid snapshot of proxy users: %v", err)
+		}
+	}
+	return nil
+}
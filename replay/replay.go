@@ -0,0 +1,225 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package replay reads sessions captured by proxy, and replays them against a proxy to reproduce bugs.
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/berkaroad/saashard/net/mysql"
+)
+
+// Version of capture file.
+const Version = 1
+
+// Sides of packets.
+const (
+	SideFrontend = "frontend"
+	SideBackend  = "backend"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Header is the first line of capture file.
+type Header struct {
+	Version      int    `json:"version"`
+	ConnectionID uint32 `json:"connection_id"`
+	User         string `json:"user"`
+	DB           string `json:"db"`
+	Client       string `json:"client"`
+	Capability   uint32 `json:"capability"`
+	Time         string `json:"ts"`
+}
+
+// Record is a packet of capture file, data is payload without header.
+type Record struct {
+	Time string `json:"ts"`
+	Side string `json:"side"`
+	Addr string `json:"addr"`
+	In   bool   `json:"in"` // received by proxy
+	Seq  uint8  `json:"seq"`
+	Data []byte `json:"data"`
+}
+
+// Capture is a captured session.
+type Capture struct {
+	Header  Header
+	Records []*Record
+}
+
+// Command is a command sent by client, with packets exchanged until next command.
+type Command struct {
+	Packets []*Record
+}
+
+// ReadCaptureFile read capture file.
+func ReadCaptureFile(fileName string) (*Capture, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadCapture(f)
+}
+
+// ReadCapture read capture, one json per line.
+func ReadCapture(r io.Reader) (*Capture, error) {
+	c := new(Capture)
+	dec := json.NewDecoder(bufio.NewReader(r))
+	if err := dec.Decode(&c.Header); err != nil {
+		return nil, err
+	}
+	if c.Header.Version != Version {
+		return nil, fmt.Errorf("capture version %d is not supported", c.Header.Version)
+	}
+	for {
+		record := new(Record)
+		if err := dec.Decode(record); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		c.Records = append(c.Records, record)
+	}
+	return c, nil
+}
+
+// Commands of frontend side, packets before the first command are skipped.
+func (c *Capture) Commands() []*Command {
+	var commands []*Command
+	var command *Command
+	for _, record := range c.Records {
+		if record.Side != SideFrontend {
+			continue
+		}
+		if record.In && record.Seq == 0 {
+			command = new(Command)
+			commands = append(commands, command)
+		}
+		if command != nil {
+			command.Packets = append(command.Packets, record)
+		}
+	}
+	return commands
+}
+
+// Mismatch is a packet responded differently.
+type Mismatch struct {
+	Command  int // index of command
+	Request  []byte
+	Expected []byte
+	Actual   []byte
+	Err      error
+}
+
+func (m *Mismatch) String() string {
+	if m.Err != nil {
+		return fmt.Sprintf("command %d %q: %v", m.Command, m.Request, m.Err)
+	}
+	return fmt.Sprintf("command %d %q: expected %q, actual %q", m.Command, m.Request, m.Expected, m.Actual)
+}
+
+// Result of replay.
+type Result struct {
+	Commands   int
+	Mismatches []*Mismatch
+}
+
+// Replayer feeds frontend packets of capture into proxy, and compares responses.
+type Replayer struct {
+	Addr     string
+	User     string // default is user of capture.
+	Password string
+	DB       string // default is db of capture.
+	Timeout  time.Duration
+}
+
+// Replay capture against proxy. It stops at the first packet that couldn't be read, since session state is unknown then.
+func (r *Replayer) Replay(c *Capture) (*Result, error) {
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	user, db := r.User, r.DB
+	if user == "" {
+		user = c.Header.User
+	}
+	if db == "" {
+		db = c.Header.DB
+	}
+	conn, err := net.DialTimeout("tcp", r.Addr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	pkg := mysql.NewPacketIO(conn)
+	conn.SetDeadline(time.Now().Add(timeout))
+	var salt []byte
+	capability, status, collation, err := pkg.ReadInitialHandshake(&salt, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = pkg.WriteAuthHandshake(&capability, user, r.Password, db, salt, collation, nil); err != nil {
+		return nil, err
+	}
+	if _, err = pkg.ReadOK(capability, &status); err != nil {
+		return nil, err
+	}
+
+	result := new(Result)
+	for i, command := range c.Commands() {
+		result.Commands++
+		request := command.Packets[0].Data
+		for _, record := range command.Packets {
+			conn.SetDeadline(time.Now().Add(timeout))
+			pkg.Sequence = record.Seq
+			if record.In {
+				data := make([]byte, 4, 4+len(record.Data))
+				if err = pkg.WritePacket(append(data, record.Data...)); err != nil {
+					return result, err
+				}
+				if len(record.Data) > 0 && record.Data[0] == mysql.COM_QUIT {
+					return result, nil
+				}
+				continue
+			}
+			actual, err := pkg.ReadPacket()
+			if err != nil {
+				result.Mismatches = append(result.Mismatches, &Mismatch{Command: i, Request: request, Expected: record.Data, Err: err})
+				return result, nil
+			}
+			if !bytes.Equal(actual, record.Data) {
+				result.Mismatches = append(result.Mismatches, &Mismatch{Command: i, Request: request, Expected: record.Data, Actual: actual})
+			}
+		}
+	}
+	return result, nil
+}
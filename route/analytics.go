@@ -0,0 +1,107 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"strings"
+
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+var aggregateFuncs = map[string]bool{
+	"avg":          true,
+	"count":        true,
+	"group_concat": true,
+	"max":          true,
+	"min":          true,
+	"std":          true,
+	"stddev":       true,
+	"sum":          true,
+	"variance":     true,
+}
+
+// classifyAnalytics decide whether the plan goes to analytics replica,
+// by hint, routing override, or estimated cost not less than AnalyticsCost.
+// Only plan that could execute on slave is able to go to analytics replica.
+func (r *Router) classifyAnalytics(fingerprint string, plan *normalPlan) {
+	if r.Overrides[fingerprint] == OverrideAnalytics {
+		plan.onAnalytics = true
+	}
+	if !plan.onAnalytics && r.AnalyticsCost > 0 {
+		if statement, ok := plan.Statement.(sqlparser.SelectStatement); ok {
+			plan.onAnalytics = estimateCost(statement) >= r.AnalyticsCost
+		}
+	}
+	plan.onAnalytics = plan.onAnalytics && plan.onSlave
+}
+
+// estimateCost of select statement by its structure,
+// each join, group by, having, distinct and aggregate function add cost.
+func estimateCost(statement sqlparser.SelectStatement) int {
+	switch v := statement.(type) {
+	case *sqlparser.Select:
+		cost := 0
+		for _, tableExpr := range v.From {
+			cost += estimateTableExprCost(tableExpr)
+		}
+		cost -= 2 // the first table
+		if len(v.GroupBy) > 0 {
+			cost += 2
+		}
+		if v.Having != nil {
+			cost++
+		}
+		if len(v.Distinct) > 0 {
+			cost++
+		}
+		for _, selectExpr := range v.SelectExprs {
+			if expr, ok := selectExpr.(*sqlparser.NonStarExpr); ok {
+				if funcExpr, ok := expr.Expr.(*sqlparser.FuncExpr); ok &&
+					aggregateFuncs[strings.ToLower(string(funcExpr.Name))] {
+					cost++
+				}
+			}
+		}
+		if cost < 0 {
+			cost = 0
+		}
+		return cost
+	case *sqlparser.Union:
+		return estimateCost(v.Left) + estimateCost(v.Right) + 1
+	}
+	return 0
+}
+
+func estimateTableExprCost(tableExpr sqlparser.TableExpr) int {
+	switch v := tableExpr.(type) {
+	case *sqlparser.JoinTableExpr:
+		return estimateTableExprCost(v.LeftExpr) + estimateTableExprCost(v.RightExpr)
+	case *sqlparser.ParenTableExpr:
+		return estimateTableExprCost(v.Expr)
+	case *sqlparser.AliasedTableExpr:
+		if subquery, ok := v.Expr.(*sqlparser.Subquery); ok {
+			return 2 + estimateCost(subquery.Select)
+		}
+	}
+	return 2
+}
@@ -0,0 +1,621 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/berkaroad/saashard/config"
+)
+
+// Shard key normalizations.
+const (
+	NormalizeTrim     = "trim"
+	NormalizeCaseFold = "casefold"
+	NormalizeNFC      = "nfc"
+)
+
+// IsShardKeyNormalize check whether the name of normalization is supported.
+func IsShardKeyNormalize(name string) bool {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case NormalizeTrim, NormalizeCaseFold, NormalizeNFC:
+		return true
+	}
+	return false
+}
+
+// normalizeShardKey normalize string value of shard key, so that values the backend's unique index treats as equal are routed to the same node.
+// Normalizations come from shard_key_normalize, and from shard_key_collation:
+// "_ci" collation folds case, and PAD SPACE collation (all except "_0900_") ignores trailing spaces.
+func normalizeShardKey(schema *config.SchemaConfig, val string) string {
+	if len(val) < 2 || val[0] != '\'' || val[len(val)-1] != '\'' {
+		return val
+	}
+	var trim, rtrim, caseFold, nfc bool
+	for _, name := range schema.ShardKeyNormalize {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case NormalizeTrim:
+			trim = true
+		case NormalizeCaseFold:
+			caseFold = true
+		case NormalizeNFC:
+			nfc = true
+		}
+	}
+	if collation := strings.ToLower(schema.ShardKeyCollation); collation != "" {
+		if strings.HasSuffix(collation, "_ci") {
+			caseFold = true
+		}
+		if !strings.Contains(collation, "_0900_") {
+			rtrim = true
+		}
+	}
+	if !trim && !rtrim && !caseFold && !nfc {
+		return val
+	}
+
+	s := val[1 : len(val)-1]
+	if nfc {
+		s = composeNFC(s)
+	}
+	if trim {
+		s = strings.Trim(s, " ")
+	} else if rtrim {
+		s = strings.TrimRight(s, " ")
+	}
+	if caseFold {
+		s = strings.Map(unicode.ToLower, strings.Map(unicode.ToUpper, s))
+	}
+	return "'" + s + "'"
+}
+
+// composeNFC compose base letter followed by combining marks into precomposed letter.
+// Only latin letters (U+00C0 - U+024F, U+1E00 - U+1EFF) are composed, which cover most of decomposed input.
+func composeNFC(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	out := make([]rune, 0, utf8.RuneCountInString(s))
+	for _, r := range s {
+		if n := len(out); n > 0 && unicode.Is(unicode.Mn, r) {
+			if composed, ok := compositions[[2]rune{out[n-1], r}]; ok {
+				out[n-1] = composed
+				continue
+			}
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// compositions canonical compositions of latin letters, generated from UnicodeData.txt.
+var compositions = map[[2]rune]rune{
+	{0x0041, 0x0300}: 0x00c0,
+	{0x0041, 0x0301}: 0x00c1,
+	{0x0041, 0x0302}: 0x00c2,
+	{0x0041, 0x0303}: 0x00c3,
+	{0x0041, 0x0308}: 0x00c4,
+	{0x0041, 0x030a}: 0x00c5,
+	{0x0043, 0x0327}: 0x00c7,
+	{0x0045, 0x0300}: 0x00c8,
+	{0x0045, 0x0301}: 0x00c9,
+	{0x0045, 0x0302}: 0x00ca,
+	{0x0045, 0x0308}: 0x00cb,
+	{0x0049, 0x0300}: 0x00cc,
+	{0x0049, 0x0301}: 0x00cd,
+	{0x0049, 0x0302}: 0x00ce,
+	{0x0049, 0x0308}: 0x00cf,
+	{0x004e, 0x0303}: 0x00d1,
+	{0x004f, 0x0300}: 0x00d2,
+	{0x004f, 0x0301}: 0x00d3,
+	{0x004f, 0x0302}: 0x00d4,
+	{0x004f, 0x0303}: 0x00d5,
+	{0x004f, 0x0308}: 0x00d6,
+	{0x0055, 0x0300}: 0x00d9,
+	{0x0055, 0x0301}: 0x00da,
+	{0x0055, 0x0302}: 0x00db,
+	{0x0055, 0x0308}: 0x00dc,
+	{0x0059, 0x0301}: 0x00dd,
+	{0x0061, 0x0300}: 0x00e0,
+	{0x0061, 0x0301}: 0x00e1,
+	{0x0061, 0x0302}: 0x00e2,
+	{0x0061, 0x0303}: 0x00e3,
+	{0x0061, 0x0308}: 0x00e4,
+	{0x0061, 0x030a}: 0x00e5,
+	{0x0063, 0x0327}: 0x00e7,
+	{0x0065, 0x0300}: 0x00e8,
+	{0x0065, 0x0301}: 0x00e9,
+	{0x0065, 0x0302}: 0x00ea,
+	{0x0065, 0x0308}: 0x00eb,
+	{0x0069, 0x0300}: 0x00ec,
+	{0x0069, 0x0301}: 0x00ed,
+	{0x0069, 0x0302}: 0x00ee,
+	{0x0069, 0x0308}: 0x00ef,
+	{0x006e, 0x0303}: 0x00f1,
+	{0x006f, 0x0300}: 0x00f2,
+	{0x006f, 0x0301}: 0x00f3,
+	{0x006f, 0x0302}: 0x00f4,
+	{0x006f, 0x0303}: 0x00f5,
+	{0x006f, 0x0308}: 0x00f6,
+	{0x0075, 0x0300}: 0x00f9,
+	{0x0075, 0x0301}: 0x00fa,
+	{0x0075, 0x0302}: 0x00fb,
+	{0x0075, 0x0308}: 0x00fc,
+	{0x0079, 0x0301}: 0x00fd,
+	{0x0079, 0x0308}: 0x00ff,
+	{0x0041, 0x0304}: 0x0100,
+	{0x0061, 0x0304}: 0x0101,
+	{0x0041, 0x0306}: 0x0102,
+	{0x0061, 0x0306}: 0x0103,
+	{0x0041, 0x0328}: 0x0104,
+	{0x0061, 0x0328}: 0x0105,
+	{0x0043, 0x0301}: 0x0106,
+	{0x0063, 0x0301}: 0x0107,
+	{0x0043, 0x0302}: 0x0108,
+	{0x0063, 0x0302}: 0x0109,
+	{0x0043, 0x0307}: 0x010a,
+	{0x0063, 0x0307}: 0x010b,
+	{0x0043, 0x030c}: 0x010c,
+	{0x0063, 0x030c}: 0x010d,
+	{0x0044, 0x030c}: 0x010e,
+	{0x0064, 0x030c}: 0x010f,
+	{0x0045, 0x0304}: 0x0112,
+	{0x0065, 0x0304}: 0x0113,
+	{0x0045, 0x0306}: 0x0114,
+	{0x0065, 0x0306}: 0x0115,
+	{0x0045, 0x0307}: 0x0116,
+	{0x0065, 0x0307}: 0x0117,
+	{0x0045, 0x0328}: 0x0118,
+	{0x0065, 0x0328}: 0x0119,
+	{0x0045, 0x030c}: 0x011a,
+	{0x0065, 0x030c}: 0x011b,
+	{0x0047, 0x0302}: 0x011c,
+	{0x0067, 0x0302}: 0x011d,
+	{0x0047, 0x0306}: 0x011e,
+	{0x0067, 0x0306}: 0x011f,
+	{0x0047, 0x0307}: 0x0120,
+	{0x0067, 0x0307}: 0x0121,
+	{0x0047, 0x0327}: 0x0122,
+	{0x0067, 0x0327}: 0x0123,
+	{0x0048, 0x0302}: 0x0124,
+	{0x0068, 0x0302}: 0x0125,
+	{0x0049, 0x0303}: 0x0128,
+	{0x0069, 0x0303}: 0x0129,
+	{0x0049, 0x0304}: 0x012a,
+	{0x0069, 0x0304}: 0x012b,
+	{0x0049, 0x0306}: 0x012c,
+	{0x0069, 0x0306}: 0x012d,
+	{0x0049, 0x0328}: 0x012e,
+	{0x0069, 0x0328}: 0x012f,
+	{0x0049, 0x0307}: 0x0130,
+	{0x004a, 0x0302}: 0x0134,
+	{0x006a, 0x0302}: 0x0135,
+	{0x004b, 0x0327}: 0x0136,
+	{0x006b, 0x0327}: 0x0137,
+	{0x004c, 0x0301}: 0x0139,
+	{0x006c, 0x0301}: 0x013a,
+	{0x004c, 0x0327}: 0x013b,
+	{0x006c, 0x0327}: 0x013c,
+	{0x004c, 0x030c}: 0x013d,
+	{0x006c, 0x030c}: 0x013e,
+	{0x004e, 0x0301}: 0x0143,
+	{0x006e, 0x0301}: 0x0144,
+	{0x004e, 0x0327}: 0x0145,
+	{0x006e, 0x0327}: 0x0146,
+	{0x004e, 0x030c}: 0x0147,
+	{0x006e, 0x030c}: 0x0148,
+	{0x004f, 0x0304}: 0x014c,
+	{0x006f, 0x0304}: 0x014d,
+	{0x004f, 0x0306}: 0x014e,
+	{0x006f, 0x0306}: 0x014f,
+	{0x004f, 0x030b}: 0x0150,
+	{0x006f, 0x030b}: 0x0151,
+	{0x0052, 0x0301}: 0x0154,
+	{0x0072, 0x0301}: 0x0155,
+	{0x0052, 0x0327}: 0x0156,
+	{0x0072, 0x0327}: 0x0157,
+	{0x0052, 0x030c}: 0x0158,
+	{0x0072, 0x030c}: 0x0159,
+	{0x0053, 0x0301}: 0x015a,
+	{0x0073, 0x0301}: 0x015b,
+	{0x0053, 0x0302}: 0x015c,
+	{0x0073, 0x0302}: 0x015d,
+	{0x0053, 0x0327}: 0x015e,
+	{0x0073, 0x0327}: 0x015f,
+	{0x0053, 0x030c}: 0x0160,
+	{0x0073, 0x030c}: 0x0161,
+	{0x0054, 0x0327}: 0x0162,
+	{0x0074, 0x0327}: 0x0163,
+	{0x0054, 0x030c}: 0x0164,
+	{0x0074, 0x030c}: 0x0165,
+	{0x0055, 0x0303}: 0x0168,
+	{0x0075, 0x0303}: 0x0169,
+	{0x0055, 0x0304}: 0x016a,
+	{0x0075, 0x0304}: 0x016b,
+	{0x0055, 0x0306}: 0x016c,
+	{0x0075, 0x0306}: 0x016d,
+	{0x0055, 0x030a}: 0x016e,
+	{0x0075, 0x030a}: 0x016f,
+	{0x0055, 0x030b}: 0x0170,
+	{0x0075, 0x030b}: 0x0171,
+	{0x0055, 0x0328}: 0x0172,
+	{0x0075, 0x0328}: 0x0173,
+	{0x0057, 0x0302}: 0x0174,
+	{0x0077, 0x0302}: 0x0175,
+	{0x0059, 0x0302}: 0x0176,
+	{0x0079, 0x0302}: 0x0177,
+	{0x0059, 0x0308}: 0x0178,
+	{0x005a, 0x0301}: 0x0179,
+	{0x007a, 0x0301}: 0x017a,
+	{0x005a, 0x0307}: 0x017b,
+	{0x007a, 0x0307}: 0x017c,
+	{0x005a, 0x030c}: 0x017d,
+	{0x007a, 0x030c}: 0x017e,
+	{0x004f, 0x031b}: 0x01a0,
+	{0x006f, 0x031b}: 0x01a1,
+	{0x0055, 0x031b}: 0x01af,
+	{0x0075, 0x031b}: 0x01b0,
+	{0x0041, 0x030c}: 0x01cd,
+	{0x0061, 0x030c}: 0x01ce,
+	{0x0049, 0x030c}: 0x01cf,
+	{0x0069, 0x030c}: 0x01d0,
+	{0x004f, 0x030c}: 0x01d1,
+	{0x006f, 0x030c}: 0x01d2,
+	{0x0055, 0x030c}: 0x01d3,
+	{0x0075, 0x030c}: 0x01d4,
+	{0x00dc, 0x0304}: 0x01d5,
+	{0x00fc, 0x0304}: 0x01d6,
+	{0x00dc, 0x0301}: 0x01d7,
+	{0x00fc, 0x0301}: 0x01d8,
+	{0x00dc, 0x030c}: 0x01d9,
+	{0x00fc, 0x030c}: 0x01da,
+	{0x00dc, 0x0300}: 0x01db,
+	{0x00fc, 0x0300}: 0x01dc,
+	{0x00c4, 0x0304}: 0x01de,
+	{0x00e4, 0x0304}: 0x01df,
+	{0x0226, 0x0304}: 0x01e0,
+	{0x0227, 0x0304}: 0x01e1,
+	{0x00c6, 0x0304}: 0x01e2,
+	{0x00e6, 0x0304}: 0x01e3,
+	{0x0047, 0x030c}: 0x01e6,
+	{0x0067, 0x030c}: 0x01e7,
+	{0x004b, 0x030c}: 0x01e8,
+	{0x006b, 0x030c}: 0x01e9,
+	{0x004f, 0x0328}: 0x01ea,
+	{0x006f, 0x0328}: 0x01eb,
+	{0x01ea, 0x0304}: 0x01ec,
+	{0x01eb, 0x0304}: 0x01ed,
+	{0x01b7, 0x030c}: 0x01ee,
+	{0x0292, 0x030c}: 0x01ef,
+	{0x006a, 0x030c}: 0x01f0,
+	{0x0047, 0x0301}: 0x01f4,
+	{0x0067, 0x0301}: 0x01f5,
+	{0x004e, 0x0300}: 0x01f8,
+	{0x006e, 0x0300}: 0x01f9,
+	{0x00c5, 0x0301}: 0x01fa,
+	{0x00e5, 0x0301}: 0x01fb,
+	{0x00c6, 0x0301}: 0x01fc,
+	{0x00e6, 0x0301}: 0x01fd,
+	{0x00d8, 0x0301}: 0x01fe,
+	{0x00f8, 0x0301}: 0x01ff,
+	{0x0041, 0x030f}: 0x0200,
+	{0x0061, 0x030f}: 0x0201,
+	{0x0041, 0x0311}: 0x0202,
+	{0x0061, 0x0311}: 0x0203,
+	{0x0045, 0x030f}: 0x0204,
+	{0x0065, 0x030f}: 0x0205,
+	{0x0045, 0x0311}: 0x0206,
+	{0x0065, 0x0311}: 0x0207,
+	{0x0049, 0x030f}: 0x0208,
+	{0x0069, 0x030f}: 0x0209,
+	{0x0049, 0x0311}: 0x020a,
+	{0x0069, 0x0311}: 0x020b,
+	{0x004f, 0x030f}: 0x020c,
+	{0x006f, 0x030f}: 0x020d,
+	{0x004f, 0x0311}: 0x020e,
+	{0x006f, 0x0311}: 0x020f,
+	{0x0052, 0x030f}: 0x0210,
+	{0x0072, 0x030f}: 0x0211,
+	{0x0052, 0x0311}: 0x0212,
+	{0x0072, 0x0311}: 0x0213,
+	{0x0055, 0x030f}: 0x0214,
+	{0x0075, 0x030f}: 0x0215,
+	{0x0055, 0x0311}: 0x0216,
+	{0x0075, 0x0311}: 0x0217,
+	{0x0053, 0x0326}: 0x0218,
+	{0x0073, 0x0326}: 0x0219,
+	{0x0054, 0x0326}: 0x021a,
+	{0x0074, 0x0326}: 0x021b,
+	{0x0048, 0x030c}: 0x021e,
+	{0x0068, 0x030c}: 0x021f,
+	{0x0041, 0x0307}: 0x0226,
+	{0x0061, 0x0307}: 0x0227,
+	{0x0045, 0x0327}: 0x0228,
+	{0x0065, 0x0327}: 0x0229,
+	{0x00d6, 0x0304}: 0x022a,
+	{0x00f6, 0x0304}: 0x022b,
+	{0x00d5, 0x0304}: 0x022c,
+	{0x00f5, 0x0304}: 0x022d,
+	{0x004f, 0x0307}: 0x022e,
+	{0x006f, 0x0307}: 0x022f,
+	{0x022e, 0x0304}: 0x0230,
+	{0x022f, 0x0304}: 0x0231,
+	{0x0059, 0x0304}: 0x0232,
+	{0x0079, 0x0304}: 0x0233,
+	{0x0041, 0x0325}: 0x1e00,
+	{0x0061, 0x0325}: 0x1e01,
+	{0x0042, 0x0307}: 0x1e02,
+	{0x0062, 0x0307}: 0x1e03,
+	{0x0042, 0x0323}: 0x1e04,
+	{0x0062, 0x0323}: 0x1e05,
+	{0x0042, 0x0331}: 0x1e06,
+	{0x0062, 0x0331}: 0x1e07,
+	{0x00c7, 0x0301}: 0x1e08,
+	{0x00e7, 0x0301}: 0x1e09,
+	{0x0044, 0x0307}: 0x1e0a,
+	{0x0064, 0x0307}: 0x1e0b,
+	{0x0044, 0x0323}: 0x1e0c,
+	{0x0064, 0x0323}: 0x1e0d,
+	{0x0044, 0x0331}: 0x1e0e,
+	{0x0064, 0x0331}: 0x1e0f,
+	{0x0044, 0x0327}: 0x1e10,
+	{0x0064, 0x0327}: 0x1e11,
+	{0x0044, 0x032d}: 0x1e12,
+	{0x0064, 0x032d}: 0x1e13,
+	{0x0112, 0x0300}: 0x1e14,
+	{0x0113, 0x0300}: 0x1e15,
+	{0x0112, 0x0301}: 0x1e16,
+	{0x0113, 0x0301}: 0x1e17,
+	{0x0045, 0x032d}: 0x1e18,
+	{0x0065, 0x032d}: 0x1e19,
+	{0x0045, 0x0330}: 0x1e1a,
+	{0x0065, 0x0330}: 0x1e1b,
+	{0x0228, 0x0306}: 0x1e1c,
+	{0x0229, 0x0306}: 0x1e1d,
+	{0x0046, 0x0307}: 0x1e1e,
+	{0x0066, 0x0307}: 0x1e1f,
+	{0x0047, 0x0304}: 0x1e20,
+	{0x0067, 0x0304}: 0x1e21,
+	{0x0048, 0x0307}: 0x1e22,
+	{0x0068, 0x0307}: 0x1e23,
+	{0x0048, 0x0323}: 0x1e24,
+	{0x0068, 0x0323}: 0x1e25,
+	{0x0048, 0x0308}: 0x1e26,
+	{0x0068, 0x0308}: 0x1e27,
+	{0x0048, 0x0327}: 0x1e28,
+	{0x0068, 0x0327}: 0x1e29,
+	{0x0048, 0x032e}: 0x1e2a,
+	{0x0068, 0x032e}: 0x1e2b,
+	{0x0049, 0x0330}: 0x1e2c,
+	{0x0069, 0x0330}: 0x1e2d,
+	{0x00cf, 0x0301}: 0x1e2e,
+	{0x00ef, 0x0301}: 0x1e2f,
+	{0x004b, 0x0301}: 0x1e30,
+	{0x006b, 0x0301}: 0x1e31,
+	{0x004b, 0x0323}: 0x1e32,
+	{0x006b, 0x0323}: 0x1e33,
+	{0x004b, 0x0331}: 0x1e34,
+	{0x006b, 0x0331}: 0x1e35,
+	{0x004c, 0x0323}: 0x1e36,
+	{0x006c, 0x0323}: 0x1e37,
+	{0x1e36, 0x0304}: 0x1e38,
+	{0x1e37, 0x0304}: 0x1e39,
+	{0x004c, 0x0331}: 0x1e3a,
+	{0x006c, 0x0331}: 0x1e3b,
+	{0x004c, 0x032d}: 0x1e3c,
+	{0x006c, 0x032d}: 0x1e3d,
+	{0x004d, 0x0301}: 0x1e3e,
+	{0x006d, 0x0301}: 0x1e3f,
+	{0x004d, 0x0307}: 0x1e40,
+	{0x006d, 0x0307}: 0x1e41,
+	{0x004d, 0x0323}: 0x1e42,
+	{0x006d, 0x0323}: 0x1e43,
+	{0x004e, 0x0307}: 0x1e44,
+	{0x006e, 0x0307}: 0x1e45,
+	{0x004e, 0x0323}: 0x1e46,
+	{0x006e, 0x0323}: 0x1e47,
+	{0x004e, 0x0331}: 0x1e48,
+	{0x006e, 0x0331}: 0x1e49,
+	{0x004e, 0x032d}: 0x1e4a,
+	{0x006e, 0x032d}: 0x1e4b,
+	{0x00d5, 0x0301}: 0x1e4c,
+	{0x00f5, 0x0301}: 0x1e4d,
+	{0x00d5, 0x0308}: 0x1e4e,
+	{0x00f5, 0x0308}: 0x1e4f,
+	{0x014c, 0x0300}: 0x1e50,
+	{0x014d, 0x0300}: 0x1e51,
+	{0x014c, 0x0301}: 0x1e52,
+	{0x014d, 0x0301}: 0x1e53,
+	{0x0050, 0x0301}: 0x1e54,
+	{0x0070, 0x0301}: 0x1e55,
+	{0x0050, 0x0307}: 0x1e56,
+	{0x0070, 0x0307}: 0x1e57,
+	{0x0052, 0x0307}: 0x1e58,
+	{0x0072, 0x0307}: 0x1e59,
+	{0x0052, 0x0323}: 0x1e5a,
+	{0x0072, 0x0323}: 0x1e5b,
+	{0x1e5a, 0x0304}: 0x1e5c,
+	{0x1e5b, 0x0304}: 0x1e5d,
+	{0x0052, 0x0331}: 0x1e5e,
+	{0x0072, 0x0331}: 0x1e5f,
+	{0x0053, 0x0307}: 0x1e60,
+	{0x0073, 0x0307}: 0x1e61,
+	{0x0053, 0x0323}: 0x1e62,
+	{0x0073, 0x0323}: 0x1e63,
+	{0x015a, 0x0307}: 0x1e64,
+	{0x015b, 0x0307}: 0x1e65,
+	{0x0160, 0x0307}: 0x1e66,
+	{0x0161, 0x0307}: 0x1e67,
+	{0x1e62, 0x0307}: 0x1e68,
+	{0x1e63, 0x0307}: 0x1e69,
+	{0x0054, 0x0307}: 0x1e6a,
+	{0x0074, 0x0307}: 0x1e6b,
+	{0x0054, 0x0323}: 0x1e6c,
+	{0x0074, 0x0323}: 0x1e6d,
+	{0x0054, 0x0331}: 0x1e6e,
+	{0x0074, 0x0331}: 0x1e6f,
+	{0x0054, 0x032d}: 0x1e70,
+	{0x0074, 0x032d}: 0x1e71,
+	{0x0055, 0x0324}: 0x1e72,
+	{0x0075, 0x0324}: 0x1e73,
+	{0x0055, 0x0330}: 0x1e74,
+	{0x0075, 0x0330}: 0x1e75,
+	{0x0055, 0x032d}: 0x1e76,
+	{0x0075, 0x032d}: 0x1e77,
+	{0x0168, 0x0301}: 0x1e78,
+	{0x0169, 0x0301}: 0x1e79,
+	{0x016a, 0x0308}: 0x1e7a,
+	{0x016b, 0x0308}: 0x1e7b,
+	{0x0056, 0x0303}: 0x1e7c,
+	{0x0076, 0x0303}: 0x1e7d,
+	{0x0056, 0x0323}: 0x1e7e,
+	{0x0076, 0x0323}: 0x1e7f,
+	{0x0057, 0x0300}: 0x1e80,
+	{0x0077, 0x0300}: 0x1e81,
+	{0x0057, 0x0301}: 0x1e82,
+	{0x0077, 0x0301}: 0x1e83,
+	{0x0057, 0x0308}: 0x1e84,
+	{0x0077, 0x0308}: 0x1e85,
+	{0x0057, 0x0307}: 0x1e86,
+	{0x0077, 0x0307}: 0x1e87,
+	{0x0057, 0x0323}: 0x1e88,
+	{0x0077, 0x0323}: 0x1e89,
+	{0x0058, 0x0307}: 0x1e8a,
+	{0x0078, 0x0307}: 0x1e8b,
+	{0x0058, 0x0308}: 0x1e8c,
+	{0x0078, 0x0308}: 0x1e8d,
+	{0x0059, 0x0307}: 0x1e8e,
+	{0x0079, 0x0307}: 0x1e8f,
+	{0x005a, 0x0302}: 0x1e90,
+	{0x007a, 0x0302}: 0x1e91,
+	{0x005a, 0x0323}: 0x1e92,
+	{0x007a, 0x0323}: 0x1e93,
+	{0x005a, 0x0331}: 0x1e94,
+	{0x007a, 0x0331}: 0x1e95,
+	{0x0068, 0x0331}: 0x1e96,
+	{0x0074, 0x0308}: 0x1e97,
+	{0x0077, 0x030a}: 0x1e98,
+	{0x0079, 0x030a}: 0x1e99,
+	{0x017f, 0x0307}: 0x1e9b,
+	{0x0041, 0x0323}: 0x1ea0,
+	{0x0061, 0x0323}: 0x1ea1,
+	{0x0041, 0x0309}: 0x1ea2,
+	{0x0061, 0x0309}: 0x1ea3,
+	{0x00c2, 0x0301}: 0x1ea4,
+	{0x00e2, 0x0301}: 0x1ea5,
+	{0x00c2, 0x0300}: 0x1ea6,
+	{0x00e2, 0x0300}: 0x1ea7,
+	{0x00c2, 0x0309}: 0x1ea8,
+	{0x00e2, 0x0309}: 0x1ea9,
+	{0x00c2, 0x0303}: 0x1eaa,
+	{0x00e2, 0x0303}: 0x1eab,
+	{0x1ea0, 0x0302}: 0x1eac,
+	{0x1ea1, 0x0302}: 0x1ead,
+	{0x0102, 0x0301}: 0x1eae,
+	{0x0103, 0x0301}: 0x1eaf,
+	{0x0102, 0x0300}: 0x1eb0,
+	{0x0103, 0x0300}: 0x1eb1,
+	{0x0102, 0x0309}: 0x1eb2,
+	{0x0103, 0x0309}: 0x1eb3,
+	{0x0102, 0x0303}: 0x1eb4,
+	{0x0103, 0x0303}: 0x1eb5,
+	{0x1ea0, 0x0306}: 0x1eb6,
+	{0x1ea1, 0x0306}: 0x1eb7,
+	{0x0045, 0x0323}: 0x1eb8,
+	{0x0065, 0x0323}: 0x1eb9,
+	{0x0045, 0x0309}: 0x1eba,
+	{0x0065, 0x0309}: 0x1ebb,
+	{0x0045, 0x0303}: 0x1ebc,
+	{0x0065, 0x0303}: 0x1ebd,
+	{0x00ca, 0x0301}: 0x1ebe,
+	{0x00ea, 0x0301}: 0x1ebf,
+	{0x00ca, 0x0300}: 0x1ec0,
+	{0x00ea, 0x0300}: 0x1ec1,
+	{0x00ca, 0x0309}: 0x1ec2,
+	{0x00ea, 0x0309}: 0x1ec3,
+	{0x00ca, 0x0303}: 0x1ec4,
+	{0x00ea, 0x0303}: 0x1ec5,
+	{0x1eb8, 0x0302}: 0x1ec6,
+	{0x1eb9, 0x0302}: 0x1ec7,
+	{0x0049, 0x0309}: 0x1ec8,
+	{0x0069, 0x0309}: 0x1ec9,
+	{0x0049, 0x0323}: 0x1eca,
+	{0x0069, 0x0323}: 0x1ecb,
+	{0x004f, 0x0323}: 0x1ecc,
+	{0x006f, 0x0323}: 0x1ecd,
+	{0x004f, 0x0309}: 0x1ece,
+	{0x006f, 0x0309}: 0x1ecf,
+	{0x00d4, 0x0301}: 0x1ed0,
+	{0x00f4, 0x0301}: 0x1ed1,
+	{0x00d4, 0x0300}: 0x1ed2,
+	{0x00f4, 0x0300}: 0x1ed3,
+	{0x00d4, 0x0309}: 0x1ed4,
+	{0x00f4, 0x0309}: 0x1ed5,
+	{0x00d4, 0x0303}: 0x1ed6,
+	{0x00f4, 0x0303}: 0x1ed7,
+	{0x1ecc, 0x0302}: 0x1ed8,
+	{0x1ecd, 0x0302}: 0x1ed9,
+	{0x01a0, 0x0301}: 0x1eda,
+	{0x01a1, 0x0301}: 0x1edb,
+	{0x01a0, 0x0300}: 0x1edc,
+	{0x01a1, 0x0300}: 0x1edd,
+	{0x01a0, 0x0309}: 0x1ede,
+	{0x01a1, 0x0309}: 0x1edf,
+	{0x01a0, 0x0303}: 0x1ee0,
+	{0x01a1, 0x0303}: 0x1ee1,
+	{0x01a0, 0x0323}: 0x1ee2,
+	{0x01a1, 0x0323}: 0x1ee3,
+	{0x0055, 0x0323}: 0x1ee4,
+	{0x0075, 0x0323}: 0x1ee5,
+	{0x0055, 0x0309}: 0x1ee6,
+	{0x0075, 0x0309}: 0x1ee7,
+	{0x01af, 0x0301}: 0x1ee8,
+	{0x01b0, 0x0301}: 0x1ee9,
+	{0x01af, 0x0300}: 0x1eea,
+	{0x01b0, 0x0300}: 0x1eeb,
+	{0x01af, 0x0309}: 0x1eec,
+	{0x01b0, 0x0309}: 0x1eed,
+	{0x01af, 0x0303}: 0x1eee,
+	{0x01b0, 0x0303}: 0x1eef,
+	{0x01af, 0x0323}: 0x1ef0,
+	{0x01b0, 0x0323}: 0x1ef1,
+	{0x0059, 0x0300}: 0x1ef2,
+	{0x0079, 0x0300}: 0x1ef3,
+	{0x0059, 0x0323}: 0x1ef4,
+	{0x0079, 0x0323}: 0x1ef5,
+	{0x0059, 0x0309}: 0x1ef6,
+	{0x0079, 0x0309}: 0x1ef7,
+	{0x0059, 0x0303}: 0x1ef8,
+	{0x0079, 0x0303}: 0x1ef9,
+}
@@ -0,0 +1,210 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/sqlparser"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// Router.CostPolicy
+const (
+	QueryCostReject       = "reject"
+	QueryCostDeprioritize = "deprioritize" // go to analytics replica, or reject if it couldn't.
+)
+
+// IsQueryCostPolicy check query_cost_policy, empty is reject.
+func IsQueryCostPolicy(policy string) bool {
+	switch strings.ToLower(policy) {
+	case "", QueryCostReject, QueryCostDeprioritize:
+		return true
+	}
+	return false
+}
+
+// CostStats is sampled statistics of tables, used by cost estimation of select.
+type CostStats interface {
+	// GetTableRows return estimated rows of table summed over nodes, -1 if not sampled.
+	GetTableRows(schema, table string) int64
+	// GetCardinality of leading index column, summed over nodes if it's unique or shard key, otherwise max of nodes,
+	// -1 if not sampled.
+	GetCardinality(schema, table, column string) int64
+}
+
+// queryCost is estimated rows examined by select, tables not sampled are free.
+type queryCost struct {
+	stats   CostStats
+	schema  string
+	fanout  int // nodes of plan.
+	nodes   int // nodes of schema.
+	rows    int64
+	reasons []string
+}
+
+// checkQueryCost reject or deprioritize select, whose estimated cost is greater than MaxQueryCost.
+func (r *Router) checkQueryCost(plan *normalPlan) error {
+	if r.MaxQueryCost <= 0 || r.Stats == nil {
+		return nil
+	}
+	statement, ok := plan.Statement.(sqlparser.SelectStatement)
+	schemaConfig := r.Schemas[r.SchemaName]
+	if !ok || schemaConfig == nil || len(schemaConfig.Nodes) == 0 {
+		return nil
+	}
+	cost := &queryCost{stats: r.Stats, schema: schemaConfig.Name, fanout: len(plan.nodeNames), nodes: len(schemaConfig.Nodes)}
+	if plan.anyNode {
+		cost.fanout = 1
+	}
+	cost.estimate(statement)
+	if cost.rows <= r.MaxQueryCost {
+		return nil
+	}
+	if cost.fanout > 1 && schemaConfig.ShardEnabled() {
+		cost.reasons = append(cost.reasons, fmt.Sprintf("fan-out to %d nodes without shard key", cost.fanout))
+	}
+	reason := fmt.Sprintf("estimated cost %d exceeds max_query_cost %d: %s",
+		cost.rows, r.MaxQueryCost, strings.Join(cost.reasons, ", "))
+	if strings.EqualFold(r.CostPolicy, QueryCostDeprioritize) && plan.onSlave {
+		plan.onAnalytics = true
+		simplelog.Info("%s %s %s user=%s,reason=%s,sql=%s", "route", "checkQueryCost", "Deprioritized to analytics replica",
+			r.User, reason, sqlparser.String(statement))
+		return nil
+	}
+	simplelog.Warn("%s %s %s user=%s,reason=%s,sql=%s", "route", "checkQueryCost", "Rejected expensive query",
+		r.User, reason, sqlparser.String(statement))
+	return mysql.NewError(mysql.ER_TOO_BIG_SELECT, "Query is rejected, "+reason)
+}
+
+func (cost *queryCost) estimate(statement sqlparser.SelectStatement) {
+	switch v := statement.(type) {
+	case *sqlparser.Select:
+		if v.With != nil {
+			for _, cte := range v.With.CTEs {
+				cost.estimate(cte.Select)
+			}
+		}
+		var filters []*sqlparser.ColName
+		if v.Where != nil {
+			filters = collectFilterColumns(v.Where.Expr, filters)
+		}
+		cost.estimateTableExprs(v.From, filters)
+	case *sqlparser.Union:
+		if v.With != nil {
+			for _, cte := range v.With.CTEs {
+				cost.estimate(cte.Select)
+			}
+		}
+		cost.estimate(v.Left)
+		cost.estimate(v.Right)
+	}
+}
+
+// estimateTableExprs add rows examined of each table, table with filter on leading index column is looked up by index,
+// otherwise it's full scanned at fan-out nodes.
+func (cost *queryCost) estimateTableExprs(tableExprs sqlparser.TableExprs, filters []*sqlparser.ColName) {
+	for _, tableExpr := range tableExprs {
+		switch v := tableExpr.(type) {
+		case *sqlparser.AliasedTableExpr:
+			switch expr := v.Expr.(type) {
+			case *sqlparser.TableName:
+				if sqlparser.IsSystemDB(strings.ToLower(string(expr.Qualifier))) {
+					continue
+				}
+				cost.estimateTable(strings.Trim(string(expr.Name), "`"), strings.Trim(string(v.As), "`"), filters)
+			case *sqlparser.Subquery:
+				cost.estimate(expr.Select)
+			}
+		case *sqlparser.ParenTableExpr:
+			cost.estimateTableExprs(sqlparser.TableExprs{v.Expr}, filters)
+		case *sqlparser.JoinTableExpr:
+			// right table is looked up by columns of ON for each row of left.
+			cost.estimateTableExprs(sqlparser.TableExprs{v.LeftExpr}, filters)
+			joinFilters := filters
+			if v.On != nil {
+				joinFilters = collectFilterColumns(v.On, append([]*sqlparser.ColName{}, filters...))
+			}
+			cost.estimateTableExprs(sqlparser.TableExprs{v.RightExpr}, joinFilters)
+		}
+	}
+}
+
+func (cost *queryCost) estimateTable(table, alias string, filters []*sqlparser.ColName) {
+	rows := cost.stats.GetTableRows(cost.schema, table)
+	if rows < 0 {
+		return
+	}
+	var cardinality int64
+	for _, col := range filters {
+		qualifier := strings.Trim(string(col.Qualifier), "`")
+		if len(qualifier) > 0 && !strings.EqualFold(qualifier, table) && !strings.EqualFold(qualifier, alias) {
+			continue
+		}
+		if c := cost.stats.GetCardinality(cost.schema, table, strings.Trim(string(col.Name), "`")); c > cardinality {
+			cardinality = c
+		}
+	}
+	if cardinality > 0 {
+		// rows of a key are at the same node if routed by shard key, or spread over fan-out nodes.
+		if examined := rows / cardinality; examined > 0 {
+			cost.rows += examined
+		} else {
+			cost.rows++
+		}
+		return
+	}
+	examined := rows * int64(cost.fanout) / int64(cost.nodes)
+	cost.rows += examined
+	cost.reasons = append(cost.reasons, fmt.Sprintf("full scan of %s (~%d rows) without index on filter columns", table, examined))
+}
+
+// collectFilterColumns of conditions joined by AND, that compare column with value or another column.
+func collectFilterColumns(expr sqlparser.BoolExpr, filters []*sqlparser.ColName) []*sqlparser.ColName {
+	switch v := expr.(type) {
+	case *sqlparser.AndExpr:
+		filters = collectFilterColumns(v.Left, filters)
+		return collectFilterColumns(v.Right, filters)
+	case *sqlparser.ParenBoolExpr:
+		return collectFilterColumns(v.Expr, filters)
+	case *sqlparser.ComparisonExpr:
+		switch v.Operator {
+		case sqlparser.AST_NE, sqlparser.AST_NOT_IN, sqlparser.AST_NOT_LIKE,
+			sqlparser.AST_REGEXP, sqlparser.AST_NOT_REGEXP:
+			return filters
+		}
+		if col, ok := v.Left.(*sqlparser.ColName); ok {
+			filters = append(filters, col)
+		}
+		if col, ok := v.Right.(*sqlparser.ColName); ok {
+			filters = append(filters, col)
+		}
+	case *sqlparser.RangeCond:
+		if col, ok := v.Left.(*sqlparser.ColName); ok && v.Operator == sqlparser.AST_BETWEEN {
+			filters = append(filters, col)
+		}
+	}
+	return filters
+}
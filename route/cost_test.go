@@ -0,0 +1,102 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+type testCostStats struct {
+	rows          map[string]int64
+	cardinalities map[string]int64
+}
+
+func (stats *testCostStats) GetTableRows(schema, table string) int64 {
+	if rows, ok := stats.rows[schema+"."+table]; ok {
+		return rows
+	}
+	return -1
+}
+
+func (stats *testCostStats) GetCardinality(schema, table, column string) int64 {
+	if cardinality, ok := stats.cardinalities[schema+"."+table+"."+column]; ok {
+		return cardinality
+	}
+	return -1
+}
+
+func TestCheckQueryCost(t *testing.T) {
+	schemas := map[string]*config.SchemaConfig{"db": {
+		Name:     "db",
+		ShardKey: "tenant_id",
+		Nodes:    []string{"node1", "node2"},
+		Tables:   []config.TableConfig{{Name: "t1"}, {Name: "t2"}},
+	}}
+	nodes := map[string]*config.NodeConfig{"node1": {Name: "node1"}, "node2": {Name: "node2"}}
+	stats := &testCostStats{
+		rows:          map[string]int64{"db.t1": 100000},
+		cardinalities: map[string]int64{"db.t1.id": 100000},
+	}
+	cases := []struct {
+		sql       string
+		policy    string
+		inTrans   bool
+		err       string
+		analytics bool
+	}{
+		{"select * from t1 where tenant_id = 1 and state = 1", "", false, "full scan of t1 (~50000 rows)", false},
+		{"select /*!saashard nodes=node1,node2 */ * from t1 where state = 1", "", false, "fan-out to 2 nodes", false},
+		{"select * from t1 where tenant_id = 1 and id = 5", "", false, "", false},
+		{"select * from t2 where tenant_id = 1 and state = 1", "", false, "", false},
+		{"select * from t1 where tenant_id = 1 and state = 1", QueryCostDeprioritize, false, "", true},
+		{"select * from t1 where tenant_id = 1 and state = 1", QueryCostDeprioritize, true, "full scan of t1", false},
+	}
+	for _, c := range cases {
+		statement, err := sqlparser.Parse(c.sql)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", c.sql, err)
+		}
+		router := NewRouter("db", schemas, nodes, 1, "u", c.inTrans)
+		router.MaxQueryCost = 1000
+		router.Stats = stats
+		router.CostPolicy = c.policy
+		plan, err := router.BuildNormalPlan(statement)
+		if len(c.err) > 0 {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("BuildNormalPlan(%q) error = %v, want %q", c.sql, err, c.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("BuildNormalPlan(%q) error: %v", c.sql, err)
+			continue
+		}
+		if plan.OnAnalytics() != c.analytics {
+			t.Errorf("BuildNormalPlan(%q) on analytics = %v, want %v", c.sql, plan.OnAnalytics(), c.analytics)
+		}
+	}
+}
@@ -36,7 +36,7 @@ func (r *Router) buildCreateTablePlan(statement *sqlparser.CreateTable) (*normal
 
 	plan := new(normalPlan)
 	if len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = schemaConfig.Nodes
 	}
@@ -53,7 +53,7 @@ func (r *Router) buildCreateIndexPlan(statement *sqlparser.CreateIndex) (*normal
 
 	plan := new(normalPlan)
 	if len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = schemaConfig.Nodes
 	}
@@ -70,10 +70,15 @@ func (r *Router) buildAlterTable(statement *sqlparser.AlterTable) (*normalPlan,
 
 	plan := new(normalPlan)
 	if len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = schemaConfig.Nodes
 	}
+	if !hint.AllowDestructive {
+		if err := r.checkAlterTableSafety(statement, plan.nodeNames); err != nil {
+			return nil, err
+		}
+	}
 	plan.Statement = statement
 	return plan, nil
 }
@@ -88,7 +93,7 @@ func (r *Router) buildRenameTablePlan(statement *sqlparser.RenameTable) (*normal
 
 	plan := new(normalPlan)
 	if len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = schemaConfig.Nodes
 	}
@@ -105,7 +110,39 @@ func (r *Router) buildDropTablePlan(statement *sqlparser.DropTable) (*normalPlan
 
 	plan := new(normalPlan)
 	if len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
+	} else {
+		plan.nodeNames = schemaConfig.Nodes
+	}
+	plan.Statement = statement
+	return plan, nil
+}
+
+func (r *Router) buildTruncateTablePlan(statement *sqlparser.TruncateTable) (*normalPlan, error) {
+	schemaConfig := r.Schemas[r.SchemaName]
+	statement.Name.Qualifier = nil
+	hint := ReadHint(&statement.Comments)
+
+	plan := new(normalPlan)
+	if len(hint.Nodes) > 0 {
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
+	} else {
+		plan.nodeNames = schemaConfig.Nodes
+	}
+	plan.Statement = statement
+	return plan, nil
+}
+
+func (r *Router) buildTableMaintenancePlan(statement *sqlparser.TableMaintenance) (*normalPlan, error) {
+	schemaConfig := r.Schemas[r.SchemaName]
+	for _, table := range statement.Tables {
+		table.Qualifier = nil
+	}
+	hint := ReadHint(&statement.Comments)
+
+	plan := new(normalPlan)
+	if len(hint.Nodes) > 0 {
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = schemaConfig.Nodes
 	}
@@ -122,7 +159,42 @@ func (r *Router) buildDropIndexPlan(statement *sqlparser.DropIndex) (*normalPlan
 
 	plan := new(normalPlan)
 	if len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
+	} else {
+		plan.nodeNames = schemaConfig.Nodes
+	}
+	if !hint.AllowDestructive {
+		if err := r.checkDropIndexSafety(statement, plan.nodeNames, string(statement.Table.Name), string(statement.Name)); err != nil {
+			return nil, err
+		}
+	}
+	plan.Statement = statement
+	return plan, nil
+}
+
+func (r *Router) buildCreateRoutinePlan(statement *sqlparser.CreateRoutine) (*normalPlan, error) {
+	schemaConfig := r.Schemas[r.SchemaName]
+	statement.Name.Qualifier = nil
+	hint := ReadHint(&statement.Comments)
+
+	plan := new(normalPlan)
+	if len(hint.Nodes) > 0 {
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
+	} else {
+		plan.nodeNames = schemaConfig.Nodes
+	}
+	plan.Statement = statement
+	return plan, nil
+}
+
+func (r *Router) buildDropRoutinePlan(statement *sqlparser.DropRoutine) (*normalPlan, error) {
+	schemaConfig := r.Schemas[r.SchemaName]
+	statement.Name.Qualifier = nil
+	hint := ReadHint(&statement.Comments)
+
+	plan := new(normalPlan)
+	if len(hint.Nodes) > 0 {
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = schemaConfig.Nodes
 	}
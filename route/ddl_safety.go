@@ -0,0 +1,277 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/sqlparser"
+	"github.com/berkaroad/saashard/utils/simplelog"
+)
+
+// TableInspector read table struct from backend, it's used by ddl safety check.
+type TableInspector interface {
+	// GetColumnType return column type like 'varchar(64)', or empty if column not exists.
+	GetColumnType(nodeName, table, column string) (string, error)
+	// GetIndexColumns return column names of the index, or empty if index not exists.
+	GetIndexColumns(nodeName, table, index string) ([]string, error)
+}
+
+// checkAlterTableSafety reject drop column, drop or truncate partition, narrowing column type and
+// dropping index that contains shard key.
+func (r *Router) checkAlterTableSafety(statement *sqlparser.AlterTable, nodeNames []string) error {
+	table := string(statement.Table.Name)
+	for _, spec := range statement.AlterSpecs {
+		switch v := spec.(type) {
+		case *sqlparser.DropColumnSpec:
+			return r.rejectDestructiveDDL(statement, "drop column "+sqlparser.String(v.ColumnName))
+		case *sqlparser.DropPartitionSpec:
+			return r.rejectDestructiveDDL(statement, sqlparser.String(v))
+		case *sqlparser.AddOrModifyColumnSpec:
+			if v.Action != "modify" {
+				continue
+			}
+			if err := r.checkColumnTypeSafety(statement, nodeNames, table, v.ColumnName, v.ColumnDef); err != nil {
+				return err
+			}
+		case *sqlparser.ChangeColumnSpec:
+			if err := r.checkColumnTypeSafety(statement, nodeNames, table, v.OldColumnName, v.ColumnDef); err != nil {
+				return err
+			}
+		case *sqlparser.DropIndexSpec:
+			if err := r.checkDropIndexSafety(statement, nodeNames, table, string(v.Name)); err != nil {
+				return err
+			}
+		case *sqlparser.DropPrimaryKeySpec:
+			if err := r.checkDropIndexSafety(statement, nodeNames, table, "PRIMARY"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkDropIndexSafety reject dropping index that contains shard key.
+func (r *Router) checkDropIndexSafety(statement sqlparser.Statement, nodeNames []string, table, index string) error {
+	schemaConfig := r.Schemas[r.SchemaName]
+	if !schemaConfig.ShardEnabled() || r.Inspector == nil || len(nodeNames) == 0 {
+		return nil
+	}
+	columns, err := r.Inspector.GetIndexColumns(nodeNames[0], table, index)
+	if err != nil {
+		return err
+	}
+	for _, column := range columns {
+		if strings.EqualFold(column, schemaConfig.ShardKey) {
+			return r.rejectDestructiveDDL(statement, "drop index "+index+" that contains shard key")
+		}
+	}
+	return nil
+}
+
+func (r *Router) checkColumnTypeSafety(statement sqlparser.Statement, nodeNames []string, table string,
+	columnName *sqlparser.ColName, columnDef *sqlparser.ColumnDefinition) error {
+	if r.Inspector == nil || len(nodeNames) == 0 || columnDef == nil || columnDef.Type == nil {
+		return nil
+	}
+	column := string(columnName.Name)
+	oldType, err := r.Inspector.GetColumnType(nodeNames[0], table, column)
+	if err != nil {
+		return err
+	}
+	if oldType == "" {
+		return nil
+	}
+	newType := columnDef.Type.TypeName
+	if columnDef.Type.IsUnsigned {
+		newType += " unsigned"
+	}
+	if isNarrowingColumnType(parseColumnType(oldType), parseColumnType(newType)) {
+		return r.rejectDestructiveDDL(statement, "narrow column "+column+" from "+oldType+" to "+newType)
+	}
+	return nil
+}
+
+func (r *Router) rejectDestructiveDDL(statement sqlparser.Statement, reason string) error {
+	simplelog.Warn("%s %s %s user=%s,reason=%s,sql=%s", "route", "rejectDestructiveDDL", errors.ErrDestructiveDDL.Error(),
+		r.User, reason, sqlparser.String(statement))
+	return errors.ErrDestructiveDDL
+}
+
+const (
+	typeFamilyUnknown = iota
+	typeFamilyInteger
+	typeFamilyDecimal
+	typeFamilyFloat
+	typeFamilyString
+	typeFamilyTemporal
+	typeFamilyEnum
+	typeFamilySet
+	typeFamilyBit
+)
+
+// columnType is parsed from column type, such as 'int(11) unsigned'.
+type columnType struct {
+	base     string
+	family   int
+	args     []string
+	unsigned bool
+}
+
+func parseColumnType(typ string) *columnType {
+	typ = strings.ToLower(strings.TrimSpace(typ))
+	ct := new(columnType)
+	ct.unsigned = strings.Contains(typ, " unsigned")
+	base := typ
+	if pos := strings.IndexAny(typ, "( "); pos > 0 {
+		base = typ[:pos]
+	}
+	if start, end := strings.Index(typ, "("), strings.LastIndex(typ, ")"); start > 0 && end > start {
+		for _, arg := range strings.Split(typ[start+1:end], ",") {
+			ct.args = append(ct.args, strings.Trim(strings.TrimSpace(arg), "'\""))
+		}
+	}
+	switch base {
+	case "integer":
+		base = "int"
+	case "bool", "boolean":
+		base = "tinyint"
+	case "real":
+		base = "double"
+	case "dec", "numeric", "fixed":
+		base = "decimal"
+	case "nchar":
+		base = "char"
+	case "nvarchar":
+		base = "varchar"
+	}
+	ct.base = base
+
+	switch base {
+	case "tinyint", "smallint", "mediumint", "int", "bigint":
+		ct.family = typeFamilyInteger
+	case "decimal":
+		ct.family = typeFamilyDecimal
+	case "float", "double":
+		ct.family = typeFamilyFloat
+	case "char", "varchar", "binary", "varbinary",
+		"tinytext", "text", "mediumtext", "longtext",
+		"tinyblob", "blob", "mediumblob", "longblob":
+		ct.family = typeFamilyString
+	case "date", "time", "datetime", "timestamp", "year":
+		ct.family = typeFamilyTemporal
+	case "enum":
+		ct.family = typeFamilyEnum
+	case "set":
+		ct.family = typeFamilySet
+	case "bit":
+		ct.family = typeFamilyBit
+	}
+	return ct
+}
+
+func (ct *columnType) arg(i int, defaultValue int) int {
+	if i < len(ct.args) {
+		if val, err := strconv.Atoi(ct.args[i]); err == nil {
+			return val
+		}
+	}
+	return defaultValue
+}
+
+var integerRanks = map[string]int{"tinyint": 1, "smallint": 2, "mediumint": 3, "int": 4, "bigint": 5}
+
+// integerDigits is the max digits of integer type.
+var integerDigits = map[string]int{"tinyint": 3, "smallint": 5, "mediumint": 8, "int": 10, "bigint": 20}
+
+var stringCapacities = map[string]int{
+	"tinytext": 255, "text": 65535, "mediumtext": 16777215, "longtext": 4294967295,
+	"tinyblob": 255, "blob": 65535, "mediumblob": 16777215, "longblob": 4294967295,
+}
+
+func (ct *columnType) stringCapacity() int {
+	if capacity, ok := stringCapacities[ct.base]; ok {
+		return capacity
+	}
+	return ct.arg(0, 1)
+}
+
+// isNarrowingColumnType check whether column type change may lose data.
+// Changing between different type families is treated as narrowing,
+// except integer to decimal with enough digits.
+func isNarrowingColumnType(oldType, newType *columnType) bool {
+	if oldType.family == typeFamilyUnknown || newType.family == typeFamilyUnknown {
+		return oldType.base != newType.base
+	}
+
+	switch {
+	case oldType.family == typeFamilyInteger && newType.family == typeFamilyInteger:
+		oldRank, newRank := integerRanks[oldType.base], integerRanks[newType.base]
+		if !oldType.unsigned && newType.unsigned {
+			return true
+		}
+		if oldType.unsigned && !newType.unsigned {
+			return newRank <= oldRank
+		}
+		return newRank < oldRank
+	case oldType.family == typeFamilyInteger && newType.family == typeFamilyDecimal:
+		precision, scale := newType.arg(0, 10), newType.arg(1, 0)
+		return precision-scale < integerDigits[oldType.base] || (!oldType.unsigned && newType.unsigned)
+	case oldType.family == typeFamilyDecimal && newType.family == typeFamilyDecimal:
+		oldPrecision, oldScale := oldType.arg(0, 10), oldType.arg(1, 0)
+		newPrecision, newScale := newType.arg(0, 10), newType.arg(1, 0)
+		return newScale < oldScale || newPrecision-newScale < oldPrecision-oldScale || (!oldType.unsigned && newType.unsigned)
+	case oldType.family == typeFamilyFloat && newType.family == typeFamilyFloat:
+		return oldType.base == "double" && newType.base == "float" || (!oldType.unsigned && newType.unsigned)
+	case oldType.family == typeFamilyString && newType.family == typeFamilyString:
+		return newType.stringCapacity() < oldType.stringCapacity()
+	case oldType.family == typeFamilyTemporal && newType.family == typeFamilyTemporal:
+		if oldType.base == newType.base {
+			return newType.arg(0, 0) < oldType.arg(0, 0)
+		}
+		switch oldType.base + ">" + newType.base {
+		case "date>datetime", "date>timestamp", "timestamp>datetime":
+			return newType.arg(0, 0) < oldType.arg(0, 0)
+		}
+		return true
+	case oldType.family == newType.family && (oldType.family == typeFamilyEnum || oldType.family == typeFamilySet):
+		for _, value := range oldType.args {
+			found := false
+			for _, newValue := range newType.args {
+				if value == newValue {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return true
+			}
+		}
+		return false
+	case oldType.family == typeFamilyBit && newType.family == typeFamilyBit:
+		return newType.arg(0, 1) < oldType.arg(0, 1)
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+func TestHintNodesOfExpandedSchema(t *testing.T) {
+	schemas := map[string]*config.SchemaConfig{"db": {
+		Name:     "db",
+		ShardKey: "tenant_id",
+		Nodes:    []string{"node1_0000", "node1_0001", "node2_0002", "node2_0003"},
+		ExpandedNodes: map[string][]string{
+			"node1": {"node1_0000", "node1_0001"},
+			"node2": {"node2_0002", "node2_0003"},
+		},
+	}}
+	nodes := make(map[string]*config.NodeConfig)
+	for _, name := range schemas["db"].Nodes {
+		nodes[name] = &config.NodeConfig{Name: name}
+	}
+	cases := []struct {
+		sql   string
+		nodes []string
+	}{
+		{"create /*!saashard nodes=node1 */ table t (tenant_id int)", []string{"node1_0000", "node1_0001"}},
+		{"create /*!saashard nodes=node2_0003 */ table t (tenant_id int)", []string{"node2_0003"}},
+		{"create /*!saashard nodes=node1,node2_0002 */ table t (tenant_id int)", []string{"node1_0000", "node1_0001", "node2_0002"}},
+		{"create table t (tenant_id int)", []string{"node1_0000", "node1_0001", "node2_0002", "node2_0003"}},
+		{"drop /*!saashard nodes=node2 */ table t", []string{"node2_0002", "node2_0003"}},
+	}
+	for _, c := range cases {
+		statement, err := sqlparser.Parse(c.sql)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", c.sql, err)
+		}
+		router := NewRouter("db", schemas, nodes, 1, "u", false)
+		plan, err := router.BuildNormalPlan(statement)
+		if err != nil {
+			t.Fatalf("BuildNormalPlan(%q) error: %v", c.sql, err)
+		}
+		if nodes := plan.GetNodeNames(); !reflect.DeepEqual(nodes, c.nodes) {
+			t.Errorf("BuildNormalPlan(%q) nodes = %v, want %v", c.sql, nodes, c.nodes)
+		}
+	}
+}
@@ -25,9 +25,11 @@ package route
 import (
 	"strings"
 
+	"github.com/berkaroad/saashard/config"
 	"github.com/berkaroad/saashard/errors"
 	"github.com/berkaroad/saashard/net/mysql"
 	"github.com/berkaroad/saashard/sqlparser"
+	"github.com/berkaroad/saashard/utils"
 )
 
 func (r *Router) buildInsertPlan(statement *sqlparser.Insert) (*normalPlan, error) {
@@ -45,18 +47,23 @@ func (r *Router) buildInsertPlan(statement *sqlparser.Insert) (*normalPlan, erro
 			}
 		}
 
-		colValue, err := sqlparser.CheckColumnInInsertOrReplace(statement.Columns, statement.Rows, statement.OnDup, schemaConfig.ShardKey)
-		if err != nil {
+		var err error
+		if nodeIndex, err = insertOrReplaceShardIndex(schemaConfig, string(statement.Table.Name), &statement.Columns, statement.Rows, statement.OnDup); err != nil {
 			return nil, err
 		}
-
-		algo := ParseShardAlgorithm(schemaConfig.ShardAlgo)
-		nodeIndex, err = algo(sqlparser.String(colValue), len(schemaConfig.Nodes))
-		if err != nil {
+		if err = checkInsertSelectShard(schemaConfig, statement.Rows, nodeIndex); err != nil {
 			return nil, err
 		}
 	}
+	if err := r.rewriteSubShardInsertSelect(schemaConfig, statement.Rows, schemaConfig.Nodes[nodeIndex]); err != nil {
+		return nil, err
+	}
 
+	if err := r.rewriteSubShardTable(schemaConfig, statement.Table, schemaConfig.Nodes[nodeIndex], func(key string) (sqlparser.ValExpr, error) {
+		return sqlparser.CheckColumnInInsertOrReplace(statement.Columns, statement.Rows, statement.OnDup, key)
+	}); err != nil {
+		return nil, err
+	}
 	ReadHint(&statement.Comments)
 
 	plan := new(normalPlan)
@@ -91,22 +98,26 @@ func (r *Router) buildUpdatePlan(statement *sqlparser.Update) (*normalPlan, erro
 
 		// WHERE expression, should contain shardkey.
 		if statement.Where == nil || statement.Where.Expr == nil {
-			return nil, errors.ErrWhereOrJoinOnKey
+			return nil, orderedDMLError(errors.ErrWhereOrJoinOnKey, statement.OrderBy, statement.Limit)
 		}
 		var err error
 		var colValue sqlparser.ValExpr
 		if colValue, err = sqlparser.CheckColumnInBoolExpr(statement.Where.Expr, schemaConfig.ShardKey); err != nil {
-			return nil, err
+			return nil, orderedDMLError(err, statement.OrderBy, statement.Limit)
 		} else if colValue == nil {
-			return nil, errors.ErrWhereOrJoinOnKey
+			return nil, orderedDMLError(errors.ErrWhereOrJoinOnKey, statement.OrderBy, statement.Limit)
 		}
 
-		algo := ParseShardAlgorithm(schemaConfig.ShardAlgo)
-		nodeIndex, err = algo(sqlparser.String(colValue), len(schemaConfig.Nodes))
+		nodeIndex, err = ShardIndex(schemaConfig, sqlparser.String(colValue))
 		if err != nil {
 			return nil, err
 		}
 	}
+	if err := r.rewriteSubShardTable(schemaConfig, statement.Table, schemaConfig.Nodes[nodeIndex], func(key string) (sqlparser.ValExpr, error) {
+		return sqlparser.CheckColumnInBoolExpr(whereExpr(statement.Where), key)
+	}); err != nil {
+		return nil, orderedDMLError(err, statement.OrderBy, statement.Limit)
+	}
 	ReadHint(&statement.Comments)
 
 	plan := new(normalPlan)
@@ -133,22 +144,26 @@ func (r *Router) buildDeletePlan(statement *sqlparser.Delete) (*normalPlan, erro
 
 		// WHERE expression, should contain shardkey.
 		if statement.Where == nil || statement.Where.Expr == nil {
-			return nil, errors.ErrWhereOrJoinOnKey
+			return nil, orderedDMLError(errors.ErrWhereOrJoinOnKey, statement.OrderBy, statement.Limit)
 		}
 		var err error
 		var colValue sqlparser.ValExpr
 		if colValue, err = sqlparser.CheckColumnInBoolExpr(statement.Where.Expr, schemaConfig.ShardKey); err != nil {
-			return nil, err
+			return nil, orderedDMLError(err, statement.OrderBy, statement.Limit)
 		} else if colValue == nil {
-			return nil, errors.ErrWhereOrJoinOnKey
+			return nil, orderedDMLError(errors.ErrWhereOrJoinOnKey, statement.OrderBy, statement.Limit)
 		}
 
-		algo := ParseShardAlgorithm(schemaConfig.ShardAlgo)
-		nodeIndex, err = algo(sqlparser.String(colValue), len(schemaConfig.Nodes))
+		nodeIndex, err = ShardIndex(schemaConfig, sqlparser.String(colValue))
 		if err != nil {
 			return nil, err
 		}
 	}
+	if err := r.rewriteSubShardTable(schemaConfig, statement.Table, schemaConfig.Nodes[nodeIndex], func(key string) (sqlparser.ValExpr, error) {
+		return sqlparser.CheckColumnInBoolExpr(whereExpr(statement.Where), key)
+	}); err != nil {
+		return nil, orderedDMLError(err, statement.OrderBy, statement.Limit)
+	}
 	ReadHint(&statement.Comments)
 
 	plan := new(normalPlan)
@@ -158,6 +173,19 @@ func (r *Router) buildDeletePlan(statement *sqlparser.Delete) (*normalPlan, erro
 	return plan, nil
 }
 
+// orderedDMLError of update or delete with order by or limit, that isn't in a single shard or sub-shard,
+// since global ordering across shards couldn't be honored.
+func orderedDMLError(err error, orderBy sqlparser.OrderBy, limit *sqlparser.Limit) error {
+	if len(orderBy) == 0 && limit == nil {
+		return err
+	}
+	switch err {
+	case errors.ErrWhereOrJoinOnKey, errors.ErrSubShardKey:
+		return errors.ErrOrderedDMLMulti
+	}
+	return err
+}
+
 func (r *Router) buildReplacePlan(statement *sqlparser.Replace) (*normalPlan, error) {
 	schemaConfig := r.Schemas[r.SchemaName]
 	statement.Table.Qualifier = nil
@@ -173,17 +201,22 @@ func (r *Router) buildReplacePlan(statement *sqlparser.Replace) (*normalPlan, er
 			}
 		}
 
-		colValue, err := sqlparser.CheckColumnInInsertOrReplace(statement.Columns, statement.Rows, nil, schemaConfig.ShardKey)
-		if err != nil {
+		var err error
+		if nodeIndex, err = insertOrReplaceShardIndex(schemaConfig, string(statement.Table.Name), &statement.Columns, statement.Rows, nil); err != nil {
 			return nil, err
 		}
-
-		algo := ParseShardAlgorithm(schemaConfig.ShardAlgo)
-		nodeIndex, err = algo(sqlparser.String(colValue), len(schemaConfig.Nodes))
-		if err != nil {
+		if err = checkInsertSelectShard(schemaConfig, statement.Rows, nodeIndex); err != nil {
 			return nil, err
 		}
 	}
+	if err := r.rewriteSubShardInsertSelect(schemaConfig, statement.Rows, schemaConfig.Nodes[nodeIndex]); err != nil {
+		return nil, err
+	}
+	if err := r.rewriteSubShardTable(schemaConfig, statement.Table, schemaConfig.Nodes[nodeIndex], func(key string) (sqlparser.ValExpr, error) {
+		return sqlparser.CheckColumnInInsertOrReplace(statement.Columns, statement.Rows, nil, key)
+	}); err != nil {
+		return nil, err
+	}
 	ReadHint(&statement.Comments)
 
 	plan := new(normalPlan)
@@ -192,3 +225,130 @@ func (r *Router) buildReplacePlan(statement *sqlparser.Replace) (*normalPlan, er
 
 	return plan, nil
 }
+
+// checkInsertSelectShard check tables of select in INSERT ... SELECT, are in the same shard as inserted rows.
+func checkInsertSelectShard(schemaConfig *config.SchemaConfig, rows sqlparser.InsertRows, nodeIndex int) error {
+	statement, ok := rows.(sqlparser.SelectStatement)
+	if _, isSimple := rows.(*sqlparser.SimpleSelect); !ok || isSimple {
+		return nil
+	}
+	if !schemaConfig.CheckTableDisabled {
+		if err := sqlparser.CheckTableExprsInSelect(statement, schemaConfig.GetTables()); err != nil {
+			return err
+		}
+	}
+	colValue, err := sqlparser.CheckColumnInSelect(statement, schemaConfig.ShardKey)
+	if err != nil {
+		return err
+	} else if colValue == nil {
+		return errors.ErrWhereOrJoinOnKey
+	}
+	selectIndex, err := ShardIndex(schemaConfig, sqlparser.String(colValue))
+	if err != nil {
+		return err
+	}
+	if selectIndex != nodeIndex {
+		return errors.ErrInsertSelectKey
+	}
+	return nil
+}
+
+func (r *Router) rewriteSubShardInsertSelect(schemaConfig *config.SchemaConfig, rows sqlparser.InsertRows, nodeName string) error {
+	if statement, ok := rows.(sqlparser.SelectStatement); ok {
+		return r.rewriteSubShardSelect(schemaConfig, statement, nodeName)
+	}
+	return nil
+}
+
+// insertOrReplaceShardIndex get index of node for insert or replace, rows with null or missing shard key follow shard_key_policy of table.
+func insertOrReplaceShardIndex(schemaConfig *config.SchemaConfig, table string, columns *sqlparser.Columns, rows sqlparser.InsertRows, onDup sqlparser.OnDup) (int, error) {
+	table = strings.Trim(strings.ToLower(table), "`")
+	if tableConfig, ok := schemaConfig.GetTables()[table]; ok && tableConfig.ShardKeyPolicy != "" {
+		return insertShardIndex(schemaConfig, tableConfig, columns, rows, onDup)
+	}
+
+	colValue, err := sqlparser.CheckColumnInInsertOrReplace(*columns, rows, onDup, schemaConfig.ShardKey)
+	if err != nil {
+		return 0, err
+	}
+	return ShardIndex(schemaConfig, sqlparser.String(colValue))
+}
+
+func (r *Router) buildCallPlan(statement *sqlparser.Call) (*normalPlan, error) {
+	schemaConfig := r.Schemas[r.SchemaName]
+	statement.Name.Qualifier = nil
+	hint := ReadHint(&statement.Comments)
+
+	plan := new(normalPlan)
+	if schemaConfig.ShardEnabled() {
+		// procedure couldn't be routed by shard key, so it runs in the single hinted node.
+		nodeNames := utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
+		if len(nodeNames) != 1 {
+			return nil, errors.ErrCallNode
+		}
+		plan.nodeNames = nodeNames
+	} else {
+		plan.nodeNames = []string{schemaConfig.Nodes[0]}
+	}
+	plan.Statement = statement
+	return plan, nil
+}
+
+func (r *Router) buildLoadDataPlan(statement *sqlparser.LoadData) (*normalPlan, error) {
+	if statement.Local {
+		return nil, errors.ErrLocalInFile
+	}
+	schemaConfig := r.Schemas[r.SchemaName]
+	statement.Table.Qualifier = nil
+	hint := ReadHint(&statement.Comments)
+
+	nodeNames := []string{schemaConfig.Nodes[0]}
+	if schemaConfig.ShardEnabled() {
+		if !schemaConfig.CheckTableDisabled {
+			// check table exists or not.
+			table := string(statement.Table.Name)
+			table = strings.Trim(strings.ToLower(table), "`")
+			tables := schemaConfig.GetTables()
+			if _, ok := tables[table]; !ok {
+				return nil, mysql.NewDefaultError(mysql.ER_NO_SUCH_TABLE, r.SchemaName, table)
+			}
+		}
+
+		// rows of file couldn't be split by proxy, so shard key is set by SET clause, or it runs in the single hinted node.
+		if colValue := loadDataValue(statement.Set, schemaConfig.ShardKey); colValue != nil {
+			nodeIndex, err := ShardIndex(schemaConfig, sqlparser.String(colValue))
+			if err != nil {
+				return nil, err
+			}
+			nodeNames = []string{schemaConfig.Nodes[nodeIndex]}
+		} else if nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes); len(nodeNames) != 1 {
+			return nil, errors.ErrLoadDataKey
+		}
+	}
+	if err := r.rewriteSubShardTable(schemaConfig, statement.Table, nodeNames[0], func(key string) (sqlparser.ValExpr, error) {
+		return loadDataValue(statement.Set, key), nil
+	}); err != nil {
+		return nil, err
+	}
+
+	plan := new(normalPlan)
+	plan.nodeNames = nodeNames
+	plan.Statement = statement
+	return plan, nil
+}
+
+// loadDataValue get literal value of key in SET clause of load data, nil if not found.
+func loadDataValue(set sqlparser.UpdateExprs, key string) sqlparser.ValExpr {
+	for _, setExpr := range set {
+		colName := strings.Trim(strings.ToLower(string(setExpr.Name.Name)), "`")
+		if colName != key {
+			continue
+		}
+		switch v := setExpr.Expr.(type) {
+		case sqlparser.StrVal, sqlparser.NumVal:
+			return v
+		}
+		return nil
+	}
+	return nil
+}
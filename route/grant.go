@@ -0,0 +1,63 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package route
+
+import (
+	"strings"
+
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/sqlparser"
+	"github.com/berkaroad/saashard/utils"
+)
+
+// buildGrantPlan broadcast GRANT or REVOKE to nodes of schema, if allow_grant is true.
+// Privilege level should be in current schema, and it's rewritten to database of node.
+func (r *Router) buildGrantPlan(statement sqlparser.Statement, comments *sqlparser.Comments, level *sqlparser.PrivilegeLevel) (*normalPlan, error) {
+	if !r.AllowGrant {
+		return nil, errors.ErrGrantDenied
+	}
+	if level.Qualifier != nil {
+		if !strings.EqualFold(strings.Trim(string(level.Qualifier), "`"), r.SchemaName) {
+			return nil, errors.ErrGrantLevel
+		}
+		level.Qualifier = nil
+	}
+	return r.buildAccountPlan(statement, comments)
+}
+
+// buildAccountPlan broadcast CREATE USER, ALTER USER, DROP USER to nodes of schema, if allow_grant is true.
+func (r *Router) buildAccountPlan(statement sqlparser.Statement, comments *sqlparser.Comments) (*normalPlan, error) {
+	if !r.AllowGrant {
+		return nil, errors.ErrGrantDenied
+	}
+	schemaConfig := r.Schemas[r.SchemaName]
+	hint := ReadHint(comments)
+
+	plan := new(normalPlan)
+	if len(hint.Nodes) > 0 {
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
+	} else {
+		plan.nodeNames = schemaConfig.Nodes
+	}
+	plan.Statement = statement
+	return plan, nil
+}
@@ -30,14 +30,21 @@ import (
 )
 
 var hintPrefix = "/*!saashard "
+var hintCommentPrefix = "/* saashard:"
 var hintNodesPrefix = "nodes="
+var hintAllowDestructive = "allow_destructive"
+var hintAnalytics = "analytics"
 
 // Hint to extra process.
 // Nodes: /*!saashard nodes=node1,node2 */
 // OnMaster: /*!saashard master */
+// AllowDestructive: /*!saashard allow_destructive */ or /* saashard:allow_destructive */
+// OnAnalytics: /*!saashard analytics */
 type Hint struct {
-	OnMaster bool
-	Nodes    []string
+	OnMaster         bool
+	Nodes            []string
+	AllowDestructive bool
+	OnAnalytics      bool
 }
 
 // ReadHint read hint from comments
@@ -45,11 +52,16 @@ func ReadHint(comments *sqlparser.Comments) *Hint {
 	hint := new(Hint)
 	for i, comment := range *comments {
 		commentStr := string(comment)
-		if strings.HasPrefix(commentStr, hintPrefix) {
-			commentStr = strings.TrimSuffix(strings.TrimPrefix(commentStr, hintPrefix), "*/")
+		if strings.HasPrefix(commentStr, hintPrefix) || strings.HasPrefix(commentStr, hintCommentPrefix) {
+			commentStr = strings.TrimPrefix(strings.TrimPrefix(commentStr, hintPrefix), hintCommentPrefix)
+			commentStr = strings.TrimSuffix(commentStr, "*/")
 			commentStr = strings.ToLower(strings.TrimSpace(commentStr))
 			if commentStr == "master" {
 				hint.OnMaster = true
+			} else if commentStr == hintAllowDestructive {
+				hint.AllowDestructive = true
+			} else if commentStr == hintAnalytics {
+				hint.OnAnalytics = true
 			} else if strings.HasPrefix(commentStr, hintNodesPrefix) {
 				nodesStr := strings.TrimPrefix(commentStr, hintNodesPrefix)
 				for _, nodeStr := range strings.Split(nodesStr, ",") {
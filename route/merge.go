@@ -0,0 +1,255 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+// Merge describe how rows of fan-out select are merged in proxy, when they couldn't be concatenated.
+// If grouped, rows with the same GroupBy columns are aggregated into one row by combiners of aggregates,
+// then rows are sorted by OrderBy, then Offset and Count of LIMIT are applied.
+type Merge struct {
+	Grouped    bool
+	GroupBy    []int // columns of GROUP BY, or all columns of DISTINCT
+	Aggregates []MergeAggregate
+	OrderBy    []mysql.SortKey // ORDER BY, or GROUP BY columns if no ORDER BY
+	Offset     int64
+	Count      int64 // -1 if no limit
+	Columns    int   // columns of client if nodes return hidden columns after them, such as COUNT of AVG, or 0.
+}
+
+// MergeAggregate is aggregate function at column of select expressions.
+type MergeAggregate struct {
+	Column int
+	Func   *sqlparser.FuncExpr
+
+	// CountDistinct column is distinct values of nodes, see sqlparser.RewriteCountDistinct.
+	CountDistinct bool
+
+	// Avg column is SUM of AVG at nodes, it's divided by COUNT at hidden CountColumn after merged.
+	Avg         bool
+	CountColumn int
+}
+
+// newMerge build merge of select, and the select sent to nodes, COUNT(DISTINCT x) is rewritten to list distinct x,
+// and AVG(x) is rewritten to SUM(x), with COUNT(x) appended as hidden column.
+// It's nil if rows of nodes couldn't be merged, such as having, aggregate function without combiner or nested in expression,
+// group by or order by expression not in select expressions.
+func newMerge(statement *sqlparser.Select) (*Merge, *sqlparser.Select) {
+	if statement.Having != nil {
+		return nil, nil
+	}
+	merge := &Merge{Count: -1}
+	var avgs []int
+	for i, selectExpr := range statement.SelectExprs {
+		expr, ok := selectExpr.(*sqlparser.NonStarExpr)
+		if !ok {
+			return nil, nil
+		}
+		funcExpr, ok := expr.Expr.(*sqlparser.FuncExpr)
+		if !ok || !isAggregateFunc(funcExpr.Name) {
+			if hasAggregate(expr.Expr) {
+				return nil, nil
+			}
+			continue
+		}
+		if hasAggregate(funcExpr.Exprs) {
+			return nil, nil
+		}
+		if funcExpr.Distinct && strings.EqualFold(string(funcExpr.Name), "count") {
+			merge.Aggregates = append(merge.Aggregates, MergeAggregate{Column: i, Func: funcExpr, CountDistinct: true})
+			continue
+		}
+		if !funcExpr.Distinct && strings.EqualFold(string(funcExpr.Name), "avg") && len(funcExpr.Exprs) == 1 {
+			avgs = append(avgs, i)
+			continue
+		}
+		if _, ok = mysql.GetFuncCombiner(funcExpr, 0); !ok {
+			return nil, nil
+		}
+		merge.Aggregates = append(merge.Aggregates, MergeAggregate{Column: i, Func: funcExpr})
+	}
+	selectExprs := statement.SelectExprs
+	if len(avgs) > 0 {
+		selectExprs = append(sqlparser.SelectExprs{}, statement.SelectExprs...)
+		for _, i := range avgs {
+			expr := selectExprs[i].(*sqlparser.NonStarExpr)
+			funcExpr := expr.Expr.(*sqlparser.FuncExpr)
+			as := expr.As
+			if len(as) == 0 {
+				as = []byte(sqlparser.String(funcExpr))
+			}
+			sum := &sqlparser.FuncExpr{Name: []byte("sum"), Exprs: funcExpr.Exprs}
+			count := &sqlparser.FuncExpr{Name: []byte("count"), Exprs: funcExpr.Exprs}
+			selectExprs[i] = &sqlparser.NonStarExpr{Expr: sum, As: as}
+			merge.Aggregates = append(merge.Aggregates,
+				MergeAggregate{Column: i, Func: sum, Avg: true, CountColumn: len(selectExprs)},
+				MergeAggregate{Column: len(selectExprs), Func: count})
+			selectExprs = append(selectExprs, &sqlparser.NonStarExpr{Expr: count})
+		}
+		merge.Columns = len(statement.SelectExprs)
+	}
+	for _, expr := range statement.GroupBy {
+		column := selectColumn(statement.SelectExprs, expr)
+		if column < 0 {
+			return nil, nil
+		}
+		merge.GroupBy = append(merge.GroupBy, column)
+	}
+	if len(statement.Distinct) > 0 {
+		if len(merge.Aggregates) > 0 || len(merge.GroupBy) > 0 {
+			return nil, nil
+		}
+		for i := range statement.SelectExprs {
+			merge.GroupBy = append(merge.GroupBy, i)
+		}
+	}
+	merge.Grouped = len(merge.Aggregates) > 0 || len(merge.GroupBy) > 0
+	for _, order := range statement.OrderBy {
+		column := selectColumn(statement.SelectExprs, order.Expr)
+		if column < 0 {
+			return nil, nil
+		}
+		merge.OrderBy = append(merge.OrderBy, mysql.SortKey{Column: column, Desc: order.Direction == sqlparser.AST_DESC})
+	}
+	if len(statement.OrderBy) == 0 && len(statement.GroupBy) > 0 {
+		for _, column := range merge.GroupBy {
+			merge.OrderBy = append(merge.OrderBy, mysql.SortKey{Column: column})
+		}
+	}
+	if statement.Limit != nil {
+		var ok bool
+		if merge.Offset, merge.Count, ok = limitValues(statement.Limit); !ok {
+			return nil, nil
+		}
+	}
+
+	for _, aggregate := range merge.Aggregates {
+		if aggregate.CountDistinct {
+			// distinct values are listed by group by at nodes, it's the only aggregate.
+			rewritten, column := sqlparser.RewriteCountDistinct(statement)
+			if rewritten == nil || column != aggregate.Column {
+				return nil, nil
+			}
+			return merge, rewritten
+		}
+	}
+
+	nodeStatement := *statement
+	nodeStatement.SelectExprs = selectExprs
+	if merge.Grouped {
+		// all groups of nodes are merged before sorted and limited.
+		nodeStatement.OrderBy, nodeStatement.Limit = nil, nil
+	} else if statement.Limit != nil {
+		// each node returns rows till the last one of limit.
+		nodeStatement.Limit = &sqlparser.Limit{Rowcount: sqlparser.NumVal(strconv.FormatInt(merge.Offset+merge.Count, 10))}
+	}
+	return merge, &nodeStatement
+}
+
+// isAggregateFunc is true for built-in aggregate function, or function with registered combiner.
+func isAggregateFunc(name []byte) bool {
+	if aggregateFuncs[strings.ToLower(string(name))] {
+		return true
+	}
+	_, ok := mysql.GetCombiner(string(name))
+	return ok
+}
+
+// hasAggregate is true if node contains aggregate function or window function.
+func hasAggregate(node sqlparser.SQLNode) (found bool) {
+	buf := sqlparser.NewTrackedBuffer(func(buf *sqlparser.TrackedBuffer, node sqlparser.SQLNode) {
+		switch v := node.(type) {
+		case *sqlparser.FuncExpr:
+			found = found || isAggregateFunc(v.Name)
+		case *sqlparser.WindowExpr:
+			found = true
+		}
+		node.Format(buf)
+	})
+	buf.Fprintf("%v", node)
+	return
+}
+
+// selectColumn get index of select expression referred by expr of order by or group by,
+// that's position, alias or the same expression, -1 if not found.
+func selectColumn(selectExprs sqlparser.SelectExprs, expr sqlparser.ValExpr) int {
+	if num, ok := expr.(sqlparser.NumVal); ok {
+		if n, err := strconv.Atoi(string(num)); err == nil && n >= 1 && n <= len(selectExprs) {
+			return n - 1
+		}
+		return -1
+	}
+	colName, isColName := expr.(*sqlparser.ColName)
+	if isColName && colName.Qualifier == nil {
+		for i, selectExpr := range selectExprs {
+			if v, ok := selectExpr.(*sqlparser.NonStarExpr); ok && strings.EqualFold(string(v.As), string(colName.Name)) {
+				return i
+			}
+		}
+	}
+	name := sqlparser.String(expr)
+	for i, selectExpr := range selectExprs {
+		v, ok := selectExpr.(*sqlparser.NonStarExpr)
+		if !ok {
+			continue
+		}
+		if sqlparser.String(v.Expr) == name {
+			return i
+		}
+		// column of the same name, one of them isn't qualified.
+		if c, ok := v.Expr.(*sqlparser.ColName); ok && isColName && strings.EqualFold(string(c.Name), string(colName.Name)) &&
+			(c.Qualifier == nil || colName.Qualifier == nil) {
+			return i
+		}
+	}
+	return -1
+}
+
+// limitValues get offset and row count of limit, they should be numbers.
+func limitValues(limit *sqlparser.Limit) (offset, count int64, ok bool) {
+	if limit.Offset != nil {
+		v, isNum := limit.Offset.(sqlparser.NumVal)
+		if !isNum {
+			return 0, 0, false
+		}
+		var err error
+		if offset, err = strconv.ParseInt(string(v), 10, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	v, isNum := limit.Rowcount.(sqlparser.NumVal)
+	if !isNum {
+		return 0, 0, false
+	}
+	count, err := strconv.ParseInt(string(v), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return offset, count, true
+}
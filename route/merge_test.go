@@ -0,0 +1,106 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+func TestNewMerge(t *testing.T) {
+	cases := []struct {
+		sql       string
+		nodeSQL   string // empty if couldn't be merged
+		mergeDesc string
+	}{
+		{"select a, b from t order by b desc, 1", "select a, b from t order by b desc, 1 ", "group=false[] agg=[] order=[{1 true} {0 false}] limit=0,-1"},
+		{"select a, b as x from t order by x limit 10, 5", "select a, b as x from t order by x  limit 15", "group=false[] agg=[] order=[{1 false}] limit=10,5"},
+		{"select t.a from t order by a limit 3", "select t.a from t order by a  limit 3", "group=false[] agg=[] order=[{0 false}] limit=0,3"},
+		{"select b, count(*), sum(c) as s from t group by b order by s desc limit 2", "select b, count(*), sum(c) as s from t group by b",
+			"group=true[0] agg=[1 2] order=[{2 true}] limit=0,2"},
+		{"select count(*), max(a), bit_or(b) from t", "select count(*), max(a), bit_or(b) from t", "group=true[] agg=[0 1 2] order=[] limit=0,-1"},
+		{"select b, min(a) from t group by 1", "select b, min(a) from t group by 1", "group=true[0] agg=[1] order=[{0 false}] limit=0,-1"},
+		{"select g, group_concat(distinct a order by a desc separator ';') from t group by g",
+			"select g, group_concat(distinct a order by a desc separator ';') from t group by g", "group=true[0] agg=[1] order=[{0 false}] limit=0,-1"},
+		{"select distinct a, b from t limit 5", "select distinct a, b from t", "group=true[0 1] agg=[] order=[] limit=0,5"},
+		{"select g, count(distinct a) as n from t where b = 1 group by g order by n desc limit 1",
+			"select g, a as n from t where b = 1 group by g, a", "group=true[0] agg=[1d] order=[{1 true}] limit=0,1"},
+		{"select count(distinct a) from t", "select a as `count(distinct a)` from t group by a", "group=true[] agg=[0d] order=[] limit=0,-1"},
+		{"select count(distinct a), count(*) from t", "", ""},
+		{"select count(distinct a) from t group by b", "", ""},
+		{"select a from t order by b", "", ""},
+		{"select * from t order by a", "", ""},
+		{"select a from t order by a limit ?", "", ""},
+		{"select sum(a)+0 from t", "", ""},
+		{"select coalesce(max(a), 0) from t", "", ""},
+		{"select count(*) over () from t", "", ""},
+		{"select avg(a) from t", "select sum(a) as `avg(a)`, count(a) from t", "group=true[] agg=[0a1 1] order=[] limit=0,-1 columns=1"},
+		{"select g, avg(a) as x, count(*) from t group by g order by x desc limit 3",
+			"select g, sum(a) as x, count(*), count(a) from t group by g", "group=true[0] agg=[2 1a3 3] order=[{1 true}] limit=0,3 columns=3"},
+		{"select avg(distinct a) from t", "", ""},
+		{"select sum(distinct a) from t", "", ""},
+		{"select max(count(*)) from t", "", ""},
+		{"select count(*) from t group by b", "", ""},
+		{"select b, count(*) from t group by b having count(*) > 1", "", ""},
+		{"select distinct count(*) from t", "", ""},
+		{"select g, group_concat(a order by b) from t group by g", "", ""},
+	}
+	for _, c := range cases {
+		statement, err := sqlparser.Parse(c.sql)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", c.sql, err)
+		}
+		merge, nodeStatement := newMerge(statement.(*sqlparser.Select))
+		if c.nodeSQL == "" {
+			if merge != nil {
+				t.Errorf("newMerge(%q) = %+v, want nil", c.sql, merge)
+			}
+			continue
+		}
+		if merge == nil {
+			t.Errorf("newMerge(%q) = nil, want %s", c.sql, c.mergeDesc)
+			continue
+		}
+		if got := sqlparser.String(nodeStatement); got != c.nodeSQL {
+			t.Errorf("newMerge(%q) node sql = %q, want %q", c.sql, got, c.nodeSQL)
+		}
+		var columns []string
+		for _, aggregate := range merge.Aggregates {
+			if columns = append(columns, strconv.Itoa(aggregate.Column)); aggregate.CountDistinct {
+				columns[len(columns)-1] += "d"
+			} else if aggregate.Avg {
+				columns[len(columns)-1] += "a" + strconv.Itoa(aggregate.CountColumn)
+			}
+		}
+		got := fmt.Sprintf("group=%v%v agg=%v order=%v limit=%d,%d", merge.Grouped, merge.GroupBy, columns, merge.OrderBy, merge.Offset, merge.Count)
+		if merge.Columns > 0 {
+			got += fmt.Sprintf(" columns=%d", merge.Columns)
+		}
+		if got != c.mergeDesc {
+			t.Errorf("newMerge(%q) = %s, want %s", c.sql, got, c.mergeDesc)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+// Routing override targets.
+const (
+	OverrideMaster    = "master"
+	OverrideSlave     = "slave"
+	OverrideAnalytics = "analytics"
+)
+
+// applyOverride steer the plan by routing override of statement's fingerprint.
+// Write statements and statements in transaction never go to slave.
+func (r *Router) applyOverride(fingerprint string, plan *normalPlan) {
+	switch r.Overrides[fingerprint] {
+	case OverrideMaster:
+		plan.onSlave = false
+	case OverrideSlave, OverrideAnalytics:
+		if _, ok := plan.Statement.(sqlparser.SelectStatement); ok &&
+			!r.InTrans && !IsWriteStatement(plan.Statement) {
+			plan.onSlave = true
+		}
+	}
+}
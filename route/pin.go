@@ -0,0 +1,199 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+// pinnedRouter return router with schema of pinned node group, if tables of statement are pinned by pin_nodes.
+// Tables of statement must be in the same node group, otherwise it's rejected.
+// Router itself is returned if no table is pinned.
+func (r *Router) pinnedRouter(statement sqlparser.Statement) (*Router, error) {
+	schema := r.Schemas[r.SchemaName]
+	if schema == nil || !hasPinnedTable(schema) {
+		return r, nil
+	}
+	tables := make(map[string]bool)
+	collectTableNames(statement, tables)
+
+	group, first := "", true
+	var pinNodes []string
+	for table := range tables {
+		var nodes []string
+		if tableConfig := schema.GetTables()[table]; tableConfig != nil {
+			nodes = tableConfig.PinNodes
+		}
+		if first {
+			group, pinNodes, first = strings.Join(nodes, ","), nodes, false
+		} else if group != strings.Join(nodes, ",") {
+			return nil, errors.ErrCrossNodeGroup
+		}
+	}
+	if len(pinNodes) == 0 {
+		return r, nil
+	}
+
+	pinned := *schema
+	pinned.Nodes = pinNodes
+	if len(pinNodes) == 1 {
+		// no shard key is needed in single node.
+		pinned.ShardKey = ""
+	}
+	schemas := make(map[string]*config.SchemaConfig, len(r.Schemas))
+	for name, s := range r.Schemas {
+		schemas[name] = s
+	}
+	schemas[r.SchemaName] = &pinned
+
+	router := *r
+	router.Schemas = schemas
+	return &router, nil
+}
+
+func hasPinnedTable(schema *config.SchemaConfig) bool {
+	for _, table := range schema.GetTables() {
+		if len(table.PinNodes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// TableNames of dml and ddl statement in lower case and sorted, tables of system db are skipped.
+func TableNames(statement sqlparser.Statement) []string {
+	tables := make(map[string]bool)
+	collectTableNames(statement, tables)
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// collectTableNames of dml and ddl statement, tables of system db are skipped.
+func collectTableNames(statement sqlparser.Statement, tables map[string]bool) {
+	switch v := statement.(type) {
+	case *sqlparser.Select:
+		if v.With != nil {
+			local := make(map[string]bool)
+			collectTableExprNames(v.From, local)
+			collectCTETableNames(v.With, local, tables)
+			return
+		}
+		collectTableExprNames(v.From, tables)
+	case *sqlparser.Union:
+		if v.With != nil {
+			local := make(map[string]bool)
+			collectTableNames(v.Left, local)
+			collectTableNames(v.Right, local)
+			collectCTETableNames(v.With, local, tables)
+			return
+		}
+		collectTableNames(v.Left, tables)
+		collectTableNames(v.Right, tables)
+	case *sqlparser.Insert:
+		collectTableName(v.Table, tables)
+		if rows, ok := v.Rows.(sqlparser.SelectStatement); ok {
+			collectTableNames(rows, tables)
+		}
+	case *sqlparser.Replace:
+		collectTableName(v.Table, tables)
+		if rows, ok := v.Rows.(sqlparser.SelectStatement); ok {
+			collectTableNames(rows, tables)
+		}
+	case *sqlparser.Update:
+		collectTableName(v.Table, tables)
+	case *sqlparser.Delete:
+		collectTableName(v.Table, tables)
+	case *sqlparser.LoadData:
+		collectTableName(v.Table, tables)
+	case *sqlparser.CreateTable:
+		collectTableName(v.Table, tables)
+	case *sqlparser.AlterTable:
+		collectTableName(v.Table, tables)
+	case *sqlparser.DropTable:
+		collectTableName(v.Name, tables)
+	case *sqlparser.TruncateTable:
+		collectTableName(v.Name, tables)
+	case *sqlparser.TableMaintenance:
+		for _, table := range v.Tables {
+			collectTableName(table, tables)
+		}
+	case *sqlparser.LockTables:
+		for _, table := range v.Tables {
+			collectTableName(table.Table, tables)
+		}
+	case *sqlparser.CreateIndex:
+		collectTableName(v.Table, tables)
+	case *sqlparser.DropIndex:
+		collectTableName(v.Table, tables)
+	case *sqlparser.RenameTable:
+		collectTableName(v.OldName, tables)
+		collectTableName(v.NewName, tables)
+	}
+}
+
+// collectCTETableNames collect tables of common table expressions into local, then merge local into tables without names of common table expressions.
+func collectCTETableNames(with *sqlparser.With, local map[string]bool, tables map[string]bool) {
+	for _, cte := range with.CTEs {
+		collectTableNames(cte.Select, local)
+	}
+	for name := range with.Names() {
+		delete(local, name)
+	}
+	for name := range local {
+		tables[name] = true
+	}
+}
+
+func collectTableExprNames(tableExprs sqlparser.TableExprs, tables map[string]bool) {
+	for _, tableExpr := range tableExprs {
+		switch v := tableExpr.(type) {
+		case *sqlparser.AliasedTableExpr:
+			switch expr := v.Expr.(type) {
+			case *sqlparser.TableName:
+				collectTableName(expr, tables)
+			case *sqlparser.Subquery:
+				collectTableNames(expr.Select, tables)
+			}
+		case *sqlparser.ParenTableExpr:
+			collectTableExprNames(sqlparser.TableExprs{v.Expr}, tables)
+		case *sqlparser.JoinTableExpr:
+			collectTableExprNames(sqlparser.TableExprs{v.LeftExpr, v.RightExpr}, tables)
+		}
+	}
+}
+
+func collectTableName(tableName *sqlparser.TableName, tables map[string]bool) {
+	if tableName == nil || sqlparser.IsSystemDB(strings.ToLower(string(tableName.Qualifier))) {
+		return
+	}
+	tables[strings.Trim(strings.ToLower(string(tableName.Name)), "`")] = true
+}
@@ -0,0 +1,179 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/berkaroad/saashard/net/mysql"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+// Results of commonly probed statements by bi tools, synthesized by proxy,
+// so that they are the same whichever node is connected.
+
+type engine struct {
+	name, support, comment, transactions, xa, savepoints string
+}
+
+var engines = []engine{
+	{"InnoDB", "DEFAULT", "Supports transactions, row-level locking, and foreign keys", "YES", "YES", "YES"},
+	{"MEMORY", "YES", "Hash based, stored in memory, useful for temporary tables", "NO", "NO", "NO"},
+	{"MyISAM", "YES", "MyISAM storage engine", "NO", "NO", "NO"},
+}
+
+func newProbeField(table, name string, columnLength uint32, columnType uint8) *mysql.Field {
+	return &mysql.Field{Schema: []byte("information_schema"),
+		Table:        []byte(table),
+		OrgTable:     []byte(table),
+		Name:         []byte(name),
+		OrgName:      []byte(strings.ToUpper(name)),
+		Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+		ColumnLength: columnLength,
+		ColumnType:   columnType,
+		Flags:        mysql.NOT_NULL_FLAG,
+		Decimals:     0}
+}
+
+// likeMatcher match by 'like' pattern, nil matches all. It's not ok for 'where', that executes on backend.
+func likeMatcher(likeOrWhere sqlparser.Expr) (match func(name string) bool, ok bool) {
+	switch v := likeOrWhere.(type) {
+	case nil:
+		return func(name string) bool { return true }, true
+	case *sqlparser.LikeExpr:
+		if val, ok := v.Expr.(sqlparser.StrVal); ok {
+			var buf []string
+			for _, ch := range strings.ToLower(string(val)) {
+				switch ch {
+				case '%':
+					buf = append(buf, ".*")
+				case '_':
+					buf = append(buf, ".")
+				default:
+					buf = append(buf, regexp.QuoteMeta(string(ch)))
+				}
+			}
+			re := regexp.MustCompile("^" + strings.Join(buf, "") + "$")
+			return func(name string) bool { return re.MatchString(strings.ToLower(name)) }, true
+		}
+	}
+	return nil, false
+}
+
+func showEnginesResult() *mysql.Result {
+	result := new(mysql.Result)
+	result.Status = mysql.SERVER_STATUS_AUTOCOMMIT
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{
+		newProbeField("ENGINES", "Engine", 192, mysql.MYSQL_TYPE_VAR_STRING),
+		newProbeField("ENGINES", "Support", 24, mysql.MYSQL_TYPE_VAR_STRING),
+		newProbeField("ENGINES", "Comment", 240, mysql.MYSQL_TYPE_VAR_STRING),
+		newProbeField("ENGINES", "Transactions", 9, mysql.MYSQL_TYPE_VAR_STRING),
+		newProbeField("ENGINES", "XA", 9, mysql.MYSQL_TYPE_VAR_STRING),
+		newProbeField("ENGINES", "Savepoints", 9, mysql.MYSQL_TYPE_VAR_STRING),
+	}
+	result.Rows = make([]*mysql.Row, 0, len(engines))
+	for _, e := range engines {
+		row := mysql.NewTextRow(result.Resultset.Fields)
+		row.AppendStringValue(e.name)
+		row.AppendStringValue(e.support)
+		row.AppendStringValue(e.comment)
+		row.AppendStringValue(e.transactions)
+		row.AppendStringValue(e.xa)
+		row.AppendStringValue(e.savepoints)
+		result.Rows = append(result.Rows, row)
+	}
+	return result
+}
+
+func showCharsetResult(match func(name string) bool) *mysql.Result {
+	result := new(mysql.Result)
+	result.Status = mysql.SERVER_STATUS_AUTOCOMMIT
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{
+		newProbeField("CHARACTER_SETS", "Charset", 96, mysql.MYSQL_TYPE_VAR_STRING),
+		newProbeField("CHARACTER_SETS", "Description", 180, mysql.MYSQL_TYPE_VAR_STRING),
+		newProbeField("CHARACTER_SETS", "Default collation", 96, mysql.MYSQL_TYPE_VAR_STRING),
+		newProbeField("CHARACTER_SETS", "Maxlen", 3, mysql.MYSQL_TYPE_LONGLONG),
+	}
+	names := make([]string, 0, len(mysql.CharsetInfos))
+	for name := range mysql.CharsetInfos {
+		if match(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	result.Rows = make([]*mysql.Row, 0, len(names))
+	for _, name := range names {
+		row := mysql.NewTextRow(result.Resultset.Fields)
+		row.AppendStringValue(name)
+		row.AppendStringValue(mysql.CharsetInfos[name].Description)
+		row.AppendStringValue(mysql.Charsets[name])
+		row.AppendIntValue(int64(mysql.CharsetInfos[name].MaxLen))
+		result.Rows = append(result.Rows, row)
+	}
+	return result
+}
+
+func showCollationResult(match func(name string) bool) *mysql.Result {
+	result := new(mysql.Result)
+	result.Status = mysql.SERVER_STATUS_AUTOCOMMIT
+	result.Resultset = new(mysql.Resultset)
+	result.Resultset.Fields = []*mysql.Field{
+		newProbeField("COLLATIONS", "Collation", 96, mysql.MYSQL_TYPE_VAR_STRING),
+		newProbeField("COLLATIONS", "Charset", 96, mysql.MYSQL_TYPE_VAR_STRING),
+		newProbeField("COLLATIONS", "Id", 11, mysql.MYSQL_TYPE_LONGLONG),
+		newProbeField("COLLATIONS", "Default", 9, mysql.MYSQL_TYPE_VAR_STRING),
+		newProbeField("COLLATIONS", "Compiled", 9, mysql.MYSQL_TYPE_VAR_STRING),
+		newProbeField("COLLATIONS", "Sortlen", 3, mysql.MYSQL_TYPE_LONGLONG),
+	}
+	names := make([]string, 0, len(mysql.CollationNames))
+	for name := range mysql.CollationNames {
+		if match(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	result.Rows = make([]*mysql.Row, 0, len(names))
+	for _, name := range names {
+		charset := name
+		if i := strings.Index(name, "_"); i > 0 {
+			charset = name[:i]
+		}
+		isDefault := ""
+		if mysql.Charsets[charset] == name {
+			isDefault = "Yes"
+		}
+		row := mysql.NewTextRow(result.Resultset.Fields)
+		row.AppendStringValue(name)
+		row.AppendStringValue(charset)
+		row.AppendUIntValue(uint64(mysql.CollationNames[name]))
+		row.AppendStringValue(isDefault)
+		row.AppendStringValue("Yes")
+		row.AppendUIntValue(1)
+		result.Rows = append(result.Rows, row)
+	}
+	return result
+}
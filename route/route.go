@@ -40,6 +40,8 @@ type Route interface {
 	GetPlanSQL() string
 	GetNodeNames() []string
 	OnSlave() bool
+	OnAnalytics() bool
+	GetMerge() *Merge
 }
 
 // Plan to execute.
@@ -61,8 +63,26 @@ type normalPlan struct {
 	Result         *mysql.Result // If has result then get it, or execute plan.
 	nodeNames      []string
 	queryNodeNames []string
-	onSlave        bool // Execute at slave or master.
-	anyNode        bool // Can execute at any node or not.
+	onSlave        bool   // Execute at slave or master.
+	onAnalytics    bool   // Execute at analytics replica if exists, it's only for read.
+	anyNode        bool   // Can execute at any node or not.
+	merge          *Merge // Merge rows of fan-out select, nil if they're concatenated.
+}
+
+// pinUserVariables execute statement with user variables at master conn of a single node,
+// because they're in session of backend conn.
+func (plan *normalPlan) pinUserVariables() error {
+	if plan.Statement == nil {
+		return nil
+	}
+	if referenced, assigned := sqlparser.UserVariables(plan.Statement); len(referenced) == 0 && len(assigned) == 0 {
+		return nil
+	}
+	if len(plan.nodeNames) > 1 && !plan.anyNode {
+		return errors.ErrUserVarInMulti
+	}
+	plan.onSlave, plan.onAnalytics, plan.anyNode = false, false, false
+	return nil
 }
 
 func (plan *normalPlan) GetPlanSQL() string {
@@ -77,6 +97,14 @@ func (plan *normalPlan) OnSlave() bool {
 	return plan.onSlave
 }
 
+func (plan *normalPlan) OnAnalytics() bool {
+	return plan.onAnalytics
+}
+
+func (plan *normalPlan) GetMerge() *Merge {
+	return plan.merge
+}
+
 // Execute the plan
 func (plan *normalPlan) Execute(executor func(statements []sqlparser.Statement, results []*mysql.Result,
 	dataNodes []string, isSlave bool,
@@ -106,6 +134,7 @@ func (plan *normalPlan) Execute(executor func(statements []sqlparser.Statement,
 				plan.onSlave,
 				planSQL,
 			)
+			fireSlowLog(state, execTime, clientAddr, plan.nodeNames, backendConnAddrs, plan.onSlave, planSQL)
 		}
 		return err
 	}
@@ -140,6 +169,7 @@ func (plan *normalPlan) ExecuteWithStmtPrepare(executor func(statements []sqlpar
 				plan.onSlave,
 				planSQL,
 			)
+			fireSlowLog(state, execTime, clientAddr, plan.nodeNames, nil, plan.onSlave, planSQL)
 		}
 		return stmt, err
 	}
@@ -153,7 +183,9 @@ type mergedPlan struct {
 	nodeNames      []string
 	queryNodeNames map[sqlparser.Statement][]string // select or union will use.
 	onSlave        bool                             // Execute at slave or master.
+	onAnalytics    bool                             // Execute at analytics replica if exists.
 	anyNode        bool                             // Can execute at any node or not.
+	merge          *Merge                           // Merge rows of fan-out select, only for single statement.
 }
 
 func (plan *mergedPlan) GetPlanSQL() string {
@@ -172,6 +204,14 @@ func (plan *mergedPlan) OnSlave() bool {
 	return plan.onSlave
 }
 
+func (plan *mergedPlan) OnAnalytics() bool {
+	return plan.onAnalytics
+}
+
+func (plan *mergedPlan) GetMerge() *Merge {
+	return plan.merge
+}
+
 // Execute the plan
 func (plan *mergedPlan) Execute(executor func(statements []sqlparser.Statement, results []*mysql.Result,
 	dataNodes []string, isSlave bool,
@@ -200,6 +240,7 @@ func (plan *mergedPlan) Execute(executor func(statements []sqlparser.Statement,
 				plan.onSlave,
 				planSQL,
 			)
+			fireSlowLog(state, execTime, clientAddr, plan.nodeNames, backendConnAddrs, plan.onSlave, planSQL)
 		}
 		return err
 	}
@@ -233,6 +274,7 @@ func (plan *mergedPlan) ExecuteWithStmtPrepare(executor func(statements []sqlpar
 				plan.onSlave,
 				planSQL,
 			)
+			fireSlowLog(state, execTime, clientAddr, plan.nodeNames, nil, plan.onSlave, planSQL)
 		}
 		return stmt, err
 	}
@@ -241,12 +283,26 @@ func (plan *mergedPlan) ExecuteWithStmtPrepare(executor func(statements []sqlpar
 
 // Router used to build plan.
 type Router struct {
-	SchemaName   string
-	Schemas      map[string]*config.SchemaConfig
-	Nodes        map[string]*config.NodeConfig
-	ConnectionID uint32
-	User         string
-	InTrans      bool
+	SchemaName    string
+	Schemas       map[string]*config.SchemaConfig
+	Nodes         map[string]*config.NodeConfig
+	ConnectionID  uint32
+	User          string
+	InTrans       bool
+	Inspector     TableInspector    // Used by ddl safety check.
+	ReadOnly      bool              // Connected from read-only listener.
+	Overrides     map[string]string // Routing overrides, fingerprint -> target.
+	AnalyticsCost int               // Estimated cost to go to analytics replica, 0 means disabled.
+	MaxQueryCost  int64             // Estimated rows examined by select to reject or deprioritize, 0 means disabled.
+	CostPolicy    string            // reject or deprioritize, when MaxQueryCost is exceeded.
+	Stats         CostStats         // Used by cost estimation.
+	AllowGrant    bool              // Broadcast GRANT, REVOKE and user statements to nodes, or reject them.
+	AllowLock     bool              // Forward LOCK TABLES to owning node, or reject it.
+	PartialResult bool              // Last fan-out select of session returned partial result.
+
+	// ReplicaLag get measured lag in seconds of slaves of node, or of its replica by addr if it isn't empty,
+	// for saashard_replica_lag(node[, addr]).
+	ReplicaLag func(node, addr string) (int64, bool)
 }
 
 // NewRouter to create router.
@@ -286,7 +342,9 @@ func (r *Router) BuildMergedPlan(statements ...sqlparser.Statement) (plan Plan,
 	mergedPlan.nodeNames = firstNormalPlan.nodeNames
 	mergedPlan.queryNodeNames = make(map[sqlparser.Statement][]string)
 	mergedPlan.onSlave = firstNormalPlan.onSlave
+	mergedPlan.onAnalytics = firstNormalPlan.onAnalytics
 	mergedPlan.anyNode = firstNormalPlan.anyNode
+	mergedPlan.merge = firstNormalPlan.merge
 	mergedPlan.Results[0] = firstNormalPlan.Result
 	mergedPlan.Statements[0] = firstNormalPlan.Statement
 
@@ -312,6 +370,8 @@ func (r *Router) BuildMergedPlan(statements ...sqlparser.Statement) (plan Plan,
 				if mergedPlan.onSlave {
 					mergedPlan.onSlave = currentPlan.onSlave
 				}
+				// all plans must go to analytics replica
+				mergedPlan.onAnalytics = mergedPlan.onAnalytics && currentPlan.onAnalytics
 
 				// mapping nodes to query statement.
 				if len(currentPlan.queryNodeNames) > 0 {
@@ -327,6 +387,21 @@ func (r *Router) BuildMergedPlan(statements ...sqlparser.Statement) (plan Plan,
 
 // BuildNormalPlan to build plan
 func (r *Router) BuildNormalPlan(statement sqlparser.Statement) (plan Plan, err error) {
+	if r.ReadOnly && IsWriteStatement(statement) {
+		return nil, errors.ErrReadOnlyListener
+	}
+
+	var fingerprint string
+	if len(r.Overrides) > 0 {
+		fingerprint = sqlparser.Fingerprint(statement)
+	}
+
+	// dml and ddl of pinned tables use schema of its node group.
+	var router *Router
+	if router, err = r.pinnedRouter(statement); err != nil {
+		return
+	}
+
 	var realPlan *normalPlan
 	switch v := statement.(type) {
 	case *sqlparser.UseDB:
@@ -384,6 +459,12 @@ func (r *Router) BuildNormalPlan(statement sqlparser.Statement) (plan Plan, err
 		realPlan, err = r.buildShowCreateProcedurePlan(v)
 	case *sqlparser.ShowCreateFunction:
 		realPlan, err = r.buildShowCreateFunctionPlan(v)
+	case *sqlparser.ShowGrants:
+		realPlan, err = r.buildShowGrantsPlan(v)
+	case *sqlparser.ShowWarnings:
+		realPlan, err = r.buildShowWarningsPlan(v, &v.Comments)
+	case *sqlparser.ShowErrors:
+		realPlan, err = r.buildShowWarningsPlan(v, &v.Comments)
 
 	case *sqlparser.SetCharset:
 		realPlan, err = r.buildSetCharsetPlan(v)
@@ -393,9 +474,17 @@ func (r *Router) BuildNormalPlan(statement sqlparser.Statement) (plan Plan, err
 		realPlan, err = r.buildSetVariablePlan(v)
 	case *sqlparser.SetTransactionIsolationLevel:
 		realPlan, err = r.buildSetTransactionIsolationLevelPlan(v)
+	case *sqlparser.Prepare:
+		realPlan, err = r.buildPreparePlan(v, &v.Comments)
+	case *sqlparser.Deallocate:
+		realPlan, err = r.buildPreparePlan(v, &v.Comments)
 
 	case sqlparser.TransactionStatement:
 		realPlan, err = r.buildTransactionPlan(v)
+	case *sqlparser.LockTables:
+		realPlan, err = router.buildLockTablesPlan(v)
+	case *sqlparser.UnlockTables:
+		realPlan, err = r.buildUnlockTablesPlan(v)
 
 	case *sqlparser.Explain:
 		realPlan, err = r.buildExplainPlan(v)
@@ -403,31 +492,54 @@ func (r *Router) BuildNormalPlan(statement sqlparser.Statement) (plan Plan, err
 	case *sqlparser.SimpleSelect:
 		realPlan, err = r.buildSimpleSelectPlan(v)
 	case *sqlparser.Select:
-		realPlan, err = r.buildSelectPlan(v)
+		realPlan, err = router.buildSelectPlan(v)
 	case *sqlparser.Union:
-		realPlan, err = r.buildUnionPlan(v)
+		realPlan, err = router.buildUnionPlan(v)
 
 	case *sqlparser.Insert:
-		realPlan, err = r.buildInsertPlan(v)
+		realPlan, err = router.buildInsertPlan(v)
 	case *sqlparser.Update:
-		realPlan, err = r.buildUpdatePlan(v)
+		realPlan, err = router.buildUpdatePlan(v)
 	case *sqlparser.Delete:
-		realPlan, err = r.buildDeletePlan(v)
+		realPlan, err = router.buildDeletePlan(v)
 	case *sqlparser.Replace:
-		realPlan, err = r.buildReplacePlan(v)
+		realPlan, err = router.buildReplacePlan(v)
+	case *sqlparser.Call:
+		realPlan, err = r.buildCallPlan(v)
+	case *sqlparser.LoadData:
+		realPlan, err = router.buildLoadDataPlan(v)
 
 	case *sqlparser.CreateTable:
-		realPlan, err = r.buildCreateTablePlan(v)
+		realPlan, err = router.buildCreateTablePlan(v)
 	case *sqlparser.CreateIndex:
-		realPlan, err = r.buildCreateIndexPlan(v)
+		realPlan, err = router.buildCreateIndexPlan(v)
 	case *sqlparser.AlterTable:
-		realPlan, err = r.buildAlterTable(v)
+		realPlan, err = router.buildAlterTable(v)
 	case *sqlparser.RenameTable:
-		realPlan, err = r.buildRenameTablePlan(v)
+		realPlan, err = router.buildRenameTablePlan(v)
 	case *sqlparser.DropTable:
-		realPlan, err = r.buildDropTablePlan(v)
+		realPlan, err = router.buildDropTablePlan(v)
+	case *sqlparser.TruncateTable:
+		realPlan, err = router.buildTruncateTablePlan(v)
+	case *sqlparser.TableMaintenance:
+		realPlan, err = router.buildTableMaintenancePlan(v)
 	case *sqlparser.DropIndex:
-		realPlan, err = r.buildDropIndexPlan(v)
+		realPlan, err = router.buildDropIndexPlan(v)
+	case *sqlparser.CreateRoutine:
+		realPlan, err = r.buildCreateRoutinePlan(v)
+	case *sqlparser.DropRoutine:
+		realPlan, err = r.buildDropRoutinePlan(v)
+
+	case *sqlparser.Grant:
+		realPlan, err = r.buildGrantPlan(v, &v.Comments, v.Level)
+	case *sqlparser.Revoke:
+		realPlan, err = r.buildGrantPlan(v, &v.Comments, v.Level)
+	case *sqlparser.CreateUser:
+		realPlan, err = r.buildAccountPlan(v, &v.Comments)
+	case *sqlparser.AlterUser:
+		realPlan, err = r.buildAccountPlan(v, &v.Comments)
+	case *sqlparser.DropUser:
+		realPlan, err = r.buildAccountPlan(v, &v.Comments)
 
 	case *sqlparser.KillConnection:
 		realPlan, err = r.buildKillConnection(v)
@@ -437,6 +549,41 @@ func (r *Router) BuildNormalPlan(statement sqlparser.Statement) (plan Plan, err
 	default:
 		realPlan, err = nil, errors.ErrNoPlan
 	}
+	if realPlan != nil {
+		if len(fingerprint) > 0 {
+			r.applyOverride(fingerprint, realPlan)
+		}
+		r.classifyAnalytics(fingerprint, realPlan)
+		if err = r.checkQueryCost(realPlan); err != nil {
+			return nil, err
+		}
+		if err = realPlan.pinUserVariables(); err != nil {
+			return nil, err
+		}
+	}
 	plan = realPlan
 	return
 }
+
+// IsWriteStatement check whether statement would modify data or schema.
+func IsWriteStatement(statement sqlparser.Statement) bool {
+	switch v := statement.(type) {
+	case *sqlparser.Insert, *sqlparser.Update, *sqlparser.Delete, *sqlparser.Replace, *sqlparser.Call, *sqlparser.LoadData,
+		*sqlparser.Grant, *sqlparser.Revoke,
+		*sqlparser.CreateUser, *sqlparser.AlterUser, *sqlparser.DropUser:
+		return true
+	case *sqlparser.Select:
+		return v.Lock == sqlparser.AST_FOR_UPDATE
+	case sqlparser.DDLStatement:
+		return true
+	case *sqlparser.TableMaintenance:
+		return !v.IsReadOnly()
+	case *sqlparser.LockTables:
+		for _, table := range v.Tables {
+			if table.Lock == sqlparser.AST_LOCK_WRITE {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -25,9 +25,11 @@ package route
 import (
 	"strings"
 
+	"github.com/berkaroad/saashard/config"
 	"github.com/berkaroad/saashard/errors"
 	"github.com/berkaroad/saashard/net/mysql"
 	"github.com/berkaroad/saashard/sqlparser"
+	"github.com/berkaroad/saashard/utils"
 )
 
 var currentUserField = &mysql.Field{Schema: []byte(""),
@@ -63,6 +65,50 @@ var connectionIDField = &mysql.Field{Schema: []byte(""),
 	Flags:        mysql.NOT_NULL_FLAG | mysql.BINARY_FLAG,
 	Decimals:     0}
 
+var versionCommentField = &mysql.Field{Schema: []byte(""),
+	Table:        []byte(""),
+	OrgTable:     []byte(""),
+	Name:         []byte("@@version_comment"),
+	OrgName:      []byte(""),
+	Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+	ColumnLength: 84,
+	ColumnType:   mysql.MYSQL_TYPE_VAR_STRING,
+	Flags:        mysql.NOT_NULL_FLAG,
+	Decimals:     31}
+
+var versionVariableField = &mysql.Field{Schema: []byte(""),
+	Table:        []byte(""),
+	OrgTable:     []byte(""),
+	Name:         []byte("@@version"),
+	OrgName:      []byte(""),
+	Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+	ColumnLength: 72,
+	ColumnType:   mysql.MYSQL_TYPE_VAR_STRING,
+	Flags:        mysql.NOT_NULL_FLAG,
+	Decimals:     31}
+
+var lowerCaseTableNamesField = &mysql.Field{Schema: []byte(""),
+	Table:        []byte(""),
+	OrgTable:     []byte(""),
+	Name:         []byte("@@lower_case_table_names"),
+	OrgName:      []byte(""),
+	Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+	ColumnLength: 21,
+	ColumnType:   mysql.MYSQL_TYPE_LONGLONG,
+	Flags:        mysql.NOT_NULL_FLAG | mysql.BINARY_FLAG,
+	Decimals:     0}
+
+var partialResultField = &mysql.Field{Schema: []byte(""),
+	Table:        []byte(""),
+	OrgTable:     []byte(""),
+	Name:         []byte("@@saashard_partial_result"),
+	OrgName:      []byte(""),
+	Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+	ColumnLength: 21,
+	ColumnType:   mysql.MYSQL_TYPE_LONGLONG,
+	Flags:        mysql.NOT_NULL_FLAG | mysql.BINARY_FLAG,
+	Decimals:     0}
+
 var databaseField = &mysql.Field{Schema: []byte(""),
 	Table:        []byte(""),
 	OrgTable:     []byte(""),
@@ -88,13 +134,56 @@ func (r *Router) buildSimpleSelectPlan(statement *sqlparser.SimpleSelect) (*norm
 		"connection_id()": func(row *mysql.Row) { row.AppendUIntValue(uint64(r.ConnectionID)) },
 		"database()":      func(row *mysql.Row) { row.AppendStringValue(r.SchemaName) }}
 
+	// system variables answered by proxy, whichever scope.
+	supportedVariableFields := map[string]*mysql.Field{
+		// probed by bi tools and drivers, same as 'show variables'.
+		"version_comment":        versionCommentField,
+		"version":                versionVariableField,
+		"lower_case_table_names": lowerCaseTableNamesField,
+		// 1 if last fan-out select of session skipped failed nodes.
+		"saashard_partial_result": partialResultField}
+
+	supportedVariableValues := map[string]func(*mysql.Row){
+		"version_comment":        func(row *mysql.Row) { row.AppendStringValue(mysql.SourceInfo) },
+		"version":                func(row *mysql.Row) { row.AppendStringValue(mysql.ServerVersion) },
+		"lower_case_table_names": func(row *mysql.Row) { row.AppendUIntValue(1) },
+		"saashard_partial_result": func(row *mysql.Row) {
+			if r.PartialResult {
+				row.AppendUIntValue(1)
+			} else {
+				row.AppendUIntValue(0)
+			}
+		}}
+
 	allFieldsSupported := true
-	for _, fieldExpr := range statement.SelectExprs {
+	fields := make([]*mysql.Field, len(statement.SelectExprs))
+	values := make([]func(*mysql.Row), len(statement.SelectExprs))
+	for i, fieldExpr := range statement.SelectExprs {
 		fieldName := strings.ToLower(sqlparser.String(fieldExpr))
-		if _, ok := supportedFieldNames[fieldName]; !ok {
-			allFieldsSupported = false
-			break
+		if field, ok := supportedFieldNames[fieldName]; ok {
+			fields[i], values[i] = field, supportedFieldValues[fieldName]
+			continue
+		}
+		if variable, ok := systemVariableExpr(fieldExpr); ok {
+			name := strings.ToLower(string(variable.Name))
+			if field, ok := supportedVariableFields[name]; ok {
+				fields[i], values[i] = renameField(field, fieldExpr), supportedVariableValues[name]
+				continue
+			}
+		}
+		if node, addr, ok := replicaLagNode(fieldExpr); ok && r.ReplicaLag != nil {
+			fields[i] = newReplicaLagField(fieldExpr)
+			values[i] = func(row *mysql.Row) {
+				if lag, ok := r.ReplicaLag(node, addr); ok {
+					row.AppendIntValue(lag)
+				} else {
+					row.AppendNullValue()
+				}
+			}
+			continue
 		}
+		allFieldsSupported = false
+		break
 	}
 	hint := ReadHint(&statement.Comments)
 
@@ -109,16 +198,11 @@ func (r *Router) buildSimpleSelectPlan(statement *sqlparser.SimpleSelect) (*norm
 		result := new(mysql.Result)
 		result.Status = mysql.SERVER_STATUS_AUTOCOMMIT
 		result.Resultset = new(mysql.Resultset)
-		result.Resultset.Fields = make([]*mysql.Field, len(statement.SelectExprs))
-		for i, fieldExpr := range statement.SelectExprs {
-			fieldName := strings.ToLower(sqlparser.String(fieldExpr))
-			result.Resultset.Fields[i] = supportedFieldNames[fieldName]
-		}
+		result.Resultset.Fields = fields
 		result.Rows = make([]*mysql.Row, 1)
 		row := mysql.NewTextRow(result.Resultset.Fields)
-		for _, fieldExpr := range statement.SelectExprs {
-			fieldName := strings.ToLower(sqlparser.String(fieldExpr))
-			supportedFieldValues[fieldName](row)
+		for _, value := range values {
+			value(row)
 		}
 		result.Rows[0] = row
 		plan.Result = result
@@ -126,11 +210,89 @@ func (r *Router) buildSimpleSelectPlan(statement *sqlparser.SimpleSelect) (*norm
 	return plan, nil
 }
 
+// systemVariableExpr get system variable of select expression, such as '@@session.version_comment'.
+func systemVariableExpr(fieldExpr sqlparser.SelectExpr) (*sqlparser.SystemVariable, bool) {
+	if expr, ok := fieldExpr.(*sqlparser.NonStarExpr); ok {
+		if variable, ok := expr.Expr.(*sqlparser.SystemVariable); ok {
+			return variable, true
+		}
+	}
+	return nil, false
+}
+
+// renameField copy field with name of select expression, alias or expression as written.
+func renameField(field *mysql.Field, fieldExpr sqlparser.SelectExpr) *mysql.Field {
+	expr := fieldExpr.(*sqlparser.NonStarExpr)
+	name := string(expr.As)
+	if len(name) == 0 {
+		name = sqlparser.String(expr.Expr)
+	}
+	if name == string(field.Name) {
+		return field
+	}
+	renamed := *field
+	renamed.Name = []byte(name)
+	return &renamed
+}
+
+// replicaLagNode get node and replica addr of 'saashard_replica_lag(node[, addr])', that is measured lag in seconds
+// of slaves of node, or of the slave or analytics replica by addr, null if unknown.
+func replicaLagNode(fieldExpr sqlparser.SelectExpr) (node, addr string, ok bool) {
+	expr, ok := fieldExpr.(*sqlparser.NonStarExpr)
+	if !ok {
+		return "", "", false
+	}
+	funcExpr, ok := expr.Expr.(*sqlparser.FuncExpr)
+	if !ok || len(funcExpr.Exprs) < 1 || len(funcExpr.Exprs) > 2 ||
+		strings.ToLower(string(funcExpr.Name)) != "saashard_replica_lag" {
+		return "", "", false
+	}
+	args := make([]string, len(funcExpr.Exprs))
+	for i, arg := range funcExpr.Exprs {
+		val, ok := arg.(sqlparser.StrVal)
+		if !ok {
+			return "", "", false
+		}
+		args[i] = string(val)
+	}
+	if len(args) == 2 {
+		addr = args[1]
+	}
+	return args[0], addr, true
+}
+
+func newReplicaLagField(fieldExpr sqlparser.SelectExpr) *mysql.Field {
+	expr := fieldExpr.(*sqlparser.NonStarExpr)
+	name := string(expr.As)
+	if len(name) == 0 {
+		name = sqlparser.String(expr.Expr)
+	}
+	return &mysql.Field{Schema: []byte(""),
+		Table:        []byte(""),
+		OrgTable:     []byte(""),
+		Name:         []byte(name),
+		OrgName:      []byte(""),
+		Charset:      uint16(mysql.DEFAULT_COLLATION_ID),
+		ColumnLength: 21,
+		ColumnType:   mysql.MYSQL_TYPE_LONGLONG,
+		Flags:        mysql.BINARY_FLAG,
+		Decimals:     0}
+}
+
 func (r *Router) buildSelectPlan(statement *sqlparser.Select) (*normalPlan, error) {
 	schemaConfig := r.Schemas[r.SchemaName]
 	isOnlySystemDB := false
 	nodeIndex := 0
-	sqlparser.SetLimitInSelect(statement, schemaConfig.MaxRowCount)
+	var fanoutNodes []string
+	hint := ReadHint(&statement.Comments)
+	// limit is injected into select of each node.
+	concatenable := IsConcatenable(statement)
+	r.injectLimit(schemaConfig, statement)
+	var merge *Merge
+	var mergeStatement *sqlparser.Select
+	if !concatenable {
+		merge, mergeStatement = newMerge(statement)
+	}
 	if schemaConfig.ShardEnabled() {
 		if isOnlySystemDB = sqlparser.IsOnlySystemDBInTableExprs(statement.From); !isOnlySystemDB {
 			var err error
@@ -141,25 +303,47 @@ func (r *Router) buildSelectPlan(statement *sqlparser.Select) (*normalPlan, erro
 			}
 
 			var colValue sqlparser.ValExpr
-			if colValue, err = sqlparser.CheckColumnInSelect(statement, schemaConfig.ShardKey); err != nil {
+			colValue, err = sqlparser.CheckColumnInSelect(statement, schemaConfig.ShardKey)
+			if (err == errors.ErrWhereOrJoinOnKey || err == nil && colValue == nil) && len(hint.Nodes) > 0 && (concatenable || merge != nil) {
+				// select without shard key fans out to hinted nodes, if rows of nodes could be concatenated or merged.
+				if fanoutNodes = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes); len(fanoutNodes) == 0 {
+					return nil, errors.ErrNoRouteNode
+				}
+				if merge != nil {
+					statement = mergeStatement
+				}
+			} else if err != nil {
 				return nil, err
 			} else if colValue == nil {
 				return nil, errors.ErrWhereOrJoinOnKey
-			}
-
-			algo := ParseShardAlgorithm(schemaConfig.ShardAlgo)
-			nodeIndex, err = algo(sqlparser.String(colValue), len(schemaConfig.Nodes))
-			if err != nil {
-				return nil, err
+			} else {
+				nodeIndex, err = ShardIndex(schemaConfig, sqlparser.String(colValue))
+				if err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
-	hint := ReadHint(&statement.Comments)
+	if len(fanoutNodes) > 0 {
+		// physical table of sub-sharded table isn't qualified, it's in database of each node.
+		if err := r.rewriteSubShardSelect(schemaConfig, statement, ""); err != nil {
+			return nil, err
+		}
+	} else if !isOnlySystemDB {
+		if err := r.rewriteSubShardSelect(schemaConfig, statement, schemaConfig.Nodes[nodeIndex]); err != nil {
+			return nil, err
+		}
+	}
 
 	plan := new(normalPlan)
 
 	plan.nodeNames = []string{schemaConfig.Nodes[nodeIndex]}
+	if len(fanoutNodes) > 0 {
+		plan.nodeNames = fanoutNodes
+		plan.merge = merge
+	}
 	plan.onSlave = true && !hint.OnMaster && !r.InTrans
+	plan.onAnalytics = hint.OnAnalytics
 	if isOnlySystemDB {
 		plan.anyNode = true
 	}
@@ -168,6 +352,60 @@ func (r *Router) buildSelectPlan(statement *sqlparser.Select) (*normalPlan, erro
 	return plan, nil
 }
 
+// injectLimit append limit max_row_count to select without limit, to protect from unbounded dumps.
+// Select with only aggregate functions and no group by is skipped, so is user not in max_row_count_users.
+func (r *Router) injectLimit(schemaConfig *config.SchemaConfig, statement *sqlparser.Select) {
+	if schemaConfig.MaxRowCount <= 0 || statement.Limit != nil || isAggregateOnly(statement) {
+		return
+	}
+	if len(schemaConfig.MaxRowCountUsers) > 0 {
+		designated := false
+		for _, user := range schemaConfig.MaxRowCountUsers {
+			if user == r.User {
+				designated = true
+				break
+			}
+		}
+		if !designated {
+			return
+		}
+	}
+	sqlparser.SetLimitInSelect(statement, schemaConfig.MaxRowCount)
+}
+
+// isAggregateOnly is true if select returns one row at most, by aggregate functions without group by.
+func isAggregateOnly(statement *sqlparser.Select) bool {
+	if len(statement.GroupBy) > 0 {
+		return false
+	}
+	for _, selectExpr := range statement.SelectExprs {
+		expr, ok := selectExpr.(*sqlparser.NonStarExpr)
+		if !ok {
+			return false
+		}
+		funcExpr, ok := expr.Expr.(*sqlparser.FuncExpr)
+		if !ok || !aggregateFuncs[strings.ToLower(string(funcExpr.Name))] {
+			return false
+		}
+	}
+	return true
+}
+
+// IsConcatenable is true if results of select in nodes could be merged by concatenating their rows,
+// that's without group by, having, distinct, order by, limit, aggregate functions or window functions at any depth.
+func IsConcatenable(statement sqlparser.Statement) bool {
+	v, ok := statement.(*sqlparser.Select)
+	if !ok || len(v.GroupBy) > 0 || v.Having != nil || len(v.Distinct) > 0 || len(v.OrderBy) > 0 || v.Limit != nil {
+		return false
+	}
+	for _, selectExpr := range v.SelectExprs {
+		if expr, ok := selectExpr.(*sqlparser.NonStarExpr); ok && hasAggregate(expr.Expr) {
+			return false
+		}
+	}
+	return true
+}
+
 func (r *Router) buildUnionPlan(statement *sqlparser.Union) (*normalPlan, error) {
 	schemaConfig := r.Schemas[r.SchemaName]
 	nodeIndex := 0
@@ -186,12 +424,14 @@ func (r *Router) buildUnionPlan(statement *sqlparser.Union) (*normalPlan, error)
 			return nil, errors.ErrWhereOrJoinOnKey
 		}
 
-		algo := ParseShardAlgorithm(schemaConfig.ShardAlgo)
-		nodeIndex, err = algo(sqlparser.String(colValue), len(schemaConfig.Nodes))
+		nodeIndex, err = ShardIndex(schemaConfig, sqlparser.String(colValue))
 		if err != nil {
 			return nil, err
 		}
 	}
+	if err := r.rewriteSubShardSelect(schemaConfig, statement, schemaConfig.Nodes[nodeIndex]); err != nil {
+		return nil, err
+	}
 	var hint *Hint
 	switch left := statement.Left.(type) {
 	case *sqlparser.SimpleSelect:
@@ -205,6 +445,7 @@ func (r *Router) buildUnionPlan(statement *sqlparser.Union) (*normalPlan, error)
 	plan.onSlave = true && !r.InTrans
 	if hint != nil {
 		plan.onSlave = plan.onSlave && !hint.OnMaster
+		plan.onAnalytics = hint.OnAnalytics
 	}
 	plan.Statement = statement
 
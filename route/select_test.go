@@ -0,0 +1,82 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"testing"
+
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+func TestIsConcatenable(t *testing.T) {
+	cases := []struct {
+		sql  string
+		want bool
+	}{
+		{"select a, b + 1, concat(a, b) from t where c = 1", true},
+		{"select * from t", true},
+		{"select a from t order by a", false},
+		{"select a from t limit 1", false},
+		{"select distinct a from t", false},
+		{"select count(*) from t", false},
+		{"select sum(a)+0 from t", false},
+		{"select coalesce(max(a), 0) from t", false},
+		{"select count(*) over () from t", false},
+		{"select a, row_number() over (order by a) from t", false},
+		{"select (select max(b) from u) from t", false},
+	}
+	for _, c := range cases {
+		statement, err := sqlparser.Parse(c.sql)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", c.sql, err)
+		}
+		if got := IsConcatenable(statement); got != c.want {
+			t.Errorf("IsConcatenable(%q) = %v, want %v", c.sql, got, c.want)
+		}
+	}
+}
+
+func TestReplicaLagNode(t *testing.T) {
+	cases := []struct {
+		sql        string
+		node, addr string
+		ok         bool
+	}{
+		{"select saashard_replica_lag('node1')", "node1", "", true},
+		{"select SAASHARD_REPLICA_LAG('node1', '10.0.0.2:3306') as lag", "node1", "10.0.0.2:3306", true},
+		{"select saashard_replica_lag()", "", "", false},
+		{"select saashard_replica_lag(node1)", "", "", false},
+		{"select saashard_replica_lag('node1', '10.0.0.2:3306', 'x')", "", "", false},
+		{"select replica_lag('node1')", "", "", false},
+	}
+	for _, c := range cases {
+		statement, err := sqlparser.Parse(c.sql)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", c.sql, err)
+		}
+		node, addr, ok := replicaLagNode(statement.(*sqlparser.SimpleSelect).SelectExprs[0])
+		if node != c.node || addr != c.addr || ok != c.ok {
+			t.Errorf("replicaLagNode(%q) = %q, %q, %v, want %q, %q, %v", c.sql, node, addr, ok, c.node, c.addr, c.ok)
+		}
+	}
+}
@@ -71,3 +71,16 @@ func (r *Router) buildSetTransactionIsolationLevelPlan(statement *sqlparser.SetT
 	plan.Statement = statement
 	return plan, nil
 }
+
+// buildPreparePlan build plan of PREPARE or DEALLOCATE PREPARE, that's tracked and answered by proxy.
+func (r *Router) buildPreparePlan(statement sqlparser.Statement, comments *sqlparser.Comments) (*normalPlan, error) {
+	schemaConfig := r.Schemas[r.SchemaName]
+	ReadHint(comments)
+
+	plan := new(normalPlan)
+	plan.nodeNames = []string{schemaConfig.Nodes[0]}
+	plan.onSlave = false
+	plan.anyNode = true
+	plan.Statement = statement
+	return plan, nil
+}
@@ -26,35 +26,162 @@ import (
 	"hash/crc32"
 	"strconv"
 	"strings"
+	"unicode/utf16"
 
+	"github.com/berkaroad/saashard/config"
 	"github.com/berkaroad/saashard/errors"
 )
 
-// ShardAlgorithm shard algorithm
+// ShardAlgorithm shard algorithm.
+// Rows are placed by it, so result of the same value, node count and seed must never change across versions.
 type ShardAlgorithm func(val string, dataNodeCount int, params ...interface{}) (int, error)
 
+var shardAlgorithms = map[string]ShardAlgorithm{
+	"":        HashShardAlgo,
+	"hash":    HashShardAlgo,
+	"mod":     ModShardAlgo,
+	"crc32":   HashShardAlgo,
+	"murmur3": Murmur3ShardAlgo,
+	"xxhash":  XXHashShardAlgo,
+	"java":    JavaShardAlgo,
+	"php":     HashShardAlgo, // crc32($key) % $count
+}
+
 // ParseShardAlgorithm parse ShardAlgorithm
 func ParseShardAlgorithm(name string) ShardAlgorithm {
 	name = strings.ToLower(name)
 	name = strings.TrimSpace(name)
-	var algo ShardAlgorithm
-	switch name {
-	case "mod":
-		algo = ModShardAlgo
-	default:
-		algo = HashShardAlgo
+	if algo, ok := shardAlgorithms[name]; ok {
+		return algo
+	}
+	return HashShardAlgo
+}
+
+// IsShardAlgorithm check whether the name of shard algorithm is supported.
+func IsShardAlgorithm(name string) bool {
+	_, ok := shardAlgorithms[strings.ToLower(strings.TrimSpace(name))]
+	return ok
+}
+
+// ShardIndex get index of node by shard key value, with shard_algo and shard_hash_seed of schema.
+// String value is normalized by shard_key_normalize and shard_key_collation before hashing.
+// Node of capacity weight n has n consecutive slots of shard key, unweighted node has only one slot.
+func ShardIndex(schema *config.SchemaConfig, val string) (int, error) {
+	algo := ParseShardAlgorithm(schema.ShardAlgo)
+	val = normalizeShardKey(schema, val)
+	if len(schema.NodeWeights) == 0 {
+		return algo(val, len(schema.Nodes), schema.ShardHashSeed)
+	}
+	slots := ShardSlots(schema)
+	if len(slots) == 0 {
+		return 0, errors.ErrNoDataNode
+	}
+	slot, err := algo(val, len(slots), schema.ShardHashSeed)
+	if err != nil {
+		return 0, err
+	}
+	return slots[slot], nil
+}
+
+// ShardSlots get index of node of each slot, by capacity weights of nodes.
+func ShardSlots(schema *config.SchemaConfig) []int {
+	slots := make([]int, 0, len(schema.Nodes))
+	for i, node := range schema.Nodes {
+		for j := 0; j < schema.NodeWeight(node); j++ {
+			slots = append(slots, i)
+		}
+	}
+	return slots
+}
+
+// ShardMove is ratio of shard keys moved between nodes, when schema is changed.
+type ShardMove struct {
+	From, To string
+	Ratio    float64
+}
+
+// maxShardMoveSlots limit slots compared by ShardMoves, result is estimated when exceeded.
+const maxShardMoveSlots = 1 << 20
+
+// ShardMoves get migration plan between nodes, when nodes or capacity weights of schema are changed.
+// Hashed slot of a key is uniformly distributed, so the keys are compared by slots of both schemas,
+// within least common multiple of their slot counts, shard algorithm and hash seed should be the same.
+func ShardMoves(from, to *config.SchemaConfig) []ShardMove {
+	fromSlots, toSlots := ShardSlots(from), ShardSlots(to)
+	if len(fromSlots) == 0 || len(toSlots) == 0 {
+		return nil
 	}
-	return algo
+	total := len(fromSlots) / gcd(len(fromSlots), len(toSlots)) * len(toSlots)
+	if total > maxShardMoveSlots {
+		total = maxShardMoveSlots
+	}
+	counts := make(map[[2]string]int)
+	var moves []ShardMove
+	for slot := 0; slot < total; slot++ {
+		fromNode, toNode := from.Nodes[fromSlots[slot%len(fromSlots)]], to.Nodes[toSlots[slot%len(toSlots)]]
+		if fromNode != toNode {
+			key := [2]string{fromNode, toNode}
+			if counts[key] == 0 {
+				moves = append(moves, ShardMove{From: fromNode, To: toNode})
+			}
+			counts[key]++
+		}
+	}
+	for i := range moves {
+		moves[i].Ratio = float64(counts[[2]string{moves[i].From, moves[i].To}]) / float64(total)
+	}
+	return moves
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
 }
 
-// HashShardAlgo hash shard algorithm
+// seedOf params of ShardAlgorithm.
+func seedOf(params []interface{}) uint32 {
+	if len(params) > 0 {
+		if seed, ok := params[0].(uint32); ok {
+			return seed
+		}
+	}
+	return 0
+}
+
+// HashShardAlgo hash shard algorithm, crc32(ieee) of value, seed is the initial crc.
 func HashShardAlgo(val string, dataNodeCount int, params ...interface{}) (int, error) {
 	val = strings.Trim(val, "'")
-	hashCode := crc32.ChecksumIEEE([]byte(val))
+	hashCode := crc32.Update(seedOf(params), crc32.IEEETable, []byte(val))
 	index := int(hashCode) % dataNodeCount
 	return index, nil
 }
 
+// Murmur3ShardAlgo murmur3 32-bit(x86) shard algorithm.
+func Murmur3ShardAlgo(val string, dataNodeCount int, params ...interface{}) (int, error) {
+	val = strings.Trim(val, "'")
+	hashCode := murmur3Sum32([]byte(val), seedOf(params))
+	return int(hashCode % uint32(dataNodeCount)), nil
+}
+
+// XXHashShardAlgo xxhash 64-bit shard algorithm.
+func XXHashShardAlgo(val string, dataNodeCount int, params ...interface{}) (int, error) {
+	val = strings.Trim(val, "'")
+	hashCode := xxhashSum64([]byte(val), uint64(seedOf(params)))
+	return int(hashCode % uint64(dataNodeCount)), nil
+}
+
+// JavaShardAlgo is compatible with '(key.hashCode() & Integer.MAX_VALUE) % count' of java, seed is ignored.
+func JavaShardAlgo(val string, dataNodeCount int, params ...interface{}) (int, error) {
+	val = strings.Trim(val, "'")
+	var hashCode int32
+	for _, ch := range utf16.Encode([]rune(val)) {
+		hashCode = 31*hashCode + int32(ch)
+	}
+	return int(hashCode&0x7fffffff) % dataNodeCount, nil
+}
+
 // ModShardAlgo mod shard algorithm
 func ModShardAlgo(val string, dataNodeCount int, params ...interface{}) (int, error) {
 	num, err := strconv.Atoi(val)
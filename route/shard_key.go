@@ -0,0 +1,319 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+// Policies of insert rows whose shard key is null or missing.
+// Empty policy keeps the old behavior: missing shard key is rejected, and null is hashed as 'null'.
+const (
+	ShardKeyReject  = "reject"
+	ShardKeyDefault = "default"
+	ShardKeyDerive  = "derive"
+)
+
+// derive expressions parsed, key is shard_key_derive of table.
+var deriveExprs = struct {
+	sync.RWMutex
+	exprs map[string]sqlparser.Expr
+}{exprs: make(map[string]sqlparser.Expr)}
+
+// ParseShardKeyDerive parse shard_key_derive, such as "lower(email)", "substring_index(email, '@', -1)".
+// Supported functions are lower, upper, trim, left, right, substring_index and concat.
+func ParseShardKeyDerive(derive string) (sqlparser.Expr, error) {
+	deriveExprs.RLock()
+	expr, ok := deriveExprs.exprs[derive]
+	deriveExprs.RUnlock()
+	if ok {
+		return expr, nil
+	}
+
+	statement, err := sqlparser.Parse("select " + derive + " from dual")
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := statement.(*sqlparser.Select)
+	if !ok || len(sel.SelectExprs) != 1 {
+		return nil, errors.ErrShardKeyDerive
+	}
+	nonStar, ok := sel.SelectExprs[0].(*sqlparser.NonStarExpr)
+	if !ok || !checkDeriveExpr(nonStar.Expr) {
+		return nil, errors.ErrShardKeyDerive
+	}
+	expr = nonStar.Expr
+
+	deriveExprs.Lock()
+	deriveExprs.exprs[derive] = expr
+	deriveExprs.Unlock()
+	return expr, nil
+}
+
+// CheckShardKeyPolicy check shard_key_policy of table.
+func CheckShardKeyPolicy(schema *config.SchemaConfig, table *config.TableConfig) error {
+	switch strings.ToLower(table.ShardKeyPolicy) {
+	case "", ShardKeyReject:
+	case ShardKeyDefault:
+		for _, node := range schema.Nodes {
+			if node == table.DefaultNode {
+				return nil
+			}
+		}
+		return errors.ErrInvalidArgument
+	case ShardKeyDerive:
+		_, err := ParseShardKeyDerive(table.ShardKeyDerive)
+		return err
+	default:
+		return errors.ErrInvalidArgument
+	}
+	return nil
+}
+
+// insertShardIndex get index of node for insert or replace, by shard_key_policy of table.
+// Derived shard key is also filled into insert values, so that rows can be found by shard key later.
+func insertShardIndex(schema *config.SchemaConfig, table *config.TableConfig, columns *sqlparser.Columns, rows sqlparser.InsertRows, onDup sqlparser.OnDup) (int, error) {
+	policy := strings.ToLower(table.ShardKeyPolicy)
+	if *columns == nil {
+		return 0, errors.ErrInsertColumnsKey
+	}
+	values, ok := rows.(sqlparser.Values)
+	if !ok {
+		return 0, errors.ErrInsertValuesKey
+	}
+	for _, dupExpr := range onDup {
+		if strings.Trim(strings.ToLower(string(dupExpr.Name.Name)), "`") == schema.ShardKey {
+			return 0, errors.ErrUpdateKey
+		}
+	}
+
+	shardKeyPos := -1
+	for colIndex, columnExpr := range *columns {
+		if nonStar, ok := columnExpr.(*sqlparser.NonStarExpr); ok && sqlparser.GetColName(nonStar.Expr) == schema.ShardKey {
+			shardKeyPos = colIndex
+			break
+		}
+	}
+	columnCount := len(*columns)
+	if shardKeyPos < 0 && policy == ShardKeyDerive {
+		*columns = append(*columns, &sqlparser.NonStarExpr{Expr: &sqlparser.ColName{Name: []byte(schema.ShardKey)}})
+		shardKeyPos = columnCount
+	}
+
+	nodeIndex := -1
+	for i, row := range values {
+		tuple, ok := row.(sqlparser.ValTuple)
+		if !ok {
+			return 0, errors.ErrInsertValuesKey
+		}
+		if len(tuple) != columnCount {
+			return 0, errors.ErrColsLenNotMatch
+		}
+		var colValue sqlparser.ValExpr
+		if shardKeyPos >= 0 && shardKeyPos < columnCount {
+			colValue = tuple[shardKeyPos]
+		}
+
+		rowIndex := 0
+		var err error
+		if _, isNull := colValue.(*sqlparser.NullVal); colValue == nil || isNull {
+			switch policy {
+			case ShardKeyDefault:
+				for index, node := range schema.Nodes {
+					if node == table.DefaultNode {
+						rowIndex = index
+					}
+				}
+			case ShardKeyDerive:
+				if colValue, err = deriveShardKey(table.ShardKeyDerive, (*columns)[:columnCount], tuple); err != nil {
+					return 0, err
+				}
+				if shardKeyPos < columnCount {
+					tuple[shardKeyPos] = colValue
+				} else {
+					tuple = append(tuple, colValue)
+				}
+				values[i] = tuple
+				rowIndex, err = ShardIndex(schema, sqlparser.String(colValue))
+			default:
+				return 0, errors.ErrNullShardKey
+			}
+		} else {
+			rowIndex, err = ShardIndex(schema, sqlparser.String(colValue))
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		if nodeIndex >= 0 && nodeIndex != rowIndex {
+			return 0, errors.ErrInsertValuesKey
+		}
+		nodeIndex = rowIndex
+	}
+	if nodeIndex < 0 {
+		return 0, errors.ErrInsertValuesKey
+	}
+	return nodeIndex, nil
+}
+
+// deriveShardKey evaluate shard_key_derive against columns of insert row.
+func deriveShardKey(derive string, columns sqlparser.Columns, row sqlparser.ValTuple) (sqlparser.ValExpr, error) {
+	expr, err := ParseShardKeyDerive(derive)
+	if err != nil {
+		return nil, err
+	}
+	vals := make(map[string]sqlparser.ValExpr, len(columns))
+	for i, columnExpr := range columns {
+		if nonStar, ok := columnExpr.(*sqlparser.NonStarExpr); ok {
+			vals[sqlparser.GetColName(nonStar.Expr)] = row[i]
+		}
+	}
+	val, err := evalDeriveExpr(expr, vals)
+	if err != nil {
+		return nil, err
+	}
+	if _, isNull := val.(*sqlparser.NullVal); isNull {
+		return nil, errors.ErrNullShardKey
+	}
+	return val, nil
+}
+
+func checkDeriveExpr(expr sqlparser.Expr) bool {
+	switch expr := expr.(type) {
+	case *sqlparser.ColName, sqlparser.StrVal, sqlparser.NumVal:
+		return true
+	case *sqlparser.FuncExpr:
+		switch strings.ToLower(string(expr.Name)) {
+		case "lower", "upper", "trim", "left", "right", "substring_index", "concat":
+		default:
+			return false
+		}
+		for _, arg := range expr.Exprs {
+			if !checkDeriveExpr(arg) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func evalDeriveExpr(expr sqlparser.Expr, vals map[string]sqlparser.ValExpr) (sqlparser.ValExpr, error) {
+	switch expr := expr.(type) {
+	case *sqlparser.ColName:
+		val, ok := vals[sqlparser.GetColName(expr)]
+		if !ok {
+			return &sqlparser.NullVal{}, nil
+		}
+		switch val.(type) {
+		case sqlparser.StrVal, sqlparser.NumVal, *sqlparser.NullVal:
+			return val, nil
+		}
+		return nil, errors.ErrShardKeyDerive
+	case sqlparser.StrVal, sqlparser.NumVal:
+		return expr.(sqlparser.ValExpr), nil
+	case *sqlparser.FuncExpr:
+		args := make([]string, len(expr.Exprs))
+		for i, argExpr := range expr.Exprs {
+			arg, err := evalDeriveExpr(argExpr, vals)
+			if err != nil {
+				return nil, err
+			}
+			switch arg := arg.(type) {
+			case sqlparser.StrVal:
+				args[i] = string(arg)
+			case sqlparser.NumVal:
+				args[i] = string(arg)
+			default:
+				return arg, nil
+			}
+		}
+		result, err := callDeriveFunc(strings.ToLower(string(expr.Name)), args)
+		if err != nil {
+			return nil, err
+		}
+		return sqlparser.StrVal(result), nil
+	}
+	return nil, errors.ErrShardKeyDerive
+}
+
+func callDeriveFunc(name string, args []string) (string, error) {
+	switch name {
+	case "lower", "upper", "trim":
+		if len(args) != 1 {
+			return "", errors.ErrShardKeyDerive
+		}
+		switch name {
+		case "lower":
+			return strings.ToLower(args[0]), nil
+		case "upper":
+			return strings.ToUpper(args[0]), nil
+		}
+		return strings.Trim(args[0], " "), nil
+	case "left", "right":
+		if len(args) != 2 {
+			return "", errors.ErrShardKeyDerive
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil {
+			return "", errors.ErrShardKeyDerive
+		}
+		runes := []rune(args[0])
+		if n < 0 {
+			n = 0
+		}
+		if n > len(runes) {
+			n = len(runes)
+		}
+		if name == "left" {
+			return string(runes[:n]), nil
+		}
+		return string(runes[len(runes)-n:]), nil
+	case "substring_index":
+		if len(args) != 3 {
+			return "", errors.ErrShardKeyDerive
+		}
+		count, err := strconv.Atoi(args[2])
+		if err != nil || args[1] == "" {
+			return "", errors.ErrShardKeyDerive
+		}
+		parts := strings.Split(args[0], args[1])
+		if count >= 0 {
+			if count < len(parts) {
+				parts = parts[:count]
+			}
+		} else if -count < len(parts) {
+			parts = parts[len(parts)+count:]
+		}
+		return strings.Join(parts, args[1]), nil
+	case "concat":
+		return strings.Join(args, ""), nil
+	}
+	return "", errors.ErrShardKeyDerive
+}
@@ -0,0 +1,134 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/errors"
+)
+
+// Hash results must never change, since rows are placed by them.
+func TestMurmur3Sum32(t *testing.T) {
+	cases := []struct {
+		data string
+		seed uint32
+		want uint32
+	}{
+		{"", 0, 0},
+		{"", 1, 0x514e28b7},
+		{"hello", 0, 0x248bfa47},
+		{"The quick brown fox jumps over the lazy dog", 0, 0x2e4ff723},
+	}
+	for _, c := range cases {
+		if got := murmur3Sum32([]byte(c.data), c.seed); got != c.want {
+			t.Errorf("murmur3Sum32(%q, %d) = %#x, want %#x", c.data, c.seed, got, c.want)
+		}
+	}
+}
+
+func TestXXHashSum64(t *testing.T) {
+	cases := []struct {
+		data string
+		want uint64
+	}{
+		{"", 0xef46db3751d8e999},
+		{"a", 0xd24ec4f1a98c6e5b},
+		{"abc", 0x44bc2cf5ad770999},
+		{"Nobody inspects the spammish repetition", 0xfbcea83c8a378bf1},
+	}
+	for _, c := range cases {
+		if got := xxhashSum64([]byte(c.data), 0); got != c.want {
+			t.Errorf("xxhashSum64(%q) = %#x, want %#x", c.data, got, c.want)
+		}
+	}
+}
+
+func TestShardAlgorithms(t *testing.T) {
+	cases := []struct {
+		algo string
+		val  string
+		seed uint32
+		want int
+	}{
+		{"hash", "'hello'", 0, 907060870 % 7},
+		{"php", "hello", 0, 907060870 % 7},
+		{"crc32", "hello", 0, 907060870 % 7},
+		{"java", "hello", 0, 99162322 % 7},
+		{"murmur3", "hello", 0, 0x248bfa47 % 7},
+		{"mod", "10", 0, 2},
+	}
+	for _, c := range cases {
+		got, err := ParseShardAlgorithm(c.algo)(c.val, 7, c.seed)
+		if err != nil || got != c.want {
+			t.Errorf("%s(%s) = %d, %v, want %d", c.algo, c.val, got, err, c.want)
+		}
+	}
+}
+
+func TestNormalizeShardKey(t *testing.T) {
+	cases := []struct {
+		normalize []string
+		collation string
+		val       string
+		want      string
+	}{
+		{nil, "", "'ABC '", "'ABC '"},
+		{nil, "utf8mb4_general_ci", "'ABC '", "'abc'"},
+		{nil, "utf8mb4_bin", "'ABC '", "'ABC'"},
+		{nil, "utf8mb4_0900_ai_ci", "'ABC '", "'abc '"},
+		{[]string{"trim", "casefold"}, "", "' ABC '", "'abc'"},
+		{[]string{"nfc"}, "", "'cafe\u0301'", "'caf\u00e9'"},
+		{[]string{"nfc", "casefold"}, "", "'E\u0323\u0302'", "'\u1ec7'"},
+		{[]string{"casefold"}, "", "10", "10"},
+	}
+	for _, c := range cases {
+		schema := &config.SchemaConfig{ShardKeyNormalize: c.normalize, ShardKeyCollation: c.collation}
+		if got := normalizeShardKey(schema, c.val); got != c.want {
+			t.Errorf("normalizeShardKey(%v, %q, %q) = %q, want %q", c.normalize, c.collation, c.val, got, c.want)
+		}
+	}
+}
+
+func TestShardIndexWeighted(t *testing.T) {
+	schema := &config.SchemaConfig{Nodes: []string{"n1", "n2"}, ShardKey: "k", ShardAlgo: "mod", NodeWeights: map[string]int{"n1": 3}}
+	want := []int{0, 0, 0, 1, 0, 0, 0, 1}
+	for i, w := range want {
+		if got, _ := ShardIndex(schema, strconv.Itoa(i+1)); got != w {
+			t.Errorf("ShardIndex(%d) = %d, want %d", i+1, got, w)
+		}
+	}
+
+	from := &config.SchemaConfig{Nodes: []string{"n1", "n2"}, ShardKey: "k", ShardAlgo: "mod"}
+	moves := ShardMoves(from, schema)
+	if len(moves) != 1 || moves[0].From != "n2" || moves[0].To != "n1" || moves[0].Ratio != 0.25 {
+		t.Errorf("ShardMoves = %v, want [{n2 n1 0.25}]", moves)
+	}
+
+	zero := &config.SchemaConfig{Nodes: []string{"n1", "n2"}, ShardKey: "k", NodeWeights: map[string]int{"n1": 0, "n2": 0}}
+	if _, err := ShardIndex(zero, "1"); err != errors.ErrNoDataNode {
+		t.Errorf("ShardIndex of zero weights: err = %v, want %v", err, errors.ErrNoDataNode)
+	}
+}
@@ -73,6 +73,7 @@ func (r *Router) buildShowEnginesPlan(statement *sqlparser.ShowEngines) (*normal
 	plan.onSlave = true && !r.InTrans
 	plan.Statement = statement
 	plan.anyNode = true
+	plan.Result = showEnginesResult()
 
 	return plan, nil
 }
@@ -95,7 +96,7 @@ func (r *Router) buildShowProcessListPlan(statement *sqlparser.ShowProcessList)
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -111,7 +112,7 @@ func (r *Router) buildShowFullProcessListPlan(statement *sqlparser.ShowFullProce
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -127,7 +128,7 @@ func (r *Router) buildShowSlaveStatusPlan(statement *sqlparser.ShowSlaveStatus)
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -156,13 +157,17 @@ func (r *Router) buildShowCharsetPlan(statement *sqlparser.ShowCharset) (*normal
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
 	plan.onSlave = true && !r.InTrans && !hint.OnMaster
 	plan.Statement = statement
 	plan.anyNode = true
+	// 'where' and hint of nodes execute on backend.
+	if match, ok := likeMatcher(statement.LikeOrWhere); ok && len(hint.Nodes) == 0 {
+		plan.Result = showCharsetResult(match)
+	}
 
 	return plan, nil
 }
@@ -173,13 +178,17 @@ func (r *Router) buildShowCollationPlan(statement *sqlparser.ShowCollation) (*no
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
 	plan.onSlave = true && !r.InTrans && !hint.OnMaster
 	plan.Statement = statement
 	plan.anyNode = true
+	// 'where' and hint of nodes execute on backend.
+	if match, ok := likeMatcher(statement.LikeOrWhere); ok && len(hint.Nodes) == 0 {
+		plan.Result = showCollationResult(match)
+	}
 
 	return plan, nil
 }
@@ -190,7 +199,7 @@ func (r *Router) buildShowVariablesPlan(statement *sqlparser.ShowVariables) (*no
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -254,7 +263,7 @@ func (r *Router) buildShowStatusPlan(statement *sqlparser.ShowStatus) (*normalPl
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -269,7 +278,7 @@ func (r *Router) buildShowDatabasesPlan(statement *sqlparser.ShowDatabases) (*no
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -312,7 +321,7 @@ func (r *Router) buildShowTablesPlan(statement *sqlparser.ShowTables) (*normalPl
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -363,7 +372,7 @@ func (r *Router) buildShowFullTablesPlan(statement *sqlparser.ShowFullTables) (*
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -426,7 +435,7 @@ func (r *Router) buildShowTableStatusPlan(statement *sqlparser.ShowTableStatus)
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -481,7 +490,7 @@ func (r *Router) buildShowColumnsPlan(statement *sqlparser.ShowColumns) (*normal
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -522,7 +531,7 @@ func (r *Router) buildShowFullColumnsPlan(statement *sqlparser.ShowFullColumns)
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -563,7 +572,7 @@ func (r *Router) buildShowIndexPlan(statement *sqlparser.ShowIndex) (*normalPlan
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -593,7 +602,7 @@ func (r *Router) buildShowTriggersPlan(statement *sqlparser.ShowTriggers) (*norm
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -756,7 +765,7 @@ func (r *Router) buildShowProcedureStatusPlan(statement *sqlparser.ShowProcedure
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -915,7 +924,7 @@ func (r *Router) buildShowFunctionStatusPlan(statement *sqlparser.ShowFunctionSt
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -1083,7 +1092,7 @@ func (r *Router) buildShowCreateDatabasePlan(statement *sqlparser.ShowCreateData
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -1118,7 +1127,7 @@ func (r *Router) buildShowCreateTablePlan(statement *sqlparser.ShowCreateTable)
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -1153,7 +1162,7 @@ func (r *Router) buildShowCreateViewPlan(statement *sqlparser.ShowCreateView) (*
 
 	plan := new(normalPlan)
 	if hint.Nodes != nil && len(hint.Nodes) > 0 {
-		plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 	} else {
 		plan.nodeNames = []string{schemaConfig.Nodes[0]}
 	}
@@ -1184,7 +1193,7 @@ func (r *Router) buildShowCreateTriggerPlan(statement *sqlparser.ShowCreateTrigg
 		trigger = strings.Trim(strings.ToLower(trigger), "`")
 
 		if hint.Nodes != nil && len(hint.Nodes) > 0 {
-			plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+			plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 		} else {
 			plan.nodeNames = []string{schemaConfig.Nodes[0]}
 		}
@@ -1217,7 +1226,7 @@ func (r *Router) buildShowCreateProcedurePlan(statement *sqlparser.ShowCreatePro
 		procedure = strings.Trim(strings.ToLower(procedure), "`")
 
 		if hint.Nodes != nil && len(hint.Nodes) > 0 {
-			plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+			plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 		} else {
 			plan.nodeNames = []string{schemaConfig.Nodes[0]}
 		}
@@ -1250,7 +1259,7 @@ func (r *Router) buildShowCreateFunctionPlan(statement *sqlparser.ShowCreateFunc
 		function = strings.Trim(strings.ToLower(function), "`")
 
 		if hint.Nodes != nil && len(hint.Nodes) > 0 {
-			plan.nodeNames = utils.StringCollectionIntersection(hint.Nodes, schemaConfig.Nodes)
+			plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
 		} else {
 			plan.nodeNames = []string{schemaConfig.Nodes[0]}
 		}
@@ -1261,3 +1270,37 @@ func (r *Router) buildShowCreateFunctionPlan(statement *sqlparser.ShowCreateFunc
 	}
 	return nil, errors.ErrNoPlan
 }
+
+func (r *Router) buildShowGrantsPlan(statement *sqlparser.ShowGrants) (*normalPlan, error) {
+	schemaConfig := r.Schemas[r.SchemaName]
+	hint := ReadHint(&statement.Comments)
+
+	plan := new(normalPlan)
+	if hint.Nodes != nil && len(hint.Nodes) > 0 {
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
+	} else {
+		plan.nodeNames = []string{schemaConfig.Nodes[0]}
+	}
+	plan.onSlave = true && !r.InTrans && !hint.OnMaster
+	plan.Statement = statement
+	plan.anyNode = true
+
+	return plan, nil
+}
+
+// buildShowWarningsPlan warnings and errors are of backend connection, so it's on master of representative node.
+func (r *Router) buildShowWarningsPlan(statement sqlparser.ShowStatement, comments *sqlparser.Comments) (*normalPlan, error) {
+	schemaConfig := r.Schemas[r.SchemaName]
+	hint := ReadHint(comments)
+
+	plan := new(normalPlan)
+	if hint.Nodes != nil && len(hint.Nodes) > 0 {
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
+	} else {
+		plan.nodeNames = []string{schemaConfig.Nodes[0]}
+	}
+	plan.Statement = statement
+	plan.anyNode = true
+
+	return plan, nil
+}
@@ -0,0 +1,55 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"net"
+	"time"
+)
+
+// SlowLog is a plan executed longer than slow_log_time.
+type SlowLog struct {
+	Time     string   `json:"time"`
+	State    string   `json:"state"`
+	Duration float64  `json:"duration"` // milliseconds.
+	Client   string   `json:"client"`
+	Nodes    []string `json:"nodes"`
+	Backends []string `json:"backends,omitempty"`
+	OnSlave  bool     `json:"on_slave"`
+	SQL      string   `json:"sql"`
+}
+
+// OnSlowLog is called with each slow log if it's set, such as shipping to elasticsearch.
+var OnSlowLog func(log *SlowLog)
+
+func fireSlowLog(state string, execTime float64, clientAddr net.Addr, nodeNames, backendConnAddrs []string, onSlave bool, sql string) {
+	if OnSlowLog == nil {
+		return
+	}
+	log := &SlowLog{Time: time.Now().Format(time.RFC3339Nano), State: state, Duration: execTime,
+		Nodes: nodeNames, Backends: backendConnAddrs, OnSlave: onSlave, SQL: sql}
+	if clientAddr != nil {
+		log.Client = clientAddr.String()
+	}
+	OnSlowLog(log)
+}
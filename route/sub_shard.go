@@ -0,0 +1,154 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package route
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/berkaroad/saashard/config"
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/sqlparser"
+)
+
+// SubShardEnabled check whether table is sub-sharded into tables in each node.
+func SubShardEnabled(table *config.TableConfig) bool {
+	return table != nil && table.SubShardKey != "" && table.SubShardCount > 1
+}
+
+// SubShardIndex get index of table in node by sub shard key value, with sub_shard_algo of table.
+func SubShardIndex(schema *config.SchemaConfig, table *config.TableConfig, val string) (int, error) {
+	algo := ParseShardAlgorithm(table.SubShardAlgo)
+	return algo(normalizeShardKey(schema, val), table.SubShardCount, schema.ShardHashSeed)
+}
+
+// PhysicalTableName name of sub-sharded table, such as "orders_3".
+func PhysicalTableName(table string, index int) string {
+	return fmt.Sprintf("%s_%d", table, index)
+}
+
+// rewriteSubShardTable rewrite table name to db-qualified physical name, if table is sub-sharded.
+// Value of sub shard key is found by findValue, nil value means not found.
+func (r *Router) rewriteSubShardTable(schema *config.SchemaConfig, tableName *sqlparser.TableName, nodeName string,
+	findValue func(key string) (sqlparser.ValExpr, error)) error {
+	table := strings.Trim(strings.ToLower(string(tableName.Name)), "`")
+	tableConfig := schema.GetTables()[table]
+	if !SubShardEnabled(tableConfig) {
+		return nil
+	}
+
+	colValue, err := findValue(tableConfig.SubShardKey)
+	switch err {
+	case nil:
+		if colValue == nil {
+			return errors.ErrSubShardKey
+		}
+	case errors.ErrWhereOrJoinOnKey, errors.ErrInsertColumnsKey, errors.ErrInsertValuesKey:
+		return errors.ErrSubShardKey
+	default:
+		return err
+	}
+	index, err := SubShardIndex(schema, tableConfig, sqlparser.String(colValue))
+	if err != nil {
+		return err
+	}
+
+	tableName.Name = []byte(PhysicalTableName(table, index))
+	if node, ok := r.Nodes[nodeName]; ok && node.Database != "" {
+		tableName.Qualifier = []byte(node.Database)
+	}
+	return nil
+}
+
+// rewriteSubShardSelect rewrite sub-sharded tables in FROM of select or union.
+// Alias of original name is added, so that qualified columns are still valid.
+func (r *Router) rewriteSubShardSelect(schema *config.SchemaConfig, statement sqlparser.SelectStatement, nodeName string) error {
+	switch statement := statement.(type) {
+	case *sqlparser.Select:
+		if err := r.rewriteSubShardWith(schema, statement.With, nodeName); err != nil {
+			return err
+		}
+		findValue := func(key string) (sqlparser.ValExpr, error) {
+			return sqlparser.CheckColumnInBoolExpr(whereExpr(statement.Where), key)
+		}
+		for _, tableExpr := range statement.From {
+			if err := r.rewriteSubShardTableExpr(schema, tableExpr, nodeName, findValue); err != nil {
+				return err
+			}
+		}
+	case *sqlparser.Union:
+		if err := r.rewriteSubShardWith(schema, statement.With, nodeName); err != nil {
+			return err
+		}
+		if err := r.rewriteSubShardSelect(schema, statement.Left, nodeName); err != nil {
+			return err
+		}
+		return r.rewriteSubShardSelect(schema, statement.Right, nodeName)
+	}
+	return nil
+}
+
+func (r *Router) rewriteSubShardWith(schema *config.SchemaConfig, with *sqlparser.With, nodeName string) error {
+	if with == nil {
+		return nil
+	}
+	for _, cte := range with.CTEs {
+		if err := r.rewriteSubShardSelect(schema, cte.Select, nodeName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Router) rewriteSubShardTableExpr(schema *config.SchemaConfig, tableExpr sqlparser.TableExpr, nodeName string,
+	findValue func(key string) (sqlparser.ValExpr, error)) error {
+	switch tableExpr := tableExpr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		tableName, ok := tableExpr.Expr.(*sqlparser.TableName)
+		if !ok {
+			return nil
+		}
+		name := tableName.Name
+		if err := r.rewriteSubShardTable(schema, tableName, nodeName, findValue); err != nil {
+			return err
+		}
+		if tableExpr.As == nil && string(tableName.Name) != string(name) {
+			tableExpr.As = name
+		}
+	case *sqlparser.ParenTableExpr:
+		return r.rewriteSubShardTableExpr(schema, tableExpr.Expr, nodeName, findValue)
+	case *sqlparser.JoinTableExpr:
+		if err := r.rewriteSubShardTableExpr(schema, tableExpr.LeftExpr, nodeName, findValue); err != nil {
+			return err
+		}
+		return r.rewriteSubShardTableExpr(schema, tableExpr.RightExpr, nodeName, findValue)
+	}
+	return nil
+}
+
+func whereExpr(where *sqlparser.Where) sqlparser.BoolExpr {
+	if where == nil {
+		return nil
+	}
+	return where.Expr
+}
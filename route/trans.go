@@ -22,7 +22,11 @@
 
 package route
 
-import "github.com/berkaroad/saashard/sqlparser"
+import (
+	"github.com/berkaroad/saashard/errors"
+	"github.com/berkaroad/saashard/sqlparser"
+	"github.com/berkaroad/saashard/utils"
+)
 
 func (r *Router) buildTransactionPlan(statement sqlparser.TransactionStatement) (*normalPlan, error) {
 	schemaConfig := r.Schemas[r.SchemaName]
@@ -34,3 +38,38 @@ func (r *Router) buildTransactionPlan(statement sqlparser.TransactionStatement)
 
 	return plan, nil
 }
+
+// buildLockTablesPlan forward LOCK TABLES to the single node that owns the tables, if allow_lock_tables is true.
+// Tables of sharded schema are in every node, so the node should be hinted.
+func (r *Router) buildLockTablesPlan(statement *sqlparser.LockTables) (*normalPlan, error) {
+	if !r.AllowLock {
+		return nil, errors.ErrLockDenied
+	}
+	schemaConfig := r.Schemas[r.SchemaName]
+	for _, table := range statement.Tables {
+		table.Table.Qualifier = nil
+	}
+	hint := ReadHint(&statement.Comments)
+
+	plan := new(normalPlan)
+	if len(hint.Nodes) > 0 {
+		plan.nodeNames = utils.StringCollectionIntersection(schemaConfig.ExpandNodeNames(hint.Nodes), schemaConfig.Nodes)
+	} else if !schemaConfig.ShardEnabled() || len(schemaConfig.Nodes) == 1 {
+		plan.nodeNames = []string{schemaConfig.Nodes[0]}
+	}
+	if len(plan.nodeNames) != 1 {
+		return nil, errors.ErrLockNode
+	}
+	plan.Statement = statement
+	return plan, nil
+}
+
+// buildUnlockTablesPlan UNLOCK TABLES runs at node of locked tables of session, it's chosen by proxy.
+func (r *Router) buildUnlockTablesPlan(statement *sqlparser.UnlockTables) (*normalPlan, error) {
+	schemaConfig := r.Schemas[r.SchemaName]
+	plan := new(normalPlan)
+	plan.nodeNames = []string{schemaConfig.Nodes[0]}
+	plan.Statement = statement
+	plan.anyNode = true
+	return plan, nil
+}
@@ -23,11 +23,14 @@
 package server
 
 import (
+	"io"
 	"sync/atomic"
+	"time"
 
 	"github.com/berkaroad/saashard/admin"
 	"github.com/berkaroad/saashard/config"
 	"github.com/berkaroad/saashard/proxy"
+	"github.com/berkaroad/saashard/utils/simplelog"
 )
 
 const (
@@ -39,6 +42,13 @@ const (
 	Unknown
 )
 
+// defaults of kubernetes mode, drain within default terminationGracePeriodSeconds(30).
+const (
+	kubernetesConfigWatchInterval = 10
+	kubernetesDrainDelay          = 5
+	kubernetesDrainTimeout        = 25
+)
+
 // Server is startup endpoint.
 type Server struct {
 	cfg *config.Config
@@ -56,15 +66,56 @@ func NewServer(cfg *config.Config) (*Server, error) {
 	var err error
 	s := new(Server)
 	s.cfg = cfg
+	if cfg.Kubernetes {
+		setKubernetesDefaults(cfg)
+	}
 
 	atomic.StoreInt32(&s.statusIndex, 0)
 	s.status[s.statusIndex] = Online
 
+	if cfg.Preflight {
+		var results []proxy.PreflightResult
+		if results, err = Preflight(cfg); err != nil {
+			return s, err
+		}
+		if err = proxy.PreflightError(results); err != nil {
+			return s, err
+		}
+	}
+
 	s.proxy, err = proxy.NewServer(cfg)
-	s.admin, err = admin.NewServer(cfg)
+	s.admin, err = admin.NewServer(cfg, s.proxy)
 	return s, err
 }
 
+// setKubernetesDefaults of options not set, config of mounted ConfigMap or Secret is watched, and proxy is drained when SIGTERM.
+func setKubernetesDefaults(cfg *config.Config) {
+	if cfg.ConfigWatchInterval == 0 {
+		cfg.ConfigWatchInterval = kubernetesConfigWatchInterval
+	}
+	if cfg.DrainDelay == 0 {
+		cfg.DrainDelay = kubernetesDrainDelay
+	}
+	if cfg.DrainTimeout == 0 {
+		cfg.DrainTimeout = kubernetesDrainTimeout
+	}
+}
+
+// CheckConfig validate config without connecting to backends.
+func CheckConfig(cfg *config.Config) error {
+	return proxy.CheckConfig(cfg)
+}
+
+// Preflight check backends of config, without starting server.
+func Preflight(cfg *config.Config) ([]proxy.PreflightResult, error) {
+	return proxy.Preflight(cfg)
+}
+
+// Dump write logical dump of table into w, without starting server.
+func Dump(cfg *config.Config, schema, table string, chunkSize int, w io.Writer) error {
+	return proxy.Dump(cfg, schema, table, chunkSize, w)
+}
+
 // Run server.
 func (s *Server) Run() {
 	s.running = true
@@ -92,6 +143,46 @@ func (s *Server) Status() string {
 	return status
 }
 
+// WatchConfig parse config file every config_watch_interval seconds, and reload proxy if changed.
+// Mounted ConfigMap or Secret is updated by kubelet in place, so file is parsed again rather than notified.
+func (s *Server) WatchConfig(fileName, env string) {
+	if s.cfg.ConfigWatchInterval <= 0 {
+		return
+	}
+	last := s.cfg
+	for {
+		time.Sleep(time.Duration(s.cfg.ConfigWatchInterval) * time.Second)
+		if !s.running {
+			return
+		}
+		cfg, err := config.ParseConfigFileWithEnv(fileName, env)
+		if err != nil {
+			simplelog.Error("%s %s %s", "server", "WatchConfig", err.Error())
+			continue
+		}
+		if s.cfg.Kubernetes {
+			setKubernetesDefaults(cfg)
+		}
+		if config.Equal(last, cfg) {
+			continue
+		}
+		simplelog.Info("%s %s %s file=%s", "server", "WatchConfig", "Config is changed", fileName)
+		s.proxy.Reload(last, cfg)
+		last = cfg
+	}
+}
+
+// Drain server within drain_timeout seconds, then close it. It's closed at once if drain_timeout is 0.
+func (s *Server) Drain() {
+	next := 1 - atomic.LoadInt32(&s.statusIndex)
+	s.status[next] = Offline
+	atomic.StoreInt32(&s.statusIndex, next)
+	if s.cfg.DrainTimeout > 0 {
+		s.proxy.Drain(time.Duration(s.cfg.DrainDelay)*time.Second, time.Duration(s.cfg.DrainTimeout)*time.Second)
+	}
+	s.Close()
+}
+
 // Close server.
 func (s *Server) Close() {
 	s.running = false
@@ -27,6 +27,7 @@
 package sqlparser
 
 import (
+	"bytes"
 	"errors"
 	"strconv"
 	"strings"
@@ -50,11 +51,7 @@ import (
 // is the AST representation of the query.
 func Parse(sql string) (Statement, error) {
 	// yyDebug = 4
-	tokenizer := NewStringTokenizer(sql)
-	if yyParse(tokenizer) != 0 {
-		return nil, errors.New(tokenizer.LastError)
-	}
-	return tokenizer.ParseTree, nil
+	return ParseWithSQLMode(sql, 0)
 }
 
 // SQLNode defines the interface for all nodes
@@ -214,6 +211,17 @@ func (node *TableName) Format(buf *TrackedBuffer) {
 	escape(buf, node.Name)
 }
 
+// TableNames represents a list of table names.
+type TableNames []*TableName
+
+func (node TableNames) Format(buf *TrackedBuffer) {
+	var prefix string
+	for _, n := range node {
+		buf.Fprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+}
+
 // ParenTableExpr represents a parenthesized TableExpr.
 type ParenTableExpr struct {
 	Expr TableExpr
@@ -328,27 +336,42 @@ type Expr interface {
 	SQLNode
 }
 
-func (*AndExpr) IExpr()        {}
-func (*OrExpr) IExpr()         {}
-func (*NotExpr) IExpr()        {}
-func (*ParenBoolExpr) IExpr()  {}
-func (*ComparisonExpr) IExpr() {}
-func (*RangeCond) IExpr()      {}
-func (*NullCheck) IExpr()      {}
-func (*ExistsExpr) IExpr()     {}
-func (StrVal) IExpr()          {}
-func (NumVal) IExpr()          {}
-func (ValArg) IExpr()          {}
-func (*NullVal) IExpr()        {}
-func (*ColName) IExpr()        {}
-func (ValTuple) IExpr()        {}
-func (*Subquery) IExpr()       {}
-func (*BinaryExpr) IExpr()     {}
-func (*UnaryExpr) IExpr()      {}
-func (*FuncExpr) IExpr()       {}
-func (*CaseExpr) IExpr()       {}
-func (*LikeExpr) IExpr()       {}
-func (*WhereExpr) IExpr()      {}
+func (*AndExpr) IExpr()         {}
+func (*OrExpr) IExpr()          {}
+func (*XorExpr) IExpr()         {}
+func (*BoolValExpr) IExpr()     {}
+func (*NotExpr) IExpr()         {}
+func (*ParenBoolExpr) IExpr()   {}
+func (*ComparisonExpr) IExpr()  {}
+func (*RangeCond) IExpr()       {}
+func (*NullCheck) IExpr()       {}
+func (*ExistsExpr) IExpr()      {}
+func (StrVal) IExpr()           {}
+func (NumVal) IExpr()           {}
+func (HexVal) IExpr()           {}
+func (BitVal) IExpr()           {}
+func (ValArg) IExpr()           {}
+func (*NullVal) IExpr()         {}
+func (*ColName) IExpr()         {}
+func (*UserVariable) IExpr()    {}
+func (*SystemVariable) IExpr()  {}
+func (*Assignment) IExpr()      {}
+func (ValTuple) IExpr()         {}
+func (*Subquery) IExpr()        {}
+func (*BinaryExpr) IExpr()      {}
+func (*JSONExtractExpr) IExpr() {}
+func (*MatchExpr) IExpr()       {}
+func (*IntervalExpr) IExpr()    {}
+func (*CastExpr) IExpr()        {}
+func (*IntroducerExpr) IExpr()  {}
+func (*UnaryBinaryExpr) IExpr() {}
+func (*CollateExpr) IExpr()     {}
+func (*UnaryExpr) IExpr()       {}
+func (*FuncExpr) IExpr()        {}
+func (*CaseExpr) IExpr()        {}
+func (*WindowExpr) IExpr()      {}
+func (*LikeExpr) IExpr()        {}
+func (*WhereExpr) IExpr()       {}
 
 // BoolExpr represents a boolean expression.
 type BoolExpr interface {
@@ -358,12 +381,15 @@ type BoolExpr interface {
 
 func (*AndExpr) IBoolExpr()        {}
 func (*OrExpr) IBoolExpr()         {}
+func (*XorExpr) IBoolExpr()        {}
 func (*NotExpr) IBoolExpr()        {}
 func (*ParenBoolExpr) IBoolExpr()  {}
 func (*ComparisonExpr) IBoolExpr() {}
 func (*RangeCond) IBoolExpr()      {}
 func (*NullCheck) IBoolExpr()      {}
 func (*ExistsExpr) IBoolExpr()     {}
+func (*MatchExpr) IBoolExpr()      {}
+func (*BoolValExpr) IBoolExpr()    {}
 
 // AndExpr represents an AND expression.
 type AndExpr struct {
@@ -383,6 +409,24 @@ func (node *OrExpr) Format(buf *TrackedBuffer) {
 	buf.Fprintf("%v or %v", node.Left, node.Right)
 }
 
+// XorExpr represents a logical XOR expression.
+type XorExpr struct {
+	Left, Right BoolExpr
+}
+
+func (node *XorExpr) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v xor %v", node.Left, node.Right)
+}
+
+// BoolValExpr represents a value expression as operand of logical operator, such as a in 'a xor b'.
+type BoolValExpr struct {
+	Expr ValExpr
+}
+
+func (node *BoolValExpr) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v", node.Expr)
+}
+
 // NotExpr represents a NOT expression.
 type NotExpr struct {
 	Expr BoolExpr
@@ -409,17 +453,19 @@ type ComparisonExpr struct {
 
 // ComparisonExpr.Operator
 const (
-	AST_EQ       = "="
-	AST_LT       = "<"
-	AST_GT       = ">"
-	AST_LE       = "<="
-	AST_GE       = ">="
-	AST_NE       = "!="
-	AST_NSE      = "<=>"
-	AST_IN       = "in"
-	AST_NOT_IN   = "not in"
-	AST_LIKE     = "like"
-	AST_NOT_LIKE = "not like"
+	AST_EQ         = "="
+	AST_LT         = "<"
+	AST_GT         = ">"
+	AST_LE         = "<="
+	AST_GE         = ">="
+	AST_NE         = "!="
+	AST_NSE        = "<=>"
+	AST_IN         = "in"
+	AST_NOT_IN     = "not in"
+	AST_LIKE       = "like"
+	AST_NOT_LIKE   = "not like"
+	AST_REGEXP     = "regexp"
+	AST_NOT_REGEXP = "not regexp"
 )
 
 func (node *ComparisonExpr) Format(buf *TrackedBuffer) {
@@ -474,22 +520,41 @@ type ValExpr interface {
 	Expr
 }
 
-func (StrVal) IValExpr()      {}
-func (NumVal) IValExpr()      {}
-func (ValArg) IValExpr()      {}
-func (*NullVal) IValExpr()    {}
-func (*ColName) IValExpr()    {}
-func (ValTuple) IValExpr()    {}
-func (*Subquery) IValExpr()   {}
-func (*BinaryExpr) IValExpr() {}
-func (*UnaryExpr) IValExpr()  {}
-func (*FuncExpr) IValExpr()   {}
-func (*CaseExpr) IValExpr()   {}
+func (StrVal) IValExpr()           {}
+func (NumVal) IValExpr()           {}
+func (HexVal) IValExpr()           {}
+func (BitVal) IValExpr()           {}
+func (ValArg) IValExpr()           {}
+func (*NullVal) IValExpr()         {}
+func (*ColName) IValExpr()         {}
+func (*UserVariable) IValExpr()    {}
+func (*SystemVariable) IValExpr()  {}
+func (*Assignment) IValExpr()      {}
+func (ValTuple) IValExpr()         {}
+func (*Subquery) IValExpr()        {}
+func (*BinaryExpr) IValExpr()      {}
+func (*JSONExtractExpr) IValExpr() {}
+func (*MatchExpr) IValExpr()       {}
+func (*IntervalExpr) IValExpr()    {}
+func (*CastExpr) IValExpr()        {}
+func (*IntroducerExpr) IValExpr()  {}
+func (*UnaryBinaryExpr) IValExpr() {}
+func (*CollateExpr) IValExpr()     {}
+func (*UnaryExpr) IValExpr()       {}
+func (*FuncExpr) IValExpr()        {}
+func (*CaseExpr) IValExpr()        {}
+func (*WindowExpr) IValExpr()      {}
 
 // StrVal represents a string value.
 type StrVal []byte
 
 func (node StrVal) Format(buf *TrackedBuffer) {
+	if buf.SQLMode&SQL_MODE_NO_BACKSLASH_ESCAPES > 0 {
+		buf.WriteByte('\'')
+		buf.Write(bytes.Replace([]byte(node), []byte("'"), []byte("''"), -1))
+		buf.WriteByte('\'')
+		return
+	}
 	s := sqltypes.MakeString([]byte(node))
 	s.EncodeSQL(buf)
 }
@@ -501,6 +566,20 @@ func (node NumVal) Format(buf *TrackedBuffer) {
 	buf.Fprintf("%s", []byte(node))
 }
 
+// HexVal represents a hexadecimal literal in original form, such as x'ab'.
+type HexVal []byte
+
+func (node HexVal) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%s", []byte(node))
+}
+
+// BitVal represents a bit literal in original form, such as b'0101'.
+type BitVal []byte
+
+func (node BitVal) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%s", []byte(node))
+}
+
 // ValArg represents a named bind var argument.
 type ValArg []byte
 
@@ -541,9 +620,82 @@ func (node *ColName) Format(buf *TrackedBuffer) {
 	escape(buf, node.Name)
 }
 
+// UserVariable represents a user variable @name in expression.
+type UserVariable struct {
+	Name []byte // name without @
+}
+
+func (node *UserVariable) Format(buf *TrackedBuffer) {
+	buf.Fprintf("@")
+	escape(buf, node.Name)
+}
+
+// Assignment represents an assignment @name := expr in expression, its value is the assigned value.
+type Assignment struct {
+	Name []byte // name without @
+	Expr ValExpr
+}
+
+func (node *Assignment) Format(buf *TrackedBuffer) {
+	buf.Fprintf("@")
+	escape(buf, node.Name)
+	buf.Fprintf(" := %v", node.Expr)
+}
+
+// SystemVariable represents a system variable @@name or @@scope.name in expression.
+type SystemVariable struct {
+	Scope string // AST_SCOPE_GLOBAL, AST_SCOPE_SESSION, AST_SCOPE_LOCAL, or empty if it isn't specified.
+	Name  []byte // name without @@
+}
+
+func (node *SystemVariable) Format(buf *TrackedBuffer) {
+	buf.Fprintf("@@")
+	if node.Scope != "" {
+		buf.Fprintf("%s.", node.Scope)
+	}
+	escape(buf, node.Name)
+}
+
+// NewSystemVariable create system variable of column name @@name or @@scope.name, false if it isn't system variable.
+func NewSystemVariable(col *ColName) (*SystemVariable, bool) {
+	if col.Qualifier == nil {
+		if len(col.Name) > 2 && bytes.HasPrefix(col.Name, []byte("@@")) {
+			return &SystemVariable{Name: col.Name[2:]}, true
+		}
+		return nil, false
+	}
+	switch scope := string(bytes.ToLower(col.Qualifier)); scope {
+	case "@@" + AST_SCOPE_GLOBAL, "@@" + AST_SCOPE_SESSION, "@@" + AST_SCOPE_LOCAL:
+		return &SystemVariable{Scope: scope[2:], Name: col.Name}, true
+	}
+	return nil, false
+}
+
+// IsUserVariableName check whether identifier is user variable @name, but not system variable @@name.
+func IsUserVariableName(name []byte) bool {
+	return len(name) > 1 && name[0] == '@' && name[1] != '@'
+}
+
+// UserVariables get names of user variables in lower case, that are referenced or assigned in statement.
+func UserVariables(node SQLNode) (referenced, assigned []string) {
+	buf := NewTrackedBuffer(func(buf *TrackedBuffer, node SQLNode) {
+		switch v := node.(type) {
+		case *UserVariable:
+			referenced = append(referenced, strings.ToLower(string(v.Name)))
+		case *Assignment:
+			assigned = append(assigned, strings.ToLower(string(v.Name)))
+		}
+		node.Format(buf)
+	})
+	buf.Fprintf("%v", node)
+	return
+}
+
 func escape(buf *TrackedBuffer, name []byte) {
 	if _, ok := keywords[strings.ToLower(string(name))]; ok {
 		buf.Fprintf("`%s`", name)
+	} else if bytes.IndexByte(name, '`') >= 0 {
+		buf.Fprintf("`%s`", bytes.Replace(name, []byte("`"), []byte("``"), -1))
 	} else {
 		needQuota := false
 		// except "*"
@@ -602,24 +754,98 @@ func (node *Subquery) Format(buf *TrackedBuffer) {
 
 // BinaryExpr represents a binary value expression.
 type BinaryExpr struct {
-	Operator    byte
+	Operator    string
 	Left, Right Expr
 }
 
 // BinaryExpr.Operator
 const (
-	AST_BITAND = '&'
-	AST_BITOR  = '|'
-	AST_BITXOR = '^'
-	AST_PLUS   = '+'
-	AST_MINUS  = '-'
-	AST_MULT   = '*'
-	AST_DIV    = '/'
-	AST_MOD    = '%'
+	AST_BITAND  = "&"
+	AST_BITOR   = "|"
+	AST_BITXOR  = "^"
+	AST_PLUS    = "+"
+	AST_MINUS   = "-"
+	AST_MULT    = "*"
+	AST_DIV     = "/"
+	AST_MOD     = "%"
+	AST_INT_DIV = " div "
 )
 
 func (node *BinaryExpr) Format(buf *TrackedBuffer) {
-	buf.Fprintf("%v%c%v", node.Left, node.Operator, node.Right)
+	buf.Fprintf("%v%s%v", node.Left, node.Operator, node.Right)
+}
+
+// JSONExtractExpr represents column->path and column->>path, path is string literal.
+type JSONExtractExpr struct {
+	Operator string
+	Column   *ColName
+	Path     StrVal
+}
+
+// JSONExtractExpr.Operator
+const (
+	AST_JSON_EXTRACT         = "->"
+	AST_JSON_UNQUOTE_EXTRACT = "->>"
+)
+
+func (node *JSONExtractExpr) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v%s%v", node.Column, node.Operator, node.Path)
+}
+
+// MatchExpr represents MATCH (columns) AGAINST (expr [modifier]) of fulltext search,
+// it's relevance value, and also boolean expression in where.
+type MatchExpr struct {
+	Columns  Columns
+	Expr     ValExpr
+	Modifier string
+}
+
+// MatchExpr.Modifier
+const (
+	AST_MATCH_NATURAL_LANGUAGE           = " in natural language mode"
+	AST_MATCH_NATURAL_LANGUAGE_EXPANSION = " in natural language mode with query expansion"
+	AST_MATCH_BOOLEAN                    = " in boolean mode"
+	AST_MATCH_EXPANSION                  = " with query expansion"
+)
+
+func (node *MatchExpr) Format(buf *TrackedBuffer) {
+	buf.Fprintf("match%v against (%v%s)", node.Columns, node.Expr, node.Modifier)
+}
+
+// IntervalExpr represents INTERVAL expr unit of temporal arithmetic, unit is in lower case.
+type IntervalExpr struct {
+	Expr ValExpr
+	Unit string
+}
+
+func (node *IntervalExpr) Format(buf *TrackedBuffer) {
+	buf.Fprintf("interval %v %s", node.Expr, node.Unit)
+}
+
+// CastExpr represents CAST(expr AS type), CONVERT(expr, type) and CONVERT(expr USING charset),
+// type and charset are in lower case.
+type CastExpr struct {
+	Operator string
+	Expr     ValExpr
+	To       string
+}
+
+// CastExpr.Operator
+const (
+	AST_CAST          = "cast"
+	AST_CONVERT       = "convert"
+	AST_CONVERT_USING = "convert using"
+)
+
+func (node *CastExpr) Format(buf *TrackedBuffer) {
+	switch node.Operator {
+	case AST_CONVERT:
+		buf.Fprintf("convert(%v, %s)", node.Expr, node.To)
+	case AST_CONVERT_USING:
+		buf.Fprintf("convert(%v using %s)", node.Expr, node.To)
+	default:
+		buf.Fprintf("cast(%v as %s)", node.Expr, node.To)
+	}
 }
 
 // UnaryExpr represents a unary value expression.
@@ -639,11 +865,57 @@ func (node *UnaryExpr) Format(buf *TrackedBuffer) {
 	buf.Fprintf("%c%v", node.Operator, node.Expr)
 }
 
+// IntroducerExpr represents a string with charset introducer, such as _utf8mb4'abc', charset is in lower case.
+// National string such as N'abc' is introduced by AST_NATIONAL_CHARSET.
+type IntroducerExpr struct {
+	National bool
+	Charset  []byte
+	Expr     ValExpr
+}
+
+// IntroducerExpr.Charset of national string.
+const AST_NATIONAL_CHARSET = "utf8"
+
+func (node *IntroducerExpr) Format(buf *TrackedBuffer) {
+	if node.National {
+		buf.Fprintf("N%v", node.Expr)
+		return
+	}
+	if _, ok := node.Expr.(StrVal); ok {
+		buf.Fprintf("_%s%v", node.Charset, node.Expr)
+		return
+	}
+	buf.Fprintf("_%s %v", node.Charset, node.Expr)
+}
+
+// UnaryBinaryExpr represents BINARY expr, that casts expr to binary string.
+type UnaryBinaryExpr struct {
+	Expr ValExpr
+}
+
+func (node *UnaryBinaryExpr) Format(buf *TrackedBuffer) {
+	buf.Fprintf("binary %v", node.Expr)
+}
+
+// CollateExpr represents expr COLLATE collation, collation is in lower case.
+type CollateExpr struct {
+	Expr      ValExpr
+	Collation []byte
+}
+
+func (node *CollateExpr) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v collate %s", node.Expr, node.Collation)
+}
+
 // FuncExpr represents a function call.
 type FuncExpr struct {
 	Name     []byte
 	Distinct bool
 	Exprs    ValExprs
+
+	// ORDER BY and SEPARATOR of GROUP_CONCAT, Separator is nil if not specified.
+	OrderBy   OrderBy
+	Separator []byte
 }
 
 func (node *FuncExpr) Format(buf *TrackedBuffer) {
@@ -651,7 +923,32 @@ func (node *FuncExpr) Format(buf *TrackedBuffer) {
 	if node.Distinct {
 		distinct = "distinct "
 	}
-	buf.Fprintf("%s(%s%v)", node.Name, distinct, node.Exprs)
+	buf.Fprintf("%s(%s%v%v", node.Name, distinct, node.Exprs, node.OrderBy)
+	if node.Separator != nil {
+		buf.Fprintf(" separator %v", StrVal(node.Separator))
+	}
+	buf.Fprintf(")")
+}
+
+// WindowExpr represents a window function, that is function with OVER clause.
+type WindowExpr struct {
+	Func        *FuncExpr
+	PartitionBy ValExprs
+	OrderBy     OrderBy
+}
+
+func (node *WindowExpr) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v over (", node.Func)
+	prefix := "order by "
+	if len(node.PartitionBy) > 0 {
+		buf.Fprintf("partition by %v", node.PartitionBy)
+		prefix = " order by "
+	}
+	for _, order := range node.OrderBy {
+		buf.Fprintf("%s%v", prefix, order)
+		prefix = ", "
+	}
+	buf.Fprintf(")")
 }
 
 // CaseExpr represents a CASE expression.
@@ -61,6 +61,216 @@ func (node *KillConnection) GetConnectionID() uint32 {
 	return connID
 }
 
+// Reload reload statement, such as 'reload tls'.
+type Reload struct {
+	Name []byte
+}
+
+// Format Reload
+func (node *Reload) Format(buf *TrackedBuffer) {
+	buf.Fprintf("reload %s", node.Name)
+}
+
+func (node *Reload) IStatement()      {}
+func (node *Reload) IAdminStatement() {}
+
+// CloneTenant clone tenant statement, such as 'clone tenant 1 from prod to staging'.
+type CloneTenant struct {
+	Tenant ValExpr
+	From   []byte
+	To     []byte
+}
+
+// Format CloneTenant
+func (node *CloneTenant) Format(buf *TrackedBuffer) {
+	buf.Fprintf("clone tenant %v from %s to %s", node.Tenant, node.From, node.To)
+}
+
+func (node *CloneTenant) IStatement()      {}
+func (node *CloneTenant) IAdminStatement() {}
+
+// ShowPreflight show preflight statement, checks against backends.
+type ShowPreflight struct{}
+
+// Format ShowPreflight
+func (node *ShowPreflight) Format(buf *TrackedBuffer) {
+	buf.Fprintf("show preflight")
+}
+
+func (node *ShowPreflight) IStatement()      {}
+func (node *ShowPreflight) IAdminStatement() {}
+
+// ShowAudit show audit statement, recent admin actions.
+type ShowAudit struct{}
+
+// Format ShowAudit
+func (node *ShowAudit) Format(buf *TrackedBuffer) {
+	buf.Fprintf("show audit")
+}
+
+func (node *ShowAudit) IStatement()      {}
+func (node *ShowAudit) IAdminStatement() {}
+
+// ShowMigration show migration statement, shard keys moved between nodes by staged shard rules.
+type ShowMigration struct{}
+
+// Format ShowMigration
+func (node *ShowMigration) Format(buf *TrackedBuffer) {
+	buf.Fprintf("show migration")
+}
+
+func (node *ShowMigration) IStatement()      {}
+func (node *ShowMigration) IAdminStatement() {}
+
+// FailoverDrill failover drill statement, such as 'start failover drill host1' and 'stop failover drill'.
+type FailoverDrill struct {
+	Action string
+	Host   []byte
+}
+
+// FailoverDrill.Action
+const (
+	AST_DRILL_START = "start"
+	AST_DRILL_STOP  = "stop"
+)
+
+// Format FailoverDrill
+func (node *FailoverDrill) Format(buf *TrackedBuffer) {
+	if node.Action == AST_DRILL_START {
+		buf.Fprintf("start failover drill %s", node.Host)
+	} else {
+		buf.Fprintf("stop failover drill")
+	}
+}
+
+func (node *FailoverDrill) IStatement()      {}
+func (node *FailoverDrill) IAdminStatement() {}
+
+// ShowFailoverDrill show failover drill statement, report of current or last drill.
+type ShowFailoverDrill struct{}
+
+// Format ShowFailoverDrill
+func (node *ShowFailoverDrill) Format(buf *TrackedBuffer) {
+	buf.Fprintf("show failover drill")
+}
+
+func (node *ShowFailoverDrill) IStatement()      {}
+func (node *ShowFailoverDrill) IAdminStatement() {}
+
+// ExportRouting export routing statement, such as 'export routing json' and 'export routing markdown'.
+type ExportRouting struct {
+	Type string
+}
+
+// ExportRouting.Type
+const (
+	AST_EXPORT_JSON     = "json"
+	AST_EXPORT_MARKDOWN = "markdown"
+)
+
+// Format ExportRouting
+func (node *ExportRouting) Format(buf *TrackedBuffer) {
+	buf.Fprintf("export routing %s", node.Type)
+}
+
+func (node *ExportRouting) IStatement()      {}
+func (node *ExportRouting) IAdminStatement() {}
+
+// CreateProxyUser create proxy user statement, such as 'create proxy user 'u1' identified by 'pwd' schemas s1, s2 read only'.
+type CreateProxyUser struct {
+	IfNotExists bool
+	Name        []byte
+	Options     *ProxyUserOptions
+}
+
+// Format CreateProxyUser
+func (node *CreateProxyUser) Format(buf *TrackedBuffer) {
+	buf.Fprintf("create proxy user ")
+	if node.IfNotExists {
+		buf.Fprintf("if not exists ")
+	}
+	buf.Fprintf("%v%v", StrVal(node.Name), node.Options)
+}
+
+func (node *CreateProxyUser) IStatement()      {}
+func (node *CreateProxyUser) IAdminStatement() {}
+
+// AlterProxyUser alter proxy user statement, only specified options are changed.
+type AlterProxyUser struct {
+	Name    []byte
+	Options *ProxyUserOptions
+}
+
+// Format AlterProxyUser
+func (node *AlterProxyUser) Format(buf *TrackedBuffer) {
+	buf.Fprintf("alter proxy user %v%v", StrVal(node.Name), node.Options)
+}
+
+func (node *AlterProxyUser) IStatement()      {}
+func (node *AlterProxyUser) IAdminStatement() {}
+
+// DropProxyUser drop proxy user statement.
+type DropProxyUser struct {
+	IfExists bool
+	Name     []byte
+}
+
+// Format DropProxyUser
+func (node *DropProxyUser) Format(buf *TrackedBuffer) {
+	buf.Fprintf("drop proxy user ")
+	if node.IfExists {
+		buf.Fprintf("if exists ")
+	}
+	buf.Fprintf("%v", StrVal(node.Name))
+}
+
+func (node *DropProxyUser) IStatement()      {}
+func (node *DropProxyUser) IAdminStatement() {}
+
+// ShowProxyUsers show proxy users statement.
+type ShowProxyUsers struct{}
+
+// Format ShowProxyUsers
+func (node *ShowProxyUsers) Format(buf *TrackedBuffer) {
+	buf.Fprintf("show proxy users")
+}
+
+func (node *ShowProxyUsers) IStatement()      {}
+func (node *ShowProxyUsers) IAdminStatement() {}
+
+// ProxyUserOptions options of proxy user, that are specified in statement.
+type ProxyUserOptions struct {
+	Identified bool
+	Password   StrVal
+	Schemas    [][]byte // nil if not specified
+	ReadOnly   string   // AST_READ_ONLY, AST_READ_WRITE, or empty if not specified
+}
+
+// ProxyUserOptions.ReadOnly
+const (
+	AST_READ_ONLY  = "read only"
+	AST_READ_WRITE = "read write"
+)
+
+// Format ProxyUserOptions
+func (node *ProxyUserOptions) Format(buf *TrackedBuffer) {
+	if node.Identified {
+		buf.Fprintf(" identified by %v", node.Password)
+	}
+	if node.Schemas != nil {
+		buf.Fprintf(" schemas ")
+		for i, schema := range node.Schemas {
+			if i > 0 {
+				buf.Fprintf(", ")
+			}
+			escape(buf, schema)
+		}
+	}
+	if node.ReadOnly != "" {
+		buf.Fprintf(" %s", node.ReadOnly)
+	}
+}
+
 // KillQuery kill query statement
 type KillQuery struct {
 	ConnectionID NumVal
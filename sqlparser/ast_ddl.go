@@ -41,11 +41,15 @@ type CreateTable struct {
 	Table        *TableName
 	CreateDefs   CreateDefinitions
 	TableOptions OptionKeyValues
+	Partition    *PartitionOptions
 }
 
 // Format CreateTable
 func (node *CreateTable) Format(buf *TrackedBuffer) {
 	buf.Fprintf("create %v table if not exists %v\n(\n%v\n) %v", node.Comments, node.Table, node.CreateDefs, node.TableOptions)
+	if node.Partition != nil {
+		buf.Fprintf("\n%v", node.Partition)
+	}
 }
 
 func (node *CreateTable) IStatement()    {}
@@ -60,6 +64,7 @@ type CreateIndex struct {
 	IndexType     []byte
 	IndexColumns  IndexColNames
 	IndexOption   []byte
+	LockAlgorithm OptionKeyValues
 }
 
 // Format CreateIndex
@@ -77,6 +82,9 @@ func (node *CreateIndex) Format(buf *TrackedBuffer) {
 		indexOption = " " + string(node.IndexOption)
 	}
 	buf.Fprintf("create %v%s index %s%s on %v(%v)%s", node.Comments, strIndexCategory, node.Name, indexType, node.Table, node.IndexColumns, indexOption)
+	if len(node.LockAlgorithm) > 0 {
+		buf.Fprintf(" %v", node.LockAlgorithm)
+	}
 }
 
 func (node *CreateIndex) IStatement()    {}
@@ -88,16 +96,99 @@ type AlterTable struct {
 	Ignore     string
 	Table      *TableName
 	AlterSpecs AlterSpecifications
+	Partition  *PartitionOptions
 }
 
 // Format AlterTable
 func (node *AlterTable) Format(buf *TrackedBuffer) {
 	buf.Fprintf("alter %s table %v\n%v", node.Ignore, node.Table, node.AlterSpecs)
+	if node.Partition != nil {
+		if len(node.AlterSpecs) > 0 {
+			buf.Fprintf("\n")
+		}
+		buf.Fprintf("%v", node.Partition)
+	}
 }
 
 func (node *AlterTable) IStatement()    {}
 func (node *AlterTable) IDDLStatement() {}
 
+// PartitionOptions partition by clause of create table or alter table.
+type PartitionOptions struct {
+	Linear      bool
+	Type        string
+	Expr        ValExpr // expression of range, list or hash
+	Columns     Columns // columns of range columns, list columns or key
+	Partitions  []byte
+	Definitions []*PartitionDefinition
+}
+
+// PartitionOptions.Type
+const (
+	AST_PARTITION_RANGE = "range"
+	AST_PARTITION_LIST  = "list"
+	AST_PARTITION_HASH  = "hash"
+	AST_PARTITION_KEY   = "key"
+)
+
+// Format PartitionOptions
+func (node *PartitionOptions) Format(buf *TrackedBuffer) {
+	buf.Fprintf("partition by ")
+	if node.Linear {
+		buf.Fprintf("linear ")
+	}
+	switch {
+	case node.Expr != nil:
+		buf.Fprintf("%s(%v)", node.Type, node.Expr)
+	case node.Type == AST_PARTITION_KEY:
+		buf.Fprintf("%s(%v)", node.Type, SelectExprs(node.Columns))
+	default:
+		buf.Fprintf("%s columns%v", node.Type, node.Columns)
+	}
+	if node.Partitions != nil {
+		buf.Fprintf(" partitions %s", node.Partitions)
+	}
+	if len(node.Definitions) > 0 {
+		buf.Fprintf(" (")
+		for i, definition := range node.Definitions {
+			if i > 0 {
+				buf.Fprintf(",")
+			}
+			buf.Fprintf("\n\t%v", definition)
+		}
+		buf.Fprintf("\n)")
+	}
+}
+
+// PartitionDefinition partition of partition by clause, or add partition.
+type PartitionDefinition struct {
+	Name     []byte
+	Values   string
+	Tuple    ValExprs // values of values less than or values in
+	MaxValue bool     // values less than maxvalue
+	Options  OptionKeyValues
+}
+
+// PartitionDefinition.Values
+const (
+	AST_VALUES_LESS_THAN = " values less than"
+	AST_VALUES_IN        = " values in"
+)
+
+// Format PartitionDefinition
+func (node *PartitionDefinition) Format(buf *TrackedBuffer) {
+	buf.Fprintf("partition ")
+	escape(buf, node.Name)
+	if node.MaxValue {
+		buf.Fprintf("%s maxvalue", node.Values)
+	} else if node.Values != "" {
+		buf.Fprintf("%s (%v)", node.Values, node.Tuple)
+	}
+	if len(node.Options) > 0 {
+		buf.Fprintf(" %v", node.Options)
+	}
+}
+
 // RenameTable rename table
 type RenameTable struct {
 	Comments Comments
@@ -128,21 +219,89 @@ func (node *DropTable) Format(buf *TrackedBuffer) {
 func (node *DropTable) IStatement()    {}
 func (node *DropTable) IDDLStatement() {}
 
+// TruncateTable truncate table
+type TruncateTable struct {
+	Comments Comments
+	Name     *TableName
+}
+
+// Format TruncateTable
+func (node *TruncateTable) Format(buf *TrackedBuffer) {
+	buf.Fprintf("truncate %v table %v", node.Comments, node.Name)
+}
+
+func (node *TruncateTable) IStatement()    {}
+func (node *TruncateTable) IDDLStatement() {}
+
 // DropIndex drop index
 type DropIndex struct {
-	Comments Comments
-	Name     []byte
-	Table    *TableName
+	Comments      Comments
+	Name          []byte
+	Table         *TableName
+	LockAlgorithm OptionKeyValues
 }
 
 // Format DropIndex
 func (node *DropIndex) Format(buf *TrackedBuffer) {
 	buf.Fprintf("drop %v index %s on %v", node.Comments, node.Name, node.Table)
+	if len(node.LockAlgorithm) > 0 {
+		buf.Fprintf(" %v", node.LockAlgorithm)
+	}
 }
 
 func (node *DropIndex) IStatement()    {}
 func (node *DropIndex) IDDLStatement() {}
 
+// CreateRoutine create procedure, function, trigger or event statement.
+// Body is kept verbatim.
+type CreateRoutine struct {
+	Comments Comments
+	Definer  string
+	Kind     string
+	Name     *TableName
+	Body     string
+}
+
+// Routine kinds.
+const (
+	AST_PROCEDURE = "procedure"
+	AST_FUNCTION  = "function"
+	AST_TRIGGER   = "trigger"
+	AST_EVENT     = "event"
+)
+
+// Format CreateRoutine
+func (node *CreateRoutine) Format(buf *TrackedBuffer) {
+	definer := ""
+	if node.Definer != "" {
+		definer = node.Definer + " "
+	}
+	buf.Fprintf("create %v%s%s %v %s", node.Comments, definer, node.Kind, node.Name, node.Body)
+}
+
+func (node *CreateRoutine) IStatement()    {}
+func (node *CreateRoutine) IDDLStatement() {}
+
+// DropRoutine drop procedure, function or trigger
+type DropRoutine struct {
+	Comments Comments
+	Kind     string
+	IfExists bool
+	Name     *TableName
+}
+
+// Format DropRoutine
+func (node *DropRoutine) Format(buf *TrackedBuffer) {
+	buf.Fprintf("drop %v%s ", node.Comments, node.Kind)
+	if node.IfExists {
+		buf.Fprintf("if exists ")
+	}
+	buf.Fprintf("%v", node.Name)
+}
+
+func (node *DropRoutine) IStatement()    {}
+func (node *DropRoutine) IDDLStatement() {}
+
 // CreateDefinitions create definition list.
 type CreateDefinitions []CreateDefinition
 
@@ -271,6 +430,41 @@ func (node *CreateForeignKeyDefinition) Format(buf *TrackedBuffer) {
 
 func (node *CreateForeignKeyDefinition) ICreateDefinition() {}
 
+// CheckConstraint check constraint of table or column, such as 'constraint c check (price > 0) not enforced'.
+type CheckConstraint struct {
+	Symbol   []byte
+	Expr     BoolExpr
+	Enforced string
+}
+
+// CheckConstraint.Enforced
+const (
+	AST_ENFORCED     = " enforced"
+	AST_NOT_ENFORCED = " not enforced"
+)
+
+// Format CheckConstraint
+func (node *CheckConstraint) Format(buf *TrackedBuffer) {
+	if node.Symbol != nil {
+		buf.Fprintf("constraint ", nil)
+		escape(buf, node.Symbol)
+		buf.Fprintf(" ", nil)
+	}
+	buf.Fprintf("check (%v)%s", node.Expr, node.Enforced)
+}
+
+// CreateCheckDefinition create check constraint definition
+type CreateCheckDefinition struct {
+	Check *CheckConstraint
+}
+
+// Format CreateCheckDefinition
+func (node *CreateCheckDefinition) Format(buf *TrackedBuffer) {
+	buf.Fprintf("\t%v", node.Check)
+}
+
+func (node *CreateCheckDefinition) ICreateDefinition() {}
+
 // ColumnDefinition column definition
 type ColumnDefinition struct {
 	Type            *DataType
@@ -282,8 +476,18 @@ type ColumnDefinition struct {
 	ColumnFormat    []byte
 	ColumnStorage   []byte
 	ReferenceDef    []byte
+	Check           *CheckConstraint
+	// Generated expression of generated column, nil if column isn't generated.
+	Generated        ValExpr
+	GeneratedStorage string
 }
 
+// ColumnDefinition.GeneratedStorage
+const (
+	AST_VIRTUAL = " virtual"
+	AST_STORED  = " stored"
+)
+
 // Format ColumnDefinition
 func (node *ColumnDefinition) Format(buf *TrackedBuffer) {
 	strNullOrNotNull := ""
@@ -316,7 +520,19 @@ func (node *ColumnDefinition) Format(buf *TrackedBuffer) {
 	if node.ReferenceDef != nil {
 		strReferenceDef = " " + string(node.ReferenceDef)
 	}
-	buf.Fprintf("%v%s%s%s%s%s%s%s%s", node.Type, strNullOrNotNull, strDefaultValue, strAutoIncrement, node.UniqueOrKey, strComment, strColumnFormat, strColumnStorage, strReferenceDef)
+	strUniqueOrKey := ""
+	if node.UniqueOrKey != nil {
+		strUniqueOrKey = " " + string(node.UniqueOrKey)
+	}
+	if node.Generated != nil {
+		buf.Fprintf("%v generated always as (%v)%s%s%s%s%s", node.Type, node.Generated, node.GeneratedStorage,
+			strNullOrNotNull, strUniqueOrKey, strComment, strReferenceDef)
+	} else {
+		buf.Fprintf("%v%s%s%s%s%s%s%s%s", node.Type, strNullOrNotNull, strDefaultValue, strAutoIncrement, strUniqueOrKey, strComment, strColumnFormat, strColumnStorage, strReferenceDef)
+	}
+	if node.Check != nil {
+		buf.Fprintf(" %v", node.Check)
+	}
 }
 
 // DataType data type.
@@ -621,6 +837,54 @@ func (node *DropForeignKeySpec) Format(buf *TrackedBuffer) {
 
 func (node *DropForeignKeySpec) IAlterSpecification() {}
 
+// AddCheckSpec add check constraint specification
+type AddCheckSpec struct {
+	Check *CheckConstraint
+}
+
+// Format AddCheckSpec
+func (node *AddCheckSpec) Format(buf *TrackedBuffer) {
+	buf.Fprintf("add %v", node.Check)
+}
+
+func (node *AddCheckSpec) IAlterSpecification() {}
+
+// DropCheckSpec drop check constraint specification, or drop constraint of any type.
+type DropCheckSpec struct {
+	Type string
+	Name []byte
+}
+
+// DropCheckSpec.Type and AlterCheckSpec.Type
+const (
+	AST_CHECK_CONSTRAINT = "check"
+	AST_CONSTRAINT       = "constraint"
+)
+
+// Format DropCheckSpec
+func (node *DropCheckSpec) Format(buf *TrackedBuffer) {
+	buf.Fprintf("drop %s ", node.Type)
+	escape(buf, node.Name)
+}
+
+func (node *DropCheckSpec) IAlterSpecification() {}
+
+// AlterCheckSpec alter check constraint specification, such as 'alter check c not enforced'.
+type AlterCheckSpec struct {
+	Type     string
+	Name     []byte
+	Enforced string
+}
+
+// Format AlterCheckSpec
+func (node *AlterCheckSpec) Format(buf *TrackedBuffer) {
+	buf.Fprintf("alter %s ", node.Type)
+	escape(buf, node.Name)
+	buf.Fprintf("%s", node.Enforced)
+}
+
+func (node *AlterCheckSpec) IAlterSpecification() {}
+
 // DisableKeysSpec disable keys specification
 type DisableKeysSpec struct {
 }
@@ -642,3 +906,58 @@ func (node *EnableKeysSpec) Format(buf *TrackedBuffer) {
 }
 
 func (node *EnableKeysSpec) IAlterSpecification() {}
+
+// AddPartitionSpec add partition specification.
+type AddPartitionSpec struct {
+	Definitions []*PartitionDefinition
+}
+
+// Format AddPartitionSpec
+func (node *AddPartitionSpec) Format(buf *TrackedBuffer) {
+	buf.Fprintf("add partition (")
+	for i, definition := range node.Definitions {
+		if i > 0 {
+			buf.Fprintf(", ")
+		}
+		buf.Fprintf("%v", definition)
+	}
+	buf.Fprintf(")")
+}
+
+func (node *AddPartitionSpec) IAlterSpecification() {}
+
+// DropPartitionSpec drop or truncate partition specification, rows of partitions are removed.
+type DropPartitionSpec struct {
+	Action string
+	Names  [][]byte
+}
+
+// DropPartitionSpec.Action
+const (
+	AST_DROP_PARTITION     = "drop"
+	AST_TRUNCATE_PARTITION = "truncate"
+)
+
+// Format DropPartitionSpec
+func (node *DropPartitionSpec) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%s partition ", node.Action)
+	for i, name := range node.Names {
+		if i > 0 {
+			buf.Fprintf(", ")
+		}
+		escape(buf, name)
+	}
+}
+
+func (node *DropPartitionSpec) IAlterSpecification() {}
+
+// RemovePartitioningSpec remove partitioning specification.
+type RemovePartitioningSpec struct {
+}
+
+// Format RemovePartitioningSpec
+func (node *RemovePartitioningSpec) Format(buf *TrackedBuffer) {
+	buf.Fprintf("remove partitioning")
+}
+
+func (node *RemovePartitioningSpec) IAlterSpecification() {}
@@ -93,3 +93,136 @@ func (node *Replace) Format(buf *TrackedBuffer) {
 }
 
 func (node *Replace) IStatement() {}
+
+// Call represents a CALL statement of stored procedure.
+type Call struct {
+	Comments Comments
+	Name     *TableName
+	Args     ValExprs
+}
+
+func (node *Call) Format(buf *TrackedBuffer) {
+	buf.Fprintf("call %v%v(%v)", node.Comments, node.Name, node.Args)
+}
+
+func (node *Call) IStatement() {}
+
+// LoadData represents a LOAD DATA INFILE statement.
+type LoadData struct {
+	Comments   Comments
+	Priority   string
+	Local      bool
+	File       ValExpr
+	Duplicate  string
+	Table      *TableName
+	Charset    []byte
+	Fields     *LoadFields
+	Lines      *LoadLines
+	IgnoreRows ValExpr
+	Columns    Columns
+	Set        UpdateExprs
+}
+
+// LoadData.Priority and LoadData.Duplicate
+const (
+	AST_LOW_PRIORITY = "low_priority"
+	AST_CONCURRENT   = "concurrent"
+	AST_REPLACE      = "replace"
+)
+
+func (node *LoadData) Format(buf *TrackedBuffer) {
+	buf.Fprintf("load %vdata ", node.Comments)
+	if node.Priority != "" {
+		buf.Fprintf("%s ", node.Priority)
+	}
+	if node.Local {
+		buf.Fprintf("local ")
+	}
+	buf.Fprintf("infile %v", node.File)
+	if node.Duplicate != "" {
+		buf.Fprintf(" %s", node.Duplicate)
+	}
+	buf.Fprintf(" into table %v", node.Table)
+	if node.Charset != nil {
+		buf.Fprintf(" character set %s", node.Charset)
+	}
+	buf.Fprintf("%v%v", node.Fields, node.Lines)
+	if node.IgnoreRows != nil {
+		buf.Fprintf(" ignore %v lines", node.IgnoreRows)
+	}
+	if node.Columns != nil {
+		buf.Fprintf(" %v", node.Columns)
+	}
+	if node.Set != nil {
+		buf.Fprintf(" set %v", node.Set)
+	}
+}
+
+func (node *LoadData) IStatement() {}
+
+// setModifiers set priority and local of load data, false if they're unknown or duplicated.
+func (node *LoadData) setModifiers(modifiers [][]byte) bool {
+	for _, modifier := range modifiers {
+		switch m := string(modifier); m {
+		case AST_LOW_PRIORITY, AST_CONCURRENT:
+			if node.Priority != "" || node.Local {
+				return false
+			}
+			node.Priority = m
+		case "local":
+			if node.Local {
+				return false
+			}
+			node.Local = true
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// LoadFields represents FIELDS clause of LOAD DATA.
+type LoadFields struct {
+	Terminated ValExpr
+	Optionally bool
+	Enclosed   ValExpr
+	Escaped    ValExpr
+}
+
+func (node *LoadFields) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Fprintf(" fields")
+	if node.Terminated != nil {
+		buf.Fprintf(" terminated by %v", node.Terminated)
+	}
+	if node.Enclosed != nil {
+		if node.Optionally {
+			buf.Fprintf(" optionally")
+		}
+		buf.Fprintf(" enclosed by %v", node.Enclosed)
+	}
+	if node.Escaped != nil {
+		buf.Fprintf(" escaped by %v", node.Escaped)
+	}
+}
+
+// LoadLines represents LINES clause of LOAD DATA.
+type LoadLines struct {
+	Starting   ValExpr
+	Terminated ValExpr
+}
+
+func (node *LoadLines) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Fprintf(" lines")
+	if node.Starting != nil {
+		buf.Fprintf(" starting by %v", node.Starting)
+	}
+	if node.Terminated != nil {
+		buf.Fprintf(" terminated by %v", node.Terminated)
+	}
+}
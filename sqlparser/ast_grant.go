@@ -0,0 +1,283 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package sqlparser
+
+import "bytes"
+
+// Grant represents a GRANT statement.
+type Grant struct {
+	Comments    Comments
+	Privileges  Privileges
+	ObjectType  string
+	Level       *PrivilegeLevel
+	Accounts    Accounts
+	GrantOption bool
+}
+
+// Grant.ObjectType, besides AST_FUNCTION and AST_PROCEDURE.
+const (
+	AST_TABLE = "table"
+)
+
+func (node *Grant) Format(buf *TrackedBuffer) {
+	buf.Fprintf("grant %v%v on ", node.Comments, node.Privileges)
+	if node.ObjectType != "" {
+		buf.Fprintf("%s ", node.ObjectType)
+	}
+	buf.Fprintf("%v to %v", node.Level, node.Accounts)
+	if node.GrantOption {
+		buf.Fprintf(" with grant option")
+	}
+}
+
+func (node *Grant) IStatement() {}
+
+// Revoke represents a REVOKE statement.
+type Revoke struct {
+	Comments   Comments
+	Privileges Privileges
+	ObjectType string
+	Level      *PrivilegeLevel
+	Accounts   Accounts
+}
+
+func (node *Revoke) Format(buf *TrackedBuffer) {
+	buf.Fprintf("revoke %v%v on ", node.Comments, node.Privileges)
+	if node.ObjectType != "" {
+		buf.Fprintf("%s ", node.ObjectType)
+	}
+	buf.Fprintf("%v from %v", node.Level, node.Accounts)
+}
+
+func (node *Revoke) IStatement() {}
+
+// Privileges represents privilege list of GRANT and REVOKE.
+type Privileges []*Privilege
+
+func (node Privileges) Format(buf *TrackedBuffer) {
+	var prefix string
+	for _, n := range node {
+		buf.Fprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+}
+
+// Privilege represents a privilege, such as 'select (a, b)' or 'all privileges'.
+type Privilege struct {
+	Name    string
+	Columns Columns
+}
+
+func (node *Privilege) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%s", node.Name)
+	if node.Columns != nil {
+		buf.Fprintf(" %v", node.Columns)
+	}
+}
+
+// PrivilegeLevel represents target of privileges, such as '*', 'db.*' or 'db.table'.
+type PrivilegeLevel struct {
+	Qualifier []byte
+	Name      []byte
+}
+
+func (node *PrivilegeLevel) Format(buf *TrackedBuffer) {
+	if node.Qualifier != nil {
+		escape(buf, node.Qualifier)
+		buf.Fprintf(".")
+	}
+	escape(buf, node.Name)
+}
+
+// Accounts represents account list of GRANT and REVOKE.
+type Accounts []*Account
+
+func (node Accounts) Format(buf *TrackedBuffer) {
+	var prefix string
+	for _, n := range node {
+		buf.Fprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+}
+
+// Account represents a mysql account 'user'@'host', host is nil if omitted.
+type Account struct {
+	User []byte
+	Host []byte
+}
+
+// NewAccount split account of identifier user@host.
+func NewAccount(name []byte) *Account {
+	if i := bytes.IndexByte(name, '@'); i >= 0 {
+		return &Account{User: name[:i], Host: name[i+1:]}
+	}
+	return &Account{User: name}
+}
+
+func (node *Account) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v", StrVal(node.User))
+	if node.Host != nil {
+		buf.Fprintf("@%v", StrVal(node.Host))
+	}
+}
+
+// CreateUser represents a CREATE USER statement.
+type CreateUser struct {
+	Comments    Comments
+	IfNotExists bool
+	Users       UserSpecs
+	Require     RequireOptions
+}
+
+func (node *CreateUser) Format(buf *TrackedBuffer) {
+	buf.Fprintf("create %vuser ", node.Comments)
+	if node.IfNotExists {
+		buf.Fprintf("if not exists ")
+	}
+	buf.Fprintf("%v%v", node.Users, node.Require)
+}
+
+func (node *CreateUser) IStatement() {}
+
+// AlterUser represents an ALTER USER statement.
+type AlterUser struct {
+	Comments Comments
+	IfExists bool
+	Users    UserSpecs
+	Require  RequireOptions
+}
+
+func (node *AlterUser) Format(buf *TrackedBuffer) {
+	buf.Fprintf("alter %vuser ", node.Comments)
+	if node.IfExists {
+		buf.Fprintf("if exists ")
+	}
+	buf.Fprintf("%v%v", node.Users, node.Require)
+}
+
+func (node *AlterUser) IStatement() {}
+
+// DropUser represents a DROP USER statement.
+type DropUser struct {
+	Comments Comments
+	IfExists bool
+	Accounts Accounts
+}
+
+func (node *DropUser) Format(buf *TrackedBuffer) {
+	buf.Fprintf("drop %vuser ", node.Comments)
+	if node.IfExists {
+		buf.Fprintf("if exists ")
+	}
+	buf.Fprintf("%v", node.Accounts)
+}
+
+func (node *DropUser) IStatement() {}
+
+// UserSpecs represents account specifications of CREATE USER and ALTER USER.
+type UserSpecs []*UserSpec
+
+func (node UserSpecs) Format(buf *TrackedBuffer) {
+	var prefix string
+	for _, n := range node {
+		buf.Fprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+}
+
+// UserSpec represents an account with its authentication.
+type UserSpec struct {
+	Account *Account
+	Auth    *AuthOption
+}
+
+func (node *UserSpec) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v%v", node.Account, node.Auth)
+}
+
+// AuthOption represents 'identified [with plugin] by password' or 'identified with plugin as hash'.
+type AuthOption struct {
+	Plugin   []byte
+	Password ValExpr
+	Hashed   bool // password is hash of authentication string.
+}
+
+func (node *AuthOption) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Fprintf(" identified")
+	if node.Plugin != nil {
+		buf.Fprintf(" with %s", node.Plugin)
+	}
+	if node.Password == nil {
+		return
+	}
+	switch {
+	case node.Hashed && node.Plugin != nil:
+		buf.Fprintf(" as %v", node.Password)
+	case node.Hashed:
+		buf.Fprintf(" by password %v", node.Password)
+	default:
+		buf.Fprintf(" by %v", node.Password)
+	}
+}
+
+// RequireOptions represents REQUIRE clause of CREATE USER and ALTER USER.
+type RequireOptions []*RequireOption
+
+func (node RequireOptions) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Fprintf(" require")
+	for i, n := range node {
+		if i > 0 {
+			buf.Fprintf(" and")
+		}
+		buf.Fprintf(" %v", n)
+	}
+}
+
+// RequireOption represents none, ssl, x509, or cipher, issuer and subject with value.
+type RequireOption struct {
+	Name  string
+	Value ValExpr
+}
+
+func (node *RequireOption) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%s", node.Name)
+	if node.Value != nil {
+		buf.Fprintf(" %v", node.Value)
+	}
+}
+
+// IsRequireOption check name of require option, withValue is for cipher, issuer and subject.
+func IsRequireOption(name []byte, withValue bool) bool {
+	switch string(name) {
+	case "none", "ssl", "x509":
+		return !withValue
+	case "cipher", "issuer", "subject":
+		return withValue
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sqlparser
+
+// LockTables statement, such as 'lock tables t1 read, t2 as a write'.
+type LockTables struct {
+	Comments Comments
+	Tables   []*TableLock
+}
+
+// Format LockTables
+func (node *LockTables) Format(buf *TrackedBuffer) {
+	buf.Fprintf("lock %vtables ", node.Comments)
+	for i, table := range node.Tables {
+		if i > 0 {
+			buf.Fprintf(", ")
+		}
+		buf.Fprintf("%v", table)
+	}
+}
+
+func (node *LockTables) IStatement() {}
+
+// TableLock is a table with lock type of LOCK TABLES.
+type TableLock struct {
+	Table *TableName
+	As    []byte
+	Lock  string
+}
+
+// TableLock.Lock
+const (
+	AST_LOCK_READ       = "read"
+	AST_LOCK_READ_LOCAL = "read local"
+	AST_LOCK_WRITE      = "write"
+)
+
+// Format TableLock
+func (node *TableLock) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%v", node.Table)
+	if node.As != nil {
+		buf.Fprintf(" as ")
+		escape(buf, node.As)
+	}
+	buf.Fprintf(" %s", node.Lock)
+}
+
+// UnlockTables statement.
+type UnlockTables struct {
+	Comments Comments
+}
+
+// Format UnlockTables
+func (node *UnlockTables) Format(buf *TrackedBuffer) {
+	buf.Fprintf("unlock %vtables", node.Comments)
+}
+
+func (node *UnlockTables) IStatement() {}
@@ -0,0 +1,99 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sqlparser
+
+import "strings"
+
+// TableMaintenance represents ANALYZE, OPTIMIZE, CHECK or REPAIR TABLE statement.
+type TableMaintenance struct {
+	Comments Comments
+	Action   string
+	Modifier string
+	Tables   TableNames
+	Options  []string
+}
+
+// TableMaintenance.Action
+const (
+	AST_ANALYZE  = "analyze"
+	AST_OPTIMIZE = "optimize"
+	AST_CHECK    = "check"
+	AST_REPAIR   = "repair"
+)
+
+// Format TableMaintenance
+func (node *TableMaintenance) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%s %v", node.Action, node.Comments)
+	if node.Modifier != "" {
+		buf.Fprintf("%s ", node.Modifier)
+	}
+	buf.Fprintf("table %v", node.Tables)
+	for _, option := range node.Options {
+		buf.Fprintf(" %s", option)
+	}
+}
+
+func (node *TableMaintenance) IStatement() {}
+
+// IsReadOnly is true if statement doesn't change tables.
+func (node *TableMaintenance) IsReadOnly() bool {
+	return node.Action == AST_CHECK
+}
+
+// setOptions set modifier and options of action, false if they're unknown or duplicated.
+func (node *TableMaintenance) setOptions(modifier []byte, options [][]byte) bool {
+	if modifier != nil {
+		switch m := strings.ToLower(string(modifier)); m {
+		case "no_write_to_binlog", "local":
+			node.Modifier = m
+		default:
+			return false
+		}
+	}
+	for _, option := range options {
+		o := strings.ToLower(string(option))
+		switch node.Action {
+		case AST_CHECK:
+			switch o {
+			case "for upgrade", "quick", "fast", "medium", "extended", "changed":
+			default:
+				return false
+			}
+		case AST_REPAIR:
+			switch o {
+			case "quick", "extended", "use_frm":
+			default:
+				return false
+			}
+		default:
+			return false
+		}
+		for _, existed := range node.Options {
+			if existed == o {
+				return false
+			}
+		}
+		node.Options = append(node.Options, o)
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sqlparser
+
+import "fmt"
+
+// Prepare represents a PREPARE statement, From is StrVal of query or ColName of user variable.
+type Prepare struct {
+	Comments Comments
+	Name     []byte
+	From     ValExpr
+}
+
+func (node *Prepare) Format(buf *TrackedBuffer) {
+	buf.Fprintf("prepare %v%s from %v", node.Comments, node.Name, node.From)
+}
+
+func (node *Prepare) IStatement() {}
+
+// Execute represents an EXECUTE statement of prepared statement.
+type Execute struct {
+	Comments Comments
+	Name     []byte
+	Using    ValExprs
+}
+
+func (node *Execute) Format(buf *TrackedBuffer) {
+	buf.Fprintf("execute %v%s", node.Comments, node.Name)
+	if len(node.Using) > 0 {
+		buf.Fprintf(" using %v", node.Using)
+	}
+}
+
+func (node *Execute) IStatement() {}
+
+// Deallocate represents a DEALLOCATE PREPARE or DROP PREPARE statement.
+type Deallocate struct {
+	Comments Comments
+	Name     []byte
+}
+
+func (node *Deallocate) Format(buf *TrackedBuffer) {
+	buf.Fprintf("deallocate %vprepare %s", node.Comments, node.Name)
+}
+
+func (node *Deallocate) IStatement() {}
+
+// CountArgs count placeholders '?' of statement.
+func CountArgs(statement Statement) int {
+	count := 0
+	buf := NewTrackedBuffer(func(buf *TrackedBuffer, node SQLNode) {
+		if arg, ok := node.(ValArg); ok && string(arg) == "?" {
+			count++
+		}
+		node.Format(buf)
+	})
+	buf.Fprintf("%v", statement)
+	return count
+}
+
+// BindArgs replace placeholders '?' of statement by args in order, and parse it again.
+func BindArgs(statement Statement, args ValExprs, sqlMode SQLMode) (Statement, error) {
+	if count := CountArgs(statement); count != len(args) {
+		return nil, fmt.Errorf("statement has %d placeholders, but %d args", count, len(args))
+	}
+	i := 0
+	buf := NewTrackedBuffer(func(buf *TrackedBuffer, node SQLNode) {
+		if arg, ok := node.(ValArg); ok && string(arg) == "?" {
+			node = args[i]
+			i++
+		}
+		node.Format(buf)
+	})
+	buf.SQLMode = sqlMode
+	buf.Fprintf("%v", statement)
+	return ParseWithSQLMode(buf.String(), sqlMode)
+}
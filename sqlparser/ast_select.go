@@ -26,6 +26,8 @@
 
 package sqlparser
 
+import "strings"
+
 // SelectStatement any SELECT statement.
 type SelectStatement interface {
 	IStatement()
@@ -52,6 +54,7 @@ func (*SimpleSelect) IInsertRows()      {}
 
 // Select represents a SELECT statement.
 type Select struct {
+	With        *With
 	Comments    Comments
 	Distinct    string
 	SelectExprs SelectExprs
@@ -77,8 +80,8 @@ const (
 
 // Format Select.
 func (node *Select) Format(buf *TrackedBuffer) {
-	buf.Fprintf("select %v%s%v from %v%v%v%v%v%v%s",
-		node.Comments, node.Distinct, node.SelectExprs,
+	buf.Fprintf("%vselect %v%s%v from %v%v%v%v%v%v%s",
+		node.With, node.Comments, node.Distinct, node.SelectExprs,
 		node.From, node.Where,
 		node.GroupBy, node.Having, node.OrderBy,
 		node.Limit, node.Lock)
@@ -90,6 +93,7 @@ func (node *Select) IInsertRows()      {}
 
 // Union represents a UNION statement.
 type Union struct {
+	With        *With
 	Type        string
 	Left, Right SelectStatement
 }
@@ -104,9 +108,78 @@ const (
 )
 
 func (node *Union) Format(buf *TrackedBuffer) {
-	buf.Fprintf("%v %s %v", node.Left, node.Type, node.Right)
+	buf.Fprintf("%v%v %s %v", node.With, node.Left, node.Type, node.Right)
 }
 
 func (node *Union) IStatement()       {}
 func (node *Union) ISelectStatement() {}
 func (node *Union) IInsertRows()      {}
+
+// With represents a WITH clause of common table expressions.
+type With struct {
+	Recursive bool
+	CTEs      []*CommonTableExpr
+}
+
+func (node *With) Format(buf *TrackedBuffer) {
+	if node == nil {
+		return
+	}
+	buf.Fprintf("with ")
+	if node.Recursive {
+		buf.Fprintf("recursive ")
+	}
+	for i, cte := range node.CTEs {
+		if i > 0 {
+			buf.Fprintf(", ")
+		}
+		buf.Fprintf("%v", cte)
+	}
+	buf.Fprintf(" ")
+}
+
+// Names of common table expressions in lower case, which shadow tables in statement.
+func (node *With) Names() map[string]bool {
+	names := make(map[string]bool)
+	if node != nil {
+		for _, cte := range node.CTEs {
+			names[strings.ToLower(string(cte.Name))] = true
+		}
+	}
+	return names
+}
+
+// SetWith set WITH clause of select or union, return false if statement couldn't have WITH clause.
+func SetWith(statement SelectStatement, with *With) bool {
+	switch v := statement.(type) {
+	case *Select:
+		v.With = with
+	case *Union:
+		v.With = with
+	default:
+		return false
+	}
+	return true
+}
+
+// CommonTableExpr represents a common table expression of WITH clause.
+type CommonTableExpr struct {
+	Name    []byte
+	Columns [][]byte
+	Select  SelectStatement
+}
+
+func (node *CommonTableExpr) Format(buf *TrackedBuffer) {
+	escape(buf, node.Name)
+	if len(node.Columns) > 0 {
+		buf.Fprintf("(")
+		for i, column := range node.Columns {
+			if i > 0 {
+				buf.Fprintf(", ")
+			}
+			escape(buf, column)
+		}
+		buf.Fprintf(")")
+	}
+	buf.Fprintf(" as (%v)", node.Select)
+}
@@ -26,6 +26,11 @@
 
 package sqlparser
 
+import (
+	"bytes"
+	"strings"
+)
+
 // http://dev.mysql.com/doc/refman/5.6/en/set-statement.html
 
 // SetStatement set statement
@@ -39,7 +44,7 @@ type SetStatement interface {
 type SetVariable struct {
 	Comments Comments
 	Scope    string // SESSION or GLOBAL
-	Exprs    UpdateExprs
+	Exprs    SetExprs
 }
 
 func (node *SetVariable) Format(buf *TrackedBuffer) {
@@ -53,6 +58,110 @@ func (node *SetVariable) Format(buf *TrackedBuffer) {
 func (node *SetVariable) IStatement()    {}
 func (node *SetVariable) ISetStatement() {}
 
+// SetExprs represents assignments of SET statement.
+type SetExprs []*SetExpr
+
+func (node SetExprs) Format(buf *TrackedBuffer) {
+	var prefix string
+	for _, n := range node {
+		buf.Fprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+}
+
+// SetExpr represents an assignment to user variable @name, or system variable @@scope.name.
+type SetExpr struct {
+	User  bool   // user variable
+	Scope string // global, session, local or persist of @@scope.name, empty if it isn't specified.
+	Name  []byte // name without @ or @@
+	Expr  ValExpr
+}
+
+// SetExpr.Scope
+const (
+	AST_SCOPE_GLOBAL       = "global"
+	AST_SCOPE_SESSION      = "session"
+	AST_SCOPE_LOCAL        = "local"
+	AST_SCOPE_PERSIST      = "persist"
+	AST_SCOPE_PERSIST_ONLY = "persist_only"
+)
+
+// NewSetExpr create assignment to identifier name, it's user variable if prefixed by @, or system variable (@@name is of session scope).
+func NewSetExpr(name []byte, expr ValExpr) *SetExpr {
+	if bytes.HasPrefix(name, []byte("@@")) {
+		return &SetExpr{Scope: AST_SCOPE_SESSION, Name: name[2:], Expr: expr}
+	} else if bytes.HasPrefix(name, []byte("@")) {
+		return &SetExpr{User: true, Name: name[1:], Expr: expr}
+	}
+	return &SetExpr{Name: name, Expr: expr}
+}
+
+// NewScopedSetExpr create assignment to system variable @@scope.name, false if scope is unknown.
+func NewScopedSetExpr(scope, name []byte, expr ValExpr) (*SetExpr, bool) {
+	if !bytes.HasPrefix(scope, []byte("@@")) {
+		return nil, false
+	}
+	switch s := strings.ToLower(string(scope[2:])); s {
+	case AST_SCOPE_GLOBAL, AST_SCOPE_SESSION, AST_SCOPE_LOCAL, AST_SCOPE_PERSIST, AST_SCOPE_PERSIST_ONLY:
+		return &SetExpr{Scope: s, Name: name, Expr: expr}, true
+	}
+	return nil, false
+}
+
+func (node *SetExpr) Format(buf *TrackedBuffer) {
+	if node.User {
+		buf.Fprintf("@")
+	} else if node.Scope != "" {
+		buf.Fprintf("@@%s.", node.Scope)
+	}
+	escape(buf, node.Name)
+	buf.Fprintf(" = %v", node.Expr)
+}
+
+// SessionVariable is lower case reference of variable in session, @name of user variable, or @@session.name of system variable.
+func (node *SetExpr) SessionVariable() string {
+	buf := NewTrackedBuffer(nil)
+	if node.User {
+		buf.Fprintf("@")
+	} else {
+		buf.Fprintf("@@%s.", AST_SCOPE_SESSION)
+	}
+	escape(buf, bytes.ToLower(node.Name))
+	return buf.String()
+}
+
+// SetKeyword represents keyword value DEFAULT, ON of SET assignment.
+type SetKeyword []byte
+
+func (node SetKeyword) Format(buf *TrackedBuffer) {
+	buf.Fprintf("%s", []byte(node))
+}
+
+func (SetKeyword) IExpr()    {}
+func (SetKeyword) IValExpr() {}
+
+// VarName is lower case name of variable, @name of user variable, or name of system variable.
+func (node *SetExpr) VarName() string {
+	if node.User {
+		return "@" + strings.ToLower(string(node.Name))
+	}
+	return strings.ToLower(string(node.Name))
+}
+
+// IsGlobal is true if it's system variable of global or persist scope, that's specified in statement or assignment.
+func (node *SetExpr) IsGlobal(statementScope string) bool {
+	if node.User {
+		return false
+	}
+	switch node.Scope {
+	case AST_SCOPE_GLOBAL, AST_SCOPE_PERSIST, AST_SCOPE_PERSIST_ONLY:
+		return true
+	case AST_SCOPE_SESSION, AST_SCOPE_LOCAL:
+		return false
+	}
+	return strings.ToLower(statementScope) == AST_SCOPE_GLOBAL
+}
+
 // SetCharset set charset.
 type SetCharset struct {
 	Comments Comments
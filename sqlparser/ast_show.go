@@ -514,3 +514,63 @@ func (node *ShowProfiles) Format(buf *TrackedBuffer) {
 
 func (node *ShowProfiles) IStatement()     {}
 func (node *ShowProfiles) IShowStatement() {}
+
+// ShowGrants statement
+type ShowGrants struct {
+	Comments    Comments
+	For         *Account
+	CurrentUser bool // FOR CURRENT_USER or CURRENT_USER()
+}
+
+// Format ShowGrants
+func (node *ShowGrants) Format(buf *TrackedBuffer) {
+	buf.Fprintf("show %v grants", node.Comments)
+	if node.CurrentUser {
+		buf.Fprintf(" for current_user()")
+	} else if node.For != nil {
+		buf.Fprintf(" for %v", node.For)
+	}
+}
+
+func (node *ShowGrants) IStatement()     {}
+func (node *ShowGrants) IShowStatement() {}
+
+// ShowWarnings statement
+type ShowWarnings struct {
+	Comments Comments
+	Limit    *Limit
+}
+
+// Format ShowWarnings
+func (node *ShowWarnings) Format(buf *TrackedBuffer) {
+	buf.Fprintf("show %v warnings%v", node.Comments, node.Limit)
+}
+
+func (node *ShowWarnings) IStatement()     {}
+func (node *ShowWarnings) IShowStatement() {}
+
+// ShowErrors statement
+type ShowErrors struct {
+	Comments Comments
+	Limit    *Limit
+}
+
+// Format ShowErrors
+func (node *ShowErrors) Format(buf *TrackedBuffer) {
+	buf.Fprintf("show %v errors%v", node.Comments, node.Limit)
+}
+
+func (node *ShowErrors) IStatement()     {}
+func (node *ShowErrors) IShowStatement() {}
+
+// ShowLastRoute statement, routing of previous statement in session, it's answered by proxy.
+type ShowLastRoute struct {
+}
+
+// Format ShowLastRoute
+func (node *ShowLastRoute) Format(buf *TrackedBuffer) {
+	buf.Fprintf("show saashard last route")
+}
+
+func (node *ShowLastRoute) IStatement()     {}
+func (node *ShowLastRoute) IShowStatement() {}
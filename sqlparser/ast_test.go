@@ -1,6 +1,10 @@
 package sqlparser
 
-import "testing"
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
 
 func TestParseSelect(t *testing.T) {
 	var sql string
@@ -82,3 +86,39 @@ func TestParseSelect(t *testing.T) {
 	}
 
 }
+
+func TestParseBinaryString(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		val := make([]byte, r.Intn(64))
+		r.Read(val)
+
+		// escaped by client library, or only quote and backslash escaped.
+		escaped := []byte(String(StrVal(val)))
+		raw := append([]byte{'\''}, bytes.Replace(bytes.Replace(val, []byte("\\"), []byte("\\\\"), -1), []byte("'"), []byte("''"), -1)...)
+		raw = append(raw, '\'')
+		for _, literal := range [][]byte{escaped, raw} {
+			sql := "select * from t where a = " + string(literal)
+			stmt, err := Parse(sql)
+			if err != nil {
+				t.Fatalf("%v: %q", err, sql)
+			}
+			if got := stmt.(*Select).Where.Expr.(*ComparisonExpr).Right.(StrVal); !bytes.Equal(got, val) {
+				t.Fatalf("got %q, want %q", []byte(got), val)
+			}
+			if sql2 := String(stmt); sql2 != sql && sql2 != "select * from t where a = "+string(escaped) {
+				t.Fatalf("got %q, want %q", sql2, sql)
+			}
+		}
+
+		sqlMode := SQL_MODE_NO_BACKSLASH_ESCAPES
+		sql := StringWithSQLMode(StrVal(val), sqlMode)
+		stmt, err := ParseWithSQLMode("select "+sql, sqlMode)
+		if err != nil {
+			t.Fatalf("%v: %q", err, sql)
+		}
+		if got := stmt.(*SimpleSelect).SelectExprs[0].(*NonStarExpr).Expr.(StrVal); !bytes.Equal(got, val) {
+			t.Fatalf("got %q, want %q", []byte(got), val)
+		}
+	}
+}
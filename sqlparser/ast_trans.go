@@ -65,3 +65,42 @@ func (node *Rollback) Format(buf *TrackedBuffer) {
 
 func (node *Rollback) IStatement()            {}
 func (node *Rollback) ITransactionStatement() {}
+
+// Savepoint statement
+type Savepoint struct {
+	Name []byte
+}
+
+func (node *Savepoint) Format(buf *TrackedBuffer) {
+	buf.Fprintf("savepoint ")
+	escape(buf, node.Name)
+}
+
+func (node *Savepoint) IStatement()            {}
+func (node *Savepoint) ITransactionStatement() {}
+
+// RollbackSavepoint statement
+type RollbackSavepoint struct {
+	Name []byte
+}
+
+func (node *RollbackSavepoint) Format(buf *TrackedBuffer) {
+	buf.Fprintf("rollback to savepoint ")
+	escape(buf, node.Name)
+}
+
+func (node *RollbackSavepoint) IStatement()            {}
+func (node *RollbackSavepoint) ITransactionStatement() {}
+
+// ReleaseSavepoint statement
+type ReleaseSavepoint struct {
+	Name []byte
+}
+
+func (node *ReleaseSavepoint) Format(buf *TrackedBuffer) {
+	buf.Fprintf("release savepoint ")
+	escape(buf, node.Name)
+}
+
+func (node *ReleaseSavepoint) IStatement()            {}
+func (node *ReleaseSavepoint) ITransactionStatement() {}
@@ -0,0 +1,58 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sqlparser
+
+import "strings"
+
+// Fingerprint of the statement, literals are replaced with '?', comments are removed,
+// and it's in lower case. Statements differ only in literals have the same fingerprint.
+func Fingerprint(node SQLNode) string {
+	buf := NewTrackedBuffer(formatFingerprint)
+	buf.Fprintf("%v", node)
+	return strings.ToLower(strings.Join(strings.Fields(buf.String()), " "))
+}
+
+func formatFingerprint(buf *TrackedBuffer, node SQLNode) {
+	switch v := node.(type) {
+	case StrVal, NumVal, HexVal, BitVal, ValArg:
+		buf.WriteArg("?")
+	case Comments:
+	case ValTuple:
+		// in (1, 2, 3) is same as in (1)
+		for _, expr := range v {
+			switch expr.(type) {
+			case StrVal, NumVal, HexVal, BitVal, ValArg:
+			default:
+				node.Format(buf)
+				return
+			}
+		}
+		buf.WriteString("(?)")
+	default:
+		node.Format(buf)
+	}
+}
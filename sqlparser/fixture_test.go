@@ -0,0 +1,107 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sqlparser
+
+import (
+	"bufio"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden outputs of testdata/*.txt")
+
+var goldenEscaper = strings.NewReplacer("\n", `\n`, "\t", `\t`)
+
+// Fixture files of testdata are statements of one line, followed by golden output,
+// '=> ' is the statement formatted if it's different from input, '!! ' is the parse error,
+// newline and tab of output are escaped.
+// Run 'go test -run TestParseFixtures -update' to regenerate golden outputs after grammar changed.
+func TestParseFixtures(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		lines, err := readLines(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var golden []string
+		for i := 0; i < len(lines); i++ {
+			line := lines[i]
+			if len(strings.TrimSpace(line)) == 0 || strings.HasPrefix(line, "#") {
+				golden = append(golden, line)
+				continue
+			}
+			var want string
+			if i+1 < len(lines) && (strings.HasPrefix(lines[i+1], "=> ") || strings.HasPrefix(lines[i+1], "!! ")) {
+				i++
+				want = lines[i]
+			}
+			got := parseFixture(line)
+			golden = append(golden, line)
+			if len(got) > 0 {
+				golden = append(golden, got)
+			}
+			if got != want && !*update {
+				t.Errorf("%s:%d: %s\ngot:  %q\nwant: %q", file, i+1, line, got, want)
+			}
+		}
+		if *update {
+			if err = ioutil.WriteFile(file, []byte(strings.Join(golden, "\n")+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+// parseFixture return golden output of statement, empty if formatted as input.
+func parseFixture(sql string) string {
+	stmt, err := Parse(sql)
+	if err != nil {
+		return "!! " + err.Error()
+	}
+	if out := String(stmt); out != sql {
+		return "=> " + goldenEscaper.Replace(out)
+	}
+	return ""
+}
+
+func readLines(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
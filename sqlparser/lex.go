@@ -29,6 +29,7 @@ package sqlparser
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/berkaroad/saashard/sqlparser/sqltypes"
@@ -49,6 +50,9 @@ type Tokenizer struct {
 	LastError     string
 	posVarIndex   int
 	ParseTree     Statement
+	SQLMode       SQLMode
+	// versionComment is true inside executable comment /*!50003 ... */ of mysql, whose content is scanned as tokens.
+	versionComment bool
 }
 
 // NewStringTokenizer creates a new Tokenizer for the
@@ -100,12 +104,19 @@ var keywords = map[string]int{
 	"as":        AS,
 	"and":       AND,
 	"or":        OR,
+	"xor":       XOR,
+	"div":       DIV,
+	"mod":       MOD,
 	"not":       NOT,
 	"exists":    EXISTS,
 	"in":        IN,
 	"is":        IS,
 	"like":      LIKE,
+	"regexp":    REGEXP,
+	"rlike":     REGEXP,
 	"between":   BETWEEN,
+	"interval":  INTERVAL,
+	"convert":   CONVERT,
 	"null":      NULL,
 	"asc":       ASC,
 	"desc":      DESC,
@@ -115,11 +126,14 @@ var keywords = map[string]int{
 	"default":   DEFAULT,
 	"set":       SET,
 	"lock":      LOCK,
+	"unlock":    UNLOCK,
+	"algorithm": ALGORITHM,
 
 	"create":   CREATE,
 	"alter":    ALTER,
 	"rename":   RENAME,
 	"drop":     DROP,
+	"truncate": TRUNCATE,
 	"table":    TABLE,
 	"index":    INDEX,
 	"view":     VIEW,
@@ -131,6 +145,11 @@ var keywords = map[string]int{
 	"btree":    BTREE,
 	"hash":     HASH,
 
+	"analyze":  ANALYZE,
+	"optimize": OPTIMIZE,
+	"check":    CHECK,
+	"repair":   REPAIR,
+
 	// Data Type
 	"bit":        BIT,
 	"tinyint":    TINYINT,
@@ -205,13 +224,33 @@ var keywords = map[string]int{
 	"enable":  ENABLE,
 	"disable": DISABLE,
 
-	"using":    USING,
-	"begin":    BEGIN,
-	"rollback": ROLLBACK,
-	"commit":   COMMIT,
+	"using":     USING,
+	"begin":     BEGIN,
+	"rollback":  ROLLBACK,
+	"commit":    COMMIT,
+	"savepoint": SAVEPOINT,
+	"release":   RELEASE,
 
 	"names":        NAMES,
 	"replace":      REPLACE,
+	"call":         CALL,
+	"prepare":      PREPARE,
+	"execute":      EXECUTE,
+	"deallocate":   DEALLOCATE,
+	"grant":        GRANT,
+	"revoke":       REVOKE,
+	"option":       OPTION,
+	"identified":   IDENTIFIED,
+	"require":      REQUIRE,
+	"load":         LOAD,
+	"infile":       INFILE,
+	"low_priority": LOW_PRIORITY,
+	"lines":        LINES,
+	"starting":     STARTING,
+	"terminated":   TERMINATED,
+	"optionally":   OPTIONALLY,
+	"enclosed":     ENCLOSED,
+	"escaped":      ESCAPED,
 	"start":        START,
 	"transaction":  TRANSACTION,
 	"isolation":    ISOLATION,
@@ -222,6 +261,11 @@ var keywords = map[string]int{
 	"uncommitted":  UNCOMMITTED,
 	"serializable": SERIALIZABLE,
 	"collate":      COLLATE,
+	"separator":    SEPARATOR,
+	"with":         WITH,
+	"recursive":    RECURSIVE,
+	"over":         OVER,
+	"partition":    PARTITION,
 	"offset":       OFFSET,
 	"charset":      CHARSET,
 	"character":    CHARACTER,
@@ -253,6 +297,9 @@ var keywords = map[string]int{
 	"global":  GLOBAL,
 
 	"profiles": PROFILES,
+	"grants":   GRANTS,
+	"warnings": WARNINGS,
+	"errors":   ERRORS,
 
 	// function
 	"position": POSITION,
@@ -260,6 +307,9 @@ var keywords = map[string]int{
 	"kill":       KILL,
 	"query":      QUERY,
 	"connection": CONNECTION,
+	"reload":     RELOAD,
+	"clone":      CLONE,
+	"proxy":      PROXY,
 
 	// charset
 	"armscii8": ARMSCII8,
@@ -390,6 +440,19 @@ var keywords = map[string]int{
 	"utf8mb4_general_ci":  UTF8MB4_GENERAL_CI,
 	"utf8mb4_unicode_ci":  UTF8MB4_UNICODE_CI,
 	"utf8mb4_bin":         UTF8MB4_BIN,
+	"utf8mb4_0900_ai_ci":  UTF8MB4_0900_AI_CI,
+	"utf8mb4_0900_bin":    UTF8MB4_0900_BIN,
+}
+
+// introducers are charsets of string literal, such as _utf8mb4'abc'.
+var introducers = map[string]bool{
+	"armscii8": true, "ascii": true, "big5": true, "binary": true, "cp1250": true, "cp1251": true,
+	"cp1256": true, "cp1257": true, "cp850": true, "cp852": true, "cp866": true, "cp932": true,
+	"dec8": true, "eucjpms": true, "euckr": true, "gb18030": true, "gb2312": true, "gbk": true,
+	"geostd8": true, "greek": true, "hebrew": true, "hp8": true, "keybcs2": true, "koi8r": true,
+	"koi8u": true, "latin1": true, "latin2": true, "latin5": true, "latin7": true, "macce": true,
+	"macroman": true, "sjis": true, "swe7": true, "tis620": true, "ucs2": true, "ujis": true,
+	"utf16": true, "utf16le": true, "utf32": true, "utf8": true, "utf8mb3": true, "utf8mb4": true,
 }
 
 // Lex returns the next token form the Tokenizer.
@@ -403,7 +466,7 @@ func (tkn *Tokenizer) Lex(lval *yySymType) int {
 		typ, val = tkn.Scan()
 	}
 	switch typ {
-	case ID, STRING, NUMBER, VALUE_ARG, COMMENTS:
+	case ID, STRING, NUMBER, VALUE_ARG, COMMENTS, INTRODUCER, HEX_LITERAL, BIT_LITERAL, NCHAR_STRING:
 		lval.bytes = val
 	}
 	tkn.errorToken = val
@@ -428,7 +491,7 @@ func (tkn *Tokenizer) Scan() (int, []byte) {
 		return 0, nil
 	}
 
-	if tkn.lastChar == 0 {
+	if tkn.Position == 0 {
 		tkn.next()
 	}
 	tkn.skipBlank()
@@ -444,7 +507,23 @@ func (tkn *Tokenizer) Scan() (int, []byte) {
 		switch ch {
 		case EOFCHAR:
 			return 0, nil
-		case '=', ',', ';', '(', ')', '+', '*', '%', '&', '|', '^', '~':
+		case '*':
+			if tkn.versionComment && tkn.lastChar == '/' {
+				tkn.next()
+				tkn.versionComment = false
+				return tkn.Scan()
+			}
+			return int(ch), nil
+		case '=', ',', ';', '(', ')', '+', '%', '&', '^', '~':
+			return int(ch), nil
+		case '|':
+			if tkn.lastChar == '|' {
+				tkn.next()
+				if tkn.SQLMode&SQL_MODE_PIPES_AS_CONCAT > 0 {
+					return PIPE_CONCAT, nil
+				}
+				return OR, nil
+			}
 			return int(ch), nil
 		case '?':
 			tkn.posVarIndex++
@@ -464,16 +543,34 @@ func (tkn *Tokenizer) Scan() (int, []byte) {
 				return tkn.scanCommentType1("//")
 			case '*':
 				tkn.next()
+				if tkn.lastChar == '!' && isDigit(tkn.peek()) && !tkn.AllowComments && !tkn.versionComment {
+					// skip version, content is executed by mysql of the version or later.
+					tkn.next()
+					for isDigit(tkn.lastChar) {
+						tkn.next()
+					}
+					tkn.versionComment = true
+					return tkn.Scan()
+				}
 				return tkn.scanCommentType2()
 			default:
 				return int(ch), nil
 			}
 		case '-':
-			if tkn.lastChar == '-' {
+			switch tkn.lastChar {
+			case '-':
 				tkn.next()
 				return tkn.scanCommentType1("--")
+			case '>':
+				tkn.next()
+				if tkn.lastChar == '>' {
+					tkn.next()
+					return JSON_UNQUOTE_EXTRACT_OP, nil
+				}
+				return JSON_EXTRACT_OP, nil
+			default:
+				return int(ch), nil
 			}
-			return int(ch), nil
 		case '<':
 			switch tkn.lastChar {
 			case '>':
@@ -503,7 +600,12 @@ func (tkn *Tokenizer) Scan() (int, []byte) {
 				return NE, nil
 			}
 			return LEX_ERROR, []byte("!")
-		case '\'', '"':
+		case '"':
+			if tkn.SQLMode&SQL_MODE_ANSI_QUOTES > 0 {
+				return tkn.scanString(ch, ID)
+			}
+			return tkn.scanString(ch, STRING)
+		case '\'':
 			return tkn.scanString(ch, STRING)
 		case '`':
 			return tkn.scanString(ch, ID)
@@ -538,16 +640,80 @@ func (tkn *Tokenizer) scanIdentifier() (int, []byte) {
 		buffer.WriteByte(byte(tkn.lastChar))
 	}
 	lowered := bytes.ToLower(buffer.Bytes())
+	if tkn.lastChar == '\'' && (string(lowered) == "x" || string(lowered) == "b") {
+		return tkn.scanHexOrBit(buffer.Bytes()[0])
+	}
+	if tkn.lastChar == '\'' && string(lowered) == "n" {
+		// national string, such as N'abc'.
+		tkn.next()
+		return tkn.scanString('\'', NCHAR_STRING)
+	}
 	if keywordID, found := keywords[string(lowered)]; found {
+		if keywordID == INTERVAL && tkn.isIdentifierEnd() {
+			// column named interval, it isn't followed by interval expression.
+			return ID, buffer.Bytes()
+		}
 		return keywordID, lowered
 	}
+	if len(lowered) > 1 && lowered[0] == '_' && introducers[string(lowered[1:])] {
+		// charset introducer of string, such as _utf8mb4'abc'.
+		tkn.skipBlank()
+		if tkn.lastChar == '\'' || (tkn.lastChar == '"' && tkn.SQLMode&SQL_MODE_ANSI_QUOTES == 0) ||
+			(strings.ContainsRune("xXbB", rune(tkn.lastChar)) && tkn.peek() == '\'') {
+			return INTRODUCER, lowered[1:]
+		}
+	}
 	return ID, buffer.Bytes()
 }
 
+// identifierEndTokens could follow an identifier, but couldn't start an expression.
+var identifierEndTokens = map[int]bool{
+	0: true, ';': true, ',': true, ')': true, '.': true, '=': true, '<': true, '>': true, LE: true, GE: true, NE: true,
+	NULL_SAFE_EQUAL: true, '*': true, '/': true, '%': true, '&': true, '|': true, '^': true,
+	FROM: true, AS: true, WHERE: true, GROUP: true, ORDER: true, LIMIT: true, HAVING: true, UNION: true, ON: true,
+	AND: true, OR: true, XOR: true, IS: true, IN: true, LIKE: true, BETWEEN: true, REGEXP: true, DIV: true, COLLATE: true,
+	WHEN: true, THEN: true, ELSE: true, END: true, ASC: true, DESC: true, FOR: true, INTO: true,
+}
+
+// isIdentifierEnd check whether the next token ends the identifier, the position isn't changed.
+func (tkn *Tokenizer) isIdentifierEnd() bool {
+	saved := *tkn
+	offset, _ := tkn.InStream.Seek(0, io.SeekCurrent)
+	typ, _ := tkn.Scan()
+	*tkn = saved
+	tkn.InStream.Seek(offset, io.SeekStart)
+	return identifierEndTokens[typ]
+}
+
+// scanHexOrBit scan x'ab' or b'0101' after prefix, original form is kept, such as X'AB'.
+func (tkn *Tokenizer) scanHexOrBit(prefix byte) (int, []byte) {
+	typ, base := HEX_LITERAL, 16
+	if prefix == 'b' || prefix == 'B' {
+		typ, base = BIT_LITERAL, 2
+	}
+	buffer := bytes.NewBuffer(make([]byte, 0, 8))
+	buffer.WriteByte(prefix)
+	tkn.Next(buffer)
+	tkn.scanMantissa(base, buffer)
+	if tkn.lastChar != '\'' {
+		return LEX_ERROR, buffer.Bytes()
+	}
+	tkn.Next(buffer)
+	if typ == HEX_LITERAL && buffer.Len()%2 != 1 {
+		// hexadecimal digits should be even, such as x'0a'.
+		return LEX_ERROR, buffer.Bytes()
+	}
+	return typ, buffer.Bytes()
+}
+
 func (tkn *Tokenizer) scanBindVar() (int, []byte) {
 	buffer := bytes.NewBuffer(make([]byte, 0, 8))
 	buffer.WriteByte(byte(tkn.lastChar))
-	for tkn.next(); isLetter(tkn.lastChar) || isDigit(tkn.lastChar) || tkn.lastChar == '.'; tkn.next() {
+	if tkn.next(); tkn.lastChar == '=' {
+		tkn.next()
+		return ASSIGN, nil
+	}
+	for ; isLetter(tkn.lastChar) || isDigit(tkn.lastChar) || tkn.lastChar == '.'; tkn.next() {
 		buffer.WriteByte(byte(tkn.lastChar))
 	}
 	if buffer.Len() == 1 {
@@ -630,11 +796,15 @@ func (tkn *Tokenizer) scanString(delim uint16, typ int) (int, []byte) {
 			} else {
 				break
 			}
-		} else if ch == '\\' {
+		} else if ch == '\\' && (typ == STRING || typ == NCHAR_STRING) && tkn.SQLMode&SQL_MODE_NO_BACKSLASH_ESCAPES == 0 {
 			if tkn.lastChar == EOFCHAR {
 				return LEX_ERROR, buffer.Bytes()
 			}
-			if decodedChar := sqltypes.SQLDecodeMap[byte(tkn.lastChar)]; decodedChar == sqltypes.DONTESCAPE {
+			if tkn.lastChar == '%' || tkn.lastChar == '_' {
+				// '\%' and '\_' are kept, they're used in LIKE pattern.
+				buffer.WriteByte('\\')
+				ch = tkn.lastChar
+			} else if decodedChar := sqltypes.SQLDecodeMap[byte(tkn.lastChar)]; decodedChar == sqltypes.DONTESCAPE {
 				ch = tkn.lastChar
 			} else {
 				ch = uint16(decodedChar)
@@ -682,6 +852,16 @@ func (tkn *Tokenizer) scanCommentType2() (int, []byte) {
 	return COMMENTS, buffer.Bytes()
 }
 
+// peek char after lastChar, without consuming it.
+func (tkn *Tokenizer) peek() uint16 {
+	ch, err := tkn.InStream.ReadByte()
+	if err != nil {
+		return EOFCHAR
+	}
+	tkn.InStream.UnreadByte()
+	return uint16(ch)
+}
+
 func (tkn *Tokenizer) next() {
 	if ch, err := tkn.InStream.ReadByte(); err != nil {
 		// Only EOF is possible.
@@ -0,0 +1,259 @@
+// Copyright 2012, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sqlparser
+
+import (
+	"strings"
+)
+
+var defaultDelimiter = ";"
+
+// SplitSQLScript is to split sql script to single-sql.
+// It understands DELIMITER directives, quoted strings and comments,
+// and keeps the body of stored routine as one statement.
+func SplitSQLScript(script string) []string {
+	return SplitSQLScriptWithSQLMode(script, 0)
+}
+
+// SplitSQLScriptWithSQLMode is to split sql script to single-sql with session's sql_mode.
+func SplitSQLScriptWithSQLMode(script string, sqlMode SQLMode) []string {
+	var sqls []string
+	var delimiter = defaultDelimiter
+	var start, depth int
+	var routineChecked, isRoutine bool
+
+	appendSQL := func(end int) {
+		if sql := strings.TrimSpace(script[start:end]); sql != "" {
+			sqls = append(sqls, sql)
+		}
+		depth = 0
+		routineChecked, isRoutine = false, false
+	}
+
+	for i := 0; i < len(script); {
+		ch := script[i]
+		if !isBlank(uint16(ch)) && strings.TrimSpace(script[start:i]) == "" {
+			if newDelimiter, next, ok := readDelimiterDirective(script, i); ok {
+				if newDelimiter != "" {
+					delimiter = newDelimiter
+				}
+				i = next
+				start = i
+				continue
+			}
+		}
+
+		switch {
+		case ch == '\'' || ch == '"' || ch == '`':
+			i = skipQuoted(script, i, sqlMode&SQL_MODE_NO_BACKSLASH_ESCAPES == 0)
+			continue
+		case ch == '#' || strings.HasPrefix(script[i:], "-- ") || strings.HasPrefix(script[i:], "--\t") ||
+			strings.HasPrefix(script[i:], "--\n") || script[i:] == "--":
+			if end := strings.IndexByte(script[i:], '\n'); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(script)
+			}
+			continue
+		case strings.HasPrefix(script[i:], "/*"):
+			if end := strings.Index(script[i+2:], "*/"); end >= 0 {
+				i += end + 4
+			} else {
+				i = len(script)
+			}
+			continue
+		case strings.HasPrefix(script[i:], delimiter):
+			if delimiter != defaultDelimiter || depth == 0 {
+				appendSQL(i)
+				i += len(delimiter)
+				start = i
+				continue
+			}
+		case isLetter(uint16(ch)):
+			wordEnd := i + 1
+			for wordEnd < len(script) && (isLetter(uint16(script[wordEnd])) || isDigit(uint16(script[wordEnd]))) {
+				wordEnd++
+			}
+			if delimiter == defaultDelimiter {
+				switch word := strings.ToLower(script[i:wordEnd]); word {
+				case "begin", "case", "end":
+					if !routineChecked {
+						isRoutine = parseCreateRoutine(script[start:i]) != nil
+						routineChecked = true
+					}
+					if !isRoutine {
+						break
+					}
+					if word != "end" {
+						depth++
+					} else if depth > 0 && !isEndOfBlock(script[wordEnd:]) {
+						depth--
+						// 'END CASE' closes CASE, the case word isn't opening another one.
+						wordEnd += endCaseLength(script[wordEnd:])
+					}
+				}
+			}
+			i = wordEnd
+			continue
+		}
+		i++
+	}
+	appendSQL(len(script))
+	return sqls
+}
+
+// readDelimiterDirective read 'DELIMITER xx' at pos, returns the new delimiter and the end of line.
+func readDelimiterDirective(script string, pos int) (delimiter string, next int, ok bool) {
+	const directive = "delimiter"
+	rest := script[pos:]
+	if len(rest) <= len(directive) || !strings.EqualFold(rest[:len(directive)], directive) ||
+		(rest[len(directive)] != ' ' && rest[len(directive)] != '\t') {
+		return "", pos, false
+	}
+	line := rest[len(directive):]
+	next = len(script)
+	if end := strings.IndexByte(line, '\n'); end >= 0 {
+		line = line[:end]
+		next = pos + len(directive) + end + 1
+	}
+	if fields := strings.Fields(line); len(fields) > 0 {
+		delimiter = fields[0]
+	}
+	return delimiter, next, true
+}
+
+// skipQuoted skip quoted string or identifier at pos, returns the position after it.
+func skipQuoted(script string, pos int, backslashEscapes bool) int {
+	quote := script[pos]
+	for i := pos + 1; i < len(script); i++ {
+		switch script[i] {
+		case '\\':
+			if backslashEscapes && quote != '`' {
+				i++
+			}
+		case quote:
+			return i + 1
+		}
+	}
+	return len(script)
+}
+
+// isEndOfBlock check 'END IF', 'END LOOP', 'END WHILE' or 'END REPEAT',
+// which closes a block that isn't opened by BEGIN or CASE.
+func isEndOfBlock(rest string) bool {
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return false
+	}
+	word := strings.ToLower(strings.TrimRight(fields[0], ";"))
+	return word == "if" || word == "loop" || word == "while" || word == "repeat"
+}
+
+// endCaseLength get length of blanks and 'CASE' after 'END', 0 if it isn't 'END CASE'.
+func endCaseLength(rest string) int {
+	i := 0
+	for i < len(rest) && isBlank(uint16(rest[i])) {
+		i++
+	}
+	if len(rest) < i+4 || !strings.EqualFold(rest[i:i+4], "case") ||
+		(len(rest) > i+4 && (isLetter(uint16(rest[i+4])) || isDigit(uint16(rest[i+4])))) {
+		return 0
+	}
+	return i + 4
+}
+
+// parseCreateRoutine parse CREATE PROCEDURE/FUNCTION/TRIGGER/EVENT,
+// the routine body is kept verbatim, because the parser couldn't understand it.
+func parseCreateRoutine(sql string) *CreateRoutine {
+	tkn := NewStringTokenizer(sql)
+	offset := func() int {
+		if tkn.Position == 0 {
+			return 0
+		} else if tkn.Position > len(sql) {
+			return len(sql)
+		}
+		return tkn.Position - 1
+	}
+
+	if typ, _ := tkn.Scan(); typ != CREATE {
+		return nil
+	}
+	routine := new(CreateRoutine)
+	definerStart := offset()
+	typ, val := tkn.Scan()
+	for typ == COMMENTS {
+		routine.Comments = append(routine.Comments, val)
+		definerStart = offset()
+		typ, val = tkn.Scan()
+	}
+
+	kindStart := definerStart
+	if typ == ID && strings.ToLower(string(val)) == "definer" {
+		for i := 0; i < 10 && routine.Kind == ""; i++ {
+			kindStart = offset()
+			if typ, val = tkn.Scan(); typ == 0 {
+				return nil
+			}
+			routine.Kind = routineKind(typ, val)
+		}
+		routine.Definer = strings.TrimSpace(sql[definerStart:kindStart])
+	} else {
+		routine.Kind = routineKind(typ, val)
+	}
+	if routine.Kind == "" {
+		return nil
+	}
+
+	if typ, val = tkn.Scan(); typ != ID {
+		return nil
+	}
+	routine.Name = &TableName{Name: val}
+	bodyStart := offset()
+	if typ, _ = tkn.Scan(); typ == '.' {
+		if typ, val = tkn.Scan(); typ != ID {
+			return nil
+		}
+		routine.Name.Qualifier, routine.Name.Name = routine.Name.Name, val
+		bodyStart = offset()
+	}
+	routine.Body = strings.TrimSpace(sql[bodyStart:])
+	return routine
+}
+
+func routineKind(typ int, val []byte) string {
+	switch {
+	case typ == PROCEDURE:
+		return AST_PROCEDURE
+	case typ == FUNCTION:
+		return AST_FUNCTION
+	case typ == TRIGGER:
+		return AST_TRIGGER
+	case typ == ID && strings.ToLower(string(val)) == AST_EVENT:
+		return AST_EVENT
+	}
+	return ""
+}
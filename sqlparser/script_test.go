@@ -0,0 +1,52 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sqlparser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitSQLScript(t *testing.T) {
+	cases := []struct {
+		script string
+		want   []string
+	}{
+		{"select 1; select 2;", []string{"select 1", "select 2"}},
+		{"select ';'; -- a; b\nselect 2 /* ; */", []string{"select ';'", "-- a; b\nselect 2 /* ; */"}},
+		{"create procedure p() begin select 1; select 2; end; select 3",
+			[]string{"create procedure p() begin select 1; select 2; end", "select 3"}},
+		{"create procedure p(x int) begin case x when 1 then select 1; else select 2; end case; end; select 3; select 4",
+			[]string{"create procedure p(x int) begin case x when 1 then select 1; else select 2; end case; end", "select 3", "select 4"}},
+		{"create procedure p(x int) begin select case x when 1 then 'a' end; if x then select 1; end if; end; select 2",
+			[]string{"create procedure p(x int) begin select case x when 1 then 'a' end; if x then select 1; end if; end", "select 2"}},
+		{"select case when 1 then 2 end; select 3", []string{"select case when 1 then 2 end", "select 3"}},
+		{"delimiter //\ncreate procedure p() begin select 1; end//\ndelimiter ;\nselect 2",
+			[]string{"create procedure p() begin select 1; end", "select 2"}},
+	}
+	for _, c := range cases {
+		if got := SplitSQLScript(c.script); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("SplitSQLScript(%q) = %q, want %q", c.script, got, c.want)
+		}
+	}
+}
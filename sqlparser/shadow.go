@@ -0,0 +1,93 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sqlparser
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ShadowParser parses the sql with another grammar, such as grammar of next version,
+// and returns formatted statement, which is compared with formatted statement of this grammar.
+type ShadowParser func(sql string, sqlMode SQLMode) (string, error)
+
+// BaselineShadowParser is name of shadow parser, that parses the sql by this grammar,
+// then parses its formatted statement for backend again. It diverges when formatted statement isn't parsed back the same.
+const BaselineShadowParser = "baseline"
+
+var shadowParsers = map[string]ShadowParser{BaselineShadowParser: baselineParser}
+var shadowParsersLock sync.RWMutex
+
+func baselineParser(sql string, sqlMode SQLMode) (string, error) {
+	statement, err := ParseWithSQLMode(sql, sqlMode)
+	if err != nil {
+		return "", err
+	}
+	if statement, err = ParseWithSQLMode(StringWithSQLMode(statement, sqlMode), sqlMode); err != nil {
+		return "", err
+	}
+	return String(statement), nil
+}
+
+// RegisterShadowParser register shadow parser by name, name is case insensitive.
+func RegisterShadowParser(name string, parser ShadowParser) {
+	shadowParsersLock.Lock()
+	defer shadowParsersLock.Unlock()
+	shadowParsers[strings.ToLower(name)] = parser
+}
+
+// GetShadowParser get shadow parser by name.
+func GetShadowParser(name string) (ShadowParser, bool) {
+	shadowParsersLock.RLock()
+	defer shadowParsersLock.RUnlock()
+	parser, ok := shadowParsers[strings.ToLower(name)]
+	return parser, ok
+}
+
+// ShadowDiff parses the sql by shadow parser, and returns the reason if it diverges from statement and err of this grammar,
+// that is, one of them fails, or their formatted statements are different. Empty is not diverged.
+func ShadowDiff(parser ShadowParser, sql string, sqlMode SQLMode, statement Statement, err error) (reason string) {
+	defer func() {
+		if e := recover(); e != nil {
+			reason = fmt.Sprintf("shadow parser panic: %v", e)
+		}
+	}()
+	shadow, shadowErr := parser(sql, sqlMode)
+	switch {
+	case err != nil && shadowErr != nil:
+		return ""
+	case err != nil:
+		return fmt.Sprintf("parser fails '%s', shadow parser succeeds", err.Error())
+	case shadowErr != nil:
+		return fmt.Sprintf("shadow parser fails '%s'", shadowErr.Error())
+	}
+	var formatted string
+	if statement != nil {
+		formatted = String(statement)
+	}
+	if formatted != shadow {
+		return fmt.Sprintf("'%s' != shadow '%s'", formatted, shadow)
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sqlparser
+
+import (
+	"errors"
+	"strings"
+)
+
+// SQLMode is the part of sql_mode, that affects tokenizer and formatter.
+// http://dev.mysql.com/doc/refman/5.6/en/sql-mode.html
+type SQLMode uint32
+
+// SQL modes.
+const (
+	SQL_MODE_ANSI_QUOTES SQLMode = 1 << iota
+	SQL_MODE_PIPES_AS_CONCAT
+	SQL_MODE_NO_BACKSLASH_ESCAPES
+)
+
+// ParseSQLMode parse sql_mode's value, such as 'ANSI_QUOTES,NO_BACKSLASH_ESCAPES'.
+func ParseSQLMode(value string) SQLMode {
+	var sqlMode SQLMode
+	for _, mode := range strings.Split(strings.ToUpper(value), ",") {
+		switch strings.TrimSpace(mode) {
+		case "ANSI":
+			sqlMode |= SQL_MODE_ANSI_QUOTES | SQL_MODE_PIPES_AS_CONCAT
+		case "ANSI_QUOTES":
+			sqlMode |= SQL_MODE_ANSI_QUOTES
+		case "PIPES_AS_CONCAT":
+			sqlMode |= SQL_MODE_PIPES_AS_CONCAT
+		case "NO_BACKSLASH_ESCAPES":
+			sqlMode |= SQL_MODE_NO_BACKSLASH_ESCAPES
+		}
+	}
+	return sqlMode
+}
+
+// ParseWithSQLMode parses the sql with session's sql_mode.
+func ParseWithSQLMode(sql string, sqlMode SQLMode) (Statement, error) {
+	if routine := parseCreateRoutine(sql); routine != nil {
+		return routine, nil
+	}
+	tokenizer := NewStringTokenizer(sql)
+	tokenizer.SQLMode = sqlMode
+	if yyParse(tokenizer) != 0 {
+		return nil, errors.New(tokenizer.LastError)
+	}
+	return tokenizer.ParseTree, nil
+}
+
+// StringWithSQLMode returns a string representation of an SQLNode,
+// that could be executed in backend with the sql_mode.
+func StringWithSQLMode(node SQLNode, sqlMode SQLMode) string {
+	buf := NewTrackedBuffer(nil)
+	buf.SQLMode = sqlMode
+	buf.Fprintf("%v", node)
+	return buf.String()
+}
@@ -74,6 +74,10 @@ func init() {
 	gob.Register(Numeric(nil))
 	gob.Register(Fractional(nil))
 	gob.Register(String(nil))
+	gob.Register(Decimal(nil))
+	gob.Register(Datetime(nil))
+	gob.Register(Time(nil))
+	gob.Register(JSON(nil))
 }
 
 // BinWriter interface is used for encoding values.
@@ -137,7 +141,7 @@ func BuildValue(goval interface{}) (v Value, err error) {
 		v = Value{String(bindVal)}
 	case time.Time:
 		v = Value{String([]byte(bindVal.Format("'2006-01-02 15:04:05'")))}
-	case Numeric, Fractional, String:
+	case Numeric, Fractional, String, Decimal, Datetime, Time, JSON:
 		v = Value{bindVal.(InnerValue)}
 	case Value:
 		v = bindVal
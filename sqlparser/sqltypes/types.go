@@ -0,0 +1,427 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sqltypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Decimal represents DECIMAL of arbitrary precision, it's exact in comparison and arithmetic.
+type Decimal []byte
+
+func (d Decimal) raw() []byte {
+	return []byte(d)
+}
+
+func (d Decimal) encodeSQL(b BinWriter) {
+	if _, err := b.Write(d.raw()); err != nil {
+		panic(err)
+	}
+}
+
+func (d Decimal) encodeASCII(b BinWriter) {
+	if _, err := b.Write(d.raw()); err != nil {
+		panic(err)
+	}
+}
+
+// Datetime represents DATE, DATETIME and TIMESTAMP as 'YYYY-MM-DD[ hh:mm:ss[.ffffff]]', zero date is allowed.
+type Datetime []byte
+
+func (t Datetime) raw() []byte {
+	return []byte(t)
+}
+
+func (t Datetime) encodeSQL(b BinWriter) {
+	String(t).encodeSQL(b)
+}
+
+func (t Datetime) encodeASCII(b BinWriter) {
+	String(t).encodeASCII(b)
+}
+
+// Time represents TIME as '[-]hhh:mm:ss[.ffffff]'.
+type Time []byte
+
+func (t Time) raw() []byte {
+	return []byte(t)
+}
+
+func (t Time) encodeSQL(b BinWriter) {
+	String(t).encodeSQL(b)
+}
+
+func (t Time) encodeASCII(b BinWriter) {
+	String(t).encodeASCII(b)
+}
+
+// JSON represents JSON document.
+type JSON []byte
+
+func (j JSON) raw() []byte {
+	return []byte(j)
+}
+
+func (j JSON) encodeSQL(b BinWriter) {
+	String(j).encodeSQL(b)
+}
+
+func (j JSON) encodeASCII(b BinWriter) {
+	String(j).encodeASCII(b)
+}
+
+// MakeDecimal makes a Decimal value from a []byte without validation.
+func MakeDecimal(b []byte) Value {
+	return Value{Decimal(b)}
+}
+
+// MakeDatetime makes a Datetime value from a []byte without validation.
+func MakeDatetime(b []byte) Value {
+	return Value{Datetime(b)}
+}
+
+// MakeTime makes a Time value from a []byte without validation.
+func MakeTime(b []byte) Value {
+	return Value{Time(b)}
+}
+
+// MakeJSON makes a JSON value from a []byte without validation.
+func MakeJSON(b []byte) Value {
+	return Value{JSON(b)}
+}
+
+// IsDecimal check is decimal.
+func (v Value) IsDecimal() (ok bool) {
+	if v.Inner != nil {
+		_, ok = v.Inner.(Decimal)
+	}
+	return ok
+}
+
+// IsTemporal check is datetime or time.
+func (v Value) IsTemporal() bool {
+	switch v.Inner.(type) {
+	case Datetime, Time:
+		return true
+	}
+	return false
+}
+
+// IsJSON check is json.
+func (v Value) IsJSON() (ok bool) {
+	if v.Inner != nil {
+		_, ok = v.Inner.(JSON)
+	}
+	return ok
+}
+
+func (v Value) isNumber() bool {
+	switch v.Inner.(type) {
+	case Numeric, Fractional, Decimal:
+		return true
+	}
+	return false
+}
+
+// Compare values, NULL is the smallest like mysql.
+// Numbers are compared by value exactly, datetime and time by time, json by rules of mysql, others by bytes.
+func Compare(a, b Value) (int, error) {
+	if a.IsNull() || b.IsNull() {
+		return compareBool(a.IsNull() && !b.IsNull(), !a.IsNull() && b.IsNull()), nil
+	}
+	switch x := a.Inner.(type) {
+	case Datetime:
+		if y, ok := b.Inner.(Datetime); ok {
+			return compareTemporal(x, y, parseDatetime)
+		}
+	case Time:
+		if y, ok := b.Inner.(Time); ok {
+			return compareTemporal(x, y, parseTime)
+		}
+	case JSON:
+		if y, ok := b.Inner.(JSON); ok {
+			return compareJSONText(x, y)
+		}
+	}
+	if a.isNumber() && b.isNumber() {
+		x, err := parseRat(a.Raw())
+		if err != nil {
+			return 0, err
+		}
+		y, err := parseRat(b.Raw())
+		if err != nil {
+			return 0, err
+		}
+		return x.Cmp(y), nil
+	}
+	return bytes.Compare(a.Raw(), b.Raw()), nil
+}
+
+// Add numbers, result is Numeric if both are Numeric, Fractional if any is Fractional, otherwise Decimal.
+// Scale of Decimal is the max scale of them, NULL is returned if any is NULL.
+func Add(a, b Value) (Value, error) {
+	if a.IsNull() || b.IsNull() {
+		return Value{}, nil
+	}
+	if !a.isNumber() || !b.isNumber() {
+		return Value{}, fmt.Errorf("couldn't add %T and %T", a.Inner, b.Inner)
+	}
+	_, fracA := a.Inner.(Fractional)
+	_, fracB := b.Inner.(Fractional)
+	if fracA || fracB {
+		x, err := strconv.ParseFloat(a.String(), 64)
+		if err != nil {
+			return Value{}, err
+		}
+		y, err := strconv.ParseFloat(b.String(), 64)
+		if err != nil {
+			return Value{}, err
+		}
+		return MakeFractional(strconv.AppendFloat(nil, x+y, 'f', -1, 64)), nil
+	}
+
+	x, err := parseRat(a.Raw())
+	if err != nil {
+		return Value{}, err
+	}
+	y, err := parseRat(b.Raw())
+	if err != nil {
+		return Value{}, err
+	}
+	sum := new(big.Rat).Add(x, y)
+	if a.IsNumeric() && b.IsNumeric() {
+		return MakeNumeric([]byte(sum.FloatString(0))), nil
+	}
+	scale := decimalScale(a.Raw())
+	if s := decimalScale(b.Raw()); s > scale {
+		scale = s
+	}
+	return MakeDecimal([]byte(sum.FloatString(scale))), nil
+}
+
+func compareBool(lt, gt bool) int {
+	if lt {
+		return -1
+	} else if gt {
+		return 1
+	}
+	return 0
+}
+
+// parseRat parse text of number exactly.
+func parseRat(b []byte) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(string(b)))
+	if !ok {
+		return nil, fmt.Errorf("invalid number '%s'", b)
+	}
+	return r, nil
+}
+
+// decimalScale is count of digits after decimal point.
+func decimalScale(b []byte) int {
+	if i := bytes.IndexByte(b, '.'); i >= 0 {
+		return len(b) - i - 1
+	}
+	return 0
+}
+
+func compareTemporal(a, b []byte, parse func([]byte) (int64, error)) (int, error) {
+	x, err := parse(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return compareBool(x < y, x > y), nil
+}
+
+// parseDatetime convert 'YYYY-MM-DD[ hh:mm:ss[.ffffff]]' to microseconds,
+// it's only used to compare, so that zero date and zero in date are allowed.
+func parseDatetime(b []byte) (int64, error) {
+	s := string(b)
+	date, clock := s, ""
+	if i := strings.IndexAny(s, " T"); i >= 0 {
+		date, clock = s[:i], s[i+1:]
+	}
+	parts := strings.Split(date, "-")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid datetime '%s'", b)
+	}
+	var ymd [3]int64
+	for i, part := range parts {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid datetime '%s'", b)
+		}
+		ymd[i] = n
+	}
+	var micros int64
+	if len(clock) > 0 {
+		var err error
+		if micros, err = parseTime([]byte(clock)); err != nil || micros < 0 {
+			return 0, fmt.Errorf("invalid datetime '%s'", b)
+		}
+	}
+	return ((ymd[0]*13+ymd[1])*32+ymd[2])*86400000000 + micros, nil
+}
+
+// parseTime convert '[-]hhh:mm:ss[.ffffff]' to microseconds.
+func parseTime(b []byte) (int64, error) {
+	s := string(b)
+	negative := strings.HasPrefix(s, "-")
+	if negative {
+		s = s[1:]
+	}
+	var fraction int64
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits := s[i+1:]
+		if len(digits) == 0 || len(digits) > 6 {
+			return 0, fmt.Errorf("invalid time '%s'", b)
+		}
+		digits += strings.Repeat("0", 6-len(digits))
+		n, err := strconv.ParseInt(digits, 10, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid time '%s'", b)
+		}
+		fraction, s = n, s[:i]
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid time '%s'", b)
+	}
+	var seconds int64
+	for _, part := range parts {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid time '%s'", b)
+		}
+		seconds = seconds*60 + n
+	}
+	micros := seconds*1000000 + fraction
+	if negative {
+		micros = -micros
+	}
+	return micros, nil
+}
+
+func compareJSONText(a, b []byte) (int, error) {
+	x, err := decodeJSON(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := decodeJSON(b)
+	if err != nil {
+		return 0, err
+	}
+	return compareJSON(x, y), nil
+}
+
+func decodeJSON(b []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// jsonRank is precedence of json types, BOOLEAN > ARRAY > OBJECT > STRING > NUMBER > NULL like mysql.
+func jsonRank(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case json.Number:
+		return 1
+	case string:
+		return 2
+	case map[string]interface{}:
+		return 3
+	case []interface{}:
+		return 4
+	case bool:
+		return 5
+	}
+	return 6
+}
+
+// compareJSON of different types by precedence, arrays are compared by elements, objects by sorted keys and values.
+func compareJSON(a, b interface{}) int {
+	rankA, rankB := jsonRank(a), jsonRank(b)
+	if rankA != rankB {
+		return compareBool(rankA < rankB, rankA > rankB)
+	}
+	switch x := a.(type) {
+	case json.Number:
+		y := b.(json.Number)
+		ratX, errX := parseRat([]byte(x))
+		ratY, errY := parseRat([]byte(y))
+		if errX != nil || errY != nil {
+			return strings.Compare(string(x), string(y))
+		}
+		return ratX.Cmp(ratY)
+	case string:
+		return strings.Compare(x, b.(string))
+	case bool:
+		y := b.(bool)
+		return compareBool(!x && y, x && !y)
+	case []interface{}:
+		y := b.([]interface{})
+		for i := 0; i < len(x) && i < len(y); i++ {
+			if c := compareJSON(x[i], y[i]); c != 0 {
+				return c
+			}
+		}
+		return compareBool(len(x) < len(y), len(x) > len(y))
+	case map[string]interface{}:
+		y := b.(map[string]interface{})
+		keysX, keysY := sortedKeys(x), sortedKeys(y)
+		for i := 0; i < len(keysX) && i < len(keysY); i++ {
+			if c := strings.Compare(keysX[i], keysY[i]); c != 0 {
+				return c
+			}
+			if c := compareJSON(x[keysX[i]], y[keysY[i]]); c != 0 {
+				return c
+			}
+		}
+		return compareBool(len(keysX) < len(keysY), len(keysX) > len(keysY))
+	}
+	return 0
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,95 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sqltypes
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		a, b Value
+		want int
+	}{
+		{Value{}, MakeNumeric([]byte("1")), -1},
+		{Value{}, Value{}, 0},
+		{MakeDecimal([]byte("12345678901234567890.000000000000000001")), MakeDecimal([]byte("12345678901234567890")), 1},
+		{MakeDecimal([]byte("1.10")), MakeDecimal([]byte("1.1")), 0},
+		{MakeDecimal([]byte("-2.5")), MakeNumeric([]byte("-2")), -1},
+		{MakeNumeric([]byte("9")), MakeNumeric([]byte("10")), -1},
+		{MakeFractional([]byte("1e3")), MakeDecimal([]byte("999.99")), 1},
+		{MakeDatetime([]byte("2016-01-02 03:04:05.5")), MakeDatetime([]byte("2016-01-02 03:04:05.123456")), 1},
+		{MakeDatetime([]byte("2016-01-02")), MakeDatetime([]byte("2016-01-02 00:00:00")), 0},
+		{MakeDatetime([]byte("0000-00-00 00:00:00")), MakeDatetime([]byte("2016-01-02")), -1},
+		{MakeTime([]byte("-01:00:00")), MakeTime([]byte("00:59:59.999999")), -1},
+		{MakeTime([]byte("100:00:00")), MakeTime([]byte("99:59:59")), 1},
+		{MakeTime([]byte("-01:00:00.500000")), MakeTime([]byte("-01:00:00")), -1},
+		{MakeDatetime([]byte("0000-00-00")), MakeDatetime([]byte("0000-00-00 00:00:00.000000")), 0},
+		{MakeJSON([]byte(`10`)), MakeJSON([]byte(`9.5`)), 1},
+		{MakeJSON([]byte(`"10"`)), MakeJSON([]byte(`9`)), 1},
+		{MakeJSON([]byte(`[1, 2]`)), MakeJSON([]byte(`[1, 2, 0]`)), -1},
+		{MakeJSON([]byte(`{"b": 1, "a": 2}`)), MakeJSON([]byte(`{"a":2,"b":1}`)), 0},
+		{MakeJSON([]byte(`true`)), MakeJSON([]byte(`[1]`)), 1},
+		{MakeString([]byte("b")), MakeString([]byte("a")), 1},
+	}
+	for _, c := range cases {
+		got, err := Compare(c.a, c.b)
+		if err != nil {
+			t.Errorf("Compare(%q, %q) error: %v", c.a.Raw(), c.b.Raw(), err)
+		} else if got != c.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", c.a.Raw(), c.b.Raw(), got, c.want)
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	cases := []struct {
+		a, b Value
+		want string
+		kind string
+	}{
+		{MakeNumeric([]byte("9223372036854775807")), MakeNumeric([]byte("1")), "9223372036854775808", "numeric"},
+		{MakeDecimal([]byte("0.10")), MakeDecimal([]byte("0.2")), "0.30", "decimal"},
+		{MakeDecimal([]byte("12345678901234567890.5")), MakeNumeric([]byte("-1")), "12345678901234567889.5", "decimal"},
+		{MakeFractional([]byte("0.5")), MakeDecimal([]byte("1")), "1.5", "fractional"},
+	}
+	for _, c := range cases {
+		got, err := Add(c.a, c.b)
+		if err != nil {
+			t.Fatalf("Add(%q, %q) error: %v", c.a.Raw(), c.b.Raw(), err)
+		}
+		kind := "decimal"
+		if got.IsNumeric() {
+			kind = "numeric"
+		} else if got.IsFractional() {
+			kind = "fractional"
+		}
+		if got.String() != c.want || kind != c.kind {
+			t.Errorf("Add(%q, %q) = %s %s, want %s %s", c.a.Raw(), c.b.Raw(), kind, got.String(), c.kind, c.want)
+		}
+	}
+	if _, err := Add(MakeString([]byte("a")), MakeNumeric([]byte("1"))); err == nil {
+		t.Errorf("Add of string should fail")
+	}
+	if v, err := Add(Value{}, MakeNumeric([]byte("1"))); err != nil || !v.IsNull() {
+		t.Errorf("Add of null should be null")
+	}
+}
@@ -0,0 +1,72 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sqlparser
+
+import (
+	"fmt"
+	"io"
+)
+
+// Token is a token of sql scanned by Tokenizer, it's for external tools such as linters and formatters,
+// that reuse lexer of mysql dialect without the parser.
+type Token struct {
+	Kind     int    // ID, STRING, NUMBER, VALUE_ARG, COMMENTS, keyword such as SELECT, or char of single char token such as '('.
+	Value    []byte // value of ID, STRING (unquoted and unescaped), NUMBER, VALUE_ARG and COMMENTS, lower case of keyword.
+	Position int    // offset of the first byte of token in sql.
+	End      int    // offset after the last byte of token in sql.
+}
+
+// Name of token kind, such as 'ID', 'SELECT' or '('.
+func (t Token) Name() string {
+	return TokenName(t.Kind)
+}
+
+// TokenName get name of token kind, by symbol tables of go yacc.
+func TokenName(kind int) string {
+	if kind >= yyPrivate && kind < yyPrivate+len(yyTok2) {
+		return yyTokname(int(yyTok2[kind-yyPrivate]))
+	}
+	if kind > 0 && kind < yyPrivate {
+		return string(rune(kind))
+	}
+	return fmt.Sprintf("tok-%d", kind)
+}
+
+// NextToken scans the next token including comments, io.EOF is returned at end of sql.
+// Error is returned if the token is invalid, such as unterminated string or unexpected char.
+func (tkn *Tokenizer) NextToken() (Token, error) {
+	if tkn.Position == 0 {
+		tkn.next()
+	}
+	tkn.skipBlank()
+	start := tkn.Position - 1
+	kind, value := tkn.Scan()
+	token := Token{Kind: kind, Value: value, Position: start, End: tkn.Position - 1}
+	switch kind {
+	case 0:
+		return token, io.EOF
+	case LEX_ERROR:
+		return token, fmt.Errorf("invalid token at position %d near %s", start, value)
+	}
+	return token, nil
+}
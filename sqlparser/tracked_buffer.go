@@ -42,6 +42,7 @@ import (
 type TrackedBuffer struct {
 	*bytes.Buffer
 	nodeFormatter func(buf *TrackedBuffer, node SQLNode)
+	SQLMode       SQLMode
 }
 
 // NewTrackedBuffer create TrackedBuffer
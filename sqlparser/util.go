@@ -97,7 +97,8 @@ func CheckColumnInBoolExpr(expr BoolExpr, colName string) (strOrNumValue ValExpr
 				strOrNumValue = nil
 			}
 		}
-		if err1 != nil || err2 != nil {
+		// Both sides should have shard key's value, such as 'key = 1 or key <> 1' may be at any node.
+		if err1 != nil || err2 != nil || colValue1 == nil || colValue2 == nil {
 			strOrNumValue = nil
 			err = errors.ErrWhereOrJoinOnKey
 		}
@@ -105,6 +106,17 @@ func CheckColumnInBoolExpr(expr BoolExpr, colName string) (strOrNumValue ValExpr
 	case *ParenBoolExpr:
 		strOrNumValue, err = CheckColumnInBoolExpr(boolExpr.Expr, colName)
 		return
+	case *NotExpr:
+		// Push down NOT by De Morgan's laws, such as 'not (key <> 1 or a = 2)' is 'key = 1 and a <> 2'.
+		if negated := negateBoolExpr(boolExpr.Expr); negated != nil {
+			return CheckColumnInBoolExpr(negated, colName)
+		}
+		err = errors.ErrWhereOrJoinOnKey
+		return
+	case *NullCheck:
+		// Rows with NULL shard key may be at default node, or any node.
+		err = errors.ErrWhereOrJoinOnKey
+		return
 	case *ComparisonExpr:
 		switch boolExpr.Operator {
 		case AST_EQ:
@@ -113,6 +125,14 @@ func CheckColumnInBoolExpr(expr BoolExpr, colName string) (strOrNumValue ValExpr
 			} else if GetColName(boolExpr.Right) == colName {
 				strOrNumValue = boolExpr.Left
 			}
+			if collate, ok := strOrNumValue.(*CollateExpr); ok {
+				// value is same, only collation of comparison is changed.
+				strOrNumValue = collate.Expr
+			}
+			if introducer, ok := strOrNumValue.(*IntroducerExpr); ok {
+				// value is same, only charset is introduced.
+				strOrNumValue = introducer.Expr
+			}
 			if strOrNumValue != nil {
 				switch strOrNumValue.(type) {
 				case StrVal:
@@ -136,8 +156,70 @@ func CheckColumnInBoolExpr(expr BoolExpr, colName string) (strOrNumValue ValExpr
 	}
 }
 
+var negatedOperators = map[string]string{
+	AST_EQ:          AST_NE,
+	AST_NE:          AST_EQ,
+	AST_LT:          AST_GE,
+	AST_GE:          AST_LT,
+	AST_GT:          AST_LE,
+	AST_LE:          AST_GT,
+	AST_IN:          AST_NOT_IN,
+	AST_NOT_IN:      AST_IN,
+	AST_LIKE:        AST_NOT_LIKE,
+	AST_NOT_LIKE:    AST_LIKE,
+	AST_REGEXP:      AST_NOT_REGEXP,
+	AST_NOT_REGEXP:  AST_REGEXP,
+	AST_BETWEEN:     AST_NOT_BETWEEN,
+	AST_NOT_BETWEEN: AST_BETWEEN,
+	AST_IS_NULL:     AST_IS_NOT_NULL,
+	AST_IS_NOT_NULL: AST_IS_NULL,
+}
+
+// negateBoolExpr return expression equivalent to 'not expr', nil if unknown.
+// '<=>' isn't negated, because 'not (null <=> 1)' is true, but 'null != 1' is null.
+func negateBoolExpr(expr BoolExpr) BoolExpr {
+	switch v := expr.(type) {
+	case *AndExpr:
+		left, right := negateBoolExpr(v.Left), negateBoolExpr(v.Right)
+		if left == nil || right == nil {
+			return nil
+		}
+		return &OrExpr{Left: left, Right: right}
+	case *OrExpr:
+		left, right := negateBoolExpr(v.Left), negateBoolExpr(v.Right)
+		if left == nil || right == nil {
+			return nil
+		}
+		return &AndExpr{Left: left, Right: right}
+	case *ParenBoolExpr:
+		if inner := negateBoolExpr(v.Expr); inner != nil {
+			return &ParenBoolExpr{Expr: inner}
+		}
+	case *NotExpr:
+		return v.Expr
+	case *ComparisonExpr:
+		if operator, ok := negatedOperators[v.Operator]; ok {
+			return &ComparisonExpr{Operator: operator, Left: v.Left, Right: v.Right}
+		}
+	case *RangeCond:
+		if operator, ok := negatedOperators[v.Operator]; ok {
+			return &RangeCond{Operator: operator, Left: v.Left, From: v.From, To: v.To}
+		}
+	case *NullCheck:
+		if operator, ok := negatedOperators[v.Operator]; ok {
+			return &NullCheck{Operator: operator, Expr: v.Expr}
+		}
+	}
+	return nil
+}
+
 // CheckTableExprs remove db and check table's name.
 func CheckTableExprs(tabExprs TableExprs, tableNames interface{}) (err error) {
+	return checkTableExprs(tabExprs, tableNames, nil)
+}
+
+// checkTableExprs skip names of common table expressions in cteNames.
+func checkTableExprs(tabExprs TableExprs, tableNames interface{}, cteNames map[string]bool) (err error) {
 	for _, tabExpr := range tabExprs {
 		switch realTabExpr := tabExpr.(type) {
 		case *AliasedTableExpr:
@@ -152,22 +234,25 @@ func CheckTableExprs(tabExprs TableExprs, tableNames interface{}) (err error) {
 				}
 				if !isSystemDB {
 					tableName := strings.Trim(strings.ToLower(string(simpExpr.Name)), "`")
+					if len(simpExpr.Qualifier) == 0 && cteNames[tableName] {
+						continue
+					}
 					if isValid := utils.Contains(tableNames, tableName); !isValid {
 						err = &errors.SqlError{Code: 1146, Message: fmt.Sprintf("Table '%-.192s.%-.192s' doesn't exist", "", tableName), State: "42S02"}
 						break
 					}
 				}
 			case *Subquery:
-				if err = CheckTableExprsInSelect(simpExpr.Select, tableNames); err != nil {
+				if err = checkTableExprsInSelect(simpExpr.Select, tableNames, cteNames); err != nil {
 					break
 				}
 			}
 		case *ParenTableExpr:
-			err = CheckTableExprs(TableExprs{realTabExpr.Expr}, tableNames)
+			err = checkTableExprs(TableExprs{realTabExpr.Expr}, tableNames, cteNames)
 		case *JoinTableExpr:
-			err = CheckTableExprs(TableExprs{realTabExpr.LeftExpr}, tableNames)
+			err = checkTableExprs(TableExprs{realTabExpr.LeftExpr}, tableNames, cteNames)
 			if err == nil {
-				CheckTableExprs(TableExprs{realTabExpr.RightExpr}, tableNames)
+				checkTableExprs(TableExprs{realTabExpr.RightExpr}, tableNames, cteNames)
 			}
 		}
 	}
@@ -197,33 +282,86 @@ func IsOnlySystemDBInTableExprs(tabExprs TableExprs) bool {
 	return onlySystemDB
 }
 
-// CheckTableExprsInSelect remove db and check table's name.
+// CheckTableExprsInSelect remove db and check table's name, common table expressions are checked by their select.
 func CheckTableExprsInSelect(stmt SelectStatement, tableNames interface{}) (err error) {
+	return checkTableExprsInSelect(stmt, tableNames, nil)
+}
+
+func checkTableExprsInSelect(stmt SelectStatement, tableNames interface{}, cteNames map[string]bool) (err error) {
 	switch selStmt := stmt.(type) {
 	case *Select:
-		err = CheckTableExprs(selStmt.From, tableNames)
+		if cteNames, err = checkTableExprsInWith(selStmt.With, tableNames, cteNames); err == nil {
+			err = checkTableExprs(selStmt.From, tableNames, cteNames)
+		}
 	case *Union:
-		err = CheckTableExprsInSelect(selStmt.Left, tableNames)
+		if cteNames, err = checkTableExprsInWith(selStmt.With, tableNames, cteNames); err != nil {
+			return err
+		}
+		err = checkTableExprsInSelect(selStmt.Left, tableNames, cteNames)
 		if err == nil {
-			err = CheckTableExprsInSelect(selStmt.Right, tableNames)
+			err = checkTableExprsInSelect(selStmt.Right, tableNames, cteNames)
 		}
 	}
 	return err
 }
 
+func checkTableExprsInWith(with *With, tableNames interface{}, cteNames map[string]bool) (map[string]bool, error) {
+	if with == nil {
+		return cteNames, nil
+	}
+	cteNames = withCTENames(with, cteNames)
+	for _, cte := range with.CTEs {
+		if err := checkTableExprsInSelect(cte.Select, tableNames, cteNames); err != nil {
+			return cteNames, err
+		}
+	}
+	return cteNames, nil
+}
+
+// withCTENames merge names of common table expressions in with into names of outer statement.
+func withCTENames(with *With, cteNames map[string]bool) map[string]bool {
+	names := with.Names()
+	for name := range cteNames {
+		names[name] = true
+	}
+	return names
+}
+
+// onlyCTEInTableExprs is true if tables are all common table expressions, that have been checked.
+func onlyCTEInTableExprs(tabExprs TableExprs, cteNames map[string]bool) bool {
+	if len(cteNames) == 0 || len(tabExprs) == 0 {
+		return false
+	}
+	for _, tabExpr := range tabExprs {
+		aliasedTabExpr, ok := tabExpr.(*AliasedTableExpr)
+		if !ok {
+			return false
+		}
+		tabName, ok := aliasedTabExpr.Expr.(*TableName)
+		if !ok || len(tabName.Qualifier) > 0 || !cteNames[strings.Trim(strings.ToLower(string(tabName.Name)), "`")] {
+			return false
+		}
+	}
+	return true
+}
+
 // CheckColumnInTableExpr check shard key should exists in table expression, and has same shard key's value in it.
 func CheckColumnInTableExpr(tabExpr TableExpr, colName string) (strOrNumValue ValExpr, err error) {
+	return checkColumnInTableExpr(tabExpr, colName, nil)
+}
+
+func checkColumnInTableExpr(tabExpr TableExpr, colName string, cteNames map[string]bool) (strOrNumValue ValExpr, err error) {
 	switch realTabExpr := tabExpr.(type) {
 	case *AliasedTableExpr:
 		if subQuery, ok := realTabExpr.Expr.(*Subquery); ok {
-			valInSubQuery, errInSubQuery := CheckColumnInSelect(subQuery.Select, colName)
+			valInSubQuery, errInSubQuery := checkColumnInSelect(subQuery.Select, colName, cteNames)
 			strOrNumValue, err = mergeValExprAndError(strOrNumValue, err, valInSubQuery, errInSubQuery)
 			if err != nil {
 				return
 			}
 		}
 	case *ParenTableExpr:
-		valInParen, errInParen := CheckColumnInTableExpr(realTabExpr.Expr, colName)
+		valInParen, errInParen := checkColumnInTableExpr(realTabExpr.Expr, colName, cteNames)
 		strOrNumValue, err = mergeValExprAndError(strOrNumValue, err, valInParen, errInParen)
 		if err != nil {
 			return
@@ -240,13 +378,13 @@ func CheckColumnInTableExpr(tabExpr TableExpr, colName string) (strOrNumValue Va
 			return
 		}
 
-		valInLeft, errInLeft := CheckColumnInTableExpr(realTabExpr.LeftExpr, colName)
+		valInLeft, errInLeft := checkColumnInTableExpr(realTabExpr.LeftExpr, colName, cteNames)
 		strOrNumValue, err = mergeValExprAndError(strOrNumValue, err, valInLeft, errInLeft)
 		if err != nil {
 			return
 		}
 
-		valInRight, errInRight := CheckColumnInTableExpr(realTabExpr.RightExpr, colName)
+		valInRight, errInRight := checkColumnInTableExpr(realTabExpr.RightExpr, colName, cteNames)
 		strOrNumValue, err = mergeValExprAndError(strOrNumValue, err, valInRight, errInRight)
 		if err != nil {
 			return
@@ -256,33 +394,48 @@ func CheckColumnInTableExpr(tabExpr TableExpr, colName string) (strOrNumValue Va
 }
 
 // CheckColumnInSelect check shard key should exists in where and join expression, and has same shard key's value in it.
+// Common table expressions should have the same shard key's value, select only from them needn't shard key.
 func CheckColumnInSelect(statement SelectStatement, colName string) (strOrNumValue ValExpr, err error) {
+	return checkColumnInSelect(statement, colName, nil)
+}
+
+func checkColumnInSelect(statement SelectStatement, colName string, cteNames map[string]bool) (strOrNumValue ValExpr, err error) {
 	switch selStmt := statement.(type) {
 	case *Select:
+		if strOrNumValue, cteNames, err = checkColumnInWith(selStmt.With, colName, cteNames); err != nil {
+			return
+		}
+		if onlyCTEInTableExprs(selStmt.From, cteNames) {
+			return
+		}
 		if selStmt.Where == nil {
 			strOrNumValue = nil
 			err = errors.ErrWhereOrJoinOnKey
 			return
 		}
-		strOrNumValue, err = CheckColumnInBoolExpr(selStmt.Where.Expr, colName)
+		valInWhere, errInWhere := CheckColumnInBoolExpr(selStmt.Where.Expr, colName)
+		strOrNumValue, err = mergeValExprAndError(strOrNumValue, err, valInWhere, errInWhere)
 		if err != nil {
 			return
 		}
 		for _, tabExpr := range selStmt.From {
-			valInTab, errInTab := CheckColumnInTableExpr(tabExpr, colName)
+			valInTab, errInTab := checkColumnInTableExpr(tabExpr, colName, cteNames)
 			strOrNumValue, err = mergeValExprAndError(strOrNumValue, err, valInTab, errInTab)
 			if err != nil {
 				return
 			}
 		}
 	case *Union:
-		valInLeft, errInLeft := CheckColumnInSelect(selStmt.Left, colName)
+		if strOrNumValue, cteNames, err = checkColumnInWith(selStmt.With, colName, cteNames); err != nil {
+			return
+		}
+		valInLeft, errInLeft := checkColumnInSelect(selStmt.Left, colName, cteNames)
 		strOrNumValue, err = mergeValExprAndError(strOrNumValue, err, valInLeft, errInLeft)
 		if err != nil {
 			return
 		}
 
-		valInRight, errInRight := CheckColumnInSelect(selStmt.Right, colName)
+		valInRight, errInRight := checkColumnInSelect(selStmt.Right, colName, cteNames)
 		strOrNumValue, err = mergeValExprAndError(strOrNumValue, err, valInRight, errInRight)
 		if err != nil {
 			return
@@ -291,6 +444,21 @@ func CheckColumnInSelect(statement SelectStatement, colName string) (strOrNumVal
 	return strOrNumValue, err
 }
 
+func checkColumnInWith(with *With, colName string, cteNames map[string]bool) (strOrNumValue ValExpr, names map[string]bool, err error) {
+	if with == nil {
+		return nil, cteNames, nil
+	}
+	names = withCTENames(with, cteNames)
+	for _, cte := range with.CTEs {
+		valInCTE, errInCTE := checkColumnInSelect(cte.Select, colName, names)
+		strOrNumValue, err = mergeValExprAndError(strOrNumValue, err, valInCTE, errInCTE)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
 // SetLimitInSelect set limit expression in select statement.
 func SetLimitInSelect(statement SelectStatement, maxRowCount int) {
 	if statement != nil && maxRowCount > 0 {
@@ -306,6 +474,61 @@ func SetLimitInSelect(statement SelectStatement, maxRowCount int) {
 	}
 }
 
+// RewriteCountDistinct rewrite COUNT(DISTINCT x) of select into distinct value list x with GROUP BY x,
+// so that values of nodes could be counted in proxy. Other select expressions must be in GROUP BY,
+// and HAVING, ORDER BY and LIMIT should be applied after merged, so they are removed.
+// Column is index of x in select expressions, or -1 if it couldn't be rewritten.
+func RewriteCountDistinct(statement *Select) (rewritten *Select, column int) {
+	column = -1
+	if statement == nil || statement.Having != nil {
+		return nil, -1
+	}
+	groupBy := make(map[string]bool, len(statement.GroupBy))
+	for _, expr := range statement.GroupBy {
+		groupBy[String(expr)] = true
+	}
+	var distinctExpr ValExpr
+	selectExprs := make(SelectExprs, len(statement.SelectExprs))
+	for i, selectExpr := range statement.SelectExprs {
+		nonStar, ok := selectExpr.(*NonStarExpr)
+		if !ok {
+			return nil, -1
+		}
+		if funcExpr, ok := nonStar.Expr.(*FuncExpr); ok && funcExpr.Distinct && len(funcExpr.Exprs) == 1 &&
+			strings.EqualFold(string(funcExpr.Name), "count") {
+			if column >= 0 {
+				return nil, -1
+			}
+			as := nonStar.As
+			if len(as) == 0 {
+				as = []byte(String(funcExpr))
+			}
+			distinctExpr = funcExpr.Exprs[0]
+			selectExprs[i] = &NonStarExpr{Expr: distinctExpr, As: as}
+			column = i
+			continue
+		}
+		if !groupBy[String(nonStar.Expr)] && (len(nonStar.As) == 0 || !groupBy[string(nonStar.As)]) {
+			return nil, -1
+		}
+		selectExprs[i] = selectExpr
+	}
+	if column < 0 {
+		return nil, -1
+	}
+
+	rewritten = &Select{
+		With:        statement.With,
+		Comments:    statement.Comments,
+		SelectExprs: selectExprs,
+		From:        statement.From,
+		Where:       statement.Where,
+		GroupBy:     append(append(GroupBy{}, statement.GroupBy...), distinctExpr),
+		Lock:        statement.Lock,
+	}
+	return rewritten, column
+}
+
 // CheckColumnInInsertOrReplace check shard key should exists in columns, not in dup expers, has same shard key's value in rows.
 func CheckColumnInInsertOrReplace(columns Columns, insertRows InsertRows, onDup OnDup, colName string) (strOrNumValue ValExpr, err error) {
 	var colValue ValExpr
@@ -364,6 +587,12 @@ func CheckColumnInInsertOrReplace(columns Columns, insertRows InsertRows, onDup
 				}
 			}
 		}
+	case SelectStatement:
+		// INSERT ... SELECT, shard key's value is from select expression at the same position.
+		selectValue, _ := CheckColumnInSelect(values, colName)
+		if colValue, err = checkColumnInInsertSelect(values, selectValue, len(columns), shardKeyPos, colName); err != nil {
+			return nil, err
+		}
 	}
 	if colValue == nil {
 		return nil, errors.ErrInsertValuesKey
@@ -371,6 +600,42 @@ func CheckColumnInInsertOrReplace(columns Columns, insertRows InsertRows, onDup
 	return colValue, nil
 }
 
+// checkColumnInInsertSelect get shard key's value of select expression at pos,
+// which should be literal, or shard key of select that has selectValue.
+func checkColumnInInsertSelect(statement SelectStatement, selectValue ValExpr, columnCount, pos int, colName string) (strOrNumValue ValExpr, err error) {
+	var selectExprs SelectExprs
+	switch selStmt := statement.(type) {
+	case *Select:
+		selectExprs = selStmt.SelectExprs
+	case *SimpleSelect:
+		selectExprs = selStmt.SelectExprs
+	case *Union:
+		valInLeft, errInLeft := checkColumnInInsertSelect(selStmt.Left, selectValue, columnCount, pos, colName)
+		valInRight, errInRight := checkColumnInInsertSelect(selStmt.Right, selectValue, columnCount, pos, colName)
+		strOrNumValue, err = mergeValExprAndError(valInLeft, errInLeft, valInRight, errInRight)
+		if err == nil && strOrNumValue == nil {
+			err = errors.ErrInsertValuesKey
+		}
+		return
+	default:
+		return nil, errors.ErrInsertValuesKey
+	}
+	if len(selectExprs) != columnCount {
+		return nil, errors.ErrColsLenNotMatch
+	}
+	if expr, ok := selectExprs[pos].(*NonStarExpr); ok {
+		switch val := expr.Expr.(type) {
+		case StrVal, NumVal:
+			return val.(ValExpr), nil
+		case *ColName:
+			if GetColName(val) == colName && selectValue != nil {
+				return selectValue, nil
+			}
+		}
+	}
+	return nil, errors.ErrInsertValuesKey
+}
+
 // GetColName returns the column name, only if
 // it's a simple expression. Otherwise, it returns "".
 func GetColName(node Expr) string {
@@ -0,0 +1,243 @@
+// The MIT License (MIT)
+
+// Copyright (c) 2016 Jerry Bai
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sqlparser
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+// Shard key's value is returned only if the where expression implies 'key = value'.
+func TestCheckColumnInBoolExpr(t *testing.T) {
+	cases := []struct {
+		where string
+		want  string
+	}{
+		{"tenant_id = 1", "1"},
+		{"tenant_id = 1 and a = 2", "1"},
+		{"tenant_id = 1 or tenant_id = 1", "1"},
+		{"tenant_id = 1 or tenant_id = 2", ""},
+		{"tenant_id = 1 or tenant_id <> 1", ""},
+		{"tenant_id = 1 or a = 2", ""},
+		{"tenant_id = 1 or a > 2", ""},
+		{"tenant_id <> 1", ""},
+		{"tenant_id is null", ""},
+		{"tenant_id is not null", ""},
+		{"tenant_id = 1 and tenant_id is not null", "1"},
+		{"tenant_id = 1 or tenant_id is null", ""},
+		{"not tenant_id = 1", ""},
+		{"not (tenant_id = 1)", ""},
+		{"not (tenant_id <> 1)", "1"},
+		{"not not (tenant_id = 1)", "1"},
+		{"not (tenant_id <> 1 or a = 2)", "1"},
+		{"not (tenant_id <> 1 and a = 2)", ""},
+		{"not (tenant_id <> 1 or tenant_id <> 1)", "1"},
+		{"not (tenant_id <> 1 and tenant_id <> 1)", "1"},
+		{"not (tenant_id <> 1 or tenant_id <> 2)", ""},
+		{"not (tenant_id < 1 or tenant_id > 1)", ""},
+		{"not (tenant_id <=> 1)", ""},
+		{"not (tenant_id is not null) and tenant_id = 1", "1"},
+		{"not (tenant_id is null or a = 1)", ""},
+		{"tenant_id = 1 and not (tenant_id <> 1 or a = 2)", "1"},
+		{"not (tenant_id between 1 and 2)", ""},
+		{"tenant_id = _utf8mb4'a'", "'a'"},
+		{"tenant_id = N'a'", "'a'"},
+		{"tenant_id = 'a' collate utf8mb4_bin", "'a'"},
+		{"_utf8mb4'a' COLLATE utf8mb4_0900_ai_ci = tenant_id", "'a'"},
+		{"binary tenant_id = 'a'", ""},
+		{"tenant_id = 1 xor a", ""},
+		{"a and tenant_id = 1", "1"},
+		{"tenant_id = 1 xor a and tenant_id = 2", ""},
+		{"not a and tenant_id = 1", "1"},
+	}
+	for _, c := range cases {
+		stmt, err := Parse("select * from t where " + c.where)
+		if err != nil {
+			t.Fatalf("parse %q: %v", c.where, err)
+		}
+		var got string
+		if value, err := CheckColumnInBoolExpr(stmt.(*Select).Where.Expr, "tenant_id"); err == nil && value != nil {
+			got = String(value)
+		}
+		if got != c.want {
+			t.Errorf("CheckColumnInBoolExpr(%q) = %q, want %q", c.where, got, c.want)
+		}
+	}
+}
+
+// Common table expressions should have the same shard key's value, select only from them needn't shard key.
+func TestCheckColumnInSelectWith(t *testing.T) {
+	cases := []struct {
+		sql  string
+		want string
+	}{
+		{"with c as (select * from t where tenant_id = 1) select * from c", "1"},
+		{"with c as (select * from t where tenant_id = 1) select * from c where a = 2", "1"},
+		{"with c as (select * from t) select * from c", ""},
+		{"with c as (select * from t where tenant_id = 1), d as (select * from t where tenant_id = 1) select * from c, d", "1"},
+		{"with c as (select * from t where tenant_id = 1), d as (select * from t where tenant_id = 2) select * from c, d", ""},
+		{"with c as (select * from t where tenant_id = 1) select * from c, t where t.tenant_id = 1", "1"},
+		{"with c as (select * from t where tenant_id = 1) select * from c, t where t.tenant_id = 2", ""},
+		{"with c as (select * from t where tenant_id = 1) select * from t", ""},
+		{"with recursive c(n) as (select id from t where tenant_id = 1 union all select n+1 from c where n < 5) select * from c", "1"},
+		{"with c as (select * from t where tenant_id = 1) select * from c union select * from t where tenant_id = 1", "1"},
+		{"select * from (with c as (select * from t where tenant_id = 1) select * from c) s where tenant_id = 1", "1"},
+	}
+	for _, c := range cases {
+		stmt, err := Parse(c.sql)
+		if err != nil {
+			t.Fatalf("parse %q: %v", c.sql, err)
+		}
+		var got string
+		if value, err := CheckColumnInSelect(stmt.(SelectStatement), "tenant_id"); err == nil && value != nil {
+			got = String(value)
+		}
+		if got != c.want {
+			t.Errorf("CheckColumnInSelect(%q) = %q, want %q", c.sql, got, c.want)
+		}
+	}
+}
+
+// Shard key of INSERT ... SELECT is literal, or shard key of select at the same position.
+func TestCheckColumnInInsertSelect(t *testing.T) {
+	cases := []struct {
+		sql  string
+		want string
+	}{
+		{"insert into t1 (a, tenant_id) select a, tenant_id from t2 where tenant_id = 1", "1"},
+		{"insert into t1 (a, tenant_id) select a, 2 from t2 where tenant_id = 1", "2"},
+		{"insert into t1 (a, tenant_id) select a, tenant_id from t2", ""},
+		{"insert into t1 (a, tenant_id) select a, b from t2 where tenant_id = 1", ""},
+		{"insert into t1 (a, tenant_id) select * from t2 where tenant_id = 1", ""},
+		{"insert into t1 select * from t2 where tenant_id = 1", ""},
+		{"insert into t1 (a, tenant_id) select a, tenant_id from t2 where tenant_id = 1 union select a, 1 from t3 where tenant_id = 1", "1"},
+		{"insert into t1 (a, tenant_id) select a, tenant_id from t2 where tenant_id = 1 union select a, 2 from t3 where tenant_id = 1", ""},
+	}
+	for _, c := range cases {
+		stmt, err := Parse(c.sql)
+		if err != nil {
+			t.Fatalf("parse %q: %v", c.sql, err)
+		}
+		insert := stmt.(*Insert)
+		var got string
+		if value, err := CheckColumnInInsertOrReplace(insert.Columns, insert.Rows, insert.OnDup, "tenant_id"); err == nil && value != nil {
+			got = String(value)
+		}
+		if got != c.want {
+			t.Errorf("CheckColumnInInsertOrReplace(%q) = %q, want %q", c.sql, got, c.want)
+		}
+	}
+}
+
+func TestBindArgs(t *testing.T) {
+	cases := []struct {
+		sql   string
+		using string
+		want  string
+	}{
+		{"select * from t where tenant_id = ? and a = ?", "select 1, 'x'", "select * from t where tenant_id = 1 and a = 'x'"},
+		{"insert into t(a, b) values (?, ?)", "select @a, null", "insert  into t(a, b) values (@a, null)"},
+		{"select * from t where a in (?)", "select 1, 2", ""},
+	}
+	for _, c := range cases {
+		statement, err := Parse(c.sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		using, _ := Parse(c.using)
+		var args ValExprs
+		for _, expr := range using.(*SimpleSelect).SelectExprs {
+			args = append(args, expr.(*NonStarExpr).Expr.(ValExpr))
+		}
+		bound, err := BindArgs(statement, args, 0)
+		if len(c.want) == 0 {
+			if err == nil {
+				t.Errorf("BindArgs(%s) should be error", c.sql)
+			}
+		} else if err != nil {
+			t.Errorf("BindArgs(%s) error: %v", c.sql, err)
+		} else if got := String(bound); got != c.want {
+			t.Errorf("BindArgs(%s) = %s, want %s", c.sql, got, c.want)
+		}
+	}
+}
+
+// Shadow parser of the same grammar never diverges, except it's changed.
+func TestShadowDiff(t *testing.T) {
+	same := func(sql string, sqlMode SQLMode) (string, error) {
+		statement, err := ParseWithSQLMode(sql, sqlMode)
+		if err != nil {
+			return "", err
+		}
+		return String(statement), nil
+	}
+	upper := func(sql string, sqlMode SQLMode) (string, error) {
+		formatted, err := same(sql, sqlMode)
+		return strings.ToUpper(formatted), err
+	}
+	cases := []struct {
+		parser  ShadowParser
+		sql     string
+		diverge bool
+	}{
+		{same, "select * from t where a = 1", false},
+		{same, "select * from", false},
+		{upper, "select * from t where a = 1", true},
+		{upper, "select * from", false},
+		{baselineParser, "select * from t where a = 'it''s' and b in (1, 2)", false},
+		{baselineParser, "select * from", false},
+	}
+	for _, c := range cases {
+		statement, err := Parse(c.sql)
+		if reason := ShadowDiff(c.parser, c.sql, 0, statement, err); (len(reason) > 0) != c.diverge {
+			t.Errorf("ShadowDiff(%s) = '%s', want diverge %v", c.sql, reason, c.diverge)
+		}
+	}
+}
+
+func TestNextToken(t *testing.T) {
+	sql := "select a, 'x' from t /* c */ where b <> ?"
+	want := []string{"SELECT select", "ID a", ", ,", "STRING 'x'", "FROM from", "ID t", "COMMENTS /* c */", "WHERE where", "ID b", "NE <>", "VALUE_ARG ?"}
+	tokenizer := NewStringTokenizer(sql)
+	var got []string
+	for {
+		token, err := tokenizer.NextToken()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, token.Name()+" "+sql[token.Position:token.End])
+	}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("NextToken(%s) = %v, want %v", sql, got, want)
+	}
+	tokenizer = NewStringTokenizer("select 'x")
+	if _, err := tokenizer.NextToken(); err != nil {
+		t.Errorf("NextToken should scan select, error: %v", err)
+	}
+	if token, err := tokenizer.NextToken(); err == nil || token.Kind != LEX_ERROR {
+		t.Errorf("NextToken should fail with unterminated string")
+	}
+}
@@ -1,5 +1,6 @@
-//line yacc.y:2
+// Code generated by goyacc -o yacc.go -v /tmp/yacc.output yacc.y. DO NOT EDIT.
 
+//line yacc.y:2
 // Copyright 2012, Google Inc. All rights reserved.
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
@@ -31,7 +32,11 @@ package sqlparser
 import __yyfmt__ "fmt"
 
 //line yacc.y:28
-import "bytes"
+
+import (
+	"bytes"
+	"strings"
+)
 
 // SetParseTree to build ast.
 func SetParseTree(yylex interface{}, stmt Statement) {
@@ -49,63 +54,145 @@ func ForceEOF(yylex interface{}) {
 }
 
 var (
-	SHARE        = []byte("share")
-	MODE         = []byte("mode")
-	IF_BYTES     = []byte("if")
-	VALUES_BYTES = []byte("values")
+	SHARE              = []byte("share")
+	MODE               = []byte("mode")
+	IF_BYTES           = []byte("if")
+	VALUES_BYTES       = []byte("values")
+	MOD_BYTES          = []byte("mod")
+	TENANT             = []byte("tenant")
+	DATA_BYTES         = []byte("data")
+	ROWS_BYTES         = []byte("rows")
+	AT_BYTES           = []byte("@")
+	USER_BYTES         = []byte("user")
+	PASSWORD_BYTES     = []byte("password")
+	PREFLIGHT_BYTES    = []byte("preflight")
+	AUDIT_BYTES        = []byte("audit")
+	CURRENT_USER_BYTES = []byte("current_user")
+	LOCAL_BYTES        = []byte("local")
+	USERS_BYTES        = []byte("users")
+	SCHEMAS_BYTES      = []byte("schemas")
+	ONLY_BYTES         = []byte("only")
+	WRITE_BYTES        = []byte("write")
+	MIGRATION_BYTES    = []byte("migration")
+	FAILOVER_BYTES     = []byte("failover")
+	DRILL_BYTES        = []byte("drill")
+	STOP_BYTES         = []byte("stop")
+	EXPORT_BYTES       = []byte("export")
+	ROUTING_BYTES      = []byte("routing")
+	ENFORCED_BYTES     = []byte("enforced")
+	GENERATED_BYTES    = []byte("generated")
+	ALWAYS_BYTES       = []byte("always")
+	VIRTUAL_BYTES      = []byte("virtual")
+	STORED_BYTES       = []byte("stored")
+	SAASHARD_BYTES     = []byte("saashard")
+	LAST_BYTES         = []byte("last")
+	ROUTE_BYTES        = []byte("route")
+	RANGE_BYTES        = []byte("range")
+	LIST_BYTES         = []byte("list")
+	LINEAR_BYTES       = []byte("linear")
+	PARTITIONS_BYTES   = []byte("partitions")
+	LESS_BYTES         = []byte("less")
+	THAN_BYTES         = []byte("than")
+	MAXVALUE_BYTES     = []byte("maxvalue")
+	REMOVE_BYTES       = []byte("remove")
+	PARTITIONING_BYTES = []byte("partitioning")
+	AGAINST_BYTES      = []byte("against")
+	LANGUAGE_BYTES     = []byte("language")
+	EXPANSION_BYTES    = []byte("expansion")
+	CAST_BYTES         = []byte("cast")
+	SIGNED_BYTES       = []byte("signed")
+	// units of interval expression, year is keyword.
+	INTERVAL_UNITS = map[string]bool{
+		"microsecond": true, "second": true, "minute": true, "hour": true, "day": true,
+		"week": true, "month": true, "quarter": true,
+		"second_microsecond": true, "minute_microsecond": true, "minute_second": true,
+		"hour_microsecond": true, "hour_second": true, "hour_minute": true,
+		"day_microsecond": true, "day_second": true, "day_minute": true, "day_hour": true, "year_month": true,
+	}
+	// data types those are not keywords, json and spatial types.
+	ID_DATA_TYPES = map[string]bool{
+		"json": true, "geometry": true, "point": true, "linestring": true, "polygon": true,
+		"multipoint": true, "multilinestring": true, "multipolygon": true,
+		"geometrycollection": true, "geomcollection": true,
+	}
 )
 
-//line yacc.y:56
+//line yacc.y:116
 type yySymType struct {
-	yys         int
-	empty       struct{}
-	statement   Statement
-	selStmt     SelectStatement
-	setStmt     SetStatement
-	showStmt    ShowStatement
-	ddlStmt     DDLStatement
-	byt         byte
-	bytes       []byte
-	bytes2      [][]byte
-	str         string
-	boolean     bool
-	selectExprs SelectExprs
-	selectExpr  SelectExpr
-	columns     Columns
-	colName     *ColName
-	tableExprs  TableExprs
-	tableExpr   TableExpr
-	smTableExpr SimpleTableExpr
-	tableName   *TableName
-	indexHints  *IndexHints
-	expr        Expr
-	boolExpr    BoolExpr
-	valExpr     ValExpr
-	tuple       Tuple
-	valExprs    ValExprs
-	values      Values
-	subquery    *Subquery
-	caseExpr    *CaseExpr
-	funcExpr    *FuncExpr
-	whens       []*When
-	when        *When
-	orderBy     OrderBy
-	order       *Order
-	limit       *Limit
-	insRows     InsertRows
-	updateExprs UpdateExprs
-	updateExpr  *UpdateExpr
-	createDef   CreateDefinition
-	createDefs  CreateDefinitions
-	columnDef   *ColumnDefinition
-	dataType    *DataType
-	idxColName  *IndexColName
-	idxColNames IndexColNames
-	optKeyVal   *OptionKeyValue
-	optKeyVals  OptionKeyValues
-	fiOAfCol    *FirstOrAfterColumn
-	alterSpecs  AlterSpecifications
-	alterSpec   AlterSpecification
+	yys              int
+	empty            struct{}
+	statement        Statement
+	selStmt          SelectStatement
+	setStmt          SetStatement
+	showStmt         ShowStatement
+	ddlStmt          DDLStatement
+	byt              byte
+	bytes            []byte
+	bytes2           [][]byte
+	str              string
+	boolean          bool
+	selectExprs      SelectExprs
+	selectExpr       SelectExpr
+	columns          Columns
+	colName          *ColName
+	tableExprs       TableExprs
+	tableExpr        TableExpr
+	smTableExpr      SimpleTableExpr
+	tableName        *TableName
+	tableNames       TableNames
+	indexHints       *IndexHints
+	expr             Expr
+	boolExpr         BoolExpr
+	valExpr          ValExpr
+	tuple            Tuple
+	valExprs         ValExprs
+	values           Values
+	subquery         *Subquery
+	caseExpr         *CaseExpr
+	matchExpr        *MatchExpr
+	funcExpr         *FuncExpr
+	whens            []*When
+	when             *When
+	orderBy          OrderBy
+	order            *Order
+	limit            *Limit
+	insRows          InsertRows
+	updateExprs      UpdateExprs
+	updateExpr       *UpdateExpr
+	setExprs         SetExprs
+	setExpr          *SetExpr
+	proxyUserOptions *ProxyUserOptions
+	createDef        CreateDefinition
+	createDefs       CreateDefinitions
+	columnDef        *ColumnDefinition
+	dataType         *DataType
+	idxColName       *IndexColName
+	idxColNames      IndexColNames
+	optKeyVal        *OptionKeyValue
+	optKeyVals       OptionKeyValues
+	fiOAfCol         *FirstOrAfterColumn
+	alterSpecs       AlterSpecifications
+	alterSpec        AlterSpecification
+	cte              *CommonTableExpr
+	ctes             []*CommonTableExpr
+	loadFields       *LoadFields
+	loadLines        *LoadLines
+	privilege        *Privilege
+	privileges       Privileges
+	privLevel        *PrivilegeLevel
+	account          *Account
+	accounts         Accounts
+	userSpec         *UserSpec
+	userSpecs        UserSpecs
+	authOption       *AuthOption
+	requireOpts      RequireOptions
+	requireOpt       *RequireOption
+	tableLock        *TableLock
+	checkConstraint  *CheckConstraint
+	partitionOpts    *PartitionOptions
+	partitionDef     *PartitionDefinition
+	partitionDefs    []*PartitionDefinition
+	tableLocks       []*TableLock
 }
 
 const LEX_ERROR = 57346
@@ -143,286 +230,341 @@ const STRING = 57377
 const NUMBER = 57378
 const VALUE_ARG = 57379
 const COMMENTS = 57380
-const UNION = 57381
-const MINUS = 57382
-const EXCEPT = 57383
-const INTERSECT = 57384
-const FULL = 57385
-const JOIN = 57386
-const STRAIGHT_JOIN = 57387
-const LEFT = 57388
-const RIGHT = 57389
-const INNER = 57390
-const OUTER = 57391
-const CROSS = 57392
-const NATURAL = 57393
-const USE = 57394
-const FORCE = 57395
-const ON = 57396
-const OR = 57397
-const AND = 57398
-const NOT = 57399
-const BETWEEN = 57400
-const CASE = 57401
-const WHEN = 57402
-const THEN = 57403
-const ELSE = 57404
-const LE = 57405
-const GE = 57406
-const NE = 57407
-const NULL_SAFE_EQUAL = 57408
-const IS = 57409
-const LIKE = 57410
-const IN = 57411
-const UNARY = 57412
-const END = 57413
-const BEGIN = 57414
-const START = 57415
-const TRANSACTION = 57416
-const COMMIT = 57417
-const ROLLBACK = 57418
-const ISOLATION = 57419
-const LEVEL = 57420
-const READ = 57421
-const COMMITTED = 57422
-const UNCOMMITTED = 57423
-const REPEATABLE = 57424
-const SERIALIZABLE = 57425
-const NAMES = 57426
-const CHARSET = 57427
-const CHARACTER = 57428
-const COLLATION = 57429
-const ARMSCII8 = 57430
-const ASCII = 57431
-const BIG5 = 57432
-const BINARY = 57433
-const CP1250 = 57434
-const CP1251 = 57435
-const CP1256 = 57436
-const CP1257 = 57437
-const CP850 = 57438
-const CP852 = 57439
-const CP866 = 57440
-const CP932 = 57441
-const DEC8 = 57442
-const EUCJPMS = 57443
-const EUCKR = 57444
-const GB2312 = 57445
-const GBK = 57446
-const GEOSTD8 = 57447
-const GREEK = 57448
-const HEBREW = 57449
-const HP8 = 57450
-const KEYBCS2 = 57451
-const KOI8R = 57452
-const KOI8U = 57453
-const LATIN1 = 57454
-const LATIN2 = 57455
-const LATIN5 = 57456
-const LATIN7 = 57457
-const MACCE = 57458
-const MACROMAN = 57459
-const SJIS = 57460
-const SWE7 = 57461
-const TIS620 = 57462
-const UCS2 = 57463
-const UJIS = 57464
-const UTF16 = 57465
-const UTF16LE = 57466
-const UTF32 = 57467
-const UTF8 = 57468
-const UTF8MB4 = 57469
-const ARMSCII8_GENERAL_CI = 57470
-const ARMSCII8_BIN = 57471
-const ASCII_GENERAL_CI = 57472
-const ASCII_BIN = 57473
-const BIG5_CHINESE_CI = 57474
-const BIG5_BIN = 57475
-const CP1250_GENERAL_CI = 57476
-const CP1250_BIN = 57477
-const CP1251_GENERAL_CI = 57478
-const CP1251_GENERAL_CS = 57479
-const CP1251_BIN = 57480
-const CP1256_GENERAL_CI = 57481
-const CP1256_BIN = 57482
-const CP1257_GENERAL_CI = 57483
-const CP1257_BIN = 57484
-const CP850_GENERAL_CI = 57485
-const CP850_BIN = 57486
-const CP852_GENERAL_CI = 57487
-const CP852_BIN = 57488
-const CP866_GENERAL_CI = 57489
-const CP866_BIN = 57490
-const CP932_JAPANESE_CI = 57491
-const CP932_BIN = 57492
-const DEC8_SWEDISH_CI = 57493
-const DEC8_BIN = 57494
-const EUCJPMS_JAPANESE_CI = 57495
-const EUCJPMS_BIN = 57496
-const EUCKR_KOREAN_CI = 57497
-const EUCKR_BIN = 57498
-const GB2312_CHINESE_CI = 57499
-const GB2312_BIN = 57500
-const GBK_CHINESE_CI = 57501
-const GBK_BIN = 57502
-const GEOSTD8_GENERAL_CI = 57503
-const GEOSTD8_BIN = 57504
-const GREEK_GENERAL_CI = 57505
-const GREEK_BIN = 57506
-const HEBREW_GENERAL_CI = 57507
-const HEBREW_BIN = 57508
-const HP8_ENGLISH_CI = 57509
-const HP8_BIN = 57510
-const KEYBCS2_GENERAL_CI = 57511
-const KEYBCS2_BIN = 57512
-const KOI8R_GENERAL_CI = 57513
-const KOI8R_BIN = 57514
-const KOI8U_GENERAL_CI = 57515
-const KOI8U_BIN = 57516
-const LATIN1_GENERAL_CI = 57517
-const LATIN1_GENERAL_CS = 57518
-const LATIN1_BIN = 57519
-const LATIN2_GENERAL_CI = 57520
-const LATIN2_BIN = 57521
-const LATIN5_TURKISH_CI = 57522
-const LATIN5_BIN = 57523
-const LATIN7_GENERAL_CI = 57524
-const LATIN7_GENERAL_CS = 57525
-const LATIN7_BIN = 57526
-const MACCE_GENERAL_CI = 57527
-const MACCE_BIN = 57528
-const MACROMAN_GENERAL_CI = 57529
-const MACROMAN_BIN = 57530
-const SJIS_JAPANESE_CI = 57531
-const SJIS_BIN = 57532
-const SWE7_SWEDISH_CI = 57533
-const SWE7_BIN = 57534
-const TIS620_THAI_CI = 57535
-const TIS620_BIN = 57536
-const UCS2_GENERAL_CI = 57537
-const UCS2_UNICODE_CI = 57538
-const UCS2_BIN = 57539
-const UJIS_JAPANESE_CI = 57540
-const UJIS_BIN = 57541
-const UTF16_GENERAL_CI = 57542
-const UTF16_UNICODE_CI = 57543
-const UTF16_BIN = 57544
-const UTF16LE_GENERAL_CI = 57545
-const UTF16LE_BIN = 57546
-const UTF32_GENERAL_CI = 57547
-const UTF32_UNICODE_CI = 57548
-const UTF32_BIN = 57549
-const UTF8_GENERAL_CI = 57550
-const UTF8_UNICODE_CI = 57551
-const UTF8_BIN = 57552
-const UTF8MB4_GENERAL_CI = 57553
-const UTF8MB4_UNICODE_CI = 57554
-const UTF8MB4_BIN = 57555
-const SESSION = 57556
-const GLOBAL = 57557
-const VARIABLES = 57558
-const STATUS = 57559
-const DATABASES = 57560
-const SCHEMAS = 57561
-const DATABASE = 57562
-const STORAGE = 57563
-const ENGINES = 57564
-const TABLES = 57565
-const COLUMNS = 57566
-const FIELDS = 57567
-const PROCEDURE = 57568
-const FUNCTION = 57569
-const INDEXES = 57570
-const KEYS = 57571
-const TRIGGER = 57572
-const TRIGGERS = 57573
-const PLUGINS = 57574
-const PROCESSLIST = 57575
-const SLAVE = 57576
-const PROFILES = 57577
-const REPLACE = 57578
-const OFFSET = 57579
-const COLLATE = 57580
-const CREATE = 57581
-const ALTER = 57582
-const DROP = 57583
-const RENAME = 57584
-const TABLE = 57585
-const INDEX = 57586
-const VIEW = 57587
-const TO = 57588
-const IGNORE = 57589
-const IF = 57590
-const UNIQUE = 57591
-const FULLTEXT = 57592
-const USING = 57593
-const BTREE = 57594
-const HASH = 57595
-const BIT = 57596
-const TINYINT = 57597
-const BOOL = 57598
-const BOOLEAN = 57599
-const SMALLINT = 57600
-const MEDIUMINT = 57601
-const INT = 57602
-const INTEGER = 57603
-const BIGINT = 57604
-const REAL = 57605
-const DOUBLE = 57606
-const FLOAT = 57607
-const DECIMAL = 57608
-const DATE = 57609
-const TIME = 57610
-const TIMESTAMP = 57611
-const DATETIME = 57612
-const YEAR = 57613
-const CHAR = 57614
-const NCHAR = 57615
-const VARCHAR = 57616
-const NVARCHAR = 57617
-const TINYTEXT = 57618
-const TEXT = 57619
-const MEDIUMTEXT = 57620
-const LONGTEXT = 57621
-const VARBINARY = 57622
-const TINYBLOB = 57623
-const BLOB = 57624
-const MEDIUMBLOB = 57625
-const LONGBLOB = 57626
-const ENUM = 57627
-const AUTO_INCREMENT = 57628
-const ENGINE = 57629
-const PRIMARY = 57630
-const REFERENCES = 57631
-const COMMENT = 57632
-const COLUMN_FORMAT = 57633
-const FIXED = 57634
-const DYNAMIC = 57635
-const DISK = 57636
-const MEMORY = 57637
-const MATCH = 57638
-const PARTIAL = 57639
-const SIMPLE = 57640
-const RESTRICT = 57641
-const CASCADE = 57642
-const NO = 57643
-const ACTION = 57644
-const UNSIGNED = 57645
-const ZEROFILL = 57646
-const CONSTRAINT = 57647
-const FOREIGN = 57648
-const FIRST = 57649
-const AFTER = 57650
-const ADD = 57651
-const COLUMN = 57652
-const CHANGE = 57653
-const MODIFY = 57654
-const ENABLE = 57655
-const DISABLE = 57656
-const KILL = 57657
-const QUERY = 57658
-const CONNECTION = 57659
-const POSITION = 57660
+const INTRODUCER = 57381
+const HEX_LITERAL = 57382
+const BIT_LITERAL = 57383
+const NCHAR_STRING = 57384
+const WITH = 57385
+const UNION = 57386
+const MINUS = 57387
+const EXCEPT = 57388
+const INTERSECT = 57389
+const LOWER_THAN_COMMA = 57390
+const FULL = 57391
+const JOIN = 57392
+const STRAIGHT_JOIN = 57393
+const LEFT = 57394
+const RIGHT = 57395
+const INNER = 57396
+const OUTER = 57397
+const CROSS = 57398
+const NATURAL = 57399
+const USE = 57400
+const FORCE = 57401
+const ON = 57402
+const ASSIGN = 57403
+const OR = 57404
+const XOR = 57405
+const AND = 57406
+const NOT = 57407
+const BETWEEN = 57408
+const CASE = 57409
+const WHEN = 57410
+const THEN = 57411
+const ELSE = 57412
+const LE = 57413
+const GE = 57414
+const NE = 57415
+const NULL_SAFE_EQUAL = 57416
+const IS = 57417
+const LIKE = 57418
+const IN = 57419
+const REGEXP = 57420
+const DIV = 57421
+const MOD = 57422
+const PIPE_CONCAT = 57423
+const COLLATE = 57424
+const UNARY = 57425
+const END = 57426
+const INTERVAL = 57427
+const CONVERT = 57428
+const UNLOCK = 57429
+const SAVEPOINT = 57430
+const RELEASE = 57431
+const BEGIN = 57432
+const START = 57433
+const TRANSACTION = 57434
+const COMMIT = 57435
+const ROLLBACK = 57436
+const ISOLATION = 57437
+const LEVEL = 57438
+const READ = 57439
+const COMMITTED = 57440
+const UNCOMMITTED = 57441
+const REPEATABLE = 57442
+const SERIALIZABLE = 57443
+const NAMES = 57444
+const CHARSET = 57445
+const CHARACTER = 57446
+const COLLATION = 57447
+const ARMSCII8 = 57448
+const ASCII = 57449
+const BIG5 = 57450
+const BINARY = 57451
+const CP1250 = 57452
+const CP1251 = 57453
+const CP1256 = 57454
+const CP1257 = 57455
+const CP850 = 57456
+const CP852 = 57457
+const CP866 = 57458
+const CP932 = 57459
+const DEC8 = 57460
+const EUCJPMS = 57461
+const EUCKR = 57462
+const GB2312 = 57463
+const GBK = 57464
+const GEOSTD8 = 57465
+const GREEK = 57466
+const HEBREW = 57467
+const HP8 = 57468
+const KEYBCS2 = 57469
+const KOI8R = 57470
+const KOI8U = 57471
+const LATIN1 = 57472
+const LATIN2 = 57473
+const LATIN5 = 57474
+const LATIN7 = 57475
+const MACCE = 57476
+const MACROMAN = 57477
+const SJIS = 57478
+const SWE7 = 57479
+const TIS620 = 57480
+const UCS2 = 57481
+const UJIS = 57482
+const UTF16 = 57483
+const UTF16LE = 57484
+const UTF32 = 57485
+const UTF8 = 57486
+const UTF8MB4 = 57487
+const ARMSCII8_GENERAL_CI = 57488
+const ARMSCII8_BIN = 57489
+const ASCII_GENERAL_CI = 57490
+const ASCII_BIN = 57491
+const BIG5_CHINESE_CI = 57492
+const BIG5_BIN = 57493
+const CP1250_GENERAL_CI = 57494
+const CP1250_BIN = 57495
+const CP1251_GENERAL_CI = 57496
+const CP1251_GENERAL_CS = 57497
+const CP1251_BIN = 57498
+const CP1256_GENERAL_CI = 57499
+const CP1256_BIN = 57500
+const CP1257_GENERAL_CI = 57501
+const CP1257_BIN = 57502
+const CP850_GENERAL_CI = 57503
+const CP850_BIN = 57504
+const CP852_GENERAL_CI = 57505
+const CP852_BIN = 57506
+const CP866_GENERAL_CI = 57507
+const CP866_BIN = 57508
+const CP932_JAPANESE_CI = 57509
+const CP932_BIN = 57510
+const DEC8_SWEDISH_CI = 57511
+const DEC8_BIN = 57512
+const EUCJPMS_JAPANESE_CI = 57513
+const EUCJPMS_BIN = 57514
+const EUCKR_KOREAN_CI = 57515
+const EUCKR_BIN = 57516
+const GB2312_CHINESE_CI = 57517
+const GB2312_BIN = 57518
+const GBK_CHINESE_CI = 57519
+const GBK_BIN = 57520
+const GEOSTD8_GENERAL_CI = 57521
+const GEOSTD8_BIN = 57522
+const GREEK_GENERAL_CI = 57523
+const GREEK_BIN = 57524
+const HEBREW_GENERAL_CI = 57525
+const HEBREW_BIN = 57526
+const HP8_ENGLISH_CI = 57527
+const HP8_BIN = 57528
+const KEYBCS2_GENERAL_CI = 57529
+const KEYBCS2_BIN = 57530
+const KOI8R_GENERAL_CI = 57531
+const KOI8R_BIN = 57532
+const KOI8U_GENERAL_CI = 57533
+const KOI8U_BIN = 57534
+const LATIN1_GENERAL_CI = 57535
+const LATIN1_GENERAL_CS = 57536
+const LATIN1_BIN = 57537
+const LATIN2_GENERAL_CI = 57538
+const LATIN2_BIN = 57539
+const LATIN5_TURKISH_CI = 57540
+const LATIN5_BIN = 57541
+const LATIN7_GENERAL_CI = 57542
+const LATIN7_GENERAL_CS = 57543
+const LATIN7_BIN = 57544
+const MACCE_GENERAL_CI = 57545
+const MACCE_BIN = 57546
+const MACROMAN_GENERAL_CI = 57547
+const MACROMAN_BIN = 57548
+const SJIS_JAPANESE_CI = 57549
+const SJIS_BIN = 57550
+const SWE7_SWEDISH_CI = 57551
+const SWE7_BIN = 57552
+const TIS620_THAI_CI = 57553
+const TIS620_BIN = 57554
+const UCS2_GENERAL_CI = 57555
+const UCS2_UNICODE_CI = 57556
+const UCS2_BIN = 57557
+const UJIS_JAPANESE_CI = 57558
+const UJIS_BIN = 57559
+const UTF16_GENERAL_CI = 57560
+const UTF16_UNICODE_CI = 57561
+const UTF16_BIN = 57562
+const UTF16LE_GENERAL_CI = 57563
+const UTF16LE_BIN = 57564
+const UTF32_GENERAL_CI = 57565
+const UTF32_UNICODE_CI = 57566
+const UTF32_BIN = 57567
+const UTF8_GENERAL_CI = 57568
+const UTF8_UNICODE_CI = 57569
+const UTF8_BIN = 57570
+const UTF8MB4_GENERAL_CI = 57571
+const UTF8MB4_UNICODE_CI = 57572
+const UTF8MB4_BIN = 57573
+const UTF8MB4_0900_AI_CI = 57574
+const UTF8MB4_0900_BIN = 57575
+const SESSION = 57576
+const GLOBAL = 57577
+const VARIABLES = 57578
+const STATUS = 57579
+const DATABASES = 57580
+const SCHEMAS = 57581
+const DATABASE = 57582
+const STORAGE = 57583
+const ENGINES = 57584
+const TABLES = 57585
+const COLUMNS = 57586
+const FIELDS = 57587
+const PROCEDURE = 57588
+const FUNCTION = 57589
+const INDEXES = 57590
+const KEYS = 57591
+const TRIGGER = 57592
+const TRIGGERS = 57593
+const PLUGINS = 57594
+const PROCESSLIST = 57595
+const SLAVE = 57596
+const PROFILES = 57597
+const GRANTS = 57598
+const WARNINGS = 57599
+const ERRORS = 57600
+const REPLACE = 57601
+const CALL = 57602
+const PREPARE = 57603
+const EXECUTE = 57604
+const DEALLOCATE = 57605
+const GRANT = 57606
+const REVOKE = 57607
+const OPTION = 57608
+const IDENTIFIED = 57609
+const REQUIRE = 57610
+const LOAD = 57611
+const INFILE = 57612
+const LOW_PRIORITY = 57613
+const LINES = 57614
+const STARTING = 57615
+const TERMINATED = 57616
+const OPTIONALLY = 57617
+const ENCLOSED = 57618
+const ESCAPED = 57619
+const OFFSET = 57620
+const SEPARATOR = 57621
+const RECURSIVE = 57622
+const OVER = 57623
+const PARTITION = 57624
+const JSON_EXTRACT_OP = 57625
+const JSON_UNQUOTE_EXTRACT_OP = 57626
+const CREATE = 57627
+const ALTER = 57628
+const DROP = 57629
+const RENAME = 57630
+const TRUNCATE = 57631
+const TABLE = 57632
+const INDEX = 57633
+const VIEW = 57634
+const TO = 57635
+const IGNORE = 57636
+const IF = 57637
+const UNIQUE = 57638
+const FULLTEXT = 57639
+const USING = 57640
+const BTREE = 57641
+const HASH = 57642
+const ALGORITHM = 57643
+const BIT = 57644
+const TINYINT = 57645
+const BOOL = 57646
+const BOOLEAN = 57647
+const SMALLINT = 57648
+const MEDIUMINT = 57649
+const INT = 57650
+const INTEGER = 57651
+const BIGINT = 57652
+const REAL = 57653
+const DOUBLE = 57654
+const FLOAT = 57655
+const DECIMAL = 57656
+const DATE = 57657
+const TIME = 57658
+const TIMESTAMP = 57659
+const DATETIME = 57660
+const YEAR = 57661
+const CHAR = 57662
+const NCHAR = 57663
+const VARCHAR = 57664
+const NVARCHAR = 57665
+const TINYTEXT = 57666
+const TEXT = 57667
+const MEDIUMTEXT = 57668
+const LONGTEXT = 57669
+const VARBINARY = 57670
+const TINYBLOB = 57671
+const BLOB = 57672
+const MEDIUMBLOB = 57673
+const LONGBLOB = 57674
+const ENUM = 57675
+const AUTO_INCREMENT = 57676
+const ENGINE = 57677
+const PRIMARY = 57678
+const REFERENCES = 57679
+const COMMENT = 57680
+const COLUMN_FORMAT = 57681
+const FIXED = 57682
+const DYNAMIC = 57683
+const DISK = 57684
+const MEMORY = 57685
+const MATCH = 57686
+const PARTIAL = 57687
+const SIMPLE = 57688
+const RESTRICT = 57689
+const CASCADE = 57690
+const NO = 57691
+const ACTION = 57692
+const UNSIGNED = 57693
+const ZEROFILL = 57694
+const CONSTRAINT = 57695
+const FOREIGN = 57696
+const FIRST = 57697
+const AFTER = 57698
+const ADD = 57699
+const COLUMN = 57700
+const CHANGE = 57701
+const MODIFY = 57702
+const ENABLE = 57703
+const DISABLE = 57704
+const KILL = 57705
+const QUERY = 57706
+const CONNECTION = 57707
+const RELOAD = 57708
+const CLONE = 57709
+const PROXY = 57710
+const ANALYZE = 57711
+const OPTIMIZE = 57712
+const CHECK = 57713
+const REPAIR = 57714
+const POSITION = 57715
 
 var yyToknames = [...]string{
 	"$end",
@@ -463,12 +605,18 @@ var yyToknames = [...]string{
 	"NUMBER",
 	"VALUE_ARG",
 	"COMMENTS",
+	"INTRODUCER",
+	"HEX_LITERAL",
+	"BIT_LITERAL",
+	"NCHAR_STRING",
 	"'('",
 	"'~'",
+	"WITH",
 	"UNION",
 	"MINUS",
 	"EXCEPT",
 	"INTERSECT",
+	"LOWER_THAN_COMMA",
 	"','",
 	"FULL",
 	"JOIN",
@@ -482,7 +630,9 @@ var yyToknames = [...]string{
 	"USE",
 	"FORCE",
 	"ON",
+	"ASSIGN",
 	"OR",
+	"XOR",
 	"AND",
 	"NOT",
 	"BETWEEN",
@@ -500,6 +650,7 @@ var yyToknames = [...]string{
 	"IS",
 	"LIKE",
 	"IN",
+	"REGEXP",
 	"'|'",
 	"'&'",
 	"'+'",
@@ -507,10 +658,19 @@ var yyToknames = [...]string{
 	"'*'",
 	"'/'",
 	"'%'",
+	"DIV",
+	"MOD",
 	"'^'",
+	"PIPE_CONCAT",
+	"COLLATE",
 	"'.'",
 	"UNARY",
 	"END",
+	"INTERVAL",
+	"CONVERT",
+	"UNLOCK",
+	"SAVEPOINT",
+	"RELEASE",
 	"BEGIN",
 	"START",
 	"TRANSACTION",
@@ -653,6 +813,8 @@ var yyToknames = [...]string{
 	"UTF8MB4_GENERAL_CI",
 	"UTF8MB4_UNICODE_CI",
 	"UTF8MB4_BIN",
+	"UTF8MB4_0900_AI_CI",
+	"UTF8MB4_0900_BIN",
 	"SESSION",
 	"GLOBAL",
 	"VARIABLES",
@@ -675,13 +837,40 @@ var yyToknames = [...]string{
 	"PROCESSLIST",
 	"SLAVE",
 	"PROFILES",
+	"GRANTS",
+	"WARNINGS",
+	"ERRORS",
 	"REPLACE",
+	"CALL",
+	"PREPARE",
+	"EXECUTE",
+	"DEALLOCATE",
+	"GRANT",
+	"REVOKE",
+	"OPTION",
+	"IDENTIFIED",
+	"REQUIRE",
+	"LOAD",
+	"INFILE",
+	"LOW_PRIORITY",
+	"LINES",
+	"STARTING",
+	"TERMINATED",
+	"OPTIONALLY",
+	"ENCLOSED",
+	"ESCAPED",
 	"OFFSET",
-	"COLLATE",
+	"SEPARATOR",
+	"RECURSIVE",
+	"OVER",
+	"PARTITION",
+	"JSON_EXTRACT_OP",
+	"JSON_UNQUOTE_EXTRACT_OP",
 	"CREATE",
 	"ALTER",
 	"DROP",
 	"RENAME",
+	"TRUNCATE",
 	"TABLE",
 	"INDEX",
 	"VIEW",
@@ -693,6 +882,7 @@ var yyToknames = [...]string{
 	"USING",
 	"BTREE",
 	"HASH",
+	"ALGORITHM",
 	"BIT",
 	"TINYINT",
 	"BOOL",
@@ -757,9 +947,17 @@ var yyToknames = [...]string{
 	"KILL",
 	"QUERY",
 	"CONNECTION",
+	"RELOAD",
+	"CLONE",
+	"PROXY",
+	"ANALYZE",
+	"OPTIMIZE",
+	"CHECK",
+	"REPAIR",
 	"POSITION",
 	"')'",
 }
+
 var yyStatenames = [...]string{}
 
 const yyEofCode = 1
@@ -767,405 +965,960 @@ const yyErrCode = 2
 const yyInitialStackSize = 16
 
 //line yacctab:1
-var yyExca = [...]int{
+var yyExca = [...]int16{
 	-1, 1,
 	1, -1,
 	-2, 0,
+	-1, 1118,
+	19, 721,
+	-2, 781,
+	-1, 1686,
+	386, 826,
+	-2, 707,
+	-1, 1728,
+	386, 826,
+	-2, 707,
+	-1, 1730,
+	386, 826,
+	-2, 707,
+	-1, 1754,
+	386, 826,
+	-2, 707,
+	-1, 1756,
+	386, 826,
+	-2, 707,
+	-1, 1769,
+	386, 826,
+	-2, 707,
+	-1, 1774,
+	386, 826,
+	-2, 707,
 }
 
-const yyNprod = 560
 const yyPrivate = 57344
 
-var yyTokenNames []string
-var yyStates []string
-
-const yyLast = 1640
+const yyLast = 5630
 
-var yyAct = [...]int{
-
-	153, 446, 1063, 424, 885, 170, 1064, 142, 1024, 740,
-	747, 837, 902, 874, 637, 256, 826, 748, 436, 749,
-	163, 976, 887, 567, 290, 499, 351, 143, 573, 930,
-	884, 779, 429, 144, 154, 412, 428, 562, 501, 337,
-	77, 415, 354, 137, 392, 907, 291, 3, 261, 339,
-	42, 43, 44, 45, 1056, 955, 116, 955, 116, 265,
-	264, 273, 272, 275, 276, 277, 278, 279, 274, 455,
-	456, 457, 458, 459, 423, 460, 461, 60, 955, 1043,
-	1041, 955, 955, 955, 955, 955, 955, 388, 83, 167,
-	152, 755, 955, 162, 1040, 955, 1039, 211, 115, 939,
-	119, 938, 955, 168, 149, 150, 151, 955, 294, 157,
-	937, 955, 936, 935, 933, 955, 166, 972, 972, 972,
-	439, 116, 116, 955, 929, 944, 944, 928, 116, 927,
-	255, 160, 921, 920, 926, 572, 919, 262, 382, 918,
-	148, 152, 917, 916, 162, 915, 497, 155, 156, 386,
-	472, 515, 514, 861, 135, 149, 150, 151, 621, 141,
-	157, 889, 890, 246, 247, 610, 80, 838, 382, 382,
-	252, 382, 757, 512, 533, 306, 1102, 977, 908, 1055,
-	140, 750, 160, 295, 287, 289, 425, 449, 506, 507,
-	254, 775, 441, 440, 249, 122, 773, 620, 155, 156,
-	134, 124, 125, 771, 609, 165, 769, 767, 765, 118,
-	346, 763, 761, 734, 759, 622, 756, 751, 1067, 733,
-	519, 732, 611, 116, 753, 250, 828, 251, 753, 116,
-	116, 128, 129, 116, 116, 116, 116, 116, 116, 116,
-	116, 116, 116, 1105, 334, 116, 167, 127, 931, 116,
-	307, 344, 116, 439, 116, 522, 752, 521, 116, 310,
-	503, 359, 114, 133, 360, 313, 336, 168, 1025, 781,
-	1028, 320, 321, 166, 751, 324, 325, 326, 327, 328,
-	329, 330, 331, 332, 333, 217, 218, 335, 52, 51,
-	242, 342, 355, 231, 345, 230, 347, 227, 395, 53,
-	561, 364, 54, 167, 79, 862, 359, 303, 361, 362,
-	76, 526, 525, 752, 546, 548, 116, 116, 116, 736,
-	116, 556, 387, 263, 390, 441, 440, 305, 168, 260,
-	166, 78, 161, 213, 243, 380, 353, 274, 78, 559,
-	560, 167, 167, 383, 1100, 442, 1086, 116, 433, 1022,
-	116, 813, 635, 262, 116, 79, 549, 634, 399, 400,
-	401, 418, 402, 393, 405, 406, 407, 1085, 166, 420,
-	1082, 1081, 1058, 1057, 1051, 1050, 411, 223, 224, 225,
-	463, 1020, 414, 161, 1015, 409, 417, 226, 355, 444,
-	452, 1014, 451, 438, 437, 78, 1009, 443, 633, 466,
-	1008, 462, 158, 385, 1007, 511, 974, 973, 971, 783,
-	22, 167, 954, 474, 946, 945, 430, 531, 431, 432,
-	435, 434, 167, 925, 571, 148, 152, 554, 492, 162,
-	216, 490, 219, 220, 221, 496, 478, 480, 166, 168,
-	149, 150, 151, 530, 141, 157, 529, 491, 509, 500,
-	495, 489, 518, 158, 116, 116, 417, 476, 473, 608,
-	381, 82, 81, 827, 527, 140, 504, 160, 79, 513,
-	757, 510, 447, 448, 450, 757, 781, 524, 442, 523,
-	536, 537, 757, 155, 156, 757, 757, 757, 355, 355,
-	757, 757, 502, 757, 304, 757, 517, 750, 167, 1065,
-	1066, 750, 829, 612, 613, 614, 116, 565, 394, 1106,
-	1107, 167, 618, 619, 520, 167, 167, 167, 516, 627,
-	628, 547, 356, 117, 630, 570, 438, 437, 564, 79,
-	443, 78, 79, 808, 797, 636, 597, 343, 617, 79,
-	78, 78, 623, 624, 625, 616, 308, 168, 615, 1026,
-	1027, 311, 312, 504, 750, 264, 236, 314, 22, 215,
-	780, 318, 239, 240, 322, 323, 241, 91, 90, 89,
-	265, 264, 367, 167, 148, 152, 558, 358, 162, 237,
-	482, 238, 370, 483, 484, 366, 365, 78, 168, 149,
-	150, 151, 353, 141, 157, 1113, 79, 126, 746, 393,
-	500, 479, 164, 743, 745, 275, 276, 277, 278, 279,
-	274, 795, 1112, 302, 140, 1104, 160, 277, 278, 279,
-	274, 371, 806, 214, 167, 563, 782, 781, 265, 264,
-	812, 563, 155, 156, 505, 788, 789, 790, 791, 814,
-	79, 816, 349, 540, 88, 538, 802, 398, 541, 815,
-	539, 810, 731, 811, 403, 404, 455, 456, 457, 458,
-	459, 408, 460, 461, 730, 544, 319, 215, 161, 543,
-	542, 758, 760, 762, 764, 766, 768, 770, 772, 774,
-	272, 275, 276, 277, 278, 279, 274, 799, 800, 315,
-	215, 222, 215, 803, 804, 97, 21, 92, 93, 575,
-	576, 577, 578, 579, 580, 581, 582, 583, 584, 585,
-	586, 587, 588, 589, 590, 591, 592, 593, 594, 595,
-	596, 603, 604, 605, 606, 598, 599, 600, 601, 602,
-	607, 214, 79, 485, 486, 487, 488, 302, 158, 924,
-	923, 79, 79, 922, 338, 87, 338, 382, 79, 42,
-	43, 44, 45, 819, 214, 739, 214, 569, 508, 830,
-	832, 835, 817, 825, 509, 807, 796, 833, 831, 257,
-	840, 818, 842, 820, 844, 259, 846, 410, 848, 453,
-	850, 302, 852, 1096, 854, 340, 856, 341, 79, 79,
-	1019, 1018, 1006, 741, 742, 341, 1005, 879, 880, 152,
-	875, 875, 167, 963, 962, 258, 948, 876, 895, 896,
-	957, 947, 897, 149, 150, 151, 892, 161, 891, 900,
-	883, 882, 903, 903, 903, 881, 823, 822, 464, 886,
-	821, 801, 793, 901, 899, 904, 905, 792, 787, 898,
-	786, 785, 912, 784, 914, 778, 777, 911, 776, 913,
-	754, 294, 421, 299, 552, 298, 297, 296, 46, 273,
-	272, 275, 276, 277, 278, 279, 274, 1013, 993, 877,
-	878, 273, 272, 275, 276, 277, 278, 279, 274, 991,
-	893, 894, 167, 167, 167, 990, 989, 158, 869, 868,
-	167, 167, 167, 167, 934, 867, 956, 866, 167, 288,
-	940, 941, 942, 943, 865, 863, 860, 167, 859, 886,
-	886, 886, 951, 952, 953, 858, 857, 958, 959, 886,
-	886, 967, 960, 961, 855, 886, 853, 975, 966, 980,
-	851, 982, 849, 847, 166, 845, 864, 843, 995, 841,
-	839, 836, 870, 871, 872, 873, 994, 631, 167, 167,
-	131, 130, 1000, 978, 949, 950, 167, 979, 1011, 981,
-	1012, 725, 397, 167, 167, 996, 969, 997, 998, 999,
-	964, 965, 932, 1023, 10, 886, 886, 632, 1003, 1004,
-	970, 528, 245, 886, 9, 138, 212, 8, 169, 910,
-	886, 886, 909, 1016, 1017, 1033, 1034, 1035, 1036, 1037,
-	1038, 1029, 824, 1031, 1042, 63, 167, 167, 983, 984,
-	985, 986, 987, 988, 1054, 64, 809, 992, 62, 167,
-	167, 805, 7, 1062, 15, 798, 1030, 1061, 1032, 794,
-	14, 1048, 1049, 886, 886, 465, 1052, 1053, 1068, 13,
-	1070, 292, 629, 626, 738, 293, 886, 886, 12, 1059,
-	1060, 6, 1069, 61, 1071, 71, 116, 244, 5, 4,
-	301, 70, 22, 1087, 121, 834, 1084, 741, 742, 532,
-	69, 1088, 22, 1090, 1076, 1077, 1078, 1079, 1089, 68,
-	1091, 416, 67, 470, 1092, 1093, 1094, 1095, 152, 66,
-	65, 162, 1097, 422, 1098, 348, 1080, 167, 1083, 1001,
-	1002, 168, 149, 150, 151, 86, 294, 157, 84, 259,
-	1110, 1111, 728, 493, 309, 413, 1116, 1117, 727, 535,
-	338, 1109, 1108, 1115, 886, 317, 101, 1099, 316, 160,
-	235, 1072, 1073, 1074, 234, 1075, 22, 26, 27, 28,
-	48, 22, 233, 232, 229, 155, 156, 228, 120, 1114,
-	1044, 1045, 1046, 1047, 741, 742, 1021, 906, 138, 357,
-	23, 888, 24, 30, 25, 744, 363, 574, 426, 368,
-	369, 427, 372, 373, 374, 375, 376, 377, 378, 379,
-	95, 94, 96, 498, 445, 1103, 39, 152, 1101, 481,
-	162, 1010, 123, 248, 384, 253, 419, 384, 389, 384,
-	168, 149, 150, 151, 396, 294, 157, 968, 273, 272,
-	275, 276, 277, 278, 279, 274, 566, 726, 35, 36,
-	534, 37, 38, 22, 26, 27, 28, 477, 160, 475,
-	273, 272, 275, 276, 277, 278, 279, 274, 469, 300,
-	146, 391, 147, 145, 155, 156, 159, 23, 494, 24,
-	266, 25, 139, 545, 352, 273, 272, 275, 276, 277,
-	278, 279, 274, 454, 350, 47, 467, 468, 273, 272,
-	275, 276, 277, 278, 279, 274, 136, 455, 456, 457,
-	458, 459, 471, 460, 461, 132, 85, 729, 384, 49,
-	50, 55, 56, 57, 58, 59, 41, 72, 73, 74,
-	75, 20, 79, 11, 19, 18, 17, 16, 2, 92,
-	93, 1, 0, 98, 99, 0, 0, 0, 100, 103,
-	104, 105, 106, 108, 109, 0, 110, 0, 112, 113,
-	161, 0, 0, 0, 111, 268, 270, 0, 102, 107,
-	0, 280, 281, 282, 283, 284, 285, 286, 271, 269,
-	267, 273, 272, 275, 276, 277, 278, 279, 274, 0,
-	0, 0, 0, 0, 550, 551, 0, 0, 0, 553,
-	0, 0, 0, 0, 0, 555, 0, 0, 0, 557,
-	0, 0, 29, 0, 0, 31, 32, 34, 33, 0,
-	0, 0, 0, 568, 0, 0, 0, 0, 0, 0,
-	158, 79, 0, 0, 0, 0, 0, 0, 0, 0,
+var yyAct = [...]int16{
+	305, 843, 1725, 1359, 1686, 576, 1248, 1372, 1631, 437,
+	1420, 1687, 967, 1252, 1628, 510, 1232, 1322, 1328, 1421,
+	1261, 1467, 1323, 1562, 608, 411, 865, 1409, 1346, 1431,
+	680, 1117, 1253, 864, 1396, 1095, 1251, 533, 303, 1096,
+	832, 314, 1001, 882, 1727, 988, 1726, 1249, 982, 871,
+	304, 590, 800, 306, 511, 3, 630, 1207, 1058, 969,
+	868, 577, 1091, 835, 612, 315, 591, 636, 850, 332,
+	136, 792, 160, 471, 164, 165, 626, 294, 458, 580,
+	611, 228, 856, 1517, 454, 174, 441, 619, 603, 475,
+	476, 474, 1075, 774, 1665, 208, 1651, 208, 1649, 425,
+	208, 215, 216, 1648, 774, 226, 231, 231, 1647, 218,
+	1285, 109, 1622, 1517, 904, 905, 906, 907, 908, 1552,
+	909, 910, 1517, 1517, 1517, 1517, 380, 208, 1517, 1551,
+	774, 167, 77, 78, 79, 80, 278, 488, 487, 491,
+	492, 493, 494, 495, 496, 497, 489, 490, 498, 77,
+	78, 79, 80, 77, 78, 79, 80, 1491, 1500, 854,
+	280, 488, 487, 491, 492, 493, 494, 495, 496, 497,
+	489, 490, 498, 1499, 923, 1498, 333, 475, 476, 474,
+	1497, 1496, 854, 804, 1494, 298, 1490, 488, 487, 491,
+	492, 493, 494, 495, 496, 497, 489, 490, 498, 1489,
+	1488, 1482, 1481, 1480, 283, 1479, 1442, 1517, 1517, 1478,
+	1517, 1517, 774, 208, 208, 1477, 1476, 1456, 424, 1453,
+	427, 1517, 854, 430, 1517, 1517, 1517, 326, 1349, 797,
+	231, 413, 797, 797, 1517, 1225, 488, 487, 491, 492,
+	493, 494, 495, 496, 497, 489, 490, 498, 1505, 1224,
+	1505, 1222, 1487, 1455, 1442, 1116, 854, 1219, 774, 1206,
+	854, 774, 797, 774, 951, 921, 208, 208, 1023, 1157,
+	161, 1171, 208, 1155, 208, 208, 258, 1742, 461, 1013,
+	462, 1555, 1012, 1433, 1434, 994, 1022, 1373, 846, 1281,
+	1263, 455, 1279, 966, 1776, 1563, 1277, 1468, 472, 488,
+	487, 491, 492, 493, 494, 495, 496, 497, 489, 490,
+	498, 1170, 383, 1154, 386, 387, 388, 1690, 1780, 467,
+	1275, 429, 1273, 431, 432, 433, 1663, 1271, 174, 1172,
+	534, 1156, 1258, 1255, 1269, 1267, 223, 224, 1217, 1008,
+	225, 444, 1216, 173, 1265, 1262, 1157, 1635, 1157, 866,
+	1553, 219, 996, 997, 423, 442, 1313, 426, 446, 254,
+	1680, 336, 377, 1311, 973, 256, 257, 210, 975, 1204,
+	1241, 480, 900, 1076, 623, 135, 606, 605, 520, 523,
+	221, 222, 275, 456, 88, 1361, 207, 1203, 211, 1202,
+	208, 214, 971, 445, 453, 252, 208, 208, 452, 974,
+	208, 208, 208, 208, 208, 208, 208, 208, 208, 208,
+	448, 271, 273, 1513, 265, 574, 208, 579, 267, 1324,
+	604, 1771, 579, 1256, 1003, 885, 513, 514, 582, 276,
+	208, 1760, 208, 208, 208, 602, 585, 231, 1256, 589,
+	579, 1668, 1741, 1024, 1361, 208, 617, 1017, 620, 208,
+	1055, 1711, 1027, 208, 208, 924, 1723, 208, 220, 274,
+	1710, 1707, 1706, 1671, 634, 1640, 1670, 578, 1074, 208,
+	1257, 643, 588, 927, 644, 1351, 1256, 570, 1428, 1052,
+	1054, 85, 1627, 1258, 1071, 1257, 1231, 507, 509, 1259,
+	609, 1609, 937, 888, 1425, 860, 775, 1669, 1466, 1355,
+	857, 772, 217, 1026, 417, 418, 543, 457, 613, 1719,
+	1720, 547, 548, 613, 206, 887, 886, 550, 607, 541,
+	1667, 554, 785, 1257, 558, 559, 1632, 618, 594, 610,
+	615, 579, 782, 409, 640, 658, 333, 624, 625, 806,
+	1604, 628, 1397, 790, 1666, 1659, 1658, 641, 1617, 1612,
+	922, 210, 208, 208, 208, 794, 208, 450, 451, 1611,
+	1610, 398, 1598, 1597, 1594, 459, 459, 1548, 545, 261,
+	1547, 1546, 1516, 810, 1059, 1682, 1684, 1683, 1685, 397,
+	508, 609, 1559, 1558, 579, 827, 1507, 795, 1506, 838,
+	1486, 1453, 1443, 1115, 936, 620, 931, 208, 853, 839,
+	796, 773, 230, 1226, 852, 798, 91, 90, 394, 1011,
+	1554, 852, 1263, 163, 162, 1263, 620, 92, 1007, 1263,
+	93, 844, 845, 847, 208, 259, 1781, 1782, 208, 1287,
+	208, 1344, 896, 1089, 578, 1289, 999, 837, 472, 208,
+	818, 819, 820, 1263, 1312, 1263, 1688, 1689, 1541, 153,
+	1263, 154, 885, 1255, 155, 156, 829, 1263, 1263, 799,
+	645, 647, 649, 651, 653, 655, 254, 1263, 1263, 1360,
+	872, 531, 256, 257, 1633, 1634, 811, 1286, 970, 855,
+	994, 549, 841, 816, 817, 1006, 525, 556, 557, 897,
+	821, 560, 561, 562, 563, 564, 565, 566, 567, 568,
+	569, 867, 159, 862, 640, 894, 912, 575, 895, 1362,
+	1426, 911, 403, 913, 1000, 901, 1287, 1021, 406, 407,
+	888, 595, 408, 597, 598, 599, 1016, 1053, 1360, 1347,
+	223, 224, 858, 87, 225, 536, 616, 384, 385, 227,
+	622, 1082, 887, 886, 253, 268, 884, 883, 1465, 1464,
+	889, 1255, 898, 390, 391, 392, 1087, 1088, 1287, 977,
+	639, 976, 404, 393, 405, 771, 1427, 834, 1362, 490,
+	498, 1015, 643, 1032, 221, 222, 1069, 1067, 1068, 1066,
+	1062, 1064, 460, 1063, 1065, 1060, 1061, 470, 1020, 1018,
+	414, 91, 90, 1014, 134, 1031, 1030, 1492, 939, 925,
+	498, 138, 92, 542, 1255, 93, 1019, 175, 1105, 37,
+	42, 43, 44, 178, 177, 176, 1070, 149, 150, 151,
+	540, 539, 143, 144, 145, 36, 1618, 146, 157, 579,
+	633, 579, 438, 39, 956, 121, 1621, 41, 941, 631,
+	158, 942, 943, 812, 813, 814, 251, 815, 260, 38,
+	147, 489, 490, 498, 632, 579, 1332, 1192, 960, 983,
+	945, 946, 140, 933, 579, 555, 382, 133, 1191, 1190,
+	957, 382, 662, 1102, 270, 1619, 272, 551, 382, 578,
+	1101, 578, 837, 538, 998, 660, 659, 661, 848, 1036,
+	963, 955, 958, 389, 382, 1035, 1034, 186, 1029, 1028,
+	944, 1039, 831, 208, 208, 978, 808, 964, 990, 171,
+	993, 793, 807, 934, 989, 890, 1005, 537, 1009, 893,
+	1010, 459, 980, 986, 613, 793, 614, 141, 142, 152,
+	639, 474, 633, 148, 1788, 1607, 1056, 1085, 381, 947,
+	948, 949, 950, 381, 209, 179, 180, 1329, 476, 474,
+	381, 666, 1787, 1038, 488, 487, 491, 492, 493, 494,
+	495, 496, 497, 489, 490, 498, 381, 1077, 640, 640,
+	1042, 1043, 830, 884, 883, 1779, 10, 889, 1107, 935,
+	516, 1330, 983, 1094, 1092, 1113, 1114, 1079, 475, 476,
+	474, 1092, 1158, 1159, 436, 1160, 208, 1090, 667, 436,
+	534, 515, 995, 1098, 928, 596, 440, 579, 1168, 1169,
+	1201, 435, 1200, 579, 579, 579, 1050, 1178, 1179, 1093,
+	1181, 1182, 534, 1184, 1185, 534, 1049, 1104, 1100, 1187,
+	1109, 1108, 9, 112, 8, 7, 25, 1163, 488, 487,
+	491, 492, 493, 494, 495, 496, 497, 489, 490, 498,
+	1046, 1044, 872, 24, 1166, 1047, 1045, 1167, 1048, 1183,
+	830, 1485, 1186, 1174, 1175, 1176, 1194, 487, 491, 492,
+	493, 494, 495, 496, 497, 489, 490, 498, 45, 493,
+	494, 495, 496, 497, 489, 490, 498, 1461, 1484, 113,
+	1483, 111, 110, 120, 1223, 499, 500, 501, 502, 503,
+	504, 505, 1238, 1240, 122, 123, 124, 57, 1218, 774,
+	119, 983, 23, 1080, 1098, 1235, 22, 579, 1209, 1210,
+	6, 1211, 1212, 5, 1213, 1460, 1215, 488, 487, 491,
+	492, 493, 494, 495, 496, 497, 489, 490, 498, 4,
+	797, 1229, 840, 1231, 1264, 1266, 1268, 1270, 1272, 1274,
+	1276, 1278, 1280, 1236, 1099, 1004, 1301, 581, 627, 1244,
+	37, 990, 1250, 993, 475, 476, 474, 989, 1243, 118,
+	629, 1084, 1318, 117, 840, 579, 581, 116, 535, 1716,
+	115, 1327, 491, 492, 493, 494, 495, 496, 497, 489,
+	490, 498, 1306, 1307, 639, 639, 114, 328, 902, 1314,
+	38, 1738, 1315, 1316, 1331, 984, 327, 1326, 37, 904,
+	905, 906, 907, 908, 1338, 909, 910, 830, 1193, 1199,
+	439, 329, 1334, 822, 1676, 1325, 904, 905, 906, 907,
+	908, 823, 909, 910, 81, 468, 985, 1336, 1713, 828,
+	583, 412, 208, 77, 78, 79, 80, 1712, 38, 1288,
+	1616, 1615, 1593, 439, 439, 1592, 1348, 1535, 1294, 1295,
+	1296, 1297, 1098, 1534, 1526, 1525, 929, 1366, 1524, 1718,
+	1521, 1353, 1375, 1098, 1377, 37, 1379, 469, 1381, 1509,
+	1383, 1508, 1385, 1475, 1387, 1364, 1389, 1161, 1391, 1363,
+	1365, 1358, 291, 1005, 836, 1233, 1234, 1369, 1441, 1436,
+	1435, 1430, 1429, 1414, 1415, 1419, 284, 285, 290, 579,
+	289, 286, 287, 288, 1602, 38, 1519, 1418, 1416, 1342,
+	1439, 1440, 1341, 1399, 1340, 1444, 1308, 1411, 1305, 1405,
+	1406, 1407, 1408, 1299, 1412, 1413, 1298, 1410, 1410, 1293,
+	1292, 534, 534, 534, 1291, 1580, 1290, 1284, 1283, 1282,
+	1260, 1437, 1438, 825, 518, 1228, 1446, 1445, 1208, 1422,
+	1214, 1173, 1449, 1086, 863, 784, 532, 1471, 530, 1473,
+	527, 526, 524, 522, 1333, 420, 1335, 1458, 1450, 1451,
+	1452, 1578, 1577, 1337, 1576, 1339, 1550, 488, 487, 491,
+	492, 493, 494, 495, 496, 497, 489, 490, 498, 1522,
+	1404, 1472, 930, 1474, 488, 487, 491, 492, 493, 494,
+	495, 496, 497, 489, 490, 498, 464, 579, 1403, 579,
+	579, 465, 466, 1402, 1401, 1400, 1398, 1512, 1395, 1514,
+	1515, 579, 1394, 1393, 579, 579, 579, 579, 1392, 1518,
+	1390, 1388, 579, 1386, 1384, 1382, 1532, 1533, 1510, 1511,
+	1380, 1540, 1538, 1378, 1376, 1529, 1374, 1371, 1345, 1343,
+	1188, 592, 572, 579, 282, 1539, 281, 1422, 1556, 1422,
+	1422, 571, 572, 1536, 1537, 1542, 1566, 1766, 1568, 1765,
+	1764, 609, 1752, 1750, 1530, 1531, 1422, 1422, 1749, 1564,
+	1457, 1417, 1422, 1565, 1357, 1567, 1356, 1309, 1570, 1571,
+	1572, 1573, 1574, 1575, 1245, 1221, 586, 1579, 1195, 579,
+	579, 1111, 1073, 578, 952, 849, 1448, 822, 579, 1590,
+	1591, 777, 892, 1581, 776, 579, 1696, 579, 1675, 1447,
+	1424, 1370, 1596, 1354, 1164, 579, 579, 1601, 1037, 1603,
+	891, 1588, 1589, 1600, 1587, 1613, 1614, 1025, 899, 1605,
+	824, 1608, 378, 449, 1623, 1624, 1625, 1495, 447, 1422,
+	1422, 443, 428, 1501, 1502, 1503, 1504, 292, 1422, 277,
+	269, 182, 181, 166, 1629, 609, 1744, 609, 1641, 1642,
+	1643, 1644, 1645, 1646, 1544, 1422, 1422, 1650, 1637, 1636,
+	1639, 1638, 1560, 579, 579, 826, 1493, 1368, 1545, 1454,
+	1189, 1033, 416, 1660, 1661, 1583, 1662, 1584, 1585, 1586,
+	379, 335, 1664, 1470, 1469, 1352, 579, 579, 1652, 1653,
+	1654, 1655, 1677, 1321, 1678, 885, 1672, 1673, 1317, 1674,
+	1304, 879, 1300, 1180, 1177, 1230, 1103, 415, 1549, 213,
+	1233, 1234, 965, 1422, 1422, 1691, 918, 1693, 1246, 1561,
+	861, 593, 1367, 1247, 938, 170, 168, 410, 412, 1751,
+	1748, 1692, 1747, 1694, 208, 1724, 1422, 1422, 1697, 1698,
+	1699, 1722, 1700, 1721, 1220, 1198, 299, 1582, 1715, 1165,
+	1705, 1162, 1709, 1078, 1072, 961, 833, 1197, 1041, 1717,
+	581, 979, 1714, 888, 1784, 1783, 1728, 553, 1730, 1732,
+	552, 1729, 463, 1731, 421, 1734, 1735, 1736, 1737, 1733,
+	402, 401, 400, 399, 396, 887, 886, 395, 212, 1746,
+	1740, 1790, 1789, 1620, 1462, 1254, 83, 1432, 1739, 968,
+	1118, 1753, 869, 1755, 1754, 870, 1756, 1758, 987, 579,
+	842, 1778, 1775, 1762, 1630, 579, 1656, 1657, 1761, 1759,
+	1763, 940, 679, 1599, 1757, 255, 139, 1767, 330, 1768,
+	1543, 1196, 1769, 1040, 932, 528, 926, 1772, 309, 791,
+	1459, 310, 1773, 1233, 1234, 1774, 308, 1777, 320, 962,
+	1770, 300, 1051, 637, 903, 1785, 1786, 635, 297, 1422,
+	293, 1791, 1792, 169, 76, 578, 1743, 1679, 137, 1681,
+	1626, 1557, 1463, 972, 434, 981, 859, 20, 506, 512,
+	1701, 1702, 1703, 1704, 517, 519, 19, 18, 521, 1242,
+	229, 17, 16, 27, 15, 422, 14, 13, 529, 12,
+	35, 21, 34, 33, 508, 32, 488, 487, 491, 492,
+	493, 494, 495, 496, 497, 489, 490, 498, 31, 488,
+	487, 491, 492, 493, 494, 495, 496, 497, 489, 490,
+	498, 30, 1423, 1520, 1310, 1002, 1695, 153, 1595, 154,
+	29, 28, 155, 156, 488, 487, 491, 492, 493, 494,
+	495, 496, 497, 489, 490, 498, 419, 11, 544, 546,
+	920, 26, 172, 84, 2, 1, 0, 0, 877, 876,
+	0, 878, 0, 153, 0, 154, 0, 0, 155, 156,
+	0, 478, 479, 477, 482, 485, 0, 0, 0, 573,
+	499, 500, 501, 502, 503, 504, 505, 486, 483, 481,
+	484, 488, 487, 491, 492, 493, 494, 495, 496, 497,
+	489, 490, 498, 0, 0, 0, 884, 883, 0, 0,
+	889, 0, 0, 0, 0, 1708, 488, 487, 491, 492,
+	493, 494, 495, 496, 497, 489, 490, 498, 0, 873,
+	0, 874, 875, 881, 880, 0, 299, 0, 0, 0,
+	0, 646, 648, 650, 652, 654, 656, 657, 0, 0,
+	663, 664, 665, 0, 668, 669, 670, 671, 672, 673,
+	674, 675, 676, 677, 678, 0, 0, 0, 1320, 0,
+	0, 0, 0, 0, 0, 137, 0, 0, 0, 138,
+	0, 0, 778, 779, 0, 0, 0, 0, 0, 787,
+	0, 0, 788, 789, 0, 149, 150, 151, 0, 0,
+	143, 144, 145, 0, 801, 146, 157, 0, 0, 0,
+	0, 0, 0, 0, 0, 138, 0, 0, 158, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 147, 0,
+	0, 149, 150, 151, 0, 0, 143, 144, 145, 0,
+	140, 146, 157, 0, 153, 0, 154, 0, 546, 155,
+	156, 0, 291, 0, 158, 324, 0, 0, 786, 802,
+	917, 0, 0, 0, 147, 508, 284, 285, 290, 0,
+	289, 286, 287, 288, 518, 318, 140, 0, 488, 487,
+	491, 492, 493, 494, 495, 496, 497, 489, 490, 498,
+	0, 0, 0, 0, 803, 0, 0, 0, 0, 0,
+	0, 321, 0, 0, 0, 141, 142, 152, 0, 0,
+	0, 148, 0, 0, 0, 0, 0, 0, 316, 317,
+	0, 0, 0, 0, 325, 0, 0, 914, 915, 916,
+	0, 311, 312, 0, 153, 0, 154, 0, 0, 155,
+	156, 141, 142, 152, 0, 0, 0, 148, 0, 1528,
+	0, 0, 337, 338, 339, 805, 341, 342, 343, 344,
+	345, 346, 347, 348, 349, 350, 351, 352, 353, 354,
+	355, 356, 357, 358, 359, 360, 361, 362, 363, 364,
+	365, 366, 367, 368, 369, 370, 371, 372, 373, 374,
+	375, 376, 0, 0, 0, 0, 138, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 149, 150, 151, 0, 0, 143, 144, 145,
+	0, 0, 146, 157, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 158, 0, 0, 0, 0,
+	0, 919, 0, 0, 0, 147, 0, 1319, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 140, 0, 0,
+	546, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 517, 0, 801, 801, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 138, 0, 0, 0,
+	0, 0, 953, 954, 0, 0, 0, 0, 959, 0,
+	0, 0, 149, 150, 151, 0, 0, 143, 144, 145,
+	0, 0, 146, 157, 0, 0, 0, 0, 0, 0,
+	0, 0, 141, 142, 152, 158, 0, 37, 148, 0,
+	0, 137, 0, 0, 0, 147, 0, 0, 0, 0,
+	0, 323, 313, 291, 0, 0, 324, 140, 0, 0,
+	0, 0, 0, 0, 0, 0, 508, 284, 285, 290,
+	0, 289, 286, 287, 288, 302, 318, 38, 0, 0,
+	0, 0, 0, 0, 992, 0, 0, 0, 0, 0,
+	0, 508, 0, 0, 1057, 0, 0, 0, 0, 0,
+	301, 0, 321, 0, 0, 0, 0, 1081, 0, 0,
+	153, 1083, 154, 0, 0, 155, 156, 0, 0, 316,
+	317, 801, 141, 142, 152, 325, 0, 0, 148, 319,
+	0, 0, 311, 312, 0, 153, 0, 154, 1097, 0,
+	155, 156, 0, 0, 0, 0, 0, 0, 190, 0,
+	0, 0, 0, 0, 0, 0, 307, 0, 0, 0,
+	153, 0, 154, 0, 0, 155, 156, 0, 0, 783,
+	0, 0, 291, 0, 0, 324, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 508, 284, 285, 290, 0,
+	289, 286, 287, 288, 518, 318, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 184, 183, 185, 0, 0, 0,
+	0, 321, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 1205, 0, 0, 0, 0, 316, 317,
+	781, 0, 0, 0, 325, 0, 0, 0, 0, 1097,
+	0, 311, 312, 0, 153, 0, 154, 0, 0, 155,
+	156, 1227, 138, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 307, 0, 0, 149, 150,
+	151, 0, 0, 143, 144, 145, 0, 138, 146, 157,
+	0, 0, 600, 601, 0, 0, 0, 0, 0, 0,
+	0, 158, 0, 149, 150, 151, 0, 0, 143, 144,
+	145, 147, 138, 146, 157, 0, 0, 0, 0, 0,
+	0, 0, 0, 140, 0, 0, 158, 0, 149, 150,
+	151, 0, 0, 143, 144, 145, 147, 0, 146, 157,
+	0, 0, 323, 0, 0, 179, 180, 0, 140, 187,
+	188, 158, 0, 0, 189, 192, 193, 194, 195, 197,
+	198, 147, 199, 991, 201, 202, 0, 203, 204, 205,
+	0, 0, 0, 140, 546, 0, 546, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 141, 142,
+	152, 322, 0, 0, 148, 0, 200, 1097, 0, 0,
+	0, 191, 196, 0, 0, 1350, 138, 0, 1097, 0,
+	0, 0, 0, 141, 142, 152, 0, 0, 0, 148,
+	319, 0, 149, 150, 151, 994, 0, 143, 144, 145,
+	0, 0, 146, 157, 0, 0, 0, 0, 141, 142,
+	152, 0, 0, 0, 148, 158, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 147, 0, 0, 0, 0,
+	0, 323, 0, 0, 0, 0, 0, 140, 0, 0,
+	0, 0, 0, 0, 313, 291, 0, 0, 324, 0,
+	0, 0, 546, 0, 0, 0, 0, 0, 296, 284,
+	285, 290, 0, 289, 286, 287, 288, 302, 318, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 301, 0, 321, 0, 0, 0, 0, 0,
+	0, 0, 141, 142, 152, 0, 0, 0, 148, 319,
+	780, 316, 317, 295, 0, 0, 0, 325, 0, 0,
+	0, 0, 0, 0, 311, 312, 0, 153, 0, 154,
+	0, 0, 155, 156, 0, 313, 291, 0, 0, 324,
+	0, 0, 0, 0, 0, 0, 0, 0, 307, 508,
+	284, 285, 290, 0, 289, 286, 287, 288, 302, 318,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 1523, 0, 0, 0, 1527, 0, 0, 0,
+	0, 0, 0, 301, 0, 321, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 316, 317, 0, 0, 0, 0, 325, 0,
+	0, 0, 0, 0, 0, 311, 312, 0, 153, 0,
+	154, 0, 1569, 155, 156, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 307,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 37, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	291, 0, 1606, 324, 0, 0, 0, 0, 0, 138,
+	0, 0, 0, 508, 284, 285, 290, 0, 289, 286,
+	287, 288, 518, 318, 38, 149, 150, 151, 0, 0,
+	143, 144, 145, 0, 0, 146, 157, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 158, 321,
+	0, 0, 0, 0, 0, 0, 0, 0, 147, 0,
+	0, 0, 0, 0, 323, 0, 316, 317, 0, 0,
+	140, 0, 325, 0, 0, 0, 0, 0, 0, 311,
+	312, 0, 153, 0, 154, 0, 0, 155, 156, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	138, 0, 0, 307, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 322, 0, 0, 149, 150, 151, 0,
+	89, 143, 144, 145, 0, 0, 146, 157, 0, 0,
+	0, 0, 0, 0, 0, 141, 142, 152, 0, 158,
+	0, 148, 319, 0, 0, 0, 0, 0, 0, 147,
+	0, 0, 0, 0, 0, 323, 0, 0, 82, 0,
+	86, 140, 94, 95, 96, 97, 98, 99, 100, 101,
+	102, 103, 104, 105, 106, 107, 108, 0, 125, 126,
+	127, 128, 129, 130, 131, 132, 0, 291, 0, 0,
+	324, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	508, 284, 285, 290, 322, 289, 286, 287, 288, 518,
+	318, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 141, 142, 152, 0,
+	0, 0, 148, 319, 138, 0, 321, 0, 0, 37,
+	0, 0, 0, 262, 263, 264, 0, 0, 0, 0,
+	149, 150, 151, 316, 317, 143, 144, 145, 0, 325,
+	146, 157, 0, 0, 0, 0, 311, 312, 137, 153,
+	0, 154, 0, 158, 155, 156, 0, 638, 0, 38,
+	0, 0, 0, 147, 0, 0, 0, 0, 0, 323,
+	307, 0, 0, 0, 0, 140, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 291, 0, 0, 324,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 508,
+	284, 285, 290, 0, 289, 286, 287, 288, 518, 318,
+	0, 291, 0, 0, 324, 0, 0, 153, 0, 154,
+	0, 0, 155, 156, 508, 284, 285, 290, 0, 289,
+	286, 287, 288, 518, 318, 321, 0, 0, 0, 0,
+	141, 142, 152, 0, 0, 0, 148, 319, 0, 0,
+	0, 0, 316, 317, 0, 0, 0, 0, 325, 0,
+	321, 0, 0, 0, 0, 311, 312, 0, 153, 0,
+	154, 0, 0, 155, 156, 0, 0, 316, 317, 0,
+	0, 0, 0, 325, 0, 0, 0, 0, 0, 307,
+	311, 312, 0, 153, 0, 154, 0, 0, 155, 156,
+	0, 138, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 307, 0, 0, 149, 150, 151,
+	0, 0, 143, 144, 145, 0, 0, 146, 157, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	158, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	147, 233, 234, 235, 236, 0, 323, 0, 0, 0,
+	0, 0, 140, 232, 0, 0, 0, 0, 0, 138,
+	0, 0, 0, 0, 0, 0, 246, 242, 0, 0,
+	137, 0, 0, 0, 0, 149, 150, 151, 0, 439,
+	143, 144, 145, 0, 0, 146, 157, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 158, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 147, 0,
+	138, 0, 0, 0, 0, 0, 0, 141, 142, 152,
+	140, 0, 0, 148, 319, 584, 149, 150, 151, 0,
+	0, 143, 144, 145, 0, 138, 146, 157, 0, 153,
+	0, 154, 0, 0, 155, 156, 0, 0, 0, 158,
+	0, 149, 150, 151, 0, 0, 143, 144, 145, 147,
+	0, 146, 157, 0, 0, 323, 0, 0, 0, 0,
+	0, 140, 0, 0, 158, 0, 233, 234, 235, 236,
+	0, 0, 0, 0, 147, 141, 142, 152, 232, 0,
+	323, 148, 0, 0, 0, 0, 140, 0, 0, 0,
+	0, 246, 242, 0, 0, 137, 0, 0, 0, 0,
+	0, 0, 0, 0, 322, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 141, 142, 152, 0,
+	0, 0, 148, 319, 0, 0, 0, 0, 0, 0,
+	0, 1303, 0, 0, 0, 0, 0, 0, 137, 0,
+	0, 141, 142, 152, 0, 0, 0, 148, 319, 0,
+	0, 0, 0, 0, 153, 0, 154, 0, 0, 155,
+	156, 0, 0, 0, 0, 0, 0, 0, 0, 245,
+	0, 138, 0, 0, 244, 37, 42, 43, 44, 0,
+	0, 247, 0, 0, 248, 249, 0, 149, 150, 151,
+	0, 0, 143, 144, 145, 250, 0, 146, 157, 39,
+	63, 40, 56, 41, 75, 0, 0, 153, 0, 154,
+	158, 0, 155, 156, 0, 38, 237, 238, 239, 0,
+	147, 0, 240, 243, 0, 479, 477, 482, 485, 0,
+	0, 71, 140, 499, 500, 501, 502, 503, 504, 505,
+	486, 483, 481, 484, 488, 487, 491, 492, 493, 494,
+	495, 496, 497, 489, 490, 498, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 137, 241, 0,
+	0, 0, 64, 69, 70, 65, 66, 0, 67, 68,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 141, 142, 152,
+	0, 0, 0, 148, 245, 0, 138, 0, 0, 244,
+	0, 0, 0, 0, 0, 1239, 247, 0, 0, 248,
+	249, 137, 149, 150, 151, 0, 0, 143, 144, 145,
+	250, 0, 146, 157, 1237, 0, 153, 0, 154, 0,
+	137, 155, 156, 0, 0, 158, 0, 0, 0, 0,
+	0, 237, 238, 239, 0, 147, 0, 240, 243, 138,
+	0, 0, 0, 0, 0, 0, 0, 140, 0, 0,
+	0, 0, 0, 0, 0, 149, 150, 151, 0, 0,
+	143, 144, 145, 0, 0, 146, 157, 0, 0, 0,
+	153, 0, 154, 0, 0, 155, 156, 0, 158, 0,
+	0, 0, 0, 241, 0, 0, 0, 0, 147, 153,
+	1302, 154, 0, 0, 155, 156, 0, 0, 0, 0,
+	140, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 141, 142, 152, 0, 0, 0, 148, 0,
+	0, 0, 0, 0, 45, 46, 47, 48, 49, 52,
+	53, 0, 0, 0, 51, 0, 0, 137, 1112, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	54, 55, 50, 57, 58, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 141, 142, 152, 138, 0,
+	0, 148, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 149, 150, 151, 0, 0, 143,
+	144, 145, 0, 0, 146, 157, 0, 0, 0, 0,
+	1745, 0, 0, 0, 0, 0, 153, 158, 154, 0,
+	0, 155, 156, 137, 0, 0, 0, 147, 0, 0,
+	0, 0, 138, 0, 0, 0, 0, 0, 72, 140,
+	0, 73, 74, 0, 59, 60, 61, 62, 149, 150,
+	151, 138, 0, 143, 144, 145, 1110, 0, 146, 157,
+	137, 0, 0, 0, 0, 0, 0, 149, 150, 151,
+	0, 158, 143, 144, 145, 0, 0, 146, 157, 0,
+	137, 147, 0, 0, 0, 0, 0, 0, 0, 638,
+	158, 0, 153, 140, 154, 0, 0, 155, 156, 0,
+	147, 0, 0, 0, 141, 142, 152, 0, 0, 0,
+	148, 0, 140, 0, 0, 1106, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 473, 0, 0, 0, 153,
+	0, 154, 0, 0, 155, 156, 0, 0, 0, 0,
+	137, 0, 0, 0, 0, 0, 0, 0, 0, 153,
+	0, 154, 0, 0, 155, 156, 0, 0, 141, 142,
+	152, 0, 0, 0, 148, 0, 0, 0, 138, 0,
+	0, 0, 0, 0, 0, 0, 0, 141, 142, 152,
+	0, 0, 0, 148, 149, 150, 151, 0, 0, 143,
+	144, 145, 0, 0, 146, 157, 0, 0, 0, 0,
+	0, 0, 0, 137, 621, 0, 0, 158, 0, 153,
+	0, 154, 0, 0, 155, 156, 0, 147, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 140,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 138, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	149, 150, 151, 0, 0, 143, 144, 145, 0, 0,
+	146, 157, 153, 0, 154, 0, 0, 155, 156, 0,
+	0, 138, 0, 158, 137, 0, 0, 0, 0, 0,
+	0, 0, 0, 147, 141, 142, 152, 149, 150, 151,
+	148, 138, 143, 144, 145, 140, 0, 146, 157, 0,
+	0, 0, 0, 0, 0, 0, 0, 149, 150, 151,
+	158, 0, 143, 144, 145, 0, 0, 146, 157, 0,
+	147, 0, 0, 0, 0, 0, 0, 0, 0, 851,
+	158, 0, 140, 137, 0, 0, 0, 0, 0, 0,
+	147, 0, 0, 153, 0, 154, 0, 0, 155, 156,
+	0, 138, 140, 0, 0, 0, 0, 0, 0, 0,
+	141, 142, 152, 0, 0, 0, 148, 149, 150, 151,
+	0, 0, 143, 144, 145, 0, 0, 146, 157, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 642, 0,
+	158, 508, 587, 0, 0, 0, 0, 141, 142, 152,
+	147, 0, 153, 148, 154, 0, 0, 155, 156, 0,
+	0, 0, 140, 0, 138, 334, 0, 141, 142, 152,
+	0, 0, 0, 148, 0, 0, 0, 0, 0, 0,
+	149, 150, 151, 0, 0, 143, 144, 145, 0, 0,
+	146, 157, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 158, 0, 0, 0, 0, 0, 0,
+	153, 0, 154, 147, 0, 155, 156, 0, 0, 0,
+	0, 0, 0, 0, 0, 140, 0, 141, 142, 152,
+	0, 0, 0, 148, 153, 0, 154, 0, 331, 155,
+	156, 0, 0, 0, 0, 138, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 149, 150, 151, 0, 0, 143, 144, 145, 0,
+	0, 146, 157, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 137, 158, 0, 0, 0, 0, 0,
+	141, 142, 152, 0, 147, 0, 148, 0, 0, 0,
+	0, 0, 0, 0, 138, 0, 140, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	149, 150, 151, 0, 508, 143, 144, 145, 0, 0,
+	146, 157, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 158, 0, 0, 0, 0, 0, 0,
+	0, 0, 153, 147, 154, 0, 0, 155, 156, 0,
+	0, 0, 138, 0, 0, 140, 0, 0, 0, 0,
+	0, 141, 142, 152, 137, 0, 0, 148, 149, 150,
+	151, 0, 0, 143, 144, 145, 138, 0, 146, 157,
+	0, 0, 0, 153, 0, 154, 0, 0, 155, 156,
+	0, 158, 149, 150, 151, 0, 0, 143, 144, 145,
+	0, 147, 146, 157, 0, 0, 0, 0, 0, 0,
+	0, 0, 334, 140, 0, 158, 0, 0, 0, 0,
+	141, 142, 152, 0, 0, 147, 148, 0, 0, 0,
+	0, 0, 0, 153, 0, 154, 0, 140, 155, 156,
+	0, 0, 0, 0, 0, 0, 0, 0, 477, 482,
+	485, 0, 0, 0, 137, 499, 500, 501, 502, 503,
+	504, 505, 486, 483, 481, 484, 488, 487, 491, 492,
+	493, 494, 495, 496, 497, 489, 490, 498, 141, 142,
+	152, 153, 0, 154, 148, 0, 155, 156, 0, 0,
+	0, 0, 0, 0, 138, 0, 0, 0, 0, 0,
+	0, 0, 141, 142, 152, 0, 0, 0, 148, 0,
+	149, 150, 151, 0, 0, 143, 144, 145, 0, 0,
+	146, 157, 1152, 279, 0, 154, 0, 1153, 155, 156,
+	0, 0, 0, 158, 0, 138, 0, 0, 0, 0,
+	0, 0, 0, 147, 266, 0, 0, 0, 0, 0,
+	0, 149, 150, 151, 0, 140, 143, 144, 145, 0,
+	0, 146, 157, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 158, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 147, 138, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 140, 0, 0, 0,
+	0, 149, 150, 151, 0, 0, 143, 144, 145, 0,
+	0, 146, 157, 0, 0, 0, 0, 1141, 0, 0,
+	141, 142, 152, 0, 158, 0, 148, 0, 0, 0,
+	0, 0, 0, 138, 147, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 140, 0, 0, 149,
+	150, 151, 0, 0, 143, 144, 145, 0, 0, 146,
+	157, 141, 142, 152, 0, 0, 0, 148, 0, 0,
+	0, 0, 158, 0, 0, 138, 0, 0, 0, 0,
+	0, 0, 147, 0, 0, 0, 0, 0, 0, 0,
+	0, 149, 150, 151, 140, 0, 143, 144, 145, 0,
+	0, 146, 157, 0, 0, 0, 0, 0, 0, 0,
+	0, 141, 142, 152, 158, 0, 809, 148, 0, 0,
+	0, 0, 0, 0, 147, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 140, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 141,
+	142, 152, 482, 485, 0, 148, 0, 0, 499, 500,
+	501, 502, 503, 504, 505, 486, 483, 481, 484, 488,
+	487, 491, 492, 493, 494, 495, 496, 497, 489, 490,
+	498, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 141, 142, 152, 0, 688, 0, 148, 0, 0,
+	1119, 1120, 1121, 1122, 1123, 1124, 1125, 1126, 1127, 1128,
+	1129, 1130, 1131, 1132, 1133, 1134, 1135, 1136, 1137, 1138,
+	1139, 1140, 1147, 1148, 1149, 1150, 1142, 1143, 1144, 1145,
+	1146, 1151, 682, 683, 684, 685, 686, 687, 689, 690,
+	691, 692, 693, 694, 695, 696, 697, 698, 699, 700,
+	701, 702, 703, 704, 705, 706, 707, 708, 709, 710,
+	711, 712, 713, 714, 715, 716, 717, 718, 719, 720,
+	721, 722, 723, 724, 725, 726, 727, 728, 729, 730,
+	731, 732, 733, 734, 735, 736, 737, 738, 739, 740,
+	741, 742, 743, 744, 745, 746, 747, 748, 749, 750,
+	751, 752, 753, 754, 755, 756, 757, 758, 759, 760,
+	761, 762, 763, 764, 765, 766, 767, 768, 769, 770,
+	681, 337, 338, 339, 340, 341, 342, 343, 344, 345,
+	346, 347, 348, 349, 350, 351, 352, 353, 354, 355,
+	356, 357, 358, 359, 360, 361, 362, 363, 364, 365,
+	366, 367, 368, 369, 370, 371, 372, 373, 374, 375,
+	376, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 161,
+	688, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 735, 0, 0, 0, 0, 0, 737, 0,
-	0, 40, 0, 644, 0, 0, 0, 0, 0, 29,
-	0, 0, 31, 32, 34, 33, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 682, 683, 684,
+	685, 686, 687, 689, 690, 691, 692, 693, 694, 695,
+	696, 697, 698, 699, 700, 701, 702, 703, 704, 705,
+	706, 707, 708, 709, 710, 711, 712, 713, 714, 715,
+	716, 717, 718, 719, 720, 721, 722, 723, 724, 725,
+	726, 727, 728, 729, 730, 731, 732, 733, 734, 735,
+	736, 737, 738, 739, 740, 741, 742, 743, 744, 745,
+	746, 747, 748, 749, 750, 751, 752, 753, 754, 755,
+	756, 757, 758, 759, 760, 761, 762, 763, 764, 765,
+	766, 767, 768, 769, 770, 688, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 158,
-	638, 639, 640, 641, 642, 643, 645, 646, 647, 648,
-	649, 650, 651, 652, 653, 654, 655, 656, 657, 658,
-	659, 660, 661, 662, 663, 664, 665, 666, 667, 668,
-	669, 670, 671, 672, 673, 674, 675, 676, 677, 678,
-	679, 680, 681, 682, 683, 684, 685, 686, 687, 688,
-	689, 690, 691, 692, 693, 694, 695, 696, 697, 698,
-	699, 700, 701, 702, 703, 704, 705, 706, 707, 708,
-	709, 710, 711, 712, 713, 714, 715, 716, 717, 718,
-	719, 720, 721, 722, 723, 724, 171, 172, 173, 174,
-	175, 176, 177, 178, 179, 180, 181, 182, 183, 184,
-	185, 186, 187, 188, 189, 190, 191, 192, 193, 194,
-	195, 196, 197, 198, 199, 200, 201, 202, 203, 204,
-	205, 206, 207, 208, 209, 210, 0, 0, 384, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 568,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 682, 683, 684, 685, 686, 687, 689, 690,
+	691, 692, 693, 694, 695, 696, 697, 698, 699, 700,
+	701, 702, 703, 704, 705, 706, 707, 708, 709, 710,
+	711, 712, 713, 714, 715, 716, 717, 718, 719, 720,
+	721, 722, 723, 724, 725, 726, 727, 728, 729, 730,
+	731, 732, 733, 734, 735, 736, 737, 738, 739, 740,
+	741, 742, 743, 744, 745, 746, 747, 748, 749, 750,
+	751, 752, 753, 754, 755, 756, 757, 758, 759, 760,
+	761, 762, 763, 764, 765, 766, 767, 768, 769, 770,
 }
-var yyPact = [...]int{
 
-	1131, -1000, -1000, 708, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, 820, -1000, -1000, 52, -1000, -1000, -1000, -1000, -1000,
-	1218, -1000, -1000, -1000, -1000, -1000, 221, -1000, -1000, 361,
-	130, 1136, 1091, -1000, -1000, -1000, -1000, 1087, -1000, 468,
-	1080, -1000, 25, -1000, -1000, 361, -53, 361, 1139, 1039,
-	708, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -63, -53, -11, -27, -1000, -1000, -1000, -1000,
-	-1000, 915, 914, -1000, -1000, 120, -1000, 820, 513, 959,
-	1483, 1483, -1000, -1000, 957, 549, 549, 54, 549, 549,
-	682, 139, 65, 1138, 1135, 63, 61, 1134, 1133, 1125,
-	1121, 321, -1000, 58, -1000, -1000, 250, 1032, -1000, 953,
-	361, 361, -69, -34, -1000, -1000, -31, 361, -73, 361,
-	-1000, -1000, 760, -1000, -1000, 245, 304, 570, 1275, -1000,
-	554, 405, -1000, -1000, -1000, 1166, -1000, -1000, 818, -1000,
-	-1000, -1000, -1000, 817, -1000, -1000, -1000, -1000, 816, 814,
-	1166, -1000, -1000, 692, 215, -1000, 428, -1000, 243, 1483,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -3, 549, -1000, 1166, 554, -1000, 549, 549, -1000,
-	-1000, -1000, 361, 680, 1119, 1116, -1000, 657, 361, 361,
-	549, 549, 361, 361, 361, 361, 361, 361, 361, 361,
-	361, 361, -1000, 361, 361, 294, 1110, 756, 361, 477,
-	361, 361, -51, 361, 1075, 585, -1000, 297, 120, 1166,
-	497, -1000, -1000, 361, 554, 554, 1166, 812, 511, 1166,
-	1166, 561, 1166, 1166, 1166, 1166, 1166, 1166, 1166, 1166,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 1275, 1,
-	126, 9, 1275, -1000, 1067, -1000, 1136, 69, 1166, 1166,
-	300, 1192, 294, 205, 1166, 361, -1000, 927, -1000, 1192,
-	570, -1000, -1000, 549, -1000, 361, 361, 361, -1000, 361,
-	549, 549, -1000, -1000, 1110, 1110, 1110, 549, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, 748, 736, 1102, 554, 1057,
-	294, 294, 813, 1073, -80, 92, 361, 158, -1000, 361,
-	734, 609, 304, 553, -1000, -1000, -1000, 783, -1000, -1000,
-	-1000, -1000, 496, 1192, -1000, 812, 1166, 1166, 1192, 1179,
-	-1000, 1062, 527, 603, -1000, 537, 537, 254, 254, 254,
-	-1000, -1000, 1166, -1000, 1192, -1000, -184, 124, 1166, 1154,
-	123, 536, -1000, 554, -1000, 486, 1192, -1000, -1000, 549,
-	549, 549, 549, -1000, -1000, -1000, -1000, -1000, -1000, 1057,
-	294, 1102, 1094, 1099, 570, -1000, 812, 708, 692, 101,
-	-1000, 233, -1000, 577, -1000, -79, -1000, 713, -1000, 225,
-	146, -173, -174, 193, 13, 11, -1000, 413, 411, 211,
-	952, 380, 377, 351, -1000, -1000, -1000, -1000, -1000, 1048,
-	-143, -1000, 1108, 297, 297, -1000, -1000, 598, 596, 623,
-	622, 618, 259, 22, 1166, 1166, -1000, 1192, 795, 1166,
-	-1000, 1192, -1000, -1000, 93, 1166, -1000, 235, -1000, 1166,
-	512, -1000, 244, 206, -1000, -1000, -1000, -1000, -1000, 574,
-	568, 1094, -1000, 1166, 712, -1000, -1000, 294, 90, -1000,
-	430, -99, 361, 361, 361, 361, -1000, -1000, 92, -1000,
-	294, 361, 361, -106, 294, 294, 294, 1016, 361, 361,
-	1015, -1000, -1000, 361, 911, 948, 332, 291, 286, 1483,
-	1357, 926, -1000, -1000, 1106, 1098, 609, 1230, -1000, 617,
-	-1000, 605, -1000, -1000, -1000, -1000, -38, -40, -46, -1000,
-	1192, 1192, 1166, 1192, -1000, -15, -1000, 1192, 1166, -1000,
-	-1000, -1000, -1000, 1018, -1000, -1000, 710, -1000, 1132, 812,
-	-1000, 225, 233, -1000, 196, 811, 177, -1000, -1000, 175,
-	173, 172, 169, 168, 167, 164, 157, 152, -1000, 809,
-	807, 806, -1000, 521, 370, 804, 802, 801, 799, -1000,
-	-1000, -1000, -1000, 163, 163, 163, 163, 798, 793, 1002,
-	507, 998, -80, -80, -1000, 792, -1000, 430, -80, -80,
-	994, 506, 989, 294, 430, -1000, -1000, -1000, -1000, 361,
-	-1000, -1000, 285, 1483, 1357, 1483, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, 1102, 554, 1166, 554,
-	-1000, -1000, 791, 788, 787, 1192, -1000, 1192, 975, 1166,
-	-1000, -1000, -1000, -1000, -1000, 225, -1000, 199, 200, 253,
-	-1000, -1000, 1044, 778, 905, -152, 904, -1000, -152, 903,
-	-152, 901, -152, 899, -152, 897, -152, 896, -152, 894,
-	-152, 890, -152, 888, -152, 880, 879, 872, 870, 204,
-	869, -1000, 204, 868, 861, 859, 853, 852, 204, 204,
-	204, 204, 778, 778, -80, -80, 361, 361, 786, 782,
-	781, 294, -161, 779, 777, -80, -80, 361, 361, 773,
-	430, -161, -1000, 1483, -1000, -1000, -1000, 1094, 570, 702,
-	570, 361, 361, 361, 1150, -1000, -127, 965, -1000, 962,
-	199, -120, 199, -120, -1000, -1000, -189, -1000, -1000, -191,
-	-1000, -192, -1000, -195, -1000, -198, -1000, -201, -1000, -202,
-	-1000, 698, -1000, 695, -1000, 694, -1000, 89, -205, -207,
-	-210, -5, 943, -220, -5, -221, -222, -224, -233, -235,
-	-5, -5, -5, -5, 81, -1000, 80, 772, 767, -80,
-	-80, 294, 294, 294, 78, -1000, 771, -1000, -1000, 294,
-	294, 294, 294, 765, 764, -80, -80, 294, -161, -1000,
-	-1000, 950, 74, -1000, 73, 72, 294, -129, 918, -1000,
-	-1000, -127, 199, -127, 199, -1000, -146, -146, -146, -146,
-	-146, -146, 850, 849, 843, -146, 832, -1000, -1000, -1000,
-	-1000, 1357, 1483, 163, -1000, 163, 163, 163, -1000, -1000,
-	-1000, -1000, -1000, -1000, 778, 204, 204, 294, 294, 757,
-	753, 70, 66, 62, -80, 294, -1000, 831, -1000, -1000,
-	57, 50, 294, 294, 752, 751, 47, -1000, -1000, 1149,
-	274, -1000, 361, -1000, -1000, 692, 32, 242, -1000, -129,
-	-127, -129, -127, -152, -152, -152, -152, -152, -152, -238,
-	-240, -254, -152, -255, -1000, -1000, 204, 204, 204, 204,
-	-1000, -5, -5, 41, 40, 294, 294, -125, -1000, -1000,
-	-1000, -1000, -1000, -280, -1000, -1000, 39, 38, 294, 294,
-	-125, -1000, 361, -1000, -125, 190, -1000, -1000, -1000, 32,
-	-129, 32, -129, -1000, -1000, -1000, -1000, -1000, -1000, -146,
-	-146, -146, -1000, -146, -5, -5, -5, -5, -1000, -1000,
-	-127, -1000, 37, 36, -1000, 361, 1045, -1000, -1000, 33,
-	12, -1000, 361, -1000, -1000, -1000, -1000, -1000, -125, 32,
-	-125, 32, -152, -152, -152, -152, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, 744, -1000, -1000, -1000, -1000, -1000, -125,
-	-1000, -125, -1000, -1000, -1000, -1000, 294, -1000, -1000, 10,
-	-135, 558, 197, -1000, 1114, -1000, -1000, -1000, 158, 158,
-	555, 538, 1142, 1115, 158, 158, -1000, -1000,
+var yyPact = [...]int16{
+	3750, -32768, -32768, 1197, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, 1196, -32768, 186, -32768,
+	350, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, 804, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, 760, -32768, 67, 4690,
+	599, 4690, 234, 4690, 4690, 1539, 1203, 1639, -32768, -32768,
+	-32768, -32768, 1637, -32768, 4690, -32768, 696, 1538, 1537, 2426,
+	-32768, 257, -32768, -32768, 4690, 58, 4690, 1709, 1614, 4690,
+	4690, 4690, 226, 75, 4690, 3631, 3631, 361, 242, 1197,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, 535, -32768, -32768, -32768, 109, 4599, 1536, 1536, 106,
+	1536, 154, 124, -32768, 1535, 4780, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, 4690,
+	-32768, -32768, 1430, 1428, -32768, 1271, 1533, -32768, -32768, 2784,
+	-32768, 1196, 1155, -32768, 1178, 4481, 1582, 5170, 5170, -32768,
+	-32768, -32768, 1518, 1581, 861, 861, 486, 861, 861, 884,
+	495, 356, 1708, 1705, 327, 309, 1704, 1703, 1702, 1701,
+	457, -32768, 281, 1641, 1643, 1643, -32768, -32768, 693, 1612,
+	-32768, 1573, 4690, 4690, 1332, 1695, 41, 4690, 47, 4690,
+	1528, 47, 4690, 47, 47, 47, -32768, 948, -32768, 3496,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, 943, 45, 1527, 45, 87, -32768, -32768, 47, 1524,
+	105, 1519, 54, 58, 469, 4690, 4690, -32768, 93, -32768,
+	89, 4690, 78, 4690, 4690, -32768, -32768, 4690, -32768, 4690,
+	-32768, -32768, -32768, 1693, -32768, -32768, -32768, -32768, -32768, 1381,
+	-32768, -32768, -32768, 1226, -32768, -32768, 690, 4196, 923, 1846,
+	-32768, 2875, 2362, -32768, 128, 937, -32768, 3340, 3340, 82,
+	-32768, 3340, 1330, 1329, 1021, -32768, -32768, -32768, -32768, 1328,
+	1327, 3340, 1325, -32768, -32768, -32768, 1197, 4690, 1323, 4690,
+	1127, 625, -32768, 843, 786, 5170, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, 707, -32768, 861,
+	-32768, 3340, 2875, -32768, 861, 861, -32768, -32768, -32768, 4690,
+	868, 1691, 1688, -32768, 856, 4690, 4690, 861, 861, 4690,
+	4690, 4690, 4690, 4690, 4690, 4690, 4690, 4690, 4690, -32768,
+	1437, -32768, 3340, -32768, 4690, 4690, 4640, 1680, 1211, -32768,
+	3196, 4457, -32768, 3340, -32768, 1427, 1631, -32768, 47, 4690,
+	942, 4690, 4690, 4690, 2337, 115, 3631, -32768, -32768, 4640,
+	115, 1427, 858, 45, 4690, 4690, 1427, 4259, 4690, 1518,
+	66, -32768, 4690, 4690, 1107, -32768, 4690, 1119, -32768, 820,
+	1119, -32768, -32768, 4690, -32768, -32768, -32768, -32768, 4136, 2784,
+	4399, -32768, -32768, 4690, 2875, 2875, 2875, 2875, 2875, 2875,
+	3340, 1311, 803, 3340, 3340, 3340, 930, 3340, 3340, 3340,
+	3340, 3340, 3340, 3340, 3340, 3340, 3340, 3340, 5256, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, 5054, -32768, 668, 112,
+	212, 107, 1846, 1489, 1486, 3340, 2481, -32768, 3009, -32768,
+	1322, 1764, 3340, -32768, 1203, 3340, 3340, 3340, 854, 1789,
+	4640, -32768, 1203, 211, -32768, 4738, 548, 2071, 4690, 838,
+	832, -32768, 5041, -32768, 1789, 923, 1846, -32768, -32768, 861,
+	-32768, 4690, 4690, 4690, -32768, 4690, 861, 861, -32768, -32768,
+	1680, 1680, 1680, 861, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, 1188, 1516, 1302, -32768, 1210, 1166, -32768, 828, -32768,
+	1673, 2875, 1270, 4640, -32768, 210, 1789, -32768, -32768, 1058,
+	1091, -32768, 1482, -32768, 4259, 259, 4690, -32768, -32768, -32768,
+	1480, -32768, -32768, 4340, -32768, -32768, -32768, -32768, 209, -32768,
+	4340, 449, -32768, 213, 1630, 4259, 1321, 36, 449, -32768,
+	-32768, -32768, 1597, 4690, 1107, 1107, 1506, 4690, 1107, 4690,
+	-32768, 4690, 718, 1514, 64, 1147, 1173, 4196, 3264, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, 5054, 882, 3739, 864,
+	4741, -32768, 5054, 882, 3739, 864, 4741, 1789, -32768, 1311,
+	3340, 3340, 3340, 1789, 1789, 2033, -32768, 1625, 1095, 981,
+	674, 704, 990, 990, 757, 757, 757, 757, 757, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, 4690, -32768, -32768, 3340, -32768, -32768, -32768, 1789, 1871,
+	-32768, -124, 161, 3340, 176, -32768, -32768, 953, 1789, 1319,
+	207, 840, -32768, 2875, 205, 103, 1635, 4690, -32768, 726,
+	-32768, 1789, -32768, -32768, -32768, 3340, 826, 2071, 2071, -32768,
+	-32768, -32768, 861, 861, 861, 861, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -125, 1479, 3340, 3340, 1270, 4640, 1673,
+	4640, 3340, 1643, 1671, 923, -32768, 1311, 1197, 1009, -32768,
+	1427, -32768, -32768, -32768, -32768, -32768, 1621, -72, 362, 90,
+	60, 664, 662, -32768, 4640, 1682, -32768, 1427, 4690, -32768,
+	1191, -32768, -32768, 2387, 939, -32768, 38, -32768, 602, 127,
+	1104, -32768, 397, 312, -91, -94, 420, -100, 146, 1513,
+	239, 188, -32768, 825, 824, 677, 1572, 822, 821, 815,
+	-32768, -32768, 1504, -32768, 1506, -32768, 718, -32768, -32768, -32768,
+	4690, 1677, 4136, 4136, -32768, -32768, 998, 997, 1005, 973,
+	963, 418, 61, -32768, 1789, 1789, 869, 3340, -32768, 1789,
+	450, -32768, -32768, 1670, 1477, 79, 1673, 1669, 450, 5170,
+	3340, -32768, 642, -32768, 3340, 1099, 4690, -32768, 1320, -32768,
+	-32768, 643, 521, -32768, 2071, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, 1789, 1789, 928, 921, 1643, -32768, 1789,
+	-32768, 3315, 1103, -32768, -32768, -32768, -32768, -32768, 362, -32768,
+	806, 799, 1611, -32768, -32768, 1427, 719, 4116, -32768, 1427,
+	-32768, 4079, -32768, 1476, 4003, 4690, 602, 204, -32768, 4853,
+	-38, 4690, 4690, -32768, 4690, 4690, -32768, -32768, 1667, 4690,
+	1500, -32768, -32768, 1665, 1597, -32768, 4640, 4690, 4690, -40,
+	-32768, 1318, 4640, 4640, 4640, 1607, 4690, 4690, 1606, 4690,
+	4690, 4690, 4690, 4690, 4690, -32768, -32768, -32768, 4690, 1424,
+	1571, 795, 794, 783, 5170, 5381, 1473, -32768, -32768, -32768,
+	1675, 1661, 1173, 1156, -32768, 959, -32768, 957, -32768, -32768,
+	-32768, -32768, 83, 81, 63, -32768, 3340, 1789, -130, 1315,
+	1315, 1315, -32768, 1315, 1315, -32768, 1317, -32768, 1315, -32768,
+	18, 14, 3315, -132, -32768, 1660, 1470, -138, 3340, -140,
+	-154, 214, -32768, 1789, 3340, 1312, 1203, -32768, -32768, -32768,
+	-32768, -32768, 1609, -32768, -32768, 1092, -32768, 1751, 1618, 1311,
+	-32768, 3886, 3867, 65, 1123, -32768, -32768, -32768, 1091, -32768,
+	4690, -32768, -32768, 1469, 1634, 397, 2387, -32768, 455, 1307,
+	302, -32768, -32768, 301, 292, 291, 284, 279, 277, 253,
+	249, 246, -32768, 1306, 1305, 1304, -32768, 634, 592, 1303,
+	1301, 1297, 1296, -32768, -32768, -32768, -32768, 505, 505, 505,
+	505, 1293, 1290, -32768, 1605, 3684, 1603, 1285, 36, 36,
+	-32768, 1283, 1462, 1089, -32768, 329, -32768, 4853, 36, 36,
+	1601, 1981, 1596, 122, 4640, 4853, -32768, -32768, -32768, -32768,
+	4690, -32768, -32768, 1089, 913, 913, 1089, -32768, -32768, 782,
+	5170, 5381, 5170, -32768, -32768, -32768, 1673, 2875, 3340, 2875,
+	-32768, -32768, 1281, 1279, 1276, 1789, -32768, -32768, 1423, 512,
+	-32768, -32768, -32768, -32768, 1422, -32768, -32768, -32768, 435, -32768,
+	3315, -161, -32768, 1058, -32768, -32768, -32768, 1789, 3340, 86,
+	1588, 3315, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, 4690, -32768, 220, -32768, -32768, 1461, 1459, 127, 397,
+	-32768, 358, 304, 402, 1633, -32768, -32768, 1576, 1271, 1497,
+	1421, -80, 1420, -32768, -80, 1418, -80, 1417, -80, 1414,
+	-80, 1409, -80, 1408, -80, 1407, -80, 1405, -80, 1404,
+	-80, 1402, 1397, 1396, 1392, 423, 1390, -32768, 423, 1389,
+	1388, 1387, 1382, 1364, 423, 423, 423, 423, 1271, 1271,
+	36, 36, 4690, 4690, 1275, 2875, 1274, 1262, 4640, -32768,
+	1496, 451, 1259, 1258, -87, 1257, 1256, 36, 36, 4690,
+	4690, 1255, 203, -32768, 4690, 4853, -87, -32768, -32768, -32768,
+	1495, -32768, 5170, -32768, -32768, -32768, 1643, 923, 1058, 923,
+	4690, 4690, 4690, -170, 1570, 202, -172, 1455, 435, -32768,
+	1042, -32768, 1717, -32768, 630, 217, -32768, -32768, -32768, -56,
+	1587, -32768, 1586, 358, -16, 358, -16, 1240, -32768, -32768,
+	-32768, -173, -32768, -32768, -174, -32768, -180, -32768, -184, -32768,
+	-186, -32768, -187, -32768, -188, -32768, 1039, -32768, 1037, -32768,
+	1010, -32768, 201, -189, -190, -203, 701, 1567, -205, 701,
+	-208, -209, -214, -216, -231, 701, 701, 701, 701, 199,
+	-32768, 197, 1238, 1236, 36, 36, 4640, 24, 4640, 4640,
+	183, -32768, 1273, 1227, 1363, 3340, 1225, 1222, 1221, 3340,
+	1800, -32768, -32768, 4640, 4640, 4640, 4640, 1220, 1214, 36,
+	36, 4640, 122, -32768, 624, -87, -32768, -32768, -32768, 1568,
+	182, 181, 178, -32768, 5170, 1350, -32768, -32768, -260, -270,
+	290, -98, 4640, 323, 1563, 5170, -32768, -59, 1454, -32768,
+	-32768, -56, 358, -56, 358, 3340, -32768, -76, -76, -76,
+	-76, -76, -76, 1348, 1346, 1345, -76, 1309, -32768, -32768,
+	-32768, -32768, 5381, 5170, 505, -32768, 505, 505, 505, -32768,
+	-32768, -32768, -32768, -32768, -32768, 1271, 423, 423, 4640, 4640,
+	1212, 1209, 175, 913, 174, 173, 36, 4640, -32768, 1278,
+	-32768, 122, -32768, 151, 4640, 3340, 546, 102, -32768, 171,
+	-32768, -32768, 170, 160, 4640, 4640, 1208, 1207, 159, -32768,
+	-32768, 792, -32768, -32768, 1716, 753, -32768, -32768, -32768, -32768,
+	-277, -32768, -32768, 4690, 4690, 4690, 1009, 195, -32768, -32768,
+	5170, -32768, 270, 319, -32768, -59, -56, -59, -56, 76,
+	-80, -80, -80, -80, -80, -80, -281, -286, -291, -80,
+	-293, -32768, -32768, 423, 423, 423, 423, -32768, 701, 701,
+	157, 156, 4640, 4640, -26, -32768, -32768, -32768, -32768, 362,
+	-32768, -32768, -295, 155, -32768, 131, 52, -32768, 108, -32768,
+	-32768, -32768, -32768, 77, 74, 4640, 4640, -26, 1494, 1181,
+	-32768, 4690, -32768, 4690, -32768, -32768, 51, -32768, 286, 286,
+	-32768, -26, 289, -32768, -32768, -32768, 270, -59, 270, -59,
+	1492, -32768, -32768, -32768, -32768, -32768, -32768, -76, -76, -76,
+	-32768, -76, 701, 701, 701, 701, -32768, -32768, -56, -32768,
+	73, 72, -32768, 4690, -32768, 1618, -32768, -32768, -32768, -32768,
+	-32768, -32768, 71, 62, -32768, 1204, 3340, 4690, 1134, 1177,
+	1233, 221, 1659, 1657, 165, 1651, -83, -32768, -32768, -32768,
+	-32768, -26, 270, -26, 270, 417, -32768, -80, -80, -80,
+	-80, -32768, -32768, -32768, -32768, -32768, -32768, -32768, 1158, -32768,
+	-32768, -32768, 3340, 397, 53, -32768, -102, 1547, 3813, 1648,
+	1646, 1453, 1448, 1645, 1447, -32768, -32768, -117, -83, -26,
+	-83, -26, -56, 358, -32768, -32768, -32768, -32768, 4640, 42,
+	-32768, 397, 4690, -32768, 4640, -32768, -32768, 1445, 1444, -32768,
+	-32768, 1442, -32768, -32768, -83, -32768, -83, -26, -56, 32,
+	397, -32768, -32768, 1009, -32768, -32768, -32768, -32768, -32768, -83,
+	-26, -65, -32768, -32768, -83, 912, 266, -32768, -32768, 1687,
+	-32768, -32768, -32768, 259, 259, 889, 871, 1715, 1713, 259,
+	259, -32768, -32768,
 }
-var yyPgo = [...]int{
 
-	0, 1311, 1308, 46, 1059, 1058, 1051, 1048, 1039, 1030,
-	1024, 1022, 987, 984, 974, 1307, 1306, 1305, 1304, 1303,
-	1301, 1265, 696, 1296, 1286, 523, 1285, 263, 48, 1276,
-	1264, 26, 1263, 1254, 42, 1253, 12, 39, 43, 1252,
-	1250, 41, 7, 899, 33, 24, 1248, 1246, 34, 1243,
-	27, 1242, 1241, 44, 1240, 1239, 1227, 1220, 1217, 35,
-	1216, 23, 9, 15, 1207, 49, 1196, 37, 20, 205,
-	333, 1195, 1193, 1192, 3, 74, 1191, 4, 30, 0,
-	5, 14, 1189, 644, 19, 45, 16, 21, 8, 6,
-	2, 1188, 1185, 1, 1184, 153, 29, 25, 1183, 36,
-	1171, 1168, 13, 17, 10, 91, 11, 31, 28, 1167,
-	38, 18, 32, 1165, 1161, 22, 1140,
+var yyPgo = [...]int16{
+	0, 1895, 1894, 54, 1893, 343, 1892, 1139, 1123, 1120,
+	1116, 1112, 1053, 1036, 1891, 1035, 1034, 1032, 976, 1887,
+	1886, 1871, 1870, 78, 46, 2, 18, 1868, 1866, 1865,
+	42, 1864, 22, 17, 1863, 1862, 507, 56, 1861, 1848,
+	1835, 1833, 1832, 1831, 1830, 1829, 1827, 1826, 1825, 1824,
+	1823, 745, 76, 1822, 1821, 739, 81, 1820, 602, 88,
+	68, 51, 66, 1819, 1817, 1816, 1807, 80, 64, 1806,
+	82, 1805, 48, 1804, 1803, 1802, 1801, 14, 1800, 1799,
+	1797, 1796, 3150, 825, 1794, 1793, 848, 1790, 77, 73,
+	1788, 1787, 67, 1784, 1783, 291, 84, 1782, 37, 79,
+	185, 1781, 371, 63, 38, 1506, 53, 15, 1779, 1778,
+	28, 65, 1776, 50, 1771, 41, 1770, 58, 57, 1769,
+	71, 1768, 1766, 1765, 1764, 1763, 1761, 40, 35, 39,
+	16, 25, 1760, 9, 24, 62, 5, 1758, 69, 87,
+	60, 52, 61, 126, 99, 86, 1756, 1755, 26, 33,
+	1753, 19, 10, 0, 183, 30, 1752, 1751, 807, 32,
+	21, 3, 23, 8, 11, 4, 1742, 1741, 1, 1740,
+	34, 157, 45, 1738, 49, 1735, 1732, 27, 13, 36,
+	20, 7, 110, 31, 1730, 44, 43, 47, 6, 59,
+	1729, 12, 1727, 29, 1726, 1725,
 }
-var yyR1 = [...]int{
 
+var yyR1 = [...]uint8{
 	0, 1, 2, 2, 2, 2, 2, 2, 2, 2,
 	2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
-	2, 3, 3, 3, 11, 11, 14, 14, 12, 13,
-	19, 19, 19, 19, 19, 19, 19, 19, 19, 19,
-	19, 19, 4, 4, 4, 4, 4, 4, 15, 15,
-	16, 17, 18, 20, 20, 20, 7, 7, 8, 9,
-	10, 10, 5, 5, 5, 5, 5, 5, 5, 5,
-	5, 5, 5, 5, 5, 5, 5, 5, 5, 5,
-	5, 5, 5, 5, 5, 5, 5, 5, 5, 5,
-	5, 5, 5, 5, 5, 5, 5, 5, 6, 116,
-	21, 22, 22, 23, 23, 23, 23, 23, 24, 24,
-	26, 26, 27, 27, 27, 29, 29, 28, 28, 28,
-	30, 30, 31, 31, 31, 32, 32, 32, 32, 32,
-	32, 32, 32, 32, 33, 33, 34, 34, 35, 35,
-	35, 35, 36, 36, 102, 102, 37, 37, 38, 38,
-	38, 38, 38, 39, 39, 39, 39, 39, 39, 39,
-	39, 39, 39, 40, 40, 40, 40, 40, 40, 40,
-	41, 41, 46, 46, 44, 44, 48, 45, 45, 43,
-	43, 43, 43, 43, 43, 43, 43, 43, 43, 43,
-	43, 43, 43, 54, 54, 54, 54, 54, 54, 47,
-	47, 49, 49, 49, 51, 55, 55, 52, 52, 53,
-	56, 56, 50, 50, 42, 42, 42, 42, 57, 57,
-	58, 58, 59, 59, 60, 60, 61, 62, 62, 62,
-	63, 63, 63, 63, 64, 64, 64, 65, 65, 66,
-	66, 67, 67, 68, 68, 69, 71, 71, 72, 72,
-	25, 25, 73, 73, 73, 78, 78, 77, 77, 75,
-	75, 74, 74, 76, 76, 79, 79, 80, 80, 80,
-	80, 80, 80, 80, 80, 80, 80, 80, 80, 80,
-	80, 80, 80, 80, 80, 80, 80, 80, 80, 80,
-	80, 80, 80, 80, 80, 80, 80, 80, 80, 80,
-	80, 80, 80, 80, 80, 80, 80, 81, 81, 81,
-	81, 81, 81, 81, 81, 81, 81, 81, 81, 81,
-	81, 81, 81, 81, 81, 81, 81, 81, 81, 81,
-	81, 81, 81, 81, 81, 81, 81, 81, 81, 81,
-	81, 81, 81, 81, 81, 81, 81, 81, 81, 81,
-	81, 81, 81, 81, 81, 81, 81, 81, 81, 81,
-	81, 81, 81, 81, 81, 81, 81, 81, 81, 81,
-	81, 81, 81, 81, 81, 81, 81, 81, 81, 81,
-	81, 81, 81, 81, 81, 81, 81, 81, 81, 81,
-	81, 81, 81, 81, 82, 82, 82, 82, 83, 83,
-	83, 70, 70, 70, 98, 98, 97, 97, 97, 97,
-	97, 97, 97, 97, 108, 108, 108, 108, 108, 109,
-	109, 109, 109, 109, 109, 109, 109, 109, 109, 109,
-	109, 109, 109, 109, 109, 109, 109, 109, 109, 109,
-	109, 109, 109, 109, 109, 109, 109, 109, 109, 109,
-	109, 109, 109, 109, 109, 109, 109, 109, 109, 109,
-	109, 109, 109, 109, 109, 109, 109, 109, 109, 109,
-	109, 103, 103, 84, 104, 104, 86, 86, 86, 86,
-	86, 85, 85, 87, 87, 87, 87, 88, 88, 88,
-	88, 90, 90, 89, 91, 91, 91, 91, 92, 92,
-	92, 92, 92, 94, 94, 93, 93, 93, 93, 105,
-	105, 106, 106, 107, 107, 95, 95, 96, 96, 110,
-	110, 113, 113, 112, 112, 111, 111, 111, 111, 111,
-	111, 111, 111, 111, 101, 101, 100, 100, 99, 99,
-	99, 99, 99, 99, 99, 99, 99, 99, 99, 99,
-	99, 99, 99, 99, 99, 99, 115, 115, 114, 114,
+	2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
+	2, 2, 2, 2, 2, 2, 3, 3, 3, 3,
+	4, 4, 6, 6, 5, 5, 15, 15, 18, 18,
+	19, 20, 20, 20, 49, 73, 73, 73, 74, 74,
+	74, 75, 75, 75, 76, 76, 76, 77, 77, 77,
+	77, 77, 78, 78, 79, 79, 79, 80, 80, 80,
+	81, 81, 64, 65, 66, 67, 67, 68, 69, 69,
+	69, 69, 69, 69, 70, 70, 71, 71, 71, 72,
+	72, 53, 54, 55, 55, 56, 57, 57, 58, 58,
+	58, 58, 58, 58, 58, 58, 58, 58, 58, 58,
+	58, 58, 58, 58, 58, 58, 58, 58, 59, 59,
+	59, 59, 60, 60, 60, 60, 60, 61, 61, 62,
+	62, 62, 62, 62, 63, 63, 45, 45, 46, 48,
+	48, 47, 47, 16, 17, 43, 43, 43, 43, 43,
+	43, 43, 43, 43, 43, 43, 43, 7, 7, 7,
+	7, 7, 7, 7, 21, 21, 36, 36, 23, 23,
+	23, 37, 37, 37, 22, 22, 38, 38, 39, 40,
+	40, 40, 41, 41, 42, 44, 44, 44, 44, 44,
+	44, 44, 44, 44, 44, 44, 44, 44, 139, 139,
+	140, 140, 140, 140, 10, 10, 11, 12, 50, 50,
+	50, 50, 51, 51, 52, 52, 52, 14, 14, 13,
+	13, 13, 13, 13, 8, 8, 8, 8, 8, 8,
+	8, 8, 8, 8, 8, 8, 8, 8, 8, 8,
+	8, 8, 8, 8, 8, 8, 8, 8, 8, 8,
+	8, 8, 8, 8, 8, 8, 8, 8, 8, 8,
+	8, 8, 8, 8, 8, 8, 9, 194, 82, 83,
+	83, 84, 84, 84, 84, 84, 85, 85, 87, 87,
+	88, 88, 88, 90, 90, 89, 89, 89, 91, 91,
+	92, 92, 92, 93, 93, 93, 93, 93, 93, 93,
+	93, 93, 94, 94, 95, 95, 96, 96, 97, 97,
+	97, 97, 98, 98, 177, 177, 99, 99, 100, 100,
+	100, 100, 100, 100, 100, 100, 100, 100, 100, 100,
+	100, 100, 100, 100, 101, 101, 101, 101, 101, 101,
+	101, 101, 101, 101, 101, 101, 101, 101, 102, 102,
+	102, 102, 102, 102, 102, 103, 103, 108, 108, 106,
+	106, 111, 107, 107, 105, 105, 105, 105, 105, 105,
+	105, 105, 105, 105, 105, 105, 105, 105, 105, 105,
+	105, 105, 105, 105, 105, 105, 105, 105, 105, 105,
+	105, 105, 121, 121, 121, 121, 121, 121, 121, 121,
+	121, 121, 117, 117, 117, 117, 117, 117, 117, 117,
+	117, 117, 117, 117, 117, 117, 117, 117, 117, 118,
+	118, 122, 122, 110, 110, 115, 116, 116, 116, 116,
+	116, 109, 109, 109, 112, 112, 112, 114, 123, 123,
+	119, 119, 120, 124, 124, 113, 113, 104, 104, 104,
+	104, 104, 104, 104, 104, 104, 104, 125, 125, 126,
+	126, 127, 127, 128, 128, 129, 129, 130, 130, 130,
+	131, 131, 131, 131, 132, 132, 132, 133, 133, 134,
+	134, 135, 135, 137, 137, 138, 138, 138, 138, 141,
+	141, 141, 141, 136, 136, 142, 144, 144, 145, 145,
+	86, 86, 147, 147, 147, 152, 152, 151, 151, 149,
+	149, 148, 148, 150, 150, 191, 191, 190, 190, 189,
+	189, 189, 189, 153, 153, 153, 146, 146, 146, 146,
+	146, 146, 146, 146, 146, 146, 146, 146, 146, 146,
+	146, 146, 146, 146, 146, 154, 154, 154, 154, 154,
+	154, 154, 154, 154, 154, 154, 154, 154, 154, 154,
+	154, 154, 154, 154, 154, 154, 154, 154, 154, 154,
+	154, 154, 154, 154, 154, 154, 154, 154, 154, 154,
+	154, 154, 154, 154, 154, 156, 156, 155, 155, 155,
+	155, 155, 155, 155, 155, 155, 155, 155, 155, 155,
+	155, 155, 155, 155, 155, 155, 155, 155, 155, 155,
+	155, 155, 155, 155, 155, 155, 155, 155, 155, 155,
+	155, 155, 155, 155, 155, 155, 155, 155, 155, 155,
+	155, 155, 155, 155, 155, 155, 155, 155, 155, 155,
+	155, 155, 155, 155, 155, 155, 155, 155, 155, 155,
+	155, 155, 155, 155, 155, 155, 155, 155, 155, 155,
+	155, 155, 155, 155, 155, 155, 155, 155, 155, 155,
+	155, 155, 155, 155, 155, 155, 157, 157, 157, 157,
+	158, 158, 158, 143, 143, 143, 173, 173, 172, 172,
+	172, 172, 172, 172, 172, 172, 172, 25, 25, 24,
+	27, 27, 26, 26, 183, 183, 183, 183, 183, 183,
+	183, 195, 195, 28, 28, 184, 184, 184, 184, 184,
+	184, 184, 184, 184, 184, 184, 184, 184, 184, 184,
+	184, 184, 184, 184, 184, 184, 184, 184, 184, 184,
+	184, 184, 184, 184, 184, 184, 184, 184, 184, 184,
+	184, 184, 184, 184, 184, 184, 184, 184, 184, 184,
+	184, 184, 184, 184, 184, 184, 184, 184, 178, 178,
+	159, 179, 179, 161, 161, 161, 161, 161, 160, 160,
+	162, 162, 162, 162, 163, 163, 163, 163, 165, 165,
+	164, 166, 166, 166, 166, 167, 167, 167, 167, 167,
+	169, 169, 168, 168, 168, 168, 180, 180, 181, 181,
+	182, 182, 170, 170, 171, 171, 185, 185, 188, 188,
+	187, 187, 186, 186, 186, 186, 186, 186, 186, 186,
+	186, 30, 30, 29, 31, 31, 31, 31, 31, 31,
+	31, 31, 35, 35, 34, 34, 33, 33, 32, 32,
+	32, 32, 176, 176, 175, 175, 174, 174, 174, 174,
+	174, 174, 174, 174, 174, 174, 174, 174, 174, 174,
+	174, 174, 174, 174, 174, 174, 174, 174, 174, 174,
+	174, 174, 174, 193, 193, 192, 192,
 }
-var yyR2 = [...]int{
 
+var yyR2 = [...]int8{
 	0, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 5, 12, 3, 8, 8, 6, 6, 8, 7,
-	2, 2, 2, 2, 2, 2, 2, 2, 2, 2,
-	2, 2, 4, 5, 4, 4, 6, 7, 1, 2,
-	1, 1, 2, 2, 3, 3, 9, 12, 6, 6,
-	6, 6, 5, 4, 4, 5, 5, 4, 4, 4,
-	6, 5, 7, 5, 7, 6, 6, 7, 7, 5,
-	5, 6, 6, 6, 6, 5, 5, 5, 5, 5,
-	5, 3, 4, 4, 2, 3, 2, 2, 3, 0,
-	2, 0, 2, 1, 2, 1, 1, 1, 0, 1,
-	1, 3, 1, 3, 2, 1, 1, 0, 1, 2,
-	1, 3, 3, 3, 5, 1, 1, 2, 3, 2,
-	3, 2, 2, 2, 1, 1, 1, 3, 0, 5,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 5, 12, 3, 4,
+	0, 1, 1, 3, 5, 8, 8, 8, 6, 6,
+	4, 0, 2, 3, 16, 0, 2, 2, 0, 1,
+	1, 0, 3, 2, 0, 2, 2, 0, 4, 4,
+	5, 4, 0, 2, 0, 4, 4, 0, 3, 3,
+	0, 2, 6, 6, 5, 1, 3, 2, 0, 3,
+	4, 3, 5, 5, 0, 2, 1, 2, 3, 1,
+	2, 9, 8, 1, 3, 2, 1, 2, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 0, 1,
+	1, 1, 1, 3, 1, 3, 3, 1, 3, 1,
+	2, 3, 1, 2, 0, 3, 5, 5, 4, 0,
+	2, 4, 4, 8, 7, 2, 2, 2, 2, 2,
+	2, 2, 2, 2, 2, 2, 2, 4, 5, 4,
+	4, 6, 6, 7, 4, 4, 1, 3, 2, 4,
+	3, 1, 2, 1, 3, 3, 1, 2, 1, 1,
+	3, 4, 2, 3, 2, 2, 3, 3, 2, 7,
+	7, 6, 6, 3, 4, 3, 3, 2, 1, 1,
+	0, 4, 3, 3, 10, 13, 7, 6, 5, 5,
+	5, 6, 0, 1, 0, 2, 3, 4, 3, 6,
+	7, 5, 5, 5, 5, 4, 4, 5, 5, 4,
+	4, 4, 6, 5, 7, 5, 7, 6, 6, 7,
+	7, 5, 5, 6, 6, 6, 6, 5, 5, 5,
+	5, 5, 5, 3, 4, 4, 2, 3, 2, 2,
+	3, 5, 7, 4, 4, 4, 3, 0, 2, 0,
+	2, 1, 2, 1, 1, 1, 0, 1, 1, 3,
+	1, 3, 2, 1, 1, 0, 1, 2, 1, 3,
+	3, 3, 5, 1, 1, 2, 3, 2, 3, 2,
+	2, 2, 1, 1, 1, 3, 1, 3, 0, 5,
 	5, 5, 1, 3, 1, 3, 0, 2, 1, 3,
-	3, 2, 3, 3, 3, 4, 3, 4, 5, 6,
-	3, 4, 2, 1, 1, 1, 1, 1, 1, 1,
-	2, 1, 1, 3, 3, 1, 3, 1, 3, 1,
-	1, 1, 3, 3, 3, 3, 3, 3, 3, 3,
-	2, 1, 1, 3, 4, 4, 5, 6, 4, 1,
-	1, 1, 1, 1, 5, 0, 1, 1, 2, 4,
-	0, 2, 1, 3, 1, 1, 1, 1, 0, 3,
-	0, 2, 0, 3, 1, 3, 2, 0, 1, 1,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 2, 2, 3, 3, 3, 4, 3, 4, 3,
+	4, 5, 6, 3, 4, 2, 1, 3, 1, 1,
+	1, 1, 1, 1, 1, 2, 1, 1, 3, 3,
+	1, 3, 1, 3, 1, 1, 3, 3, 3, 1,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 2, 3, 2, 1, 6, 1, 3, 3, 6,
+	6, 6, 3, 4, 4, 5, 8, 6, 9, 7,
+	6, 4, 2, 2, 5, 2, 1, 2, 2, 1,
+	2, 6, 1, 2, 1, 1, 2, 1, 2, 0,
+	3, 0, 3, 0, 2, 9, 0, 4, 7, 3,
+	3, 1, 1, 1, 1, 1, 1, 5, 0, 1,
+	1, 2, 4, 0, 2, 1, 3, 1, 1, 1,
+	1, 1, 2, 2, 2, 1, 1, 0, 3, 0,
+	2, 0, 3, 1, 3, 2, 2, 0, 1, 1,
 	0, 2, 4, 4, 0, 2, 4, 0, 3, 1,
-	3, 0, 5, 1, 3, 3, 0, 2, 0, 3,
+	3, 0, 5, 1, 3, 3, 5, 4, 4, 1,
+	1, 1, 1, 1, 3, 3, 0, 2, 0, 3,
 	0, 1, 0, 1, 1, 1, 3, 2, 5, 0,
-	1, 2, 2, 0, 1, 1, 1, 1, 1, 1,
+	1, 2, 2, 0, 1, 0, 1, 1, 2, 3,
+	3, 3, 3, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
@@ -1178,281 +1931,424 @@ var yyR2 = [...]int{
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 1, 2, 2, 2, 1, 0, 1,
-	1, 0, 2, 2, 1, 3, 2, 8, 6, 6,
-	7, 8, 8, 7, 7, 8, 8, 9, 9, 1,
-	4, 3, 6, 1, 1, 3, 6, 3, 6, 3,
-	6, 3, 6, 3, 6, 3, 8, 3, 8, 3,
-	8, 3, 6, 8, 1, 1, 4, 1, 4, 1,
-	4, 1, 4, 4, 7, 7, 7, 7, 1, 4,
-	4, 1, 1, 1, 1, 4, 4, 4, 4, 6,
-	6, 1, 2, 2, 0, 1, 0, 1, 2, 1,
-	2, 0, 2, 0, 2, 2, 2, 0, 2, 2,
-	2, 0, 1, 7, 0, 2, 2, 2, 0, 3,
-	3, 6, 6, 0, 1, 1, 1, 2, 2, 0,
-	1, 0, 1, 0, 1, 0, 3, 0, 2, 0,
-	2, 0, 1, 1, 2, 3, 3, 5, 4, 4,
-	3, 4, 3, 3, 0, 1, 1, 3, 1, 5,
-	7, 7, 8, 8, 9, 9, 8, 6, 5, 3,
-	3, 3, 3, 4, 2, 2, 0, 1, 2, 2,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 2, 2, 2, 1,
+	0, 1, 1, 0, 2, 2, 1, 3, 2, 8,
+	6, 6, 7, 8, 8, 7, 1, 0, 1, 6,
+	0, 1, 1, 2, 8, 9, 9, 10, 10, 11,
+	12, 0, 2, 0, 1, 1, 4, 3, 6, 1,
+	1, 3, 6, 3, 6, 3, 6, 3, 6, 3,
+	6, 3, 8, 3, 8, 3, 8, 3, 6, 8,
+	1, 1, 4, 1, 4, 1, 4, 1, 4, 4,
+	7, 7, 7, 7, 1, 4, 4, 1, 1, 1,
+	1, 4, 4, 4, 4, 6, 6, 1, 1, 2,
+	2, 0, 1, 0, 1, 2, 1, 2, 0, 2,
+	0, 2, 2, 2, 0, 2, 2, 2, 0, 1,
+	7, 0, 2, 2, 2, 0, 3, 3, 6, 6,
+	0, 1, 1, 1, 2, 2, 0, 1, 0, 1,
+	0, 1, 0, 3, 0, 2, 0, 2, 0, 1,
+	1, 2, 3, 3, 5, 4, 4, 3, 4, 3,
+	3, 0, 1, 5, 4, 4, 5, 5, 3, 4,
+	4, 5, 0, 2, 0, 3, 1, 3, 3, 9,
+	7, 8, 0, 1, 1, 3, 1, 5, 7, 7,
+	8, 8, 9, 9, 8, 2, 6, 5, 3, 3,
+	3, 3, 4, 3, 3, 4, 4, 5, 3, 3,
+	2, 2, 2, 0, 1, 2, 2,
 }
-var yyChk = [...]int{
 
-	-1000, -1, -2, -3, -4, -5, -6, -11, -12, -13,
-	-14, -19, -7, -8, -9, -10, -15, -16, -17, -18,
-	-20, -22, 5, 29, 31, 33, 6, 7, 8, 251,
-	32, 254, 255, 257, 256, 87, 88, 90, 91, 55,
-	330, -23, 41, 42, 43, 44, 38, -21, -116, -21,
-	-21, 237, 236, 247, 250, -21, -21, -21, -21, -21,
-	-3, -11, -12, -14, -13, -4, -5, -6, -7, -8,
-	-9, -10, -21, -21, -21, -21, 89, -79, 34, 235,
-	36, 332, 331, -3, 17, -24, 18, -22, -83, 101,
-	100, 99, 229, 230, 101, 100, 102, -83, 233, 234,
-	238, 46, 258, 239, 240, 241, 242, 259, 243, 244,
-	246, 254, 248, 249, 237, -34, -79, -25, 262, -34,
-	9, 25, 258, -73, 264, 265, -25, 258, 258, 259,
-	36, 36, -26, -27, 80, 34, -29, -38, -43, -39,
-	60, 39, -42, -50, -44, -49, -54, -51, 20, 35,
-	36, 37, 21, -79, -48, 78, 79, 40, 333, -47,
-	62, 263, 24, -68, 89, -69, -50, -79, 34, 29,
-	-80, 103, 104, 105, 106, 107, 108, 109, 110, 111,
-	112, 113, 114, 115, 116, 117, 118, 119, 120, 121,
-	122, 123, 124, 125, 126, 127, 128, 129, 130, 131,
-	132, 133, 134, 135, 136, 137, 138, 139, 140, 141,
-	142, -80, 29, -70, 74, 10, -70, 231, 232, -70,
-	-70, -70, 9, 238, 239, 240, 248, 232, 9, 9,
-	232, 232, 9, 9, 9, 9, 235, 258, 260, 241,
-	242, 245, 232, 84, 25, 29, -34, -34, -72, 263,
-	259, 258, -34, -71, 263, -79, -63, 9, 45, 15,
-	84, -28, -79, 19, 59, 58, -40, 75, 60, 74,
-	61, 73, 77, 76, 83, 78, 79, 80, 81, 82,
-	66, 67, 68, 69, 70, 71, 72, -38, -43, -38,
-	-45, -3, -43, -43, 39, -48, 39, 39, 39, 39,
-	-55, -43, 45, 92, 66, 84, -80, 253, -70, -43,
-	-38, -70, -70, -34, -70, 9, 9, 9, -70, 9,
-	-34, -34, -70, -70, -34, -34, -34, -34, -34, -34,
-	-34, -34, -34, -34, -79, -34, -68, -37, 10, -65,
-	29, 39, -34, 60, -79, -34, 261, -34, 20, 57,
-	-30, -31, -33, 39, -34, -48, -27, -43, 80, -79,
-	-79, -38, -38, -43, -44, 75, 74, 61, -43, -43,
-	21, 60, -43, -43, -43, -43, -43, -43, -43, -43,
-	334, 334, 45, 334, -43, 334, 80, -45, 18, -43,
-	-45, -52, -53, 63, -69, 93, -43, 35, -70, -34,
-	-34, -34, -34, -70, -70, -37, -37, -37, -70, -65,
-	29, -37, -59, 13, -38, -41, 24, -3, -68, -66,
-	-50, 39, 20, -75, -74, 266, -101, -100, -99, -112,
-	324, 326, 327, 256, 329, 328, -111, 302, 301, 28,
-	101, 100, 253, 305, -34, -94, -93, 314, 315, 29,
-	316, -34, -37, 45, -32, 47, 48, 49, 50, 51,
-	53, 54, -28, -31, 45, 252, -44, -43, -43, 59,
-	21, -43, 334, 334, -45, 75, 334, -56, -53, 65,
-	-38, -82, 94, 97, 98, -70, -70, -70, -70, -41,
-	-68, -59, -63, 14, -46, -44, 334, 45, -98, -97,
-	-50, -110, 259, 27, 320, 57, 267, 268, 45, -111,
-	325, 259, 27, -110, 325, 325, 325, 303, 259, 27,
-	321, 244, 244, 66, 66, 101, 100, 253, 29, 66,
-	66, 66, 21, 317, -57, 11, -31, -31, 47, 52,
-	47, 52, 47, 47, 47, -35, 55, 262, 56, 334,
-	-43, -43, 59, -43, 334, -43, 86, -43, 64, 95,
-	96, 94, -67, 57, -67, -63, -60, -61, -43, 45,
-	-50, 334, 45, -108, -109, 269, 270, 271, 272, 273,
-	274, 275, 276, 277, 278, 279, 280, 281, 282, 283,
-	284, 285, 286, 287, 288, 289, 290, 106, 295, 296,
-	297, 298, 299, 291, 292, 293, 294, 300, 29, 303,
-	264, 321, -79, -79, -79, -34, -99, -50, -79, -79,
-	303, 264, 321, -50, -50, -50, 27, -79, -79, 27,
-	-79, 36, 29, 66, 66, 66, -80, -81, 143, 144,
-	145, 146, 147, 148, 106, 149, 150, 151, 152, 153,
-	154, 155, 156, 157, 158, 159, 160, 161, 162, 163,
-	164, 165, 166, 167, 168, 169, 170, 171, 172, 173,
-	174, 175, 176, 177, 178, 179, 180, 181, 182, 183,
-	184, 185, 186, 187, 188, 189, 190, 191, 192, 193,
-	194, 195, 196, 197, 198, 199, 200, 201, 202, 203,
-	204, 205, 206, 207, 208, 209, 210, 211, 212, 213,
-	214, 215, 216, 217, 218, 219, 220, 221, 222, 223,
-	224, 225, 226, 227, 228, 35, -58, 12, 14, 57,
-	47, 47, 259, 259, 259, -43, 334, -43, 26, 45,
-	-62, 22, 23, -44, -113, -112, -97, -104, -103, -84,
-	301, 21, 60, 28, 39, -105, 39, 318, -105, 39,
-	-105, 39, -105, 39, -105, 39, -105, 39, -105, 39,
-	-105, 39, -105, 39, -105, 39, 39, 39, 39, -107,
-	39, 106, -107, 39, 39, 39, 39, 39, -107, -107,
-	-107, -107, 39, 39, 27, -79, 259, 27, 27, -75,
-	-75, 39, -108, -75, -75, 27, -79, 259, 27, 27,
-	-50, -108, -79, 66, -80, -81, -80, -59, -38, -45,
-	-38, 39, 39, 39, 27, -61, -86, 264, 27, 303,
-	-104, -84, -104, -103, 21, -42, 36, -106, 319, 36,
-	-106, 36, -106, 36, -106, 36, -106, 36, -106, 36,
-	-106, 36, -106, 36, -106, 36, -106, 36, 36, 36,
-	36, -95, 101, 36, -95, 36, 36, 36, 36, 36,
-	-95, -95, -95, -95, -102, -42, -102, -75, -75, -79,
-	-79, 39, 39, 39, -78, -77, -50, -115, -114, 322,
-	323, 39, 39, -75, -75, -79, -79, 39, -108, -115,
-	-80, -63, -36, -79, -36, -36, 7, -85, 305, 27,
-	27, -86, -104, -86, -104, 334, 334, 334, 334, 334,
-	334, 334, 45, 45, 45, 334, 45, 334, 334, 334,
-	-96, 253, 29, 334, -96, 334, 334, 334, 334, 334,
-	-96, -96, -96, -96, 45, 334, 334, 39, 39, -75,
-	-75, -78, -78, -78, 334, 45, -62, 39, -50, -50,
-	-78, -78, 39, 39, -75, -75, -78, -115, -64, 16,
-	30, 334, 45, 334, 334, -68, -87, 306, 35, -85,
-	-86, -85, -86, -105, -105, -105, -105, -105, -105, 36,
-	36, 36, -105, 36, -81, -80, -107, -107, -107, -107,
-	-42, -95, -95, -78, -78, 39, 39, 334, 334, 334,
-	-76, -74, -77, 36, 334, 334, -78, -78, 39, 39,
-	334, 7, 75, -79, -88, 236, 307, 308, 28, -87,
-	-85, -87, -85, -106, -106, -106, -106, -106, -106, 334,
-	334, 334, -106, 334, -95, -95, -95, -95, -96, -96,
-	334, 334, -78, -78, -89, 304, 334, 334, 334, -78,
-	-78, -89, -79, -90, -89, 309, 310, 28, -88, -87,
-	-88, -87, -105, -105, -105, -105, -96, -96, -96, -96,
-	-85, 334, 334, -34, -62, 334, 334, -79, -90, -88,
-	-90, -88, -106, -106, -106, -106, 39, -90, -90, -78,
-	334, -91, 311, -92, 57, 46, 312, 313, 8, 7,
-	-93, -93, 57, 57, 7, 8, -93, -93,
+var yyChk = [...]int16{
+	-32768, -1, -2, -3, -7, -8, -9, -15, -16, -17,
+	-18, -19, -45, -46, -47, -49, -53, -54, -64, -65,
+	-66, -43, -10, -11, -12, -13, -14, -50, -21, -22,
+	-38, -39, -40, -41, -42, -44, -83, 5, 45, 29,
+	31, 33, 6, 7, 8, 274, 275, 276, 277, 278,
+	302, 284, 279, 280, 300, 301, 32, 303, 304, 384,
+	385, 386, 387, 30, 102, 105, 106, 108, 109, 103,
+	104, 61, 378, 381, 382, 34, -84, 46, 47, 48,
+	49, 38, -82, -194, -4, 295, -82, 383, 34, -82,
+	257, 256, 267, 270, -82, -82, -82, -82, -82, -82,
+	-82, -82, -82, -82, -82, -82, -82, -82, -82, -3,
+	-15, -16, -18, -17, -7, -8, -9, -10, -11, -12,
+	-13, 31, 300, 301, 302, -82, -82, -82, -82, -82,
+	-82, -82, -82, 107, 34, 308, -153, 34, 255, -146,
+	316, 381, 382, 276, 277, 278, 281, 304, 387, 271,
+	272, 273, 383, 103, 105, 108, 109, 282, 294, 103,
+	-153, 36, 380, 379, -153, -153, 34, -3, 17, -85,
+	18, -83, -6, -5, -153, -158, 119, 118, 117, 249,
+	250, 34, 34, 119, 118, 120, -158, 253, 254, 258,
+	52, 305, 259, 260, 261, 262, 306, 263, 264, 266,
+	300, 268, 269, 271, 272, 273, 257, -95, -153, -86,
+	309, -95, 9, 25, -95, -153, -153, 276, 34, 276,
+	383, 305, 306, 261, 262, 265, -153, -55, -56, -57,
+	-58, -153, 17, 5, 6, 7, 8, 300, 301, 302,
+	306, 352, 31, 307, 258, 253, 30, 265, 268, 269,
+	279, -55, 34, 383, 305, -147, 311, 312, 34, 383,
+	-86, 34, -82, -82, -82, 305, 305, -95, -51, 34,
+	-51, 305, -51, 258, 305, 258, 305, 34, -153, 103,
+	-153, 36, 36, -104, 35, 36, 40, 41, 42, 39,
+	37, 21, 34, -87, -88, 89, 34, -90, -100, -105,
+	-101, 68, 43, -104, -113, -153, -106, 124, -112, -121,
+	-114, 100, 101, 20, -115, -111, 87, 88, 44, 388,
+	-109, 70, 359, 310, 24, 93, -3, 51, 19, 43,
+	-137, 107, -138, -153, 34, 29, -154, 121, 122, 123,
+	124, 125, 126, 127, 128, 129, 130, 131, 132, 133,
+	134, 135, 136, 137, 138, 139, 140, 141, 142, 143,
+	144, 145, 146, 147, 148, 149, 150, 151, 152, 153,
+	154, 155, 156, 157, 158, 159, 160, -154, 34, 29,
+	-143, 82, 10, -143, 251, 252, -143, -143, -143, 9,
+	258, 259, 260, 268, 252, 9, 9, 252, 252, 9,
+	9, 9, 9, 255, 305, 307, 261, 262, 265, 252,
+	16, -131, 15, -131, 97, 25, 29, -95, -95, -20,
+	43, 9, -48, 313, -153, -144, 310, -153, 34, -144,
+	-153, -144, -144, -144, -73, 63, 51, -133, -58, 43,
+	63, -145, 310, 34, -145, 306, -144, 34, 305, 34,
+	-95, -95, 305, 305, -96, -95, 305, -36, -23, -95,
+	-36, -153, -153, 9, 35, 40, 41, -131, 9, 51,
+	97, -89, -153, 19, 67, 65, 66, 67, 65, 66,
+	-102, 83, 68, 82, 84, 69, 81, 86, 85, 94,
+	95, 87, 88, 89, 90, 91, 92, 93, 96, 74,
+	75, 76, 77, 78, 79, 80, -105, -100, 34, -100,
+	-107, -3, -105, 298, 299, 64, 43, -105, 43, -105,
+	296, -105, 43, -111, 43, -102, 43, 43, -123, -105,
+	43, -5, 43, -98, -153, 51, 110, 74, 97, 35,
+	34, -154, 96, -143, -105, -100, -105, -143, -143, -95,
+	-143, 9, 9, 9, -143, 9, -95, -95, -143, -143,
+	-95, -95, -95, -95, -95, -95, -95, -95, -95, -95,
+	-62, 34, 35, -105, -153, -95, -136, -142, -113, -153,
+	-99, 10, -133, 29, 389, -107, -105, 35, -113, -107,
+	-61, -62, 34, 20, -144, -95, 63, -95, -95, -95,
+	285, 286, -153, -59, 305, 262, 261, -56, -134, -113,
+	-59, -67, -68, -62, 68, -145, -95, -153, -67, -139,
+	-153, 35, -95, 308, -96, -96, -52, 51, -96, 51,
+	-37, 19, 34, 112, -153, -91, -92, -94, 43, -95,
+	-111, -88, 89, -153, -153, -100, -105, -100, -105, -100,
+	-105, -100, -105, -100, -105, -100, -105, -105, -106, 83,
+	82, 84, 69, -105, -105, -105, 21, 68, -105, -105,
+	-105, -105, -105, -105, -105, -105, -105, -105, -105, -156,
+	-155, 34, 161, 162, 163, 164, 165, 166, 124, 167,
+	168, 169, 170, 171, 172, 173, 174, 175, 176, 177,
+	178, 179, 180, 181, 182, 183, 184, 185, 186, 187,
+	188, 189, 190, 191, 192, 193, 194, 195, 196, 197,
+	198, 199, 200, 201, 202, 203, 204, 205, 206, 207,
+	208, 209, 210, 211, 212, 213, 214, 215, 216, 217,
+	218, 219, 220, 221, 222, 223, 224, 225, 226, 227,
+	228, 229, 230, 231, 232, 233, 234, 235, 236, 237,
+	238, 239, 240, 241, 242, 243, 244, 245, 246, 247,
+	248, 97, 389, 389, 51, 389, 35, 35, -105, -105,
+	389, 89, -107, 18, 43, -153, 334, -105, -105, -105,
+	-107, -119, -120, 71, -134, -3, 389, 51, -138, 111,
+	-141, -105, 28, 63, -154, 124, -153, 74, 74, 35,
+	-155, -143, -95, -95, -95, -95, -143, -143, -99, -99,
+	-99, -143, 35, 43, 34, 51, 293, -133, 29, -99,
+	51, 74, -127, 13, -100, -103, 24, -3, -136, 389,
+	51, -139, -169, -168, 362, 363, 29, 364, -95, 35,
+	-60, 89, -153, 389, 51, -60, -70, 51, 283, -69,
+	282, 20, -139, 43, -149, -148, 313, -70, -140, -176,
+	-175, -174, -187, 372, 374, 375, 302, 301, 304, 34,
+	377, 376, -186, 350, 349, 28, 119, 118, 96, 353,
+	-95, 34, 16, -95, -52, -23, -153, -37, 34, 34,
+	308, -99, 51, -93, 53, 54, 55, 56, 57, 59,
+	60, -89, -92, -106, -105, -105, -105, 67, 21, -105,
+	19, 389, 389, 13, 294, -107, -122, 297, 51, 313,
+	83, 389, -124, -120, 73, -100, 389, 389, 19, -153,
+	-157, 112, 115, 116, 74, -141, -141, -143, -143, -143,
+	-143, 389, 35, -105, -105, -103, -136, -127, -142, -105,
+	-131, 14, -108, -106, -62, 21, 365, -191, -190, -189,
+	316, 30, -74, 274, 309, 308, 97, 97, -113, 9,
+	-68, -71, -72, -153, 14, 45, -140, -173, -172, -113,
+	-185, 306, 27, -24, 368, 63, 314, 315, 282, 34,
+	112, -30, -29, 297, 51, -186, 373, 306, 27, -185,
+	-24, 297, 373, 373, 373, 351, 306, 27, 369, 386,
+	368, 297, 386, 368, 297, 34, 264, 264, 74, 74,
+	119, 118, 96, 29, 74, 74, 74, 34, -37, -153,
+	-125, 11, -92, -92, 53, 58, 53, 58, 53, 53,
+	53, -97, 61, 309, 62, 389, 67, -105, -117, 124,
+	335, 336, 330, 333, 331, 334, 329, 327, 328, 326,
+	366, 34, 14, 35, 389, 13, 294, -127, 14, -117,
+	-154, -105, 99, -105, 72, -153, 43, 113, 114, 112,
+	-141, -135, 63, -135, -131, -128, -129, -105, -115, 51,
+	-189, 74, 74, 25, -61, 89, 89, -153, -61, -72,
+	67, 35, 35, -153, -153, 389, 51, -183, -184, 317,
+	318, 319, 320, 321, 322, 323, 324, 325, 326, 327,
+	328, 329, 330, 331, 332, 333, 334, 335, 336, 337,
+	338, 124, 343, 344, 345, 346, 347, 339, 340, 341,
+	342, 348, 29, 34, 351, 311, 369, 386, -153, -153,
+	-153, -95, 14, -98, 34, 14, -174, -113, -153, -153,
+	351, 311, 369, 43, -113, -113, -113, 27, -153, -153,
+	27, -153, -153, -98, -153, -153, -98, -153, 36, 29,
+	74, 74, 74, -154, -155, 35, -126, 12, 14, 63,
+	53, 53, 306, 306, 306, -105, 389, -118, 43, -118,
+	-118, -118, -118, -118, 43, -118, 324, 324, -128, 389,
+	14, 35, 389, -107, 389, 389, 389, -105, 43, -3,
+	26, 51, -130, 22, 23, -130, -106, 28, -153, 28,
+	-153, 305, -63, 45, -72, 35, 14, 19, -188, -187,
+	-172, -179, -178, -159, -195, 349, 21, 68, 28, 34,
+	43, -180, 43, 366, -180, 43, -180, 43, -180, 43,
+	-180, 43, -180, 43, -180, 43, -180, 43, -180, 43,
+	-180, 43, 43, 43, 43, -182, 43, 124, -182, 43,
+	43, 43, 43, 43, -182, -182, -182, -182, 43, 43,
+	27, -153, 306, 27, 27, 43, -149, -149, 43, 35,
+	-31, 34, 315, 27, -183, -149, -149, 27, -153, 306,
+	27, 27, -33, -32, 297, -113, -183, -153, -26, 34,
+	68, -26, 74, -154, -155, -154, -127, -100, -107, -100,
+	43, 43, 43, 36, 119, 36, -110, 294, -128, 389,
+	-105, 389, 27, -129, -95, 279, 35, 35, -30, -161,
+	311, 27, 351, -179, -159, -179, -178, 19, 21, -104,
+	34, 36, -181, 367, 36, -181, 36, -181, 36, -181,
+	36, -181, 36, -181, 36, -181, 36, -181, 36, -181,
+	36, -181, 36, 36, 36, 36, -170, 119, 36, -170,
+	36, 36, 36, 36, 36, -170, -170, -170, -170, -177,
+	-104, -177, -149, -149, -153, -153, 43, -100, 43, 43,
+	-152, -151, -113, -35, 34, 43, 259, 315, 27, 43,
+	43, -193, -192, 370, 371, 43, 43, -149, -149, -153,
+	-153, 43, 51, 389, -153, -183, -193, 34, -154, -131,
+	-98, -98, -98, 389, 29, 51, 389, 35, -110, -116,
+	83, 45, 7, -75, 119, 118, 281, -160, 353, 27,
+	27, -161, -179, -161, -179, 43, 389, 389, 389, 389,
+	389, 389, 389, 51, 51, 51, 389, 51, 389, 389,
+	389, -171, 96, 29, 389, -171, 389, 389, 389, 389,
+	389, -171, -171, -171, -171, 51, 389, 389, 43, 43,
+	-149, -149, -152, 389, -152, -152, 389, 51, -130, 43,
+	-34, 43, 36, -105, 43, 43, 43, -105, 389, -134,
+	-113, -113, -152, -152, 43, 43, -149, -149, -152, -32,
+	-188, 24, -193, -132, 16, 30, 389, 389, 389, -154,
+	36, 389, 389, 60, 320, 379, -136, -76, 260, 259,
+	29, -154, -162, 354, 35, -160, -161, -160, -161, -105,
+	-180, -180, -180, -180, -180, -180, 36, 36, 36, -180,
+	36, -155, -154, -182, -182, -182, -182, -104, -170, -170,
+	-152, -152, 43, 43, 389, -27, -26, 389, 389, -150,
+	-148, -151, 36, -33, 389, -134, -105, 389, -134, 389,
+	389, 389, 389, -152, -152, 43, 43, 389, 34, 83,
+	7, 83, 389, -153, -153, -153, -78, 287, -77, -77,
+	-154, -163, 256, 355, 356, 28, -162, -160, -162, -160,
+	389, -181, -181, -181, -181, -181, -181, 389, 389, 389,
+	-181, 389, -170, -170, -170, -170, -171, -171, 389, 389,
+	-152, -152, -164, 352, -191, 389, 389, 389, 389, 389,
+	389, 389, -152, -152, -164, 34, 43, -153, -153, -80,
+	309, -79, 289, 291, 290, 292, -165, -164, 357, 358,
+	28, -163, -162, -163, -162, -28, 34, -180, -180, -180,
+	-180, -171, -171, -171, -171, -160, 389, 389, -95, -130,
+	389, 389, 43, 34, -107, -153, 45, -133, 36, 288,
+	289, 14, 14, 291, 14, -25, -24, -185, -165, -163,
+	-165, -163, -161, -178, -181, -181, -181, -181, 43, -107,
+	-188, 389, 379, -81, 29, 287, -153, 14, 14, 35,
+	35, 14, 35, -25, -165, -25, -165, -160, -161, -152,
+	389, -188, -153, -136, 35, 35, 35, -25, -25, -165,
+	-160, 389, -188, -25, -165, -166, 359, -25, -167, 63,
+	52, 360, 361, 8, 7, -168, -168, 63, 63, 7,
+	8, -168, -168,
 }
-var yyDef = [...]int{
 
-	101, -2, 1, 2, 3, 4, 5, 6, 7, 8,
+var yyDef = [...]int16{
+	279, -2, 1, 2, 3, 4, 5, 6, 7, 8,
 	9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
-	19, 20, 99, 99, 99, 99, 99, 99, 99, 99,
-	0, 99, 99, 99, 99, 48, 0, 50, 51, 0,
-	0, 0, 103, 105, 106, 107, 102, 108, 101, 398,
-	398, 94, 0, 96, 97, 0, 250, 0, 0, 0,
-	30, 31, 32, 33, 34, 35, 36, 37, 38, 39,
-	40, 41, 252, 250, 0, 0, 49, 52, 265, 266,
-	53, 0, 0, 23, 104, 0, 109, 100, 0, 0,
-	0, 0, 399, 400, 0, 401, 401, 0, 401, 401,
-	401, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 91, 0, 95, 98, 136, 0, 251, 0,
-	0, 0, 248, 0, 253, 254, 0, 0, 246, 0,
-	54, 55, 230, 110, 112, 265, 117, 115, 116, 148,
-	0, 0, 179, 180, 181, 0, 191, 192, 0, 214,
-	215, 216, 217, 212, 175, 201, 202, 203, 0, 0,
-	205, 199, 200, 42, 0, 243, 0, 212, 265, 0,
-	44, 267, 268, 269, 270, 271, 272, 273, 274, 275,
-	276, 277, 278, 279, 280, 281, 282, 283, 284, 285,
-	286, 287, 288, 289, 290, 291, 292, 293, 294, 295,
-	296, 297, 298, 299, 300, 301, 302, 303, 304, 305,
-	306, 45, 401, 63, 0, 0, 64, 401, 401, 67,
-	68, 69, 0, 401, 0, 0, 92, 401, 0, 0,
-	401, 401, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 93, 0, 0, 0, 146, 237, 0, 0,
-	0, 0, 0, 0, 0, 0, 21, 0, 0, 0,
-	0, 114, 118, 0, 0, 0, 0, 0, 0, 0,
+	19, 20, 21, 22, 23, 24, 25, 26, 27, 28,
+	29, 30, 31, 32, 33, 34, 35, 277, 40, 277,
+	277, 277, 277, 277, 277, 277, 277, 277, 277, 277,
+	277, 277, 277, 277, 277, 277, 0, 277, 277, 277,
+	277, 277, 277, 277, 277, 186, 0, 188, 189, 0,
+	0, 0, 0, 0, 0, 0, 0, 281, 283, 284,
+	285, 280, 286, 279, 0, 41, 690, 0, 207, 690,
+	266, 0, 268, 269, 0, 510, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 512, 510, 155,
+	156, 157, 158, 159, 160, 161, 162, 163, 164, 165,
+	166, 277, 277, 277, 277, 0, 0, 222, 222, 0,
+	222, 0, 0, 187, 0, 0, 192, 533, 534, 535,
+	536, 537, 538, 539, 540, 541, 542, 543, 544, 545,
+	546, 547, 548, 549, 550, 551, 552, 553, 554, 0,
+	194, 195, 0, 0, 198, 0, 0, 38, 282, 0,
+	287, 278, 0, 42, 0, 0, 0, 0, 0, 691,
+	692, 203, 206, 0, 693, 693, 0, 693, 693, 693,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	163, 164, 165, 166, 167, 168, 169, 151, 0, 0,
-	0, 0, 177, 190, 0, 162, 0, 0, 0, 0,
-	0, 206, 0, 0, 0, 0, 43, 0, 62, 402,
-	403, 65, 66, 401, 71, 0, 0, 0, 73, 0,
-	401, 401, 79, 80, 146, 146, 146, 401, 85, 86,
-	87, 88, 89, 90, 137, 237, 146, 222, 0, 0,
-	0, 0, 0, 0, 259, 534, 0, 503, 247, 0,
-	146, 120, 117, 0, 134, 135, 111, 231, 113, 213,
-	119, 149, 150, 153, 154, 0, 0, 0, 156, 0,
-	160, 0, 182, 183, 184, 185, 186, 187, 188, 189,
-	152, 174, 0, 176, 177, 193, 0, 0, 0, 0,
-	0, 210, 207, 0, 244, 0, 245, 46, 70, 401,
-	401, 401, 401, 75, 76, 81, 82, 83, 84, 0,
-	0, 222, 230, 0, 147, 26, 0, 171, 27, 0,
-	239, 519, 249, 0, 260, 0, 58, 535, 536, 538,
-	519, 0, 0, 0, 0, 0, 523, 0, 0, 0,
-	0, 0, 0, 0, 59, 60, 504, 505, 506, 0,
-	0, 61, 218, 0, 0, 125, 126, 0, 0, 0,
-	0, 0, 138, 0, 0, 0, 155, 157, 0, 0,
-	161, 178, 194, 195, 0, 0, 198, 0, 208, 0,
-	0, 47, 0, 0, 397, 72, 77, 78, 74, 241,
-	241, 230, 29, 0, 170, 172, 238, 0, 0, 404,
-	0, 0, 0, 0, 0, 0, 261, 262, 0, 524,
+	0, 263, 0, 270, 480, 480, 267, 276, 314, 0,
+	511, 0, 0, 0, 51, 0, 149, 0, 506, 0,
+	0, 506, 0, 506, 506, 506, 55, 0, 103, 487,
+	106, 108, 109, 110, 111, 112, 113, 114, 115, 116,
+	117, 118, 119, 120, 121, 122, 123, 124, 125, 126,
+	127, 0, 508, 0, 508, 0, 513, 514, 506, 0,
+	0, 0, 512, 510, 0, 0, 0, 228, 0, 223,
+	0, 0, 0, 0, 0, 184, 185, 0, 190, 549,
+	193, 196, 197, 0, 457, 458, 459, 460, 461, 0,
+	465, 466, 205, 480, 288, 290, 533, 295, 293, 294,
+	328, 0, 0, 374, 375, 455, 379, 0, 0, 394,
+	396, 0, 0, 0, 356, 370, 444, 445, 446, 0,
+	0, 448, 0, 441, 442, 443, 39, 0, 0, 0,
+	167, 0, 493, 0, 533, 0, 169, 555, 556, 557,
+	558, 559, 560, 561, 562, 563, 564, 565, 566, 567,
+	568, 569, 570, 571, 572, 573, 574, 575, 576, 577,
+	578, 579, 580, 581, 582, 583, 584, 585, 586, 587,
+	588, 589, 590, 591, 592, 593, 594, 170, 275, 693,
+	235, 0, 0, 236, 693, 693, 239, 240, 241, 0,
+	693, 0, 0, 264, 693, 0, 0, 693, 693, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 265,
+	0, 273, 0, 274, 0, 0, 0, 326, 487, 50,
+	0, 0, 148, 0, 151, 0, 0, 152, 506, 0,
+	0, 0, 0, 0, 0, 128, 0, 105, 107, 0,
+	128, 0, 0, 508, 0, 0, 0, 0, 0, 0,
+	0, 227, 0, 0, 224, 316, 0, 174, 176, 0,
+	175, 204, 191, 0, 462, 463, 464, 36, 0, 0,
+	0, 292, 296, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 554, 555, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 507, 508, 220, 0, 121, 0, 127, 0,
-	129, 0, 131, 132, 133, 122, 0, 0, 0, 123,
-	232, 233, 0, 158, 196, 0, 204, 211, 0, 394,
-	395, 396, 24, 0, 25, 28, 223, 224, 227, 0,
-	240, 521, 519, 406, 474, 419, 509, 423, 424, 509,
-	509, 509, 509, 509, 509, 509, 509, 509, 444, 445,
-	447, 449, 451, 513, 513, 0, 0, 458, 0, 461,
-	462, 463, 464, 513, 513, 513, 513, 0, 0, 0,
-	0, 0, 259, 259, 520, 0, 537, 0, 259, 259,
-	0, 0, 0, 0, 0, 549, 550, 551, 552, 0,
-	525, 526, 0, 0, 0, 0, 530, 532, 307, 308,
-	309, 310, 311, 312, 313, 314, 315, 316, 317, 318,
-	319, 320, 321, 322, 323, 324, 325, 326, 327, 328,
-	329, 330, 331, 332, 333, 334, 335, 336, 337, 338,
-	339, 340, 341, 342, 343, 344, 345, 346, 347, 348,
-	349, 350, 351, 352, 353, 354, 355, 356, 357, 358,
-	359, 360, 361, 362, 363, 364, 365, 366, 367, 368,
-	369, 370, 371, 372, 373, 374, 375, 376, 377, 378,
-	379, 380, 381, 382, 383, 384, 385, 386, 387, 388,
-	389, 390, 391, 392, 393, 533, 222, 0, 0, 0,
-	128, 130, 0, 0, 0, 159, 197, 209, 0, 0,
-	226, 228, 229, 173, 56, 522, 405, 476, 474, 474,
-	475, 471, 0, 0, 0, 511, 0, 510, 511, 0,
-	511, 0, 511, 0, 511, 0, 511, 0, 511, 0,
-	511, 0, 511, 0, 511, 0, 0, 0, 0, 515,
-	0, 514, 515, 0, 0, 0, 0, 0, 515, 515,
-	515, 515, 0, 0, 259, 259, 0, 0, 0, 0,
-	0, 0, 556, 0, 0, 259, 259, 0, 0, 0,
-	0, 556, 553, 0, 529, 531, 528, 230, 221, 219,
-	124, 0, 0, 0, 0, 225, 481, 477, 479, 0,
-	476, 474, 476, 474, 472, 473, 0, 421, 512, 0,
-	425, 0, 427, 0, 429, 0, 431, 0, 433, 0,
-	435, 0, 437, 0, 439, 0, 441, 0, 0, 0,
-	0, 517, 0, 0, 517, 0, 0, 0, 0, 0,
-	517, 517, 517, 517, 0, 144, 0, 0, 0, 259,
-	259, 0, 0, 0, 0, 255, 227, 539, 557, 0,
-	0, 0, 0, 0, 0, 259, 259, 0, 556, 548,
-	527, 234, 0, 142, 0, 0, 0, 483, 0, 478,
-	480, 481, 476, 481, 476, 420, 509, 509, 509, 509,
-	509, 509, 0, 0, 0, 509, 0, 446, 448, 450,
-	452, 0, 0, 513, 453, 513, 513, 513, 459, 460,
-	465, 466, 467, 468, 0, 515, 515, 0, 0, 0,
-	0, 0, 0, 0, 263, 0, 257, 0, 558, 559,
-	0, 0, 0, 0, 0, 0, 0, 547, 22, 0,
-	0, 139, 0, 140, 141, 242, 487, 0, 482, 483,
-	481, 483, 481, 511, 511, 511, 511, 511, 511, 0,
-	0, 0, 511, 0, 518, 516, 515, 515, 515, 515,
-	145, 517, 517, 0, 0, 0, 0, 0, 408, 409,
-	57, 264, 256, 0, 540, 541, 0, 0, 0, 0,
-	0, 235, 0, 143, 491, 0, 484, 485, 486, 487,
-	483, 487, 483, 422, 426, 428, 430, 432, 434, 509,
-	509, 509, 442, 509, 517, 517, 517, 517, 469, 470,
-	481, 410, 0, 0, 413, 0, 227, 542, 543, 0,
-	0, 546, 0, 414, 492, 488, 489, 490, 491, 487,
-	491, 487, 511, 511, 511, 511, 454, 455, 456, 457,
-	407, 411, 412, 0, 258, 544, 545, 236, 415, 491,
-	416, 491, 436, 438, 440, 443, 0, 417, 418, 0,
-	494, 498, 0, 493, 0, 495, 496, 497, 0, 0,
-	499, 500, 0, 0, 0, 0, 502, 501,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 358,
+	359, 360, 361, 362, 363, 364, 341, 342, 533, 0,
+	0, 0, 372, 0, 0, 0, 0, 391, 0, 393,
+	0, 0, 0, 355, 0, 0, 0, 0, 0, 449,
+	0, 43, 0, 0, 322, 0, 0, 0, 0, 0,
+	0, 168, 0, 234, 694, 695, 0, 237, 238, 693,
+	243, 0, 0, 0, 245, 0, 693, 693, 251, 252,
+	326, 326, 326, 693, 257, 258, 259, 260, 261, 262,
+	271, 142, 139, 481, 315, 487, 326, 503, 0, 455,
+	471, 0, 0, 0, 52, 0, 372, 146, 147, 150,
+	84, 137, 142, 507, 0, 810, 0, 231, 232, 233,
+	0, 56, 57, 0, 129, 130, 131, 104, 0, 489,
+	0, 94, 85, 88, 0, 0, 0, 519, 94, 210,
+	208, 209, 862, 0, 218, 219, 220, 0, 224, 0,
+	178, 0, 183, 181, 0, 326, 298, 295, 0, 312,
+	313, 289, 291, 456, 297, 329, 332, 330, 335, 331,
+	338, 333, 334, 336, 337, 339, 340, 344, 345, 0,
+	0, 0, 0, 347, 349, 0, 353, 0, 380, 381,
+	382, 383, 384, 385, 386, 387, 388, 389, 390, 392,
+	595, 596, 597, 598, 599, 600, 601, 602, 603, 604,
+	605, 606, 607, 608, 609, 610, 611, 612, 613, 614,
+	615, 616, 617, 618, 619, 620, 621, 622, 623, 624,
+	625, 626, 627, 628, 629, 630, 631, 632, 633, 634,
+	635, 636, 637, 638, 639, 640, 641, 642, 643, 644,
+	645, 646, 647, 648, 649, 650, 651, 652, 653, 654,
+	655, 656, 657, 658, 659, 660, 661, 662, 663, 664,
+	665, 666, 667, 668, 669, 670, 671, 672, 673, 674,
+	675, 676, 677, 678, 679, 680, 681, 682, 683, 684,
+	685, 0, 343, 369, 0, 371, 376, 377, 378, 372,
+	402, 0, 0, 0, 431, 397, 398, 0, 357, 0,
+	0, 453, 450, 0, 0, 0, 0, 0, 494, 0,
+	495, 499, 500, 501, 502, 558, 0, 0, 0, 171,
+	172, 242, 693, 693, 693, 693, 247, 248, 253, 254,
+	255, 256, 143, 0, 140, 0, 0, 0, 0, 471,
+	0, 0, 480, 0, 327, 48, 0, 366, 49, 53,
+	0, 202, 229, 811, 812, 813, 0, 0, 525, 58,
+	0, 132, 134, 488, 0, 0, 82, 0, 0, 87,
+	0, 509, 210, 826, 0, 520, 0, 83, 201, 841,
+	863, 864, 866, 826, 0, 0, 0, 0, 0, 0,
+	0, 0, 830, 0, 0, 0, 0, 0, 0, 0,
+	217, 225, 0, 317, 221, 177, 0, 180, 183, 182,
+	0, 467, 0, 0, 303, 304, 0, 0, 0, 0,
+	0, 318, 0, 346, 348, 350, 0, 0, 354, 373,
+	0, 403, 404, 0, 0, 0, 471, 0, 0, 0,
+	0, 411, 0, 451, 0, 0, 0, 44, 0, 323,
+	173, 0, 0, 689, 0, 497, 498, 244, 249, 250,
+	246, 272, 141, 482, 483, 491, 491, 480, 504, 505,
+	154, 0, 365, 367, 138, 814, 815, 230, 526, 527,
+	0, 0, 0, 59, 60, 0, 0, 0, 490, 0,
+	86, 95, 96, 99, 0, 0, 200, 0, 696, 0,
+	0, 0, 0, 706, 0, 0, 521, 522, 0, 0,
+	0, 216, 842, 0, 0, 831, 0, 0, 0, 0,
+	875, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 890, 891, 892, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 226, 179, 199,
+	469, 0, 299, 0, 305, 0, 307, 0, 309, 310,
+	311, 300, 0, 0, 0, 301, 0, 351, 0, 429,
+	429, 429, 416, 429, 429, 419, 429, 422, 429, 424,
+	425, 427, 0, 0, 405, 0, 0, 0, 0, 0,
+	0, 0, 447, 454, 0, 0, 0, 686, 687, 688,
+	496, 46, 0, 47, 153, 472, 473, 477, 477, 0,
+	528, 0, 0, 0, 144, 133, 135, 136, 102, 97,
+	0, 100, 89, 0, 91, 828, 826, 698, -2, 725,
+	816, 729, 730, 816, 816, 816, 816, 816, 816, 816,
+	816, 816, 750, 751, 753, 755, 757, 820, 820, 0,
+	0, 764, 0, 767, 768, 769, 770, 820, 820, 820,
+	820, 0, 0, 777, 0, 0, 0, 0, 519, 519,
+	827, 0, 0, 212, 213, 0, 865, 0, 519, 519,
+	0, 0, 0, 0, 0, 0, 878, 879, 880, 881,
+	0, 883, 884, 888, 0, 0, 889, 832, 833, 0,
+	0, 0, 0, 837, 839, 840, 471, 0, 0, 0,
+	306, 308, 0, 0, 0, 352, 399, 412, 0, 413,
+	415, 417, 418, 420, 0, 423, 426, 428, 433, 407,
+	0, 0, 395, 432, 400, 401, 410, 452, 0, 0,
+	0, 0, 475, 478, 479, 476, 368, 529, 530, 531,
+	532, 0, 101, 0, 98, 90, 0, 0, 841, 829,
+	697, 783, 781, 781, 0, 782, 778, 0, 0, 0,
+	0, 818, 0, 817, 818, 0, 818, 0, 818, 0,
+	818, 0, 818, 0, 818, 0, 818, 0, 818, 0,
+	818, 0, 0, 0, 0, 822, 0, 821, 822, 0,
+	0, 0, 0, 0, 822, 822, 822, 822, 0, 0,
+	519, 519, 0, 0, 0, 0, 0, 0, 0, 211,
+	852, 0, 0, 0, 893, 0, 0, 519, 519, 0,
+	0, 0, 0, 856, 0, 0, 893, 882, 885, 712,
+	0, 886, 0, 836, 838, 835, 480, 470, 468, 302,
+	0, 0, 0, 0, 0, 0, 0, 0, 433, 409,
+	436, 45, 0, 474, 61, 0, 92, 93, 214, 788,
+	784, 786, 0, 783, 781, 783, 781, 0, 779, 780,
+	722, 0, 727, 819, 0, 731, 0, 733, 0, 735,
+	0, 737, 0, 739, 0, 741, 0, 743, 0, 745,
+	0, 747, 0, 0, 0, 0, 824, 0, 0, 824,
+	0, 0, 0, 0, 0, 824, 824, 824, 824, 0,
+	324, 0, 0, 0, 519, 519, 0, 0, 0, 0,
+	0, 515, 477, 854, 0, 0, 0, 0, 0, 0,
+	0, 867, 894, 0, 0, 0, 0, 0, 0, 519,
+	519, 0, 0, 887, 828, 893, 877, 713, 834, 484,
+	0, 0, 0, 430, 0, 0, 406, 434, 0, 0,
+	0, 0, 0, 64, 0, 0, 145, 790, 0, 785,
+	787, 788, 783, 788, 783, 0, 726, 816, 816, 816,
+	816, 816, 816, 0, 0, 0, 816, 0, 752, 754,
+	756, 758, 0, 0, 820, 759, 820, 820, 820, 765,
+	766, 771, 772, 773, 774, 0, 822, 822, 0, 0,
+	0, 0, 0, 710, 0, 0, 523, 0, 517, 0,
+	843, 0, 853, 0, 0, 0, 0, 0, 848, 0,
+	895, 896, 0, 0, 0, 0, 0, 0, 0, 857,
+	858, 0, 876, 37, 0, 0, 319, 320, 321, 414,
+	0, 408, 435, 0, 0, 0, 492, 72, 67, 67,
+	0, 63, 794, 0, 789, 790, 788, 790, 788, 0,
+	818, 818, 818, 818, 818, 818, 0, 0, 0, 818,
+	0, 825, 823, 822, 822, 822, 822, 325, 824, 824,
+	0, 0, 0, 0, 0, 709, 711, 700, 701, 525,
+	524, 516, 0, 0, 844, 0, 0, 850, 0, 845,
+	849, 868, 869, 0, 0, 0, 0, 0, 0, 0,
+	485, 0, 421, 0, 439, 440, 77, 74, 65, 66,
+	62, 798, 0, 791, 792, 793, 794, 790, 794, 790,
+	723, 728, 732, 734, 736, 738, 740, 816, 816, 816,
+	748, 816, 824, 824, 824, 824, 775, 776, 788, 702,
+	0, 0, 705, 0, 215, 477, 855, 846, 847, 851,
+	870, 871, 0, 0, 874, 0, 0, 0, 437, 487,
+	0, 73, 0, 0, 0, 0, -2, 799, 795, 796,
+	797, 798, 794, 798, 794, 783, 724, 818, 818, 818,
+	818, 760, 761, 762, 763, 699, 703, 704, 0, 518,
+	872, 873, 0, 828, 0, 486, 0, 80, 0, 0,
+	0, 0, 0, 0, 0, 714, 708, 0, -2, 798,
+	-2, 798, 788, 783, 742, 744, 746, 749, 0, 0,
+	860, 828, 0, 54, 0, 78, 79, 0, 0, 68,
+	69, 0, 71, 715, -2, 716, -2, 798, 788, 0,
+	828, 861, 438, 81, 75, 76, 70, 717, 718, -2,
+	798, 801, 859, 719, -2, 805, 0, 720, 800, 0,
+	802, 803, 804, 0, 0, 806, 807, 0, 0, 0,
+	0, 809, 808,
 }
-var yyTok1 = [...]int{
 
+var yyTok1 = [...]int16{
 	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 82, 77, 3,
-	39, 334, 80, 78, 45, 79, 84, 81, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 91, 86, 3,
+	43, 389, 89, 87, 51, 88, 97, 90, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	67, 66, 68, 3, 3, 3, 3, 3, 3, 3,
+	75, 74, 76, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 83, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 94, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 76, 3, 40,
+	3, 3, 3, 3, 85, 3, 44,
 }
-var yyTok2 = [...]int{
 
+var yyTok2 = [...]int16{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
-	32, 33, 34, 35, 36, 37, 38, 41, 42, 43,
-	44, 46, 47, 48, 49, 50, 51, 52, 53, 54,
+	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
+	42, 45, 46, 47, 48, 49, 50, 52, 53, 54,
 	55, 56, 57, 58, 59, 60, 61, 62, 63, 64,
-	65, 69, 70, 71, 72, 73, 74, 75, 85, 86,
-	87, 88, 89, 90, 91, 92, 93, 94, 95, 96,
-	97, 98, 99, 100, 101, 102, 103, 104, 105, 106,
+	65, 66, 67, 68, 69, 70, 71, 72, 73, 77,
+	78, 79, 80, 81, 82, 83, 84, 92, 93, 95,
+	96, 98, 99, 100, 101, 102, 103, 104, 105, 106,
 	107, 108, 109, 110, 111, 112, 113, 114, 115, 116,
 	117, 118, 119, 120, 121, 122, 123, 124, 125, 126,
 	127, 128, 129, 130, 131, 132, 133, 134, 135, 136,
@@ -1471,7 +2367,8 @@ var yyTok2 = [...]int{
 	257, 258, 259, 260, 261, 262, 263, 264, 265, 266,
 	267, 268, 269, 270, 271, 272,
 }
-var yyTok3 = [...]int{
+
+var yyTok3 = [...]uint16{
 	57600, 273, 57601, 274, 57602, 275, 57603, 276, 57604, 277,
 	57605, 278, 57606, 279, 57607, 280, 57608, 281, 57609, 282,
 	57610, 283, 57611, 284, 57612, 285, 57613, 286, 57614, 287,
@@ -1484,7 +2381,18 @@ var yyTok3 = [...]int{
 	57645, 318, 57646, 319, 57647, 320, 57648, 321, 57649, 322,
 	57650, 323, 57651, 324, 57652, 325, 57653, 326, 57654, 327,
 	57655, 328, 57656, 329, 57657, 330, 57658, 331, 57659, 332,
-	57660, 333, 0,
+	57660, 333, 57661, 334, 57662, 335, 57663, 336, 57664, 337,
+	57665, 338, 57666, 339, 57667, 340, 57668, 341, 57669, 342,
+	57670, 343, 57671, 344, 57672, 345, 57673, 346, 57674, 347,
+	57675, 348, 57676, 349, 57677, 350, 57678, 351, 57679, 352,
+	57680, 353, 57681, 354, 57682, 355, 57683, 356, 57684, 357,
+	57685, 358, 57686, 359, 57687, 360, 57688, 361, 57689, 362,
+	57690, 363, 57691, 364, 57692, 365, 57693, 366, 57694, 367,
+	57695, 368, 57696, 369, 57697, 370, 57698, 371, 57699, 372,
+	57700, 373, 57701, 374, 57702, 375, 57703, 376, 57704, 377,
+	57705, 378, 57706, 379, 57707, 380, 57708, 381, 57709, 382,
+	57710, 383, 57711, 384, 57712, 385, 57713, 386, 57714, 387,
+	57715, 388, 0,
 }
 
 var yyErrorMessages = [...]struct {
@@ -1526,7 +2434,7 @@ func yyNewParser() yyParser {
 	return &yyParserImpl{}
 }
 
-const yyFlag = -1000
+const yyFlag = -32768
 
 func yyTokname(c int) string {
 	if c >= 1 && c-1 < len(yyToknames) {
@@ -1565,9 +2473,9 @@ func yyErrorMessage(state, lookAhead int) string {
 	expected := make([]int, 0, 4)
 
 	// Look for shiftable tokens.
-	base := yyPact[state]
+	base := int(yyPact[state])
 	for tok := TOKSTART; tok-1 < len(yyToknames); tok++ {
-		if n := base + tok; n >= 0 && n < yyLast && yyChk[yyAct[n]] == tok {
+		if n := base + tok; n >= 0 && n < yyLast && int(yyChk[int(yyAct[n])]) == tok {
 			if len(expected) == cap(expected) {
 				return res
 			}
@@ -1577,13 +2485,13 @@ func yyErrorMessage(state, lookAhead int) string {
 
 	if yyDef[state] == -2 {
 		i := 0
-		for yyExca[i] != -1 || yyExca[i+1] != state {
+		for yyExca[i] != -1 || int(yyExca[i+1]) != state {
 			i += 2
 		}
 
 		// Look for tokens that we accept or reduce.
 		for i += 2; yyExca[i] >= 0; i += 2 {
-			tok := yyExca[i]
+			tok := int(yyExca[i])
 			if tok < TOKSTART || yyExca[i+1] == 0 {
 				continue
 			}
@@ -1614,30 +2522,30 @@ func yylex1(lex yyLexer, lval *yySymType) (char, token int) {
 	token = 0
 	char = lex.Lex(lval)
 	if char <= 0 {
-		token = yyTok1[0]
+		token = int(yyTok1[0])
 		goto out
 	}
 	if char < len(yyTok1) {
-		token = yyTok1[char]
+		token = int(yyTok1[char])
 		goto out
 	}
 	if char >= yyPrivate {
 		if char < yyPrivate+len(yyTok2) {
-			token = yyTok2[char-yyPrivate]
+			token = int(yyTok2[char-yyPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(yyTok3); i += 2 {
-		token = yyTok3[i+0]
+		token = int(yyTok3[i+0])
 		if token == char {
-			token = yyTok3[i+1]
+			token = int(yyTok3[i+1])
 			goto out
 		}
 	}
 
 out:
 	if token == 0 {
-		token = yyTok2[1] /* unknown char */
+		token = int(yyTok2[1]) /* unknown char */
 	}
 	if yyDebug >= 3 {
 		__yyfmt__.Printf("lex %s(%d)\n", yyTokname(token), uint(char))
@@ -1692,7 +2600,7 @@ yystack:
 	yyS[yyp].yys = yystate
 
 yynewstate:
-	yyn = yyPact[yystate]
+	yyn = int(yyPact[yystate])
 	if yyn <= yyFlag {
 		goto yydefault /* simple state */
 	}
@@ -1703,8 +2611,8 @@ yynewstate:
 	if yyn < 0 || yyn >= yyLast {
 		goto yydefault
 	}
-	yyn = yyAct[yyn]
-	if yyChk[yyn] == yytoken { /* valid shift */
+	yyn = int(yyAct[yyn])
+	if int(yyChk[yyn]) == yytoken { /* valid shift */
 		yyrcvr.char = -1
 		yytoken = -1
 		yyVAL = yyrcvr.lval
@@ -1717,7 +2625,7 @@ yynewstate:
 
 yydefault:
 	/* default state action */
-	yyn = yyDef[yystate]
+	yyn = int(yyDef[yystate])
 	if yyn == -2 {
 		if yyrcvr.char < 0 {
 			yyrcvr.char, yytoken = yylex1(yylex, &yyrcvr.lval)
@@ -1726,18 +2634,18 @@ yydefault:
 		/* look through exception table */
 		xi := 0
 		for {
-			if yyExca[xi+0] == -1 && yyExca[xi+1] == yystate {
+			if yyExca[xi+0] == -1 && int(yyExca[xi+1]) == yystate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			yyn = yyExca[xi+0]
+			yyn = int(yyExca[xi+0])
 			if yyn < 0 || yyn == yytoken {
 				break
 			}
 		}
-		yyn = yyExca[xi+1]
+		yyn = int(yyExca[xi+1])
 		if yyn < 0 {
 			goto ret0
 		}
@@ -1759,10 +2667,10 @@ yydefault:
 
 			/* find a state where "error" is a legal shift action */
 			for yyp >= 0 {
-				yyn = yyPact[yyS[yyp].yys] + yyErrCode
+				yyn = int(yyPact[yyS[yyp].yys]) + yyErrCode
 				if yyn >= 0 && yyn < yyLast {
-					yystate = yyAct[yyn] /* simulate a shift of "error" */
-					if yyChk[yystate] == yyErrCode {
+					yystate = int(yyAct[yyn]) /* simulate a shift of "error" */
+					if int(yyChk[yystate]) == yyErrCode {
 						goto yystack
 					}
 				}
@@ -1798,7 +2706,7 @@ yydefault:
 	yypt := yyp
 	_ = yypt // guard against "declared and not used"
 
-	yyp -= yyR2[yyn]
+	yyp -= int(yyR2[yyn])
 	// yyp is now the index of $0. Perform the default action. Iff the
 	// reduced production is ε, $1 is possibly out of range.
 	if yyp+1 >= len(yyS) {
@@ -1809,16 +2717,16 @@ yydefault:
 	yyVAL = yyS[yyp+1]
 
 	/* consult goto table to find next state */
-	yyn = yyR1[yyn]
-	yyg := yyPgo[yyn]
+	yyn = int(yyR1[yyn])
+	yyg := int(yyPgo[yyn])
 	yyj := yyg + yyS[yyp].yys + 1
 
 	if yyj >= yyLast {
-		yystate = yyAct[yyg]
+		yystate = int(yyAct[yyg])
 	} else {
-		yystate = yyAct[yyj]
-		if yyChk[yystate] != -yyn {
-			yystate = yyAct[yyg]
+		yystate = int(yyAct[yyj])
+		if int(yyChk[yystate]) != -yyn {
+			yystate = int(yyAct[yyg])
 		}
 	}
 	// dummy call; replaced with literal code
@@ -1826,91 +2734,143 @@ yydefault:
 
 	case 1:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:294
+//line yacc.y:466
 		{
 			SetParseTree(yylex, yyDollar[1].statement)
 		}
 	case 2:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:300
+//line yacc.y:472
 		{
 			yyVAL.statement = yyDollar[1].selStmt
 		}
 	case 3:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:302
+//line yacc.y:474
 		{
 			yyVAL.statement = yyDollar[1].setStmt
 		}
 	case 4:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:304
+//line yacc.y:476
 		{
 			yyVAL.statement = yyDollar[1].showStmt
 		}
 	case 5:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:306
+//line yacc.y:478
 		{
 			yyVAL.statement = yyDollar[1].showStmt
 		}
-	case 11:
+	case 21:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:495
+		{
+			yyVAL.statement = yyDollar[1].ddlStmt
+		}
+	case 22:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:313
+//line yacc.y:497
 		{
 			yyVAL.statement = yyDollar[1].ddlStmt
 		}
-	case 12:
+	case 23:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:315
+//line yacc.y:499
 		{
 			yyVAL.statement = yyDollar[1].ddlStmt
 		}
-	case 13:
+	case 24:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:317
+//line yacc.y:501
 		{
 			yyVAL.statement = yyDollar[1].ddlStmt
 		}
-	case 14:
+	case 25:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:319
+//line yacc.y:503
 		{
 			yyVAL.statement = yyDollar[1].ddlStmt
 		}
-	case 20:
+	case 35:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:326
+//line yacc.y:514
 		{
 			yyVAL.statement = nil
 		}
-	case 21:
+	case 36:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:330
+//line yacc.y:518
 		{
 			yyVAL.selStmt = &SimpleSelect{Comments: Comments(yyDollar[2].bytes2), Distinct: yyDollar[3].str, SelectExprs: yyDollar[4].selectExprs, Limit: yyDollar[5].limit}
 		}
-	case 22:
+	case 37:
 		yyDollar = yyS[yypt-12 : yypt+1]
-		//line yacc.y:334
+//line yacc.y:522
 		{
 			yyVAL.selStmt = &Select{Comments: Comments(yyDollar[2].bytes2), Distinct: yyDollar[3].str, SelectExprs: yyDollar[4].selectExprs, From: yyDollar[6].tableExprs, Where: NewWhere(AST_WHERE, yyDollar[7].boolExpr), GroupBy: GroupBy(yyDollar[8].valExprs), Having: NewWhere(AST_HAVING, yyDollar[9].boolExpr), OrderBy: yyDollar[10].orderBy, Limit: yyDollar[11].limit, Lock: yyDollar[12].str}
 		}
-	case 23:
+	case 38:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:338
+//line yacc.y:526
 		{
 			yyVAL.selStmt = &Union{Type: yyDollar[2].str, Left: yyDollar[1].selStmt, Right: yyDollar[3].selStmt}
 		}
-	case 24:
+	case 39:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:530
+		{
+			if !SetWith(yyDollar[4].selStmt, &With{Recursive: yyDollar[2].boolean, CTEs: yyDollar[3].ctes}) {
+				yylex.Error("expecting select from table after with")
+				return 1
+			}
+			yyVAL.selStmt = yyDollar[4].selStmt
+		}
+	case 40:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:539
+		{
+			yyVAL.boolean = false
+		}
+	case 41:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:543
+		{
+			yyVAL.boolean = true
+		}
+	case 42:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:549
+		{
+			yyVAL.ctes = []*CommonTableExpr{yyDollar[1].cte}
+		}
+	case 43:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:553
+		{
+			yyVAL.ctes = append(yyDollar[1].ctes, yyDollar[3].cte)
+		}
+	case 44:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:559
+		{
+			yyVAL.cte = &CommonTableExpr{Name: yyDollar[1].bytes, Select: yyDollar[4].selStmt}
+		}
+	case 45:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line yacc.y:563
+		{
+			yyVAL.cte = &CommonTableExpr{Name: yyDollar[1].bytes, Columns: yyDollar[3].bytes2, Select: yyDollar[7].selStmt}
+		}
+	case 46:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:344
+//line yacc.y:569
 		{
 			yyVAL.statement = &Insert{Comments: Comments(yyDollar[2].bytes2), Ignore: yyDollar[3].str, Table: yyDollar[5].tableName, Columns: yyDollar[6].columns, Rows: yyDollar[7].insRows, OnDup: OnDup(yyDollar[8].updateExprs)}
 		}
-	case 25:
+	case 47:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:348
+//line yacc.y:573
 		{
 			cols := make(Columns, 0, len(yyDollar[7].updateExprs))
 			vals := make(ValTuple, 0, len(yyDollar[7].updateExprs))
@@ -1920,15 +2880,15 @@ yydefault:
 			}
 			yyVAL.statement = &Insert{Comments: Comments(yyDollar[2].bytes2), Ignore: yyDollar[3].str, Table: yyDollar[5].tableName, Columns: cols, Rows: Values{vals}, OnDup: OnDup(yyDollar[8].updateExprs)}
 		}
-	case 26:
+	case 48:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:360
+//line yacc.y:585
 		{
 			yyVAL.statement = &Replace{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[4].tableName, Columns: yyDollar[5].columns, Rows: yyDollar[6].insRows}
 		}
-	case 27:
+	case 49:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:364
+//line yacc.y:589
 		{
 			cols := make(Columns, 0, len(yyDollar[6].updateExprs))
 			vals := make(ValTuple, 0, len(yyDollar[6].updateExprs))
@@ -1938,2360 +2898,4241 @@ yydefault:
 			}
 			yyVAL.statement = &Replace{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[4].tableName, Columns: cols, Rows: Values{vals}}
 		}
-	case 28:
-		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:376
+	case 50:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:601
 		{
-			yyVAL.statement = &Update{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[3].tableName, Exprs: yyDollar[5].updateExprs, Where: NewWhere(AST_WHERE, yyDollar[6].boolExpr), OrderBy: yyDollar[7].orderBy, Limit: yyDollar[8].limit}
+			yyVAL.statement = &Call{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[3].tableName, Args: yyDollar[4].valExprs}
 		}
-	case 29:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:382
+	case 51:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:606
 		{
-			yyVAL.statement = &Delete{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[4].tableName, Where: NewWhere(AST_WHERE, yyDollar[5].boolExpr), OrderBy: yyDollar[6].orderBy, Limit: yyDollar[7].limit}
+			yyVAL.valExprs = nil
 		}
-	case 30:
+	case 52:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:388
+//line yacc.y:610
 		{
-			yyVAL.statement = &Explain{Statement: yyDollar[2].selStmt}
+			yyVAL.valExprs = nil
 		}
-	case 31:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:392
+	case 53:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:614
 		{
-			yyVAL.statement = &Explain{Statement: yyDollar[2].statement}
+			yyVAL.valExprs = yyDollar[2].valExprs
 		}
-	case 32:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:396
+	case 54:
+		yyDollar = yyS[yypt-16 : yypt+1]
+//line yacc.y:620
 		{
-			yyVAL.statement = &Explain{Statement: yyDollar[2].statement}
+			if !bytes.Equal(yyDollar[3].bytes, DATA_BYTES) {
+				yylex.Error("expecting data")
+				return 1
+			}
+			load := &LoadData{Comments: Comments(yyDollar[2].bytes2), File: StrVal(yyDollar[6].bytes), Duplicate: yyDollar[7].str, Table: yyDollar[10].tableName, Charset: yyDollar[11].bytes, Fields: yyDollar[12].loadFields, Lines: yyDollar[13].loadLines, IgnoreRows: yyDollar[14].valExpr, Columns: yyDollar[15].columns, Set: yyDollar[16].updateExprs}
+			if !load.setModifiers(yyDollar[4].bytes2) {
+				yylex.Error("expecting low_priority, concurrent or local")
+				return 1
+			}
+			yyVAL.statement = load
 		}
-	case 33:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:400
+	case 55:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:634
 		{
-			yyVAL.statement = &Explain{Statement: yyDollar[2].statement}
+			yyVAL.bytes2 = nil
 		}
-	case 34:
+	case 56:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:404
+//line yacc.y:638
 		{
-			yyVAL.statement = &Explain{Statement: yyDollar[2].statement}
+			yyVAL.bytes2 = append(yyDollar[1].bytes2, []byte(AST_LOW_PRIORITY))
 		}
-	case 35:
+	case 57:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:408
+//line yacc.y:642
 		{
-			yyVAL.statement = &Explain{Statement: yyDollar[2].setStmt}
+			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[2].bytes)
 		}
-	case 36:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:412
+	case 58:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:647
 		{
-			yyVAL.statement = &Explain{Statement: yyDollar[2].showStmt}
+			yyVAL.str = ""
 		}
-	case 37:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:416
+	case 59:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:651
 		{
-			yyVAL.statement = &Explain{Statement: yyDollar[2].showStmt}
+			yyVAL.str = AST_REPLACE
 		}
-	case 38:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:420
+	case 60:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:655
 		{
-			yyVAL.statement = &Explain{Statement: yyDollar[2].ddlStmt}
+			yyVAL.str = AST_IGNORE
 		}
-	case 39:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:424
+	case 61:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:660
 		{
-			yyVAL.statement = &Explain{Statement: yyDollar[2].ddlStmt}
+			yyVAL.bytes = nil
 		}
-	case 40:
+	case 62:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:664
+		{
+			yyVAL.bytes = yyDollar[3].bytes
+		}
+	case 63:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:428
+//line yacc.y:668
 		{
-			yyVAL.statement = &Explain{Statement: yyDollar[2].ddlStmt}
+			yyVAL.bytes = yyDollar[2].bytes
 		}
-	case 41:
+	case 64:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:673
+		{
+			yyVAL.loadFields = nil
+		}
+	case 65:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:432
+//line yacc.y:677
 		{
-			yyVAL.statement = &Explain{Statement: yyDollar[2].ddlStmt}
+			yyVAL.loadFields = yyDollar[2].loadFields
 		}
-	case 42:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:438
+	case 66:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:681
 		{
-			yyVAL.setStmt = &SetVariable{
-				Comments: Comments(yyDollar[2].bytes2),
-				Scope:    string(yyDollar[3].bytes),
-				Exprs:    yyDollar[4].updateExprs,
-			}
+			yyVAL.loadFields = yyDollar[2].loadFields
 		}
-	case 43:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:446
+	case 67:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:686
 		{
-			yyVAL.setStmt = &SetCharset{
-				Comments: Comments(yyDollar[2].bytes2),
-				Charset:  string(yyDollar[5].bytes),
-			}
+			yyVAL.loadFields = &LoadFields{}
 		}
-	case 44:
+	case 68:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:453
+//line yacc.y:690
 		{
-			yyVAL.setStmt = &SetCharset{
-				Comments: Comments(yyDollar[2].bytes2),
-				Charset:  string(yyDollar[4].bytes),
-			}
+			yyDollar[1].loadFields.Terminated = StrVal(yyDollar[4].bytes)
+			yyVAL.loadFields = yyDollar[1].loadFields
 		}
-	case 45:
+	case 69:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:460
+//line yacc.y:695
 		{
-			yyVAL.setStmt = &SetNames{
-				Comments: Comments(yyDollar[2].bytes2),
-				Names:    string(yyDollar[4].bytes),
-			}
+			yyDollar[1].loadFields.Enclosed = StrVal(yyDollar[4].bytes)
+			yyVAL.loadFields = yyDollar[1].loadFields
 		}
-	case 46:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:467
+	case 70:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:700
 		{
-			yyVAL.setStmt = &SetNames{
-				Comments: Comments(yyDollar[2].bytes2),
-				Names:    string(yyDollar[4].bytes),
-				Collate:  string(yyDollar[6].bytes),
-			}
+			yyDollar[1].loadFields.Enclosed = StrVal(yyDollar[5].bytes)
+			yyDollar[1].loadFields.Optionally = true
+			yyVAL.loadFields = yyDollar[1].loadFields
 		}
-	case 47:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:475
+	case 71:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:706
 		{
-			yyVAL.setStmt = &SetTransactionIsolationLevel{
-				Comments:       Comments(yyDollar[2].bytes2),
-				Scope:          string(yyDollar[3].bytes),
-				IsolationLevel: string(yyDollar[7].bytes),
-			}
+			yyDollar[1].loadFields.Escaped = StrVal(yyDollar[4].bytes)
+			yyVAL.loadFields = yyDollar[1].loadFields
 		}
-	case 48:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:485
+	case 72:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:712
 		{
-			yyVAL.statement = &Begin{}
+			yyVAL.loadLines = nil
 		}
-	case 49:
+	case 73:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:489
+//line yacc.y:716
 		{
-			yyVAL.statement = &Begin{}
+			yyVAL.loadLines = yyDollar[2].loadLines
 		}
-	case 50:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:495
+	case 74:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:721
 		{
-			yyVAL.statement = &Commit{}
+			yyVAL.loadLines = &LoadLines{}
 		}
-	case 51:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:501
+	case 75:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:725
 		{
-			yyVAL.statement = &Rollback{}
+			yyDollar[1].loadLines.Starting = StrVal(yyDollar[4].bytes)
+			yyVAL.loadLines = yyDollar[1].loadLines
 		}
-	case 52:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:507
+	case 76:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:730
 		{
-			yyVAL.statement = &UseDB{DB: string(yyDollar[2].bytes)}
+			yyDollar[1].loadLines.Terminated = StrVal(yyDollar[4].bytes)
+			yyVAL.loadLines = yyDollar[1].loadLines
 		}
-	case 53:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:514
+	case 77:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:736
 		{
-			yyVAL.statement = &KillConnection{ConnectionID: NumVal(yyDollar[2].bytes)}
+			yyVAL.valExpr = nil
 		}
-	case 54:
+	case 78:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:518
+//line yacc.y:740
 		{
-			yyVAL.statement = &KillConnection{ConnectionID: NumVal(yyDollar[3].bytes)}
+			yyVAL.valExpr = NumVal(yyDollar[2].bytes)
 		}
-	case 55:
+	case 79:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:522
+//line yacc.y:744
 		{
-			yyVAL.statement = &KillQuery{ConnectionID: NumVal(yyDollar[3].bytes)}
-		}
-	case 56:
-		yyDollar = yyS[yypt-9 : yypt+1]
-		//line yacc.y:528
-		{
-			yyVAL.ddlStmt = &CreateTable{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[5].tableName, CreateDefs: yyDollar[7].createDefs, TableOptions: yyDollar[9].optKeyVals}
-		}
-	case 57:
-		yyDollar = yyS[yypt-12 : yypt+1]
-		//line yacc.y:532
-		{
-			yyVAL.ddlStmt = &CreateIndex{Comments: Comments(yyDollar[2].bytes2), IndexCategory: yyDollar[3].bytes, Name: yyDollar[5].bytes, IndexType: yyDollar[6].bytes, Table: yyDollar[8].tableName, IndexColumns: yyDollar[10].idxColNames, IndexOption: yyDollar[12].bytes}
-		}
-	case 58:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:538
-		{
-			yyVAL.ddlStmt = &AlterTable{Comments: Comments(yyDollar[2].bytes2), Ignore: yyDollar[3].str, Table: yyDollar[5].tableName, AlterSpecs: yyDollar[6].alterSpecs}
-		}
-	case 59:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:544
-		{
-			yyVAL.ddlStmt = &RenameTable{Comments: Comments(yyDollar[2].bytes2), OldName: yyDollar[4].tableName, NewName: yyDollar[6].tableName}
-		}
-	case 60:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:550
-		{
-			yyVAL.ddlStmt = &DropTable{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName, RefOption: yyDollar[6].bytes}
-		}
-	case 61:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:554
-		{
-			yyVAL.ddlStmt = &DropIndex{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[4].bytes, Table: yyDollar[6].tableName}
-		}
-	case 62:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:560
-		{
-			yyVAL.showStmt = &ShowCharset{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[5].expr}
-		}
-	case 63:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:564
-		{
-			yyVAL.showStmt = &ShowCharset{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[4].expr}
-		}
-	case 64:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:568
-		{
-			yyVAL.showStmt = &ShowCollation{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[4].expr}
-		}
-	case 65:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:572
-		{
-			yyVAL.showStmt = &ShowVariables{Comments: Comments(yyDollar[2].bytes2), Scope: string(yyDollar[3].bytes), LikeOrWhere: yyDollar[5].expr}
-		}
-	case 66:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:576
-		{
-			yyVAL.showStmt = &ShowStatus{Comments: Comments(yyDollar[2].bytes2), Scope: string(yyDollar[3].bytes), LikeOrWhere: yyDollar[5].expr}
-		}
-	case 67:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:580
-		{
-			yyVAL.showStmt = &ShowDatabases{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[4].expr}
-		}
-	case 68:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:584
-		{
-			yyVAL.showStmt = &ShowDatabases{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[4].expr}
-		}
-	case 69:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:588
-		{
-			yyVAL.showStmt = &ShowTables{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[4].expr}
-		}
-	case 70:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:592
-		{
-			yyVAL.showStmt = &ShowTables{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, LikeOrWhere: yyDollar[6].expr}
-		}
-	case 71:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:596
-		{
-			yyVAL.showStmt = &ShowFullTables{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[5].expr}
-		}
-	case 72:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:600
-		{
-			yyVAL.showStmt = &ShowFullTables{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[6].tableName, LikeOrWhere: yyDollar[7].expr}
-		}
-	case 73:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:604
-		{
-			yyVAL.showStmt = &ShowTableStatus{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[5].expr}
-		}
-	case 74:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:608
-		{
-			yyVAL.showStmt = &ShowTableStatus{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[6].tableName, LikeOrWhere: yyDollar[7].expr}
-		}
-	case 75:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:612
-		{
-			yyVAL.showStmt = &ShowColumns{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, LikeOrWhere: yyDollar[6].expr}
-		}
-	case 76:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:616
-		{
-			yyVAL.showStmt = &ShowColumns{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, LikeOrWhere: yyDollar[6].expr}
-		}
-	case 77:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:620
-		{
-			yyVAL.showStmt = &ShowFullColumns{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[6].tableName, LikeOrWhere: yyDollar[7].expr}
-		}
-	case 78:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:624
-		{
-			yyVAL.showStmt = &ShowFullColumns{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[6].tableName, LikeOrWhere: yyDollar[7].expr}
-		}
-	case 79:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:628
-		{
-			yyVAL.showStmt = &ShowProcedureStatus{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[5].expr}
+			if !bytes.Equal(yyDollar[3].bytes, ROWS_BYTES) {
+				yylex.Error("expecting lines or rows")
+				return 1
+			}
+			yyVAL.valExpr = NumVal(yyDollar[2].bytes)
 		}
 	case 80:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:632
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:753
 		{
-			yyVAL.showStmt = &ShowFunctionStatus{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[5].expr}
+			yyVAL.updateExprs = nil
 		}
 	case 81:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:636
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:757
 		{
-			yyVAL.showStmt = &ShowIndex{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, Where: yyDollar[6].boolExpr}
+			yyVAL.updateExprs = yyDollar[2].updateExprs
 		}
 	case 82:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:640
+//line yacc.y:763
 		{
-			yyVAL.showStmt = &ShowIndex{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, Where: yyDollar[6].boolExpr}
+			if !bytes.EqualFold(yyDollar[3].bytes, USER_BYTES) {
+				yylex.Error("expecting user")
+				return 1
+			}
+			yyVAL.statement = &CreateUser{Comments: Comments(yyDollar[2].bytes2), IfNotExists: yyDollar[4].boolean, Users: yyDollar[5].userSpecs, Require: yyDollar[6].requireOpts}
 		}
 	case 83:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:644
+//line yacc.y:773
 		{
-			yyVAL.showStmt = &ShowIndex{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, Where: yyDollar[6].boolExpr}
+			if !bytes.EqualFold(yyDollar[3].bytes, USER_BYTES) {
+				yylex.Error("expecting user")
+				return 1
+			}
+			yyVAL.statement = &AlterUser{Comments: Comments(yyDollar[2].bytes2), IfExists: yyDollar[4].boolean, Users: yyDollar[5].userSpecs, Require: yyDollar[6].requireOpts}
 		}
 	case 84:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:648
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:783
 		{
-			yyVAL.showStmt = &ShowTriggers{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, LikeOrWhere: yyDollar[6].expr}
+			if !bytes.EqualFold(yyDollar[3].bytes, USER_BYTES) {
+				yylex.Error("expecting user")
+				return 1
+			}
+			yyVAL.statement = &DropUser{Comments: Comments(yyDollar[2].bytes2), IfExists: yyDollar[4].boolean, Accounts: yyDollar[5].accounts}
 		}
 	case 85:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:652
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:793
 		{
-			yyVAL.showStmt = &ShowCreateDatabase{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName}
+			yyVAL.userSpecs = UserSpecs{yyDollar[1].userSpec}
 		}
 	case 86:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:656
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:797
 		{
-			yyVAL.showStmt = &ShowCreateTable{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName}
+			yyVAL.userSpecs = append(yyDollar[1].userSpecs, yyDollar[3].userSpec)
 		}
 	case 87:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:660
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:803
 		{
-			yyVAL.showStmt = &ShowCreateView{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName}
+			yyVAL.userSpec = &UserSpec{Account: yyDollar[1].account, Auth: yyDollar[2].authOption}
 		}
 	case 88:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:664
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:808
 		{
-			yyVAL.showStmt = &ShowCreateProcedure{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName}
+			yyVAL.authOption = nil
 		}
 	case 89:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:668
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:812
 		{
-			yyVAL.showStmt = &ShowCreateFunction{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName}
+			yyVAL.authOption = &AuthOption{Password: StrVal(yyDollar[3].bytes)}
 		}
 	case 90:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:672
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:816
 		{
-			yyVAL.showStmt = &ShowCreateTrigger{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName}
+			if !bytes.Equal(yyDollar[3].bytes, PASSWORD_BYTES) {
+				yylex.Error("expecting password")
+				return 1
+			}
+			yyVAL.authOption = &AuthOption{Password: StrVal(yyDollar[4].bytes), Hashed: true}
 		}
 	case 91:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:676
+//line yacc.y:824
 		{
-			yyVAL.showStmt = &ShowProcessList{Comments: Comments(yyDollar[2].bytes2)}
+			yyVAL.authOption = &AuthOption{Plugin: yyDollar[3].bytes}
 		}
 	case 92:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:680
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:828
 		{
-			yyVAL.showStmt = &ShowFullProcessList{Comments: Comments(yyDollar[2].bytes2)}
+			yyVAL.authOption = &AuthOption{Plugin: yyDollar[3].bytes, Password: StrVal(yyDollar[5].bytes)}
 		}
 	case 93:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:684
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:832
 		{
-			yyVAL.showStmt = &ShowSlaveStatus{Comments: Comments(yyDollar[2].bytes2)}
+			yyVAL.authOption = &AuthOption{Plugin: yyDollar[3].bytes, Password: StrVal(yyDollar[5].bytes), Hashed: true}
 		}
 	case 94:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:688
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:837
 		{
-			yyVAL.showStmt = &ShowEngines{}
+			yyVAL.requireOpts = nil
 		}
 	case 95:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:692
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:841
 		{
-			yyVAL.showStmt = &ShowEngines{}
+			yyVAL.requireOpts = yyDollar[2].requireOpts
 		}
 	case 96:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:696
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:847
 		{
-			yyVAL.showStmt = &ShowPlugins{}
+			yyVAL.requireOpts = RequireOptions{yyDollar[1].requireOpt}
 		}
 	case 97:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:700
+//line yacc.y:851
 		{
-			yyVAL.showStmt = &ShowProfiles{}
+			yyVAL.requireOpts = append(yyDollar[1].requireOpts, yyDollar[2].requireOpt)
 		}
 	case 98:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:706
+//line yacc.y:855
 		{
-			yyVAL.showStmt = &ShowColumns{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[3].tableName}
+			yyVAL.requireOpts = append(yyDollar[1].requireOpts, yyDollar[3].requireOpt)
 		}
 	case 99:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:711
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:861
 		{
-			SetAllowComments(yylex, true)
+			if !IsRequireOption(yyDollar[1].bytes, false) {
+				yylex.Error("expecting none, ssl or x509")
+				return 1
+			}
+			yyVAL.requireOpt = &RequireOption{Name: string(yyDollar[1].bytes)}
 		}
 	case 100:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:715
+//line yacc.y:869
 		{
-			yyVAL.bytes2 = yyDollar[2].bytes2
-			SetAllowComments(yylex, false)
+			if !IsRequireOption(yyDollar[1].bytes, true) {
+				yylex.Error("expecting cipher, issuer or subject")
+				return 1
+			}
+			yyVAL.requireOpt = &RequireOption{Name: string(yyDollar[1].bytes), Value: StrVal(yyDollar[2].bytes)}
 		}
 	case 101:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:721
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line yacc.y:879
 		{
-			yyVAL.bytes2 = nil
+			yyVAL.statement = &Grant{Comments: Comments(yyDollar[2].bytes2), Privileges: yyDollar[3].privileges, ObjectType: yyDollar[5].str, Level: yyDollar[6].privLevel, Accounts: yyDollar[8].accounts, GrantOption: yyDollar[9].boolean}
 		}
 	case 102:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:725
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line yacc.y:885
 		{
-			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[2].bytes)
+			yyVAL.statement = &Revoke{Comments: Comments(yyDollar[2].bytes2), Privileges: yyDollar[3].privileges, ObjectType: yyDollar[5].str, Level: yyDollar[6].privLevel, Accounts: yyDollar[8].accounts}
 		}
 	case 103:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:731
+//line yacc.y:891
 		{
-			yyVAL.str = AST_UNION
+			yyVAL.privileges = Privileges{yyDollar[1].privilege}
 		}
 	case 104:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:735
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:895
 		{
-			yyVAL.str = AST_UNION_ALL
+			yyVAL.privileges = append(yyDollar[1].privileges, yyDollar[3].privilege)
 		}
 	case 105:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:739
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:901
 		{
-			yyVAL.str = AST_SET_MINUS
+			yyVAL.privilege = &Privilege{Name: string(yyDollar[1].bytes), Columns: yyDollar[2].columns}
 		}
 	case 106:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:743
+//line yacc.y:907
 		{
-			yyVAL.str = AST_EXCEPT
+			yyVAL.bytes = yyDollar[1].bytes
 		}
 	case 107:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:747
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:911
 		{
-			yyVAL.str = AST_INTERSECT
+			yyVAL.bytes = append(append(yyDollar[1].bytes, ' '), yyDollar[2].bytes...)
 		}
 	case 108:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:752
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:917
 		{
-			yyVAL.str = ""
+			yyVAL.bytes = yyDollar[1].bytes
 		}
 	case 109:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:756
+//line yacc.y:919
 		{
-			yyVAL.str = AST_DISTINCT
+			yyVAL.bytes = []byte("all")
 		}
 	case 110:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:762
+//line yacc.y:921
 		{
-			yyVAL.selectExprs = SelectExprs{yyDollar[1].selectExpr}
+			yyVAL.bytes = []byte("select")
 		}
 	case 111:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:766
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:923
 		{
-			yyVAL.selectExprs = append(yyVAL.selectExprs, yyDollar[3].selectExpr)
+			yyVAL.bytes = []byte("insert")
 		}
 	case 112:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:772
+//line yacc.y:925
 		{
-			yyVAL.selectExpr = &StarExpr{}
+			yyVAL.bytes = []byte("update")
 		}
 	case 113:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:776
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:927
 		{
-			yyVAL.selectExpr = &StarExpr{TableName: bytes.ToLower(yyDollar[1].bytes)}
+			yyVAL.bytes = []byte("delete")
 		}
 	case 114:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:780
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:929
 		{
-			yyVAL.selectExpr = &NonStarExpr{Expr: yyDollar[1].expr, As: yyDollar[2].bytes}
+			yyVAL.bytes = []byte("create")
 		}
 	case 115:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:786
+//line yacc.y:931
 		{
-			yyVAL.expr = yyDollar[1].boolExpr
+			yyVAL.bytes = []byte("alter")
 		}
 	case 116:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:790
+//line yacc.y:933
 		{
-			yyVAL.expr = yyDollar[1].valExpr
+			yyVAL.bytes = []byte("drop")
 		}
 	case 117:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:795
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:935
 		{
-			yyVAL.bytes = nil
+			yyVAL.bytes = []byte("index")
 		}
 	case 118:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:799
+//line yacc.y:937
 		{
-			yyVAL.bytes = yyDollar[1].bytes
+			yyVAL.bytes = []byte("references")
 		}
 	case 119:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:803
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:939
 		{
-			yyVAL.bytes = yyDollar[2].bytes
+			yyVAL.bytes = []byte("show")
 		}
 	case 120:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:809
+//line yacc.y:941
 		{
-			yyVAL.tableExprs = TableExprs{yyDollar[1].tableExpr}
+			yyVAL.bytes = []byte("view")
 		}
 	case 121:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:813
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:943
 		{
-			yyVAL.tableExprs = append(yyVAL.tableExprs, yyDollar[3].tableExpr)
+			yyVAL.bytes = []byte("tables")
 		}
 	case 122:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:819
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:945
 		{
-			yyVAL.tableExpr = &AliasedTableExpr{Expr: yyDollar[1].smTableExpr, As: yyDollar[2].bytes, Hints: yyDollar[3].indexHints}
+			yyVAL.bytes = []byte("databases")
 		}
 	case 123:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:823
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:947
 		{
-			yyVAL.tableExpr = &ParenTableExpr{Expr: yyDollar[2].tableExpr}
+			yyVAL.bytes = []byte("lock")
 		}
 	case 124:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:827
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:949
 		{
-			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr, On: yyDollar[5].boolExpr}
+			yyVAL.bytes = []byte("trigger")
 		}
 	case 125:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:833
+//line yacc.y:951
 		{
-			yyVAL.str = AST_JOIN
+			yyVAL.bytes = []byte("processlist")
 		}
 	case 126:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:837
+//line yacc.y:953
 		{
-			yyVAL.str = AST_STRAIGHT_JOIN
+			yyVAL.bytes = []byte("slave")
 		}
 	case 127:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:841
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:955
 		{
-			yyVAL.str = AST_LEFT_JOIN
+			yyVAL.bytes = []byte("grant")
 		}
 	case 128:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:845
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:958
 		{
-			yyVAL.str = AST_LEFT_JOIN
+			yyVAL.str = ""
 		}
 	case 129:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:849
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:960
 		{
-			yyVAL.str = AST_RIGHT_JOIN
+			yyVAL.str = AST_TABLE
 		}
 	case 130:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:853
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:962
 		{
-			yyVAL.str = AST_RIGHT_JOIN
+			yyVAL.str = AST_FUNCTION
 		}
 	case 131:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:857
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:964
 		{
-			yyVAL.str = AST_JOIN
+			yyVAL.str = AST_PROCEDURE
 		}
 	case 132:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:861
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:968
 		{
-			yyVAL.str = AST_CROSS_JOIN
+			yyVAL.privLevel = &PrivilegeLevel{Name: []byte("*")}
 		}
 	case 133:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:865
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:972
 		{
-			yyVAL.str = AST_NATURAL_JOIN
+			yyVAL.privLevel = &PrivilegeLevel{Qualifier: []byte("*"), Name: []byte("*")}
 		}
 	case 134:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:871
+//line yacc.y:976
 		{
-			yyVAL.smTableExpr = yyDollar[1].tableName
+			yyVAL.privLevel = &PrivilegeLevel{Name: yyDollar[1].bytes}
 		}
 	case 135:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:875
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:980
 		{
-			yyVAL.smTableExpr = yyDollar[1].subquery
+			yyVAL.privLevel = &PrivilegeLevel{Qualifier: yyDollar[1].bytes, Name: []byte("*")}
 		}
 	case 136:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:881
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:984
 		{
-			yyVAL.tableName = &TableName{Name: yyDollar[1].bytes}
+			yyVAL.privLevel = &PrivilegeLevel{Qualifier: yyDollar[1].bytes, Name: yyDollar[3].bytes}
 		}
 	case 137:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:885
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:990
 		{
-			yyVAL.tableName = &TableName{Qualifier: yyDollar[1].bytes, Name: yyDollar[3].bytes}
+			yyVAL.accounts = Accounts{yyDollar[1].account}
 		}
 	case 138:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:890
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:994
 		{
-			yyVAL.indexHints = nil
+			yyVAL.accounts = append(yyDollar[1].accounts, yyDollar[3].account)
 		}
 	case 139:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:894
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1000
 		{
-			yyVAL.indexHints = &IndexHints{Type: AST_USE, Indexes: yyDollar[4].bytes2}
+			yyVAL.account = &Account{User: yyDollar[1].bytes}
 		}
 	case 140:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:898
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1004
 		{
-			yyVAL.indexHints = &IndexHints{Type: AST_IGNORE, Indexes: yyDollar[4].bytes2}
+			if len(yyDollar[2].bytes) < 2 || yyDollar[2].bytes[0] != '@' {
+				yylex.Error("expecting @host")
+				return 1
+			}
+			yyVAL.account = &Account{User: yyDollar[1].bytes, Host: yyDollar[2].bytes[1:]}
 		}
 	case 141:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:902
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1012
 		{
-			yyVAL.indexHints = &IndexHints{Type: AST_FORCE, Indexes: yyDollar[4].bytes2}
+			if !bytes.Equal(yyDollar[2].bytes, AT_BYTES) {
+				yylex.Error("expecting @")
+				return 1
+			}
+			yyVAL.account = &Account{User: yyDollar[1].bytes, Host: yyDollar[3].bytes}
 		}
 	case 142:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:908
+//line yacc.y:1020
 		{
-			yyVAL.bytes2 = [][]byte{yyDollar[1].bytes}
+			yyVAL.account = NewAccount(yyDollar[1].bytes)
 		}
 	case 143:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:912
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1024
 		{
-			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[3].bytes)
+			if len(yyDollar[1].bytes) < 2 || yyDollar[1].bytes[len(yyDollar[1].bytes)-1] != '@' {
+				yylex.Error("expecting user@")
+				return 1
+			}
+			yyVAL.account = &Account{User: yyDollar[1].bytes[:len(yyDollar[1].bytes)-1], Host: yyDollar[2].bytes}
 		}
 	case 144:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:918
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:1033
 		{
-			yyVAL.valExprs = ValExprs{yyDollar[1].valExpr}
+			yyVAL.boolean = false
 		}
 	case 145:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:922
+//line yacc.y:1037
 		{
-			yyVAL.valExprs = append(yyDollar[1].valExprs, yyDollar[3].valExpr)
+			yyVAL.boolean = true
 		}
 	case 146:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:927
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1043
 		{
-			yyVAL.boolExpr = nil
+			yyVAL.statement = &Prepare{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[3].bytes, From: StrVal(yyDollar[5].bytes)}
 		}
 	case 147:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:931
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1047
 		{
-			yyVAL.boolExpr = yyDollar[2].boolExpr
+			yyVAL.statement = &Prepare{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[3].bytes, From: yyDollar[5].colName}
+		}
+	case 148:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1053
+		{
+			yyVAL.statement = &Execute{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[3].bytes, Using: yyDollar[4].valExprs}
 		}
 	case 149:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:938
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:1058
 		{
-			yyVAL.boolExpr = &AndExpr{Left: yyDollar[1].boolExpr, Right: yyDollar[3].boolExpr}
+			yyVAL.valExprs = nil
 		}
 	case 150:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:942
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1062
 		{
-			yyVAL.boolExpr = &OrExpr{Left: yyDollar[1].boolExpr, Right: yyDollar[3].boolExpr}
+			yyVAL.valExprs = yyDollar[2].valExprs
 		}
 	case 151:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:946
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1068
 		{
-			yyVAL.boolExpr = &NotExpr{Expr: yyDollar[2].boolExpr}
+			yyVAL.statement = &Deallocate{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[4].bytes}
 		}
 	case 152:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:950
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1072
 		{
-			yyVAL.boolExpr = &ParenBoolExpr{Expr: yyDollar[2].boolExpr}
+			yyVAL.statement = &Deallocate{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[4].bytes}
 		}
 	case 153:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:956
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line yacc.y:1078
 		{
-			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: yyDollar[2].str, Right: yyDollar[3].valExpr}
+			yyVAL.statement = &Update{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[3].tableName, Exprs: yyDollar[5].updateExprs, Where: NewWhere(AST_WHERE, yyDollar[6].boolExpr), OrderBy: yyDollar[7].orderBy, Limit: yyDollar[8].limit}
 		}
 	case 154:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:960
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:1084
 		{
-			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_IN, Right: yyDollar[3].tuple}
+			yyVAL.statement = &Delete{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[4].tableName, Where: NewWhere(AST_WHERE, yyDollar[5].boolExpr), OrderBy: yyDollar[6].orderBy, Limit: yyDollar[7].limit}
 		}
 	case 155:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:964
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1090
 		{
-			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_IN, Right: yyDollar[4].tuple}
+			yyVAL.statement = &Explain{Statement: yyDollar[2].selStmt}
 		}
 	case 156:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:968
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1094
 		{
-			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_LIKE, Right: yyDollar[3].valExpr}
+			yyVAL.statement = &Explain{Statement: yyDollar[2].statement}
 		}
 	case 157:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:972
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1098
 		{
-			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_LIKE, Right: yyDollar[4].valExpr}
+			yyVAL.statement = &Explain{Statement: yyDollar[2].statement}
 		}
 	case 158:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:976
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1102
 		{
-			yyVAL.boolExpr = &RangeCond{Left: yyDollar[1].valExpr, Operator: AST_BETWEEN, From: yyDollar[3].valExpr, To: yyDollar[5].valExpr}
+			yyVAL.statement = &Explain{Statement: yyDollar[2].statement}
 		}
 	case 159:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:980
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1106
 		{
-			yyVAL.boolExpr = &RangeCond{Left: yyDollar[1].valExpr, Operator: AST_NOT_BETWEEN, From: yyDollar[4].valExpr, To: yyDollar[6].valExpr}
+			yyVAL.statement = &Explain{Statement: yyDollar[2].statement}
 		}
 	case 160:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:984
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1110
 		{
-			yyVAL.boolExpr = &NullCheck{Operator: AST_IS_NULL, Expr: yyDollar[1].valExpr}
+			yyVAL.statement = &Explain{Statement: yyDollar[2].setStmt}
 		}
 	case 161:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:988
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1114
 		{
-			yyVAL.boolExpr = &NullCheck{Operator: AST_IS_NOT_NULL, Expr: yyDollar[1].valExpr}
+			yyVAL.statement = &Explain{Statement: yyDollar[2].showStmt}
 		}
 	case 162:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:992
+//line yacc.y:1118
 		{
-			yyVAL.boolExpr = &ExistsExpr{Subquery: yyDollar[2].subquery}
+			yyVAL.statement = &Explain{Statement: yyDollar[2].showStmt}
 		}
 	case 163:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:998
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1122
 		{
-			yyVAL.str = AST_EQ
+			yyVAL.statement = &Explain{Statement: yyDollar[2].ddlStmt}
 		}
 	case 164:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1002
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1126
 		{
-			yyVAL.str = AST_LT
+			yyVAL.statement = &Explain{Statement: yyDollar[2].ddlStmt}
 		}
 	case 165:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1006
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1130
 		{
-			yyVAL.str = AST_GT
+			yyVAL.statement = &Explain{Statement: yyDollar[2].ddlStmt}
 		}
 	case 166:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1010
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1134
 		{
-			yyVAL.str = AST_LE
+			yyVAL.statement = &Explain{Statement: yyDollar[2].ddlStmt}
 		}
 	case 167:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1014
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1140
 		{
-			yyVAL.str = AST_GE
+			yyVAL.setStmt = &SetVariable{
+				Comments: Comments(yyDollar[2].bytes2),
+				Scope:    string(yyDollar[3].bytes),
+				Exprs:    yyDollar[4].setExprs,
+			}
 		}
 	case 168:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1018
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1148
 		{
-			yyVAL.str = AST_NE
+			yyVAL.setStmt = &SetCharset{
+				Comments: Comments(yyDollar[2].bytes2),
+				Charset:  string(yyDollar[5].bytes),
+			}
 		}
 	case 169:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1022
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1155
 		{
-			yyVAL.str = AST_NSE
+			yyVAL.setStmt = &SetCharset{
+				Comments: Comments(yyDollar[2].bytes2),
+				Charset:  string(yyDollar[4].bytes),
+			}
 		}
 	case 170:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1028
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1162
 		{
-			yyVAL.insRows = yyDollar[2].values
+			yyVAL.setStmt = &SetNames{
+				Comments: Comments(yyDollar[2].bytes2),
+				Names:    string(yyDollar[4].bytes),
+			}
 		}
 	case 171:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1032
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1169
 		{
-			yyVAL.insRows = yyDollar[1].selStmt
+			yyVAL.setStmt = &SetNames{
+				Comments: Comments(yyDollar[2].bytes2),
+				Names:    string(yyDollar[4].bytes),
+				Collate:  string(yyDollar[6].bytes),
+			}
 		}
 	case 172:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1038
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1177
 		{
-			yyVAL.values = Values{yyDollar[1].tuple}
+			yyVAL.setStmt = &SetNames{
+				Comments: Comments(yyDollar[2].bytes2),
+				Names:    string(yyDollar[4].bytes),
+				Collate:  string(yyDollar[6].bytes),
+			}
 		}
 	case 173:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1042
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:1185
 		{
-			yyVAL.values = append(yyDollar[1].values, yyDollar[3].tuple)
+			yyVAL.setStmt = &SetTransactionIsolationLevel{
+				Comments:       Comments(yyDollar[2].bytes2),
+				Scope:          string(yyDollar[3].bytes),
+				IsolationLevel: string(yyDollar[7].bytes),
+			}
 		}
 	case 174:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1048
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1195
 		{
-			yyVAL.tuple = ValTuple(yyDollar[2].valExprs)
+			yyVAL.statement = &LockTables{Comments: Comments(yyDollar[2].bytes2), Tables: yyDollar[4].tableLocks}
 		}
 	case 175:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1052
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1199
 		{
-			yyVAL.tuple = yyDollar[1].subquery
+			yyVAL.statement = &LockTables{Comments: Comments(yyDollar[2].bytes2), Tables: yyDollar[4].tableLocks}
 		}
 	case 176:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1058
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1205
 		{
-			yyVAL.subquery = &Subquery{yyDollar[2].selStmt}
+			yyVAL.tableLocks = []*TableLock{yyDollar[1].tableLock}
 		}
 	case 177:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1064
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1209
 		{
-			yyVAL.valExprs = ValExprs{yyDollar[1].valExpr}
+			yyVAL.tableLocks = append(yyDollar[1].tableLocks, yyDollar[3].tableLock)
 		}
 	case 178:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1068
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1215
 		{
-			yyVAL.valExprs = append(yyDollar[1].valExprs, yyDollar[3].valExpr)
+			yyVAL.tableLock = &TableLock{Table: yyDollar[1].tableName, Lock: yyDollar[2].str}
 		}
 	case 179:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1074
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1219
 		{
-			yyVAL.valExpr = yyDollar[1].valExpr
+			yyVAL.tableLock = &TableLock{Table: yyDollar[1].tableName, As: yyDollar[3].bytes, Lock: yyDollar[4].str}
 		}
 	case 180:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1078
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1223
 		{
-			yyVAL.valExpr = yyDollar[1].colName
+			yyVAL.tableLock = &TableLock{Table: yyDollar[1].tableName, As: bytes.ToLower(yyDollar[2].bytes), Lock: yyDollar[3].str}
 		}
 	case 181:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1082
+//line yacc.y:1229
 		{
-			yyVAL.valExpr = yyDollar[1].tuple
+			yyVAL.str = AST_LOCK_READ
 		}
 	case 182:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1086
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1233
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITAND, Right: yyDollar[3].valExpr}
+			if !bytes.EqualFold(yyDollar[2].bytes, LOCAL_BYTES) {
+				yylex.Error("expecting read local")
+				return 1
+			}
+			yyVAL.str = AST_LOCK_READ_LOCAL
 		}
 	case 183:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1090
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1241
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITOR, Right: yyDollar[3].valExpr}
+			if !bytes.EqualFold(yyDollar[1].bytes, WRITE_BYTES) {
+				yylex.Error("expecting read or write")
+				return 1
+			}
+			yyVAL.str = AST_LOCK_WRITE
 		}
 	case 184:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1094
+//line yacc.y:1251
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITXOR, Right: yyDollar[3].valExpr}
+			yyVAL.statement = &UnlockTables{Comments: Comments(yyDollar[2].bytes2)}
 		}
 	case 185:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1098
+//line yacc.y:1255
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_PLUS, Right: yyDollar[3].valExpr}
+			yyVAL.statement = &UnlockTables{Comments: Comments(yyDollar[2].bytes2)}
 		}
 	case 186:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1102
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1261
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MINUS, Right: yyDollar[3].valExpr}
+			yyVAL.statement = &Begin{}
 		}
 	case 187:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1106
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1265
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MULT, Right: yyDollar[3].valExpr}
+			yyVAL.statement = &Begin{}
 		}
 	case 188:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1110
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1271
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_DIV, Right: yyDollar[3].valExpr}
+			yyVAL.statement = &Commit{}
 		}
 	case 189:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1114
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1277
 		{
-			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MOD, Right: yyDollar[3].valExpr}
+			yyVAL.statement = &Rollback{}
 		}
 	case 190:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1118
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1281
 		{
-			if num, ok := yyDollar[2].valExpr.(NumVal); ok {
-				switch yyDollar[1].byt {
-				case '-':
-					yyVAL.valExpr = append(NumVal("-"), num...)
-				case '+':
-					yyVAL.valExpr = num
-				default:
-					yyVAL.valExpr = &UnaryExpr{Operator: yyDollar[1].byt, Expr: yyDollar[2].valExpr}
-				}
-			} else {
-				yyVAL.valExpr = &UnaryExpr{Operator: yyDollar[1].byt, Expr: yyDollar[2].valExpr}
-			}
+			yyVAL.statement = &RollbackSavepoint{Name: yyDollar[3].bytes}
 		}
 	case 191:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1133
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1285
 		{
-			yyVAL.valExpr = yyDollar[1].funcExpr
+			yyVAL.statement = &RollbackSavepoint{Name: yyDollar[4].bytes}
 		}
 	case 192:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1137
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1291
 		{
-			yyVAL.valExpr = yyDollar[1].caseExpr
+			yyVAL.statement = &Savepoint{Name: yyDollar[2].bytes}
 		}
 	case 193:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1143
+//line yacc.y:1295
 		{
-			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes}
+			yyVAL.statement = &ReleaseSavepoint{Name: yyDollar[3].bytes}
 		}
 	case 194:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:1147
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1301
 		{
-			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes, Exprs: ValExprs{&ColName{Name: []byte("*")}}}
+			yyVAL.statement = &UseDB{DB: string(yyDollar[2].bytes)}
 		}
 	case 195:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:1151
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1308
 		{
-			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes, Exprs: yyDollar[3].valExprs}
+			yyVAL.statement = &KillConnection{ConnectionID: NumVal(yyDollar[2].bytes)}
 		}
 	case 196:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:1155
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1312
 		{
-			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes, Distinct: true, Exprs: yyDollar[4].valExprs}
+			yyVAL.statement = &KillConnection{ConnectionID: NumVal(yyDollar[3].bytes)}
 		}
 	case 197:
-		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:1159
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1316
 		{
-			yyVAL.funcExpr = &FuncExpr{Name: []byte("locate"), Exprs: ValExprs{yyDollar[3].valExpr, yyDollar[5].valExpr}}
+			yyVAL.statement = &KillQuery{ConnectionID: NumVal(yyDollar[3].bytes)}
 		}
 	case 198:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:1163
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1320
 		{
-			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes, Exprs: yyDollar[3].valExprs}
+			yyVAL.statement = &Reload{Name: yyDollar[2].bytes}
 		}
 	case 199:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1169
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:1324
 		{
-			yyVAL.bytes = IF_BYTES
+			if !bytes.Equal(yyDollar[2].bytes, TENANT) {
+				yylex.Error("expecting tenant")
+				return 1
+			}
+			yyVAL.statement = &CloneTenant{Tenant: yyDollar[3].valExpr, From: yyDollar[5].bytes, To: yyDollar[7].bytes}
 		}
 	case 200:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1173
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:1332
 		{
-			yyVAL.bytes = VALUES_BYTES
+			if !bytes.EqualFold(yyDollar[4].bytes, USER_BYTES) {
+				yylex.Error("expecting user")
+				return 1
+			}
+			yyVAL.statement = &CreateProxyUser{IfNotExists: yyDollar[5].boolean, Name: yyDollar[6].bytes, Options: yyDollar[7].proxyUserOptions}
 		}
 	case 201:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1179
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1340
 		{
-			yyVAL.byt = AST_UPLUS
+			if !bytes.EqualFold(yyDollar[4].bytes, USER_BYTES) {
+				yylex.Error("expecting user")
+				return 1
+			}
+			yyVAL.statement = &AlterProxyUser{Name: yyDollar[5].bytes, Options: yyDollar[6].proxyUserOptions}
 		}
 	case 202:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1183
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1348
 		{
-			yyVAL.byt = AST_UMINUS
+			if !bytes.EqualFold(yyDollar[4].bytes, USER_BYTES) {
+				yylex.Error("expecting user")
+				return 1
+			}
+			yyVAL.statement = &DropProxyUser{IfExists: yyDollar[5].boolean, Name: yyDollar[6].bytes}
 		}
 	case 203:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1187
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1356
 		{
-			yyVAL.byt = AST_TILDA
+			if !bytes.EqualFold(yyDollar[3].bytes, USERS_BYTES) {
+				yylex.Error("expecting users")
+				return 1
+			}
+			yyVAL.statement = &ShowProxyUsers{}
 		}
 	case 204:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:1193
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1364
 		{
-			yyVAL.caseExpr = &CaseExpr{Expr: yyDollar[2].valExpr, Whens: yyDollar[3].whens, Else: yyDollar[4].valExpr}
+			if !bytes.EqualFold(yyDollar[2].bytes, FAILOVER_BYTES) || !bytes.EqualFold(yyDollar[3].bytes, DRILL_BYTES) {
+				yylex.Error("expecting failover drill")
+				return 1
+			}
+			yyVAL.statement = &FailoverDrill{Action: AST_DRILL_START, Host: yyDollar[4].bytes}
 		}
 	case 205:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1198
-		{
-			yyVAL.valExpr = nil
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1372
+		{
+			if bytes.EqualFold(yyDollar[1].bytes, STOP_BYTES) && bytes.EqualFold(yyDollar[2].bytes, FAILOVER_BYTES) && bytes.EqualFold(yyDollar[3].bytes, DRILL_BYTES) {
+				yyVAL.statement = &FailoverDrill{Action: AST_DRILL_STOP}
+			} else if bytes.EqualFold(yyDollar[1].bytes, EXPORT_BYTES) && bytes.EqualFold(yyDollar[2].bytes, ROUTING_BYTES) {
+				switch strings.ToLower(string(yyDollar[3].bytes)) {
+				case AST_EXPORT_JSON, AST_EXPORT_MARKDOWN:
+					yyVAL.statement = &ExportRouting{Type: strings.ToLower(string(yyDollar[3].bytes))}
+				default:
+					yylex.Error("expecting json or markdown")
+					return 1
+				}
+			} else {
+				yylex.Error("syntax error")
+				return 1
+			}
 		}
 	case 206:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1202
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1389
 		{
-			yyVAL.valExpr = yyDollar[1].valExpr
+			if !bytes.EqualFold(yyDollar[2].bytes, FAILOVER_BYTES) || !bytes.EqualFold(yyDollar[3].bytes, DRILL_BYTES) {
+				yylex.Error("syntax error")
+				return 1
+			}
+			yyVAL.statement = &ShowFailoverDrill{}
 		}
 	case 207:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1208
-		{
-			yyVAL.whens = []*When{yyDollar[1].when}
-		}
-	case 208:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1212
-		{
-			yyVAL.whens = append(yyDollar[1].whens, yyDollar[2].when)
-		}
-	case 209:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:1218
-		{
-			yyVAL.when = &When{Cond: yyDollar[2].boolExpr, Val: yyDollar[4].valExpr}
+//line yacc.y:1397
+		{
+			if bytes.EqualFold(yyDollar[2].bytes, PREFLIGHT_BYTES) {
+				yyVAL.statement = &ShowPreflight{}
+			} else if bytes.EqualFold(yyDollar[2].bytes, AUDIT_BYTES) {
+				yyVAL.statement = &ShowAudit{}
+			} else if bytes.EqualFold(yyDollar[2].bytes, MIGRATION_BYTES) {
+				yyVAL.statement = &ShowMigration{}
+			} else {
+				// other show statements aren't supported.
+				yylex.Error("syntax error")
+				return 1
+			}
 		}
 	case 210:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1223
+//line yacc.y:1416
 		{
-			yyVAL.valExpr = nil
+			yyVAL.proxyUserOptions = &ProxyUserOptions{}
 		}
 	case 211:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1227
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1420
 		{
-			yyVAL.valExpr = yyDollar[2].valExpr
+			yyDollar[1].proxyUserOptions.Identified, yyDollar[1].proxyUserOptions.Password = true, StrVal(yyDollar[4].bytes)
+			yyVAL.proxyUserOptions = yyDollar[1].proxyUserOptions
 		}
 	case 212:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1233
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1425
 		{
-			yyVAL.colName = &ColName{Name: yyDollar[1].bytes}
+			if !bytes.EqualFold(yyDollar[2].bytes, SCHEMAS_BYTES) {
+				yylex.Error("expecting identified by, schemas or read")
+				return 1
+			}
+			yyDollar[1].proxyUserOptions.Schemas = yyDollar[3].bytes2
+			yyVAL.proxyUserOptions = yyDollar[1].proxyUserOptions
 		}
 	case 213:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1237
+//line yacc.y:1434
 		{
-			yyVAL.colName = &ColName{Qualifier: bytes.ToLower(yyDollar[1].bytes), Name: yyDollar[3].bytes}
+			if bytes.EqualFold(yyDollar[3].bytes, ONLY_BYTES) {
+				yyDollar[1].proxyUserOptions.ReadOnly = AST_READ_ONLY
+			} else if bytes.EqualFold(yyDollar[3].bytes, WRITE_BYTES) {
+				yyDollar[1].proxyUserOptions.ReadOnly = AST_READ_WRITE
+			} else {
+				yylex.Error("expecting read only or read write")
+				return 1
+			}
+			yyVAL.proxyUserOptions = yyDollar[1].proxyUserOptions
 		}
 	case 214:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1243
+		yyDollar = yyS[yypt-10 : yypt+1]
+//line yacc.y:1448
 		{
-			yyVAL.valExpr = StrVal(yyDollar[1].bytes)
+			yyVAL.ddlStmt = &CreateTable{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[5].tableName, CreateDefs: yyDollar[7].createDefs, TableOptions: yyDollar[9].optKeyVals, Partition: yyDollar[10].partitionOpts}
 		}
 	case 215:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1247
+		yyDollar = yyS[yypt-13 : yypt+1]
+//line yacc.y:1452
 		{
-			yyVAL.valExpr = NumVal(yyDollar[1].bytes)
+			yyVAL.ddlStmt = &CreateIndex{Comments: Comments(yyDollar[2].bytes2), IndexCategory: yyDollar[3].bytes, Name: yyDollar[5].bytes, IndexType: yyDollar[6].bytes, Table: yyDollar[8].tableName, IndexColumns: yyDollar[10].idxColNames, IndexOption: yyDollar[12].bytes, LockAlgorithm: yyDollar[13].optKeyVals}
 		}
 	case 216:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1251
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:1458
 		{
-			yyVAL.valExpr = ValArg(yyDollar[1].bytes)
+			yyVAL.ddlStmt = &AlterTable{Comments: Comments(yyDollar[2].bytes2), Ignore: yyDollar[3].str, Table: yyDollar[5].tableName, AlterSpecs: yyDollar[6].alterSpecs, Partition: yyDollar[7].partitionOpts}
 		}
 	case 217:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1255
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1464
 		{
-			yyVAL.valExpr = &NullVal{}
+			yyVAL.ddlStmt = &RenameTable{Comments: Comments(yyDollar[2].bytes2), OldName: yyDollar[4].tableName, NewName: yyDollar[6].tableName}
 		}
 	case 218:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1260
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1470
 		{
-			yyVAL.valExprs = nil
+			maintenance := &TableMaintenance{Comments: Comments(yyDollar[2].bytes2), Action: AST_ANALYZE, Tables: yyDollar[5].tableNames}
+			if !maintenance.setOptions(yyDollar[3].bytes, nil) {
+				yylex.Error("expecting no_write_to_binlog or local")
+				return 1
+			}
+			yyVAL.statement = maintenance
 		}
 	case 219:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1264
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1479
 		{
-			yyVAL.valExprs = yyDollar[3].valExprs
+			maintenance := &TableMaintenance{Comments: Comments(yyDollar[2].bytes2), Action: AST_OPTIMIZE, Tables: yyDollar[5].tableNames}
+			if !maintenance.setOptions(yyDollar[3].bytes, nil) {
+				yylex.Error("expecting no_write_to_binlog or local")
+				return 1
+			}
+			yyVAL.statement = maintenance
 		}
 	case 220:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1269
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1488
 		{
-			yyVAL.boolExpr = nil
+			maintenance := &TableMaintenance{Comments: Comments(yyDollar[2].bytes2), Action: AST_CHECK, Tables: yyDollar[4].tableNames}
+			if !maintenance.setOptions(nil, yyDollar[5].bytes2) {
+				yylex.Error("expecting for upgrade, quick, fast, medium, extended or changed")
+				return 1
+			}
+			yyVAL.statement = maintenance
 		}
 	case 221:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1273
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1497
 		{
-			yyVAL.boolExpr = yyDollar[2].boolExpr
+			maintenance := &TableMaintenance{Comments: Comments(yyDollar[2].bytes2), Action: AST_REPAIR, Tables: yyDollar[5].tableNames}
+			if !maintenance.setOptions(yyDollar[3].bytes, yyDollar[6].bytes2) {
+				yylex.Error("expecting no_write_to_binlog, local, quick, extended or use_frm")
+				return 1
+			}
+			yyVAL.statement = maintenance
 		}
 	case 222:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1278
+//line yacc.y:1507
 		{
-			yyVAL.orderBy = nil
+			yyVAL.bytes = nil
 		}
 	case 223:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1282
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1511
 		{
-			yyVAL.orderBy = yyDollar[3].orderBy
+			yyVAL.bytes = yyDollar[1].bytes
 		}
 	case 224:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1288
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:1516
 		{
-			yyVAL.orderBy = OrderBy{yyDollar[1].order}
+			yyVAL.bytes2 = nil
 		}
 	case 225:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1292
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1520
 		{
-			yyVAL.orderBy = append(yyDollar[1].orderBy, yyDollar[3].order)
+			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[2].bytes)
 		}
 	case 226:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1298
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1524
 		{
-			yyVAL.order = &Order{Expr: yyDollar[1].valExpr, Direction: yyDollar[2].str}
+			yyVAL.bytes2 = append(yyDollar[1].bytes2, append([]byte("for "), yyDollar[3].bytes...))
 		}
 	case 227:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1303
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1530
 		{
-			yyVAL.str = ""
+			yyVAL.ddlStmt = &TruncateTable{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[4].tableName}
 		}
 	case 228:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1307
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1534
 		{
-			yyVAL.str = AST_ASC
+			yyVAL.ddlStmt = &TruncateTable{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[3].tableName}
 		}
 	case 229:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1311
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1540
 		{
-			yyVAL.str = AST_DESC
+			yyVAL.ddlStmt = &DropTable{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName, RefOption: yyDollar[6].bytes}
 		}
 	case 230:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1316
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:1544
 		{
-			yyVAL.limit = nil
+			yyVAL.ddlStmt = &DropIndex{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[4].bytes, Table: yyDollar[6].tableName, LockAlgorithm: yyDollar[7].optKeyVals}
 		}
 	case 231:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1320
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1548
 		{
-			yyVAL.limit = &Limit{Rowcount: yyDollar[2].valExpr}
+			yyVAL.ddlStmt = &DropRoutine{Comments: Comments(yyDollar[2].bytes2), Kind: AST_PROCEDURE, IfExists: yyDollar[4].boolean, Name: yyDollar[5].tableName}
 		}
 	case 232:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:1324
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1552
 		{
-			yyVAL.limit = &Limit{Offset: yyDollar[2].valExpr, Rowcount: yyDollar[4].valExpr}
+			yyVAL.ddlStmt = &DropRoutine{Comments: Comments(yyDollar[2].bytes2), Kind: AST_FUNCTION, IfExists: yyDollar[4].boolean, Name: yyDollar[5].tableName}
 		}
 	case 233:
-		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:1328
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1556
 		{
-			yyVAL.limit = &Limit{Offset: yyDollar[4].valExpr, Rowcount: yyDollar[2].valExpr}
+			yyVAL.ddlStmt = &DropRoutine{Comments: Comments(yyDollar[2].bytes2), Kind: AST_TRIGGER, IfExists: yyDollar[4].boolean, Name: yyDollar[5].tableName}
 		}
 	case 234:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1333
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1562
 		{
-			yyVAL.str = ""
+			yyVAL.showStmt = &ShowCharset{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[5].expr}
 		}
 	case 235:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1337
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1566
 		{
-			yyVAL.str = AST_FOR_UPDATE
+			yyVAL.showStmt = &ShowCharset{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[4].expr}
 		}
 	case 236:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:1341
+//line yacc.y:1570
 		{
-			if !bytes.Equal(yyDollar[3].bytes, SHARE) {
-				yylex.Error("expecting share")
-				return 1
-			}
-			if !bytes.Equal(yyDollar[4].bytes, MODE) {
-				yylex.Error("expecting mode")
-				return 1
-			}
-			yyVAL.str = AST_SHARE_MODE
+			yyVAL.showStmt = &ShowCollation{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[4].expr}
 		}
 	case 237:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1354
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1574
 		{
-			yyVAL.columns = nil
+			yyVAL.showStmt = &ShowVariables{Comments: Comments(yyDollar[2].bytes2), Scope: string(yyDollar[3].bytes), LikeOrWhere: yyDollar[5].expr}
 		}
 	case 238:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1358
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1578
 		{
-			yyVAL.columns = yyDollar[2].columns
+			yyVAL.showStmt = &ShowStatus{Comments: Comments(yyDollar[2].bytes2), Scope: string(yyDollar[3].bytes), LikeOrWhere: yyDollar[5].expr}
 		}
 	case 239:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1364
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1582
 		{
-			yyVAL.columns = Columns{&NonStarExpr{Expr: yyDollar[1].colName}}
+			yyVAL.showStmt = &ShowDatabases{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[4].expr}
 		}
 	case 240:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1368
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1586
 		{
-			yyVAL.columns = append(yyVAL.columns, &NonStarExpr{Expr: yyDollar[3].colName})
+			yyVAL.showStmt = &ShowDatabases{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[4].expr}
 		}
 	case 241:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1373
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1590
 		{
-			yyVAL.updateExprs = nil
+			yyVAL.showStmt = &ShowTables{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[4].expr}
 		}
 	case 242:
-		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:1377
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1594
 		{
-			yyVAL.updateExprs = yyDollar[5].updateExprs
+			yyVAL.showStmt = &ShowTables{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, LikeOrWhere: yyDollar[6].expr}
 		}
 	case 243:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1383
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1598
 		{
-			yyVAL.updateExprs = UpdateExprs{yyDollar[1].updateExpr}
+			yyVAL.showStmt = &ShowFullTables{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[5].expr}
 		}
 	case 244:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1387
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:1602
 		{
-			yyVAL.updateExprs = append(yyDollar[1].updateExprs, yyDollar[3].updateExpr)
+			yyVAL.showStmt = &ShowFullTables{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[6].tableName, LikeOrWhere: yyDollar[7].expr}
 		}
 	case 245:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1393
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1606
 		{
-			yyVAL.updateExpr = &UpdateExpr{Name: yyDollar[1].colName, Expr: yyDollar[3].valExpr}
+			yyVAL.showStmt = &ShowTableStatus{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[5].expr}
 		}
 	case 246:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1398
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:1610
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.showStmt = &ShowTableStatus{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[6].tableName, LikeOrWhere: yyDollar[7].expr}
 		}
 	case 247:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1400
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1614
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.showStmt = &ShowColumns{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, LikeOrWhere: yyDollar[6].expr}
 		}
 	case 248:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1403
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1618
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.showStmt = &ShowColumns{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, LikeOrWhere: yyDollar[6].expr}
 		}
 	case 249:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1405
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:1622
 		{
-			yyVAL.empty = struct{}{}
+			yyVAL.showStmt = &ShowFullColumns{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[6].tableName, LikeOrWhere: yyDollar[7].expr}
 		}
 	case 250:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1408
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:1626
 		{
-			yyVAL.str = ""
+			yyVAL.showStmt = &ShowFullColumns{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[6].tableName, LikeOrWhere: yyDollar[7].expr}
 		}
 	case 251:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1410
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1630
 		{
-			yyVAL.str = AST_IGNORE
+			yyVAL.showStmt = &ShowProcedureStatus{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[5].expr}
 		}
 	case 252:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1413
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1634
 		{
-			yyVAL.bytes = nil
+			yyVAL.showStmt = &ShowFunctionStatus{Comments: Comments(yyDollar[2].bytes2), LikeOrWhere: yyDollar[5].expr}
 		}
 	case 253:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1415
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1638
 		{
-			yyVAL.bytes = []byte("unique")
+			yyVAL.showStmt = &ShowIndex{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, Where: yyDollar[6].boolExpr}
 		}
 	case 254:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1417
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1642
 		{
-			yyVAL.bytes = []byte("fulltext")
+			yyVAL.showStmt = &ShowIndex{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, Where: yyDollar[6].boolExpr}
 		}
 	case 255:
-		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1421
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1646
 		{
-			yyVAL.idxColNames = IndexColNames{yyDollar[1].idxColName}
+			yyVAL.showStmt = &ShowIndex{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, Where: yyDollar[6].boolExpr}
 		}
 	case 256:
-		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1425
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:1650
 		{
-			yyVAL.idxColNames = append(yyDollar[1].idxColNames, yyDollar[3].idxColName)
+			yyVAL.showStmt = &ShowTriggers{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[5].tableName, LikeOrWhere: yyDollar[6].expr}
 		}
 	case 257:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1431
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1654
 		{
-			yyVAL.idxColName = &IndexColName{ColumnName: yyDollar[1].colName, AscOrDesc: yyDollar[2].str}
+			yyVAL.showStmt = &ShowCreateDatabase{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName}
 		}
 	case 258:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:1435
+//line yacc.y:1658
+		{
+			yyVAL.showStmt = &ShowCreateTable{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName}
+		}
+	case 259:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1662
+		{
+			yyVAL.showStmt = &ShowCreateView{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName}
+		}
+	case 260:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1666
+		{
+			yyVAL.showStmt = &ShowCreateProcedure{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName}
+		}
+	case 261:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1670
+		{
+			yyVAL.showStmt = &ShowCreateFunction{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName}
+		}
+	case 262:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1674
+		{
+			yyVAL.showStmt = &ShowCreateTrigger{Comments: Comments(yyDollar[2].bytes2), Name: yyDollar[5].tableName}
+		}
+	case 263:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1678
+		{
+			yyVAL.showStmt = &ShowProcessList{Comments: Comments(yyDollar[2].bytes2)}
+		}
+	case 264:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1682
+		{
+			yyVAL.showStmt = &ShowFullProcessList{Comments: Comments(yyDollar[2].bytes2)}
+		}
+	case 265:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1686
+		{
+			yyVAL.showStmt = &ShowSlaveStatus{Comments: Comments(yyDollar[2].bytes2)}
+		}
+	case 266:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1690
+		{
+			yyVAL.showStmt = &ShowEngines{}
+		}
+	case 267:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1694
+		{
+			yyVAL.showStmt = &ShowEngines{}
+		}
+	case 268:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1698
+		{
+			yyVAL.showStmt = &ShowPlugins{}
+		}
+	case 269:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1702
+		{
+			yyVAL.showStmt = &ShowProfiles{}
+		}
+	case 270:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1706
+		{
+			yyVAL.showStmt = &ShowGrants{Comments: Comments(yyDollar[2].bytes2)}
+		}
+	case 271:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1710
+		{
+			if yyDollar[5].account.Host == nil && bytes.EqualFold(yyDollar[5].account.User, CURRENT_USER_BYTES) {
+				yyVAL.showStmt = &ShowGrants{Comments: Comments(yyDollar[2].bytes2), CurrentUser: true}
+			} else {
+				yyVAL.showStmt = &ShowGrants{Comments: Comments(yyDollar[2].bytes2), For: yyDollar[5].account}
+			}
+		}
+	case 272:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:1718
+		{
+			if !bytes.EqualFold(yyDollar[5].bytes, CURRENT_USER_BYTES) {
+				yylex.Error("expecting current_user")
+				return 1
+			}
+			yyVAL.showStmt = &ShowGrants{Comments: Comments(yyDollar[2].bytes2), CurrentUser: true}
+		}
+	case 273:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1726
+		{
+			yyVAL.showStmt = &ShowWarnings{Comments: Comments(yyDollar[2].bytes2), Limit: yyDollar[4].limit}
+		}
+	case 274:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1730
+		{
+			yyVAL.showStmt = &ShowErrors{Comments: Comments(yyDollar[2].bytes2), Limit: yyDollar[4].limit}
+		}
+	case 275:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:1734
+		{
+			if !bytes.EqualFold(yyDollar[2].bytes, SAASHARD_BYTES) || !bytes.EqualFold(yyDollar[3].bytes, LAST_BYTES) || !bytes.EqualFold(yyDollar[4].bytes, ROUTE_BYTES) {
+				yylex.Error("expecting saashard last route")
+				return 1
+			}
+			yyVAL.showStmt = &ShowLastRoute{}
+		}
+	case 276:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1744
+		{
+			yyVAL.showStmt = &ShowColumns{Comments: Comments(yyDollar[2].bytes2), From: yyDollar[3].tableName}
+		}
+	case 277:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:1749
+		{
+			SetAllowComments(yylex, true)
+		}
+	case 278:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1753
+		{
+			yyVAL.bytes2 = yyDollar[2].bytes2
+			SetAllowComments(yylex, false)
+		}
+	case 279:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:1759
+		{
+			yyVAL.bytes2 = nil
+		}
+	case 280:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1763
+		{
+			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[2].bytes)
+		}
+	case 281:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1769
+		{
+			yyVAL.str = AST_UNION
+		}
+	case 282:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1773
+		{
+			yyVAL.str = AST_UNION_ALL
+		}
+	case 283:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1777
+		{
+			yyVAL.str = AST_SET_MINUS
+		}
+	case 284:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1781
+		{
+			yyVAL.str = AST_EXCEPT
+		}
+	case 285:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1785
+		{
+			yyVAL.str = AST_INTERSECT
+		}
+	case 286:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:1790
+		{
+			yyVAL.str = ""
+		}
+	case 287:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1794
+		{
+			yyVAL.str = AST_DISTINCT
+		}
+	case 288:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1800
+		{
+			yyVAL.selectExprs = SelectExprs{yyDollar[1].selectExpr}
+		}
+	case 289:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1804
+		{
+			yyVAL.selectExprs = append(yyVAL.selectExprs, yyDollar[3].selectExpr)
+		}
+	case 290:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1810
+		{
+			yyVAL.selectExpr = &StarExpr{}
+		}
+	case 291:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1814
+		{
+			yyVAL.selectExpr = &StarExpr{TableName: bytes.ToLower(yyDollar[1].bytes)}
+		}
+	case 292:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1818
+		{
+			yyVAL.selectExpr = &NonStarExpr{Expr: yyDollar[1].expr, As: yyDollar[2].bytes}
+		}
+	case 293:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1824
+		{
+			yyVAL.expr = yyDollar[1].boolExpr
+		}
+	case 294:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1828
+		{
+			yyVAL.expr = yyDollar[1].valExpr
+		}
+	case 295:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:1833
+		{
+			yyVAL.bytes = nil
+		}
+	case 296:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1837
+		{
+			yyVAL.bytes = yyDollar[1].bytes
+		}
+	case 297:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1841
+		{
+			yyVAL.bytes = yyDollar[2].bytes
+		}
+	case 298:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1847
+		{
+			yyVAL.tableExprs = TableExprs{yyDollar[1].tableExpr}
+		}
+	case 299:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1851
+		{
+			yyVAL.tableExprs = append(yyVAL.tableExprs, yyDollar[3].tableExpr)
+		}
+	case 300:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1857
+		{
+			yyVAL.tableExpr = &AliasedTableExpr{Expr: yyDollar[1].smTableExpr, As: yyDollar[2].bytes, Hints: yyDollar[3].indexHints}
+		}
+	case 301:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1861
+		{
+			yyVAL.tableExpr = &ParenTableExpr{Expr: yyDollar[2].tableExpr}
+		}
+	case 302:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1865
+		{
+			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr, On: yyDollar[5].boolExpr}
+		}
+	case 303:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1871
+		{
+			yyVAL.str = AST_JOIN
+		}
+	case 304:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1875
+		{
+			yyVAL.str = AST_STRAIGHT_JOIN
+		}
+	case 305:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1879
+		{
+			yyVAL.str = AST_LEFT_JOIN
+		}
+	case 306:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1883
+		{
+			yyVAL.str = AST_LEFT_JOIN
+		}
+	case 307:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1887
+		{
+			yyVAL.str = AST_RIGHT_JOIN
+		}
+	case 308:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1891
+		{
+			yyVAL.str = AST_RIGHT_JOIN
+		}
+	case 309:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1895
+		{
+			yyVAL.str = AST_JOIN
+		}
+	case 310:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1899
+		{
+			yyVAL.str = AST_CROSS_JOIN
+		}
+	case 311:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1903
+		{
+			yyVAL.str = AST_NATURAL_JOIN
+		}
+	case 312:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1909
+		{
+			yyVAL.smTableExpr = yyDollar[1].tableName
+		}
+	case 313:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1913
+		{
+			yyVAL.smTableExpr = yyDollar[1].subquery
+		}
+	case 314:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1919
+		{
+			yyVAL.tableName = &TableName{Name: yyDollar[1].bytes}
+		}
+	case 315:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1923
+		{
+			yyVAL.tableName = &TableName{Qualifier: yyDollar[1].bytes, Name: yyDollar[3].bytes}
+		}
+	case 316:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1929
+		{
+			yyVAL.tableNames = TableNames{yyDollar[1].tableName}
+		}
+	case 317:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1933
+		{
+			yyVAL.tableNames = append(yyVAL.tableNames, yyDollar[3].tableName)
+		}
+	case 318:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:1938
+		{
+			yyVAL.indexHints = nil
+		}
+	case 319:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1942
+		{
+			yyVAL.indexHints = &IndexHints{Type: AST_USE, Indexes: yyDollar[4].bytes2}
+		}
+	case 320:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1946
+		{
+			yyVAL.indexHints = &IndexHints{Type: AST_IGNORE, Indexes: yyDollar[4].bytes2}
+		}
+	case 321:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:1950
+		{
+			yyVAL.indexHints = &IndexHints{Type: AST_FORCE, Indexes: yyDollar[4].bytes2}
+		}
+	case 322:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1956
+		{
+			yyVAL.bytes2 = [][]byte{yyDollar[1].bytes}
+		}
+	case 323:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1960
+		{
+			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[3].bytes)
+		}
+	case 324:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:1966
+		{
+			yyVAL.valExprs = ValExprs{yyDollar[1].valExpr}
+		}
+	case 325:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1970
+		{
+			yyVAL.valExprs = append(yyDollar[1].valExprs, yyDollar[3].valExpr)
+		}
+	case 326:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:1975
+		{
+			yyVAL.boolExpr = nil
+		}
+	case 327:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:1979
+		{
+			yyVAL.boolExpr = yyDollar[2].boolExpr
+		}
+	case 329:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1986
+		{
+			yyVAL.boolExpr = &AndExpr{Left: yyDollar[1].boolExpr, Right: yyDollar[3].boolExpr}
+		}
+	case 330:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1990
+		{
+			yyVAL.boolExpr = &OrExpr{Left: yyDollar[1].boolExpr, Right: yyDollar[3].boolExpr}
+		}
+	case 331:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1994
+		{
+			yyVAL.boolExpr = &XorExpr{Left: yyDollar[1].boolExpr, Right: yyDollar[3].boolExpr}
+		}
+	case 332:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:1998
+		{
+			yyVAL.boolExpr = &AndExpr{Left: yyDollar[1].boolExpr, Right: &BoolValExpr{Expr: yyDollar[3].valExpr}}
+		}
+	case 333:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2002
+		{
+			yyVAL.boolExpr = &AndExpr{Left: &BoolValExpr{Expr: yyDollar[1].valExpr}, Right: yyDollar[3].boolExpr}
+		}
+	case 334:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2006
+		{
+			yyVAL.boolExpr = &AndExpr{Left: &BoolValExpr{Expr: yyDollar[1].valExpr}, Right: &BoolValExpr{Expr: yyDollar[3].valExpr}}
+		}
+	case 335:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2010
+		{
+			yyVAL.boolExpr = &OrExpr{Left: yyDollar[1].boolExpr, Right: &BoolValExpr{Expr: yyDollar[3].valExpr}}
+		}
+	case 336:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2014
+		{
+			yyVAL.boolExpr = &OrExpr{Left: &BoolValExpr{Expr: yyDollar[1].valExpr}, Right: yyDollar[3].boolExpr}
+		}
+	case 337:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2018
+		{
+			yyVAL.boolExpr = &OrExpr{Left: &BoolValExpr{Expr: yyDollar[1].valExpr}, Right: &BoolValExpr{Expr: yyDollar[3].valExpr}}
+		}
+	case 338:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2022
+		{
+			yyVAL.boolExpr = &XorExpr{Left: yyDollar[1].boolExpr, Right: &BoolValExpr{Expr: yyDollar[3].valExpr}}
+		}
+	case 339:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2026
+		{
+			yyVAL.boolExpr = &XorExpr{Left: &BoolValExpr{Expr: yyDollar[1].valExpr}, Right: yyDollar[3].boolExpr}
+		}
+	case 340:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2030
+		{
+			yyVAL.boolExpr = &XorExpr{Left: &BoolValExpr{Expr: yyDollar[1].valExpr}, Right: &BoolValExpr{Expr: yyDollar[3].valExpr}}
+		}
+	case 341:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2034
+		{
+			yyVAL.boolExpr = &NotExpr{Expr: &BoolValExpr{Expr: yyDollar[2].valExpr}}
+		}
+	case 342:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2038
+		{
+			yyVAL.boolExpr = &NotExpr{Expr: yyDollar[2].boolExpr}
+		}
+	case 343:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2042
+		{
+			yyVAL.boolExpr = &ParenBoolExpr{Expr: yyDollar[2].boolExpr}
+		}
+	case 344:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2048
+		{
+			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: yyDollar[2].str, Right: yyDollar[3].valExpr}
+		}
+	case 345:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2052
+		{
+			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_IN, Right: yyDollar[3].tuple}
+		}
+	case 346:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2056
+		{
+			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_IN, Right: yyDollar[4].tuple}
+		}
+	case 347:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2060
+		{
+			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_LIKE, Right: yyDollar[3].valExpr}
+		}
+	case 348:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2064
+		{
+			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_LIKE, Right: yyDollar[4].valExpr}
+		}
+	case 349:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2068
+		{
+			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_REGEXP, Right: yyDollar[3].valExpr}
+		}
+	case 350:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2072
+		{
+			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: AST_NOT_REGEXP, Right: yyDollar[4].valExpr}
+		}
+	case 351:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:2076
+		{
+			yyVAL.boolExpr = &RangeCond{Left: yyDollar[1].valExpr, Operator: AST_BETWEEN, From: yyDollar[3].valExpr, To: yyDollar[5].valExpr}
+		}
+	case 352:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:2080
+		{
+			yyVAL.boolExpr = &RangeCond{Left: yyDollar[1].valExpr, Operator: AST_NOT_BETWEEN, From: yyDollar[4].valExpr, To: yyDollar[6].valExpr}
+		}
+	case 353:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2084
+		{
+			yyVAL.boolExpr = &NullCheck{Operator: AST_IS_NULL, Expr: yyDollar[1].valExpr}
+		}
+	case 354:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2088
+		{
+			yyVAL.boolExpr = &NullCheck{Operator: AST_IS_NOT_NULL, Expr: yyDollar[1].valExpr}
+		}
+	case 355:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2092
+		{
+			yyVAL.boolExpr = &ExistsExpr{Subquery: yyDollar[2].subquery}
+		}
+	case 356:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2096
+		{
+			yyVAL.boolExpr = yyDollar[1].matchExpr
+		}
+	case 357:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2100
+		{
+			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].matchExpr, Operator: yyDollar[2].str, Right: yyDollar[3].valExpr}
+		}
+	case 358:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2106
+		{
+			yyVAL.str = AST_EQ
+		}
+	case 359:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2110
+		{
+			yyVAL.str = AST_LT
+		}
+	case 360:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2114
+		{
+			yyVAL.str = AST_GT
+		}
+	case 361:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2118
+		{
+			yyVAL.str = AST_LE
+		}
+	case 362:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2122
+		{
+			yyVAL.str = AST_GE
+		}
+	case 363:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2126
+		{
+			yyVAL.str = AST_NE
+		}
+	case 364:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2130
+		{
+			yyVAL.str = AST_NSE
+		}
+	case 365:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2136
+		{
+			yyVAL.insRows = yyDollar[2].values
+		}
+	case 366:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2140
+		{
+			yyVAL.insRows = yyDollar[1].selStmt
+		}
+	case 367:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2146
+		{
+			yyVAL.values = Values{yyDollar[1].tuple}
+		}
+	case 368:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2150
+		{
+			yyVAL.values = append(yyDollar[1].values, yyDollar[3].tuple)
+		}
+	case 369:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2156
+		{
+			yyVAL.tuple = ValTuple(yyDollar[2].valExprs)
+		}
+	case 370:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2160
+		{
+			yyVAL.tuple = yyDollar[1].subquery
+		}
+	case 371:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2166
+		{
+			yyVAL.subquery = &Subquery{yyDollar[2].selStmt}
+		}
+	case 372:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2172
+		{
+			yyVAL.valExprs = ValExprs{yyDollar[1].valExpr}
+		}
+	case 373:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2176
+		{
+			yyVAL.valExprs = append(yyDollar[1].valExprs, yyDollar[3].valExpr)
+		}
+	case 374:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2182
+		{
+			yyVAL.valExpr = yyDollar[1].valExpr
+		}
+	case 375:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2186
+		{
+			if yyDollar[1].colName.Qualifier == nil && IsUserVariableName(yyDollar[1].colName.Name) {
+				yyVAL.valExpr = &UserVariable{Name: yyDollar[1].colName.Name[1:]}
+			} else if variable, ok := NewSystemVariable(yyDollar[1].colName); ok {
+				yyVAL.valExpr = variable
+			} else if bytes.HasPrefix(yyDollar[1].colName.Qualifier, AT_BYTES) {
+				yylex.Error("expecting @@global, @@session or @@local")
+				return 1
+			} else {
+				yyVAL.valExpr = yyDollar[1].colName
+			}
+		}
+	case 376:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2199
+		{
+			yyVAL.valExpr = &JSONExtractExpr{Column: yyDollar[1].colName, Operator: AST_JSON_EXTRACT, Path: StrVal(yyDollar[3].bytes)}
+		}
+	case 377:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2203
+		{
+			yyVAL.valExpr = &JSONExtractExpr{Column: yyDollar[1].colName, Operator: AST_JSON_UNQUOTE_EXTRACT, Path: StrVal(yyDollar[3].bytes)}
+		}
+	case 378:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2207
+		{
+			if !IsUserVariableName(yyDollar[1].bytes) {
+				yylex.Error("expecting @name before :=")
+				return 1
+			}
+			yyVAL.valExpr = &Assignment{Name: yyDollar[1].bytes[1:], Expr: yyDollar[3].valExpr}
+		}
+	case 379:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2215
+		{
+			yyVAL.valExpr = yyDollar[1].tuple
+		}
+	case 380:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2219
+		{
+			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITAND, Right: yyDollar[3].valExpr}
+		}
+	case 381:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2223
+		{
+			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITOR, Right: yyDollar[3].valExpr}
+		}
+	case 382:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2227
+		{
+			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_BITXOR, Right: yyDollar[3].valExpr}
+		}
+	case 383:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2231
+		{
+			yyVAL.valExpr = &FuncExpr{Name: []byte("concat"), Exprs: ValExprs{yyDollar[1].valExpr, yyDollar[3].valExpr}}
+		}
+	case 384:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2235
+		{
+			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_PLUS, Right: yyDollar[3].valExpr}
+		}
+	case 385:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2239
+		{
+			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MINUS, Right: yyDollar[3].valExpr}
+		}
+	case 386:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2243
+		{
+			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MULT, Right: yyDollar[3].valExpr}
+		}
+	case 387:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2247
+		{
+			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_DIV, Right: yyDollar[3].valExpr}
+		}
+	case 388:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2251
+		{
+			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MOD, Right: yyDollar[3].valExpr}
+		}
+	case 389:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2255
+		{
+			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_INT_DIV, Right: yyDollar[3].valExpr}
+		}
+	case 390:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2259
+		{
+			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: AST_MOD, Right: yyDollar[3].valExpr}
+		}
+	case 391:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2263
+		{
+			yyVAL.valExpr = &UnaryBinaryExpr{Expr: yyDollar[2].valExpr}
+		}
+	case 392:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2267
+		{
+			yyVAL.valExpr = &CollateExpr{Expr: yyDollar[1].valExpr, Collation: yyDollar[3].bytes}
+		}
+	case 393:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2271
+		{
+			if num, ok := yyDollar[2].valExpr.(NumVal); ok {
+				switch yyDollar[1].byt {
+				case '-':
+					yyVAL.valExpr = append(NumVal("-"), num...)
+				case '+':
+					yyVAL.valExpr = num
+				default:
+					yyVAL.valExpr = &UnaryExpr{Operator: yyDollar[1].byt, Expr: yyDollar[2].valExpr}
+				}
+			} else {
+				yyVAL.valExpr = &UnaryExpr{Operator: yyDollar[1].byt, Expr: yyDollar[2].valExpr}
+			}
+		}
+	case 394:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2286
+		{
+			yyVAL.valExpr = yyDollar[1].funcExpr
+		}
+	case 395:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:2290
+		{
+			yyVAL.valExpr = &WindowExpr{Func: yyDollar[1].funcExpr, PartitionBy: yyDollar[4].valExprs, OrderBy: yyDollar[5].orderBy}
+		}
+	case 396:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2294
+		{
+			yyVAL.valExpr = yyDollar[1].caseExpr
+		}
+	case 397:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2298
+		{
+			unit := strings.ToLower(string(yyDollar[3].bytes))
+			if !INTERVAL_UNITS[unit] {
+				yylex.Error("expecting unit of interval")
+				return 1
+			}
+			yyVAL.valExpr = &IntervalExpr{Expr: yyDollar[2].valExpr, Unit: unit}
+		}
+	case 398:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2307
+		{
+			yyVAL.valExpr = &IntervalExpr{Expr: yyDollar[2].valExpr, Unit: "year"}
+		}
+	case 399:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:2311
+		{
+			if !bytes.EqualFold(yyDollar[1].bytes, CAST_BYTES) {
+				yylex.Error("expecting cast")
+				return 1
+			}
+			yyVAL.valExpr = &CastExpr{Operator: AST_CAST, Expr: yyDollar[3].valExpr, To: yyDollar[5].str}
+		}
+	case 400:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:2319
+		{
+			yyVAL.valExpr = &CastExpr{Operator: AST_CONVERT, Expr: yyDollar[3].valExpr, To: yyDollar[5].str}
+		}
+	case 401:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:2323
+		{
+			yyVAL.valExpr = &CastExpr{Operator: AST_CONVERT_USING, Expr: yyDollar[3].valExpr, To: string(yyDollar[5].bytes)}
+		}
+	case 402:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2329
+		{
+			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes}
+		}
+	case 403:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2333
+		{
+			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes, Exprs: ValExprs{&ColName{Name: []byte("*")}}}
+		}
+	case 404:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2337
+		{
+			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes, Exprs: yyDollar[3].valExprs}
+		}
+	case 405:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:2341
+		{
+			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes, Distinct: true, Exprs: yyDollar[4].valExprs}
+		}
+	case 406:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line yacc.y:2345
+		{
+			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes, Exprs: yyDollar[3].valExprs, OrderBy: yyDollar[6].orderBy, Separator: yyDollar[7].bytes}
+		}
+	case 407:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:2349
+		{
+			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes, Exprs: yyDollar[3].valExprs, Separator: append([]byte{}, yyDollar[5].bytes...)}
+		}
+	case 408:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line yacc.y:2353
+		{
+			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes, Distinct: true, Exprs: yyDollar[4].valExprs, OrderBy: yyDollar[7].orderBy, Separator: yyDollar[8].bytes}
+		}
+	case 409:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:2357
+		{
+			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes, Distinct: true, Exprs: yyDollar[4].valExprs, Separator: append([]byte{}, yyDollar[6].bytes...)}
+		}
+	case 410:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:2361
+		{
+			yyVAL.funcExpr = &FuncExpr{Name: []byte("locate"), Exprs: ValExprs{yyDollar[3].valExpr, yyDollar[5].valExpr}}
+		}
+	case 411:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2365
+		{
+			yyVAL.funcExpr = &FuncExpr{Name: yyDollar[1].bytes, Exprs: yyDollar[3].valExprs}
+		}
+	case 412:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2371
+		{
+			yyVAL.str = "binary" + yyDollar[2].str
+		}
+	case 413:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2375
+		{
+			yyVAL.str = "char" + yyDollar[2].str
+		}
+	case 414:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:2379
+		{
+			yyVAL.str = "char" + yyDollar[2].str + " character set " + string(yyDollar[5].bytes)
+		}
+	case 415:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2383
+		{
+			yyVAL.str = "nchar" + yyDollar[2].str
+		}
+	case 416:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2387
+		{
+			yyVAL.str = "date"
+		}
+	case 417:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2391
+		{
+			yyVAL.str = "datetime" + yyDollar[2].str
+		}
+	case 418:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2395
+		{
+			yyVAL.str = "time" + yyDollar[2].str
+		}
+	case 419:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2399
+		{
+			yyVAL.str = "year"
+		}
+	case 420:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2403
+		{
+			yyVAL.str = "decimal" + yyDollar[2].str
+		}
+	case 421:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:2407
+		{
+			yyVAL.str = "decimal(" + string(yyDollar[3].bytes) + "," + string(yyDollar[5].bytes) + ")"
+		}
+	case 422:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2411
+		{
+			yyVAL.str = "double"
+		}
+	case 423:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2415
+		{
+			yyVAL.str = "float" + yyDollar[2].str
+		}
+	case 424:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2419
+		{
+			yyVAL.str = "real"
+		}
+	case 425:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2423
+		{
+			yyVAL.str = "unsigned"
+		}
+	case 426:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2427
+		{
+			yyVAL.str = "unsigned integer"
+		}
+	case 427:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2431
+		{
+			switch {
+			case bytes.EqualFold(yyDollar[1].bytes, SIGNED_BYTES):
+				yyVAL.str = "signed"
+			case strings.EqualFold(string(yyDollar[1].bytes), "json"):
+				yyVAL.str = "json"
+			default:
+				yylex.Error("expecting type of cast")
+				return 1
+			}
+		}
+	case 428:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2443
+		{
+			if !bytes.EqualFold(yyDollar[1].bytes, SIGNED_BYTES) {
+				yylex.Error("expecting signed")
+				return 1
+			}
+			yyVAL.str = "signed integer"
+		}
+	case 429:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2452
+		{
+			yyVAL.str = ""
+		}
+	case 430:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2456
+		{
+			yyVAL.str = "(" + string(yyDollar[2].bytes) + ")"
+		}
+	case 431:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2461
+		{
+			yyVAL.valExprs = nil
+		}
+	case 432:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2465
+		{
+			yyVAL.valExprs = yyDollar[3].valExprs
+		}
+	case 433:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2470
+		{
+			yyVAL.bytes = nil
+		}
+	case 434:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2474
+		{
+			yyVAL.bytes = append([]byte{}, yyDollar[2].bytes...)
+		}
+	case 435:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line yacc.y:2480
+		{
+			if !bytes.EqualFold(yyDollar[5].bytes, AGAINST_BYTES) {
+				yylex.Error("expecting against")
+				return 1
+			}
+			yyVAL.matchExpr = &MatchExpr{Columns: yyDollar[3].columns, Expr: yyDollar[7].valExpr, Modifier: yyDollar[8].str}
+		}
+	case 436:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2489
+		{
+			yyVAL.str = ""
+		}
+	case 437:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2493
+		{
+			if !bytes.EqualFold(yyDollar[3].bytes, LANGUAGE_BYTES) || !bytes.EqualFold(yyDollar[4].bytes, MODE) {
+				yylex.Error("expecting language mode")
+				return 1
+			}
+			yyVAL.str = AST_MATCH_NATURAL_LANGUAGE
+		}
+	case 438:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:2501
+		{
+			if !bytes.EqualFold(yyDollar[3].bytes, LANGUAGE_BYTES) || !bytes.EqualFold(yyDollar[4].bytes, MODE) || !bytes.EqualFold(yyDollar[7].bytes, EXPANSION_BYTES) {
+				yylex.Error("expecting language mode with query expansion")
+				return 1
+			}
+			yyVAL.str = AST_MATCH_NATURAL_LANGUAGE_EXPANSION
+		}
+	case 439:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2509
+		{
+			if !bytes.EqualFold(yyDollar[3].bytes, MODE) {
+				yylex.Error("expecting mode")
+				return 1
+			}
+			yyVAL.str = AST_MATCH_BOOLEAN
+		}
+	case 440:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2517
+		{
+			if !bytes.EqualFold(yyDollar[3].bytes, EXPANSION_BYTES) {
+				yylex.Error("expecting expansion")
+				return 1
+			}
+			yyVAL.str = AST_MATCH_EXPANSION
+		}
+	case 441:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2527
+		{
+			yyVAL.bytes = IF_BYTES
+		}
+	case 442:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2531
+		{
+			yyVAL.bytes = VALUES_BYTES
+		}
+	case 443:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2535
+		{
+			yyVAL.bytes = MOD_BYTES
+		}
+	case 444:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2541
+		{
+			yyVAL.byt = AST_UPLUS
+		}
+	case 445:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2545
+		{
+			yyVAL.byt = AST_UMINUS
+		}
+	case 446:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2549
+		{
+			yyVAL.byt = AST_TILDA
+		}
+	case 447:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:2555
+		{
+			yyVAL.caseExpr = &CaseExpr{Expr: yyDollar[2].valExpr, Whens: yyDollar[3].whens, Else: yyDollar[4].valExpr}
+		}
+	case 448:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2560
+		{
+			yyVAL.valExpr = nil
+		}
+	case 449:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2564
+		{
+			yyVAL.valExpr = yyDollar[1].valExpr
+		}
+	case 450:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2570
+		{
+			yyVAL.whens = []*When{yyDollar[1].when}
+		}
+	case 451:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2574
+		{
+			yyVAL.whens = append(yyDollar[1].whens, yyDollar[2].when)
+		}
+	case 452:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2580
+		{
+			yyVAL.when = &When{Cond: yyDollar[2].boolExpr, Val: yyDollar[4].valExpr}
+		}
+	case 453:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2585
+		{
+			yyVAL.valExpr = nil
+		}
+	case 454:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2589
+		{
+			yyVAL.valExpr = yyDollar[2].valExpr
+		}
+	case 455:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2595
+		{
+			yyVAL.colName = &ColName{Name: yyDollar[1].bytes}
+		}
+	case 456:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2599
+		{
+			yyVAL.colName = &ColName{Qualifier: bytes.ToLower(yyDollar[1].bytes), Name: yyDollar[3].bytes}
+		}
+	case 457:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2605
+		{
+			yyVAL.valExpr = StrVal(yyDollar[1].bytes)
+		}
+	case 458:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2609
+		{
+			yyVAL.valExpr = NumVal(yyDollar[1].bytes)
+		}
+	case 459:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2613
+		{
+			yyVAL.valExpr = HexVal(yyDollar[1].bytes)
+		}
+	case 460:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2617
+		{
+			yyVAL.valExpr = BitVal(yyDollar[1].bytes)
+		}
+	case 461:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2621
+		{
+			yyVAL.valExpr = &IntroducerExpr{National: true, Charset: []byte(AST_NATIONAL_CHARSET), Expr: StrVal(yyDollar[1].bytes)}
+		}
+	case 462:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2625
+		{
+			yyVAL.valExpr = &IntroducerExpr{Charset: yyDollar[1].bytes, Expr: StrVal(yyDollar[2].bytes)}
+		}
+	case 463:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2629
+		{
+			yyVAL.valExpr = &IntroducerExpr{Charset: yyDollar[1].bytes, Expr: HexVal(yyDollar[2].bytes)}
+		}
+	case 464:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2633
+		{
+			yyVAL.valExpr = &IntroducerExpr{Charset: yyDollar[1].bytes, Expr: BitVal(yyDollar[2].bytes)}
+		}
+	case 465:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2637
+		{
+			yyVAL.valExpr = ValArg(yyDollar[1].bytes)
+		}
+	case 466:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2641
+		{
+			yyVAL.valExpr = &NullVal{}
+		}
+	case 467:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2646
+		{
+			yyVAL.valExprs = nil
+		}
+	case 468:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2650
+		{
+			yyVAL.valExprs = yyDollar[3].valExprs
+		}
+	case 469:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2655
+		{
+			yyVAL.boolExpr = nil
+		}
+	case 470:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2659
+		{
+			yyVAL.boolExpr = yyDollar[2].boolExpr
+		}
+	case 471:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2664
+		{
+			yyVAL.orderBy = nil
+		}
+	case 472:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2668
+		{
+			yyVAL.orderBy = yyDollar[3].orderBy
+		}
+	case 473:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2674
+		{
+			yyVAL.orderBy = OrderBy{yyDollar[1].order}
+		}
+	case 474:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2678
+		{
+			yyVAL.orderBy = append(yyDollar[1].orderBy, yyDollar[3].order)
+		}
+	case 475:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2684
+		{
+			yyVAL.order = &Order{Expr: yyDollar[1].valExpr, Direction: yyDollar[2].str}
+		}
+	case 476:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2688
+		{
+			yyVAL.order = &Order{Expr: yyDollar[1].matchExpr, Direction: yyDollar[2].str}
+		}
+	case 477:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2693
+		{
+			yyVAL.str = ""
+		}
+	case 478:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2697
+		{
+			yyVAL.str = AST_ASC
+		}
+	case 479:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2701
+		{
+			yyVAL.str = AST_DESC
+		}
+	case 480:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2706
+		{
+			yyVAL.limit = nil
+		}
+	case 481:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2710
+		{
+			yyVAL.limit = &Limit{Rowcount: yyDollar[2].valExpr}
+		}
+	case 482:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2714
+		{
+			yyVAL.limit = &Limit{Offset: yyDollar[2].valExpr, Rowcount: yyDollar[4].valExpr}
+		}
+	case 483:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2718
+		{
+			yyVAL.limit = &Limit{Offset: yyDollar[4].valExpr, Rowcount: yyDollar[2].valExpr}
+		}
+	case 484:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2723
+		{
+			yyVAL.str = ""
+		}
+	case 485:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2727
+		{
+			yyVAL.str = AST_FOR_UPDATE
+		}
+	case 486:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2731
+		{
+			if !bytes.Equal(yyDollar[3].bytes, SHARE) {
+				yylex.Error("expecting share")
+				return 1
+			}
+			if !bytes.Equal(yyDollar[4].bytes, MODE) {
+				yylex.Error("expecting mode")
+				return 1
+			}
+			yyVAL.str = AST_SHARE_MODE
+		}
+	case 487:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2744
+		{
+			yyVAL.columns = nil
+		}
+	case 488:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2748
+		{
+			yyVAL.columns = yyDollar[2].columns
+		}
+	case 489:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2754
+		{
+			yyVAL.columns = Columns{&NonStarExpr{Expr: yyDollar[1].colName}}
+		}
+	case 490:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2758
+		{
+			yyVAL.columns = append(yyVAL.columns, &NonStarExpr{Expr: yyDollar[3].colName})
+		}
+	case 491:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2763
+		{
+			yyVAL.updateExprs = nil
+		}
+	case 492:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:2767
+		{
+			yyVAL.updateExprs = yyDollar[5].updateExprs
+		}
+	case 493:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2773
+		{
+			yyVAL.setExprs = SetExprs{yyDollar[1].setExpr}
+		}
+	case 494:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2777
+		{
+			yyVAL.setExprs = append(yyDollar[1].setExprs, yyDollar[3].setExpr)
+		}
+	case 495:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2783
+		{
+			yyVAL.setExpr = NewSetExpr(yyDollar[1].bytes, yyDollar[3].valExpr)
+		}
+	case 496:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:2787
+		{
+			expr, ok := NewScopedSetExpr(yyDollar[1].bytes, yyDollar[3].bytes, yyDollar[5].valExpr)
+			if !ok {
+				yylex.Error("expecting @@global, @@session, @@local or @@persist")
+				return 1
+			}
+			yyVAL.setExpr = expr
+		}
+	case 497:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2796
+		{
+			if !bytes.Equal(yyDollar[1].bytes, AT_BYTES) {
+				yylex.Error("expecting @")
+				return 1
+			}
+			yyVAL.setExpr = &SetExpr{User: true, Name: yyDollar[2].bytes, Expr: yyDollar[4].valExpr}
+		}
+	case 498:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:2804
+		{
+			if bytes.Equal(yyDollar[1].bytes, AT_BYTES) {
+				yyVAL.setExpr = &SetExpr{User: true, Name: yyDollar[2].bytes, Expr: yyDollar[4].valExpr}
+			} else if bytes.EqualFold(yyDollar[1].bytes, LOCAL_BYTES) {
+				yyVAL.setExpr = &SetExpr{Scope: AST_SCOPE_LOCAL, Name: yyDollar[2].bytes, Expr: yyDollar[4].valExpr}
+			} else {
+				yylex.Error("expecting @ or local")
+				return 1
+			}
+		}
+	case 500:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2818
+		{
+			yyVAL.valExpr = SetKeyword("default")
+		}
+	case 501:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2822
+		{
+			yyVAL.valExpr = SetKeyword("on")
+		}
+	case 502:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2826
+		{
+			yyVAL.valExpr = SetKeyword(yyDollar[1].bytes)
+		}
+	case 503:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2832
+		{
+			yyVAL.updateExprs = UpdateExprs{yyDollar[1].updateExpr}
+		}
+	case 504:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2836
+		{
+			yyVAL.updateExprs = append(yyDollar[1].updateExprs, yyDollar[3].updateExpr)
+		}
+	case 505:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2842
+		{
+			yyVAL.updateExpr = &UpdateExpr{Name: yyDollar[1].colName, Expr: yyDollar[3].valExpr}
+		}
+	case 506:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2847
+		{
+			yyVAL.boolean = false
+		}
+	case 507:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2849
+		{
+			yyVAL.boolean = true
+		}
+	case 508:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2852
+		{
+			yyVAL.boolean = false
+		}
+	case 509:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2854
+		{
+			yyVAL.boolean = true
+		}
+	case 510:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2857
+		{
+			yyVAL.str = ""
+		}
+	case 511:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2859
+		{
+			yyVAL.str = AST_IGNORE
+		}
+	case 512:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2862
+		{
+			yyVAL.bytes = nil
+		}
+	case 513:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2864
+		{
+			yyVAL.bytes = []byte("unique")
+		}
+	case 514:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2866
+		{
+			yyVAL.bytes = []byte("fulltext")
+		}
+	case 515:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2870
+		{
+			yyVAL.idxColNames = IndexColNames{yyDollar[1].idxColName}
+		}
+	case 516:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2874
+		{
+			yyVAL.idxColNames = append(yyDollar[1].idxColNames, yyDollar[3].idxColName)
+		}
+	case 517:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2880
+		{
+			yyVAL.idxColName = &IndexColName{ColumnName: yyDollar[1].colName, AscOrDesc: yyDollar[2].str}
+		}
+	case 518:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:2884
+		{
+			yyVAL.idxColName = &IndexColName{ColumnName: yyDollar[1].colName, Length: NumVal(yyDollar[3].bytes), AscOrDesc: yyDollar[5].str}
+		}
+	case 519:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2889
+		{
+			yyVAL.bytes = nil
+		}
+	case 520:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2891
+		{
+			yyVAL.bytes = yyDollar[1].bytes
+		}
+	case 521:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2895
+		{
+			yyVAL.bytes = []byte("using btree")
+		}
+	case 522:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2897
+		{
+			yyVAL.bytes = []byte("using hash")
+		}
+	case 523:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2900
+		{
+			yyVAL.bytes = nil
+		}
+	case 524:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2902
+		{
+			yyVAL.bytes = yyDollar[1].bytes
+		}
+	case 525:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:2905
+		{
+			yyVAL.optKeyVals = nil
+		}
+	case 526:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2907
+		{
+			yyVAL.optKeyVals = yyDollar[1].optKeyVals
+		}
+	case 527:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2911
+		{
+			yyVAL.optKeyVals = OptionKeyValues{yyDollar[1].optKeyVal}
+		}
+	case 528:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:2915
+		{
+			yyVAL.optKeyVals = append(yyDollar[1].optKeyVals, yyDollar[2].optKeyVal)
+		}
+	case 529:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2921
+		{
+			yyVAL.optKeyVal = &OptionKeyValue{Key: "algorithm", Value: "default"}
+		}
+	case 530:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2925
+		{
+			yyVAL.optKeyVal = &OptionKeyValue{Key: "algorithm", Value: string(yyDollar[3].bytes)}
+		}
+	case 531:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2929
+		{
+			yyVAL.optKeyVal = &OptionKeyValue{Key: "lock", Value: "default"}
+		}
+	case 532:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:2933
+		{
+			yyVAL.optKeyVal = &OptionKeyValue{Key: "lock", Value: string(yyDollar[3].bytes)}
+		}
+	case 533:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2939
+		{
+			yyVAL.bytes = bytes.ToLower(yyDollar[1].bytes)
+		}
+	case 534:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2943
+		{
+			yyVAL.bytes = []byte("database")
+		}
+	case 536:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2951
+		{
+			yyVAL.bytes = []byte("algorithm")
+		}
+	case 537:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2955
+		{
+			yyVAL.bytes = []byte("reload")
+		}
+	case 538:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2959
+		{
+			yyVAL.bytes = []byte("clone")
+		}
+	case 539:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2963
+		{
+			yyVAL.bytes = []byte("prepare")
+		}
+	case 540:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2967
+		{
+			yyVAL.bytes = []byte("execute")
+		}
+	case 541:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2971
+		{
+			yyVAL.bytes = []byte("deallocate")
+		}
+	case 542:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2975
+		{
+			yyVAL.bytes = []byte("option")
+		}
+	case 543:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2979
+		{
+			yyVAL.bytes = []byte("truncate")
+		}
+	case 544:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2983
+		{
+			yyVAL.bytes = []byte("repair")
+		}
+	case 545:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2987
+		{
+			yyVAL.bytes = []byte("grants")
+		}
+	case 546:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2991
 		{
-			yyVAL.idxColName = &IndexColName{ColumnName: yyDollar[1].colName, Length: NumVal(yyDollar[3].bytes), AscOrDesc: yyDollar[5].str}
+			yyVAL.bytes = []byte("warnings")
 		}
-	case 259:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1440
+	case 547:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:2995
 		{
-			yyVAL.bytes = nil
+			yyVAL.bytes = []byte("errors")
 		}
-	case 260:
+	case 548:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1442
+//line yacc.y:2999
 		{
-			yyVAL.bytes = yyDollar[1].bytes
+			yyVAL.bytes = []byte("proxy")
 		}
-	case 261:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1446
+	case 549:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:3003
 		{
-			yyVAL.bytes = []byte("using btree")
+			yyVAL.bytes = []byte("savepoint")
 		}
-	case 262:
-		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1448
+	case 550:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:3007
 		{
-			yyVAL.bytes = []byte("using hash")
+			yyVAL.bytes = []byte("begin")
 		}
-	case 263:
-		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1451
+	case 551:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:3011
 		{
-			yyVAL.bytes = nil
+			yyVAL.bytes = []byte("commit")
 		}
-	case 264:
+	case 552:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1453
+//line yacc.y:3015
 		{
-			yyVAL.bytes = yyDollar[1].bytes
+			yyVAL.bytes = []byte("rollback")
 		}
-	case 265:
+	case 553:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1457
+//line yacc.y:3019
 		{
-			yyVAL.bytes = bytes.ToLower(yyDollar[1].bytes)
+			yyVAL.bytes = []byte("identified")
 		}
-	case 266:
+	case 554:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1461
+//line yacc.y:3023
 		{
-			yyVAL.bytes = []byte("database")
+			yyVAL.bytes = []byte("separator")
 		}
-	case 267:
+	case 555:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1472
+//line yacc.y:3034
 		{
 			yyVAL.bytes = []byte("armscii8")
 		}
-	case 268:
+	case 556:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1474
+//line yacc.y:3036
 		{
 			yyVAL.bytes = []byte("ascii")
 		}
-	case 269:
+	case 557:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1476
+//line yacc.y:3038
 		{
 			yyVAL.bytes = []byte("big5")
 		}
-	case 270:
+	case 558:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1478
+//line yacc.y:3040
 		{
 			yyVAL.bytes = []byte("binary")
 		}
-	case 271:
+	case 559:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1480
+//line yacc.y:3042
 		{
 			yyVAL.bytes = []byte("cp1250")
 		}
-	case 272:
+	case 560:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1482
+//line yacc.y:3044
 		{
 			yyVAL.bytes = []byte("cp1251")
 		}
-	case 273:
+	case 561:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1484
+//line yacc.y:3046
 		{
 			yyVAL.bytes = []byte("cp1256")
 		}
-	case 274:
+	case 562:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1486
+//line yacc.y:3048
 		{
 			yyVAL.bytes = []byte("cp1257")
 		}
-	case 275:
+	case 563:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1488
+//line yacc.y:3050
 		{
 			yyVAL.bytes = []byte("cp850")
 		}
-	case 276:
+	case 564:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1490
+//line yacc.y:3052
 		{
 			yyVAL.bytes = []byte("cp852")
 		}
-	case 277:
+	case 565:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1492
+//line yacc.y:3054
 		{
 			yyVAL.bytes = []byte("cp866")
 		}
-	case 278:
+	case 566:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1494
+//line yacc.y:3056
 		{
 			yyVAL.bytes = []byte("cp932")
 		}
-	case 279:
+	case 567:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1496
+//line yacc.y:3058
 		{
 			yyVAL.bytes = []byte("dec8")
 		}
-	case 280:
+	case 568:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1498
+//line yacc.y:3060
 		{
 			yyVAL.bytes = []byte("eucjpms")
 		}
-	case 281:
+	case 569:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1500
+//line yacc.y:3062
 		{
 			yyVAL.bytes = []byte("euckr")
 		}
-	case 282:
+	case 570:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1502
+//line yacc.y:3064
 		{
 			yyVAL.bytes = []byte("gb2312")
 		}
-	case 283:
+	case 571:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1504
+//line yacc.y:3066
 		{
 			yyVAL.bytes = []byte("gbk")
 		}
-	case 284:
+	case 572:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1506
+//line yacc.y:3068
 		{
 			yyVAL.bytes = []byte("geostd8")
 		}
-	case 285:
+	case 573:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1508
+//line yacc.y:3070
 		{
 			yyVAL.bytes = []byte("greek")
 		}
-	case 286:
+	case 574:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1510
+//line yacc.y:3072
 		{
 			yyVAL.bytes = []byte("hebrew")
 		}
-	case 287:
+	case 575:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1512
+//line yacc.y:3074
 		{
 			yyVAL.bytes = []byte("hp8")
 		}
-	case 288:
+	case 576:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1514
+//line yacc.y:3076
 		{
 			yyVAL.bytes = []byte("keybcs2")
 		}
-	case 289:
+	case 577:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1516
+//line yacc.y:3078
 		{
 			yyVAL.bytes = []byte("koi8r")
 		}
-	case 290:
+	case 578:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1518
+//line yacc.y:3080
 		{
 			yyVAL.bytes = []byte("koi8u")
 		}
-	case 291:
+	case 579:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1520
+//line yacc.y:3082
 		{
 			yyVAL.bytes = []byte("latin1")
 		}
-	case 292:
+	case 580:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1522
+//line yacc.y:3084
 		{
 			yyVAL.bytes = []byte("latin2")
 		}
-	case 293:
+	case 581:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1524
+//line yacc.y:3086
 		{
 			yyVAL.bytes = []byte("latin5")
 		}
-	case 294:
+	case 582:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1526
+//line yacc.y:3088
 		{
 			yyVAL.bytes = []byte("latin7")
 		}
-	case 295:
+	case 583:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1528
+//line yacc.y:3090
 		{
 			yyVAL.bytes = []byte("macce")
 		}
-	case 296:
+	case 584:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1530
+//line yacc.y:3092
 		{
 			yyVAL.bytes = []byte("macroman")
 		}
-	case 297:
+	case 585:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1532
+//line yacc.y:3094
 		{
 			yyVAL.bytes = []byte("sjis")
 		}
-	case 298:
+	case 586:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1534
+//line yacc.y:3096
 		{
 			yyVAL.bytes = []byte("swe7")
 		}
-	case 299:
+	case 587:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1536
+//line yacc.y:3098
 		{
 			yyVAL.bytes = []byte("tis620")
 		}
-	case 300:
+	case 588:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1538
+//line yacc.y:3100
 		{
 			yyVAL.bytes = []byte("ucs2")
 		}
-	case 301:
+	case 589:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1540
+//line yacc.y:3102
 		{
 			yyVAL.bytes = []byte("ujis")
 		}
-	case 302:
+	case 590:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1542
+//line yacc.y:3104
 		{
 			yyVAL.bytes = []byte("utf16")
 		}
-	case 303:
+	case 591:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1544
+//line yacc.y:3106
 		{
 			yyVAL.bytes = []byte("utf16le")
 		}
-	case 304:
+	case 592:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1546
+//line yacc.y:3108
 		{
 			yyVAL.bytes = []byte("utf32")
 		}
-	case 305:
+	case 593:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1548
+//line yacc.y:3110
 		{
 			yyVAL.bytes = []byte("utf8")
 		}
-	case 306:
+	case 594:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1550
+//line yacc.y:3112
 		{
 			yyVAL.bytes = []byte("utf8mb4")
 		}
-	case 307:
+	case 596:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:3117
+		{
+			yyVAL.bytes = bytes.ToLower(yyDollar[1].bytes)
+		}
+	case 597:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1554
+//line yacc.y:3123
 		{
 			yyVAL.bytes = []byte("armscii8_general_ci")
 		}
-	case 308:
+	case 598:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1556
+//line yacc.y:3125
 		{
 			yyVAL.bytes = []byte("armscii8_bin")
 		}
-	case 309:
+	case 599:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1558
+//line yacc.y:3127
 		{
 			yyVAL.bytes = []byte("ascii_general_ci")
 		}
-	case 310:
+	case 600:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1560
+//line yacc.y:3129
 		{
 			yyVAL.bytes = []byte("ascii_bin")
 		}
-	case 311:
+	case 601:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1562
+//line yacc.y:3131
 		{
 			yyVAL.bytes = []byte("big5_chinese_ci")
 		}
-	case 312:
+	case 602:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1564
+//line yacc.y:3133
 		{
 			yyVAL.bytes = []byte("big5_bin")
 		}
-	case 313:
+	case 603:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1566
+//line yacc.y:3135
 		{
 			yyVAL.bytes = []byte("binary")
 		}
-	case 314:
+	case 604:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1568
+//line yacc.y:3137
 		{
 			yyVAL.bytes = []byte("cp1250_general_ci")
 		}
-	case 315:
+	case 605:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1570
+//line yacc.y:3139
 		{
 			yyVAL.bytes = []byte("cp1250_bin")
 		}
-	case 316:
+	case 606:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1572
+//line yacc.y:3141
 		{
 			yyVAL.bytes = []byte("cp1251_chinese_ci")
 		}
-	case 317:
+	case 607:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1574
+//line yacc.y:3143
 		{
 			yyVAL.bytes = []byte("cp1251_chinese_cs")
 		}
-	case 318:
+	case 608:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1576
+//line yacc.y:3145
 		{
 			yyVAL.bytes = []byte("cp1251_bin")
 		}
-	case 319:
+	case 609:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1578
+//line yacc.y:3147
 		{
 			yyVAL.bytes = []byte("cp1256_chinese_ci")
 		}
-	case 320:
+	case 610:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1580
+//line yacc.y:3149
 		{
 			yyVAL.bytes = []byte("cp1256_bin")
 		}
-	case 321:
+	case 611:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1582
+//line yacc.y:3151
 		{
 			yyVAL.bytes = []byte("cp1257_chinese_ci")
 		}
-	case 322:
+	case 612:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1584
+//line yacc.y:3153
 		{
 			yyVAL.bytes = []byte("cp1257_bin")
 		}
-	case 323:
+	case 613:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1586
+//line yacc.y:3155
 		{
 			yyVAL.bytes = []byte("cp850_chinese_ci")
 		}
-	case 324:
+	case 614:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1588
+//line yacc.y:3157
 		{
 			yyVAL.bytes = []byte("cp850_bin")
 		}
-	case 325:
+	case 615:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1590
+//line yacc.y:3159
 		{
 			yyVAL.bytes = []byte("cp852_chinese_ci")
 		}
-	case 326:
+	case 616:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1592
+//line yacc.y:3161
 		{
 			yyVAL.bytes = []byte("cp852_bin")
 		}
-	case 327:
+	case 617:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1594
+//line yacc.y:3163
 		{
 			yyVAL.bytes = []byte("cp866_chinese_ci")
 		}
-	case 328:
+	case 618:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1596
+//line yacc.y:3165
 		{
 			yyVAL.bytes = []byte("cp866_bin")
 		}
-	case 329:
+	case 619:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1598
+//line yacc.y:3167
 		{
 			yyVAL.bytes = []byte("cp932_japanese_ci")
 		}
-	case 330:
+	case 620:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1600
+//line yacc.y:3169
 		{
 			yyVAL.bytes = []byte("cp932_bin")
 		}
-	case 331:
+	case 621:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1602
+//line yacc.y:3171
 		{
 			yyVAL.bytes = []byte("dec8_swedish_ci")
 		}
-	case 332:
+	case 622:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1604
+//line yacc.y:3173
 		{
 			yyVAL.bytes = []byte("dec8_bin")
 		}
-	case 333:
+	case 623:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1606
+//line yacc.y:3175
 		{
 			yyVAL.bytes = []byte("eucjpms_japanese_ci")
 		}
-	case 334:
+	case 624:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1608
+//line yacc.y:3177
 		{
 			yyVAL.bytes = []byte("eucjpms_bin")
 		}
-	case 335:
+	case 625:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1610
+//line yacc.y:3179
 		{
 			yyVAL.bytes = []byte("euckr_korean_ci")
 		}
-	case 336:
+	case 626:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1612
+//line yacc.y:3181
 		{
 			yyVAL.bytes = []byte("euckr_bin")
 		}
-	case 337:
+	case 627:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1614
+//line yacc.y:3183
 		{
 			yyVAL.bytes = []byte("gb2312_chinese_ci")
 		}
-	case 338:
+	case 628:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1616
+//line yacc.y:3185
 		{
 			yyVAL.bytes = []byte("gb2312_bin")
 		}
-	case 339:
+	case 629:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1618
+//line yacc.y:3187
 		{
 			yyVAL.bytes = []byte("gbk_chinese_ci")
 		}
-	case 340:
+	case 630:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1620
+//line yacc.y:3189
 		{
 			yyVAL.bytes = []byte("gbk_bin")
 		}
-	case 341:
+	case 631:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1622
+//line yacc.y:3191
 		{
 			yyVAL.bytes = []byte("geostd8_general_ci")
 		}
-	case 342:
+	case 632:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1624
+//line yacc.y:3193
 		{
 			yyVAL.bytes = []byte("geostd8_bin")
 		}
-	case 343:
+	case 633:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1626
+//line yacc.y:3195
 		{
 			yyVAL.bytes = []byte("greek_general_ci")
 		}
-	case 344:
+	case 634:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1628
+//line yacc.y:3197
 		{
 			yyVAL.bytes = []byte("greek_bin")
 		}
-	case 345:
+	case 635:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1630
+//line yacc.y:3199
 		{
 			yyVAL.bytes = []byte("hebrew_general_ci")
 		}
-	case 346:
+	case 636:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1632
+//line yacc.y:3201
 		{
 			yyVAL.bytes = []byte("hebrew_bin")
 		}
-	case 347:
+	case 637:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1634
+//line yacc.y:3203
 		{
 			yyVAL.bytes = []byte("hp8_english_ci")
 		}
-	case 348:
+	case 638:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1636
+//line yacc.y:3205
 		{
 			yyVAL.bytes = []byte("hp8_bin")
 		}
-	case 349:
+	case 639:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1638
+//line yacc.y:3207
 		{
 			yyVAL.bytes = []byte("keybcs2_general_ci")
 		}
-	case 350:
+	case 640:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1640
+//line yacc.y:3209
 		{
 			yyVAL.bytes = []byte("keybcs2_bin")
 		}
-	case 351:
+	case 641:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1642
+//line yacc.y:3211
 		{
 			yyVAL.bytes = []byte("koi8r_general_ci")
 		}
-	case 352:
+	case 642:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1644
+//line yacc.y:3213
 		{
 			yyVAL.bytes = []byte("koi8r_bin")
 		}
-	case 353:
+	case 643:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1646
+//line yacc.y:3215
 		{
 			yyVAL.bytes = []byte("koi8u_general_ci")
 		}
-	case 354:
+	case 644:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1648
+//line yacc.y:3217
 		{
 			yyVAL.bytes = []byte("koi8u_bin")
 		}
-	case 355:
+	case 645:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1650
+//line yacc.y:3219
 		{
 			yyVAL.bytes = []byte("latin1_general_ci")
 		}
-	case 356:
+	case 646:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1652
+//line yacc.y:3221
 		{
 			yyVAL.bytes = []byte("latin1_general_cs")
 		}
-	case 357:
+	case 647:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1654
+//line yacc.y:3223
 		{
 			yyVAL.bytes = []byte("latin1_bin")
 		}
-	case 358:
+	case 648:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1656
+//line yacc.y:3225
 		{
 			yyVAL.bytes = []byte("latin2_general_ci")
 		}
-	case 359:
+	case 649:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1658
+//line yacc.y:3227
 		{
 			yyVAL.bytes = []byte("latin2_bin")
 		}
-	case 360:
+	case 650:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1660
+//line yacc.y:3229
 		{
 			yyVAL.bytes = []byte("latin5_turkish_ci")
 		}
-	case 361:
+	case 651:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1662
+//line yacc.y:3231
 		{
 			yyVAL.bytes = []byte("latin5_bin")
 		}
-	case 362:
+	case 652:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1664
+//line yacc.y:3233
 		{
 			yyVAL.bytes = []byte("latin7_general_ci")
 		}
-	case 363:
+	case 653:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1666
+//line yacc.y:3235
 		{
 			yyVAL.bytes = []byte("latin7_general_cs")
 		}
-	case 364:
+	case 654:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1668
+//line yacc.y:3237
 		{
 			yyVAL.bytes = []byte("latin7_bin")
 		}
-	case 365:
+	case 655:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1670
+//line yacc.y:3239
 		{
 			yyVAL.bytes = []byte("macce_general_ci")
 		}
-	case 366:
+	case 656:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1672
+//line yacc.y:3241
 		{
 			yyVAL.bytes = []byte("macce_bin")
 		}
-	case 367:
+	case 657:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1674
+//line yacc.y:3243
 		{
 			yyVAL.bytes = []byte("macroman_general_ci")
 		}
-	case 368:
+	case 658:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1676
+//line yacc.y:3245
 		{
 			yyVAL.bytes = []byte("macroman_bin")
 		}
-	case 369:
+	case 659:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1678
+//line yacc.y:3247
 		{
 			yyVAL.bytes = []byte("sjis_japanese_ci")
 		}
-	case 370:
+	case 660:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1680
+//line yacc.y:3249
 		{
 			yyVAL.bytes = []byte("sjis_bin")
 		}
-	case 371:
+	case 661:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1682
+//line yacc.y:3251
 		{
 			yyVAL.bytes = []byte("swe7_swedish_ci")
 		}
-	case 372:
+	case 662:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1684
+//line yacc.y:3253
 		{
 			yyVAL.bytes = []byte("swe7_bin")
 		}
-	case 373:
+	case 663:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1686
+//line yacc.y:3255
 		{
 			yyVAL.bytes = []byte("tis620_thai_ci")
 		}
-	case 374:
+	case 664:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1688
+//line yacc.y:3257
 		{
 			yyVAL.bytes = []byte("tis620_bin")
 		}
-	case 375:
+	case 665:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1690
+//line yacc.y:3259
 		{
 			yyVAL.bytes = []byte("ucs2_general_ci")
 		}
-	case 376:
+	case 666:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1692
+//line yacc.y:3261
 		{
 			yyVAL.bytes = []byte("ucs2_unicode_ci")
 		}
-	case 377:
+	case 667:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1694
+//line yacc.y:3263
 		{
 			yyVAL.bytes = []byte("ucs2_bin")
 		}
-	case 378:
+	case 668:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1696
+//line yacc.y:3265
 		{
 			yyVAL.bytes = []byte("ujis_japanese_ci")
 		}
-	case 379:
+	case 669:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1698
+//line yacc.y:3267
 		{
 			yyVAL.bytes = []byte("ujis_bin")
 		}
-	case 380:
+	case 670:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1700
+//line yacc.y:3269
 		{
 			yyVAL.bytes = []byte("utf16_general_ci")
 		}
-	case 381:
+	case 671:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1702
+//line yacc.y:3271
 		{
 			yyVAL.bytes = []byte("utf16_unicode_ci")
 		}
-	case 382:
+	case 672:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1704
+//line yacc.y:3273
 		{
 			yyVAL.bytes = []byte("utf16_bin")
 		}
-	case 383:
+	case 673:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1706
+//line yacc.y:3275
 		{
 			yyVAL.bytes = []byte("utf16le_general_ci")
 		}
-	case 384:
+	case 674:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1708
+//line yacc.y:3277
 		{
 			yyVAL.bytes = []byte("utf16le_bin")
 		}
-	case 385:
+	case 675:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1710
+//line yacc.y:3279
 		{
 			yyVAL.bytes = []byte("utf32_general_ci")
 		}
-	case 386:
+	case 676:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1712
+//line yacc.y:3281
 		{
 			yyVAL.bytes = []byte("utf32_unicode_ci")
 		}
-	case 387:
+	case 677:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1714
+//line yacc.y:3283
 		{
 			yyVAL.bytes = []byte("utf32_bin")
 		}
-	case 388:
+	case 678:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1716
+//line yacc.y:3285
 		{
 			yyVAL.bytes = []byte("utf8_general_ci")
 		}
-	case 389:
+	case 679:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1718
+//line yacc.y:3287
 		{
 			yyVAL.bytes = []byte("utf8_unicode_ci")
 		}
-	case 390:
+	case 680:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1720
+//line yacc.y:3289
 		{
 			yyVAL.bytes = []byte("utf8_bin")
 		}
-	case 391:
+	case 681:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1722
+//line yacc.y:3291
 		{
 			yyVAL.bytes = []byte("utf8mb4_general_ci")
 		}
-	case 392:
+	case 682:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1724
+//line yacc.y:3293
 		{
 			yyVAL.bytes = []byte("utf8mb4_unicode_ci")
 		}
-	case 393:
+	case 683:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1726
+//line yacc.y:3295
 		{
 			yyVAL.bytes = []byte("utf8mb4_bin")
 		}
-	case 394:
+	case 684:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:3297
+		{
+			yyVAL.bytes = []byte("utf8mb4_0900_ai_ci")
+		}
+	case 685:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:3299
+		{
+			yyVAL.bytes = []byte("utf8mb4_0900_bin")
+		}
+	case 686:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1731
+//line yacc.y:3304
 		{
 			yyVAL.bytes = []byte("read committed")
 		}
-	case 395:
+	case 687:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1733
+//line yacc.y:3306
 		{
 			yyVAL.bytes = []byte("read uncommitted")
 		}
-	case 396:
+	case 688:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1735
+//line yacc.y:3308
 		{
 			yyVAL.bytes = []byte("repeatable read")
 		}
-	case 397:
+	case 689:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1737
+//line yacc.y:3310
 		{
 			yyVAL.bytes = []byte("serializable")
 		}
-	case 398:
+	case 690:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1740
+//line yacc.y:3313
 		{
 			yyVAL.bytes = nil
 		}
-	case 399:
+	case 691:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1742
+//line yacc.y:3315
 		{
 			yyVAL.bytes = []byte("session")
 		}
-	case 400:
+	case 692:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1744
+//line yacc.y:3317
 		{
 			yyVAL.bytes = []byte("global")
 		}
-	case 401:
+	case 693:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:1747
+//line yacc.y:3320
 		{
 			yyVAL.expr = nil
 		}
-	case 402:
+	case 694:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1749
+//line yacc.y:3322
 		{
 			yyVAL.expr = &LikeExpr{Expr: yyDollar[2].valExpr}
 		}
-	case 403:
+	case 695:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1753
+//line yacc.y:3326
 		{
 			yyVAL.expr = &WhereExpr{Expr: yyDollar[2].boolExpr}
 		}
-	case 404:
+	case 696:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1759
+//line yacc.y:3332
 		{
 			yyVAL.createDefs = CreateDefinitions{yyDollar[1].createDef}
 		}
-	case 405:
+	case 697:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1763
+//line yacc.y:3336
 		{
 			yyVAL.createDefs = append(yyDollar[1].createDefs, yyDollar[3].createDef)
 		}
-	case 406:
+	case 698:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:1769
+//line yacc.y:3342
 		{
 			yyVAL.createDef = &CreateColumnDefinition{ColumnName: yyDollar[1].colName, ColumnDef: yyDollar[2].columnDef}
 		}
-	case 407:
+	case 699:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:1773
+//line yacc.y:3346
 		{
 			yyVAL.createDef = &CreatePrimaryKeyDefinition{Symbol: yyDollar[1].bytes, IndexType: yyDollar[4].bytes, IndexColumns: yyDollar[6].idxColNames}
 		}
-	case 408:
+	case 700:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:1777
+//line yacc.y:3350
 		{
 			yyVAL.createDef = &CreateIndexDefinition{Name: yyDollar[2].bytes, IndexType: yyDollar[3].bytes, IndexColumns: yyDollar[5].idxColNames}
 		}
-	case 409:
+	case 701:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:1781
+//line yacc.y:3354
 		{
 			yyVAL.createDef = &CreateIndexDefinition{Name: yyDollar[2].bytes, IndexType: yyDollar[3].bytes, IndexColumns: yyDollar[5].idxColNames}
 		}
-	case 410:
+	case 702:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:1785
+//line yacc.y:3358
 		{
 			yyVAL.createDef = &CreateUniqueIndexDefinition{Symbol: yyDollar[1].bytes, Name: yyDollar[3].bytes, IndexType: yyDollar[4].bytes, IndexColumns: yyDollar[6].idxColNames}
 		}
-	case 411:
+	case 703:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:1789
+//line yacc.y:3362
 		{
 			yyVAL.createDef = &CreateUniqueIndexDefinition{Symbol: yyDollar[1].bytes, Name: yyDollar[4].bytes, IndexType: yyDollar[5].bytes, IndexColumns: yyDollar[7].idxColNames}
 		}
-	case 412:
+	case 704:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:1793
+//line yacc.y:3366
 		{
 			yyVAL.createDef = &CreateUniqueIndexDefinition{Symbol: yyDollar[1].bytes, Name: yyDollar[4].bytes, IndexType: yyDollar[5].bytes, IndexColumns: yyDollar[7].idxColNames}
 		}
-	case 413:
+	case 705:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:1797
+//line yacc.y:3370
 		{
 			yyVAL.createDef = &CreateForeignKeyDefinition{Symbol: yyDollar[1].bytes, IndexColumns: yyDollar[5].idxColNames, ReferenceDef: yyDollar[7].bytes}
 		}
-	case 414:
-		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:1803
+	case 706:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:3374
+		{
+			yyVAL.createDef = &CreateCheckDefinition{Check: yyDollar[1].checkConstraint}
+		}
+	case 707:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:3379
+		{
+			yyVAL.checkConstraint = nil
+		}
+	case 708:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:3381
+		{
+			yyVAL.checkConstraint = yyDollar[1].checkConstraint
+		}
+	case 709:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line yacc.y:3385
+		{
+			yyVAL.checkConstraint = &CheckConstraint{Symbol: yyDollar[1].bytes, Expr: yyDollar[4].boolExpr, Enforced: yyDollar[6].str}
+		}
+	case 710:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:3390
+		{
+			yyVAL.str = ""
+		}
+	case 711:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:3392
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 712:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:3396
+		{
+			if !bytes.EqualFold(yyDollar[1].bytes, ENFORCED_BYTES) {
+				yylex.Error("expecting enforced")
+				return 1
+			}
+			yyVAL.str = AST_ENFORCED
+		}
+	case 713:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:3404
+		{
+			if !bytes.EqualFold(yyDollar[2].bytes, ENFORCED_BYTES) {
+				yylex.Error("expecting enforced")
+				return 1
+			}
+			yyVAL.str = AST_NOT_ENFORCED
+		}
+	case 714:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line yacc.y:3414
 		{
 			yyVAL.columnDef = &ColumnDefinition{Type: yyDollar[1].dataType,
 				IsAutoIncrement: yyDollar[2].boolean,
@@ -4299,11 +7140,12 @@ yydefault:
 				ColumnComment:   yyDollar[4].valExpr,
 				ColumnFormat:    yyDollar[5].bytes,
 				ColumnStorage:   yyDollar[6].bytes,
-				ReferenceDef:    yyDollar[7].bytes}
+				ReferenceDef:    yyDollar[7].bytes,
+				Check:           yyDollar[8].checkConstraint}
 		}
-	case 415:
-		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:1813
+	case 715:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line yacc.y:3425
 		{
 			yyVAL.columnDef = &ColumnDefinition{Type: yyDollar[1].dataType,
 				IsNotNull:       yyDollar[2].boolean,
@@ -4312,11 +7154,12 @@ yydefault:
 				ColumnComment:   yyDollar[5].valExpr,
 				ColumnFormat:    yyDollar[6].bytes,
 				ColumnStorage:   yyDollar[7].bytes,
-				ReferenceDef:    yyDollar[8].bytes}
+				ReferenceDef:    yyDollar[8].bytes,
+				Check:           yyDollar[9].checkConstraint}
 		}
-	case 416:
-		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:1824
+	case 716:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line yacc.y:3437
 		{
 			yyVAL.columnDef = &ColumnDefinition{Type: yyDollar[1].dataType,
 				DefaultValue:    yyDollar[2].valExpr,
@@ -4325,11 +7168,12 @@ yydefault:
 				ColumnComment:   yyDollar[5].valExpr,
 				ColumnFormat:    yyDollar[6].bytes,
 				ColumnStorage:   yyDollar[7].bytes,
-				ReferenceDef:    yyDollar[8].bytes}
+				ReferenceDef:    yyDollar[8].bytes,
+				Check:           yyDollar[9].checkConstraint}
 		}
-	case 417:
-		yyDollar = yyS[yypt-9 : yypt+1]
-		//line yacc.y:1835
+	case 717:
+		yyDollar = yyS[yypt-10 : yypt+1]
+//line yacc.y:3449
 		{
 			yyVAL.columnDef = &ColumnDefinition{Type: yyDollar[1].dataType,
 				IsNotNull:       yyDollar[2].boolean,
@@ -4339,11 +7183,12 @@ yydefault:
 				ColumnComment:   yyDollar[6].valExpr,
 				ColumnFormat:    yyDollar[7].bytes,
 				ColumnStorage:   yyDollar[8].bytes,
-				ReferenceDef:    yyDollar[9].bytes}
+				ReferenceDef:    yyDollar[9].bytes,
+				Check:           yyDollar[10].checkConstraint}
 		}
-	case 418:
-		yyDollar = yyS[yypt-9 : yypt+1]
-		//line yacc.y:1847
+	case 718:
+		yyDollar = yyS[yypt-10 : yypt+1]
+//line yacc.y:3462
 		{
 			yyVAL.columnDef = &ColumnDefinition{Type: yyDollar[1].dataType,
 				IsNotNull:       yyDollar[3].boolean,
@@ -4353,851 +7198,1150 @@ yydefault:
 				ColumnComment:   yyDollar[6].valExpr,
 				ColumnFormat:    yyDollar[7].bytes,
 				ColumnStorage:   yyDollar[8].bytes,
-				ReferenceDef:    yyDollar[9].bytes}
+				ReferenceDef:    yyDollar[9].bytes,
+				Check:           yyDollar[10].checkConstraint}
+		}
+	case 719:
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line yacc.y:3476
+		{
+			yyVAL.columnDef = &ColumnDefinition{Type: yyDollar[1].dataType,
+				Generated:        yyDollar[5].valExpr,
+				GeneratedStorage: yyDollar[7].str,
+				UniqueOrKey:      yyDollar[8].bytes,
+				ColumnComment:    yyDollar[9].valExpr,
+				ReferenceDef:     yyDollar[10].bytes,
+				Check:            yyDollar[11].checkConstraint}
+		}
+	case 720:
+		yyDollar = yyS[yypt-12 : yypt+1]
+//line yacc.y:3486
+		{
+			yyVAL.columnDef = &ColumnDefinition{Type: yyDollar[1].dataType,
+				Generated:        yyDollar[5].valExpr,
+				GeneratedStorage: yyDollar[7].str,
+				IsNotNull:        yyDollar[8].boolean,
+				UniqueOrKey:      yyDollar[9].bytes,
+				ColumnComment:    yyDollar[10].valExpr,
+				ReferenceDef:     yyDollar[11].bytes,
+				Check:            yyDollar[12].checkConstraint}
+		}
+	case 721:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:3498
+		{
+		}
+	case 722:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:3500
+		{
+			if !bytes.EqualFold(yyDollar[1].bytes, GENERATED_BYTES) || !bytes.EqualFold(yyDollar[2].bytes, ALWAYS_BYTES) {
+				yylex.Error("expecting generated always")
+				return 1
+			}
+		}
+	case 723:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:3508
+		{
+			yyVAL.str = ""
+		}
+	case 724:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:3510
+		{
+			switch {
+			case bytes.EqualFold(yyDollar[1].bytes, VIRTUAL_BYTES):
+				yyVAL.str = AST_VIRTUAL
+			case bytes.EqualFold(yyDollar[1].bytes, STORED_BYTES):
+				yyVAL.str = AST_STORED
+			default:
+				yylex.Error("expecting virtual or stored")
+				return 1
+			}
 		}
-	case 419:
+	case 725:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1861
+//line yacc.y:3524
 		{
 			yyVAL.dataType = &DataType{TypeName: "bit"}
 		}
-	case 420:
+	case 726:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:1865
+//line yacc.y:3528
 		{
 			yyVAL.dataType = &DataType{TypeName: "bit(" + string(yyDollar[3].bytes) + ")"}
 		}
-	case 421:
+	case 727:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1869
+//line yacc.y:3532
 		{
 			yyVAL.dataType = &DataType{TypeName: "tinyint", IsUnsigned: yyDollar[2].boolean, IsZeroFill: yyDollar[3].boolean}
 		}
-	case 422:
+	case 728:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:1873
+//line yacc.y:3536
 		{
 			yyVAL.dataType = &DataType{TypeName: "tinyint(" + string(yyDollar[3].bytes) + ")", IsUnsigned: yyDollar[5].boolean, IsZeroFill: yyDollar[6].boolean}
 		}
-	case 423:
+	case 729:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1877
+//line yacc.y:3540
 		{
 			yyVAL.dataType = &DataType{TypeName: "bool"}
 		}
-	case 424:
+	case 730:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1881
+//line yacc.y:3544
 		{
 			yyVAL.dataType = &DataType{TypeName: "bool"}
 		}
-	case 425:
+	case 731:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1885
+//line yacc.y:3548
 		{
 			yyVAL.dataType = &DataType{TypeName: "smallint", IsUnsigned: yyDollar[2].boolean, IsZeroFill: yyDollar[3].boolean}
 		}
-	case 426:
+	case 732:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:1889
+//line yacc.y:3552
 		{
 			yyVAL.dataType = &DataType{TypeName: "smallint(" + string(yyDollar[3].bytes) + ")", IsUnsigned: yyDollar[5].boolean, IsZeroFill: yyDollar[6].boolean}
 		}
-	case 427:
+	case 733:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1893
+//line yacc.y:3556
 		{
 			yyVAL.dataType = &DataType{TypeName: "mediumint", IsUnsigned: yyDollar[2].boolean, IsZeroFill: yyDollar[3].boolean}
 		}
-	case 428:
+	case 734:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:1897
+//line yacc.y:3560
 		{
 			yyVAL.dataType = &DataType{TypeName: "mediumint(" + string(yyDollar[3].bytes) + ")", IsUnsigned: yyDollar[5].boolean, IsZeroFill: yyDollar[6].boolean}
 		}
-	case 429:
+	case 735:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1901
+//line yacc.y:3564
 		{
 			yyVAL.dataType = &DataType{TypeName: "int", IsUnsigned: yyDollar[2].boolean, IsZeroFill: yyDollar[3].boolean}
 		}
-	case 430:
+	case 736:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:1905
+//line yacc.y:3568
 		{
 			yyVAL.dataType = &DataType{TypeName: "int(" + string(yyDollar[3].bytes) + ")", IsUnsigned: yyDollar[5].boolean, IsZeroFill: yyDollar[6].boolean}
 		}
-	case 431:
+	case 737:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1909
+//line yacc.y:3572
 		{
 			yyVAL.dataType = &DataType{TypeName: "integer", IsUnsigned: yyDollar[2].boolean, IsZeroFill: yyDollar[3].boolean}
 		}
-	case 432:
+	case 738:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:1913
+//line yacc.y:3576
 		{
 			yyVAL.dataType = &DataType{TypeName: "integer(" + string(yyDollar[3].bytes) + ")", IsUnsigned: yyDollar[5].boolean, IsZeroFill: yyDollar[6].boolean}
 		}
-	case 433:
+	case 739:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1917
+//line yacc.y:3580
 		{
 			yyVAL.dataType = &DataType{TypeName: "bigint", IsUnsigned: yyDollar[2].boolean, IsZeroFill: yyDollar[3].boolean}
 		}
-	case 434:
+	case 740:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:1921
+//line yacc.y:3584
 		{
 			yyVAL.dataType = &DataType{TypeName: "bigint(" + string(yyDollar[3].bytes) + ")", IsUnsigned: yyDollar[5].boolean, IsZeroFill: yyDollar[6].boolean}
 		}
-	case 435:
+	case 741:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1925
+//line yacc.y:3588
 		{
 			yyVAL.dataType = &DataType{TypeName: "real", IsUnsigned: yyDollar[2].boolean, IsZeroFill: yyDollar[3].boolean}
 		}
-	case 436:
+	case 742:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:1929
+//line yacc.y:3592
 		{
 			yyVAL.dataType = &DataType{TypeName: "real(" + string(yyDollar[3].bytes) + "," + string(yyDollar[5].bytes) + ")", IsUnsigned: yyDollar[7].boolean, IsZeroFill: yyDollar[8].boolean}
 		}
-	case 437:
+	case 743:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1933
+//line yacc.y:3596
 		{
 			yyVAL.dataType = &DataType{TypeName: "double", IsUnsigned: yyDollar[2].boolean, IsZeroFill: yyDollar[3].boolean}
 		}
-	case 438:
+	case 744:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:1937
+//line yacc.y:3600
 		{
 			yyVAL.dataType = &DataType{TypeName: "double(" + string(yyDollar[3].bytes) + "," + string(yyDollar[5].bytes) + ")", IsUnsigned: yyDollar[7].boolean, IsZeroFill: yyDollar[8].boolean}
 		}
-	case 439:
+	case 745:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1941
+//line yacc.y:3604
 		{
 			yyVAL.dataType = &DataType{TypeName: "float", IsUnsigned: yyDollar[2].boolean, IsZeroFill: yyDollar[3].boolean}
 		}
-	case 440:
+	case 746:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:1945
+//line yacc.y:3608
 		{
 			yyVAL.dataType = &DataType{TypeName: "float(" + string(yyDollar[3].bytes) + "," + string(yyDollar[5].bytes) + ")", IsUnsigned: yyDollar[7].boolean, IsZeroFill: yyDollar[8].boolean}
 		}
-	case 441:
+	case 747:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:1949
+//line yacc.y:3612
 		{
 			yyVAL.dataType = &DataType{TypeName: "decimal", IsUnsigned: yyDollar[2].boolean, IsZeroFill: yyDollar[3].boolean}
 		}
-	case 442:
+	case 748:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:1953
+//line yacc.y:3616
 		{
 			yyVAL.dataType = &DataType{TypeName: "decimal(" + string(yyDollar[3].bytes) + ")", IsUnsigned: yyDollar[5].boolean, IsZeroFill: yyDollar[6].boolean}
 		}
-	case 443:
+	case 749:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:1957
+//line yacc.y:3620
 		{
 			yyVAL.dataType = &DataType{TypeName: "decimal(" + string(yyDollar[3].bytes) + "," + string(yyDollar[5].bytes) + ")", IsUnsigned: yyDollar[7].boolean, IsZeroFill: yyDollar[8].boolean}
 		}
-	case 444:
+	case 750:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1961
+//line yacc.y:3624
 		{
 			yyVAL.dataType = &DataType{TypeName: "date"}
 		}
-	case 445:
+	case 751:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1965
+//line yacc.y:3628
 		{
 			yyVAL.dataType = &DataType{TypeName: "time"}
 		}
-	case 446:
+	case 752:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:1969
+//line yacc.y:3632
 		{
 			yyVAL.dataType = &DataType{TypeName: "time(" + string(yyDollar[3].bytes) + ")"}
 		}
-	case 447:
+	case 753:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1973
+//line yacc.y:3636
 		{
 			yyVAL.dataType = &DataType{TypeName: "timestamp"}
 		}
-	case 448:
+	case 754:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:1977
+//line yacc.y:3640
 		{
 			yyVAL.dataType = &DataType{TypeName: "timestamp(" + string(yyDollar[3].bytes) + ")"}
 		}
-	case 449:
+	case 755:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1981
+//line yacc.y:3644
 		{
 			yyVAL.dataType = &DataType{TypeName: "datetime"}
 		}
-	case 450:
+	case 756:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:1985
+//line yacc.y:3648
 		{
 			yyVAL.dataType = &DataType{TypeName: "datetime(" + string(yyDollar[3].bytes) + ")"}
 		}
-	case 451:
+	case 757:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:1989
+//line yacc.y:3652
 		{
 			yyVAL.dataType = &DataType{TypeName: "year"}
 		}
-	case 452:
+	case 758:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:1993
+//line yacc.y:3656
 		{
 			yyVAL.dataType = &DataType{TypeName: "char", IsBinary: yyDollar[2].boolean, Charset: yyDollar[3].bytes, Collate: yyDollar[4].bytes}
 		}
-	case 453:
+	case 759:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:1997
+//line yacc.y:3660
 		{
 			yyVAL.dataType = &DataType{TypeName: "nchar", IsBinary: yyDollar[2].boolean, Charset: yyDollar[3].bytes, Collate: yyDollar[4].bytes}
 		}
-	case 454:
+	case 760:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:2001
+//line yacc.y:3664
 		{
 			yyVAL.dataType = &DataType{TypeName: "char(" + string(yyDollar[3].bytes) + ")", IsBinary: yyDollar[5].boolean, Charset: yyDollar[6].bytes, Collate: yyDollar[7].bytes}
 		}
-	case 455:
+	case 761:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:2005
+//line yacc.y:3668
 		{
 			yyVAL.dataType = &DataType{TypeName: "nchar(" + string(yyDollar[3].bytes) + ")", IsBinary: yyDollar[5].boolean, Charset: yyDollar[6].bytes, Collate: yyDollar[7].bytes}
 		}
-	case 456:
+	case 762:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:2009
+//line yacc.y:3672
 		{
 			yyVAL.dataType = &DataType{TypeName: "varchar(" + string(yyDollar[3].bytes) + ")", IsBinary: yyDollar[5].boolean, Charset: yyDollar[6].bytes, Collate: yyDollar[7].bytes}
 		}
-	case 457:
+	case 763:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:2013
+//line yacc.y:3676
 		{
 			yyVAL.dataType = &DataType{TypeName: "nvarchar(" + string(yyDollar[3].bytes) + ")", IsBinary: yyDollar[5].boolean, Charset: yyDollar[6].bytes, Collate: yyDollar[7].bytes}
 		}
-	case 458:
+	case 764:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2017
+//line yacc.y:3680
 		{
 			yyVAL.dataType = &DataType{TypeName: "binary"}
 		}
-	case 459:
+	case 765:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:2021
+//line yacc.y:3684
 		{
 			yyVAL.dataType = &DataType{TypeName: "binary(" + string(yyDollar[3].bytes) + ")"}
 		}
-	case 460:
+	case 766:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:2025
+//line yacc.y:3688
 		{
 			yyVAL.dataType = &DataType{TypeName: "varbinary(" + string(yyDollar[3].bytes) + ")"}
 		}
-	case 461:
+	case 767:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2029
+//line yacc.y:3692
 		{
 			yyVAL.dataType = &DataType{TypeName: "tinyblob"}
 		}
-	case 462:
+	case 768:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2033
+//line yacc.y:3696
 		{
 			yyVAL.dataType = &DataType{TypeName: "blob"}
 		}
-	case 463:
+	case 769:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2037
+//line yacc.y:3700
 		{
 			yyVAL.dataType = &DataType{TypeName: "mediumblob"}
 		}
-	case 464:
+	case 770:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2041
+//line yacc.y:3704
 		{
 			yyVAL.dataType = &DataType{TypeName: "longblob"}
 		}
-	case 465:
+	case 771:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:2045
+//line yacc.y:3708
 		{
 			yyVAL.dataType = &DataType{TypeName: "tinytext", IsBinary: yyDollar[2].boolean, Charset: yyDollar[3].bytes, Collate: yyDollar[4].bytes}
 		}
-	case 466:
+	case 772:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:2049
+//line yacc.y:3712
 		{
 			yyVAL.dataType = &DataType{TypeName: "text", IsBinary: yyDollar[2].boolean, Charset: yyDollar[3].bytes, Collate: yyDollar[4].bytes}
 		}
-	case 467:
+	case 773:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:2053
+//line yacc.y:3716
 		{
 			yyVAL.dataType = &DataType{TypeName: "mediumtext", IsBinary: yyDollar[2].boolean, Charset: yyDollar[3].bytes, Collate: yyDollar[4].bytes}
 		}
-	case 468:
+	case 774:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:2057
+//line yacc.y:3720
 		{
 			yyVAL.dataType = &DataType{TypeName: "longtext", IsBinary: yyDollar[2].boolean, Charset: yyDollar[3].bytes, Collate: yyDollar[4].bytes}
 		}
-	case 469:
+	case 775:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:2061
+//line yacc.y:3724
 		{
 			yyVAL.dataType = &DataType{TypeName: "enum(" + String(yyDollar[3].valExprs) + ")", Charset: yyDollar[5].bytes, Collate: yyDollar[6].bytes}
 		}
-	case 470:
+	case 776:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:2065
+//line yacc.y:3728
 		{
 			yyVAL.dataType = &DataType{TypeName: "set(" + String(yyDollar[3].valExprs) + ")", Charset: yyDollar[5].bytes, Collate: yyDollar[6].bytes}
 		}
-	case 471:
+	case 777:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:3732
+		{
+			name := strings.ToLower(string(yyDollar[1].bytes))
+			if !ID_DATA_TYPES[name] {
+				yylex.Error("expecting data type")
+				return 1
+			}
+			yyVAL.dataType = &DataType{TypeName: name}
+		}
+	case 778:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2071
+//line yacc.y:3743
 		{
 			yyVAL.boolean = false
 		}
-	case 472:
+	case 779:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2073
+//line yacc.y:3745
 		{
 			yyVAL.boolean = true
 		}
-	case 473:
+	case 780:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2077
+//line yacc.y:3749
 		{
 			yyVAL.valExpr = yyDollar[2].valExpr
 		}
-	case 474:
+	case 781:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2080
+//line yacc.y:3752
 		{
 			yyVAL.boolean = false
 		}
-	case 475:
+	case 782:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2082
+//line yacc.y:3754
 		{
 			yyVAL.boolean = true
 		}
-	case 476:
+	case 783:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2085
+//line yacc.y:3757
 		{
 			yyVAL.bytes = nil
 		}
-	case 477:
+	case 784:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2087
+//line yacc.y:3759
 		{
 			yyVAL.bytes = []byte("unique key")
 		}
-	case 478:
+	case 785:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2089
+//line yacc.y:3761
 		{
 			yyVAL.bytes = []byte("unique key")
 		}
-	case 479:
+	case 786:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2091
+//line yacc.y:3763
 		{
 			yyVAL.bytes = []byte("primary key")
 		}
-	case 480:
+	case 787:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2093
+//line yacc.y:3765
 		{
 			yyVAL.bytes = []byte("primary key")
 		}
-	case 481:
+	case 788:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2096
+//line yacc.y:3768
 		{
 			yyVAL.valExpr = nil
 		}
-	case 482:
+	case 789:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2098
+//line yacc.y:3770
 		{
 			yyVAL.valExpr = StrVal(yyDollar[2].bytes)
 		}
-	case 483:
+	case 790:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2103
+//line yacc.y:3775
 		{
 			yyVAL.bytes = nil
 		}
-	case 484:
+	case 791:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2105
+//line yacc.y:3777
 		{
 			yyVAL.bytes = []byte("fixed")
 		}
-	case 485:
+	case 792:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2107
+//line yacc.y:3779
 		{
 			yyVAL.bytes = []byte("dynamic")
 		}
-	case 486:
+	case 793:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2109
+//line yacc.y:3781
 		{
 			yyVAL.bytes = []byte("default")
 		}
-	case 487:
+	case 794:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2112
+//line yacc.y:3784
 		{
 			yyVAL.bytes = nil
 		}
-	case 488:
+	case 795:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2114
+//line yacc.y:3786
 		{
 			yyVAL.bytes = []byte("disk")
 		}
-	case 489:
+	case 796:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2116
+//line yacc.y:3788
 		{
 			yyVAL.bytes = []byte("memory")
 		}
-	case 490:
+	case 797:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2118
+//line yacc.y:3790
 		{
 			yyVAL.bytes = []byte("default")
 		}
-	case 491:
+	case 798:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2121
+//line yacc.y:3793
 		{
 			yyVAL.bytes = nil
 		}
-	case 492:
+	case 799:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2123
+//line yacc.y:3795
 		{
 			yyVAL.bytes = yyDollar[1].bytes
 		}
-	case 493:
+	case 800:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:2127
+//line yacc.y:3799
 		{
 			yyVAL.bytes = []byte("references " + String(yyDollar[2].tableName) + "(" + String(yyDollar[4].idxColNames) + ")" + string(yyDollar[6].bytes) + string(yyDollar[7].bytes))
 		}
-	case 494:
+	case 801:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2130
+//line yacc.y:3802
 		{
 			yyVAL.bytes = nil
 		}
-	case 495:
+	case 802:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2132
+//line yacc.y:3804
 		{
 			yyVAL.bytes = []byte("match full")
 		}
-	case 496:
+	case 803:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2134
+//line yacc.y:3806
 		{
 			yyVAL.bytes = []byte("match partial")
 		}
-	case 497:
+	case 804:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2136
+//line yacc.y:3808
 		{
 			yyVAL.bytes = []byte("match simple")
 		}
-	case 498:
+	case 805:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2139
+//line yacc.y:3811
 		{
 			yyVAL.bytes = nil
 		}
-	case 499:
+	case 806:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:2141
+//line yacc.y:3813
 		{
 			yyVAL.bytes = []byte("on delete " + string(yyDollar[3].bytes))
 		}
-	case 500:
+	case 807:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:2143
+//line yacc.y:3815
 		{
 			yyVAL.bytes = []byte("on update " + string(yyDollar[3].bytes))
 		}
-	case 501:
+	case 808:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:2145
+//line yacc.y:3817
 		{
 			yyVAL.bytes = []byte("on update " + string(yyDollar[3].bytes) + " on delete " + string(yyDollar[6].bytes))
 		}
-	case 502:
+	case 809:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:2147
+//line yacc.y:3819
 		{
 			yyVAL.bytes = []byte("on update " + string(yyDollar[6].bytes) + " on delete " + string(yyDollar[3].bytes))
 		}
-	case 503:
+	case 810:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2150
+//line yacc.y:3822
 		{
 			yyVAL.bytes = nil
 		}
-	case 504:
+	case 811:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2152
+//line yacc.y:3824
 		{
 			yyVAL.bytes = yyDollar[1].bytes
 		}
-	case 505:
+	case 812:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2156
+//line yacc.y:3828
 		{
 			yyVAL.bytes = []byte("restrict")
 		}
-	case 506:
+	case 813:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2158
+//line yacc.y:3830
 		{
 			yyVAL.bytes = []byte("cascade")
 		}
-	case 507:
+	case 814:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2160
+//line yacc.y:3832
 		{
 			yyVAL.bytes = []byte("set null")
 		}
-	case 508:
+	case 815:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2162
+//line yacc.y:3834
 		{
 			yyVAL.bytes = []byte("no action")
 		}
-	case 509:
+	case 816:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2165
+//line yacc.y:3837
 		{
 			yyVAL.boolean = false
 		}
-	case 510:
+	case 817:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2167
+//line yacc.y:3839
 		{
 			yyVAL.boolean = true
 		}
-	case 511:
+	case 818:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2170
+//line yacc.y:3842
 		{
 			yyVAL.boolean = false
 		}
-	case 512:
+	case 819:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2172
+//line yacc.y:3844
 		{
 			yyVAL.boolean = true
 		}
-	case 513:
+	case 820:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2175
+//line yacc.y:3847
 		{
 			yyVAL.boolean = false
 		}
-	case 514:
+	case 821:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2177
+//line yacc.y:3849
 		{
 			yyVAL.boolean = true
 		}
-	case 515:
+	case 822:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2180
+//line yacc.y:3852
 		{
 			yyVAL.bytes = nil
 		}
-	case 516:
+	case 823:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:2182
+//line yacc.y:3854
 		{
 			yyVAL.bytes = yyDollar[3].bytes
 		}
-	case 517:
+	case 824:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2185
+//line yacc.y:3857
 		{
 			yyVAL.bytes = nil
 		}
-	case 518:
+	case 825:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2187
+//line yacc.y:3859
 		{
 			yyVAL.bytes = yyDollar[2].bytes
 		}
-	case 519:
+	case 826:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2190
+//line yacc.y:3862
 		{
 			yyVAL.bytes = nil
 		}
-	case 520:
+	case 827:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2192
+//line yacc.y:3864
 		{
 			yyVAL.bytes = yyDollar[2].bytes
 		}
-	case 521:
+	case 828:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2195
+//line yacc.y:3867
 		{
 			yyVAL.optKeyVals = nil
 		}
-	case 522:
+	case 829:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2197
+//line yacc.y:3869
 		{
 			yyVAL.optKeyVals = yyDollar[1].optKeyVals
 		}
-	case 523:
+	case 830:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2201
+//line yacc.y:3873
 		{
 			yyVAL.optKeyVals = OptionKeyValues{yyDollar[1].optKeyVal}
 		}
-	case 524:
+	case 831:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2203
+//line yacc.y:3875
 		{
 			yyVAL.optKeyVals = append(yyDollar[1].optKeyVals, yyDollar[2].optKeyVal)
 		}
-	case 525:
+	case 832:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:2207
+//line yacc.y:3879
 		{
 			yyVAL.optKeyVal = &OptionKeyValue{Key: "engine", Value: string(yyDollar[3].bytes)}
 		}
-	case 526:
+	case 833:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:2211
+//line yacc.y:3883
 		{
 			yyVAL.optKeyVal = &OptionKeyValue{Key: "auto_increment", Value: string(yyDollar[3].bytes)}
 		}
-	case 527:
+	case 834:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:2215
+//line yacc.y:3887
 		{
 			yyVAL.optKeyVal = &OptionKeyValue{Key: "charset", Value: string(yyDollar[5].bytes)}
 		}
-	case 528:
+	case 835:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:2219
+//line yacc.y:3891
 		{
 			yyVAL.optKeyVal = &OptionKeyValue{Key: "charset", Value: string(yyDollar[4].bytes)}
 		}
-	case 529:
+	case 836:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:2223
+//line yacc.y:3895
 		{
 			yyVAL.optKeyVal = &OptionKeyValue{Key: "charset", Value: string(yyDollar[4].bytes)}
 		}
-	case 530:
+	case 837:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:2227
+//line yacc.y:3899
 		{
 			yyVAL.optKeyVal = &OptionKeyValue{Key: "charset", Value: string(yyDollar[3].bytes)}
 		}
-	case 531:
+	case 838:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:2231
+//line yacc.y:3903
 		{
 			yyVAL.optKeyVal = &OptionKeyValue{Key: "collate", Value: string(yyDollar[4].bytes)}
 		}
-	case 532:
+	case 839:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:2235
+//line yacc.y:3907
 		{
 			yyVAL.optKeyVal = &OptionKeyValue{Key: "collate", Value: string(yyDollar[3].bytes)}
 		}
-	case 533:
+	case 840:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:2239
+//line yacc.y:3911
 		{
 			yyVAL.optKeyVal = &OptionKeyValue{Key: "comment", Value: String(StrVal(yyDollar[3].bytes))}
 		}
-	case 534:
+	case 841:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:3916
+		{
+			yyVAL.partitionOpts = nil
+		}
+	case 842:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:3918
+		{
+			yyVAL.partitionOpts = yyDollar[1].partitionOpts
+		}
+	case 843:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:3922
+		{
+			yyVAL.partitionOpts = yyDollar[3].partitionOpts
+			yyVAL.partitionOpts.Partitions = yyDollar[4].bytes
+			yyVAL.partitionOpts.Definitions = yyDollar[5].partitionDefs
+		}
+	case 844:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:3930
+		{
+			yyVAL.partitionOpts = &PartitionOptions{Expr: yyDollar[3].valExpr}
+			switch {
+			case bytes.EqualFold(yyDollar[1].bytes, RANGE_BYTES):
+				yyVAL.partitionOpts.Type = AST_PARTITION_RANGE
+			case bytes.EqualFold(yyDollar[1].bytes, LIST_BYTES):
+				yyVAL.partitionOpts.Type = AST_PARTITION_LIST
+			default:
+				yylex.Error("expecting range or list")
+				return 1
+			}
+		}
+	case 845:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:3943
+		{
+			yyVAL.partitionOpts = &PartitionOptions{Type: AST_PARTITION_HASH, Expr: yyDollar[3].valExpr}
+		}
+	case 846:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:3947
+		{
+			yyVAL.partitionOpts = &PartitionOptions{Columns: yyDollar[4].columns}
+			switch {
+			case bytes.EqualFold(yyDollar[1].bytes, RANGE_BYTES):
+				yyVAL.partitionOpts.Type = AST_PARTITION_RANGE
+			case bytes.EqualFold(yyDollar[1].bytes, LIST_BYTES):
+				yyVAL.partitionOpts.Type = AST_PARTITION_LIST
+			default:
+				yylex.Error("expecting range or list")
+				return 1
+			}
+		}
+	case 847:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:3960
+		{
+			if !bytes.EqualFold(yyDollar[1].bytes, LINEAR_BYTES) {
+				yylex.Error("expecting linear hash")
+				return 1
+			}
+			yyVAL.partitionOpts = &PartitionOptions{Linear: true, Type: AST_PARTITION_HASH, Expr: yyDollar[4].valExpr}
+		}
+	case 848:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:3968
+		{
+			yyVAL.partitionOpts = &PartitionOptions{Type: AST_PARTITION_KEY}
+		}
+	case 849:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:3972
+		{
+			yyVAL.partitionOpts = &PartitionOptions{Type: AST_PARTITION_KEY, Columns: yyDollar[3].columns}
+		}
+	case 850:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:3976
+		{
+			if !bytes.EqualFold(yyDollar[1].bytes, LINEAR_BYTES) {
+				yylex.Error("expecting linear key")
+				return 1
+			}
+			yyVAL.partitionOpts = &PartitionOptions{Linear: true, Type: AST_PARTITION_KEY}
+		}
+	case 851:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:3984
+		{
+			if !bytes.EqualFold(yyDollar[1].bytes, LINEAR_BYTES) {
+				yylex.Error("expecting linear key")
+				return 1
+			}
+			yyVAL.partitionOpts = &PartitionOptions{Linear: true, Type: AST_PARTITION_KEY, Columns: yyDollar[4].columns}
+		}
+	case 852:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:3993
+		{
+			yyVAL.bytes = nil
+		}
+	case 853:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:3995
+		{
+			if !bytes.EqualFold(yyDollar[1].bytes, PARTITIONS_BYTES) {
+				yylex.Error("expecting partitions")
+				return 1
+			}
+			yyVAL.bytes = yyDollar[2].bytes
+		}
+	case 854:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line yacc.y:4004
+		{
+			yyVAL.partitionDefs = nil
+		}
+	case 855:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:4006
+		{
+			yyVAL.partitionDefs = yyDollar[2].partitionDefs
+		}
+	case 856:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line yacc.y:4010
+		{
+			yyVAL.partitionDefs = []*PartitionDefinition{yyDollar[1].partitionDef}
+		}
+	case 857:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:4012
+		{
+			yyVAL.partitionDefs = append(yyDollar[1].partitionDefs, yyDollar[3].partitionDef)
+		}
+	case 858:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:4016
+		{
+			yyVAL.partitionDef = &PartitionDefinition{Name: yyDollar[2].bytes, Options: yyDollar[3].optKeyVals}
+		}
+	case 859:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line yacc.y:4020
+		{
+			if !bytes.EqualFold(yyDollar[4].bytes, LESS_BYTES) || !bytes.EqualFold(yyDollar[5].bytes, THAN_BYTES) {
+				yylex.Error("expecting less than")
+				return 1
+			}
+			yyVAL.partitionDef = &PartitionDefinition{Name: yyDollar[2].bytes, Values: AST_VALUES_LESS_THAN, Tuple: yyDollar[7].valExprs, Options: yyDollar[9].optKeyVals}
+		}
+	case 860:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line yacc.y:4028
+		{
+			if !bytes.EqualFold(yyDollar[4].bytes, LESS_BYTES) || !bytes.EqualFold(yyDollar[5].bytes, THAN_BYTES) || !bytes.EqualFold(yyDollar[6].bytes, MAXVALUE_BYTES) {
+				yylex.Error("expecting less than maxvalue")
+				return 1
+			}
+			yyVAL.partitionDef = &PartitionDefinition{Name: yyDollar[2].bytes, Values: AST_VALUES_LESS_THAN, MaxValue: true, Options: yyDollar[7].optKeyVals}
+		}
+	case 861:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line yacc.y:4036
+		{
+			yyVAL.partitionDef = &PartitionDefinition{Name: yyDollar[2].bytes, Values: AST_VALUES_IN, Tuple: yyDollar[6].valExprs, Options: yyDollar[8].optKeyVals}
+		}
+	case 862:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2244
+//line yacc.y:4041
 		{
 			yyVAL.alterSpecs = nil
 		}
-	case 535:
+	case 863:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2246
+//line yacc.y:4043
 		{
 			yyVAL.alterSpecs = yyDollar[1].alterSpecs
 		}
-	case 536:
+	case 864:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2250
+//line yacc.y:4047
 		{
 			yyVAL.alterSpecs = AlterSpecifications{yyDollar[1].alterSpec}
 		}
-	case 537:
+	case 865:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:2252
+//line yacc.y:4049
 		{
 			yyVAL.alterSpecs = append(yyDollar[1].alterSpecs, yyDollar[3].alterSpec)
 		}
-	case 538:
+	case 866:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2256
+//line yacc.y:4053
 		{
 			yyVAL.alterSpec = yyDollar[1].optKeyVals
 		}
-	case 539:
+	case 867:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:2260
+//line yacc.y:4057
 		{
 			yyVAL.alterSpec = &AddOrModifyColumnSpec{Action: "add", ColumnName: yyDollar[3].colName, ColumnDef: yyDollar[4].columnDef, FirstOrAfterColumn: yyDollar[5].fiOAfCol}
 		}
-	case 540:
+	case 868:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:2264
+//line yacc.y:4061
 		{
 			yyVAL.alterSpec = &AddIndexSpec{Name: yyDollar[3].bytes, IndexType: yyDollar[4].bytes, IndexColumns: yyDollar[6].idxColNames}
 		}
-	case 541:
+	case 869:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line yacc.y:2268
+//line yacc.y:4065
 		{
 			yyVAL.alterSpec = &AddIndexSpec{Name: yyDollar[3].bytes, IndexType: yyDollar[4].bytes, IndexColumns: yyDollar[6].idxColNames}
 		}
-	case 542:
+	case 870:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:2272
+//line yacc.y:4069
 		{
 			yyVAL.alterSpec = &AddPrimaryKeySpec{Symbol: yyDollar[2].bytes, IndexType: yyDollar[5].bytes, IndexColumns: yyDollar[7].idxColNames}
 		}
-	case 543:
+	case 871:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:2276
+//line yacc.y:4073
 		{
 			yyVAL.alterSpec = &AddUniqueIndexSpec{Symbol: yyDollar[2].bytes, Name: yyDollar[4].bytes, IndexType: yyDollar[5].bytes, IndexColumns: yyDollar[7].idxColNames}
 		}
-	case 544:
+	case 872:
 		yyDollar = yyS[yypt-9 : yypt+1]
-		//line yacc.y:2280
+//line yacc.y:4077
 		{
 			yyVAL.alterSpec = &AddUniqueIndexSpec{Symbol: yyDollar[2].bytes, Name: yyDollar[5].bytes, IndexType: yyDollar[6].bytes, IndexColumns: yyDollar[8].idxColNames}
 		}
-	case 545:
+	case 873:
 		yyDollar = yyS[yypt-9 : yypt+1]
-		//line yacc.y:2284
+//line yacc.y:4081
 		{
 			yyVAL.alterSpec = &AddUniqueIndexSpec{Symbol: yyDollar[2].bytes, Name: yyDollar[5].bytes, IndexType: yyDollar[6].bytes, IndexColumns: yyDollar[8].idxColNames}
 		}
-	case 546:
+	case 874:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line yacc.y:2288
+//line yacc.y:4085
 		{
 			yyVAL.alterSpec = &AddForeignKeySpec{Symbol: yyDollar[2].bytes, IndexColumns: yyDollar[6].idxColNames, ReferenceDef: yyDollar[8].bytes}
 		}
-	case 547:
+	case 875:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:4089
+		{
+			yyVAL.alterSpec = &AddCheckSpec{Check: yyDollar[2].checkConstraint}
+		}
+	case 876:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line yacc.y:2292
+//line yacc.y:4093
 		{
 			yyVAL.alterSpec = &ChangeColumnSpec{OldColumnName: yyDollar[3].colName, ColumnName: yyDollar[4].colName, ColumnDef: yyDollar[5].columnDef, FirstOrAfterColumn: yyDollar[6].fiOAfCol}
 		}
-	case 548:
+	case 877:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line yacc.y:2296
+//line yacc.y:4097
 		{
 			yyVAL.alterSpec = &AddOrModifyColumnSpec{Action: "modify", ColumnName: yyDollar[3].colName, ColumnDef: yyDollar[4].columnDef, FirstOrAfterColumn: yyDollar[5].fiOAfCol}
 		}
-	case 549:
+	case 878:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:2300
+//line yacc.y:4101
 		{
 			yyVAL.alterSpec = &DropColumnSpec{ColumnName: yyDollar[3].colName}
 		}
-	case 550:
+	case 879:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:2304
+//line yacc.y:4105
 		{
 			yyVAL.alterSpec = &DropPrimaryKeySpec{}
 		}
-	case 551:
+	case 880:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:2308
+//line yacc.y:4109
 		{
 			yyVAL.alterSpec = &DropIndexSpec{Name: yyDollar[3].bytes}
 		}
-	case 552:
+	case 881:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line yacc.y:2312
+//line yacc.y:4113
 		{
 			yyVAL.alterSpec = &DropIndexSpec{Name: yyDollar[3].bytes}
 		}
-	case 553:
+	case 882:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line yacc.y:2316
+//line yacc.y:4117
 		{
 			yyVAL.alterSpec = &DropForeignKeySpec{Name: yyDollar[4].bytes}
 		}
-	case 554:
+	case 883:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:4121
+		{
+			yyVAL.alterSpec = &DropCheckSpec{Type: AST_CHECK_CONSTRAINT, Name: yyDollar[3].bytes}
+		}
+	case 884:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:4125
+		{
+			yyVAL.alterSpec = &DropCheckSpec{Type: AST_CONSTRAINT, Name: yyDollar[3].bytes}
+		}
+	case 885:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:4129
+		{
+			yyVAL.alterSpec = &AlterCheckSpec{Type: AST_CHECK_CONSTRAINT, Name: yyDollar[3].bytes, Enforced: yyDollar[4].str}
+		}
+	case 886:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line yacc.y:4133
+		{
+			yyVAL.alterSpec = &AlterCheckSpec{Type: AST_CONSTRAINT, Name: yyDollar[3].bytes, Enforced: yyDollar[4].str}
+		}
+	case 887:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line yacc.y:4137
+		{
+			yyVAL.alterSpec = &AddPartitionSpec{Definitions: yyDollar[4].partitionDefs}
+		}
+	case 888:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:4141
+		{
+			yyVAL.alterSpec = &DropPartitionSpec{Action: AST_DROP_PARTITION, Names: yyDollar[3].bytes2}
+		}
+	case 889:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line yacc.y:4145
+		{
+			yyVAL.alterSpec = &DropPartitionSpec{Action: AST_TRUNCATE_PARTITION, Names: yyDollar[3].bytes2}
+		}
+	case 890:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line yacc.y:4149
+		{
+			if !bytes.EqualFold(yyDollar[1].bytes, REMOVE_BYTES) || !bytes.EqualFold(yyDollar[2].bytes, PARTITIONING_BYTES) {
+				yylex.Error("expecting remove partitioning")
+				return 1
+			}
+			yyVAL.alterSpec = &RemovePartitioningSpec{}
+		}
+	case 891:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2320
+//line yacc.y:4157
 		{
 			yyVAL.alterSpec = &DisableKeysSpec{}
 		}
-	case 555:
+	case 892:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2324
+//line yacc.y:4161
 		{
 			yyVAL.alterSpec = &EnableKeysSpec{}
 		}
-	case 556:
+	case 893:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line yacc.y:2329
+//line yacc.y:4166
 		{
 			yyVAL.fiOAfCol = nil
 		}
-	case 557:
+	case 894:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line yacc.y:2331
+//line yacc.y:4168
 		{
 			yyVAL.fiOAfCol = yyDollar[1].fiOAfCol
 		}
-	case 558:
+	case 895:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2335
+//line yacc.y:4172
 		{
 			yyVAL.fiOAfCol = &FirstOrAfterColumn{FirstOrAfter: "first", ColumnName: yyDollar[2].colName}
 		}
-	case 559:
+	case 896:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line yacc.y:2339
+//line yacc.y:4176
 		{
 			yyVAL.fiOAfCol = &FirstOrAfterColumn{FirstOrAfter: "after", ColumnName: yyDollar[2].colName}
 		}
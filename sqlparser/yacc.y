@@ -27,7 +27,10 @@
 
 package sqlparser
 
-import "bytes"
+import (
+  "bytes"
+  "strings"
+)
 
 // SetParseTree to build ast.
 func SetParseTree(yylex interface{}, stmt Statement) {
@@ -49,6 +52,63 @@ var (
   MODE  =        []byte("mode")
   IF_BYTES =     []byte("if")
   VALUES_BYTES = []byte("values")
+  MOD_BYTES = []byte("mod")
+  TENANT =       []byte("tenant")
+  DATA_BYTES =   []byte("data")
+  ROWS_BYTES =   []byte("rows")
+  AT_BYTES =     []byte("@")
+  USER_BYTES =   []byte("user")
+  PASSWORD_BYTES = []byte("password")
+  PREFLIGHT_BYTES = []byte("preflight")
+  AUDIT_BYTES = []byte("audit")
+  CURRENT_USER_BYTES = []byte("current_user")
+  LOCAL_BYTES = []byte("local")
+  USERS_BYTES = []byte("users")
+  SCHEMAS_BYTES = []byte("schemas")
+  ONLY_BYTES = []byte("only")
+  WRITE_BYTES = []byte("write")
+  MIGRATION_BYTES = []byte("migration")
+  FAILOVER_BYTES = []byte("failover")
+  DRILL_BYTES = []byte("drill")
+  STOP_BYTES = []byte("stop")
+  EXPORT_BYTES = []byte("export")
+  ROUTING_BYTES = []byte("routing")
+  ENFORCED_BYTES = []byte("enforced")
+  GENERATED_BYTES = []byte("generated")
+  ALWAYS_BYTES = []byte("always")
+  VIRTUAL_BYTES = []byte("virtual")
+  STORED_BYTES = []byte("stored")
+  SAASHARD_BYTES = []byte("saashard")
+  LAST_BYTES = []byte("last")
+  ROUTE_BYTES = []byte("route")
+  RANGE_BYTES = []byte("range")
+  LIST_BYTES = []byte("list")
+  LINEAR_BYTES = []byte("linear")
+  PARTITIONS_BYTES = []byte("partitions")
+  LESS_BYTES = []byte("less")
+  THAN_BYTES = []byte("than")
+  MAXVALUE_BYTES = []byte("maxvalue")
+  REMOVE_BYTES = []byte("remove")
+  PARTITIONING_BYTES = []byte("partitioning")
+  AGAINST_BYTES = []byte("against")
+  LANGUAGE_BYTES = []byte("language")
+  EXPANSION_BYTES = []byte("expansion")
+  CAST_BYTES = []byte("cast")
+  SIGNED_BYTES = []byte("signed")
+  // units of interval expression, year is keyword.
+  INTERVAL_UNITS = map[string]bool{
+    "microsecond": true, "second": true, "minute": true, "hour": true, "day": true,
+    "week": true, "month": true, "quarter": true,
+    "second_microsecond": true, "minute_microsecond": true, "minute_second": true,
+    "hour_microsecond": true, "hour_second": true, "hour_minute": true,
+    "day_microsecond": true, "day_second": true, "day_minute": true, "day_hour": true, "year_month": true,
+  }
+  // data types those are not keywords, json and spatial types.
+  ID_DATA_TYPES = map[string]bool{
+    "json": true, "geometry": true, "point": true, "linestring": true, "polygon": true,
+    "multipoint": true, "multilinestring": true, "multipolygon": true,
+    "geometrycollection": true, "geomcollection": true,
+  }
 )
 
 %}
@@ -73,6 +133,7 @@ var (
   tableExpr   TableExpr
   smTableExpr SimpleTableExpr
   tableName   *TableName
+  tableNames  TableNames
   indexHints  *IndexHints
   expr        Expr
   boolExpr    BoolExpr
@@ -82,6 +143,7 @@ var (
   values      Values
   subquery    *Subquery
   caseExpr    *CaseExpr
+  matchExpr   *MatchExpr
   funcExpr    *FuncExpr
   whens       []*When
   when        *When
@@ -91,6 +153,9 @@ var (
   insRows     InsertRows
   updateExprs UpdateExprs
   updateExpr  *UpdateExpr
+  setExprs    SetExprs
+  setExpr     *SetExpr
+  proxyUserOptions *ProxyUserOptions
   createDef   CreateDefinition
   createDefs  CreateDefinitions
   columnDef   *ColumnDefinition
@@ -102,37 +167,65 @@ var (
   fiOAfCol    *FirstOrAfterColumn
   alterSpecs  AlterSpecifications
   alterSpec   AlterSpecification
+  cte         *CommonTableExpr
+  ctes        []*CommonTableExpr
+  loadFields  *LoadFields
+  loadLines   *LoadLines
+  privilege   *Privilege
+  privileges  Privileges
+  privLevel   *PrivilegeLevel
+  account     *Account
+  accounts    Accounts
+  userSpec    *UserSpec
+  userSpecs   UserSpecs
+  authOption  *AuthOption
+  requireOpts RequireOptions
+  requireOpt  *RequireOption
+  tableLock   *TableLock
+  checkConstraint *CheckConstraint
+  partitionOpts *PartitionOptions
+  partitionDef *PartitionDefinition
+  partitionDefs []*PartitionDefinition
+  tableLocks  []*TableLock
 }
 
 %token LEX_ERROR
 %token <empty> SELECT INSERT UPDATE DELETE FROM WHERE GROUP HAVING ORDER BY LIMIT FOR
 %token <empty> ALL DISTINCT AS EXISTS NULL ASC DESC VALUES INTO DUPLICATE KEY DEFAULT SET LOCK
 %token <empty> SHOW EXPLAIN DESCRIBE
-%token <bytes> ID STRING NUMBER VALUE_ARG COMMENTS
+%token <bytes> ID STRING NUMBER VALUE_ARG COMMENTS INTRODUCER HEX_LITERAL BIT_LITERAL NCHAR_STRING
 %token <empty> '(' '~'
 
+%nonassoc <empty> WITH
 %left <empty> UNION MINUS EXCEPT INTERSECT
+%nonassoc <empty> LOWER_THAN_COMMA
 %left <empty> ','
 %left <empty> FULL JOIN STRAIGHT_JOIN LEFT RIGHT INNER OUTER CROSS NATURAL USE FORCE
 %left <empty> ON
+%right <empty> ASSIGN
 %left <empty> OR
+%left <empty> XOR
 %left <empty> AND
 %right <empty> NOT
 %left <empty> BETWEEN CASE WHEN THEN ELSE
 //REGEXP 
-%left <empty> '=' '<' '>' LE GE NE NULL_SAFE_EQUAL IS LIKE IN
+%left <empty> '=' '<' '>' LE GE NE NULL_SAFE_EQUAL IS LIKE IN REGEXP
 %left <empty> '|'
 %left <empty> '&'
 //%left <empty> SHIFT_LEFT SHIFT_RIGHT
 %left <empty> '+' '-'
-%left <empty> '*' '/' '%'
+%left <empty> '*' '/' '%' DIV MOD
 %left <empty> '^'
+%left <empty> PIPE_CONCAT
+%left <empty> COLLATE
 %nonassoc <empty> '.'
 %left <empty> UNARY
 
 %left <empty> END
+%token <empty> INTERVAL CONVERT
 
 // Transaction Tokens
+%token <empty> UNLOCK SAVEPOINT RELEASE
 %token <empty> BEGIN START TRANSACTION COMMIT ROLLBACK ISOLATION LEVEL READ COMMITTED UNCOMMITTED REPEATABLE SERIALIZABLE
 
 // Charset Tokens
@@ -152,7 +245,7 @@ var (
 %token <empty> LATIN1_GENERAL_CI LATIN1_GENERAL_CS LATIN1_BIN LATIN2_GENERAL_CI LATIN2_BIN LATIN5_TURKISH_CI LATIN5_BIN LATIN7_GENERAL_CI LATIN7_GENERAL_CS LATIN7_BIN
 %token <empty> MACCE_GENERAL_CI MACCE_BIN MACROMAN_GENERAL_CI MACROMAN_BIN SJIS_JAPANESE_CI SJIS_BIN SWE7_SWEDISH_CI SWE7_BIN TIS620_THAI_CI TIS620_BIN
 %token <empty> UCS2_GENERAL_CI UCS2_UNICODE_CI UCS2_BIN UJIS_JAPANESE_CI UJIS_BIN UTF16_GENERAL_CI UTF16_UNICODE_CI UTF16_BIN UTF16LE_GENERAL_CI UTF16LE_BIN
-%token <empty> UTF32_GENERAL_CI UTF32_UNICODE_CI UTF32_BIN UTF8_GENERAL_CI UTF8_UNICODE_CI UTF8_BIN UTF8MB4_GENERAL_CI UTF8MB4_UNICODE_CI UTF8MB4_BIN
+%token <empty> UTF32_GENERAL_CI UTF32_UNICODE_CI UTF32_BIN UTF8_GENERAL_CI UTF8_UNICODE_CI UTF8_BIN UTF8MB4_GENERAL_CI UTF8MB4_UNICODE_CI UTF8MB4_BIN UTF8MB4_0900_AI_CI UTF8MB4_0900_BIN
 
 
 // Scope Tokens
@@ -164,19 +257,37 @@ var (
 %token <empty> TABLES COLUMNS FIELDS PROCEDURE FUNCTION INDEXES KEYS TRIGGER TRIGGERS
 %token <empty> PLUGINS PROCESSLIST SLAVE
 %token <empty> PROFILES
+%token <empty> GRANTS WARNINGS ERRORS
 
 // Replace
 %token <empty> REPLACE
 
+// Call
+%token <empty> CALL
+
+// Prepared statement
+%token <empty> PREPARE EXECUTE DEALLOCATE
+
+// Grant
+%token <empty> GRANT REVOKE OPTION IDENTIFIED REQUIRE
+
+// Load data
+%token <empty> LOAD INFILE LOW_PRIORITY LINES STARTING TERMINATED OPTIONALLY ENCLOSED ESCAPED
+
 //offset
 %token <empty> OFFSET
-//collate
-%token <empty> COLLATE
+//separator of group_concat
+%token <empty> SEPARATOR
+//common table expression
+%token <empty> RECURSIVE
+//window function
+%token <empty> OVER PARTITION
+%token <empty> JSON_EXTRACT_OP JSON_UNQUOTE_EXTRACT_OP
 
 // DDL Tokens
-%token <empty> CREATE ALTER DROP RENAME
+%token <empty> CREATE ALTER DROP RENAME TRUNCATE
 %token <empty> TABLE INDEX VIEW TO IGNORE IF UNIQUE FULLTEXT USING
-%token <empty> BTREE HASH
+%token <empty> BTREE HASH ALGORITHM
 
 // Data Type Tokens
 %token <empty> BIT TINYINT BOOL BOOLEAN SMALLINT MEDIUMINT INT INTEGER BIGINT REAL DOUBLE FLOAT DECIMAL
@@ -197,7 +308,10 @@ var (
 %token <empty> ADD COLUMN CHANGE MODIFY
 %token <empty> ENABLE DISABLE
 
-%token <empty> KILL QUERY CONNECTION
+%token <empty> KILL QUERY CONNECTION RELOAD CLONE PROXY
+
+// Table maintenance
+%token <empty> ANALYZE OPTIMIZE CHECK REPAIR
 
 // Functin
 %token <empty> POSITION
@@ -206,12 +320,55 @@ var (
 
 %type <statement> command
 %type <selStmt> select_statement
+%type <boolean> recursive_opt
+%type <cte> cte
+%type <ctes> cte_list
 %type <setStmt> set_statement
 %type <showStmt> show_statement describe_statement
-%type <ddlStmt> create_statement alter_statement rename_statement drop_statement
-%type <statement> insert_statement update_statement delete_statement replace_statement 
-%type <statement> begin_statement commit_statement rollback_statement 
+%type <ddlStmt> create_statement alter_statement rename_statement drop_statement truncate_statement
+%type <statement> insert_statement update_statement delete_statement replace_statement call_statement
+%type <valExprs> call_args_opt
+%type <statement> lock_statement unlock_statement
+%type <tableLock> table_lock
+%type <checkConstraint> check_constraint check_constraint_opt
+%type <str> enforced enforced_opt
+%type <str> generated_storage_opt
+%type <partitionOpts> partition_options partition_options_opt partition_type
+%type <partitionDef> partition_definition
+%type <partitionDefs> partition_definition_list partition_definitions_opt
+%type <bytes> partition_count_opt
+%type <tableLocks> table_lock_list
+%type <str> table_lock_type
+%type <statement> begin_statement commit_statement rollback_statement savepoint_statement 
 %type <statement> use_statement explain_statement admin_statement
+%type <statement> prepare_statement execute_statement deallocate_statement
+%type <valExprs> using_opt
+%type <statement> load_statement
+%type <statement> maintenance_statement
+%type <bytes> maintenance_modifier_opt
+%type <bytes2> maintenance_option_list
+%type <statement> grant_statement revoke_statement
+%type <privileges> privilege_list
+%type <privilege> privilege
+%type <bytes> privilege_words privilege_word
+%type <str> privilege_object_opt
+%type <privLevel> privilege_level
+%type <accounts> account_list
+%type <account> account
+%type <boolean> grant_option_opt
+%type <statement> create_user_statement alter_user_statement drop_user_statement
+%type <userSpecs> user_spec_list
+%type <userSpec> user_spec
+%type <authOption> auth_option_opt
+%type <requireOpts> require_opt require_list
+%type <requireOpt> require_option
+%type <bytes2> load_modifier_list
+%type <str> load_duplicate_opt
+%type <bytes> load_charset_opt
+%type <loadFields> load_fields_opt load_field_list
+%type <loadLines> load_lines_opt load_line_list
+%type <valExpr> load_ignore_opt
+%type <updateExprs> load_set_opt
 
 %type <bytes2> comments_list_opt comments_list
 %type <str> union_op
@@ -226,6 +383,7 @@ var (
 %type <str> join_type
 %type <smTableExpr> simple_table_expression
 %type <tableName> table_name
+%type <tableNames> table_name_list
 %type <indexHints> index_hint_list
 %type <bytes2> sql_id_list
 %type <boolExpr> where_expression_opt
@@ -237,13 +395,18 @@ var (
 %type <valExprs> value_expression_list
 %type <values> tuple_list
 %type <bytes> keyword_as_func
+%type <bytes> separator_opt
 %type <subquery> subquery
 %type <byt> unary_operator
 %type <colName> column_name
 %type <caseExpr> case_expression
+%type <matchExpr> match_expression
+%type <str> match_modifier_opt
+%type <str> convert_type convert_length_opt
 %type <whens> when_expression_list
 %type <when> when_expression
 %type <funcExpr> function_expression
+%type <valExprs> partition_by_opt
 %type <valExpr> value_expression_opt else_expression_opt
 %type <valExprs> group_by_opt
 %type <boolExpr> having_opt
@@ -255,15 +418,22 @@ var (
 %type <columns> column_list_opt column_list
 %type <updateExprs> on_dup_opt
 %type <updateExprs> update_list
+%type <setExprs> set_list
+%type <setExpr> set_expression
+%type <bytes> proxy_user_name
+%type <proxyUserOptions> proxy_user_option_list
+%type <valExpr> set_value
 %type <updateExpr> update_expression
 %type <expr> where_or_like_opt
-%type <empty> exists_opt not_exists_opt
+%type <boolean> exists_opt not_exists_opt
+%type <bytes> non_reserved_keyword
 %type <bytes> index_category_opt index_type index_type_opt index_option_opt
 %type <idxColName> index_column_name
 %type <idxColNames> index_column_list
 %type <bytes> sql_id
 // %type <empty> force_eof
 %type <bytes> charset_words collate_words
+%type <bytes> collate_name
 %type <bytes> isolation_level
 %type <bytes> scope_opt
 
@@ -283,6 +453,8 @@ var (
 %type <bytes> constraint_opt
 %type <optKeyVal> table_option
 %type <optKeyVals> table_option_list table_option_list_opt
+%type <optKeyVal> lock_algorithm_option
+%type <optKeyVals> lock_algorithm_option_list lock_algorithm_option_list_opt
 
 %type <fiOAfCol> first_or_after_column first_or_after_column_opt
 
@@ -308,6 +480,16 @@ command:
 | update_statement
 | delete_statement
 | replace_statement
+| call_statement
+| prepare_statement
+| execute_statement
+| deallocate_statement
+| load_statement
+| grant_statement
+| revoke_statement
+| create_user_statement
+| alter_user_statement
+| drop_user_statement
 | explain_statement
 | create_statement
   { $$ = $1 }
@@ -317,9 +499,15 @@ command:
   { $$ = $1 }
 | drop_statement
   { $$ = $1 }
+| truncate_statement
+  { $$ = $1 }
+| maintenance_statement
+| lock_statement
+| unlock_statement
 | begin_statement
 | commit_statement
 | rollback_statement
+| savepoint_statement
 | use_statement
 | admin_statement
 | comments_list
@@ -338,6 +526,43 @@ select_statement:
   {
     $$ = &Union{Type: $2, Left: $1, Right: $3}
   }
+| WITH recursive_opt cte_list select_statement
+  {
+    if !SetWith($4, &With{Recursive: $2, CTEs: $3}) {
+      yylex.Error("expecting select from table after with")
+      return 1
+    }
+    $$ = $4
+  }
+
+recursive_opt:
+  {
+    $$ = false
+  }
+| RECURSIVE
+  {
+    $$ = true
+  }
+
+cte_list:
+  cte
+  {
+    $$ = []*CommonTableExpr{$1}
+  }
+| cte_list ',' cte
+  {
+    $$ = append($1, $3)
+  }
+
+cte:
+  sql_id AS '(' select_statement ')'
+  {
+    $$ = &CommonTableExpr{Name: $1, Select: $4}
+  }
+| sql_id '(' sql_id_list ')' AS '(' select_statement ')'
+  {
+    $$ = &CommonTableExpr{Name: $1, Columns: $3, Select: $7}
+  }
 
 insert_statement:
   INSERT comments_list_opt ignore_opt INTO table_name column_list_opt row_list on_dup_opt
@@ -371,6 +596,483 @@ replace_statement:
     $$ = &Replace{Comments: Comments($2), Table: $4, Columns: cols, Rows: Values{vals}}
   }
 
+call_statement:
+  CALL comments_list_opt table_name call_args_opt
+  {
+    $$ = &Call{Comments: Comments($2), Name: $3, Args: $4}
+  }
+
+call_args_opt:
+  {
+    $$ = nil
+  }
+| '(' ')'
+  {
+    $$ = nil
+  }
+| '(' value_expression_list ')'
+  {
+    $$ = $2
+  }
+
+load_statement:
+  LOAD comments_list_opt sql_id load_modifier_list INFILE STRING load_duplicate_opt INTO TABLE table_name load_charset_opt load_fields_opt load_lines_opt load_ignore_opt column_list_opt load_set_opt
+  {
+    if !bytes.Equal($3, DATA_BYTES) {
+      yylex.Error("expecting data")
+      return 1
+    }
+    load := &LoadData{Comments: Comments($2), File: StrVal($6), Duplicate: $7, Table: $10, Charset: $11, Fields: $12, Lines: $13, IgnoreRows: $14, Columns: $15, Set: $16}
+    if !load.setModifiers($4) {
+      yylex.Error("expecting low_priority, concurrent or local")
+      return 1
+    }
+    $$ = load
+  }
+
+load_modifier_list:
+  {
+    $$ = nil
+  }
+| load_modifier_list LOW_PRIORITY
+  {
+    $$ = append($1, []byte(AST_LOW_PRIORITY))
+  }
+| load_modifier_list sql_id
+  {
+    $$ = append($1, $2)
+  }
+
+load_duplicate_opt:
+  {
+    $$ = ""
+  }
+| REPLACE
+  {
+    $$ = AST_REPLACE
+  }
+| IGNORE
+  {
+    $$ = AST_IGNORE
+  }
+
+load_charset_opt:
+  {
+    $$ = nil
+  }
+| CHARACTER SET charset_words
+  {
+    $$ = $3
+  }
+| CHARSET charset_words
+  {
+    $$ = $2
+  }
+
+load_fields_opt:
+  {
+    $$ = nil
+  }
+| FIELDS load_field_list
+  {
+    $$ = $2
+  }
+| COLUMNS load_field_list
+  {
+    $$ = $2
+  }
+
+load_field_list:
+  {
+    $$ = &LoadFields{}
+  }
+| load_field_list TERMINATED BY STRING
+  {
+    $1.Terminated = StrVal($4)
+    $$ = $1
+  }
+| load_field_list ENCLOSED BY STRING
+  {
+    $1.Enclosed = StrVal($4)
+    $$ = $1
+  }
+| load_field_list OPTIONALLY ENCLOSED BY STRING
+  {
+    $1.Enclosed = StrVal($5)
+    $1.Optionally = true
+    $$ = $1
+  }
+| load_field_list ESCAPED BY STRING
+  {
+    $1.Escaped = StrVal($4)
+    $$ = $1
+  }
+
+load_lines_opt:
+  {
+    $$ = nil
+  }
+| LINES load_line_list
+  {
+    $$ = $2
+  }
+
+load_line_list:
+  {
+    $$ = &LoadLines{}
+  }
+| load_line_list STARTING BY STRING
+  {
+    $1.Starting = StrVal($4)
+    $$ = $1
+  }
+| load_line_list TERMINATED BY STRING
+  {
+    $1.Terminated = StrVal($4)
+    $$ = $1
+  }
+
+load_ignore_opt:
+  {
+    $$ = nil
+  }
+| IGNORE NUMBER LINES
+  {
+    $$ = NumVal($2)
+  }
+| IGNORE NUMBER sql_id
+  {
+    if !bytes.Equal($3, ROWS_BYTES) {
+      yylex.Error("expecting lines or rows")
+      return 1
+    }
+    $$ = NumVal($2)
+  }
+
+load_set_opt:
+  {
+    $$ = nil
+  }
+| SET update_list
+  {
+    $$ = $2
+  }
+
+create_user_statement:
+  CREATE comments_list_opt ID not_exists_opt user_spec_list require_opt
+  {
+    if !bytes.EqualFold($3, USER_BYTES) {
+      yylex.Error("expecting user")
+      return 1
+    }
+    $$ = &CreateUser{Comments: Comments($2), IfNotExists: $4, Users: $5, Require: $6}
+  }
+
+alter_user_statement:
+  ALTER comments_list_opt ID exists_opt user_spec_list require_opt
+  {
+    if !bytes.EqualFold($3, USER_BYTES) {
+      yylex.Error("expecting user")
+      return 1
+    }
+    $$ = &AlterUser{Comments: Comments($2), IfExists: $4, Users: $5, Require: $6}
+  }
+
+drop_user_statement:
+  DROP comments_list_opt ID exists_opt account_list
+  {
+    if !bytes.EqualFold($3, USER_BYTES) {
+      yylex.Error("expecting user")
+      return 1
+    }
+    $$ = &DropUser{Comments: Comments($2), IfExists: $4, Accounts: $5}
+  }
+
+user_spec_list:
+  user_spec
+  {
+    $$ = UserSpecs{$1}
+  }
+| user_spec_list ',' user_spec
+  {
+    $$ = append($1, $3)
+  }
+
+user_spec:
+  account auth_option_opt
+  {
+    $$ = &UserSpec{Account: $1, Auth: $2}
+  }
+
+auth_option_opt:
+  {
+    $$ = nil
+  }
+| IDENTIFIED BY STRING
+  {
+    $$ = &AuthOption{Password: StrVal($3)}
+  }
+| IDENTIFIED BY sql_id STRING
+  {
+    if !bytes.Equal($3, PASSWORD_BYTES) {
+      yylex.Error("expecting password")
+      return 1
+    }
+    $$ = &AuthOption{Password: StrVal($4), Hashed: true}
+  }
+| IDENTIFIED WITH sql_id
+  {
+    $$ = &AuthOption{Plugin: $3}
+  }
+| IDENTIFIED WITH sql_id BY STRING
+  {
+    $$ = &AuthOption{Plugin: $3, Password: StrVal($5)}
+  }
+| IDENTIFIED WITH sql_id AS STRING
+  {
+    $$ = &AuthOption{Plugin: $3, Password: StrVal($5), Hashed: true}
+  }
+
+require_opt:
+  {
+    $$ = nil
+  }
+| REQUIRE require_list
+  {
+    $$ = $2
+  }
+
+require_list:
+  require_option
+  {
+    $$ = RequireOptions{$1}
+  }
+| require_list require_option
+  {
+    $$ = append($1, $2)
+  }
+| require_list AND require_option
+  {
+    $$ = append($1, $3)
+  }
+
+require_option:
+  sql_id
+  {
+    if !IsRequireOption($1, false) {
+      yylex.Error("expecting none, ssl or x509")
+      return 1
+    }
+    $$ = &RequireOption{Name: string($1)}
+  }
+| sql_id STRING
+  {
+    if !IsRequireOption($1, true) {
+      yylex.Error("expecting cipher, issuer or subject")
+      return 1
+    }
+    $$ = &RequireOption{Name: string($1), Value: StrVal($2)}
+  }
+
+grant_statement:
+  GRANT comments_list_opt privilege_list ON privilege_object_opt privilege_level TO account_list grant_option_opt
+  {
+    $$ = &Grant{Comments: Comments($2), Privileges: $3, ObjectType: $5, Level: $6, Accounts: $8, GrantOption: $9}
+  }
+
+revoke_statement:
+  REVOKE comments_list_opt privilege_list ON privilege_object_opt privilege_level FROM account_list
+  {
+    $$ = &Revoke{Comments: Comments($2), Privileges: $3, ObjectType: $5, Level: $6, Accounts: $8}
+  }
+
+privilege_list:
+  privilege
+  {
+    $$ = Privileges{$1}
+  }
+| privilege_list ',' privilege
+  {
+    $$ = append($1, $3)
+  }
+
+privilege:
+  privilege_words column_list_opt
+  {
+    $$ = &Privilege{Name: string($1), Columns: $2}
+  }
+
+privilege_words:
+  privilege_word
+  {
+    $$ = $1
+  }
+| privilege_words privilege_word
+  {
+    $$ = append(append($1, ' '), $2...)
+  }
+
+privilege_word:
+  sql_id
+  { $$ = $1 }
+| ALL
+  { $$ = []byte("all") }
+| SELECT
+  { $$ = []byte("select") }
+| INSERT
+  { $$ = []byte("insert") }
+| UPDATE
+  { $$ = []byte("update") }
+| DELETE
+  { $$ = []byte("delete") }
+| CREATE
+  { $$ = []byte("create") }
+| ALTER
+  { $$ = []byte("alter") }
+| DROP
+  { $$ = []byte("drop") }
+| INDEX
+  { $$ = []byte("index") }
+| REFERENCES
+  { $$ = []byte("references") }
+| SHOW
+  { $$ = []byte("show") }
+| VIEW
+  { $$ = []byte("view") }
+| TABLES
+  { $$ = []byte("tables") }
+| DATABASES
+  { $$ = []byte("databases") }
+| LOCK
+  { $$ = []byte("lock") }
+| TRIGGER
+  { $$ = []byte("trigger") }
+| PROCESSLIST
+  { $$ = []byte("processlist") }
+| SLAVE
+  { $$ = []byte("slave") }
+| GRANT
+  { $$ = []byte("grant") }
+
+privilege_object_opt:
+  { $$ = "" }
+| TABLE
+  { $$ = AST_TABLE }
+| FUNCTION
+  { $$ = AST_FUNCTION }
+| PROCEDURE
+  { $$ = AST_PROCEDURE }
+
+privilege_level:
+  '*'
+  {
+    $$ = &PrivilegeLevel{Name: []byte("*")}
+  }
+| '*' '.' '*'
+  {
+    $$ = &PrivilegeLevel{Qualifier: []byte("*"), Name: []byte("*")}
+  }
+| sql_id
+  {
+    $$ = &PrivilegeLevel{Name: $1}
+  }
+| sql_id '.' '*'
+  {
+    $$ = &PrivilegeLevel{Qualifier: $1, Name: []byte("*")}
+  }
+| sql_id '.' sql_id
+  {
+    $$ = &PrivilegeLevel{Qualifier: $1, Name: $3}
+  }
+
+account_list:
+  account
+  {
+    $$ = Accounts{$1}
+  }
+| account_list ',' account
+  {
+    $$ = append($1, $3)
+  }
+
+account:
+  STRING
+  {
+    $$ = &Account{User: $1}
+  }
+| STRING ID
+  {
+    if len($2) < 2 || $2[0] != '@' {
+      yylex.Error("expecting @host")
+      return 1
+    }
+    $$ = &Account{User: $1, Host: $2[1:]}
+  }
+| STRING ID STRING
+  {
+    if !bytes.Equal($2, AT_BYTES) {
+      yylex.Error("expecting @")
+      return 1
+    }
+    $$ = &Account{User: $1, Host: $3}
+  }
+| ID
+  {
+    $$ = NewAccount($1)
+  }
+| ID STRING
+  {
+    if len($1) < 2 || $1[len($1)-1] != '@' {
+      yylex.Error("expecting user@")
+      return 1
+    }
+    $$ = &Account{User: $1[:len($1)-1], Host: $2}
+  }
+
+grant_option_opt:
+  {
+    $$ = false
+  }
+| WITH GRANT OPTION
+  {
+    $$ = true
+  }
+
+prepare_statement:
+  PREPARE comments_list_opt sql_id FROM STRING
+  {
+    $$ = &Prepare{Comments: Comments($2), Name: $3, From: StrVal($5)}
+  }
+| PREPARE comments_list_opt sql_id FROM column_name
+  {
+    $$ = &Prepare{Comments: Comments($2), Name: $3, From: $5}
+  }
+
+execute_statement:
+  EXECUTE comments_list_opt sql_id using_opt
+  {
+    $$ = &Execute{Comments: Comments($2), Name: $3, Using: $4}
+  }
+
+using_opt:
+  {
+    $$ = nil
+  }
+| USING value_expression_list
+  {
+    $$ = $2
+  }
+
+deallocate_statement:
+  DEALLOCATE comments_list_opt PREPARE sql_id
+  {
+    $$ = &Deallocate{Comments: Comments($2), Name: $4}
+  }
+| DROP comments_list_opt PREPARE sql_id
+  {
+    $$ = &Deallocate{Comments: Comments($2), Name: $4}
+  }
+
 update_statement:
   UPDATE comments_list_opt table_name SET update_list where_expression_opt order_by_opt limit_opt
   {
@@ -434,7 +1136,7 @@ explain_statement:
   }
 
 set_statement:
-  SET comments_list_opt scope_opt update_list
+  SET comments_list_opt scope_opt set_list
   {
     $$ = &SetVariable{
           Comments: Comments($2), 
@@ -471,78 +1173,366 @@ set_statement:
           Collate: string($6),
 	    }
   }
-| SET comments_list_opt scope_opt TRANSACTION ISOLATION LEVEL isolation_level
+| SET comments_list_opt NAMES charset_words COLLATE collate_words
+  {
+    $$ = &SetNames{
+          Comments: Comments($2),
+          Names: string($4),
+          Collate: string($6),
+        }
+  }
+| SET comments_list_opt scope_opt TRANSACTION ISOLATION LEVEL isolation_level
+  {
+    $$ = &SetTransactionIsolationLevel{
+	        Comments: Comments($2),
+          Scope: string($3),
+	        IsolationLevel: string($7),
+	    }
+  }
+
+lock_statement:
+  LOCK comments_list_opt TABLES table_lock_list
+  {
+    $$ = &LockTables{Comments: Comments($2), Tables: $4}
+  }
+| LOCK comments_list_opt TABLE table_lock_list
+  {
+    $$ = &LockTables{Comments: Comments($2), Tables: $4}
+  }
+
+table_lock_list:
+  table_lock
+  {
+    $$ = []*TableLock{$1}
+  }
+| table_lock_list ',' table_lock
+  {
+    $$ = append($1, $3)
+  }
+
+table_lock:
+  table_name table_lock_type
+  {
+    $$ = &TableLock{Table: $1, Lock: $2}
+  }
+| table_name AS sql_id table_lock_type
+  {
+    $$ = &TableLock{Table: $1, As: $3, Lock: $4}
+  }
+| table_name ID table_lock_type
+  {
+    $$ = &TableLock{Table: $1, As: bytes.ToLower($2), Lock: $3}
+  }
+
+table_lock_type:
+  READ
+  {
+    $$ = AST_LOCK_READ
+  }
+| READ ID
+  {
+    if !bytes.EqualFold($2, LOCAL_BYTES) {
+      yylex.Error("expecting read local")
+      return 1
+    }
+    $$ = AST_LOCK_READ_LOCAL
+  }
+| ID
+  {
+    if !bytes.EqualFold($1, WRITE_BYTES) {
+      yylex.Error("expecting read or write")
+      return 1
+    }
+    $$ = AST_LOCK_WRITE
+  }
+
+unlock_statement:
+  UNLOCK comments_list_opt TABLES
+  {
+    $$ = &UnlockTables{Comments: Comments($2)}
+  }
+| UNLOCK comments_list_opt TABLE
+  {
+    $$ = &UnlockTables{Comments: Comments($2)}
+  }
+
+begin_statement:
+  BEGIN
+  {
+    $$ = &Begin{}
+  }
+| START TRANSACTION
+  {
+    $$ = &Begin{}
+  }
+
+commit_statement:
+  COMMIT
+  {
+    $$ = &Commit{}
+  }
+
+rollback_statement:
+  ROLLBACK
+  {
+    $$ = &Rollback{}
+  }
+| ROLLBACK TO sql_id
+  {
+    $$ = &RollbackSavepoint{Name: $3}
+  }
+| ROLLBACK TO SAVEPOINT sql_id
+  {
+    $$ = &RollbackSavepoint{Name: $4}
+  }
+
+savepoint_statement:
+  SAVEPOINT sql_id
+  {
+    $$ = &Savepoint{Name: $2}
+  }
+| RELEASE SAVEPOINT sql_id
+  {
+    $$ = &ReleaseSavepoint{Name: $3}
+  }
+
+use_statement:
+  USE sql_id
+  {
+	  $$ = &UseDB{DB : string($2)}
+  }
+
+
+admin_statement:
+  KILL NUMBER
+  {
+    $$ = &KillConnection{ConnectionID: NumVal($2)}
+  }
+| KILL CONNECTION NUMBER
+  {
+    $$ = &KillConnection{ConnectionID: NumVal($3)}
+  }
+| KILL QUERY NUMBER
+  {
+    $$ = &KillQuery{ConnectionID: NumVal($3)}
+  }
+| RELOAD sql_id
+  {
+    $$ = &Reload{Name: $2}
+  }
+| CLONE sql_id value FROM sql_id TO sql_id
+  {
+    if !bytes.Equal($2, TENANT) {
+      yylex.Error("expecting tenant")
+      return 1
+    }
+    $$ = &CloneTenant{Tenant: $3, From: $5, To: $7}
+  }
+| CREATE comments_list_opt PROXY ID not_exists_opt proxy_user_name proxy_user_option_list
+  {
+    if !bytes.EqualFold($4, USER_BYTES) {
+      yylex.Error("expecting user")
+      return 1
+    }
+    $$ = &CreateProxyUser{IfNotExists: $5, Name: $6, Options: $7}
+  }
+| ALTER comments_list_opt PROXY ID proxy_user_name proxy_user_option_list
+  {
+    if !bytes.EqualFold($4, USER_BYTES) {
+      yylex.Error("expecting user")
+      return 1
+    }
+    $$ = &AlterProxyUser{Name: $5, Options: $6}
+  }
+| DROP comments_list_opt PROXY ID exists_opt proxy_user_name
+  {
+    if !bytes.EqualFold($4, USER_BYTES) {
+      yylex.Error("expecting user")
+      return 1
+    }
+    $$ = &DropProxyUser{IfExists: $5, Name: $6}
+  }
+| SHOW PROXY ID
+  {
+    if !bytes.EqualFold($3, USERS_BYTES) {
+      yylex.Error("expecting users")
+      return 1
+    }
+    $$ = &ShowProxyUsers{}
+  }
+| START ID ID sql_id
+  {
+    if !bytes.EqualFold($2, FAILOVER_BYTES) || !bytes.EqualFold($3, DRILL_BYTES) {
+      yylex.Error("expecting failover drill")
+      return 1
+    }
+    $$ = &FailoverDrill{Action: AST_DRILL_START, Host: $4}
+  }
+| ID ID ID
+  {
+    if bytes.EqualFold($1, STOP_BYTES) && bytes.EqualFold($2, FAILOVER_BYTES) && bytes.EqualFold($3, DRILL_BYTES) {
+      $$ = &FailoverDrill{Action: AST_DRILL_STOP}
+    } else if bytes.EqualFold($1, EXPORT_BYTES) && bytes.EqualFold($2, ROUTING_BYTES) {
+      switch strings.ToLower(string($3)) {
+      case AST_EXPORT_JSON, AST_EXPORT_MARKDOWN:
+        $$ = &ExportRouting{Type: strings.ToLower(string($3))}
+      default:
+        yylex.Error("expecting json or markdown")
+        return 1
+      }
+    } else {
+      yylex.Error("syntax error")
+      return 1
+    }
+  }
+| SHOW ID ID
+  {
+    if !bytes.EqualFold($2, FAILOVER_BYTES) || !bytes.EqualFold($3, DRILL_BYTES) {
+      yylex.Error("syntax error")
+      return 1
+    }
+    $$ = &ShowFailoverDrill{}
+  }
+| SHOW ID
+  {
+    if bytes.EqualFold($2, PREFLIGHT_BYTES) {
+      $$ = &ShowPreflight{}
+    } else if bytes.EqualFold($2, AUDIT_BYTES) {
+      $$ = &ShowAudit{}
+    } else if bytes.EqualFold($2, MIGRATION_BYTES) {
+      $$ = &ShowMigration{}
+    } else {
+      // other show statements aren't supported.
+      yylex.Error("syntax error")
+      return 1
+    }
+  }
+
+proxy_user_name:
+  sql_id
+| STRING
+
+proxy_user_option_list:
+  {
+    $$ = &ProxyUserOptions{}
+  }
+| proxy_user_option_list IDENTIFIED BY STRING
+  {
+    $1.Identified, $1.Password = true, StrVal($4)
+    $$ = $1
+  }
+| proxy_user_option_list ID sql_id_list
+  {
+    if !bytes.EqualFold($2, SCHEMAS_BYTES) {
+      yylex.Error("expecting identified by, schemas or read")
+      return 1
+    }
+    $1.Schemas = $3
+    $$ = $1
+  }
+| proxy_user_option_list READ ID
   {
-    $$ = &SetTransactionIsolationLevel{
-	        Comments: Comments($2),
-          Scope: string($3),
-	        IsolationLevel: string($7),
-	    }
+    if bytes.EqualFold($3, ONLY_BYTES) {
+      $1.ReadOnly = AST_READ_ONLY
+    } else if bytes.EqualFold($3, WRITE_BYTES) {
+      $1.ReadOnly = AST_READ_WRITE
+    } else {
+      yylex.Error("expecting read only or read write")
+      return 1
+    }
+    $$ = $1
   }
 
-begin_statement:
-  BEGIN
+create_statement:
+  CREATE comments_list_opt TABLE not_exists_opt table_name '(' create_definition_list ')' table_option_list_opt partition_options_opt
   {
-    $$ = &Begin{}
+    $$ = &CreateTable{Comments : Comments($2), Table: $5, CreateDefs: $7, TableOptions: $9, Partition: $10 }
   }
-| START TRANSACTION
+| CREATE comments_list_opt index_category_opt INDEX sql_id index_type_opt ON table_name '(' index_column_list ')' index_option_opt lock_algorithm_option_list_opt
   {
-    $$ = &Begin{}
+    $$ = &CreateIndex{Comments : Comments($2), IndexCategory: $3, Name: $5, IndexType: $6, Table: $8, IndexColumns: $10, IndexOption: $12, LockAlgorithm: $13 }
   }
 
-commit_statement:
-  COMMIT
+alter_statement:
+  ALTER comments_list_opt ignore_opt TABLE table_name alter_specification_list_opt partition_options_opt
   {
-    $$ = &Commit{}
+    $$ = &AlterTable{Comments : Comments($2), Ignore: $3, Table: $5, AlterSpecs: $6, Partition: $7}
   }
 
-rollback_statement:
-  ROLLBACK
+rename_statement:
+  RENAME comments_list_opt TABLE table_name TO table_name
   {
-    $$ = &Rollback{}
+    $$ = &RenameTable{Comments : Comments($2), OldName: $4, NewName: $6}
   }
 
-use_statement:
-  USE sql_id
+maintenance_statement:
+  ANALYZE comments_list_opt maintenance_modifier_opt TABLE table_name_list
   {
-	  $$ = &UseDB{DB : string($2)}
+    maintenance := &TableMaintenance{Comments: Comments($2), Action: AST_ANALYZE, Tables: $5}
+    if !maintenance.setOptions($3, nil) {
+      yylex.Error("expecting no_write_to_binlog or local")
+      return 1
+    }
+    $$ = maintenance
   }
-
-
-admin_statement:
-  KILL NUMBER
+| OPTIMIZE comments_list_opt maintenance_modifier_opt TABLE table_name_list
   {
-    $$ = &KillConnection{ConnectionID: NumVal($2)}
+    maintenance := &TableMaintenance{Comments: Comments($2), Action: AST_OPTIMIZE, Tables: $5}
+    if !maintenance.setOptions($3, nil) {
+      yylex.Error("expecting no_write_to_binlog or local")
+      return 1
+    }
+    $$ = maintenance
   }
-| KILL CONNECTION NUMBER
+| CHECK comments_list_opt TABLE table_name_list maintenance_option_list
   {
-    $$ = &KillConnection{ConnectionID: NumVal($3)}
+    maintenance := &TableMaintenance{Comments: Comments($2), Action: AST_CHECK, Tables: $4}
+    if !maintenance.setOptions(nil, $5) {
+      yylex.Error("expecting for upgrade, quick, fast, medium, extended or changed")
+      return 1
+    }
+    $$ = maintenance
   }
-| KILL QUERY NUMBER
+| REPAIR comments_list_opt maintenance_modifier_opt TABLE table_name_list maintenance_option_list
   {
-    $$ = &KillQuery{ConnectionID: NumVal($3)}
+    maintenance := &TableMaintenance{Comments: Comments($2), Action: AST_REPAIR, Tables: $5}
+    if !maintenance.setOptions($3, $6) {
+      yylex.Error("expecting no_write_to_binlog, local, quick, extended or use_frm")
+      return 1
+    }
+    $$ = maintenance
   }
 
-create_statement:
-  CREATE comments_list_opt TABLE not_exists_opt table_name '(' create_definition_list ')' table_option_list_opt
+maintenance_modifier_opt:
   {
-    $$ = &CreateTable{Comments : Comments($2), Table: $5, CreateDefs: $7, TableOptions: $9 }
+    $$ = nil
   }
-| CREATE comments_list_opt index_category_opt INDEX sql_id index_type_opt ON table_name '(' index_column_list ')' index_option_opt
+| ID
   {
-    $$ = &CreateIndex{Comments : Comments($2), IndexCategory: $3, Name: $5, IndexType: $6, Table: $8, IndexColumns: $10, IndexOption: $12 }
+    $$ = $1
   }
 
-alter_statement:
-  ALTER comments_list_opt ignore_opt TABLE table_name alter_specification_list_opt
+maintenance_option_list:
+  {
+    $$ = nil
+  }
+| maintenance_option_list ID
+  {
+    $$ = append($1, $2)
+  }
+| maintenance_option_list FOR ID
   {
-    $$ = &AlterTable{Comments : Comments($2), Ignore: $3, Table: $5, AlterSpecs: $6}
+    $$ = append($1, append([]byte("for "), $3...))
   }
 
-rename_statement:
-  RENAME comments_list_opt TABLE table_name TO table_name
+truncate_statement:
+  TRUNCATE comments_list_opt TABLE table_name
   {
-    $$ = &RenameTable{Comments : Comments($2), OldName: $4, NewName: $6}
+    $$ = &TruncateTable{Comments : Comments($2), Name: $4}
+  }
+| TRUNCATE comments_list_opt table_name
+  {
+    $$ = &TruncateTable{Comments : Comments($2), Name: $3}
   }
 
 drop_statement:
@@ -550,9 +1540,21 @@ drop_statement:
   {
     $$ = &DropTable{Comments : Comments($2), Name: $5, RefOption: $6}
   }
-| DROP comments_list_opt INDEX sql_id ON table_name
+| DROP comments_list_opt INDEX sql_id ON table_name lock_algorithm_option_list_opt
+  {
+    $$ = &DropIndex{Comments : Comments($2), Name: $4, Table: $6, LockAlgorithm: $7}
+  }
+| DROP comments_list_opt PROCEDURE exists_opt table_name
   {
-    $$ = &DropIndex{Comments : Comments($2), Name: $4, Table: $6}
+    $$ = &DropRoutine{Comments : Comments($2), Kind: AST_PROCEDURE, IfExists: $4, Name: $5}
+  }
+| DROP comments_list_opt FUNCTION exists_opt table_name
+  {
+    $$ = &DropRoutine{Comments : Comments($2), Kind: AST_FUNCTION, IfExists: $4, Name: $5}
+  }
+| DROP comments_list_opt TRIGGER exists_opt table_name
+  {
+    $$ = &DropRoutine{Comments : Comments($2), Kind: AST_TRIGGER, IfExists: $4, Name: $5}
   }
 
 show_statement:
@@ -700,6 +1702,42 @@ show_statement:
   {
     $$ = &ShowProfiles{}
   }
+| SHOW comments_list_opt GRANTS
+  {
+    $$ = &ShowGrants{Comments : Comments($2)}
+  }
+| SHOW comments_list_opt GRANTS FOR account
+  {
+    if $5.Host == nil && bytes.EqualFold($5.User, CURRENT_USER_BYTES) {
+      $$ = &ShowGrants{Comments : Comments($2), CurrentUser : true}
+    } else {
+      $$ = &ShowGrants{Comments : Comments($2), For : $5}
+    }
+  }
+| SHOW comments_list_opt GRANTS FOR ID '(' ')'
+  {
+    if !bytes.EqualFold($5, CURRENT_USER_BYTES) {
+      yylex.Error("expecting current_user")
+      return 1
+    }
+    $$ = &ShowGrants{Comments : Comments($2), CurrentUser : true}
+  }
+| SHOW comments_list_opt WARNINGS limit_opt
+  {
+    $$ = &ShowWarnings{Comments : Comments($2), Limit : $4}
+  }
+| SHOW comments_list_opt ERRORS limit_opt
+  {
+    $$ = &ShowErrors{Comments : Comments($2), Limit : $4}
+  }
+| SHOW ID ID ID
+  {
+    if !bytes.EqualFold($2, SAASHARD_BYTES) || !bytes.EqualFold($3, LAST_BYTES) || !bytes.EqualFold($4, ROUTE_BYTES) {
+      yylex.Error("expecting saashard last route")
+      return 1
+    }
+    $$ = &ShowLastRoute{}
+  }
 
 describe_statement:
   DESCRIBE comments_list_opt table_name
@@ -886,6 +1924,16 @@ sql_id
     $$ = &TableName{Qualifier: $1, Name: $3}
   }
 
+table_name_list:
+  table_name
+  {
+    $$ = TableNames{$1}
+  }
+| table_name_list ',' table_name
+  {
+    $$ = append($$, $3)
+  }
+
 index_hint_list:
   {
     $$ = nil
@@ -942,6 +1990,50 @@ boolean_expression:
   {
     $$ = &OrExpr{Left: $1, Right: $3}
   }
+| boolean_expression XOR boolean_expression
+  {
+    $$ = &XorExpr{Left: $1, Right: $3}
+  }
+| boolean_expression AND value_expression
+  {
+    $$ = &AndExpr{Left: $1, Right: &BoolValExpr{Expr: $3}}
+  }
+| value_expression AND boolean_expression
+  {
+    $$ = &AndExpr{Left: &BoolValExpr{Expr: $1}, Right: $3}
+  }
+| value_expression AND value_expression
+  {
+    $$ = &AndExpr{Left: &BoolValExpr{Expr: $1}, Right: &BoolValExpr{Expr: $3}}
+  }
+| boolean_expression OR value_expression
+  {
+    $$ = &OrExpr{Left: $1, Right: &BoolValExpr{Expr: $3}}
+  }
+| value_expression OR boolean_expression
+  {
+    $$ = &OrExpr{Left: &BoolValExpr{Expr: $1}, Right: $3}
+  }
+| value_expression OR value_expression
+  {
+    $$ = &OrExpr{Left: &BoolValExpr{Expr: $1}, Right: &BoolValExpr{Expr: $3}}
+  }
+| boolean_expression XOR value_expression
+  {
+    $$ = &XorExpr{Left: $1, Right: &BoolValExpr{Expr: $3}}
+  }
+| value_expression XOR boolean_expression
+  {
+    $$ = &XorExpr{Left: &BoolValExpr{Expr: $1}, Right: $3}
+  }
+| value_expression XOR value_expression
+  {
+    $$ = &XorExpr{Left: &BoolValExpr{Expr: $1}, Right: &BoolValExpr{Expr: $3}}
+  }
+| NOT value_expression
+  {
+    $$ = &NotExpr{Expr: &BoolValExpr{Expr: $2}}
+  }
 | NOT boolean_expression
   {
     $$ = &NotExpr{Expr: $2}
@@ -972,6 +2064,14 @@ condition:
   {
     $$ = &ComparisonExpr{Left: $1, Operator: AST_NOT_LIKE, Right: $4}
   }
+| value_expression REGEXP value_expression
+  {
+    $$ = &ComparisonExpr{Left: $1, Operator: AST_REGEXP, Right: $3}
+  }
+| value_expression NOT REGEXP value_expression
+  {
+    $$ = &ComparisonExpr{Left: $1, Operator: AST_NOT_REGEXP, Right: $4}
+  }
 | value_expression BETWEEN value_expression AND value_expression
   {
     $$ = &RangeCond{Left: $1, Operator: AST_BETWEEN, From: $3, To: $5}
@@ -992,6 +2092,14 @@ condition:
   {
     $$ = &ExistsExpr{Subquery: $2}
   }
+| match_expression
+  {
+    $$ = $1
+  }
+| match_expression compare value_expression
+  {
+    $$ = &ComparisonExpr{Left: $1, Operator: $2, Right: $3}
+  }
 
 compare:
   '='
@@ -1076,7 +2184,32 @@ value_expression:
   }
 | column_name
   {
-    $$ = $1
+    if $1.Qualifier == nil && IsUserVariableName($1.Name) {
+      $$ = &UserVariable{Name: $1.Name[1:]}
+    } else if variable, ok := NewSystemVariable($1); ok {
+      $$ = variable
+    } else if bytes.HasPrefix($1.Qualifier, AT_BYTES) {
+      yylex.Error("expecting @@global, @@session or @@local")
+      return 1
+    } else {
+      $$ = $1
+    }
+  }
+| column_name JSON_EXTRACT_OP STRING
+  {
+    $$ = &JSONExtractExpr{Column: $1, Operator: AST_JSON_EXTRACT, Path: StrVal($3)}
+  }
+| column_name JSON_UNQUOTE_EXTRACT_OP STRING
+  {
+    $$ = &JSONExtractExpr{Column: $1, Operator: AST_JSON_UNQUOTE_EXTRACT, Path: StrVal($3)}
+  }
+| sql_id ASSIGN value_expression
+  {
+    if !IsUserVariableName($1) {
+      yylex.Error("expecting @name before :=")
+      return 1
+    }
+    $$ = &Assignment{Name: $1[1:], Expr: $3}
   }
 | tuple
   {
@@ -1094,6 +2227,10 @@ value_expression:
   {
     $$ = &BinaryExpr{Left: $1, Operator: AST_BITXOR, Right: $3}
   }
+| value_expression PIPE_CONCAT value_expression
+  {
+    $$ = &FuncExpr{Name: []byte("concat"), Exprs: ValExprs{$1, $3}}
+  }
 | value_expression '+' value_expression
   {
     $$ = &BinaryExpr{Left: $1, Operator: AST_PLUS, Right: $3}
@@ -1114,6 +2251,22 @@ value_expression:
   {
     $$ = &BinaryExpr{Left: $1, Operator: AST_MOD, Right: $3}
   }
+| value_expression DIV value_expression
+  {
+    $$ = &BinaryExpr{Left: $1, Operator: AST_INT_DIV, Right: $3}
+  }
+| value_expression MOD value_expression
+  {
+    $$ = &BinaryExpr{Left: $1, Operator: AST_MOD, Right: $3}
+  }
+| BINARY value_expression %prec UNARY
+  {
+    $$ = &UnaryBinaryExpr{Expr: $2}
+  }
+| value_expression COLLATE collate_name
+  {
+    $$ = &CollateExpr{Expr: $1, Collation: $3}
+  }
 | unary_operator value_expression %prec UNARY
   {
     if num, ok := $2.(NumVal); ok {
@@ -1133,35 +2286,240 @@ value_expression:
   {
     $$ = $1
   }
+| function_expression OVER '(' partition_by_opt order_by_opt ')'
+  {
+    $$ = &WindowExpr{Func: $1, PartitionBy: $4, OrderBy: $5}
+  }
 | case_expression
   {
     $$ = $1
   }
+| INTERVAL value_expression sql_id
+  {
+    unit := strings.ToLower(string($3))
+    if !INTERVAL_UNITS[unit] {
+      yylex.Error("expecting unit of interval")
+      return 1
+    }
+    $$ = &IntervalExpr{Expr: $2, Unit: unit}
+  }
+| INTERVAL value_expression YEAR
+  {
+    $$ = &IntervalExpr{Expr: $2, Unit: "year"}
+  }
+| sql_id '(' value_expression AS convert_type ')'
+  {
+    if !bytes.EqualFold($1, CAST_BYTES) {
+      yylex.Error("expecting cast")
+      return 1
+    }
+    $$ = &CastExpr{Operator: AST_CAST, Expr: $3, To: $5}
+  }
+| CONVERT '(' value_expression ',' convert_type ')'
+  {
+    $$ = &CastExpr{Operator: AST_CONVERT, Expr: $3, To: $5}
+  }
+| CONVERT '(' value_expression USING charset_words ')'
+  {
+    $$ = &CastExpr{Operator: AST_CONVERT_USING, Expr: $3, To: string($5)}
+  }
 
 function_expression:
   sql_id '(' ')'
   {
     $$ = &FuncExpr{Name: $1}
   }
-| sql_id '(' '*' ')'
+| sql_id '(' '*' ')'
+  {
+    $$ = &FuncExpr{Name: $1, Exprs: ValExprs{&ColName{Name: []byte("*")}}}
+  }
+| sql_id '(' value_expression_list ')'
+  {
+    $$ = &FuncExpr{Name: $1, Exprs: $3}
+  }
+| sql_id '(' DISTINCT value_expression_list ')'
+  {
+    $$ = &FuncExpr{Name: $1, Distinct: true, Exprs: $4}
+  }
+| sql_id '(' value_expression_list ORDER BY order_list separator_opt ')'
+  {
+    $$ = &FuncExpr{Name: $1, Exprs: $3, OrderBy: $6, Separator: $7}
+  }
+| sql_id '(' value_expression_list SEPARATOR STRING ')'
+  {
+    $$ = &FuncExpr{Name: $1, Exprs: $3, Separator: append([]byte{}, $5...)}
+  }
+| sql_id '(' DISTINCT value_expression_list ORDER BY order_list separator_opt ')'
+  {
+    $$ = &FuncExpr{Name: $1, Distinct: true, Exprs: $4, OrderBy: $7, Separator: $8}
+  }
+| sql_id '(' DISTINCT value_expression_list SEPARATOR STRING ')'
+  {
+    $$ = &FuncExpr{Name: $1, Distinct: true, Exprs: $4, Separator: append([]byte{}, $6...)}
+  }
+| POSITION '(' value_expression IN value_expression ')'
+  {
+    $$ = &FuncExpr{Name: []byte("locate"), Exprs: ValExprs{$3,$5}}
+  }
+| keyword_as_func '(' value_expression_list ')'
+  {
+    $$ = &FuncExpr{Name: $1, Exprs: $3}
+  }
+
+convert_type:
+  BINARY convert_length_opt
+  {
+    $$ = "binary" + $2
+  }
+| CHAR convert_length_opt
+  {
+    $$ = "char" + $2
+  }
+| CHAR convert_length_opt CHARACTER SET charset_words
+  {
+    $$ = "char" + $2 + " character set " + string($5)
+  }
+| NCHAR convert_length_opt
+  {
+    $$ = "nchar" + $2
+  }
+| DATE
+  {
+    $$ = "date"
+  }
+| DATETIME convert_length_opt
+  {
+    $$ = "datetime" + $2
+  }
+| TIME convert_length_opt
+  {
+    $$ = "time" + $2
+  }
+| YEAR
+  {
+    $$ = "year"
+  }
+| DECIMAL convert_length_opt
+  {
+    $$ = "decimal" + $2
+  }
+| DECIMAL '(' NUMBER ',' NUMBER ')'
+  {
+    $$ = "decimal(" + string($3) + "," + string($5) + ")"
+  }
+| DOUBLE
+  {
+    $$ = "double"
+  }
+| FLOAT convert_length_opt
+  {
+    $$ = "float" + $2
+  }
+| REAL
+  {
+    $$ = "real"
+  }
+| UNSIGNED
+  {
+    $$ = "unsigned"
+  }
+| UNSIGNED INTEGER
+  {
+    $$ = "unsigned integer"
+  }
+| ID
+  {
+    switch {
+    case bytes.EqualFold($1, SIGNED_BYTES):
+      $$ = "signed"
+    case strings.EqualFold(string($1), "json"):
+      $$ = "json"
+    default:
+      yylex.Error("expecting type of cast")
+      return 1
+    }
+  }
+| ID INTEGER
+  {
+    if !bytes.EqualFold($1, SIGNED_BYTES) {
+      yylex.Error("expecting signed")
+      return 1
+    }
+    $$ = "signed integer"
+  }
+
+convert_length_opt:
+  {
+    $$ = ""
+  }
+| '(' NUMBER ')'
+  {
+    $$ = "(" + string($2) + ")"
+  }
+
+partition_by_opt:
+  {
+    $$ = nil
+  }
+| PARTITION BY value_expression_list
+  {
+    $$ = $3
+  }
+
+separator_opt:
+  {
+    $$ = nil
+  }
+| SEPARATOR STRING
+  {
+    $$ = append([]byte{}, $2...)
+  }
+
+match_expression:
+  MATCH '(' column_list ')' sql_id '(' value_expression match_modifier_opt ')'
+  {
+    if !bytes.EqualFold($5, AGAINST_BYTES) {
+      yylex.Error("expecting against")
+      return 1
+    }
+    $$ = &MatchExpr{Columns: $3, Expr: $7, Modifier: $8}
+  }
+
+match_modifier_opt:
   {
-    $$ = &FuncExpr{Name: $1, Exprs: ValExprs{&ColName{Name: []byte("*")}}}
+    $$ = ""
   }
-| sql_id '(' value_expression_list ')'
+| IN NATURAL sql_id sql_id
   {
-    $$ = &FuncExpr{Name: $1, Exprs: $3}
+    if !bytes.EqualFold($3, LANGUAGE_BYTES) || !bytes.EqualFold($4, MODE) {
+      yylex.Error("expecting language mode")
+      return 1
+    }
+    $$ = AST_MATCH_NATURAL_LANGUAGE
   }
-| sql_id '(' DISTINCT value_expression_list ')'
+| IN NATURAL sql_id sql_id WITH QUERY sql_id
   {
-    $$ = &FuncExpr{Name: $1, Distinct: true, Exprs: $4}
+    if !bytes.EqualFold($3, LANGUAGE_BYTES) || !bytes.EqualFold($4, MODE) || !bytes.EqualFold($7, EXPANSION_BYTES) {
+      yylex.Error("expecting language mode with query expansion")
+      return 1
+    }
+    $$ = AST_MATCH_NATURAL_LANGUAGE_EXPANSION
   }
-| POSITION '(' value_expression IN value_expression ')'
+| IN BOOLEAN sql_id
   {
-    $$ = &FuncExpr{Name: []byte("locate"), Exprs: ValExprs{$3,$5}}
+    if !bytes.EqualFold($3, MODE) {
+      yylex.Error("expecting mode")
+      return 1
+    }
+    $$ = AST_MATCH_BOOLEAN
   }
-| keyword_as_func '(' value_expression_list ')'
+| WITH QUERY sql_id
   {
-    $$ = &FuncExpr{Name: $1, Exprs: $3}
+    if !bytes.EqualFold($3, EXPANSION_BYTES) {
+      yylex.Error("expecting expansion")
+      return 1
+    }
+    $$ = AST_MATCH_EXPANSION
   }
 
 keyword_as_func:
@@ -1173,6 +2531,10 @@ keyword_as_func:
   {
     $$ = VALUES_BYTES
   }
+| MOD
+  {
+    $$ = MOD_BYTES
+  }
 
 unary_operator:
   '+'
@@ -1247,6 +2609,30 @@ value:
   {
     $$ = NumVal($1)
   }
+| HEX_LITERAL
+  {
+    $$ = HexVal($1)
+  }
+| BIT_LITERAL
+  {
+    $$ = BitVal($1)
+  }
+| NCHAR_STRING
+  {
+    $$ = &IntroducerExpr{National: true, Charset: []byte(AST_NATIONAL_CHARSET), Expr: StrVal($1)}
+  }
+| INTRODUCER STRING
+  {
+    $$ = &IntroducerExpr{Charset: $1, Expr: StrVal($2)}
+  }
+| INTRODUCER HEX_LITERAL
+  {
+    $$ = &IntroducerExpr{Charset: $1, Expr: HexVal($2)}
+  }
+| INTRODUCER BIT_LITERAL
+  {
+    $$ = &IntroducerExpr{Charset: $1, Expr: BitVal($2)}
+  }
 | VALUE_ARG
   {
     $$ = ValArg($1)
@@ -1298,6 +2684,10 @@ order:
   {
     $$ = &Order{Expr: $1, Direction: $2}
   }
+| match_expression asc_desc_opt
+  {
+    $$ = &Order{Expr: $1, Direction: $2}
+  }
 
 asc_desc_opt:
   {
@@ -1378,6 +2768,65 @@ on_dup_opt:
     $$ = $5
   }
 
+set_list:
+  set_expression
+  {
+    $$ = SetExprs{$1}
+  }
+| set_list ',' set_expression
+  {
+    $$ = append($1, $3)
+  }
+
+set_expression:
+  sql_id '=' set_value
+  {
+    $$ = NewSetExpr($1, $3)
+  }
+| ID '.' sql_id '=' set_value
+  {
+    expr, ok := NewScopedSetExpr($1, $3, $5)
+    if !ok {
+      yylex.Error("expecting @@global, @@session, @@local or @@persist")
+      return 1
+    }
+    $$ = expr
+  }
+| ID STRING '=' set_value
+  {
+    if !bytes.Equal($1, AT_BYTES) {
+      yylex.Error("expecting @")
+      return 1
+    }
+    $$ = &SetExpr{User: true, Name: $2, Expr: $4}
+  }
+| ID ID '=' set_value
+  {
+    if bytes.Equal($1, AT_BYTES) {
+      $$ = &SetExpr{User: true, Name: $2, Expr: $4}
+    } else if bytes.EqualFold($1, LOCAL_BYTES) {
+      $$ = &SetExpr{Scope: AST_SCOPE_LOCAL, Name: $2, Expr: $4}
+    } else {
+      yylex.Error("expecting @ or local")
+      return 1
+    }
+  }
+
+set_value:
+  value_expression
+| DEFAULT
+  {
+    $$ = SetKeyword("default")
+  }
+| ON
+  {
+    $$ = SetKeyword("on")
+  }
+| charset_words
+  {
+    $$ = SetKeyword($1)
+  }
+
 update_list:
   update_expression
   {
@@ -1395,14 +2844,14 @@ update_expression:
   }
 
 exists_opt:
-  { $$ = struct{}{} }
+  { $$ = false }
 | IF EXISTS
-  { $$ = struct{}{} }
+  { $$ = true }
 
 not_exists_opt:
-  { $$ = struct{}{} }
+  { $$ = false }
 | IF NOT EXISTS
-  { $$ = struct{}{} }
+  { $$ = true }
 
 ignore_opt:
   { $$ = "" }
@@ -1452,6 +2901,39 @@ index_option_opt:
 | index_type
   { $$ = $1 }
 
+lock_algorithm_option_list_opt:
+  { $$ = nil }
+| lock_algorithm_option_list
+  { $$ = $1 }
+
+lock_algorithm_option_list:
+  lock_algorithm_option
+  {
+    $$ = OptionKeyValues{$1}
+  }
+| lock_algorithm_option_list lock_algorithm_option
+  {
+    $$ = append($1, $2)
+  }
+
+lock_algorithm_option:
+  ALGORITHM '=' DEFAULT
+  {
+    $$ = &OptionKeyValue{Key: "algorithm", Value: "default"}
+  }
+| ALGORITHM '=' sql_id
+  {
+    $$ = &OptionKeyValue{Key: "algorithm", Value: string($3)}
+  }
+| LOCK '=' DEFAULT
+  {
+    $$ = &OptionKeyValue{Key: "lock", Value: "default"}
+  }
+| LOCK '=' sql_id
+  {
+    $$ = &OptionKeyValue{Key: "lock", Value: string($3)}
+  }
+
 sql_id:
   ID
   {
@@ -1461,6 +2943,86 @@ sql_id:
   {
     $$ = []byte("database")
   }
+| non_reserved_keyword
+
+// non_reserved_keyword keywords that are also accepted as identifier.
+non_reserved_keyword:
+  ALGORITHM
+  {
+    $$ = []byte("algorithm")
+  }
+| RELOAD
+  {
+    $$ = []byte("reload")
+  }
+| CLONE
+  {
+    $$ = []byte("clone")
+  }
+| PREPARE
+  {
+    $$ = []byte("prepare")
+  }
+| EXECUTE
+  {
+    $$ = []byte("execute")
+  }
+| DEALLOCATE
+  {
+    $$ = []byte("deallocate")
+  }
+| OPTION
+  {
+    $$ = []byte("option")
+  }
+| TRUNCATE
+  {
+    $$ = []byte("truncate")
+  }
+| REPAIR
+  {
+    $$ = []byte("repair")
+  }
+| GRANTS
+  {
+    $$ = []byte("grants")
+  }
+| WARNINGS
+  {
+    $$ = []byte("warnings")
+  }
+| ERRORS
+  {
+    $$ = []byte("errors")
+  }
+| PROXY
+  {
+    $$ = []byte("proxy")
+  }
+| SAVEPOINT
+  {
+    $$ = []byte("savepoint")
+  }
+| BEGIN
+  {
+    $$ = []byte("begin")
+  }
+| COMMIT
+  {
+    $$ = []byte("commit")
+  }
+| ROLLBACK
+  {
+    $$ = []byte("rollback")
+  }
+| IDENTIFIED
+  {
+    $$ = []byte("identified")
+  }
+| SEPARATOR
+  {
+    $$ = []byte("separator")
+  }
 
 // force_eof:
 // {
@@ -1549,6 +3111,13 @@ charset_words:
 | UTF8MB4
   { $$ = []byte("utf8mb4") }
 
+collate_name:
+  collate_words
+| ID
+  {
+    $$ = bytes.ToLower($1)
+  }
+
 collate_words:
   ARMSCII8_GENERAL_CI
   { $$ = []byte("armscii8_general_ci") }
@@ -1724,6 +3293,10 @@ collate_words:
   { $$ = []byte("utf8mb4_unicode_ci") }
 | UTF8MB4_BIN
   { $$ = []byte("utf8mb4_bin") }
+| UTF8MB4_0900_AI_CI
+  { $$ = []byte("utf8mb4_0900_ai_ci") }
+| UTF8MB4_0900_BIN
+  { $$ = []byte("utf8mb4_0900_bin") }
 
 
 isolation_level:
@@ -1797,9 +3370,47 @@ create_definition:
   {
     $$ = &CreateForeignKeyDefinition{Symbol: $1, IndexColumns: $5, ReferenceDef: $7}
   }
+| check_constraint
+  {
+    $$ = &CreateCheckDefinition{Check: $1}
+  }
+
+check_constraint_opt:
+  { $$ = nil }
+| check_constraint
+  { $$ = $1 }
+
+check_constraint:
+  constraint_opt CHECK '(' boolean_expression ')' enforced_opt
+  {
+    $$ = &CheckConstraint{Symbol: $1, Expr: $4, Enforced: $6}
+  }
+
+enforced_opt:
+  { $$ = "" }
+| enforced
+  { $$ = $1 }
+
+enforced:
+  ID
+  {
+    if !bytes.EqualFold($1, ENFORCED_BYTES) {
+      yylex.Error("expecting enforced")
+      return 1
+    }
+    $$ = AST_ENFORCED
+  }
+| NOT ID
+  {
+    if !bytes.EqualFold($2, ENFORCED_BYTES) {
+      yylex.Error("expecting enforced")
+      return 1
+    }
+    $$ = AST_NOT_ENFORCED
+  }
 
 column_definition:
-  data_type auto_increment_opt unique_or_primary_opt column_comment_opt column_format_opt column_storage_opt reference_definition_opt
+  data_type auto_increment_opt unique_or_primary_opt column_comment_opt column_format_opt column_storage_opt reference_definition_opt check_constraint_opt
   {
     $$ = &ColumnDefinition{Type: $1,
           IsAutoIncrement: $2,
@@ -1807,9 +3418,10 @@ column_definition:
           ColumnComment: $4,
           ColumnFormat: $5,
           ColumnStorage: $6,
-          ReferenceDef: $7 }
+          ReferenceDef: $7,
+          Check: $8 }
   }
-| data_type not_null auto_increment_opt unique_or_primary_opt column_comment_opt column_format_opt column_storage_opt reference_definition_opt
+| data_type not_null auto_increment_opt unique_or_primary_opt column_comment_opt column_format_opt column_storage_opt reference_definition_opt check_constraint_opt
   {
     $$ = &ColumnDefinition{Type: $1,
           IsNotNull: $2,
@@ -1818,9 +3430,10 @@ column_definition:
           ColumnComment: $5,
           ColumnFormat: $6,
           ColumnStorage: $7,
-          ReferenceDef: $8 }
+          ReferenceDef: $8,
+          Check: $9 }
   }
-| data_type default_value auto_increment_opt unique_or_primary_opt column_comment_opt column_format_opt column_storage_opt reference_definition_opt
+| data_type default_value auto_increment_opt unique_or_primary_opt column_comment_opt column_format_opt column_storage_opt reference_definition_opt check_constraint_opt
   {
     $$ = &ColumnDefinition{Type: $1,
           DefaultValue: $2,
@@ -1829,9 +3442,10 @@ column_definition:
           ColumnComment: $5,
           ColumnFormat: $6,
           ColumnStorage: $7,
-          ReferenceDef: $8 }
+          ReferenceDef: $8,
+          Check: $9 }
   }
-| data_type not_null default_value auto_increment_opt unique_or_primary_opt column_comment_opt column_format_opt column_storage_opt reference_definition_opt
+| data_type not_null default_value auto_increment_opt unique_or_primary_opt column_comment_opt column_format_opt column_storage_opt reference_definition_opt check_constraint_opt
   {
     $$ = &ColumnDefinition{Type: $1,
           IsNotNull: $2,
@@ -1841,9 +3455,10 @@ column_definition:
           ColumnComment: $6,
           ColumnFormat: $7,
           ColumnStorage: $8,
-          ReferenceDef: $9 }
+          ReferenceDef: $9,
+          Check: $10 }
   }
-| data_type default_value not_null auto_increment_opt unique_or_primary_opt column_comment_opt column_format_opt column_storage_opt reference_definition_opt
+| data_type default_value not_null auto_increment_opt unique_or_primary_opt column_comment_opt column_format_opt column_storage_opt reference_definition_opt check_constraint_opt
   {
     $$ = &ColumnDefinition{Type: $1,
           IsNotNull: $3,
@@ -1853,7 +3468,55 @@ column_definition:
           ColumnComment: $6,
           ColumnFormat: $7,
           ColumnStorage: $8,
-          ReferenceDef: $9 }
+          ReferenceDef: $9,
+          Check: $10 }
+  }
+
+| data_type generated_always_opt AS '(' value_expression ')' generated_storage_opt unique_or_primary_opt column_comment_opt reference_definition_opt check_constraint_opt
+  {
+    $$ = &ColumnDefinition{Type: $1,
+          Generated: $5,
+          GeneratedStorage: $7,
+          UniqueOrKey: $8,
+          ColumnComment: $9,
+          ReferenceDef: $10,
+          Check: $11 }
+  }
+| data_type generated_always_opt AS '(' value_expression ')' generated_storage_opt not_null unique_or_primary_opt column_comment_opt reference_definition_opt check_constraint_opt
+  {
+    $$ = &ColumnDefinition{Type: $1,
+          Generated: $5,
+          GeneratedStorage: $7,
+          IsNotNull: $8,
+          UniqueOrKey: $9,
+          ColumnComment: $10,
+          ReferenceDef: $11,
+          Check: $12 }
+  }
+
+generated_always_opt:
+  {}
+| ID ID
+  {
+    if !bytes.EqualFold($1, GENERATED_BYTES) || !bytes.EqualFold($2, ALWAYS_BYTES) {
+      yylex.Error("expecting generated always")
+      return 1
+    }
+  }
+
+generated_storage_opt:
+  { $$ = "" }
+| ID
+  {
+    switch {
+    case bytes.EqualFold($1, VIRTUAL_BYTES):
+      $$ = AST_VIRTUAL
+    case bytes.EqualFold($1, STORED_BYTES):
+      $$ = AST_STORED
+    default:
+      yylex.Error("expecting virtual or stored")
+      return 1
+    }
   }
 
 data_type:
@@ -2065,6 +3728,15 @@ data_type:
   {
     $$ = &DataType{TypeName: "set(" + String($3) + ")", Charset: $5, Collate: $6 }
   }
+| ID
+  {
+    name := strings.ToLower(string($1))
+    if !ID_DATA_TYPES[name] {
+      yylex.Error("expecting data type")
+      return 1
+    }
+    $$ = &DataType{TypeName: name}
+  }
 
 not_null:
   NULL
@@ -2240,6 +3912,131 @@ table_option:
     $$ = &OptionKeyValue{Key: "comment", Value: String(StrVal($3))}
   }
 
+partition_options_opt:
+  { $$ = nil }
+| partition_options
+  { $$ = $1 }
+
+partition_options:
+  PARTITION BY partition_type partition_count_opt partition_definitions_opt
+  {
+    $$ = $3
+    $$.Partitions = $4
+    $$.Definitions = $5
+  }
+
+partition_type:
+  ID '(' value_expression ')'
+  {
+    $$ = &PartitionOptions{Expr: $3}
+    switch {
+    case bytes.EqualFold($1, RANGE_BYTES):
+      $$.Type = AST_PARTITION_RANGE
+    case bytes.EqualFold($1, LIST_BYTES):
+      $$.Type = AST_PARTITION_LIST
+    default:
+      yylex.Error("expecting range or list")
+      return 1
+    }
+  }
+| HASH '(' value_expression ')'
+  {
+    $$ = &PartitionOptions{Type: AST_PARTITION_HASH, Expr: $3}
+  }
+| ID COLUMNS '(' column_list ')'
+  {
+    $$ = &PartitionOptions{Columns: $4}
+    switch {
+    case bytes.EqualFold($1, RANGE_BYTES):
+      $$.Type = AST_PARTITION_RANGE
+    case bytes.EqualFold($1, LIST_BYTES):
+      $$.Type = AST_PARTITION_LIST
+    default:
+      yylex.Error("expecting range or list")
+      return 1
+    }
+  }
+| ID HASH '(' value_expression ')'
+  {
+    if !bytes.EqualFold($1, LINEAR_BYTES) {
+      yylex.Error("expecting linear hash")
+      return 1
+    }
+    $$ = &PartitionOptions{Linear: true, Type: AST_PARTITION_HASH, Expr: $4}
+  }
+| KEY '(' ')'
+  {
+    $$ = &PartitionOptions{Type: AST_PARTITION_KEY}
+  }
+| KEY '(' column_list ')'
+  {
+    $$ = &PartitionOptions{Type: AST_PARTITION_KEY, Columns: $3}
+  }
+| ID KEY '(' ')'
+  {
+    if !bytes.EqualFold($1, LINEAR_BYTES) {
+      yylex.Error("expecting linear key")
+      return 1
+    }
+    $$ = &PartitionOptions{Linear: true, Type: AST_PARTITION_KEY}
+  }
+| ID KEY '(' column_list ')'
+  {
+    if !bytes.EqualFold($1, LINEAR_BYTES) {
+      yylex.Error("expecting linear key")
+      return 1
+    }
+    $$ = &PartitionOptions{Linear: true, Type: AST_PARTITION_KEY, Columns: $4}
+  }
+
+partition_count_opt:
+  { $$ = nil }
+| ID NUMBER
+  {
+    if !bytes.EqualFold($1, PARTITIONS_BYTES) {
+      yylex.Error("expecting partitions")
+      return 1
+    }
+    $$ = $2
+  }
+
+partition_definitions_opt:
+  { $$ = nil }
+| '(' partition_definition_list ')'
+  { $$ = $2 }
+
+partition_definition_list:
+  partition_definition
+  { $$ = []*PartitionDefinition{$1} }
+| partition_definition_list ',' partition_definition
+  { $$ = append($1, $3) }
+
+partition_definition:
+  PARTITION sql_id table_option_list_opt
+  {
+    $$ = &PartitionDefinition{Name: $2, Options: $3}
+  }
+| PARTITION sql_id VALUES ID ID '(' value_expression_list ')' table_option_list_opt
+  {
+    if !bytes.EqualFold($4, LESS_BYTES) || !bytes.EqualFold($5, THAN_BYTES) {
+      yylex.Error("expecting less than")
+      return 1
+    }
+    $$ = &PartitionDefinition{Name: $2, Values: AST_VALUES_LESS_THAN, Tuple: $7, Options: $9}
+  }
+| PARTITION sql_id VALUES ID ID ID table_option_list_opt
+  {
+    if !bytes.EqualFold($4, LESS_BYTES) || !bytes.EqualFold($5, THAN_BYTES) || !bytes.EqualFold($6, MAXVALUE_BYTES) {
+      yylex.Error("expecting less than maxvalue")
+      return 1
+    }
+    $$ = &PartitionDefinition{Name: $2, Values: AST_VALUES_LESS_THAN, MaxValue: true, Options: $7}
+  }
+| PARTITION sql_id VALUES IN '(' value_expression_list ')' table_option_list_opt
+  {
+    $$ = &PartitionDefinition{Name: $2, Values: AST_VALUES_IN, Tuple: $6, Options: $8}
+  }
+
 alter_specification_list_opt:
   { $$ = nil }
 | alter_specification_list
@@ -2288,6 +4085,10 @@ alter_specification:
   {
     $$ = &AddForeignKeySpec{Symbol: $2, IndexColumns: $6, ReferenceDef: $8}
   }
+| ADD check_constraint
+  {
+    $$ = &AddCheckSpec{Check: $2}
+  }
 | CHANGE COLUMN column_name column_name column_definition first_or_after_column_opt
   {
     $$ = &ChangeColumnSpec{OldColumnName: $3, ColumnName: $4, ColumnDef: $5, FirstOrAfterColumn: $6 }
@@ -2316,6 +4117,42 @@ alter_specification:
   {
     $$ = &DropForeignKeySpec{Name: $4}
   }
+| DROP CHECK sql_id
+  {
+    $$ = &DropCheckSpec{Type: AST_CHECK_CONSTRAINT, Name: $3}
+  }
+| DROP CONSTRAINT sql_id
+  {
+    $$ = &DropCheckSpec{Type: AST_CONSTRAINT, Name: $3}
+  }
+| ALTER CHECK sql_id enforced
+  {
+    $$ = &AlterCheckSpec{Type: AST_CHECK_CONSTRAINT, Name: $3, Enforced: $4}
+  }
+| ALTER CONSTRAINT sql_id enforced
+  {
+    $$ = &AlterCheckSpec{Type: AST_CONSTRAINT, Name: $3, Enforced: $4}
+  }
+| ADD PARTITION '(' partition_definition_list ')'
+  {
+    $$ = &AddPartitionSpec{Definitions: $4}
+  }
+| DROP PARTITION sql_id_list %prec LOWER_THAN_COMMA
+  {
+    $$ = &DropPartitionSpec{Action: AST_DROP_PARTITION, Names: $3}
+  }
+| TRUNCATE PARTITION sql_id_list %prec LOWER_THAN_COMMA
+  {
+    $$ = &DropPartitionSpec{Action: AST_TRUNCATE_PARTITION, Names: $3}
+  }
+| ID ID
+  {
+    if !bytes.EqualFold($1, REMOVE_BYTES) || !bytes.EqualFold($2, PARTITIONING_BYTES) {
+      yylex.Error("expecting remove partitioning")
+      return 1
+    }
+    $$ = &RemovePartitioningSpec{}
+  }
 | DISABLE KEYS
   {
     $$ = &DisableKeysSpec{}
@@ -36,6 +36,16 @@ type Counter struct {
 	ClientQPS    int64
 	ErrLogTotal  int64
 	SlowLogTotal int64
+	MemoryUsed   int64 // bytes of rows buffered by all sessions
+
+	DiffTotal         int64 // queries compared in diff mode
+	DiffMismatchTotal int64 // queries with different results in diff mode
+
+	ShadowTotal        int64 // statements parsed by shadow parser
+	ShadowDivergeTotal int64 // statements diverged between parser and shadow parser
+
+	LongTransTotal int64 // transactions exceeded long_trans_time
+	BigTransTotal  int64 // transactions exceeded big_trans_statements or big_trans_rows
 }
 
 // IncrClientConns is to increase client conns.
@@ -63,6 +73,41 @@ func (c *Counter) IncrSlowLogTotal() {
 	atomic.AddInt64(&c.SlowLogTotal, 1)
 }
 
+// AddMemoryUsed is to add memory used, and return the total.
+func (c *Counter) AddMemoryUsed(n int64) int64 {
+	return atomic.AddInt64(&c.MemoryUsed, n)
+}
+
+// IncrDiffTotal is to increase diff total.
+func (c *Counter) IncrDiffTotal() {
+	atomic.AddInt64(&c.DiffTotal, 1)
+}
+
+// IncrDiffMismatchTotal is to increase diff mismatch total.
+func (c *Counter) IncrDiffMismatchTotal() {
+	atomic.AddInt64(&c.DiffMismatchTotal, 1)
+}
+
+// IncrShadowTotal is to increase shadow total.
+func (c *Counter) IncrShadowTotal() {
+	atomic.AddInt64(&c.ShadowTotal, 1)
+}
+
+// IncrShadowDivergeTotal is to increase shadow diverge total.
+func (c *Counter) IncrShadowDivergeTotal() {
+	atomic.AddInt64(&c.ShadowDivergeTotal, 1)
+}
+
+// IncrLongTransTotal is to increase long transaction total.
+func (c *Counter) IncrLongTransTotal() {
+	atomic.AddInt64(&c.LongTransTotal, 1)
+}
+
+// IncrBigTransTotal is to increase big transaction total.
+func (c *Counter) IncrBigTransTotal() {
+	atomic.AddInt64(&c.BigTransTotal, 1)
+}
+
 // FlushCounter is to flush the count per second
 func (c *Counter) FlushCounter() {
 	atomic.StoreInt64(&c.OldClientQPS, c.ClientQPS)
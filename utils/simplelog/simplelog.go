@@ -4,19 +4,53 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"sync/atomic"
 )
 
+// Log levels.
+const (
+	DebugLevel int32 = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+var level = DebugLevel
+
 var infoLog = log.New(os.Stdout, "[info] ", log.LstdFlags)
 var warnLog = log.New(os.Stdout, "[warn] ", log.LstdFlags)
 var errorLog = log.New(os.Stderr, "[error] ", log.LstdFlags)
 var verboseLog = log.New(os.Stdout, "[verbose] ", log.LstdFlags)
 
+// SetLevel set log level [debug|info|warn|error].
+func SetLevel(levelName string) error {
+	for i, name := range levelNames {
+		if name == strings.ToLower(levelName) {
+			atomic.StoreInt32(&level, int32(i))
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid log level %s", levelName)
+}
+
+// GetLevel get log level.
+func GetLevel() string {
+	return levelNames[atomic.LoadInt32(&level)]
+}
+
 func Info(format string, args ...interface{}) {
-	infoLog.Println(fmt.Sprintf(format, args...))
+	if atomic.LoadInt32(&level) <= InfoLevel {
+		infoLog.Println(fmt.Sprintf(format, args...))
+	}
 }
 
 func Warn(format string, args ...interface{}) {
-	warnLog.Println(fmt.Sprintf(format, args...))
+	if atomic.LoadInt32(&level) <= WarnLevel {
+		warnLog.Println(fmt.Sprintf(format, args...))
+	}
 }
 
 func Error(format string, args ...interface{}) {